@@ -0,0 +1,121 @@
+// Command import migrates reports from the spreadsheets used before the app,
+// exported as CSV or JSON, like POST /v1/admin/reports/import but without the
+// request size limit. Rows keep their status and timestamps; see
+// internal/importer for the columns.
+//
+//	DB_TYPE=postgres DB_CONNECTION_STRING=postgres://... go run ./cmd/import -user <id> -actor admin@example.com reports.csv
+//
+// Cloud SQL (CLOUD_SQL_INSTANCE, DB_USER, DB_PASSWORD, DB_NAME) and Firestore
+// (GOOGLE_CLOUD_PROJECT) are configured as for the server, and gs:// media must be
+// in GCS_BUCKET (default traffic-watch-media), as for the server. -dry-run checks the
+// file without storing anything. Rows imported before are skipped, so a file
+// can be fixed and imported again.
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"donzhit_me_backend/internal/importer"
+	"donzhit_me_backend/internal/storage"
+)
+
+func main() {
+	format := flag.String("format", "", "csv or json (default: from the file extension)")
+	userID := flag.String("user", "", "owner of rows without a userId")
+	actor := flag.String("actor", "", "reviewer recorded on reviewed rows without reviewedBy")
+	dryRun := flag.Bool("dry-run", false, "check the rows without storing them")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: import [flags] <file>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	*format = cmp.Or(*format, importer.FormatOf(path, ""))
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+	rows, err := importer.Parse(file, *format)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", path, err)
+	}
+
+	ctx := context.Background()
+	client, err := openStorage(ctx)
+	if err != nil {
+		log.Fatalf("Failed to open storage: %v", err)
+	}
+	defer client.Close()
+
+	opts := importer.Options{UserID: *userID, Actor: *actor, DryRun: *dryRun, MediaBucket: cmp.Or(os.Getenv("GCS_BUCKET"), "traffic-watch-media")}
+	result, err := importer.Import(ctx, client, rows, opts)
+	if err != nil {
+		log.Fatalf("Import stopped: %v", err)
+	}
+	for _, e := range result.Errors {
+		log.Printf("Row %d (legacy ID %q): %s", e.Row, e.LegacyID, e.Message)
+	}
+
+	verb := "Imported"
+	if *dryRun {
+		verb = "Would import"
+	} else if result.Imported > 0 {
+		if err := client.RefreshReportStats(ctx); err != nil {
+			log.Printf("WARNING: Failed to refresh report stats: %v", err)
+		}
+	}
+	log.Printf("%s %d reports (%d already present, %d failed)", verb, result.Imported, result.Skipped, len(result.Errors))
+	if len(result.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+// openStorage connects using the same DB_* variables as the server
+func openStorage(ctx context.Context) (storage.Client, error) {
+	switch getEnv("DB_TYPE", "firestore") {
+	case "postgres":
+		var client *storage.PostgresClient
+		var err error
+		if connString := os.Getenv("DB_CONNECTION_STRING"); connString != "" {
+			client, err = storage.NewPostgresClientFromConnString(ctx, connString, storage.DefaultPoolConfig())
+		} else if instance := os.Getenv("CLOUD_SQL_INSTANCE"); instance != "" {
+			client, err = storage.NewPostgresClient(ctx, instance, getEnv("DB_USER", "donzhit_app"), os.Getenv("DB_PASSWORD"),
+				getEnv("DB_NAME", "donzhit"), storage.DefaultPoolConfig())
+		} else {
+			return nil, fmt.Errorf("DB_TYPE=postgres requires either DB_CONNECTION_STRING or CLOUD_SQL_INSTANCE to be set")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := client.ValidateSchema(ctx); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("schema validation failed: %w", err)
+		}
+		return client, nil
+	default:
+		projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+		if projectID == "" {
+			return nil, fmt.Errorf("GOOGLE_CLOUD_PROJECT is required for Firestore")
+		}
+		return storage.NewFirestoreClient(ctx, projectID)
+	}
+}
+
+// getEnv gets an environment variable with a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,325 @@
+// Command seed populates a local database with sample users, reports in every
+// status, media references, comments and reactions for frontend development.
+//
+// Run against local Postgres:
+//
+//	DB_TYPE=postgres DB_CONNECTION_STRING=postgres://... go run ./cmd/seed
+//
+// or the Firestore emulator:
+//
+//	FIRESTORE_EMULATOR_HOST=localhost:8081 GOOGLE_CLOUD_PROJECT=demo go run ./cmd/seed
+//
+// Fixture IDs are deterministic, so running it again skips reports that already exist.
+// JWTs for the sample users are printed at the end (signed with JWT_SECRET/JWT_ISSUER).
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// seedNamespace derives stable fixture IDs so reruns are idempotent
+var seedNamespace = uuid.MustParse("5b1c7d0e-6f0a-4f57-9c55-0d6f3f2f7a10")
+
+func seedID(name string) string {
+	return uuid.NewSHA1(seedNamespace, []byte(name)).String()
+}
+
+var seedUsers = []models.User{
+	{ID: "seed-admin", Email: "admin@example.com", Role: models.RoleAdmin},
+	{ID: "seed-contributor", Email: "contributor@example.com", Role: models.RoleContributor},
+	{ID: "seed-contributor-2", Email: "cyclist@example.com", Role: models.RoleContributor},
+	{ID: "seed-viewer", Email: "viewer@example.com", Role: models.RoleViewer},
+}
+
+// reportFixture describes one sample report and the state to leave it in
+type reportFixture struct {
+	name       string
+	userID     string
+	title      string
+	desc       string
+	daysAgo    int
+	roadUsages []string
+	eventTypes []string
+	state      string
+	city       string
+	lat, lng   float64
+	status     string
+	priority   int    // Used when status is reviewed_pass
+	reason     string // Used when status is reviewed_fail
+	mergeInto  string // Fixture name of the canonical report when status is merged
+	media      []models.MediaFile
+	comments   []string
+	reactions  map[string]string // userID -> reaction type
+}
+
+var reportFixtures = []reportFixture{
+	{
+		name: "red-light-downtown", userID: "seed-contributor",
+		title: "SUV ran red light at 5th and Vine", desc: "Northbound SUV entered the intersection well after the light turned red while pedestrians were crossing.",
+		daysAgo: 2, roadUsages: []string{"Pedestrian"}, eventTypes: []string{"Red Light"},
+		state: "Ohio", city: "Cincinnati", lat: 39.1031, lng: -84.5120,
+		status: models.StatusReviewedPass, priority: 1,
+		media: []models.MediaFile{{
+			FileName: "dashcam.mp4", ContentType: "video/mp4", Size: 18_400_000,
+			URL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		}},
+		comments: []string{"This intersection needs a red light camera.", "Saw the same thing last week."},
+		reactions: map[string]string{
+			"seed-contributor-2": models.ReactionAngryPedestrian,
+			"seed-viewer":        models.ReactionThumbsUp,
+		},
+	},
+	{
+		name: "speeding-school-zone", userID: "seed-contributor-2",
+		title: "Speeding through school zone", desc: "Sedan doing roughly 45 in a 20 school zone during morning drop-off.",
+		daysAgo: 5, roadUsages: []string{"Cyclist"}, eventTypes: []string{"Speeding", "Reckless"},
+		state: "Ohio", city: "Cincinnati", lat: 39.1290, lng: -84.5160,
+		status: models.StatusReviewedPass, priority: 3,
+		media: []models.MediaFile{{
+			FileName: "helmet-cam.jpg", ContentType: "image/jpeg", Size: 2_300_000,
+			URL: "https://placehold.co/1280x720.jpg?text=Speeding",
+		}},
+		comments:  []string{"Happens every day around 8am."},
+		reactions: map[string]string{"seed-contributor": models.ReactionAngryBicycle},
+	},
+	{
+		name: "phone-driver-highway", userID: "seed-contributor",
+		title: "Driver texting on I-71", desc: "Pickup drifting between lanes while the driver looked at their phone.",
+		daysAgo: 9, roadUsages: []string{"Auto"}, eventTypes: []string{"On Phone"},
+		state: "Kentucky", city: "Louisville", lat: 38.2527, lng: -85.7585,
+		status: models.StatusReviewedPass, priority: 4,
+		reactions: map[string]string{"seed-viewer": models.ReactionThumbsDown},
+	},
+	{
+		name: "crosswalk-ignored", userID: "seed-contributor-2",
+		title: "Car didn't yield at crosswalk", desc: "Driver turned right through an occupied crosswalk.",
+		daysAgo: 1, roadUsages: []string{"Pedestrian"}, eventTypes: []string{"Pedestrian Intersection"},
+		state: "Ontario", city: "Toronto", lat: 43.6532, lng: -79.3832,
+		status: models.StatusSubmitted,
+	},
+	{
+		name: "bus-lane-blocked", userID: "seed-contributor",
+		title: "Delivery truck blocking bus lane", desc: "Truck parked in the bus lane for 20 minutes during rush hour.",
+		daysAgo: 0, roadUsages: []string{"Public Transit", "Commercial"}, eventTypes: []string{"Reckless"},
+		state: "Illinois", city: "Chicago", lat: 41.8781, lng: -87.6298,
+		status: models.StatusSubmitted,
+	},
+	{
+		name: "rejected-no-detail", userID: "seed-contributor",
+		title: "Bad driver", desc: "Someone drove badly.",
+		daysAgo: 14, roadUsages: []string{"Auto"}, eventTypes: []string{"Reckless"},
+		state: "Ohio", city: "Columbus",
+		status: models.StatusReviewedFail, reason: "Not enough detail to verify the incident.",
+	},
+	{
+		name: "deleted-by-owner", userID: "seed-contributor-2",
+		title: "Wrong location submitted", desc: "Submitted by mistake.",
+		daysAgo: 20, roadUsages: []string{"Cyclist"}, eventTypes: []string{"Speeding"},
+		state: "Ohio", city: "Dayton",
+		status: models.StatusDeleted,
+	},
+	{
+		name: "red-light-downtown-duplicate", userID: "seed-contributor-2",
+		title: "Red light runner at 5th & Vine", desc: "Same SUV as the other report, filmed from the opposite corner.",
+		daysAgo: 2, roadUsages: []string{"Pedestrian"}, eventTypes: []string{"Red Light"},
+		state: "Ohio", city: "Cincinnati", lat: 39.1032, lng: -84.5118,
+		status: models.StatusMerged, mergeInto: "red-light-downtown",
+		comments: []string{"Other angle of the same incident."},
+	},
+}
+
+func main() {
+	ctx := context.Background()
+
+	client, err := openStorage(ctx)
+	if err != nil {
+		log.Fatalf("Failed to open storage: %v", err)
+	}
+	defer client.Close()
+
+	for i := range seedUsers {
+		if err := client.CreateOrUpdateUser(ctx, &seedUsers[i]); err != nil {
+			log.Fatalf("Failed to create user %s: %v", seedUsers[i].Email, err)
+		}
+	}
+	log.Printf("Seeded %d users", len(seedUsers))
+
+	created := 0
+	for _, fx := range reportFixtures {
+		ok, err := seedReport(ctx, client, fx)
+		if err != nil {
+			log.Fatalf("Failed to seed report %q: %v", fx.name, err)
+		}
+		if ok {
+			created++
+		}
+	}
+	log.Printf("Seeded %d reports (%d already present)", created, len(reportFixtures)-created)
+
+	if err := client.RefreshReportStats(ctx); err != nil {
+		log.Printf("WARNING: Failed to refresh report stats: %v", err)
+	}
+
+	printTokens(ctx, client)
+}
+
+// openStorage connects using the same DB_* variables as the server, limited to local targets
+func openStorage(ctx context.Context) (storage.Client, error) {
+	switch getEnv("DB_TYPE", "firestore") {
+	case "postgres":
+		connString := os.Getenv("DB_CONNECTION_STRING")
+		if connString == "" {
+			return nil, fmt.Errorf("DB_CONNECTION_STRING is required; seeding via Cloud SQL is not supported")
+		}
+		return storage.NewPostgresClientFromConnString(ctx, connString, storage.DefaultPoolConfig())
+	default:
+		if os.Getenv("FIRESTORE_EMULATOR_HOST") == "" {
+			return nil, fmt.Errorf("FIRESTORE_EMULATOR_HOST is required; refusing to seed a real Firestore project")
+		}
+		projectID := getEnv("GOOGLE_CLOUD_PROJECT", "demo-donzhit")
+		return storage.NewFirestoreClient(ctx, projectID)
+	}
+}
+
+// seedReport creates a fixture and moves it to its target status. Returns false if it already existed.
+func seedReport(ctx context.Context, client storage.Client, fx reportFixture) (bool, error) {
+	reportID := seedID("report/" + fx.name)
+	if _, err := client.GetReport(ctx, reportID); err == nil {
+		return false, nil
+	}
+
+	dateTime := time.Now().UTC().AddDate(0, 0, -fx.daysAgo).Truncate(time.Hour)
+	report := &models.TrafficReport{
+		ID:                  reportID,
+		UserID:              fx.userID,
+		Title:               fx.title,
+		Description:         fx.desc,
+		DateTime:            dateTime,
+		RoadUsages:          fx.roadUsages,
+		EventTypes:          fx.eventTypes,
+		State:               fx.state,
+		City:                fx.city,
+		RetainMediaMetadata: true,
+		MediaFiles:          []models.MediaFile{},
+	}
+	if fx.lat != 0 || fx.lng != 0 {
+		lat, lng := fx.lat, fx.lng
+		report.Latitude, report.Longitude = &lat, &lng
+	}
+	for i, mf := range fx.media {
+		mf.ID = seedID(fmt.Sprintf("media/%s/%d", fx.name, i))
+		mf.UploadedAt = dateTime
+		report.MediaFiles = append(report.MediaFiles, mf)
+	}
+
+	if err := client.CreateReport(ctx, report); err != nil {
+		return false, fmt.Errorf("create: %w", err)
+	}
+
+	for i, content := range fx.comments {
+		author := seedUsers[(i+1)%len(seedUsers)]
+		commentedAt := dateTime.Add(time.Duration(i+1) * time.Hour)
+		if err := client.AddComment(ctx, &models.Comment{
+			ID:         seedID(fmt.Sprintf("comment/%s/%d", fx.name, i)),
+			ReportID:   reportID,
+			UserID:     author.ID,
+			UserEmail:  author.Email,
+			AuthorName: models.ContributorName(author.ID),
+			Content:    content,
+			CreatedAt:  commentedAt,
+			UpdatedAt:  commentedAt,
+		}); err != nil {
+			// The Firestore backend does not implement comments yet
+			log.Printf("WARNING: Skipping comments for %q: %v", fx.name, err)
+			break
+		}
+	}
+
+	for userID, reactionType := range fx.reactions {
+		if err := client.AddReaction(ctx, &models.Reaction{
+			ID:           seedID(fmt.Sprintf("reaction/%s/%s", fx.name, userID)),
+			ReportID:     reportID,
+			UserID:       userID,
+			UserEmail:    userEmail(userID),
+			ReactionType: reactionType,
+			CreatedAt:    dateTime.Add(30 * time.Minute),
+		}); err != nil {
+			// The Firestore backend does not implement reactions yet
+			log.Printf("WARNING: Skipping reactions for %q: %v", fx.name, err)
+			break
+		}
+	}
+
+	switch fx.status {
+	case models.StatusReviewedPass:
+		priority := fx.priority
+		err := client.UpdateReportStatusWithPriority(ctx, reportID, fx.status, "", &priority, "admin@example.com")
+		if err != nil {
+			return false, fmt.Errorf("approve: %w", err)
+		}
+	case models.StatusReviewedFail:
+		if err := client.UpdateReportStatus(ctx, reportID, fx.status, fx.reason, "admin@example.com"); err != nil {
+			return false, fmt.Errorf("reject: %w", err)
+		}
+	case models.StatusDeleted:
+		if err := client.DeleteReport(ctx, reportID, fx.userID); err != nil {
+			return false, fmt.Errorf("delete: %w", err)
+		}
+	case models.StatusMerged:
+		if err := client.MergeReports(ctx, seedID("report/"+fx.mergeInto), reportID, "admin@example.com"); err != nil {
+			return false, fmt.Errorf("merge: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// printTokens issues a JWT per sample user so the frontend can act as each role
+func printTokens(ctx context.Context, client storage.Client) {
+	jwtService := auth.NewJWTService(
+		getEnv("JWT_SECRET", auth.PlaceholderSecret),
+		getEnv("JWT_ISSUER", "donzhit.me"),
+	)
+
+	fmt.Println("\nSample user tokens (Authorization: Bearer <token>):")
+	for i := range seedUsers {
+		user := &seedUsers[i]
+		token, refreshToken, expiresAt, err := jwtService.GenerateToken(user)
+		if err != nil {
+			log.Printf("WARNING: Failed to generate token for %s: %v", user.Email, err)
+			continue
+		}
+		if err := client.UpdateUserRefreshToken(ctx, user.ID, refreshToken); err != nil {
+			log.Printf("WARNING: Failed to store refresh token for %s: %v", user.Email, err)
+			continue
+		}
+		fmt.Printf("\n%s (%s, expires %s):\n%s\n", user.Email, user.Role, expiresAt.Format(time.RFC3339), token)
+	}
+}
+
+func userEmail(userID string) string {
+	for _, u := range seedUsers {
+		if u.ID == userID {
+			return u.Email
+		}
+	}
+	return userID + "@example.com"
+}
+
+// getEnv gets an environment variable with a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
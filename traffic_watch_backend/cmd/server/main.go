@@ -6,23 +6,47 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime/debug"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"donzhit_me_backend/internal/analytics"
+	"donzhit_me_backend/internal/api"
 	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/errreport"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/flags"
 	"donzhit_me_backend/internal/handlers"
+	"donzhit_me_backend/internal/jobs"
+	"donzhit_me_backend/internal/metrics"
 	"donzhit_me_backend/internal/middleware"
 	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/moderation"
+	"donzhit_me_backend/internal/notify"
+	"donzhit_me_backend/internal/ocr"
+	"donzhit_me_backend/internal/ratelimit"
+	"donzhit_me_backend/internal/staticmap"
 	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/transcribe"
+	"donzhit_me_backend/internal/translate"
 	"donzhit_me_backend/internal/validation"
+	"donzhit_me_backend/internal/vehicle"
 )
 
 const (
 	version = "1.0.0"
 )
 
+// Set at build time with -ldflags "-X main.gitSHA=... -X main.buildTime=..."
+var (
+	gitSHA    string
+	buildTime string
+)
+
 func main() {
 	// Get configuration from environment
 	port := getEnv("PORT", "8080")
@@ -39,15 +63,185 @@ func main() {
 	dbName := getEnv("DB_NAME", "donzhit")
 	dbUser := getEnv("DB_USER", "donzhit_app")
 	dbPassword := getEnv("DB_PASSWORD", "")
+	dbReadConnectionString := getEnv("DB_READ_CONNECTION_STRING", "") // Optional read replica
+
+	// Connection pool tuning (DB_QUERY_EXEC_MODE=exec or simple_protocol for PgBouncer transaction pooling)
+	poolDefaults := storage.DefaultPoolConfig()
+	dbPoolConfig := storage.PoolConfig{
+		MaxConns:          int32(getEnvInt("DB_MAX_CONNS", int(poolDefaults.MaxConns))),
+		MinConns:          int32(getEnvInt("DB_MIN_CONNS", int(poolDefaults.MinConns))),
+		MaxConnLifetime:   getEnvDuration("DB_MAX_CONN_LIFETIME", poolDefaults.MaxConnLifetime),
+		MaxConnIdleTime:   getEnvDuration("DB_MAX_CONN_IDLE_TIME", poolDefaults.MaxConnIdleTime),
+		HealthCheckPeriod: getEnvDuration("DB_HEALTH_CHECK_PERIOD", poolDefaults.HealthCheckPeriod),
+		QueryExecMode:     getEnv("DB_QUERY_EXEC_MODE", poolDefaults.QueryExecMode),
+	}
 
 	// YouTube configuration
 	youtubeClientID := getEnv("YOUTUBE_CLIENT_ID", "")
 	youtubeClientSecret := getEnv("YOUTUBE_CLIENT_SECRET", "")
 	youtubeRefreshToken := getEnv("YOUTUBE_REFRESH_TOKEN", "")
 
-	// JWT configuration
-	jwtSecret := getEnv("JWT_SECRET", "change-this-in-production-use-256-bit-key")
-	jwtIssuer := getEnv("JWT_ISSUER", "donzhit.me")
+	// JWT configuration: lifetimes default to year-long tokens; validated below.
+	// Tokens slide forward on use, so a short JWT_ACCESS_TTL (e.g. 24h) with a
+	// longer JWT_REFRESH_TTL keeps active users signed in while idle tokens lapse.
+	jwtConfig := auth.DefaultJWTConfig(getEnv("JWT_SECRET", auth.PlaceholderSecret), getEnv("JWT_ISSUER", "donzhit.me"))
+	jwtConfig.AccessTokenTTL = getEnvDuration("JWT_ACCESS_TTL", jwtConfig.AccessTokenTTL)
+	jwtConfig.RefreshTokenTTL = getEnvDuration("JWT_REFRESH_TTL", jwtConfig.RefreshTokenTTL)
+	jwtConfig.ClockSkew = getEnvDuration("JWT_CLOCK_SKEW", jwtConfig.ClockSkew)
+
+	// Machine translation of the public feed (?lang=) via Cloud Translation, using
+	// application default credentials; TRANSLATION_CACHE_SIZE caps cached report translations
+	translationEnabled := getEnv("TRANSLATION_ENABLED", "false") == "true"
+	translationCacheSize := getEnvInt("TRANSLATION_CACHE_SIZE", translate.DefaultCacheSize)
+
+	// Transcripts of GCS-hosted videos via speech transcription, using application
+	// default credentials; TRANSCRIPTION_LANGUAGE is the language speech is expected in
+	transcriptionEnabled := getEnv("TRANSCRIPTION_ENABLED", "false") == "true"
+	transcriptionLanguage := getEnv("TRANSCRIPTION_LANGUAGE", "en-US")
+
+	// Static maps of report locations via the Static Maps API, rendered once per
+	// report and stored in the bucket; disabled without STATIC_MAPS_API_KEY
+	staticMapsAPIKey := getEnv("STATIC_MAPS_API_KEY", "")
+
+	// Admin-only OCR of report images via Cloud Vision, using application default credentials
+	ocrEnabled := getEnv("OCR_ENABLED", "false") == "true"
+
+	// Panics and 5xx responses go to Cloud Error Reporting, tagged with the service
+	// and release (Cloud Run sets K_SERVICE); otherwise they are only logged
+	errorReportingEnabled := getEnv("ERROR_REPORTING_ENABLED", "false") == "true"
+
+	// Log request and response bodies (credentials redacted) while troubleshooting
+	debugRequestLogging := getEnv("DEBUG_REQUEST_LOGGING", "false") == "true"
+	release := errreport.Release{
+		Service: getEnv("K_SERVICE", "donzhit-me-backend"),
+		Version: getEnv("RELEASE_VERSION", version),
+	}
+
+	// What to do with phone numbers, emails, and names in submitted report text:
+	// off, warn (tell the submitter), mask (in public responses), or flag (hold for review)
+	piiPolicyName := getEnv("PII_POLICY", string(moderation.PIIPolicyWarn))
+
+	// Vehicle matching configuration (HMAC key for plate/vehicle hashes)
+	vehicleHashSecret := getEnv("VEHICLE_HASH_SECRET", "")
+
+	// Storage metrics: calls slower than this are logged (0 disables)
+	slowQueryThreshold := getEnvDurationAllowZero("SLOW_QUERY_THRESHOLD", 500*time.Millisecond)
+
+	// Storage calls that run longer than STORAGE_TIMEOUT are cancelled and answered with 504 (0 disables).
+	// STORAGE_OPERATION_TIMEOUTS overrides single methods, e.g. "ListAllReports=30s,GetReport=2s" (0 disables).
+	storageTimeouts := storage.DefaultTimeouts()
+	storageTimeouts.Default = getEnvDurationAllowZero("STORAGE_TIMEOUT", storage.DefaultOperationTimeout)
+	if err := storageTimeouts.ParseOperationTimeouts(os.Getenv("STORAGE_OPERATION_TIMEOUTS")); err != nil {
+		log.Fatalf("Invalid STORAGE_OPERATION_TIMEOUTS: %v", err)
+	}
+
+	// Public feed cache, cleared on report lifecycle events that change the feed (0 disables)
+	feedCacheTTL := getEnvDurationAllowZero("FEED_CACHE_TTL", 30*time.Second)
+
+	// Lifetimes of signed media URLs in the public feed, on the owner's own reports and on
+	// admin screens (at most 7 days)
+	mediaURLDefaults := handlers.DefaultMediaURLLifetimes()
+	mediaURLLifetimes := handlers.MediaURLLifetimes{
+		Public: getEnvDuration("MEDIA_URL_TTL_PUBLIC", mediaURLDefaults.Public),
+		Owner:  getEnvDuration("MEDIA_URL_TTL_OWNER", mediaURLDefaults.Owner),
+		Admin:  getEnvDuration("MEDIA_URL_TTL_ADMIN", mediaURLDefaults.Admin),
+	}
+	if mediaURLLifetimes.Public > 0 && feedCacheTTL >= mediaURLLifetimes.Public {
+		log.Fatalf("FEED_CACHE_TTL (%v) must be shorter than MEDIA_URL_TTL_PUBLIC (%v), or cached feeds serve expired media URLs", feedCacheTTL, mediaURLLifetimes.Public)
+	}
+
+	// Lifecycle events are published to this Pub/Sub topic ("projects/<project>/topics/<topic>")
+	// using application default credentials; unset keeps them in-process
+	eventsTopic := getEnv("EVENTS_PUBSUB_TOPIC", "")
+	eventsPublishTimeout := getEnvDuration("EVENTS_PUBLISH_TIMEOUT", 10*time.Second)
+	// How often the relay delivers queued events to Pub/Sub and the notifier
+	outboxRelayInterval := getEnvDuration("OUTBOX_RELAY_INTERVAL", 5*time.Second)
+
+	// Background jobs configuration
+	statsRefreshInterval := getEnvDuration("STATS_REFRESH_INTERVAL", time.Hour)
+	wordFilterReloadInterval := getEnvDuration("WORD_FILTER_RELOAD_INTERVAL", 5*time.Minute)
+	reactionTypesReloadInterval := getEnvDuration("REACTION_TYPES_RELOAD_INTERVAL", 5*time.Minute)
+	commentPolicyReloadInterval := getEnvDuration("COMMENT_POLICY_RELOAD_INTERVAL", 5*time.Minute)
+	// Reaction changes allowed per user per window (0 disables the limit)
+	reactionRateLimit := getEnvInt("REACTION_RATE_LIMIT", 30)
+	reactionRateWindow := getEnvDuration("REACTION_RATE_WINDOW", time.Minute)
+	researchRateLimit := getEnvInt("RESEARCH_RATE_LIMIT", 60)
+	researchRateWindow := getEnvDuration("RESEARCH_RATE_WINDOW", time.Minute)
+	flagsReloadInterval := getEnvDuration("FEATURE_FLAGS_RELOAD_INTERVAL", time.Minute)
+	digestCheckInterval := getEnvDuration("DIGEST_CHECK_INTERVAL", time.Hour)
+	draftReminderInterval := getEnvDuration("DRAFT_REMINDER_CHECK_INTERVAL", time.Hour)
+	archiveCheckInterval := getEnvDuration("ARCHIVE_CHECK_INTERVAL", 24*time.Hour)
+	priorityDecayInterval := getEnvDuration("PRIORITY_DECAY_INTERVAL", 24*time.Hour)
+	hotScoreRefreshInterval := getEnvDuration("HOT_SCORE_REFRESH_INTERVAL", 15*time.Minute)
+	transcriptionInterval := getEnvDuration("TRANSCRIPTION_CHECK_INTERVAL", 5*time.Minute)
+	ocrInterval := getEnvDuration("OCR_CHECK_INTERVAL", 5*time.Minute)
+	mapImageInterval := getEnvDuration("MAP_IMAGE_CHECK_INTERVAL", 5*time.Minute)
+	uploadCheckInterval := getEnvDuration("UPLOAD_CHECK_INTERVAL", time.Minute)
+	mediaMaintenanceInterval := getEnvDuration("MEDIA_MAINTENANCE_INTERVAL", 6*time.Hour)
+	// How often public API usage counts are written to storage (0 disables usage tracking)
+	apiUsageFlushInterval := getEnvDurationAllowZero("API_USAGE_FLUSH_INTERVAL", time.Minute)
+
+	// Share of boosted priority (above the default of 100) removed on each decay run (0 disables)
+	priorityDecayPercent := getEnvInt("PRIORITY_DECAY_PERCENT", handlers.DefaultPriorityDecayPercent)
+
+	// Approved reports older than this move from the feed to the archive (0 disables)
+	archiveAfter := getEnvDurationAllowZero("ARCHIVE_AFTER", handlers.DefaultArchiveAfter)
+
+	// Drafts left unedited this long get a reminder, if their owner opted in (0 disables)
+	draftReminderAfter := getEnvDurationAllowZero("DRAFT_REMINDER_AFTER", 3*24*time.Hour)
+
+	// Times an owner may send a rejected report back to review (0 disables resubmission)
+	maxResubmissions := getEnvInt("MAX_RESUBMISSIONS", handlers.DefaultMaxResubmissions)
+
+	// Weekly digest: unsubscribe links are signed with UNSUBSCRIBE_SECRET, required
+	// outside dev mode, and prefixed with PUBLIC_API_URL
+	unsubscribeSecret := signingSecret("UNSUBSCRIBE_SECRET", devMode, jwtConfig.Secret)
+	publicAPIURL := getEnv("PUBLIC_API_URL", "")
+
+	// Web app base URL, for links in emails that need a signed-in user (e.g. invitations)
+	publicAppURL := getEnv("PUBLIC_APP_URL", "https://donzhit.me")
+
+	// Role invitation links are signed with INVITATION_SECRET, required outside dev mode
+	invitationSecret := signingSecret("INVITATION_SECRET", devMode, jwtConfig.Secret)
+
+	// Cookie-based web sessions: cookies are Secure outside dev mode
+	jwtConfig.Cookies.Domain = getEnv("SESSION_COOKIE_DOMAIN", "")
+	jwtConfig.Cookies.Secure = !devMode
+	if sameSite := getEnv("SESSION_COOKIE_SAMESITE", ""); sameSite != "" {
+		if mode, ok := auth.ParseSameSite(sameSite); ok {
+			jwtConfig.Cookies.SameSite = mode
+		} else {
+			log.Printf("WARNING: invalid SESSION_COOKIE_SAMESITE %q, using lax", sameSite)
+		}
+	}
+
+	// Bootstrap admins (comma-separated emails), always given the admin role on sign-in
+	adminEmails := strings.Split(getEnv("ADMIN_EMAILS", ""), ",")
+
+	// CORS: /v1/admin only accepts first-party origins (comma-separated, defaulting to
+	// the web app, plus localhost in dev mode), and only they may send session cookies
+	// elsewhere; the public feed is open to any origin
+	adminOrigins := []string{publicAppURL}
+	if v := getEnv("ADMIN_CORS_ORIGINS", ""); v != "" {
+		adminOrigins = strings.Split(v, ",")
+	} else if devMode {
+		adminOrigins = append(adminOrigins, "http://localhost:*", "https://localhost:*")
+	}
+	defaultCORS := middleware.DefaultCORSConfig()
+	defaultCORS.CredentialedOrigins = adminOrigins
+	adminCORS := middleware.AdminCORSConfig(adminOrigins)
+	publicCORS := middleware.PublicCORSConfig()
+
+	// Legacy /v1/legacy routes: advertised sunset date (RFC 3339, optional) and kill switch
+	legacyDisabled := getEnv("LEGACY_ROUTES_DISABLED", "false") == "true"
+	legacySunset := getEnvTime("LEGACY_SUNSET")
+
+	// Feature flag overrides for this deployment, e.g. "comments=on,new_upload_flow=25"
+	flagOverrides, flagsErr := flags.ParseOverrides(getEnv("FEATURE_FLAGS", ""))
+	if flagsErr != nil {
+		log.Printf("WARNING: Ignoring FEATURE_FLAGS: %v", flagsErr)
+		flagOverrides = nil
+	}
 
 	// Set Gin mode
 	if devMode {
@@ -58,6 +252,17 @@ func main() {
 
 	ctx := context.Background()
 
+	var errorReporter errreport.Reporter = errreport.LogReporter{}
+	if errorReportingEnabled {
+		cloudReporter, err := errreport.NewCloudReporter(ctx, projectID, release)
+		if err != nil {
+			log.Printf("WARNING: Failed to create error reporting client: %v - errors will only be logged", err)
+		} else {
+			errorReporter = cloudReporter
+			log.Printf("Reporting errors for %s %s to Cloud Error Reporting", release.Service, release.Version)
+		}
+	}
+
 	// Register custom validators
 	if err := validation.RegisterCustomValidators(); err != nil {
 		log.Fatalf("Failed to register validators: %v", err)
@@ -72,24 +277,26 @@ func main() {
 
 	// Initialize storage clients
 	var storageClient storage.Client
-	var gcsClient *storage.GCSClient
-	var youtubeClient *storage.YouTubeClient
+	var blobStore storage.BlobStore
+	var videoHost storage.VideoHost
+	var devBlobs *storage.LocalBlobStore
 	var err error
 
 	// Initialize database storage based on DB_TYPE
 	switch dbType {
 	case "postgres":
-		log.Printf("Initializing PostgreSQL storage backend")
+		log.Printf("Initializing PostgreSQL storage backend (max conns: %d, exec mode: %s)", dbPoolConfig.MaxConns, dbPoolConfig.QueryExecMode)
+		var pgClient *storage.PostgresClient
 		if dbConnectionString != "" {
 			// Use direct connection string (for local development)
-			storageClient, err = storage.NewPostgresClientFromConnString(ctx, dbConnectionString)
+			pgClient, err = storage.NewPostgresClientFromConnString(ctx, dbConnectionString, dbPoolConfig)
 			if err != nil {
 				log.Fatalf("Failed to create PostgreSQL client from connection string: %v", err)
 			}
 			log.Printf("PostgreSQL client initialized using connection string")
 		} else if cloudSQLInstance != "" {
 			// Use Cloud SQL connector (for production)
-			storageClient, err = storage.NewPostgresClient(ctx, cloudSQLInstance, dbUser, dbPassword, dbName)
+			pgClient, err = storage.NewPostgresClient(ctx, cloudSQLInstance, dbUser, dbPassword, dbName, dbPoolConfig)
 			if err != nil {
 				log.Fatalf("Failed to create PostgreSQL client via Cloud SQL: %v", err)
 			}
@@ -98,11 +305,27 @@ func main() {
 			log.Fatalf("DB_TYPE=postgres requires either DB_CONNECTION_STRING or CLOUD_SQL_INSTANCE to be set")
 		}
 
+		// Fail fast if migrations are missing rather than erroring on first request
+		if err := pgClient.ValidateSchema(ctx); err != nil {
+			log.Fatalf("PostgreSQL schema validation failed: %v", err)
+		}
+
+		// Optional read replica for public feed, map and stats queries
+		if dbReadConnectionString != "" {
+			if err := pgClient.SetReadReplica(ctx, dbReadConnectionString); err != nil {
+				log.Printf("WARNING: Failed to connect to read replica: %v - all queries will use the primary", err)
+			} else {
+				log.Printf("PostgreSQL read replica initialized")
+			}
+		}
+		storageClient = pgClient
+
 	case "firestore":
 		fallthrough
 	default:
 		if dbType != "firestore" {
 			log.Printf("WARNING: Unknown DB_TYPE '%s', falling back to Firestore", dbType)
+			dbType = "firestore"
 		}
 		log.Printf("Initializing Firestore storage backend")
 		if projectID == "" {
@@ -112,18 +335,48 @@ func main() {
 		if err != nil {
 			log.Fatalf("Failed to create Firestore client: %v", err)
 		}
+
+		// Queries without their composite index fail on first use, so name any missing
+		// ones now. Listing indexes needs extra IAM permissions, so by default this only warns.
+		if err := firestoreClient.ValidateIndexes(ctx); err != nil {
+			if getEnv("FIRESTORE_REQUIRE_INDEXES", "false") == "true" {
+				log.Fatalf("Firestore index validation failed: %v", err)
+			}
+			log.Printf("WARNING: Firestore index validation failed: %v", err)
+		}
 		storageClient = firestoreClient
 		log.Printf("Firestore client initialized (project: %s)", projectID)
 	}
+
+	// Bound each storage call, record per-method latency and log slow storage calls
+	storageMetrics := metrics.NewRegistry()
+	instrumented := storage.NewInstrumentedClient(storageClient, storageMetrics, slowQueryThreshold)
+	instrumented.SetTimeouts(storageTimeouts)
+	storageClient = instrumented
 	defer storageClient.Close()
 
-	// Initialize GCS client (needed for image storage)
-	if bucketName != "" {
-		gcsClient, err = storage.NewGCSClient(ctx, bucketName)
+	healthHandler := handlers.NewHealthHandler(release.Version)
+	healthHandler.SetBuildInfo(buildInfo())
+	healthHandler.SetStorageBackend(dbType)
+	healthHandler.AddCheck("database", storageClient.Ping)
+
+	// Initialize GCS client (needed for image storage). In dev mode without an
+	// explicit bucket, files are kept in a local directory and served by this server.
+	if devMode && os.Getenv("GCS_BUCKET") == "" {
+		devBlobs, err = storage.NewLocalBlobStore(getEnv("DEV_BLOB_DIR", ""), getEnv("PUBLIC_API_URL", "http://localhost:"+port))
+		if err != nil {
+			log.Fatalf("Failed to create dev blob store: %v", err)
+		}
+		blobStore = devBlobs
+		log.Printf("Dev mode: media files are stored in %s", devBlobs.Dir())
+	} else if bucketName != "" {
+		gcsClient, err := storage.NewGCSClient(ctx, bucketName)
 		if err != nil {
 			log.Fatalf("Failed to create GCS client: %v", err)
 		}
 		defer gcsClient.Close()
+		blobStore = gcsClient
+		healthHandler.AddCheck("gcs", gcsClient.CheckAccess)
 		log.Printf("GCS client initialized (bucket: %s)", bucketName)
 	} else {
 		log.Println("WARNING: GCS_BUCKET not set - image uploads will not work")
@@ -131,110 +384,253 @@ func main() {
 
 	// Initialize YouTube client (for video uploads)
 	if youtubeClientID != "" && youtubeClientSecret != "" && youtubeRefreshToken != "" {
-		youtubeClient, err = storage.NewYouTubeClient(ctx, youtubeClientID, youtubeClientSecret, youtubeRefreshToken)
+		youtubeClient, err := storage.NewYouTubeClient(ctx, youtubeClientID, youtubeClientSecret, youtubeRefreshToken)
 		if err != nil {
 			log.Printf("WARNING: Failed to create YouTube client: %v - video uploads will fall back to GCS", err)
 		} else {
+			videoHost = youtubeClient
+			healthHandler.AddCheck("youtube", youtubeClient.CheckToken)
 			log.Printf("YouTube client initialized for video uploads")
 		}
+	} else if devMode {
+		localVideos, err := storage.NewLocalVideoHost(getEnv("DEV_VIDEO_DIR", ""))
+		if err != nil {
+			log.Fatalf("Failed to create dev video host: %v", err)
+		}
+		videoHost = localVideos
+		log.Printf("Dev mode: videos are stored in %s instead of YouTube", localVideos.Dir())
 	} else {
 		log.Println("WARNING: YouTube credentials not configured - video uploads will use GCS")
 	}
 
 	// Initialize JWT service
-	jwtService := auth.NewJWTService(jwtSecret, jwtIssuer)
-	log.Printf("JWT service initialized (issuer: %s)", jwtIssuer)
+	if err := jwtConfig.Validate(devMode); err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+	jwtService := auth.NewJWTServiceWithConfig(jwtConfig)
+	log.Printf("JWT service initialized (issuer: %s, access TTL: %s, refresh TTL: %s, clock skew: %s)",
+		jwtConfig.Issuer, jwtConfig.AccessTokenTTL, jwtConfig.RefreshTokenTTL, jwtConfig.ClockSkew)
 
 	// Initialize handlers
-	healthHandler := handlers.NewHealthHandler(version)
-	reportsHandler := handlers.NewReportsHandler(storageClient, gcsClient, youtubeClient)
-	authHandler := handlers.NewAuthHandler(storageClient, iapValidator, jwtService)
+	reportsHandler := handlers.NewReportsHandler(storageClient, blobStore, videoHost)
+	if vehicleHashSecret != "" {
+		reportsHandler.SetVehicleHasher(vehicle.NewHasher(vehicleHashSecret))
+		log.Printf("Vehicle matching enabled")
+	} else {
+		log.Println("WARNING: VEHICLE_HASH_SECRET not set - vehicle matching disabled")
+	}
+	reportsHandler.SetWordFilter(moderation.NewWordFilter(nil))
+	if err := reportsHandler.ReloadWordFilter(ctx); err != nil {
+		log.Printf("WARNING: Failed to load blocked words: %v - word filter starts empty", err)
+	}
+	if err := reportsHandler.ReloadReactionTypes(ctx); err != nil {
+		log.Printf("WARNING: Failed to load reaction types: %v - using the built-in set", err)
+	}
+	if err := reportsHandler.ReloadCommentPolicy(ctx); err != nil {
+		log.Printf("WARNING: Failed to load comment policy: %v - using the defaults", err)
+	}
+	if reactionRateLimit > 0 {
+		reportsHandler.SetReactionRateLimit(ratelimit.New(reactionRateLimit, reactionRateWindow))
+		log.Printf("Reaction rate limit: %d changes per %s", reactionRateLimit, reactionRateWindow)
+	}
 
-	// Create Gin router
-	router := gin.New()
-
-	// Global middleware
-	router.Use(gin.Recovery())
-	router.Use(gin.Logger())
-	router.Use(middleware.CORS(middleware.DefaultCORSConfig()))
-
-	// API v1 routes
-	v1 := router.Group("/v1")
-	{
-		// Health check (no auth required)
-		v1.GET("/health", healthHandler.Health)
-
-		// Public endpoints (no auth required)
-		publicGroup := v1.Group("/public")
-		{
-			publicGroup.GET("/reports", reportsHandler.ListApprovedReports)
-			publicGroup.GET("/reports/:id/comments", reportsHandler.GetComments)
+	// Per-report media caps (file count and combined size in MB)
+	mediaLimits := validation.DefaultMediaLimits()
+	mediaLimits.MaxFiles = getEnvInt("MAX_REPORT_FILES", mediaLimits.MaxFiles)
+	mediaLimits.MaxTotalSize = int64(getEnvInt("MAX_REPORT_MEDIA_MB", int(mediaLimits.MaxTotalSize/(1024*1024)))) * 1024 * 1024
+	reportsHandler.SetMediaLimits(mediaLimits)
+	// Users at the trusted level get higher caps
+	trustedMediaLimits := validation.DefaultTrustedMediaLimits()
+	trustedMediaLimits.MaxFiles = getEnvInt("MAX_REPORT_FILES_TRUSTED", trustedMediaLimits.MaxFiles)
+	trustedMediaLimits.MaxTotalSize = int64(getEnvInt("MAX_REPORT_MEDIA_MB_TRUSTED", int(trustedMediaLimits.MaxTotalSize/(1024*1024)))) * 1024 * 1024
+	reportsHandler.SetTrustedMediaLimits(trustedMediaLimits)
+
+	// Trust levels: approved reports needed for basic and trusted, and the
+	// largest share of rejected reviews a trusted user may have
+	trustThresholds := models.DefaultTrustThresholds()
+	trustThresholds.BasicApproved = getEnvInt("TRUST_BASIC_APPROVED", trustThresholds.BasicApproved)
+	trustThresholds.TrustedApproved = getEnvInt("TRUST_TRUSTED_APPROVED", trustThresholds.TrustedApproved)
+	trustThresholds.TrustedMaxRejectedPercent = getEnvInt("TRUST_TRUSTED_MAX_REJECTED_PERCENT", trustThresholds.TrustedMaxRejectedPercent)
+	reportsHandler.SetTrustThresholds(trustThresholds)
+
+	// Request body caps (0 for none): small for JSON endpoints, and room for a
+	// full report's media at the highest limits plus its form fields on upload routes
+	uploadBodyMB := 0
+	if mediaLimits.MaxTotalSize > 0 && trustedMediaLimits.MaxTotalSize > 0 {
+		uploadBodyMB = int(max(mediaLimits.MaxTotalSize, trustedMediaLimits.MaxTotalSize)/(1024*1024)) + 10
+	}
+	bodyLimits := api.BodyLimits{
+		Default: int64(getEnvInt("MAX_BODY_KB", 1024)) * 1024,
+		Media:   int64(getEnvInt("MAX_UPLOAD_BODY_MB", uploadBodyMB)) * 1024 * 1024,
+	}
+	log.Printf("Request body limits: %d bytes, %d bytes on media upload routes", bodyLimits.Default, bodyLimits.Media)
+	reportsHandler.SetArchiveAfter(archiveAfter)
+	reportsHandler.SetPriorityDecayPercent(priorityDecayPercent)
+	reportsHandler.SetMaxResubmissions(maxResubmissions)
+	reportsHandler.SetMediaURLLifetimes(mediaURLLifetimes)
+	piiPolicy, err := moderation.ParsePIIPolicy(piiPolicyName)
+	if err != nil {
+		log.Fatalf("Invalid PII_POLICY: %v", err)
+	}
+	reportsHandler.SetPIIPolicy(piiPolicy)
+	reportsHandler.SetPublicAppURL(publicAppURL)
+	reportsHandler.SetPublicAPIURL(publicAPIURL)
+	reportsHandler.SetMediaBucket(bucketName)
+	if transcriptionEnabled {
+		transcriber, err := transcribe.NewVideoTranscriber(ctx, transcriptionLanguage)
+		if err != nil {
+			log.Printf("WARNING: Failed to create transcription client: %v - videos will not be transcribed", err)
+		} else {
+			reportsHandler.SetTranscriber(transcriber)
+			log.Printf("Video transcription enabled (language %s)", transcriptionLanguage)
 		}
-
-		// Public endpoints with optional auth (for user-specific data like "did I react?")
-		publicOptionalAuth := v1.Group("/public")
-		publicOptionalAuth.Use(middleware.OptionalJWTAuth(jwtService, storageClient))
-		{
-			publicOptionalAuth.GET("/reports/:id/engagement", reportsHandler.GetReportEngagement)
-			publicOptionalAuth.POST("/reports/engagement", reportsHandler.GetBulkEngagement)
+	}
+	if ocrEnabled {
+		textReader, err := ocr.NewVisionReader(ctx)
+		if err != nil {
+			log.Printf("WARNING: Failed to create OCR client: %v - images will not be read", err)
+		} else {
+			reportsHandler.SetTextReader(textReader)
+			log.Printf("Image OCR enabled")
 		}
-
-		// Auth endpoints (login requires Google token, not JWT)
-		authGroup := v1.Group("/auth")
-		{
-			authGroup.POST("/login", authHandler.Login)
+	}
+	if staticMapsAPIKey != "" {
+		reportsHandler.SetMapRenderer(staticmap.NewGoogleRenderer(staticMapsAPIKey))
+		log.Printf("Static map images enabled")
+	}
+	if translationEnabled {
+		translator, err := translate.NewCloudTranslator(ctx)
+		if err != nil {
+			log.Printf("WARNING: Failed to create translation client: %v - ?lang= will serve untranslated reports", err)
+		} else {
+			reportsHandler.SetTranslator(translate.NewService(translator, translationCacheSize))
+			log.Printf("Feed translation enabled (cache size %d)", translationCacheSize)
 		}
+	}
 
-		// Protected auth endpoints (requires JWT)
-		authProtected := v1.Group("/auth")
-		authProtected.Use(middleware.JWTAuth(jwtService, storageClient))
-		{
-			authProtected.GET("/me", authHandler.GetCurrentUser)
-			authProtected.POST("/logout", authHandler.Logout)
+	authHandler := handlers.NewAuthHandler(storageClient, iapValidator, jwtService)
+	authHandler.SetAdminEmails(adminEmails)
+	flagsHandler := handlers.NewFlagsHandler(storageClient, flags.NewSet(flagOverrides))
+
+	// Report lifecycle events: the single hook point for invalidating derived views
+	eventBus := events.NewBus()
+	reportsHandler.SetEventBus(eventBus)
+	reportsHandler.SetFeedCacheTTL(feedCacheTTL)
+	eventBus.Subscribe(func(e events.Event) {
+		log.Printf("Report event %s for %s by %s", e.Type, e.ReportID, e.Actor)
+		if e.Type == events.ReportCreated || e.Type == events.CommentAdded {
+			return // Neither changes the public feed
 		}
-
-		// Protected routes with JWT auth (for new JWT-based clients)
-		jwtProtected := v1.Group("")
-		jwtProtected.Use(middleware.JWTAuth(jwtService, storageClient))
-		jwtProtected.Use(middleware.RequireRole(models.RoleContributor))
-		{
-			// Reports endpoints
-			jwtProtected.POST("/reports", reportsHandler.CreateReport)
-			jwtProtected.GET("/reports", reportsHandler.ListReports)
-			jwtProtected.GET("/reports/:id", reportsHandler.GetReport)
-			jwtProtected.DELETE("/reports/:id", reportsHandler.DeleteReport)
-
-			// Reactions endpoints (requires auth)
-			jwtProtected.POST("/reports/:id/reactions", reportsHandler.AddReaction)
-			jwtProtected.DELETE("/reports/:id/reactions/:type", reportsHandler.RemoveReaction)
-
-			// Comments endpoints (requires auth)
-			jwtProtected.POST("/reports/:id/comments", reportsHandler.AddComment)
-			jwtProtected.DELETE("/reports/:id/comments/:commentId", reportsHandler.DeleteComment)
+		reportsHandler.InvalidateFeedCache()
+	})
+	// Review outcomes move the report owner's trust level
+	eventBus.Subscribe(reportsHandler.UpdateTrustOnReview)
+	var eventPublisher events.EventPublisher = events.NoopPublisher{}
+	if eventsTopic != "" {
+		publisher, err := events.NewPubSubPublisher(ctx, eventsTopic)
+		if err != nil {
+			log.Printf("WARNING: Failed to create Pub/Sub client: %v - events will not leave the process", err)
+		} else {
+			eventPublisher = publisher
+			log.Printf("Publishing report events to %s", eventsTopic)
 		}
+	}
 
-		// Admin routes (requires JWT + admin role)
-		adminGroup := v1.Group("/admin")
-		adminGroup.Use(middleware.JWTAuth(jwtService, storageClient))
-		adminGroup.Use(middleware.RequireRole(models.RoleAdmin))
-		{
-			adminGroup.GET("/reports", reportsHandler.ListAllReportsAdmin)
-			adminGroup.GET("/reports/review", reportsHandler.ListReportsForReview)
-			adminGroup.POST("/reports/:id/review", reportsHandler.ReviewReport)
-		}
+	// Notifications: no email or push provider yet, so deliveries are logged.
+	// The notifier checks each recipient's preferences before any sender runs.
+	notifier := notify.NewNotifier(storageClient,
+		notify.NewLogSender(notify.ChannelEmail),
+		notify.NewLogSender(notify.ChannelPush),
+	)
+
+	// Events leave the process only through the outbox, written in the same
+	// transaction as the change, so a crash cannot lose or invent one
+	relay := events.NewRelay(storageClient, eventsPublishTimeout, eventPublisher, notifier)
+	unsubscriber := auth.NewTokenSigner(unsubscribeSecret, auth.PurposeDigestUnsubscribe)
+	authHandler.SetUnsubscriber(unsubscriber)
+	authHandler.SetInvitations(auth.NewTokenSigner(invitationSecret, auth.PurposeRoleInvitation), notifier, publicAppURL+"/invitations/accept")
+	digestJob := notify.NewDigestJob(storageClient, notifier, unsubscriber, publicAPIURL)
+	// Draft discard links share UNSUBSCRIBE_SECRET; the purpose keeps the tokens apart
+	draftDiscarder := auth.NewTokenSigner(unsubscribeSecret, auth.PurposeDraftDiscard)
+	reportsHandler.SetDraftDiscarder(draftDiscarder)
+
+	// Start background jobs
+	scheduler := jobs.NewScheduler()
+	scheduler.SetReporter(errorReporter)
+	scheduler.Every("relay-outbox", outboxRelayInterval, relay.Run)
+	scheduler.Every("refresh-report-stats", statsRefreshInterval, storageClient.RefreshReportStats)
+	scheduler.Every("reload-word-filter", wordFilterReloadInterval, reportsHandler.ReloadWordFilter)
+	scheduler.Every("reload-reaction-types", reactionTypesReloadInterval, reportsHandler.ReloadReactionTypes)
+	scheduler.Every("reload-comment-policy", commentPolicyReloadInterval, reportsHandler.ReloadCommentPolicy)
+	scheduler.Every("reload-feature-flags", flagsReloadInterval, flagsHandler.Reload)
+	scheduler.Every("weekly-digest", digestCheckInterval, digestJob.Run)
+	if draftReminderAfter > 0 {
+		draftReminders := notify.NewDraftReminderJob(storageClient, notifier, draftDiscarder, publicAPIURL, draftReminderAfter)
+		scheduler.Every("remind-drafts", draftReminderInterval, draftReminders.Run)
+	} else {
+		log.Println("Draft reminders are disabled")
+	}
+	scheduler.Every("archive-old-reports", archiveCheckInterval, reportsHandler.ArchiveOldReports)
+	scheduler.Every("decay-report-priority", priorityDecayInterval, reportsHandler.DecayPriorities)
+	scheduler.Every("refresh-hot-scores", hotScoreRefreshInterval, storageClient.RefreshHotScores)
+	scheduler.Every("transcribe-videos", transcriptionInterval, reportsHandler.TranscribeVideos)
+	scheduler.Every("read-media-text", ocrInterval, reportsHandler.ReadMediaText)
+	scheduler.Every("render-map-images", mapImageInterval, reportsHandler.RenderMapImages)
+	scheduler.Every("check-uploads", uploadCheckInterval, reportsHandler.CheckUploads)
+	scheduler.Every("maintain-media", mediaMaintenanceInterval, reportsHandler.MaintainMedia)
+
+	var apiUsage *analytics.Recorder
+	if apiUsageFlushInterval > 0 {
+		apiUsage = analytics.NewRecorder(storageClient)
+		scheduler.Every("flush-api-usage", apiUsageFlushInterval, apiUsage.Flush)
+	} else {
+		log.Println("Public API usage tracking is disabled")
+	}
 
-		// Legacy protected routes with Google token auth (for backwards compatibility)
-		// TODO: Remove after all clients migrate to JWT
-		legacyProtected := v1.Group("/legacy")
-		legacyProtected.Use(middleware.IAPAuth(iapValidator))
-		{
-			legacyProtected.POST("/reports", reportsHandler.CreateReport)
-			legacyProtected.GET("/reports", reportsHandler.ListReports)
-			legacyProtected.GET("/reports/:id", reportsHandler.GetReport)
-			legacyProtected.DELETE("/reports/:id", reportsHandler.DeleteReport)
-		}
+	legacyMetrics := metrics.NewRegistry()
+	if legacyDisabled {
+		log.Println("Legacy Google token routes are disabled")
+	}
+
+	if debugRequestLogging {
+		log.Println("WARNING: Request and response bodies are being logged (DEBUG_REQUEST_LOGGING)")
 	}
 
+	var researchLimiter *ratelimit.Limiter
+	if researchRateLimit > 0 {
+		researchLimiter = ratelimit.New(researchRateLimit, researchRateWindow)
+		log.Printf("Research token rate limit: %d requests per %s", researchRateLimit, researchRateWindow)
+	}
+
+	// Create Gin router with all API routes
+	router := api.NewRouter(api.Dependencies{
+		Storage:           storageClient,
+		JWTService:        jwtService,
+		IAPValidator:      iapValidator,
+		HealthHandler:     healthHandler,
+		ReportsHandler:    reportsHandler,
+		AuthHandler:       authHandler,
+		Announcements:     handlers.NewAnnouncementsHandler(storageClient),
+		FlagsHandler:      flagsHandler,
+		MetricsHandler:    handlers.NewMetricsHandler(storageMetrics, legacyMetrics),
+		ResearchRateLimit: researchLimiter,
+		ErrorReporter:     errorReporter,
+		DebugLogging:      debugRequestLogging,
+		APIUsage:          apiUsage,
+		DevBlobs:          devBlobs,
+		BodyLimits:        bodyLimits,
+		Legacy: api.LegacyRoutes{
+			Disabled: legacyDisabled,
+			Sunset:   legacySunset,
+			Metrics:  legacyMetrics,
+		},
+		CORS: api.CORSPolicies{
+			Default: defaultCORS,
+			Admin:   &adminCORS,
+			Public:  &publicCORS,
+		},
+	})
+
 	// Create HTTP server
 	srv := &http.Server{
 		Addr:         ":" + port,
@@ -246,7 +642,7 @@ func main() {
 
 	// Start server in goroutine
 	go func() {
-		log.Printf("Starting server on port %s (version %s, dev mode: %v, db: %s)", port, version, devMode, dbType)
+		log.Printf("Starting server on port %s (version %s, dev mode: %v, db: %s)", port, release.Version, devMode, dbType)
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
@@ -266,10 +662,32 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
+	scheduler.Stop()
+	// Keep the counts recorded since the last scheduled flush
+	if err := apiUsage.Flush(ctx); err != nil {
+		log.Printf("WARNING: Failed to flush API usage: %v", err)
+	}
 
 	log.Println("Server exited")
 }
 
+// buildInfo returns the commit and build time set by -ldflags. Binaries built from
+// a git checkout without them report the commit and its time, as stamped by go build.
+func buildInfo() (sha, builtAt string) {
+	sha, builtAt = gitSHA, buildTime
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			switch {
+			case s.Key == "vcs.revision" && sha == "":
+				sha = s.Value
+			case s.Key == "vcs.time" && builtAt == "":
+				builtAt = s.Value
+			}
+		}
+	}
+	return sha, builtAt
+}
+
 // getEnv gets an environment variable with a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -277,3 +695,81 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// signingSecret gets the secret for one kind of signed token. Outside dev mode it
+// must be set, at least auth.MinSecretLength bytes, and differ from the JWT secret,
+// so a leaked key can only forge its own kind of token; in dev mode it defaults to
+// a placeholder derived from key.
+func signingSecret(key string, devMode bool, jwtSecret string) string {
+	secret := os.Getenv(key)
+	switch {
+	case devMode && secret == "":
+		return "dev-" + strings.ToLower(key)
+	case devMode:
+		return secret
+	case secret == "":
+		log.Fatalf("%s is required outside dev mode", key)
+	case len(secret) < auth.MinSecretLength:
+		log.Fatalf("%s must be at least %d bytes", key, auth.MinSecretLength)
+	case secret == jwtSecret:
+		log.Fatalf("%s must differ from JWT_SECRET", key)
+	}
+	return secret
+}
+
+// getEnvInt gets an integer environment variable with a default value
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("WARNING: invalid %s %q, using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getEnvDuration gets a duration environment variable (e.g. "15m") with a default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		log.Printf("WARNING: invalid %s %q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// getEnvDurationAllowZero is getEnvDuration for settings where 0 means "disabled":
+// "0" (or "0s") yields zero instead of the default; negative values are still invalid
+func getEnvDurationAllowZero(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		log.Printf("WARNING: invalid %s %q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// getEnvTime gets an RFC 3339 timestamp environment variable; unset or invalid yields the zero time
+func getEnvTime(key string) time.Time {
+	value := os.Getenv(key)
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		log.Printf("WARNING: invalid %s %q, ignoring", key, value)
+		return time.Time{}
+	}
+	return t
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetEnvDuration(t *testing.T) {
+	const key = "TEST_DURATION"
+	tests := []struct {
+		value         string
+		want          time.Duration
+		wantAllowZero time.Duration
+	}{
+		{"", time.Minute, time.Minute},
+		{"15s", 15 * time.Second, 15 * time.Second},
+		{"0", time.Minute, 0},
+		{"0s", time.Minute, 0},
+		{"-5s", time.Minute, time.Minute},
+		{"soon", time.Minute, time.Minute},
+	}
+	for _, tt := range tests {
+		t.Setenv(key, tt.value)
+		if got := getEnvDuration(key, time.Minute); got != tt.want {
+			t.Errorf("getEnvDuration(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+		if got := getEnvDurationAllowZero(key, time.Minute); got != tt.wantAllowZero {
+			t.Errorf("getEnvDurationAllowZero(%q) = %v, want %v", tt.value, got, tt.wantAllowZero)
+		}
+	}
+}
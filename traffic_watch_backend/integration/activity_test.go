@@ -0,0 +1,64 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestMyActivity_ListsCommentsAndReactionsAcrossReports(t *testing.T) {
+	ownerToken := createUser(t, "activity-owner", "activity-owner@example.com", models.RoleContributor)
+	fanToken := createUser(t, "activity-fan", "activity-fan@example.com", models.RoleContributor)
+	adminToken := createUser(t, "activity-admin", "activity-admin@example.com", models.RoleAdmin)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", ownerToken, map[string]interface{}{
+		"title":       "Blocked crosswalk",
+		"description": "Truck parked across the crosswalk",
+		"dateTime":    "2024-08-01T09:00:00Z",
+		"roadUsages":  []string{"Pedestrian"},
+		"eventTypes":  []string{"Parking"},
+		"state":       "Ohio",
+	})
+	var report models.TrafficReport
+	decode(t, w, &report)
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]string{"status": models.StatusReviewedPass})
+	if w.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/comments", fanToken, map[string]string{"content": "Seen this too"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/reactions", fanToken, map[string]string{"reactionType": models.ReactionThumbsUp})
+	if w.Code != http.StatusOK && w.Code != http.StatusCreated {
+		t.Fatalf("react: expected success, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var comments struct {
+		Comments []models.CommentActivity `json:"comments"`
+	}
+	w = doRequest(t, http.MethodGet, "/v1/auth/me/comments", fanToken, nil)
+	decode(t, w, &comments)
+	if len(comments.Comments) != 1 || comments.Comments[0].Report.ID != report.ID || comments.Comments[0].Report.Title != report.Title {
+		t.Errorf("expected one comment linked to %s, got %+v", report.ID, comments.Comments)
+	}
+
+	var reactions struct {
+		Reactions []models.ReactionActivity `json:"reactions"`
+	}
+	w = doRequest(t, http.MethodGet, "/v1/auth/me/reactions", fanToken, nil)
+	decode(t, w, &reactions)
+	if len(reactions.Reactions) != 1 || reactions.Reactions[0].ReactionType != models.ReactionThumbsUp {
+		t.Errorf("expected one thumbs-up reaction, got %+v", reactions.Reactions)
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/auth/me/comments", ownerToken, nil)
+	decode(t, w, &comments)
+	if len(comments.Comments) != 0 {
+		t.Errorf("owner has not commented, got %d comments", len(comments.Comments))
+	}
+}
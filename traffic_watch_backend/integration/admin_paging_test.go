@@ -0,0 +1,91 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/pagination"
+)
+
+// allPages follows ?offset= through an admin report list until total is reached.
+// Lists that aren't paged report no total and are read in one request.
+func allPages(t *testing.T, path, token string) []models.TrafficReport {
+	t.Helper()
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+
+	var reports []models.TrafficReport
+	for {
+		w := doRequest(t, http.MethodGet, fmt.Sprintf("%s%slimit=%d&offset=%d", path, sep, pagination.MaxLimit, len(reports)), token, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		var page models.ReportPageResponse
+		decode(t, w, &page)
+		reports = append(reports, page.Reports...)
+		if len(page.Reports) == 0 || len(reports) >= page.Total {
+			return reports
+		}
+	}
+}
+
+func TestAdminReportList_Paging(t *testing.T) {
+	token := createUser(t, "paging-owner", "paging-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "paging-admin", "paging-admin@example.com", models.RoleAdmin)
+	for i := 0; i < 3; i++ {
+		createApprovedReport(t, token, adminToken)
+	}
+
+	page := func(path string) models.ReportPageResponse {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, path, adminToken, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		var resp models.ReportPageResponse
+		decode(t, w, &resp)
+		return resp
+	}
+
+	first := page("/v1/admin/reports?limit=2")
+	if first.Count != 2 || len(first.Reports) != 2 || first.Limit != 2 || first.Offset != 0 || first.Total < 3 {
+		t.Fatalf("first page = count %d, limit %d, offset %d, total %d", first.Count, first.Limit, first.Offset, first.Total)
+	}
+	second := page("/v1/admin/reports?limit=2&offset=2")
+	if len(second.Reports) == 0 || second.Total != first.Total {
+		t.Fatalf("second page = %d reports, total %d (first said %d)", len(second.Reports), second.Total, first.Total)
+	}
+	if findReport(second.Reports, first.Reports[0].ID) != nil || findReport(second.Reports, first.Reports[1].ID) != nil {
+		t.Error("second page repeats reports from the first")
+	}
+	if all := allPages(t, "/v1/admin/reports", adminToken); len(all) != first.Total {
+		t.Errorf("walked %d reports, total says %d", len(all), first.Total)
+	}
+
+	if resp := page("/v1/admin/reports?limit=100000"); resp.Limit != pagination.MaxLimit {
+		t.Errorf("oversized limit = %d, want clamped to %d", resp.Limit, pagination.MaxLimit)
+	}
+	if resp := page("/v1/admin/reports"); resp.Limit != pagination.DefaultLimit {
+		t.Errorf("default limit = %d, want %d", resp.Limit, pagination.DefaultLimit)
+	}
+	if resp := page(fmt.Sprintf("/v1/admin/reports?offset=%d", first.Total)); len(resp.Reports) != 0 || resp.Total != first.Total {
+		t.Errorf("page past the end = %d reports, total %d", len(resp.Reports), resp.Total)
+	}
+
+	for _, path := range []string{
+		"/v1/admin/reports?limit=0",
+		"/v1/admin/reports?offset=-1",
+		"/v1/admin/reports/review?offset=abc",
+	} {
+		if w := doRequest(t, http.MethodGet, path, adminToken, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
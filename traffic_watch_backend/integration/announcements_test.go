@@ -0,0 +1,55 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestAnnouncements_PublicShowsOnlyActive(t *testing.T) {
+	adminToken := createUser(t, "announce-admin", "announce-admin@example.com", models.RoleAdmin)
+
+	w := doRequest(t, http.MethodPost, "/v1/admin/announcements", adminToken, map[string]interface{}{
+		"title":    "Scheduled maintenance",
+		"message":  "Uploads are paused tonight",
+		"severity": models.AnnouncementWarning,
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create active: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var active models.Announcement
+	decode(t, w, &active)
+
+	w = doRequest(t, http.MethodPost, "/v1/admin/announcements", adminToken, map[string]interface{}{
+		"title":    "Policy update",
+		"message":  "New community guidelines next month",
+		"severity": models.AnnouncementInfo,
+		"startsAt": time.Now().Add(24 * time.Hour),
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create scheduled: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var public struct {
+		Announcements []models.Announcement `json:"announcements"`
+	}
+	w = doRequest(t, http.MethodGet, "/v1/public/announcements", "", nil)
+	decode(t, w, &public)
+	if len(public.Announcements) != 1 || public.Announcements[0].ID != active.ID {
+		t.Errorf("expected only the active announcement, got %+v", public.Announcements)
+	}
+
+	w = doRequest(t, http.MethodDelete, "/v1/admin/announcements/"+active.ID, adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("delete: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/public/announcements", "", nil)
+	decode(t, w, &public)
+	if len(public.Announcements) != 0 {
+		t.Errorf("expected no active announcements after delete, got %d", len(public.Announcements))
+	}
+}
@@ -0,0 +1,73 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestAPIUsage_SummarizesPublicTraffic(t *testing.T) {
+	adminToken := createUser(t, "usage-admin", "usage-admin@example.com", models.RoleAdmin)
+	userToken := createUser(t, "usage-user", "usage-user@example.com", models.RoleContributor)
+
+	for _, path := range []string{
+		"/v1/public/reports?severity=fatality&state=Ohio&limit=5",
+		"/v1/public/reports?state=Ohio",
+		"/v1/public/stats",
+		"/v1/public/reports/not-a-uuid/comments",
+	} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Origin", "https://usage-embed.example.com")
+		env.router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if err := env.apiUsage.Flush(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	if w := doRequest(t, http.MethodGet, "/v1/admin/analytics/usage", userToken, nil); w.Code != http.StatusForbidden {
+		t.Errorf("usage as a contributor: expected 403, got %d", w.Code)
+	}
+	if w := doRequest(t, http.MethodGet, "/v1/admin/analytics/usage?days=0", adminToken, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("days=0: expected 400, got %d", w.Code)
+	}
+
+	var summary models.APIUsageSummary
+	w := doRequest(t, http.MethodGet, "/v1/admin/analytics/usage?days=1", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("usage: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	decode(t, w, &summary)
+
+	count := func(counts []models.APIUsageCount, key string) *models.APIUsageCount {
+		for i := range counts {
+			if counts[i].Key == key {
+				return &counts[i]
+			}
+		}
+		return nil
+	}
+	if c := count(summary.Consumers, "origin:usage-embed.example.com"); c == nil || c.Requests != 4 {
+		t.Errorf("embed consumer = %+v", c)
+	}
+	if c := count(summary.Filters, "GET /v1/public/reports?state"); c == nil || c.Requests < 2 {
+		t.Errorf("state filter = %+v", c)
+	}
+	if c := count(summary.Filters, "GET /v1/public/reports?severity"); c == nil || c.Requests < 1 {
+		t.Errorf("severity filter = %+v", c)
+	}
+	if count(summary.Filters, "GET /v1/public/reports?limit") != nil {
+		t.Error("paging parameters counted as filters")
+	}
+	if len(summary.Days) != 1 || summary.Requests < 4 {
+		t.Errorf("days = %+v, requests = %d", summary.Days, summary.Requests)
+	}
+	// Admin routes aren't public and aren't counted
+	if count(summary.Routes, "GET /v1/admin/analytics/usage") != nil {
+		t.Error("admin route counted")
+	}
+}
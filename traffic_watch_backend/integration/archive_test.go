@@ -0,0 +1,45 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestArchive_OldReportsLeaveFeed(t *testing.T) {
+	token := createUser(t, "archive-user", "archive-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "archive-admin", "archive-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+
+	ids, err := env.storage.ArchiveReportsCreatedBefore(context.Background(), report.CreatedAt.Add(time.Second))
+	if err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	archived := false
+	for _, id := range ids {
+		archived = archived || id == report.ID
+	}
+	if !archived {
+		t.Fatalf("report %s was not archived (got %v)", report.ID, ids)
+	}
+
+	if publicFeedHas(t, report.ID) {
+		t.Error("archived report should leave the public feed")
+	}
+
+	w := doRequest(t, http.MethodGet, "/v1/public/reports/archive", "", nil)
+	var archive models.ListReportsResponse
+	decode(t, w, &archive)
+	found := findReport(archive.Reports, report.ID)
+	if found == nil {
+		t.Fatal("archived report should be listed in the archive")
+	}
+	if found.Status != models.StatusArchived {
+		t.Errorf("status = %q, want %q", found.Status, models.StatusArchived)
+	}
+}
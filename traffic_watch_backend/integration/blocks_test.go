@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestBlocking_HidesCommentsAndPreventsEngagement(t *testing.T) {
+	ownerToken := createUser(t, "block-owner", "block-owner@example.com", models.RoleContributor)
+	blockedToken := createUser(t, "block-target", "block-target@example.com", models.RoleContributor)
+	adminToken := createUser(t, "block-admin", "block-admin@example.com", models.RoleAdmin)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", ownerToken, map[string]interface{}{
+		"title":       "Reckless lane change",
+		"description": "Cut across three lanes",
+		"dateTime":    "2024-07-01T09:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Reckless"},
+		"state":       "Ohio",
+	})
+	var report models.TrafficReport
+	decode(t, w, &report)
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]string{"status": models.StatusReviewedPass})
+	if w.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Comment before the block lands
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/comments", blockedToken, map[string]string{"content": "First!"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/blocks/block-target", ownerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("block: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var comments struct {
+		Count int `json:"count"`
+	}
+	w = doRequest(t, http.MethodGet, "/v1/public/reports/"+report.ID+"/comments", ownerToken, nil)
+	decode(t, w, &comments)
+	if comments.Count != 0 {
+		t.Errorf("blocker should not see blocked user's comments, got %d", comments.Count)
+	}
+	w = doRequest(t, http.MethodGet, "/v1/public/reports/"+report.ID+"/comments", "", nil)
+	decode(t, w, &comments)
+	if comments.Count != 1 {
+		t.Errorf("other viewers should still see the comment, got %d", comments.Count)
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/comments", blockedToken, map[string]string{"content": "Again"})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("comment after block: expected 403, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/reactions", blockedToken, map[string]string{"reactionType": models.ReactionThumbsUp})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("reaction after block: expected 403, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodDelete, "/v1/blocks/block-target", ownerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unblock: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/reactions", blockedToken, map[string]string{"reactionType": models.ReactionThumbsUp})
+	if w.Code == http.StatusForbidden {
+		t.Error("reaction after unblock should be allowed")
+	}
+}
@@ -0,0 +1,39 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// Request body limits the test router is built with
+const (
+	integrationBodyLimit   = 256 * 1024
+	integrationUploadLimit = 2 * 1024 * 1024
+)
+
+func TestBodyLimits_PerRouteGroup(t *testing.T) {
+	token := createUser(t, "limits-user", "limits-user@example.com", models.RoleContributor)
+	padding := strings.Repeat("a", integrationBodyLimit)
+
+	// JSON endpoints get the small limit
+	w := doRequest(t, http.MethodPatch, "/v1/auth/me/profile", token, map[string]string{"defaultCity": padding})
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized profile update: expected 413, got %d", w.Code)
+	}
+
+	// Report creation takes media, so the same body gets past the limit to validation
+	w = doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{"title": "Too long", "description": padding})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("large report body: expected 400 from validation, got %d: %.200s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{"description": strings.Repeat(padding, 9)})
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("report body over the upload limit: expected 413, got %d", w.Code)
+	}
+}
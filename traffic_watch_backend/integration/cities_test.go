@@ -0,0 +1,62 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/geo"
+	"donzhit_me_backend/internal/models"
+)
+
+func TestCities_NormalizedOnReportsAndSuggested(t *testing.T) {
+	token := createUser(t, "cities-user", "cities-user@example.com", models.RoleContributor)
+
+	create := func(city string) models.TrafficReport {
+		t.Helper()
+		w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+			"title":       "Blocked the box",
+			"description": "Sat in the intersection through the light",
+			"dateTime":    "2024-06-01T10:00:00Z",
+			"roadUsages":  []string{"Auto"},
+			"eventTypes":  []string{"Red Light"},
+			"state":       "New York",
+			"city":        city,
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		var report models.TrafficReport
+		decode(t, w, &report)
+		return report
+	}
+
+	for _, spelling := range []string{"NYC", "new york city"} {
+		report := create(spelling)
+		stored, err := env.storage.GetReport(context.Background(), report.ID)
+		if err != nil {
+			t.Fatalf("GetReport: %v", err)
+		}
+		if stored.City != "New York" || stored.CityID != "new-york/new-york" {
+			t.Errorf("%q stored as %q (%q)", spelling, stored.City, stored.CityID)
+		}
+	}
+	if report := create("Smallville"); report.City != "Smallville" || report.CityID != "" {
+		t.Errorf("unlisted city: got %q (%q)", report.City, report.CityID)
+	}
+
+	var suggestions struct {
+		Cities []geo.City `json:"cities"`
+	}
+	w := doRequest(t, http.MethodGet, "/v1/public/cities?state=New%20York&q=bro", "", nil)
+	decode(t, w, &suggestions)
+	if len(suggestions.Cities) != 2 || suggestions.Cities[0].Name != "Brooklyn" || suggestions.Cities[1].Name != "The Bronx" {
+		t.Errorf("suggestions for bro: %+v", suggestions.Cities)
+	}
+	w = doRequest(t, http.MethodGet, "/v1/public/cities?state=Atlantis", "", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown state: expected 400, got %d", w.Code)
+	}
+}
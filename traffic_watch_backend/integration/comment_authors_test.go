@@ -0,0 +1,71 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestCommentAuthors_PublicNamesHideEmails(t *testing.T) {
+	ownerToken := createUser(t, "author-owner", "author-owner@example.com", models.RoleContributor)
+	commenterToken := createUser(t, "author-commenter", "author-commenter@example.com", models.RoleContributor)
+	adminToken := createUser(t, "author-admin", "author-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, ownerToken, adminToken)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/comments", commenterToken, map[string]string{"content": "Saw it from the sidewalk"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "author-commenter@example.com") {
+		t.Errorf("created comment exposes the author's email: %s", w.Body.String())
+	}
+
+	var comments struct {
+		Comments []models.Comment `json:"comments"`
+	}
+	getComments := func() models.Comment {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, "/v1/public/reports/"+report.ID+"/comments", "", nil)
+		if strings.Contains(w.Body.String(), "@example.com") {
+			t.Errorf("public comments expose an email: %s", w.Body.String())
+		}
+		decode(t, w, &comments)
+		if len(comments.Comments) != 1 {
+			t.Fatalf("expected one comment, got %+v", comments.Comments)
+		}
+		return comments.Comments[0]
+	}
+
+	if got, want := getComments().AuthorName, models.ContributorName("author-commenter"); got != want {
+		t.Errorf("author without a display name: got %q, want %q", got, want)
+	}
+
+	// Setting a display name renames the author's existing comments
+	w = doRequest(t, http.MethodPatch, "/v1/auth/me/profile", commenterToken, map[string]string{"displayName": "  Sidewalk Sam "})
+	if w.Code != http.StatusOK {
+		t.Fatalf("profile: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := getComments().AuthorName; got != "Sidewalk Sam" {
+		t.Errorf("author with a display name: got %q", got)
+	}
+
+	for _, name := range []string{"sam@example.com", "Contributor #0001"} {
+		w = doRequest(t, http.MethodPatch, "/v1/auth/me/profile", commenterToken, map[string]string{"displayName": name})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("display name %q: expected 400, got %d", name, w.Code)
+		}
+	}
+
+	// Clearing it goes back to the pseudonym
+	w = doRequest(t, http.MethodPatch, "/v1/auth/me/profile", commenterToken, map[string]string{"displayName": ""})
+	if w.Code != http.StatusOK {
+		t.Fatalf("clear profile: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got, want := getComments().AuthorName, models.ContributorName("author-commenter"); got != want {
+		t.Errorf("author after clearing the display name: got %q, want %q", got, want)
+	}
+}
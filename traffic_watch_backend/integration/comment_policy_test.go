@@ -0,0 +1,76 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestCommentPolicy_ThrottlesLinksAndRepeats(t *testing.T) {
+	ownerToken := createUser(t, "policy-owner", "policy-owner@example.com", models.RoleContributor)
+	newcomerToken := createUser(t, "policy-newcomer", "policy-newcomer@example.com", models.RoleContributor)
+	adminToken := createUser(t, "policy-admin", "policy-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, ownerToken, adminToken)
+	comments := "/v1/reports/" + report.ID + "/comments"
+
+	var policy models.CommentPolicy
+	w := doRequest(t, http.MethodGet, "/v1/admin/moderation/comment-policy", adminToken, nil)
+	decode(t, w, &policy)
+	if w.Code != http.StatusOK || policy.LinkAction != models.LinkActionHold || policy.UpdatedAt != nil {
+		t.Fatalf("default policy: got %d: %+v", w.Code, policy)
+	}
+
+	strict := models.UpdateCommentPolicyRequest{
+		RateLimit: 3, RateWindowMinutes: 10, LinkAction: models.LinkActionReject,
+		DuplicateLimit: 1, DuplicateWindowMinutes: 60,
+	}
+	if w := doRequest(t, http.MethodPut, "/v1/admin/moderation/comment-policy", ownerToken, strict); w.Code != http.StatusForbidden {
+		t.Errorf("update as a contributor: expected 403, got %d", w.Code)
+	}
+	invalid := strict
+	invalid.LinkAction = "delete"
+	if w := doRequest(t, http.MethodPut, "/v1/admin/moderation/comment-policy", adminToken, invalid); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown link action: expected 400, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPut, "/v1/admin/moderation/comment-policy", adminToken, strict)
+	if w.Code != http.StatusOK {
+		t.Fatalf("update policy: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	t.Cleanup(func() {
+		defaults := models.DefaultCommentPolicy()
+		doRequest(t, http.MethodPut, "/v1/admin/moderation/comment-policy", adminToken, models.UpdateCommentPolicyRequest{
+			RateLimit: defaults.RateLimit, RateWindowMinutes: defaults.RateWindowMinutes, LinkAction: defaults.LinkAction,
+			DuplicateLimit: defaults.DuplicateLimit, DuplicateWindowMinutes: defaults.DuplicateWindowMinutes,
+		})
+	})
+
+	// Links need an approved report
+	link := map[string]string{"content": "Full video at https://example.org/clip"}
+	if w := doRequest(t, http.MethodPost, comments, newcomerToken, link); w.Code != http.StatusBadRequest {
+		t.Errorf("link from a newcomer: expected 400, got %d", w.Code)
+	}
+	var comment models.Comment
+	w = doRequest(t, http.MethodPost, comments, ownerToken, link)
+	decode(t, w, &comment)
+	if w.Code != http.StatusCreated || comment.Flagged {
+		t.Errorf("link from the report owner: got %d: %+v", w.Code, comment)
+	}
+
+	// A repeat of the same comment is held for review
+	for i, wantFlagged := range []bool{false, true} {
+		w = doRequest(t, http.MethodPost, comments, newcomerToken, map[string]string{"content": "Same here"})
+		decode(t, w, &comment)
+		if w.Code != http.StatusCreated || comment.Flagged != wantFlagged {
+			t.Errorf("comment %d: got %d, flagged %v; want flagged %v", i+1, w.Code, comment.Flagged, wantFlagged)
+		}
+	}
+
+	// The refused link counted towards the rate limit too
+	w = doRequest(t, http.MethodPost, comments, newcomerToken, map[string]string{"content": "One more"})
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Errorf("over the rate limit: got %d, Retry-After %q", w.Code, w.Header().Get("Retry-After"))
+	}
+}
@@ -0,0 +1,96 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// createApprovedReport submits a report as token and approves it as adminToken
+func createApprovedReport(t *testing.T, token, adminToken string) models.TrafficReport {
+	t.Helper()
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Ran the red",
+		"description": "Ran the light at Elm St",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var report models.TrafficReport
+	decode(t, w, &report)
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]string{"status": models.StatusReviewedPass})
+	if w.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	return report
+}
+
+func publicFeedHas(t *testing.T, reportID string) bool {
+	t.Helper()
+	w := doRequest(t, http.MethodGet, "/v1/public/reports", "", nil)
+	var feed models.ListReportsResponse
+	decode(t, w, &feed)
+	return findReport(feed.Reports, reportID) != nil
+}
+
+func TestDeactivation_SelfServiceHidesContent(t *testing.T) {
+	token := createUser(t, "deactivate-self", "deactivate-self@example.com", models.RoleContributor)
+	adminToken := createUser(t, "deactivate-admin", "deactivate-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+
+	w := doRequest(t, http.MethodPost, "/v1/auth/me/deactivate", token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("deactivate: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if publicFeedHas(t, report.ID) {
+		t.Error("deactivated user's report should be hidden from the public feed")
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/auth/me", token, nil)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("deactivated token: expected 403, got %d", w.Code)
+	}
+}
+
+func TestDeactivation_AdminSuspendAndReinstate(t *testing.T) {
+	token := createUser(t, "suspend-user", "suspend-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "suspend-admin", "suspend-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+
+	w := doRequest(t, http.MethodPut, "/v1/admin/users/suspend-user/suspend", token, map[string]bool{"suspended": true})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("suspend as contributor: expected 403, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodPut, "/v1/admin/users/suspend-user/suspend", adminToken, map[string]bool{"suspended": true})
+	if w.Code != http.StatusOK {
+		t.Fatalf("suspend: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/reports", token, nil)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("suspended token: expected 403, got %d", w.Code)
+	}
+	if publicFeedHas(t, report.ID) {
+		t.Error("suspended user's report should be hidden from the public feed")
+	}
+
+	w = doRequest(t, http.MethodPut, "/v1/admin/users/suspend-user/suspend", adminToken, map[string]bool{"suspended": false})
+	if w.Code != http.StatusOK {
+		t.Fatalf("reinstate: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !publicFeedHas(t, report.ID) {
+		t.Error("reinstated user's report should be back in the public feed")
+	}
+
+	w = doRequest(t, http.MethodPut, "/v1/admin/users/missing-user/suspend", adminToken, map[string]bool{"suspended": true})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("unknown user: expected 404, got %d", w.Code)
+	}
+}
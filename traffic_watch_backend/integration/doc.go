@@ -0,0 +1,6 @@
+// Package integration holds end-to-end tests that run the real router against
+// a disposable Postgres started with testcontainers. They need Docker and are
+// excluded from the default test run:
+//
+//	go test -tags integration ./integration/...
+package integration
@@ -0,0 +1,93 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestDrafts_RemindersAndSubmission(t *testing.T) {
+	ctx := context.Background()
+	token := createUser(t, "draft-user", "draft-user@example.com", models.RoleContributor)
+	otherToken := createUser(t, "draft-other", "draft-other@example.com", models.RoleContributor)
+
+	w := doRequest(t, http.MethodPost, "/v1/drafts", token, map[string]interface{}{
+		"title":      "Red light at Main St",
+		"eventTypes": []string{"Red Light"},
+		"state":      "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create draft: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var draft models.ReportDraft
+	decode(t, w, &draft)
+
+	// Drafts are private to their owner
+	w = doRequest(t, http.MethodPut, "/v1/drafts/"+draft.ID, otherToken, map[string]interface{}{"title": "Mine now"})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("other user's draft: expected 404, got %d", w.Code)
+	}
+
+	due := func() bool {
+		t.Helper()
+		drafts, err := env.storage.ListDraftsToRemind(ctx, time.Now().Add(time.Minute))
+		if err != nil {
+			t.Fatalf("ListDraftsToRemind: %v", err)
+		}
+		for _, d := range drafts {
+			if d.ID == draft.ID {
+				return true
+			}
+		}
+		return false
+	}
+	if !due() {
+		t.Fatal("expected the draft due for a reminder")
+	}
+	if err := env.storage.MarkDraftReminded(ctx, draft.ID, time.Now()); err != nil {
+		t.Fatalf("MarkDraftReminded: %v", err)
+	}
+	if due() {
+		t.Error("a reminded draft should not be due again")
+	}
+
+	// Editing the draft re-arms its reminder
+	w = doRequest(t, http.MethodPut, "/v1/drafts/"+draft.ID, token, map[string]interface{}{
+		"title":      "Red light at Main St",
+		"eventTypes": []string{"Red Light"},
+		"state":      "Ohio",
+		"city":       "Columbus",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("update draft: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !due() {
+		t.Error("expected an edited draft due for a reminder again")
+	}
+
+	// Submitting a report written in the draft removes it
+	w = doRequest(t, http.MethodPost, "/v1/reports?draftId="+draft.ID, token, map[string]interface{}{
+		"title":       "Red light at Main St",
+		"description": "A car ran the red light",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create report: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/drafts", token, nil)
+	var list struct {
+		Drafts []models.ReportDraft `json:"drafts"`
+	}
+	decode(t, w, &list)
+	if len(list.Drafts) != 0 {
+		t.Errorf("expected the submitted draft removed, got %+v", list.Drafts)
+	}
+}
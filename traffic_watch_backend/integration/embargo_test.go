@@ -0,0 +1,52 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestEmbargo_ScheduledReportStaysOutOfFeed(t *testing.T) {
+	token := createUser(t, "embargo-user", "embargo-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "embargo-admin", "embargo-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+	if !publicFeedHas(t, report.ID) {
+		t.Fatal("approved report should be in the feed")
+	}
+
+	// Re-approving with a future publish time takes it out until then
+	path := "/v1/admin/reports/" + report.ID + "/review"
+	w := doRequest(t, http.MethodPost, path, adminToken, map[string]interface{}{
+		"status":    models.StatusReviewedPass,
+		"publishAt": time.Now().Add(time.Hour),
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("approve with publishAt: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if publicFeedHas(t, report.ID) {
+		t.Error("embargoed report should not be in the feed")
+	}
+
+	// publishAt only applies to approvals
+	w = doRequest(t, http.MethodPost, path, adminToken, map[string]interface{}{
+		"status":    models.StatusReviewedFail,
+		"reason":    "duplicate",
+		"publishAt": time.Now().Add(time.Hour),
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("reject with publishAt: expected 400, got %d", w.Code)
+	}
+
+	// Approving without publishAt lifts the embargo
+	w = doRequest(t, http.MethodPost, path, adminToken, map[string]string{"status": models.StatusReviewedPass})
+	if w.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !publicFeedHas(t, report.ID) {
+		t.Error("report should be back in the feed once the embargo is cleared")
+	}
+}
@@ -0,0 +1,57 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// TestEngagementRoutes_AuthMatrix checks the engagement routes are registered
+// with optional auth on reads and required auth on writes
+func TestEngagementRoutes_AuthMatrix(t *testing.T) {
+	ownerToken := createUser(t, "routes-owner", "routes-owner@example.com", models.RoleContributor)
+	fanToken := createUser(t, "routes-fan", "routes-fan@example.com", models.RoleContributor)
+	adminToken := createUser(t, "routes-admin", "routes-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, ownerToken, adminToken)
+	base := "/v1/reports/" + report.ID
+
+	routes := []struct {
+		method, path string
+		body         interface{}
+		anonymous    int // Status without a token
+		signedIn     int // Status with the fan's token
+	}{
+		{http.MethodPost, base + "/reactions", map[string]string{"reactionType": models.ReactionThumbsUp}, http.StatusUnauthorized, http.StatusCreated},
+		{http.MethodPost, base + "/comments", map[string]string{"content": "Saw it too"}, http.StatusUnauthorized, http.StatusCreated},
+		{http.MethodGet, "/v1/public/reports/" + report.ID + "/engagement", nil, http.StatusOK, http.StatusOK},
+		{http.MethodGet, "/v1/public/reports/" + report.ID + "/comments", nil, http.StatusOK, http.StatusOK},
+		{http.MethodPost, "/v1/public/reports/engagement", map[string][]string{"reportIds": {report.ID}}, http.StatusOK, http.StatusOK},
+	}
+	for _, r := range routes {
+		if w := doRequest(t, r.method, r.path, "", r.body); w.Code != r.anonymous {
+			t.Errorf("%s %s anonymously: expected %d, got %d: %s", r.method, r.path, r.anonymous, w.Code, w.Body.String())
+		}
+		if w := doRequest(t, r.method, r.path, fanToken, r.body); w.Code != r.signedIn {
+			t.Errorf("%s %s signed in: expected %d, got %d: %s", r.method, r.path, r.signedIn, w.Code, w.Body.String())
+		}
+	}
+
+	// Optional auth on reads: the signed-in fan sees their own reaction, anonymous callers don't
+	var engagement models.ReportEngagement
+	decode(t, doRequest(t, http.MethodGet, "/v1/public/reports/"+report.ID+"/engagement", fanToken, nil), &engagement)
+	if len(engagement.UserReactions) != 1 || engagement.UserReactions[0] != models.ReactionThumbsUp || engagement.CommentCount != 1 {
+		t.Errorf("signed-in engagement = %+v", engagement)
+	}
+	decode(t, doRequest(t, http.MethodGet, "/v1/public/reports/"+report.ID+"/engagement", "", nil), &engagement)
+	if len(engagement.UserReactions) != 0 {
+		t.Errorf("anonymous engagement has user reactions: %+v", engagement.UserReactions)
+	}
+
+	// An invalid token on an optional-auth read is treated as anonymous, not rejected
+	if w := doRequest(t, http.MethodGet, "/v1/public/reports/"+report.ID+"/comments", "not-a-token", nil); w.Code != http.StatusOK {
+		t.Errorf("comments with a bad token: expected 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,54 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestEngagementSummary_OnReportLists(t *testing.T) {
+	ownerToken := createUser(t, "summary-owner", "summary-owner@example.com", models.RoleContributor)
+	fanToken := createUser(t, "summary-fan", "summary-fan@example.com", models.RoleContributor)
+	adminToken := createUser(t, "summary-admin", "summary-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, ownerToken, adminToken)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/reactions", fanToken, map[string]string{"reactionType": models.ReactionThumbsUp})
+	if w.Code != http.StatusOK && w.Code != http.StatusCreated {
+		t.Fatalf("react: got %d: %s", w.Code, w.Body.String())
+	}
+	long := strings.Repeat("very ", 40) + "long"
+	for _, content := range []string{"First!", long} {
+		w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/comments", fanToken, map[string]string{"content": content})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		time.Sleep(10 * time.Millisecond) // Keep the comments' creation times apart
+	}
+
+	check := func(name, path, token string) {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, path, token, nil)
+		var list models.ListReportsResponse
+		decode(t, w, &list)
+		found := findReport(list.Reports, report.ID)
+		if found == nil || found.Engagement == nil {
+			t.Fatalf("%s: report or its engagement missing: %+v", name, found)
+		}
+		e := found.Engagement
+		if e.CommentCount != 2 || len(e.ReactionCounts) != 1 || e.ReactionCounts[0].Count != 1 {
+			t.Errorf("%s: engagement = %+v", name, e)
+		}
+		if e.LatestComment == nil || !strings.HasPrefix(long, strings.TrimSuffix(e.LatestComment.Snippet, "…")) ||
+			len([]rune(e.LatestComment.Snippet)) != models.CommentSnippetLength {
+			t.Errorf("%s: latest comment = %+v", name, e.LatestComment)
+		}
+	}
+	check("public feed", "/v1/public/reports", "")
+	check("personalized feed", "/v1/reports/feed", fanToken)
+	check("own reports", "/v1/reports", ownerToken)
+}
@@ -0,0 +1,51 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestHotSort_EngagedReportRanksHigher(t *testing.T) {
+	token := createUser(t, "hot-user", "hot-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "hot-admin", "hot-admin@example.com", models.RoleAdmin)
+	engaged := createApprovedReport(t, token, adminToken)
+	quiet := createApprovedReport(t, token, adminToken)
+
+	for i := 0; i < 3; i++ {
+		w := doRequest(t, http.MethodPost, "/v1/reports/"+engaged.ID+"/comments", adminToken, map[string]string{"content": "Saw this too"})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	if err := env.storage.RefreshHotScores(context.Background()); err != nil {
+		t.Fatalf("refresh hot scores: %v", err)
+	}
+
+	w := doRequest(t, http.MethodGet, "/v1/public/reports?sort=hot", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("hot feed: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var feed models.ListReportsResponse
+	decode(t, w, &feed)
+	engagedAt, quietAt := -1, -1
+	for i, r := range feed.Reports {
+		switch r.ID {
+		case engaged.ID:
+			engagedAt = i
+		case quiet.ID:
+			quietAt = i
+		}
+	}
+	if engagedAt < 0 || quietAt < 0 || engagedAt > quietAt {
+		t.Errorf("engaged report (at %d) should rank above the newer quiet one (at %d)", engagedAt, quietAt)
+	}
+
+	if w := doRequest(t, http.MethodGet, "/v1/public/reports?sort=random", "", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown sort: expected 400, got %d", w.Code)
+	}
+}
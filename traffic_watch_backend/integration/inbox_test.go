@@ -0,0 +1,71 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+type inboxResponse struct {
+	Notifications []models.Notification `json:"notifications"`
+	UnreadCount   int                   `json:"unreadCount"`
+}
+
+func TestInbox_CommentNotifiesOwnerAndMarkRead(t *testing.T) {
+	ownerToken := createUser(t, "inbox-owner", "inbox-owner@example.com", models.RoleContributor)
+	commenterToken := createUser(t, "inbox-commenter", "inbox-commenter@example.com", models.RoleContributor)
+	adminToken := createUser(t, "inbox-admin", "inbox-admin@example.com", models.RoleAdmin)
+
+	// Email/push off: the inbox still gets the entry
+	w := doRequest(t, http.MethodPatch, "/v1/auth/me/notifications", ownerToken, map[string]bool{"pushOnComment": false})
+	if w.Code != http.StatusOK {
+		t.Fatalf("prefs: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports", ownerToken, map[string]interface{}{
+		"title":       "Illegal U-turn",
+		"description": "U-turn across the median",
+		"dateTime":    "2024-09-01T09:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Reckless"},
+		"state":       "Ohio",
+	})
+	var report models.TrafficReport
+	decode(t, w, &report)
+	doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]string{"status": models.StatusReviewedPass})
+
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/comments", commenterToken, map[string]string{"content": "Saw it happen"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Notifications are sent by the outbox relay
+	if err := env.relay.Run(context.Background()); err != nil {
+		t.Fatalf("relay: %v", err)
+	}
+	var inbox inboxResponse
+	w = doRequest(t, http.MethodGet, "/v1/auth/me/inbox", ownerToken, nil)
+	decode(t, w, &inbox)
+	if inbox.UnreadCount != 1 || len(inbox.Notifications) != 1 || inbox.Notifications[0].ReportID != report.ID {
+		t.Fatalf("expected one unread comment notification, got %+v", inbox)
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/auth/me/inbox/"+inbox.Notifications[0].ID+"/read", commenterToken, nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("marking another user's notification: expected 404, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, "/v1/auth/me/inbox/"+inbox.Notifications[0].ID+"/read", ownerToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("mark read: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/auth/me/inbox?unread=true", ownerToken, nil)
+	decode(t, w, &inbox)
+	if inbox.UnreadCount != 0 || len(inbox.Notifications) != 0 {
+		t.Errorf("expected no unread notifications, got %+v", inbox)
+	}
+}
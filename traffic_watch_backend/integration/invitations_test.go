@@ -0,0 +1,131 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestInvitations_AcceptGrantsRoleOnce(t *testing.T) {
+	adminToken := createUser(t, "invite-admin", "invite-admin@example.com", models.RoleAdmin)
+	inviteeToken := createUser(t, "invitee", "invitee@example.com", models.RoleContributor)
+	otherToken := createUser(t, "invite-other", "invite-other@example.com", models.RoleContributor)
+
+	w := doRequest(t, http.MethodPost, "/v1/admin/invitations", adminToken, map[string]string{"email": "Invitee@example.com", "role": "admin"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("invite: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Invitation models.RoleInvitation `json:"invitation"`
+		AcceptURL  string                `json:"acceptUrl"`
+	}
+	decode(t, w, &created)
+	link, err := url.Parse(created.AcceptURL)
+	if err != nil {
+		t.Fatalf("parse acceptUrl: %v", err)
+	}
+	token := link.Query().Get("token")
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/invitations", adminToken, nil)
+	var pending struct {
+		Count int `json:"count"`
+	}
+	decode(t, w, &pending)
+	if pending.Count == 0 {
+		t.Error("new invitation should be listed as pending")
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/auth/invitations/accept", otherToken, map[string]string{"token": token})
+	if w.Code != http.StatusForbidden {
+		t.Errorf("accept as someone else: expected 403, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, "/v1/auth/invitations/accept", inviteeToken, map[string]string{"token": token + "x"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("tampered token: expected 400, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/auth/invitations/accept", inviteeToken, map[string]string{"token": token})
+	if w.Code != http.StatusOK {
+		t.Fatalf("accept: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/admin/reports", inviteeToken, nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("invitee should now be admin, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, "/v1/auth/invitations/accept", inviteeToken, map[string]string{"token": token})
+	if w.Code != http.StatusConflict {
+		t.Errorf("second accept: expected 409, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/audit", adminToken, nil)
+	var audit struct {
+		Entries []models.AuditEntry `json:"entries"`
+	}
+	decode(t, w, &audit)
+	actions := map[string]bool{}
+	for _, e := range audit.Entries {
+		if e.TargetID == created.Invitation.ID {
+			actions[e.Action] = true
+		}
+	}
+	if !actions[models.AuditInvitationCreated] || !actions[models.AuditInvitationAccepted] {
+		t.Errorf("expected created and accepted audit entries, got %v", actions)
+	}
+}
+
+// invite creates an invitation as adminToken and returns its token
+func invite(t *testing.T, adminToken, email string, role models.UserRole) string {
+	t.Helper()
+	w := doRequest(t, http.MethodPost, "/v1/admin/invitations", adminToken, map[string]string{"email": email, "role": string(role)})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("invite %s as %s: expected 201, got %d: %s", email, role, w.Code, w.Body.String())
+	}
+	var created struct {
+		AcceptURL string `json:"acceptUrl"`
+	}
+	decode(t, w, &created)
+	link, err := url.Parse(created.AcceptURL)
+	if err != nil {
+		t.Fatalf("parse acceptUrl: %v", err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestInvitations_NeverLowerRole(t *testing.T) {
+	adminToken := createUser(t, "downgrade-admin", "downgrade-admin@example.com", models.RoleAdmin)
+	token := invite(t, adminToken, "downgrade-admin@example.com", models.RoleContributor)
+
+	w := doRequest(t, http.MethodPost, "/v1/auth/invitations/accept", adminToken, map[string]string{"token": token})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("admin accepting a contributor invitation: expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/admin/reports", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("admin should still be admin, got %d", w.Code)
+	}
+}
+
+func TestInvitations_ModeratorReviewsButIsNotAdmin(t *testing.T) {
+	adminToken := createUser(t, "mod-admin", "mod-admin@example.com", models.RoleAdmin)
+	modToken := createUser(t, "moderator", "moderator@example.com", models.RoleContributor)
+	token := invite(t, adminToken, "moderator@example.com", models.RoleModerator)
+
+	w := doRequest(t, http.MethodPost, "/v1/auth/invitations/accept", modToken, map[string]string{"token": token})
+	if w.Code != http.StatusOK {
+		t.Fatalf("accept: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, path := range []string{"/v1/admin/reports/review", "/v1/admin/moderation/comments"} {
+		if w := doRequest(t, http.MethodGet, path, modToken, nil); w.Code != http.StatusOK {
+			t.Errorf("moderator GET %s: expected 200, got %d", path, w.Code)
+		}
+	}
+	for _, path := range []string{"/v1/admin/reports", "/v1/admin/invitations"} {
+		if w := doRequest(t, http.MethodGet, path, modToken, nil); w.Code != http.StatusForbidden {
+			t.Errorf("moderator GET %s: expected 403, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestCreateReport_LegacySingularFields(t *testing.T) {
+	token := createUser(t, "legacy-user", "legacy-user@example.com", models.RoleContributor)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Ran the light",
+		"description": "Sent by an old client",
+		"dateTime":    "2024-05-01T08:00:00Z",
+		"roadUsage":   "Auto",
+		"eventType":   "Speeding",
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("expected Deprecation: true, got %q", got)
+	}
+	if got := w.Header().Values("Warning"); len(got) != 2 {
+		t.Errorf("expected a Warning per deprecated field, got %q", got)
+	}
+	var created struct {
+		RoadUsages []string `json:"roadUsages"`
+		EventTypes []string `json:"eventTypes"`
+	}
+	decode(t, w, &created)
+	if !slices.Equal(created.RoadUsages, []string{"Auto"}) || !slices.Equal(created.EventTypes, []string{"Speeding"}) {
+		t.Errorf("expected the singular fields folded in, got %v / %v", created.RoadUsages, created.EventTypes)
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Ran the light again",
+		"description": "Sent by a current client",
+		"dateTime":    "2024-05-02T08:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Speeding"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Deprecation"); got != "" {
+		t.Errorf("current fields should not be flagged deprecated, got %q", got)
+	}
+}
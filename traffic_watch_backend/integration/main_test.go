@@ -0,0 +1,190 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+
+	"donzhit_me_backend/internal/analytics"
+	"donzhit_me_backend/internal/api"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/flags"
+	"donzhit_me_backend/internal/handlers"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/notify"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+const postgresImage = "postgres:16-alpine"
+
+// deferredMigrations lists migrations whose file number sorts before a
+// migration they depend on; each runs right after the named dependency.
+var deferredMigrations = map[string]string{
+	"004_add_reaction_history.sql": "007_add_reactions_comments.sql",
+}
+
+// env is the shared test environment created once in TestMain
+var env struct {
+	storage    *storage.PostgresClient
+	jwtService *auth.JWTService
+	router     *gin.Engine
+	relay      *events.Relay
+	apiUsage   *analytics.Recorder
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	ctx := context.Background()
+	gin.SetMode(gin.TestMode)
+
+	container, err := postgres.Run(ctx, postgresImage,
+		postgres.WithDatabase("donzhit"),
+		postgres.WithUsername("donzhit_app"),
+		postgres.WithPassword("integration"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second)),
+	)
+	if err != nil {
+		log.Printf("Failed to start Postgres container: %v", err)
+		return 1
+	}
+	defer func() {
+		if err := container.Terminate(ctx); err != nil {
+			log.Printf("Failed to terminate Postgres container: %v", err)
+		}
+	}()
+
+	connString, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		log.Printf("Failed to get connection string: %v", err)
+		return 1
+	}
+
+	if err := applyMigrations(ctx, connString, "../migrations"); err != nil {
+		log.Printf("Failed to apply migrations: %v", err)
+		return 1
+	}
+
+	if err := validation.RegisterCustomValidators(); err != nil {
+		log.Printf("Failed to register validators: %v", err)
+		return 1
+	}
+
+	env.storage, err = storage.NewPostgresClientFromConnString(ctx, connString, storage.DefaultPoolConfig())
+	if err != nil {
+		log.Printf("Failed to create storage client: %v", err)
+		return 1
+	}
+	defer env.storage.Close()
+
+	if err := env.storage.ValidateSchema(ctx); err != nil {
+		log.Printf("Schema validation failed after migrations: %v", err)
+		return 1
+	}
+
+	// GCS and YouTube are left unset: these tests cover JSON report flows, not uploads
+	env.jwtService = auth.NewJWTService("integration-test-secret", "donzhit.me")
+	iapValidator := auth.NewIAPValidator("", true)
+	// Notifications go to the inbox only; no email or push senders
+	reportsHandler := handlers.NewReportsHandler(env.storage, nil, nil)
+	// Review outcomes update trust levels as in the server
+	eventBus := events.NewBus()
+	eventBus.Subscribe(reportsHandler.UpdateTrustOnReview)
+	reportsHandler.SetEventBus(eventBus)
+	notifier := notify.NewNotifier(env.storage)
+	env.relay = events.NewRelay(env.storage, 5*time.Second, notifier)
+	authHandler := handlers.NewAuthHandler(env.storage, iapValidator, env.jwtService)
+	authHandler.SetInvitations(auth.NewTokenSigner("integration-test-secret", auth.PurposeRoleInvitation), notifier, "http://localhost/invitations/accept")
+	env.apiUsage = analytics.NewRecorder(env.storage)
+	env.router = api.NewRouter(api.Dependencies{
+		Storage:        env.storage,
+		JWTService:     env.jwtService,
+		IAPValidator:   iapValidator,
+		HealthHandler:  handlers.NewHealthHandler("integration"),
+		ReportsHandler: reportsHandler,
+		AuthHandler:    authHandler,
+		Announcements:  handlers.NewAnnouncementsHandler(env.storage),
+		FlagsHandler:   handlers.NewFlagsHandler(env.storage, flags.NewSet(nil)),
+		APIUsage:       env.apiUsage,
+		BodyLimits:     api.BodyLimits{Default: integrationBodyLimit, Media: integrationUploadLimit},
+	})
+
+	return m.Run()
+}
+
+// applyMigrations runs every migration file in order using the simple query protocol
+func applyMigrations(ctx context.Context, connString, dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+
+	var ordered []string
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if _, deferred := deferredMigrations[name]; deferred {
+			continue
+		}
+		ordered = append(ordered, path)
+		for dependent, dependency := range deferredMigrations {
+			if dependency == name {
+				ordered = append(ordered, filepath.Join(dir, dependent))
+			}
+		}
+	}
+
+	conn, err := pgx.Connect(ctx, connString)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+
+	for _, path := range ordered {
+		sql, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if _, err := conn.PgConn().Exec(ctx, string(sql)).ReadAll(); err != nil {
+			return fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+	}
+	return nil
+}
+
+// createUser stores a user with the given role and returns a valid bearer token for them
+func createUser(t *testing.T, id, email string, role models.UserRole) string {
+	t.Helper()
+	ctx := context.Background()
+
+	user := &models.User{ID: id, Email: email, Role: role}
+	token, refreshToken, _, err := env.jwtService.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	user.JWTRefreshToken = refreshToken
+	if err := env.storage.CreateOrUpdateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	return token
+}
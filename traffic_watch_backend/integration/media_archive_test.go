@@ -0,0 +1,101 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/handlers"
+	"donzhit_me_backend/internal/models"
+)
+
+func TestMediaArchive_ArchivedMediaStaysReadable(t *testing.T) {
+	token := createUser(t, "media-archive-user", "media-archive-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "media-archive-admin", "media-archive-admin@example.com", models.RoleAdmin)
+	ctx := context.Background()
+
+	if _, err := env.storage.EnsureMediaPartitions(ctx, time.Now().AddDate(0, handlers.MediaPartitionsAhead, 0)); err != nil {
+		t.Fatalf("ensure partitions: %v", err)
+	}
+	// Running again finds them all in place
+	if created, err := env.storage.EnsureMediaPartitions(ctx, time.Now().AddDate(0, handlers.MediaPartitionsAhead, 0)); err != nil || len(created) != 0 {
+		t.Fatalf("second ensure: created %v, err %v", created, err)
+	}
+
+	report := createApprovedReport(t, token, adminToken)
+	mediaID := uuid.New().String()
+	if err := env.storage.AddMediaFileToReport(ctx, report.ID, models.MediaFile{
+		ID:          mediaID,
+		FileName:    "dashcam.jpg",
+		ContentType: "image/jpeg",
+		Size:        2048,
+		UploadedAt:  time.Now(),
+	}); err != nil {
+		t.Fatalf("attach media: %v", err)
+	}
+	if _, err := env.storage.ArchiveReportsCreatedBefore(ctx, report.CreatedAt.Add(time.Second)); err != nil {
+		t.Fatalf("archive reports: %v", err)
+	}
+
+	total := 0
+	for {
+		moved, err := env.storage.ArchiveMedia(ctx, handlers.MediaArchiveBatchSize)
+		if err != nil {
+			t.Fatalf("archive media: %v", err)
+		}
+		total += moved
+		if moved == 0 {
+			break
+		}
+	}
+	if total == 0 {
+		t.Fatal("expected media of the archived report to move")
+	}
+
+	hasMedia := func(reportID string) bool {
+		t.Helper()
+		got, err := env.storage.GetReport(ctx, reportID)
+		if err != nil {
+			t.Fatalf("get report: %v", err)
+		}
+		for _, mf := range got.MediaFiles {
+			if mf.ID == mediaID {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Still returned with the report, in the public archive too
+	if !hasMedia(report.ID) {
+		t.Error("archived media missing from the report")
+	}
+	w := doRequest(t, http.MethodGet, "/v1/public/reports/archive", "", nil)
+	var archive models.ListReportsResponse
+	decode(t, w, &archive)
+	if r := findReport(archive.Reports, report.ID); r == nil || len(r.MediaFiles) != 1 {
+		t.Errorf("public archive entry = %+v, want the report with its media", r)
+	}
+
+	// OCR purges reach archived media
+	if err := env.storage.SetMediaOCR(ctx, report.ID, mediaID, &models.MediaOCR{Status: models.OCRPurged, ProcessedAt: time.Now()}); err != nil {
+		t.Errorf("purge OCR of archived media: %v", err)
+	}
+
+	// Merging the archived report brings its media back to the canonical report
+	canonical := createApprovedReport(t, token, adminToken)
+	if err := env.storage.MergeReports(ctx, canonical.ID, report.ID, "media-archive-admin@example.com"); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	if !hasMedia(canonical.ID) {
+		t.Error("archived media did not move to the canonical report")
+	}
+	if hasMedia(report.ID) {
+		t.Error("merged duplicate still lists the moved media")
+	}
+}
@@ -0,0 +1,95 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestMediaFailures_ListAndRetry(t *testing.T) {
+	token := createUser(t, "media-owner", "media-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "media-admin", "media-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+	ctx := context.Background()
+
+	record := func(job string) string {
+		t.Helper()
+		failure := &models.MediaFailure{
+			ID:        uuid.New().String(),
+			Job:       job,
+			ReportID:  report.ID,
+			MediaID:   uuid.New().String(),
+			FileName:  "dashcam.mp4",
+			Error:     "quota exceeded",
+			CreatedAt: time.Now(),
+		}
+		if err := env.storage.RecordMediaFailure(ctx, failure); err != nil {
+			t.Fatalf("record failure: %v", err)
+		}
+		return failure.ID
+	}
+	youtubeID := record(models.MediaJobYouTubeUpload)
+	gcsID := record(models.MediaJobGCSUpload)
+	resolvedID := record(models.MediaJobYouTubeUpload)
+	if err := env.storage.RecordMediaRetry(ctx, resolvedID, nil); err != nil {
+		t.Fatalf("resolve failure: %v", err)
+	}
+
+	list := func(path string) map[string]models.MediaFailure {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, path, adminToken, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		var resp struct {
+			Failures []models.MediaFailure `json:"failures"`
+		}
+		decode(t, w, &resp)
+		byID := make(map[string]models.MediaFailure, len(resp.Failures))
+		for _, f := range resp.Failures {
+			byID[f.ID] = f
+		}
+		return byID
+	}
+
+	open := list("/v1/admin/media/failures")
+	if f, ok := open[youtubeID]; !ok || !f.Retryable || f.Error != "quota exceeded" || f.Attempts != 1 {
+		t.Errorf("youtube failure = %+v, present %v", f, ok)
+	}
+	if f, ok := open[gcsID]; !ok || f.Retryable {
+		t.Errorf("gcs failure = %+v, present %v", f, ok)
+	}
+	if _, ok := open[resolvedID]; ok {
+		t.Error("resolved failure listed without includeResolved")
+	}
+	if f, ok := list("/v1/admin/media/failures?includeResolved=true")[resolvedID]; !ok || f.ResolvedAt == nil || f.Attempts != 2 {
+		t.Errorf("resolved failure = %+v, present %v", f, ok)
+	}
+
+	retry := func(id string) int {
+		return doRequest(t, http.MethodPost, "/v1/admin/media/failures/"+id+"/retry", adminToken, nil).Code
+	}
+	if code := retry(gcsID); code != http.StatusConflict {
+		t.Errorf("retry gcs failure: expected 409, got %d", code)
+	}
+	if code := retry(resolvedID); code != http.StatusConflict {
+		t.Errorf("retry resolved failure: expected 409, got %d", code)
+	}
+	if code := retry(youtubeID); code != http.StatusServiceUnavailable {
+		t.Errorf("retry without YouTube configured: expected 503, got %d", code)
+	}
+	if code := retry(uuid.New().String()); code != http.StatusNotFound {
+		t.Errorf("retry unknown failure: expected 404, got %d", code)
+	}
+
+	if w := doRequest(t, http.MethodGet, "/v1/admin/media/failures", token, nil); w.Code != http.StatusForbidden {
+		t.Errorf("non-admin list: expected 403, got %d", w.Code)
+	}
+}
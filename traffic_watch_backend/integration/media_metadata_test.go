@@ -0,0 +1,74 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/metadata"
+	"donzhit_me_backend/internal/models"
+)
+
+func TestMediaMetadata_AdminOnly(t *testing.T) {
+	token := createUser(t, "meta-user", "meta-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "meta-admin", "meta-admin@example.com", models.RoleAdmin)
+
+	report := createApprovedReport(t, token, adminToken)
+	mediaID := uuid.New().String()
+	err := env.storage.AddMediaFileToReport(context.Background(), report.ID, models.MediaFile{
+		ID:          mediaID,
+		FileName:    "front.jpg",
+		ContentType: "image/jpeg",
+		Size:        1024,
+		UploadedAt:  time.Now(),
+		Metadata: models.MediaMetadata{
+			"gps_latitude":       41.5,
+			"gps_longitude":      -81.7,
+			"make":               "Apple",
+			"date_time_original": "2024-06-01T10:00:00",
+		},
+	})
+	if err != nil {
+		t.Fatalf("attach media: %v", err)
+	}
+
+	// The report itself never carries metadata, not even for its owner
+	w := doRequest(t, http.MethodGet, "/v1/reports/"+report.ID, token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get report: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "gps_latitude") {
+		t.Errorf("report JSON exposes media metadata: %s", w.Body.String())
+	}
+
+	path := "/v1/admin/reports/" + report.ID + "/media/" + mediaID + "/metadata"
+	if w := doRequest(t, http.MethodGet, path, token, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("contributor: expected 403, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodGet, path, adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("admin: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Summary metadata.Summary `json:"summary"`
+	}
+	decode(t, w, &resp)
+	if resp.Summary.GPS == nil || resp.Summary.GPS.Latitude != 41.5 {
+		t.Errorf("expected GPS in summary, got %+v", resp.Summary.GPS)
+	}
+	if resp.Summary.Device.Make != "Apple" {
+		t.Errorf("expected device make Apple, got %q", resp.Summary.Device.Make)
+	}
+
+	missing := "/v1/admin/reports/" + report.ID + "/media/" + uuid.New().String() + "/metadata"
+	if w := doRequest(t, http.MethodGet, missing, adminToken, nil); w.Code != http.StatusNotFound {
+		t.Errorf("unknown media: expected 404, got %d", w.Code)
+	}
+}
@@ -0,0 +1,104 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestMediaState_PendingMediaHidesReport(t *testing.T) {
+	token := createUser(t, "quarantine-owner", "quarantine-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "quarantine-admin", "quarantine-admin@example.com", models.RoleAdmin)
+	ctx := context.Background()
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Video still uploading",
+		"description": "Blocked the bike lane",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Speeding"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var report models.TrafficReport
+	decode(t, w, &report)
+
+	media := models.MediaFile{
+		ID:          uuid.New().String(),
+		FileName:    "dashcam.mp4",
+		ContentType: "video/mp4",
+		Size:        1024,
+		UploadedAt:  time.Now(),
+		State:       models.MediaProcessing,
+	}
+	if err := env.storage.AddMediaFileToReport(ctx, report.ID, media); err != nil {
+		t.Fatalf("attach media: %v", err)
+	}
+
+	inReviewQueue := func() bool {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, "/v1/admin/reports/review", adminToken, nil)
+		var resp models.ListReportsResponse
+		decode(t, w, &resp)
+		return findReport(resp.Reports, report.ID) != nil
+	}
+
+	if inReviewQueue() {
+		t.Error("report with processing media is in the review queue")
+	}
+	w = doRequest(t, http.MethodGet, "/v1/reports/"+report.ID, token, nil)
+	var own models.TrafficReport
+	decode(t, w, &own)
+	if len(own.MediaFiles) != 1 || own.MediaFiles[0].State != models.MediaProcessing {
+		t.Fatalf("owner's media = %+v, want one processing file", own.MediaFiles)
+	}
+
+	// Transitions must follow the state machine and start from the current state
+	if err := env.storage.SetMediaState(ctx, report.ID, media.ID, models.MediaProcessing, models.MediaReady); err == nil {
+		t.Error("processing -> ready was allowed")
+	}
+	if err := env.storage.SetMediaState(ctx, report.ID, media.ID, models.MediaQuarantined, models.MediaReady); err == nil {
+		t.Error("quarantined -> ready was allowed for processing media")
+	}
+	if err := env.storage.SetMediaState(ctx, report.ID, media.ID, models.MediaProcessing, models.MediaQuarantined); err != nil {
+		t.Fatalf("quarantine: %v", err)
+	}
+	if inReviewQueue() {
+		t.Error("report with quarantined media is in the review queue")
+	}
+
+	if err := env.storage.SetMediaState(ctx, report.ID, media.ID, models.MediaQuarantined, models.MediaReady); err != nil {
+		t.Fatalf("mark ready: %v", err)
+	}
+	if !inReviewQueue() {
+		t.Error("report with ready media is missing from the review queue")
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]string{"status": models.StatusReviewedPass})
+	if w.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !publicFeedHas(t, report.ID) {
+		t.Fatal("approved report with ready media is missing from the feed")
+	}
+
+	// Media added to an approved report takes it out of the feed until it is ready
+	if err := env.storage.AddMediaFileToReport(ctx, report.ID, models.MediaFile{
+		ID: uuid.New().String(), FileName: "photo.jpg", ContentType: "image/jpeg", Size: 512,
+		UploadedAt: time.Now(), State: models.MediaProcessing,
+	}); err != nil {
+		t.Fatalf("attach second media: %v", err)
+	}
+	if publicFeedHas(t, report.ID) {
+		t.Error("approved report with processing media is in the feed")
+	}
+}
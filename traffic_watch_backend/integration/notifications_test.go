@@ -0,0 +1,37 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestNotificationPreferences_DefaultsAndPartialUpdate(t *testing.T) {
+	token := createUser(t, "prefs-user", "prefs-user@example.com", models.RoleContributor)
+
+	var prefs models.NotificationPreferences
+	w := doRequest(t, http.MethodGet, "/v1/auth/me/notifications", token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("get: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	decode(t, w, &prefs)
+	if prefs != models.DefaultNotificationPreferences() {
+		t.Errorf("expected defaults, got %+v", prefs)
+	}
+
+	w = doRequest(t, http.MethodPatch, "/v1/auth/me/notifications", token, map[string]bool{"weeklyDigest": true})
+	if w.Code != http.StatusOK {
+		t.Fatalf("patch: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/auth/me/notifications", token, nil)
+	decode(t, w, &prefs)
+	want := models.DefaultNotificationPreferences()
+	want.WeeklyDigest = true
+	if prefs != want {
+		t.Errorf("expected only weeklyDigest changed, got %+v", prefs)
+	}
+}
@@ -0,0 +1,83 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestOCR_AdminOnlyAndPurgeable(t *testing.T) {
+	token := createUser(t, "ocr-user", "ocr-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "ocr-admin", "ocr-admin@example.com", models.RoleAdmin)
+
+	ctx := context.Background()
+	report := createApprovedReport(t, token, adminToken)
+	mediaID := uuid.New().String()
+	err := env.storage.AddMediaFileToReport(ctx, report.ID, models.MediaFile{
+		ID:          mediaID,
+		FileName:    "rear.jpg",
+		ContentType: "image/jpeg",
+		Size:        1024,
+		UploadedAt:  time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("attach media: %v", err)
+	}
+	err = env.storage.SetMediaOCR(ctx, report.ID, mediaID, &models.MediaOCR{
+		Status:          models.OCRCompleted,
+		Text:            "OHIO\nGHT 4821",
+		PlateCandidates: []string{"GHT4821"},
+		ProcessedAt:     time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("store OCR: %v", err)
+	}
+
+	// Never in public responses
+	w := doRequest(t, http.MethodGet, "/v1/public/reports", "", nil)
+	if strings.Contains(w.Body.String(), "GHT4821") {
+		t.Fatal("plate candidate leaked into the public feed")
+	}
+
+	metadataPath := "/v1/admin/reports/" + report.ID + "/media/" + mediaID + "/metadata"
+	w = doRequest(t, http.MethodGet, metadataPath, adminToken, nil)
+	var resp struct {
+		OCR *models.MediaOCR `json:"ocr"`
+	}
+	decode(t, w, &resp)
+	if resp.OCR == nil || len(resp.OCR.PlateCandidates) != 1 || resp.OCR.PlateCandidates[0] != "GHT4821" {
+		t.Fatalf("expected OCR for admins, got %+v", resp.OCR)
+	}
+
+	purgePath := "/v1/admin/reports/" + report.ID + "/ocr"
+	if w := doRequest(t, http.MethodDelete, purgePath, token, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("contributor purge: expected 403, got %d", w.Code)
+	}
+	if w := doRequest(t, http.MethodDelete, purgePath, adminToken, nil); w.Code != http.StatusOK {
+		t.Fatalf("purge: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	decode(t, doRequest(t, http.MethodGet, metadataPath, adminToken, nil), &resp)
+	if resp.OCR == nil || resp.OCR.Status != models.OCRPurged || resp.OCR.Text != "" || len(resp.OCR.PlateCandidates) != 0 {
+		t.Errorf("expected a purged tombstone, got %+v", resp.OCR)
+	}
+
+	// Purged images are not queued for OCR again
+	jobs, err := env.storage.ListMediaForOCR(ctx, 1000)
+	if err != nil {
+		t.Fatalf("list media for OCR: %v", err)
+	}
+	for _, job := range jobs {
+		if job.Media.ID == mediaID {
+			t.Error("purged image queued for OCR again")
+		}
+	}
+}
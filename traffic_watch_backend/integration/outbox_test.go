@@ -0,0 +1,85 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// outboxSink records relayed events for one report and fails while failing is set
+type outboxSink struct {
+	reportID string
+	failing  bool
+	got      []events.Event
+}
+
+func (s *outboxSink) Publish(ctx context.Context, e events.Event) error {
+	if e.ReportID != s.reportID {
+		return nil
+	}
+	s.got = append(s.got, e)
+	if s.failing {
+		return errors.New("sink unavailable")
+	}
+	return nil
+}
+
+func TestOutbox_RelaysCommittedEventsOnce(t *testing.T) {
+	token := createUser(t, "outbox-owner", "outbox-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "outbox-admin", "outbox-admin@example.com", models.RoleAdmin)
+	ctx := context.Background()
+	report := createApprovedReport(t, token, adminToken)
+
+	sink := &outboxSink{reportID: report.ID}
+	relay := events.NewRelay(env.storage, 5*time.Second, sink)
+	run := func() {
+		t.Helper()
+		if err := relay.Run(ctx); err != nil {
+			t.Fatalf("relay: %v", err)
+		}
+	}
+
+	// An event written in a rolled-back transaction is never relayed
+	errAbort := errors.New("abort")
+	err := env.storage.WithTransaction(ctx, func(tx storage.Client) error {
+		if err := tx.EnqueueOutboxEvent(ctx, events.NewOutboxEvent(events.Event{Type: events.ReportDeleted, ReportID: report.ID})); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("expected the callback's error, got %v", err)
+	}
+
+	run()
+	if len(sink.got) != 2 || sink.got[0].Type != events.ReportCreated || sink.got[1].Type != events.ReportApproved {
+		t.Fatalf("expected created then approved, got %+v", sink.got)
+	}
+	if sink.got[0].ID == "" || sink.got[0].ID == sink.got[1].ID {
+		t.Errorf("expected distinct outbox IDs, got %q and %q", sink.got[0].ID, sink.got[1].ID)
+	}
+
+	// Delivered events are not sent again
+	run()
+	if len(sink.got) != 2 {
+		t.Fatalf("delivered events were relayed again: %+v", sink.got[2:])
+	}
+
+	// A failed delivery waits out its backoff before the next attempt
+	sink.failing = true
+	if err := env.storage.EnqueueOutboxEvent(ctx, events.NewOutboxEvent(events.Event{Type: events.ReportPinned, ReportID: report.ID})); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	run()
+	run()
+	if len(sink.got) != 3 || sink.got[2].Type != events.ReportPinned {
+		t.Errorf("expected one attempt at the pinned event, got %+v", sink.got[2:])
+	}
+}
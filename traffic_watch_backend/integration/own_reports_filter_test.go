@@ -0,0 +1,69 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestOwnReports_StatusFilter(t *testing.T) {
+	token := createUser(t, "filter-owner", "filter-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "filter-admin", "filter-admin@example.com", models.RoleAdmin)
+
+	create := func(title string) models.TrafficReport {
+		w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+			"title":       title,
+			"description": "Seen on the way to work",
+			"dateTime":    "2024-06-01T10:00:00Z",
+			"roadUsages":  []string{"Auto"},
+			"eventTypes":  []string{"Speeding"},
+			"state":       "Ohio",
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		var report models.TrafficReport
+		decode(t, w, &report)
+		return report
+	}
+	pending := create("Still pending")
+	rejected := create("Will be rejected")
+	w := doRequest(t, http.MethodPost, "/v1/admin/reports/"+rejected.ID+"/review", adminToken, map[string]interface{}{
+		"status": models.StatusReviewedFail,
+		"reason": "Plate not visible",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("reject: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	list := func(path string) []models.TrafficReport {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, path, token, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		var resp models.ListReportsResponse
+		decode(t, w, &resp)
+		return resp.Reports
+	}
+
+	failed := list("/v1/reports?status=" + models.StatusReviewedFail)
+	if len(failed) != 1 || failed[0].ID != rejected.ID || failed[0].ReviewReason != "Plate not visible" {
+		t.Errorf("rejected reports = %+v, want only %s with its reason", failed, rejected.ID)
+	}
+	submitted := list("/v1/reports?status=" + models.StatusSubmitted)
+	if len(submitted) != 1 || submitted[0].ID != pending.ID {
+		t.Errorf("pending reports = %+v, want only %s", submitted, pending.ID)
+	}
+	if all := list("/v1/reports"); len(all) != 2 {
+		t.Errorf("unfiltered list has %d reports, want 2", len(all))
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/reports?status=deleted", token, nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status=deleted: expected 400, got %d", w.Code)
+	}
+}
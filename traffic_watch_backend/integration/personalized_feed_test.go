@@ -0,0 +1,67 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestPersonalizedFeed_LocalReportsFirst(t *testing.T) {
+	token := createUser(t, "feed-user", "feed-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "feed-admin", "feed-admin@example.com", models.RoleAdmin)
+	createApprovedReport(t, token, adminToken) // Ohio
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Rolled the stop sign",
+		"description": "Didn't stop at Main and 1st",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Vermont",
+		"city":        "Burlington",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var local models.TrafficReport
+	decode(t, w, &local)
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+local.ID+"/review", adminToken, map[string]string{"status": models.StatusReviewedPass})
+	if w.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodPatch, "/v1/auth/me/profile", token, map[string]string{"defaultState": "Atlantis"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown state: expected 400, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPatch, "/v1/auth/me/profile", token, map[string]string{"defaultState": "Vermont", "defaultCity": "Burlington"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("update profile: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	firstUnpinned := func(token string) string {
+		w := doRequest(t, http.MethodGet, "/v1/reports/feed", token, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("feed: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var feed models.ListReportsResponse
+		decode(t, w, &feed)
+		for _, r := range feed.Reports {
+			if !r.Pinned {
+				return r.ID
+			}
+		}
+		return ""
+	}
+	if got := firstUnpinned(token); got != local.ID {
+		t.Errorf("signed-in feed should start with the Burlington report, got %s", got)
+	}
+
+	// The feed is also open to anonymous users, without personalization
+	if w := doRequest(t, http.MethodGet, "/v1/reports/feed", "", nil); w.Code != http.StatusOK {
+		t.Errorf("anonymous feed: expected 200, got %d", w.Code)
+	}
+}
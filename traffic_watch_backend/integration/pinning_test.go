@@ -0,0 +1,63 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestPinning_PinnedReportLeadsFeed(t *testing.T) {
+	token := createUser(t, "pin-user", "pin-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "pin-admin", "pin-admin@example.com", models.RoleAdmin)
+	older := createApprovedReport(t, token, adminToken)
+	createApprovedReport(t, token, adminToken)
+
+	w := doRequest(t, http.MethodPost, "/v1/admin/reports/"+older.ID+"/pin", token, nil)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("contributor pin: expected 403, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+older.ID+"/pin", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("pin: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/public/reports", "", nil)
+	var feed models.ListReportsResponse
+	decode(t, w, &feed)
+	if len(feed.Reports) == 0 || feed.Reports[0].ID != older.ID || !feed.Reports[0].Pinned {
+		t.Fatalf("pinned report should lead the feed")
+	}
+
+	w = doRequest(t, http.MethodDelete, "/v1/admin/reports/"+older.ID+"/pin", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("unpin: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/public/reports", "", nil)
+	decode(t, w, &feed)
+	if found := findReport(feed.Reports, older.ID); found == nil || found.Pinned {
+		t.Error("unpinned report should stay in the feed without the pinned flag")
+	}
+}
+
+func TestPinning_OnlyApprovedReports(t *testing.T) {
+	token := createUser(t, "pin-pending", "pin-pending@example.com", models.RoleContributor)
+	adminToken := createUser(t, "pin-pending-admin", "pin-pending-admin@example.com", models.RoleAdmin)
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Blocked the box",
+		"description": "Sat in the intersection",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+	})
+	var report models.TrafficReport
+	decode(t, w, &report)
+
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/pin", adminToken, nil)
+	if w.Code != http.StatusConflict {
+		t.Errorf("pin pending report: expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,47 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestPriorityDecay_SkipsFrozenReports(t *testing.T) {
+	ctx := context.Background()
+	token := createUser(t, "decay-user", "decay-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "decay-admin", "decay-admin@example.com", models.RoleAdmin)
+	decaying := createApprovedReport(t, token, adminToken)
+	frozen := createApprovedReport(t, token, adminToken)
+	for _, id := range []string{decaying.ID, frozen.ID} {
+		if err := env.storage.AdjustReportPriority(ctx, id, 50); err != nil {
+			t.Fatalf("boost %s: %v", id, err)
+		}
+	}
+
+	w := doRequest(t, http.MethodPost, "/v1/admin/reports/"+frozen.ID+"/priority/freeze", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("freeze: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if _, err := env.storage.DecayReportPriorities(ctx, 10); err != nil {
+		t.Fatalf("decay: %v", err)
+	}
+
+	priority := func(id string) int {
+		report, err := env.storage.GetReport(ctx, id)
+		if err != nil || report.Priority == nil {
+			t.Fatalf("get %s: %v", id, err)
+		}
+		return *report.Priority
+	}
+	if got := priority(decaying.ID); got != 145 {
+		t.Errorf("decayed priority = %d, want 145", got)
+	}
+	if got := priority(frozen.ID); got != 150 {
+		t.Errorf("frozen priority = %d, want 150", got)
+	}
+}
@@ -0,0 +1,55 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestUpdatePriority_SetAndAdjust(t *testing.T) {
+	token := createUser(t, "priority-user", "priority-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "priority-admin", "priority-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+	path := "/v1/admin/reports/" + report.ID + "/priority"
+
+	update := func(body map[string]interface{}) int {
+		t.Helper()
+		w := doRequest(t, http.MethodPatch, path, adminToken, body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%v: expected 200, got %d: %s", body, w.Code, w.Body.String())
+		}
+		var resp struct {
+			Priority int `json:"priority"`
+		}
+		decode(t, w, &resp)
+		return resp.Priority
+	}
+
+	if got := update(map[string]interface{}{"priority": 300}); got != 300 {
+		t.Errorf("set: priority = %d, want 300", got)
+	}
+	if got := update(map[string]interface{}{"delta": -50}); got != 250 {
+		t.Errorf("delta: priority = %d, want 250", got)
+	}
+	if got := update(map[string]interface{}{"delta": models.MaxPriority}); got != models.MaxPriority {
+		t.Errorf("delta past the top: priority = %d, want %d", got, models.MaxPriority)
+	}
+	stored, err := env.storage.GetReport(context.Background(), report.ID)
+	if err != nil || stored.PriorityRank() != models.MaxPriority {
+		t.Errorf("stored priority = %v, %v", stored, err)
+	}
+
+	if w := doRequest(t, http.MethodPatch, path, adminToken, map[string]interface{}{"priority": models.MaxPriority + 1}); w.Code != http.StatusBadRequest {
+		t.Errorf("out of range: expected 400, got %d", w.Code)
+	}
+	if w := doRequest(t, http.MethodPatch, "/v1/admin/reports/00000000-0000-0000-0000-000000000000/priority", adminToken, map[string]interface{}{"delta": 1}); w.Code != http.StatusNotFound {
+		t.Errorf("missing report: expected 404, got %d", w.Code)
+	}
+	if w := doRequest(t, http.MethodPatch, path, token, map[string]interface{}{"delta": 1}); w.Code != http.StatusForbidden {
+		t.Errorf("non-admin: expected 403, got %d", w.Code)
+	}
+}
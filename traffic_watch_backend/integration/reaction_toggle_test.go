@@ -0,0 +1,70 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestToggleReaction_AddSwitchRemove(t *testing.T) {
+	ctx := context.Background()
+	token := createUser(t, "toggle-owner", "toggle-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "toggle-admin", "toggle-admin@example.com", models.RoleAdmin)
+	fanToken := createUser(t, "toggle-fan", "toggle-fan@example.com", models.RoleContributor)
+	report := createApprovedReport(t, token, adminToken)
+
+	priority := func() int {
+		r, err := env.storage.GetReport(ctx, report.ID)
+		if err != nil || r.Priority == nil {
+			t.Fatalf("get report: %v", err)
+		}
+		return *r.Priority
+	}
+	toggle := func(reactionType string) bool {
+		t.Helper()
+		w := doRequest(t, http.MethodPut, "/v1/reports/"+report.ID+"/reactions/"+reactionType, fanToken, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("toggle %s: expected 200, got %d: %s", reactionType, w.Code, w.Body.String())
+		}
+		var resp struct {
+			Reacted bool `json:"reacted"`
+		}
+		decode(t, w, &resp)
+		return resp.Reacted
+	}
+	start := priority()
+
+	if !toggle(models.ReactionThumbsUp) {
+		t.Fatal("first toggle should add the reaction")
+	}
+	if got := priority(); got != start+2 {
+		t.Errorf("priority after thumbs up = %d, want %d", got, start+2)
+	}
+
+	// A different type replaces the existing reaction
+	if !toggle(models.ReactionAngryCar) {
+		t.Fatal("toggling another type should switch to it")
+	}
+	if got := priority(); got != start+3 {
+		t.Errorf("priority after switching = %d, want %d", got, start+3)
+	}
+
+	if toggle(models.ReactionAngryCar) {
+		t.Fatal("toggling the held type should remove it")
+	}
+	if got := priority(); got != start {
+		t.Errorf("priority after removal = %d, want %d", got, start)
+	}
+	if current, _ := env.storage.GetUserReactionType(ctx, report.ID, "toggle-fan"); current != "" {
+		t.Errorf("reaction type after removal = %q, want none", current)
+	}
+
+	w := doRequest(t, http.MethodPut, "/v1/reports/"+report.ID+"/reactions/not_a_reaction", fanToken, nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown type: expected 400, got %d", w.Code)
+	}
+}
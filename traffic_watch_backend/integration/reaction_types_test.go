@@ -0,0 +1,143 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+type publicConfig struct {
+	Reactions []struct {
+		Type  string `json:"type"`
+		Label string `json:"label"`
+	} `json:"reactions"`
+}
+
+func configHasReaction(t *testing.T, reactionType string) bool {
+	t.Helper()
+	w := doRequest(t, http.MethodGet, "/v1/public/config", "", nil)
+	var config publicConfig
+	decode(t, w, &config)
+	for _, r := range config.Reactions {
+		if r.Type == reactionType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReactionTypes_AddAndRetire(t *testing.T) {
+	token := createUser(t, "reaction-type-user", "reaction-type-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "reaction-type-admin", "reaction-type-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+
+	if !configHasReaction(t, models.ReactionThumbsUp) {
+		t.Fatal("public config should list the seeded reaction types")
+	}
+
+	body := map[string]interface{}{"label": "Honk", "emoji": "📯", "score": 1, "sortOrder": 60}
+	w := doRequest(t, http.MethodPut, "/v1/admin/reactions/honk", token, body)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("contributor upsert: expected 403, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPut, "/v1/admin/reactions/Not-Valid", adminToken, body)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid key: expected 400, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPut, "/v1/admin/reactions/honk", adminToken, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("upsert: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !configHasReaction(t, "honk") {
+		t.Fatal("new reaction type should appear in the public config")
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/reactions", token, map[string]string{"reactionType": "honk"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("react with new type: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodDelete, "/v1/admin/reactions/honk", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("retire: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if configHasReaction(t, "honk") {
+		t.Error("retired reaction type should not appear in the public config")
+	}
+
+	other := createUser(t, "reaction-type-other", "reaction-type-other@example.com", models.RoleContributor)
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/reactions", other, map[string]string{"reactionType": "honk"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("react with retired type: expected 400, got %d", w.Code)
+	}
+
+	// Existing reactions of a retired type survive and can still be removed
+	w = doRequest(t, http.MethodGet, "/v1/public/reports/"+report.ID+"/engagement", "", nil)
+	var engagement models.ReportEngagement
+	decode(t, w, &engagement)
+	if len(engagement.ReactionCounts) != 1 || engagement.ReactionCounts[0].ReactionType != "honk" {
+		t.Errorf("reaction counts = %+v, want the retired honk reaction", engagement.ReactionCounts)
+	}
+	w = doRequest(t, http.MethodDelete, "/v1/reports/"+report.ID+"/reactions/honk", token, nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("remove retired reaction: expected 200, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodDelete, "/v1/admin/reactions/no_such_type", adminToken, nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("retire unknown type: expected 404, got %d", w.Code)
+	}
+}
+
+func TestReactionTypes_ScoreCap(t *testing.T) {
+	token := createUser(t, "reaction-cap-user", "reaction-cap-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "reaction-cap-admin", "reaction-cap-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+
+	body := map[string]interface{}{"label": "Cheer", "score": 2, "maxScore": 5, "sortOrder": 70}
+	if w := doRequest(t, http.MethodPut, "/v1/admin/reactions/cheer", adminToken, body); w.Code != http.StatusOK {
+		t.Fatalf("upsert: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	body["maxScore"] = -1
+	if w := doRequest(t, http.MethodPut, "/v1/admin/reactions/cheer", adminToken, body); w.Code != http.StatusBadRequest {
+		t.Errorf("negative cap: expected 400, got %d", w.Code)
+	}
+
+	priority := func() int {
+		t.Helper()
+		stored, err := env.storage.GetReport(context.Background(), report.ID)
+		if err != nil {
+			t.Fatalf("get report: %v", err)
+		}
+		return stored.PriorityRank()
+	}
+	start := priority()
+
+	// Four cheers would add 8, but the type is capped at 5
+	var voters []string
+	for i := 0; i < 4; i++ {
+		voter := createUser(t, fmt.Sprintf("reaction-cap-voter-%d", i), fmt.Sprintf("reaction-cap-voter-%d@example.com", i), models.RoleContributor)
+		voters = append(voters, voter)
+		if w := doRequest(t, http.MethodPut, "/v1/reports/"+report.ID+"/reactions/cheer", voter, nil); w.Code != http.StatusOK {
+			t.Fatalf("cheer %d: expected 200, got %d: %s", i, w.Code, w.Body.String())
+		}
+	}
+	if got := priority() - start; got != 5 {
+		t.Errorf("priority rose by %d, want the cap of 5", got)
+	}
+
+	// Dropping to three cheers (6) is still over the cap; dropping to two (4) is not
+	for i, want := range []int{5, 4} {
+		if w := doRequest(t, http.MethodPut, "/v1/reports/"+report.ID+"/reactions/cheer", voters[i], nil); w.Code != http.StatusOK {
+			t.Fatalf("un-cheer %d: expected 200, got %d", i, w.Code)
+		}
+		if got := priority() - start; got != want {
+			t.Errorf("after removing %d cheers priority is up %d, want %d", i+1, got, want)
+		}
+	}
+}
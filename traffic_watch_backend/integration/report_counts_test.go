@@ -0,0 +1,56 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestReportCounts_MatchLists(t *testing.T) {
+	token := createUser(t, "count-owner", "count-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "count-admin", "count-admin@example.com", models.RoleAdmin)
+	createApprovedReport(t, token, adminToken)
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Awaiting review",
+		"description": "Blocked the crosswalk",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Speeding"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	count := func(path, token string) int {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, path, token, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", path, w.Code, w.Body.String())
+		}
+		var resp models.CountReportsResponse
+		decode(t, w, &resp)
+		return resp.Count
+	}
+	listed := func(path, token string) int {
+		t.Helper()
+		return len(allPages(t, path, token))
+	}
+
+	if got := count("/v1/reports/count", token); got != 2 {
+		t.Errorf("own count = %d, want 2", got)
+	}
+	if got := count("/v1/reports/count?status="+models.StatusSubmitted, token); got != 1 {
+		t.Errorf("own submitted count = %d, want 1", got)
+	}
+	if got, want := count("/v1/admin/reports/review/count", adminToken), listed("/v1/admin/reports/review", adminToken); got != want || got == 0 {
+		t.Errorf("review queue count = %d, list has %d", got, want)
+	}
+
+	if w := doRequest(t, http.MethodGet, "/v1/admin/reports/review/count", token, nil); w.Code != http.StatusForbidden {
+		t.Errorf("non-admin review count: expected 403, got %d", w.Code)
+	}
+}
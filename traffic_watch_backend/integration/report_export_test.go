@@ -0,0 +1,63 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestReportExport_NDJSONWithEngagement(t *testing.T) {
+	adminToken := createUser(t, "report-export-admin", "report-export-admin@example.com", models.RoleAdmin)
+	userToken := createUser(t, "report-export-user", "report-export-user@example.com", models.RoleContributor)
+	report := createApprovedReport(t, userToken, adminToken)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/reactions", adminToken, map[string]string{"reactionType": models.ReactionThumbsUp})
+	if w.Code != http.StatusOK && w.Code != http.StatusCreated {
+		t.Fatalf("react: expected success, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/comments", adminToken, map[string]string{"content": "Saw this too"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := doRequest(t, http.MethodGet, "/v1/admin/reports/export", userToken, nil); w.Code != http.StatusForbidden {
+		t.Errorf("non-admin export: expected 403, got %d", w.Code)
+	}
+
+	export := func(query string) map[string]models.ReportExportRow {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, "/v1/admin/reports/export"+query, adminToken, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("export%s: expected 200, got %d: %s", query, w.Code, w.Body.String())
+		}
+		rows := map[string]models.ReportExportRow{}
+		scanner := bufio.NewScanner(w.Body)
+		scanner.Buffer(nil, 1<<20)
+		for scanner.Scan() {
+			var row models.ReportExportRow
+			if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+				t.Fatalf("parse ndjson line: %v", err)
+			}
+			rows[row.Report.ID] = row
+		}
+		return rows
+	}
+
+	plain, ok := export("?format=ndjson")[report.ID]
+	if !ok || plain.Engagement != nil || plain.Report.Title != report.Title {
+		t.Errorf("plain export row = %+v (found %v), want the report without engagement", plain, ok)
+	}
+	engaged, ok := export("?format=ndjson&engagement=true")[report.ID]
+	if !ok || engaged.Engagement == nil || engaged.Engagement.ReactionCounts[models.ReactionThumbsUp] != 1 || engaged.Engagement.CommentCount != 1 {
+		t.Errorf("engagement export row = %+v (found %v), want one thumbs_up and one comment", engaged.Engagement, ok)
+	}
+
+	if w := doRequest(t, http.MethodGet, "/v1/admin/reports/export?format=csv", adminToken, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("bad format: expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,93 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/importer"
+	"donzhit_me_backend/internal/models"
+)
+
+func TestReportImport_PreservesStatusAndTimestamps(t *testing.T) {
+	adminToken := createUser(t, "import-admin", "import-admin@example.com", models.RoleAdmin)
+	userToken := createUser(t, "import-user", "import-user@example.com", models.RoleContributor)
+
+	rows := []map[string]interface{}{
+		{
+			"legacyId": "import-1", "userId": "import-user", "title": "Red light at Vine",
+			"description": "SUV ran the light", "dateTime": "2021-03-04T17:30:00Z", "createdAt": "2021-03-05T09:00:00Z",
+			"roadUsages": []string{"Auto"}, "eventTypes": []string{"Red Light"}, "state": "Ohio",
+			"status": models.StatusReviewedPass, "priority": 2, "media": []string{"https://youtu.be/abc"},
+		},
+		{
+			"legacyId": "import-2", "title": "Bad driver", "description": "Swerved",
+			"dateTime": "2021-04-01T08:00:00Z", "state": "Ohio",
+			"status": models.StatusReviewedFail, "reviewReason": "Not enough detail",
+		},
+		{"legacyId": "import-3", "title": "Speeding", "description": "Fast", "dateTime": "2021-04-01T08:00:00Z", "state": "Atlantis"},
+	}
+
+	if w := doRequest(t, http.MethodPost, "/v1/admin/reports/import", userToken, rows); w.Code != http.StatusForbidden {
+		t.Errorf("import as a contributor: expected 403, got %d", w.Code)
+	}
+
+	var result models.ReportImportResult
+	w := doRequest(t, http.MethodPost, "/v1/admin/reports/import?dryRun=true", adminToken, rows)
+	decode(t, w, &result)
+	if w.Code != http.StatusOK || !result.DryRun || result.Imported != 2 || len(result.Errors) != 1 {
+		t.Fatalf("dry run: got %d: %+v", w.Code, result)
+	}
+	if _, err := env.storage.GetReport(context.Background(), importer.ReportID("import-1")); err == nil {
+		t.Fatal("dry run stored a report")
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/import", adminToken, rows)
+	decode(t, w, &result)
+	if w.Code != http.StatusOK || result.Imported != 2 || result.Skipped != 0 ||
+		len(result.Errors) != 1 || result.Errors[0].Row != 3 || result.Errors[0].LegacyID != "import-3" {
+		t.Fatalf("import: got %d: %+v", w.Code, result)
+	}
+
+	approved, err := env.storage.GetReport(context.Background(), importer.ReportID("import-1"))
+	if err != nil {
+		t.Fatalf("get imported report: %v", err)
+	}
+	if approved.Status != models.StatusReviewedPass || approved.UserID != "import-user" ||
+		!approved.CreatedAt.Equal(time.Date(2021, 3, 5, 9, 0, 0, 0, time.UTC)) ||
+		approved.Priority == nil || *approved.Priority != 2 || len(approved.MediaFiles) != 1 {
+		t.Errorf("approved report = %+v", approved)
+	}
+	rejected, err := env.storage.GetReport(context.Background(), importer.ReportID("import-2"))
+	if err != nil || rejected.UserID != "import-admin" || rejected.ReviewReason != "Not enough detail" {
+		t.Errorf("rejected report = %+v, %v", rejected, err)
+	}
+
+	var feed models.ListReportsResponse
+	decode(t, doRequest(t, http.MethodGet, "/v1/public/reports", "", nil), &feed)
+	if findReport(feed.Reports, approved.ID) == nil {
+		t.Error("imported approved report missing from the public feed")
+	}
+
+	// The same rows as a CSV upload are skipped, not duplicated
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile("file", "reports.csv")
+	part.Write([]byte("legacyId,title,description,dateTime,state,status\nimport-2,Bad driver,Swerved,2021-04-01,Ohio,reviewed_fail\n"))
+	form.Close()
+	req := httptest.NewRequest(http.MethodPost, "/v1/admin/reports/import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	w = httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	decode(t, w, &result)
+	if w.Code != http.StatusOK || result.Imported != 0 || result.Skipped != 1 {
+		t.Errorf("CSV re-import: got %d: %+v", w.Code, result)
+	}
+}
@@ -0,0 +1,58 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestReportSort_OwnAndAdminLists(t *testing.T) {
+	token := createUser(t, "sort-owner", "sort-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "sort-admin", "sort-admin@example.com", models.RoleAdmin)
+	older := createApprovedReport(t, token, adminToken)
+	newer := createApprovedReport(t, token, adminToken)
+
+	if err := env.storage.AdjustReportPriority(context.Background(), older.ID, 50); err != nil {
+		t.Fatalf("boost: %v", err)
+	}
+
+	ids := func(path, token string) []string {
+		t.Helper()
+		var out []string
+		for _, r := range allPages(t, path, token) {
+			if r.ID == older.ID || r.ID == newer.ID {
+				out = append(out, r.ID)
+			}
+		}
+		return out
+	}
+	expect := func(path, token string, want ...string) {
+		t.Helper()
+		got := ids(path, token)
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %v, want %v", path, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: got %v, want %v", path, got, want)
+				return
+			}
+		}
+	}
+
+	expect("/v1/reports", token, newer.ID, older.ID)
+	expect("/v1/reports?sort=createdAt&order=asc", token, older.ID, newer.ID)
+	expect("/v1/reports?sort=priority", token, older.ID, newer.ID)
+	expect("/v1/admin/reports?sort=priority&order=asc", adminToken, newer.ID, older.ID)
+	expect("/v1/public/reports?sort=createdAt", "", newer.ID, older.ID)
+
+	for _, path := range []string{"/v1/reports?sort=title", "/v1/reports?order=sideways"} {
+		if w := doRequest(t, http.MethodGet, path, token, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestReportStatus_InvalidTransitionsRejected(t *testing.T) {
+	token := createUser(t, "status-owner", "status-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "status-admin", "status-admin@example.com", models.RoleAdmin)
+	ctx := context.Background()
+
+	canonical := createApprovedReport(t, token, adminToken)
+	duplicate := createApprovedReport(t, token, adminToken)
+	if err := env.storage.MergeReports(ctx, canonical.ID, duplicate.ID, "status-admin@example.com"); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	review := func(id, status string) int {
+		t.Helper()
+		return doRequest(t, http.MethodPost, "/v1/admin/reports/"+id+"/review", adminToken, map[string]string{
+			"status": status,
+			"reason": "blurry",
+		}).Code
+	}
+
+	// A merged report can't be reviewed again
+	if code := review(duplicate.ID, models.StatusReviewedPass); code != http.StatusConflict {
+		t.Errorf("approve merged report: expected 409, got %d", code)
+	}
+	err := env.storage.UpdateReportStatus(ctx, duplicate.ID, models.StatusReviewedPass, "", "status-admin@example.com")
+	var transitionErr *models.StatusTransitionError
+	if !errors.As(err, &transitionErr) || transitionErr.From != models.StatusMerged || transitionErr.To != models.StatusReviewedPass {
+		t.Errorf("storage approve of merged report: err = %v, want a StatusTransitionError", err)
+	}
+
+	// Re-reviewing keeps working
+	if code := review(canonical.ID, models.StatusReviewedFail); code != http.StatusOK {
+		t.Errorf("reject approved report: expected 200, got %d", code)
+	}
+	if code := review(canonical.ID, models.StatusReviewedPass); code != http.StatusOK {
+		t.Errorf("approve rejected report: expected 200, got %d", code)
+	}
+
+	// Deleted is final
+	if w := doRequest(t, http.MethodDelete, "/v1/reports/"+canonical.ID, token, nil); w.Code != http.StatusOK {
+		t.Fatalf("delete: got %d: %s", w.Code, w.Body.String())
+	}
+	if code := review(canonical.ID, models.StatusReviewedPass); code != http.StatusNotFound {
+		t.Errorf("approve deleted report: expected 404, got %d", code)
+	}
+}
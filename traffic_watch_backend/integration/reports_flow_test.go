@@ -0,0 +1,161 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// doRequest sends a request through the router, JSON-encoding body when it is not nil
+func doRequest(t *testing.T, method, path, token string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatalf("failed to encode body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	env.router.ServeHTTP(w, req)
+	return w
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("failed to decode response %q: %v", w.Body.String(), err)
+	}
+}
+
+func findReport(reports []models.TrafficReport, id string) *models.TrafficReport {
+	for i := range reports {
+		if reports[i].ID == id {
+			return &reports[i]
+		}
+	}
+	return nil
+}
+
+func TestReportLifecycle_CreateReviewPublicFeed(t *testing.T) {
+	contributorToken := createUser(t, "flow-contributor", "contributor@example.com", models.RoleContributor)
+	adminToken := createUser(t, "flow-admin", "admin@example.com", models.RoleAdmin)
+
+	// Contributor submits a report
+	w := doRequest(t, http.MethodPost, "/v1/reports", contributorToken, map[string]interface{}{
+		"title":       "Red light runner",
+		"description": "Car ran the red light at 5th and Main",
+		"dateTime":    "2024-05-01T08:30:00Z",
+		"roadUsages":  []string{"Pedestrian"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+		"city":        "Cincinnati",
+		"latitude":    39.1031,
+		"longitude":   -84.512,
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created models.TrafficReport
+	decode(t, w, &created)
+	if created.Status != models.StatusSubmitted {
+		t.Errorf("create: expected status %q, got %q", models.StatusSubmitted, created.Status)
+	}
+
+	// Owner sees it in their own list
+	w = doRequest(t, http.MethodGet, "/v1/reports", contributorToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("list own: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var own models.ListReportsResponse
+	decode(t, w, &own)
+	if findReport(own.Reports, created.ID) == nil {
+		t.Errorf("list own: report %s missing", created.ID)
+	}
+
+	// Not public until reviewed
+	w = doRequest(t, http.MethodGet, "/v1/public/reports", "", nil)
+	var feed models.ListReportsResponse
+	decode(t, w, &feed)
+	if findReport(feed.Reports, created.ID) != nil {
+		t.Fatalf("public feed: unreviewed report %s should not be listed", created.ID)
+	}
+
+	// Contributors cannot review
+	review := map[string]interface{}{"status": models.StatusReviewedPass, "priority": 2}
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+created.ID+"/review", contributorToken, review)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("review as contributor: expected 403, got %d", w.Code)
+	}
+
+	// Admin approves
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+created.ID+"/review", adminToken, review)
+	if w.Code != http.StatusOK {
+		t.Fatalf("review: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Now public, without reviewer identity
+	w = doRequest(t, http.MethodGet, "/v1/public/reports", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("public feed: expected 200, got %d", w.Code)
+	}
+	decode(t, w, &feed)
+	published := findReport(feed.Reports, created.ID)
+	if published == nil {
+		t.Fatalf("public feed: approved report %s missing", created.ID)
+	}
+	if published.Status != models.StatusReviewedPass {
+		t.Errorf("public feed: expected status %q, got %q", models.StatusReviewedPass, published.Status)
+	}
+	if published.ReviewedBy != "" {
+		t.Errorf("public feed: reviewer identity leaked: %q", published.ReviewedBy)
+	}
+
+	// Map clusters and stats include the approved report
+	w = doRequest(t, http.MethodGet, "/v1/public/reports/clusters?bbox=-85,39,-84,40&zoom=12", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("clusters: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var clusters models.ReportClustersResponse
+	decode(t, w, &clusters)
+	if clusters.Total < 1 {
+		t.Errorf("clusters: expected approved report in viewport, got total %d", clusters.Total)
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/public/stats?days=365", "", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("stats: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var stats models.ReportStats
+	decode(t, w, &stats)
+	if stats.Total < 1 {
+		t.Errorf("stats: expected at least one approved report, got %d", stats.Total)
+	}
+}
+
+func TestProtectedRoutes_RequireAuth(t *testing.T) {
+	for _, tc := range []struct {
+		method, path string
+	}{
+		{http.MethodPost, "/v1/reports"},
+		{http.MethodGet, "/v1/reports"},
+		{http.MethodGet, "/v1/admin/reports"},
+	} {
+		w := doRequest(t, tc.method, tc.path, "", nil)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s %s: expected 401, got %d", tc.method, tc.path, w.Code)
+		}
+	}
+}
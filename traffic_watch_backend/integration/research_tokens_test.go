@@ -0,0 +1,138 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestResearchTokens_ReadOnlyWithQuota(t *testing.T) {
+	adminToken := createUser(t, "research-admin", "research-admin@example.com", models.RoleAdmin)
+	userToken := createUser(t, "research-user", "research-user@example.com", models.RoleContributor)
+	approved := createApprovedReport(t, userToken, adminToken)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", userToken, map[string]interface{}{
+		"title":       "Still in review",
+		"description": "Not approved yet",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var pending models.TrafficReport
+	decode(t, w, &pending)
+
+	if w := doRequest(t, http.MethodPost, "/v1/admin/research-tokens", userToken, map[string]interface{}{"name": "x", "email": "x@example.com"}); w.Code != http.StatusForbidden {
+		t.Errorf("non-admin create: expected 403, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, "/v1/admin/research-tokens", adminToken, map[string]interface{}{
+		"name":       "Jane Doe, City Gazette",
+		"email":      "jane@gazette.example.com",
+		"dailyQuota": 3,
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create token: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created models.CreateResearchTokenResponse
+	decode(t, w, &created)
+	secret := created.Secret
+	if created.Token.DailyQuota != 3 || secret == "" {
+		t.Fatalf("created token = %+v, secret %q", created.Token, secret)
+	}
+
+	// Only the approved dataset is readable
+	w = doRequest(t, http.MethodGet, "/v1/research/reports", secret, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("research feed: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var feed models.ListReportsResponse
+	decode(t, w, &feed)
+	if findReport(feed.Reports, approved.ID) == nil || findReport(feed.Reports, pending.ID) != nil {
+		t.Errorf("research feed should have the approved report and not the pending one")
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/research/reports/export", secret, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("research export: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	exported := map[string]bool{}
+	scanner := bufio.NewScanner(w.Body)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var row models.ReportExportRow
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatalf("parse ndjson line: %v", err)
+		}
+		if row.Report.Status != models.StatusReviewedPass {
+			t.Errorf("research export included %s report %s", row.Report.Status, row.Report.ID)
+		}
+		exported[row.Report.ID] = true
+	}
+	if !exported[approved.ID] || exported[pending.ID] {
+		t.Errorf("research export should have the approved report and not the pending one")
+	}
+
+	// The token is not a user session
+	for _, path := range []string{"/v1/reports", "/v1/admin/reports", "/v1/auth/me"} {
+		if w := doRequest(t, http.MethodGet, path, secret, nil); w.Code != http.StatusUnauthorized {
+			t.Errorf("GET %s with research token: expected 401, got %d", path, w.Code)
+		}
+	}
+	if w := doRequest(t, http.MethodGet, "/v1/research/stats", userToken, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("research route with JWT: expected 401, got %d", w.Code)
+	}
+
+	// Third request uses up the quota of 3
+	if w := doRequest(t, http.MethodGet, "/v1/research/stats", secret, nil); w.Code != http.StatusOK {
+		t.Fatalf("research stats: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/research/stats", secret, nil)
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Errorf("over quota: expected 429 with Retry-After, got %d %q", w.Code, w.Header().Get("Retry-After"))
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/research-tokens/"+created.Token.ID+"/usage", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("usage: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var usage struct {
+		Usage []models.ResearchTokenUsage `json:"usage"`
+	}
+	decode(t, w, &usage)
+	if len(usage.Usage) != 1 || usage.Usage[0].Requests != 3 || usage.Usage[0].Rejected != 1 {
+		t.Errorf("usage = %+v, want 3 requests and 1 rejected today", usage.Usage)
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/research-tokens", adminToken, nil)
+	var list struct {
+		Tokens []models.ResearchToken `json:"tokens"`
+	}
+	decode(t, w, &list)
+	var listed *models.ResearchToken
+	for i := range list.Tokens {
+		if list.Tokens[i].ID == created.Token.ID {
+			listed = &list.Tokens[i]
+		}
+	}
+	if listed == nil || listed.RequestsToday != 3 || listed.TotalRequests != 3 || listed.LastUsedAt == nil {
+		t.Errorf("listed token = %+v, want 3 requests today and in total", listed)
+	}
+
+	if w := doRequest(t, http.MethodDelete, "/v1/admin/research-tokens/"+created.Token.ID, adminToken, nil); w.Code != http.StatusOK {
+		t.Fatalf("revoke: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doRequest(t, http.MethodGet, "/v1/research/stats", secret, nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("revoked token: expected 401, got %d", w.Code)
+	}
+	if w := doRequest(t, http.MethodDelete, "/v1/admin/research-tokens/00000000-0000-0000-0000-000000000000", adminToken, nil); w.Code != http.StatusNotFound {
+		t.Errorf("revoke missing: expected 404, got %d", w.Code)
+	}
+}
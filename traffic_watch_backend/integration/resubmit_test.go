@@ -0,0 +1,88 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestResubmit_RejectedReportGoesBackToReview(t *testing.T) {
+	token := createUser(t, "resubmit-user", "resubmit-user@example.com", models.RoleContributor)
+	otherToken := createUser(t, "resubmit-other", "resubmit-other@example.com", models.RoleContributor)
+	adminToken := createUser(t, "resubmit-admin", "resubmit-admin@example.com", models.RoleAdmin)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Car",
+		"description": "Ran the light at Elm St",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var report models.TrafficReport
+	decode(t, w, &report)
+	resubmitPath := "/v1/reports/" + report.ID + "/resubmit"
+
+	reject := func() {
+		t.Helper()
+		w := doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]string{
+			"status": models.StatusReviewedFail,
+			"reason": "Title doesn't describe the incident",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("reject: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	if w := doRequest(t, http.MethodPost, resubmitPath, token, nil); w.Code != http.StatusConflict {
+		t.Errorf("resubmitting a report under review: expected 409, got %d", w.Code)
+	}
+
+	reject()
+	if w := doRequest(t, http.MethodPost, resubmitPath, otherToken, nil); w.Code != http.StatusNotFound {
+		t.Errorf("resubmitting someone else's report: expected 404, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, resubmitPath, token, map[string]string{
+		"title": "Red light runner on Elm St",
+		"note":  "Clearer title",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("resubmit: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resubmitted models.ResubmitReportResponse
+	decode(t, w, &resubmitted)
+	if resubmitted.Report.Status != models.StatusSubmitted || resubmitted.Report.Title != "Red light runner on Elm St" ||
+		resubmitted.Report.Description != report.Description || resubmitted.ResubmissionsLeft != 1 {
+		t.Errorf("resubmitted report = %+v, %d left", resubmitted.Report, resubmitted.ResubmissionsLeft)
+	}
+
+	var history struct {
+		Reviews []models.ReportReview `json:"reviews"`
+	}
+	w = doRequest(t, http.MethodGet, "/v1/reports/"+report.ID+"/reviews", token, nil)
+	decode(t, w, &history)
+	if len(history.Reviews) != 2 ||
+		history.Reviews[0].Status != models.StatusReviewedFail || history.Reviews[0].Actor != "resubmit-admin@example.com" ||
+		history.Reviews[1].Status != models.StatusSubmitted || history.Reviews[1].Reason != "Clearer title" {
+		t.Errorf("review history = %+v", history.Reviews)
+	}
+	if w := doRequest(t, http.MethodGet, "/v1/reports/"+report.ID+"/reviews", otherToken, nil); w.Code != http.StatusNotFound {
+		t.Errorf("someone else's review history: expected 404, got %d", w.Code)
+	}
+
+	// The second resubmission uses up the default limit of two
+	reject()
+	if w := doRequest(t, http.MethodPost, resubmitPath, token, nil); w.Code != http.StatusOK {
+		t.Fatalf("second resubmit: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	reject()
+	if w := doRequest(t, http.MethodPost, resubmitPath, token, nil); w.Code != http.StatusConflict {
+		t.Errorf("resubmit past the limit: expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,47 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestRoles_GrantAndRevokeAdmin(t *testing.T) {
+	adminToken := createUser(t, "roles-admin", "roles-admin@example.com", models.RoleAdmin)
+	userToken := createUser(t, "roles-user", "roles-user@example.com", models.RoleContributor)
+
+	w := doRequest(t, http.MethodGet, "/v1/admin/reports", userToken, nil)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("before grant: expected 403, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodPut, "/v1/admin/users/roles-user/role", adminToken, map[string]string{"role": "admin"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("grant: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/admin/reports", userToken, nil)
+	if w.Code != http.StatusOK {
+		t.Errorf("after grant: expected 200, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodPut, "/v1/admin/users/roles-user/role", adminToken, map[string]string{"role": "contributor"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("revoke: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/admin/reports", userToken, nil)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("after revoke: expected 403, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodPut, "/v1/admin/users/roles-admin/role", adminToken, map[string]string{"role": "viewer"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("own role: expected 400, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPut, "/v1/admin/users/roles-user/role", adminToken, map[string]string{"role": "superuser"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown role: expected 400, got %d", w.Code)
+	}
+}
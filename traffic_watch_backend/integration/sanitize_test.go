@@ -0,0 +1,44 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestSanitize_MarkupStrippedBeforeStorage(t *testing.T) {
+	token := createUser(t, "sanitize-user", "sanitize-user@example.com", models.RoleContributor)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "<b>Ran</b> the red",
+		"description": "Tom & Jerry saw it<script>alert('xss')</script>",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created models.TrafficReport
+	decode(t, w, &created)
+
+	w = doRequest(t, http.MethodGet, "/v1/reports/"+created.ID, token, nil)
+	var stored models.TrafficReport
+	decode(t, w, &stored)
+	if stored.Title != "Ran the red" {
+		t.Errorf("expected tags stripped from title, got %q", stored.Title)
+	}
+	// Stored as plain text, not HTML-escaped
+	if stored.Description != "Tom & Jerry saw it" {
+		t.Errorf("expected script dropped and & kept, got %q", stored.Description)
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+created.ID+"/comments", token, map[string]string{"content": "<i></i>"})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("markup-only comment: expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,69 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func adminSearch(t *testing.T, adminToken, query string) models.AdminSearchResponse {
+	t.Helper()
+	w := doRequest(t, http.MethodGet, "/v1/admin/search?q="+url.QueryEscape(query), adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("search %q: expected 200, got %d: %s", query, w.Code, w.Body.String())
+	}
+	var resp models.AdminSearchResponse
+	decode(t, w, &resp)
+	return resp
+}
+
+func TestAdminSearch_ReportsAndUsers(t *testing.T) {
+	token := createUser(t, "search-submitter", "rusty.reporter@example.com", models.RoleContributor)
+	adminToken := createUser(t, "search-admin", "search-admin@example.com", models.RoleAdmin)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Rusty red truck blocked the bike lane",
+		"description": "Parked in the lane for an hour",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Bicycle"},
+		"eventTypes":  []string{"Other"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var report models.TrafficReport
+	decode(t, w, &report)
+	createApprovedReport(t, token, adminToken) // "Ran the red" in Ohio: a weaker match
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/search?q=truck", token, nil)
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("contributor search: expected 403, got %d", w.Code)
+	}
+
+	resp := adminSearch(t, adminToken, "that rusty red truck report from Ohio")
+	if resp.Reports.Count == 0 || resp.Reports.Results[0].Report.ID != report.ID {
+		t.Fatalf("best report match should be %s, got %+v", report.ID, resp.Reports.Results)
+	}
+	if got := resp.Reports.Results[0].SubmitterEmail; got != "rusty.reporter@example.com" {
+		t.Errorf("submitter email = %q", got)
+	}
+
+	// Stemmed, prefix matching on words
+	if resp := adminSearch(t, adminToken, "trucks"); resp.Reports.Count == 0 {
+		t.Error("plural should match the stemmed word")
+	}
+
+	// Submitter email finds both the report and the user
+	resp = adminSearch(t, adminToken, "rusty.reporter@")
+	if resp.Users.Count != 1 || resp.Users.Results[0].ID != "search-submitter" {
+		t.Errorf("users = %+v, want the submitter", resp.Users.Results)
+	}
+	if resp.Reports.Count < 2 {
+		t.Errorf("reports by submitter email = %d, want both reports", resp.Reports.Count)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+)
+
+func TestSessionCookie_RequiresCSRFOnMutations(t *testing.T) {
+	token := createUser(t, "cookie-contributor", "cookie@example.com", models.RoleContributor)
+	const csrf = "integration-csrf-token"
+
+	send := func(method, path, body, csrfHeader string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.AddCookie(&http.Cookie{Name: auth.SessionCookieName, Value: token})
+		req.AddCookie(&http.Cookie{Name: auth.CSRFCookieName, Value: csrf})
+		if csrfHeader != "" {
+			req.Header.Set(auth.CSRFHeader, csrfHeader)
+		}
+		w := httptest.NewRecorder()
+		env.router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(http.MethodGet, "/v1/reports", "", ""); w.Code != http.StatusOK {
+		t.Fatalf("cookie GET: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/v1/blocks/someone-else", "", ""); w.Code != http.StatusForbidden {
+		t.Fatalf("cookie POST without CSRF header: expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/v1/blocks/someone-else", "", "wrong"); w.Code != http.StatusForbidden {
+		t.Fatalf("cookie POST with wrong CSRF header: expected 403, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/v1/auth/logout", "", csrf); w.Code != http.StatusOK {
+		t.Fatalf("cookie logout with CSRF header: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
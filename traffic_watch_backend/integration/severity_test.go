@@ -0,0 +1,92 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestSeverity_SetOnCreateAndFiltersFeed(t *testing.T) {
+	token := createUser(t, "severity-user", "severity-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "severity-admin", "severity-admin@example.com", models.RoleAdmin)
+
+	create := func(severity, injuries string) models.TrafficReport {
+		t.Helper()
+		w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+			"title":       "Clipped a cyclist",
+			"description": "Turned right across the bike lane",
+			"dateTime":    "2024-06-01T10:00:00Z",
+			"roadUsages":  []string{"Cyclist"},
+			"eventTypes":  []string{"Reckless"},
+			"state":       "Ohio",
+			"severity":    severity,
+			"injuries":    injuries,
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		var report models.TrafficReport
+		decode(t, w, &report)
+		w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]string{"status": models.StatusReviewedPass})
+		if w.Code != http.StatusOK {
+			t.Fatalf("approve: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		return report
+	}
+
+	// Clients that only send free-text injuries get a severity guessed from it
+	inferred := map[string]string{
+		"Rider taken to hospital with a broken wrist": models.SeverityMajorInjury,
+		"Some bruises":                   models.SeverityMinorInjury,
+		"No injuries, bumper was dented": models.SeverityPropertyDamage,
+		"Nobody hurt, luckily":           models.SeverityNearMiss,
+		"":                               "",
+	}
+	for injuries, want := range inferred {
+		if got := create("", injuries).Severity; got != want {
+			t.Errorf("injuries %q: severity %q, want %q", injuries, got, want)
+		}
+	}
+	explicit := create(models.SeverityMinorInjury, "Rider taken to hospital")
+	if explicit.Severity != models.SeverityMinorInjury {
+		t.Errorf("explicit severity overridden: got %q", explicit.Severity)
+	}
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Unknown severity",
+		"description": "Sent a severity that doesn't exist",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Speeding"},
+		"state":       "Ohio",
+		"severity":    "catastrophic",
+	})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("unknown severity: expected 400, got %d", w.Code)
+	}
+
+	for _, path := range []string{"/v1/public/reports", "/v1/reports/feed"} {
+		w = doRequest(t, http.MethodGet, path+"?severity=minor_injury,major_injury", "", nil)
+		var feed models.ListReportsResponse
+		decode(t, w, &feed)
+		if feed.Count == 0 {
+			t.Fatalf("%s: expected injury reports in the filtered feed", path)
+		}
+		for _, r := range feed.Reports {
+			if r.Severity != models.SeverityMinorInjury && r.Severity != models.SeverityMajorInjury {
+				t.Errorf("%s: report %s with severity %q passed the filter", path, r.ID, r.Severity)
+			}
+		}
+		if findReport(feed.Reports, explicit.ID) == nil {
+			t.Errorf("%s: minor injury report missing from the filtered feed", path)
+		}
+
+		w = doRequest(t, http.MethodGet, path+"?severity=catastrophic", "", nil)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: unknown severity filter: expected 400, got %d", path, w.Code)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestShadowBan_HidesContentFromOthers(t *testing.T) {
+	bannedToken := createUser(t, "shadow-banned", "banned@example.com", models.RoleContributor)
+	otherToken := createUser(t, "shadow-other", "other@example.com", models.RoleContributor)
+	adminToken := createUser(t, "shadow-admin", "shadow-admin@example.com", models.RoleAdmin)
+
+	w := doRequest(t, http.MethodPut, "/v1/admin/users/shadow-banned/shadow-ban", adminToken, map[string]bool{"shadowBanned": true})
+	if w.Code != http.StatusOK {
+		t.Fatalf("shadow-ban: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Submission is accepted and visible to its author
+	w = doRequest(t, http.MethodPost, "/v1/reports", bannedToken, map[string]interface{}{
+		"title":       "Speeding on the bridge",
+		"description": "Way over the limit",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Speeding"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created models.TrafficReport
+	decode(t, w, &created)
+
+	w = doRequest(t, http.MethodGet, "/v1/reports", bannedToken, nil)
+	var own models.ListReportsResponse
+	decode(t, w, &own)
+	if findReport(own.Reports, created.ID) == nil {
+		t.Error("author should still see their own report")
+	}
+
+	// Hidden from the default review queue, shown on request
+	w = doRequest(t, http.MethodGet, "/v1/admin/reports/review", adminToken, nil)
+	var queue models.ListReportsResponse
+	decode(t, w, &queue)
+	if findReport(queue.Reports, created.ID) != nil {
+		t.Error("review queue should hide shadow-banned reports by default")
+	}
+	w = doRequest(t, http.MethodGet, "/v1/admin/reports/review?includeShadowBanned=true", adminToken, nil)
+	decode(t, w, &queue)
+	if findReport(queue.Reports, created.ID) == nil {
+		t.Error("review queue should include shadow-banned reports when asked")
+	}
+
+	// Approve a report by someone else, then comment on it as the banned user
+	w = doRequest(t, http.MethodPost, "/v1/reports", otherToken, map[string]interface{}{
+		"title":       "Phone while driving",
+		"description": "Texting at the light",
+		"dateTime":    "2024-06-02T10:00:00Z",
+		"roadUsages":  []string{"Cyclist"},
+		"eventTypes":  []string{"On Phone"},
+		"state":       "Ohio",
+	})
+	var visible models.TrafficReport
+	decode(t, w, &visible)
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+visible.ID+"/review", adminToken, map[string]string{"status": models.StatusReviewedPass})
+	if w.Code != http.StatusOK {
+		t.Fatalf("approve: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+visible.ID+"/comments", bannedToken, map[string]string{"content": "Saw this too"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var comments struct {
+		Count int `json:"count"`
+	}
+	w = doRequest(t, http.MethodGet, "/v1/public/reports/"+visible.ID+"/comments", "", nil)
+	decode(t, w, &comments)
+	if comments.Count != 0 {
+		t.Errorf("anonymous viewers should not see shadow-banned comments, got %d", comments.Count)
+	}
+	w = doRequest(t, http.MethodGet, "/v1/public/reports/"+visible.ID+"/comments", bannedToken, nil)
+	decode(t, w, &comments)
+	if comments.Count != 1 {
+		t.Errorf("author should see their own comment, got %d", comments.Count)
+	}
+}
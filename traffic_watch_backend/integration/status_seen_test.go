@@ -0,0 +1,66 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestStatusSeen_ReviewFlagsUntilMarkedSeen(t *testing.T) {
+	token := createUser(t, "seen-owner", "seen-owner@example.com", models.RoleContributor)
+	otherToken := createUser(t, "seen-other", "seen-other@example.com", models.RoleContributor)
+	adminToken := createUser(t, "seen-admin", "seen-admin@example.com", models.RoleAdmin)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+		"title":       "Rolled the stop sign",
+		"description": "Did not stop at all",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+	})
+	var report models.TrafficReport
+	decode(t, w, &report)
+
+	unseen := func() bool {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, "/v1/reports", token, nil)
+		var own models.ListReportsResponse
+		decode(t, w, &own)
+		found := findReport(own.Reports, report.ID)
+		if found == nil {
+			t.Fatalf("report %s missing from own list", report.ID)
+		}
+		return found.HasUnseenUpdate
+	}
+
+	if unseen() {
+		t.Error("a newly submitted report should have no unseen update")
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]interface{}{
+		"status": models.StatusReviewedFail,
+		"reason": "Not a traffic violation",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("review: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if !unseen() {
+		t.Fatal("a reviewed report should have an unseen update")
+	}
+
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/seen", otherToken, nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("mark another user's report seen: expected 404, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/seen", token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("mark seen: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if unseen() {
+		t.Error("unseen update should clear once marked seen")
+	}
+}
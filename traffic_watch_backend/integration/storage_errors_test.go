@@ -0,0 +1,57 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+func TestStorageErrors_SentinelsMapToStatusCodes(t *testing.T) {
+	ownerToken := createUser(t, "sentinel-owner", "sentinel-owner@example.com", models.RoleContributor)
+	otherToken := createUser(t, "sentinel-other", "sentinel-other@example.com", models.RoleContributor)
+	adminToken := createUser(t, "sentinel-admin", "sentinel-admin@example.com", models.RoleAdmin)
+	ctx := context.Background()
+
+	if _, err := env.storage.GetReport(ctx, uuid.New().String()); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("missing report: err = %v, want ErrNotFound", err)
+	}
+	if err := env.storage.SetUserShadowBanned(ctx, uuid.New().String(), true); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("missing user: err = %v, want ErrNotFound", err)
+	}
+
+	report := createApprovedReport(t, ownerToken, adminToken)
+	duplicate, err := env.storage.GetReport(ctx, report.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := env.storage.CreateReport(ctx, duplicate); !errors.Is(err, storage.ErrConflict) {
+		t.Errorf("duplicate report ID: err = %v, want ErrConflict", err)
+	}
+
+	w := doRequest(t, http.MethodPost, "/v1/reports/"+report.ID+"/comments", ownerToken, map[string]string{"content": "Plate was covered"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("comment: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var comment models.Comment
+	decode(t, w, &comment)
+
+	// Someone else's comment is forbidden, not missing
+	path := "/v1/reports/" + report.ID + "/comments/" + comment.ID
+	if w := doRequest(t, http.MethodDelete, path, otherToken, nil); w.Code != http.StatusForbidden {
+		t.Errorf("delete other user's comment: expected 403, got %d", w.Code)
+	}
+	if w := doRequest(t, http.MethodDelete, path, ownerToken, nil); w.Code != http.StatusOK {
+		t.Errorf("delete own comment: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := doRequest(t, http.MethodDelete, "/v1/reports/"+uuid.New().String(), ownerToken, nil); w.Code != http.StatusNotFound {
+		t.Errorf("delete missing report: expected 404, got %d", w.Code)
+	}
+}
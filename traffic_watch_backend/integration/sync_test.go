@@ -0,0 +1,73 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func queuedReport(id string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          id,
+		"title":       "Speeding past the school",
+		"description": "Filed while offline",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Speeding"},
+		"state":       "Ohio",
+	}
+}
+
+func TestSync_IdempotentWithPerItemResults(t *testing.T) {
+	token := createUser(t, "sync-user", "sync-user@example.com", models.RoleContributor)
+	otherToken := createUser(t, "sync-other", "sync-other@example.com", models.RoleContributor)
+
+	first, second := uuid.New().String(), uuid.New().String()
+	invalid := queuedReport(uuid.New().String())
+	delete(invalid, "title")
+
+	body := map[string]interface{}{"reports": []interface{}{queuedReport(first), queuedReport(second), invalid}}
+	w := doRequest(t, http.MethodPost, "/v1/reports/sync", token, body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("sync: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Results []models.SyncResult `json:"results"`
+	}
+	decode(t, w, &resp)
+	want := []string{models.SyncCreated, models.SyncCreated, models.SyncInvalid}
+	for i, r := range resp.Results {
+		if r.Status != want[i] {
+			t.Errorf("item %d: expected %s, got %s (%s)", i, want[i], r.Status, r.Message)
+		}
+	}
+
+	// Resending the batch creates nothing new
+	w = doRequest(t, http.MethodPost, "/v1/reports/sync", token, body)
+	decode(t, w, &resp)
+	if resp.Results[0].Status != models.SyncDuplicate || resp.Results[0].Report == nil || resp.Results[0].Report.ID != first {
+		t.Errorf("resent item: expected duplicate of %s, got %+v", first, resp.Results[0])
+	}
+
+	// Another user's queued report with a taken ID is a conflict
+	w = doRequest(t, http.MethodPost, "/v1/reports/sync", otherToken, map[string]interface{}{"reports": []interface{}{queuedReport(first)}})
+	decode(t, w, &resp)
+	if resp.Results[0].Status != models.SyncConflict {
+		t.Errorf("taken ID: expected conflict, got %+v", resp.Results[0])
+	}
+
+	// Single-report creation honours client IDs the same way
+	w = doRequest(t, http.MethodPost, "/v1/reports", token, queuedReport(second))
+	if w.Code != http.StatusOK {
+		t.Errorf("retried create: expected 200 with the stored report, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodPost, "/v1/reports", otherToken, queuedReport(second))
+	if w.Code != http.StatusConflict {
+		t.Errorf("create with taken ID: expected 409, got %d", w.Code)
+	}
+}
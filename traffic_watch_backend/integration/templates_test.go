@@ -0,0 +1,80 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestTemplates_PrefillReportCreation(t *testing.T) {
+	token := createUser(t, "template-user", "template-user@example.com", models.RoleContributor)
+	otherToken := createUser(t, "template-other", "template-other@example.com", models.RoleContributor)
+
+	w := doRequest(t, http.MethodPost, "/v1/templates", token, map[string]interface{}{
+		"name":        "Main & 5th",
+		"title":       "Red light at Main & 5th",
+		"description": "Another driver ran the red at Main & 5th",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+		"city":        "Columbus",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create template: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var template models.ReportTemplate
+	decode(t, w, &template)
+
+	// Only the date and a custom title are sent; the rest comes from the template
+	w = doRequest(t, http.MethodPost, "/v1/reports?templateId="+template.ID, token, map[string]interface{}{
+		"title":    "Two cars ran the red",
+		"dateTime": "2024-06-01T10:00:00Z",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create from template: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var report models.TrafficReport
+	decode(t, w, &report)
+	if report.Title != "Two cars ran the red" {
+		t.Errorf("sent title should win over the template, got %q", report.Title)
+	}
+	if report.Description != "Another driver ran the red at Main & 5th" || report.State != "Ohio" || report.City != "Columbus" {
+		t.Errorf("template fields not prefilled: %+v", report)
+	}
+	if len(report.EventTypes) != 1 || report.EventTypes[0] != "Red Light" {
+		t.Errorf("expected template event types, got %v", report.EventTypes)
+	}
+
+	// Templates are private to their owner
+	w = doRequest(t, http.MethodPost, "/v1/reports?templateId="+template.ID, otherToken, map[string]interface{}{
+		"dateTime": "2024-06-01T10:00:00Z",
+	})
+	if w.Code != http.StatusNotFound {
+		t.Errorf("other user's template: expected 404, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodPut, "/v1/templates/"+template.ID, token, map[string]interface{}{"name": "Renamed"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("update template: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = doRequest(t, http.MethodGet, "/v1/templates", token, nil)
+	var list struct {
+		Templates []models.ReportTemplate `json:"templates"`
+	}
+	decode(t, w, &list)
+	if len(list.Templates) != 1 || list.Templates[0].Name != "Renamed" {
+		t.Errorf("expected the renamed template, got %+v", list.Templates)
+	}
+
+	w = doRequest(t, http.MethodDelete, "/v1/templates/"+template.ID, token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("delete template: expected 200, got %d", w.Code)
+	}
+	w = doRequest(t, http.MethodDelete, "/v1/templates/"+template.ID, token, nil)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("second delete: expected 404, got %d", w.Code)
+	}
+}
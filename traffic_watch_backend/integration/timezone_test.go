@@ -0,0 +1,59 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestTimezone_LocalTimeRoundTrip(t *testing.T) {
+	token := createUser(t, "tz-user", "tz-user@example.com", models.RoleContributor)
+
+	body := map[string]interface{}{
+		"title":       "Speeding on Main",
+		"description": "Evening rush hour",
+		"dateTime":    "2024-07-04T21:30:00Z",
+		"timeZone":    "America/New_York",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Speeding"},
+		"state":       "Ohio",
+	}
+	w := doRequest(t, http.MethodPost, "/v1/reports", token, body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		ID       string `json:"id"`
+		DateTime string `json:"dateTime"`
+	}
+	decode(t, w, &created)
+
+	w = doRequest(t, http.MethodGet, "/v1/reports/"+created.ID, token, nil)
+	var fetched struct {
+		DateTime         string `json:"dateTime"`
+		TimeZone         string `json:"timeZone"`
+		UTCOffsetMinutes int    `json:"utcOffsetMinutes"`
+	}
+	decode(t, w, &fetched)
+	if fetched.DateTime != "2024-07-04T17:30:00-04:00" {
+		t.Errorf("expected local time with offset, got %s", fetched.DateTime)
+	}
+	if fetched.TimeZone != "America/New_York" || fetched.UTCOffsetMinutes != -240 {
+		t.Errorf("unexpected zone %q / offset %d", fetched.TimeZone, fetched.UTCOffsetMinutes)
+	}
+
+	body["dateTime"] = time.Now().Add(time.Hour).Format(time.RFC3339)
+	if w := doRequest(t, http.MethodPost, "/v1/reports", token, body); w.Code != http.StatusBadRequest {
+		t.Errorf("future dateTime: expected 400, got %d", w.Code)
+	}
+
+	body["dateTime"] = "2024-07-04T21:30:00Z"
+	body["timeZone"] = "Mars/Olympus_Mons"
+	if w := doRequest(t, http.MethodPost, "/v1/reports", token, body); w.Code != http.StatusBadRequest {
+		t.Errorf("unknown timeZone: expected 400, got %d", w.Code)
+	}
+}
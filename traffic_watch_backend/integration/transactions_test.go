@@ -0,0 +1,118 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+func TestWithTransaction_CommitsAndRollsBack(t *testing.T) {
+	token := createUser(t, "tx-owner", "tx-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "tx-admin", "tx-admin@example.com", models.RoleAdmin)
+	ctx := context.Background()
+	report := createApprovedReport(t, token, adminToken)
+	publishAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	publishAtOf := func() *time.Time {
+		t.Helper()
+		got, err := env.storage.GetReport(ctx, report.ID)
+		if err != nil {
+			t.Fatalf("get report: %v", err)
+		}
+		return got.PublishAt
+	}
+
+	// A failing callback undoes its writes
+	errAbort := errors.New("abort")
+	err := env.storage.WithTransaction(ctx, func(tx storage.Client) error {
+		if err := tx.SetReportPublishAt(ctx, report.ID, &publishAt); err != nil {
+			return err
+		}
+		// Reads inside the transaction see its writes
+		inTx, err := tx.GetReport(ctx, report.ID)
+		if err != nil || inTx.PublishAt == nil {
+			t.Errorf("read inside transaction: publishAt = %v, err = %v", inTx, err)
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("expected the callback's error, got %v", err)
+	}
+	if got := publishAtOf(); got != nil {
+		t.Errorf("rolled-back publishAt persisted: %v", got)
+	}
+
+	priorityOf := func() int {
+		t.Helper()
+		got, err := env.storage.GetReport(ctx, report.ID)
+		if err != nil {
+			t.Fatalf("get report: %v", err)
+		}
+		if got.Priority == nil {
+			return 100
+		}
+		return *got.Priority
+	}
+	priority := priorityOf()
+
+	// A nested failure only undoes the nested part
+	err = env.storage.WithTransaction(ctx, func(tx storage.Client) error {
+		if err := tx.SetReportPublishAt(ctx, report.ID, &publishAt); err != nil {
+			return err
+		}
+		if err := tx.WithTransaction(ctx, func(inner storage.Client) error {
+			if err := inner.AdjustReportPriority(ctx, report.ID, 5); err != nil {
+				return err
+			}
+			return errAbort
+		}); !errors.Is(err, errAbort) {
+			t.Errorf("nested transaction: expected the callback's error, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if got := publishAtOf(); got == nil || !got.Equal(publishAt) {
+		t.Errorf("committed publishAt = %v, want %v", got, publishAt)
+	}
+	if got := priorityOf(); got != priority {
+		t.Errorf("rolled-back nested priority change persisted: priority %d, want %d", got, priority)
+	}
+}
+
+func TestReviewReport_FailedReviewKeepsPublishTime(t *testing.T) {
+	token := createUser(t, "tx-review-owner", "tx-review-owner@example.com", models.RoleContributor)
+	adminToken := createUser(t, "tx-review-admin", "tx-review-admin@example.com", models.RoleAdmin)
+	ctx := context.Background()
+
+	canonical := createApprovedReport(t, token, adminToken)
+	duplicate := createApprovedReport(t, token, adminToken)
+	if err := env.storage.MergeReports(ctx, canonical.ID, duplicate.ID, "tx-review-admin@example.com"); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	// Approving the merged duplicate fails after its publish time was set; the
+	// publish time must be rolled back with it
+	w := doRequest(t, http.MethodPost, "/v1/admin/reports/"+duplicate.ID+"/review", adminToken, map[string]interface{}{
+		"status":    models.StatusReviewedPass,
+		"publishAt": time.Now().Add(time.Hour),
+	})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("approve merged report: expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+	got, err := env.storage.GetReport(ctx, duplicate.ID)
+	if err != nil {
+		t.Fatalf("get report: %v", err)
+	}
+	if got.PublishAt != nil {
+		t.Errorf("publish time of a failed review persisted: %v", got.PublishAt)
+	}
+}
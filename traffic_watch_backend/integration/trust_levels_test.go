@@ -0,0 +1,62 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestTrustLevels_FollowReviewHistory(t *testing.T) {
+	token := createUser(t, "trust-user", "trust-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "trust-admin", "trust-admin@example.com", models.RoleAdmin)
+
+	me := func() models.CurrentUserResponse {
+		t.Helper()
+		var resp models.CurrentUserResponse
+		w := doRequest(t, http.MethodGet, "/v1/auth/me", token, nil)
+		decode(t, w, &resp)
+		if w.Code != http.StatusOK {
+			t.Fatalf("me: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		return resp
+	}
+
+	if got := me(); got.TrustLevel != models.TrustNew || got.Capabilities.PostLinks {
+		t.Errorf("new user: level %q, capabilities %+v", got.TrustLevel, got.Capabilities)
+	}
+
+	createApprovedReport(t, token, adminToken)
+	if got := me(); got.TrustLevel != models.TrustBasic || !got.Capabilities.PostLinks || got.Capabilities.AutoApprovalEligible {
+		t.Errorf("after one approval: level %q, capabilities %+v", got.TrustLevel, got.Capabilities)
+	}
+
+	for i := 0; i < 4; i++ {
+		createApprovedReport(t, token, adminToken)
+	}
+	if got := me(); got.TrustLevel != models.TrustTrusted || !got.Capabilities.HigherUploadLimits || !got.Capabilities.AutoApprovalEligible {
+		t.Errorf("after five approvals: level %q, capabilities %+v", got.TrustLevel, got.Capabilities)
+	}
+
+	// Two rejections out of seven reviews is more than trusted users may have
+	for i := 0; i < 2; i++ {
+		w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+			"title": "Blurry", "description": "Can't make out the car", "dateTime": "2024-06-01T10:00:00Z",
+			"roadUsages": []string{"Auto"}, "eventTypes": []string{"Red Light"}, "state": "Ohio",
+		})
+		var report models.TrafficReport
+		decode(t, w, &report)
+		w = doRequest(t, http.MethodPost, "/v1/admin/reports/"+report.ID+"/review", adminToken, map[string]interface{}{
+			"status": models.StatusReviewedFail,
+			"reason": "Plate not visible",
+		})
+		if w.Code != http.StatusOK {
+			t.Fatalf("reject: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	if got := me(); got.TrustLevel != models.TrustBasic {
+		t.Errorf("after rejections: level %q, want basic", got.TrustLevel)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestBatchUploadURLs_ValidatesBeforeIssuing(t *testing.T) {
+	token := createUser(t, "upload-user", "upload-user@example.com", models.RoleContributor)
+	path := "/v1/reports/" + uuid.New().String() + "/media/batch-upload-urls"
+
+	// One bad file rejects the whole batch
+	body := map[string]interface{}{"files": []map[string]interface{}{
+		{"fileName": "front.jpg", "contentType": "image/jpeg", "size": 1024},
+		{"fileName": "huge.jpg", "contentType": "image/jpeg", "size": 11 * 1024 * 1024},
+	}}
+	if w := doRequest(t, http.MethodPost, path, token, body); w.Code != http.StatusBadRequest {
+		t.Fatalf("oversized file: expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	body = map[string]interface{}{"files": []map[string]interface{}{
+		{"fileName": "notes.pdf", "contentType": "application/pdf", "size": 1024},
+	}}
+	if w := doRequest(t, http.MethodPost, path, token, body); w.Code != http.StatusBadRequest {
+		t.Fatalf("disallowed type: expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Valid files reach signing, which needs GCS (not configured here)
+	body = map[string]interface{}{"files": []map[string]interface{}{
+		{"fileName": "front.jpg", "size": 1024},
+	}}
+	if w := doRequest(t, http.MethodPost, path, token, body); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("no GCS: expected 503, got %d: %s", w.Code, w.Body.String())
+	}
+}
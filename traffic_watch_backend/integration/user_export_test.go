@@ -0,0 +1,80 @@
+//go:build integration
+
+package integration
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestUserExport_CSVAndRedactedNDJSON(t *testing.T) {
+	adminToken := createUser(t, "export-admin", "export-admin@example.com", models.RoleAdmin)
+	userToken := createUser(t, "export-user", "exported@example.com", models.RoleContributor)
+
+	w := doRequest(t, http.MethodPost, "/v1/reports", userToken, map[string]interface{}{
+		"title":       "Red light runner",
+		"description": "Ran the light at Main St",
+		"dateTime":    "2024-06-01T10:00:00Z",
+		"roadUsages":  []string{"Auto"},
+		"eventTypes":  []string{"Red Light"},
+		"state":       "Ohio",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create: expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/users/export", userToken, nil)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("non-admin export: expected 403, got %d", w.Code)
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/users/export", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("csv export: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse csv: %v", err)
+	}
+	if len(records) < 3 || records[0][0] != "id" {
+		t.Fatalf("expected header plus users, got %v", records)
+	}
+	var found bool
+	for _, rec := range records[1:] {
+		if rec[0] == "export-user" {
+			found = true
+			if rec[1] != "exported@example.com" || rec[5] != "1" {
+				t.Errorf("unexpected row for export-user: %v", rec)
+			}
+		}
+	}
+	if !found {
+		t.Error("csv export should include export-user")
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/users/export?format=ndjson&redact=true", adminToken, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("ndjson export: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		var row models.UserExportRow
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			t.Fatalf("parse ndjson line: %v", err)
+		}
+		if strings.HasPrefix(row.ID, "export-") || strings.HasPrefix(row.Email, "export") {
+			t.Errorf("redacted export leaked identity: %+v", row)
+		}
+	}
+
+	w = doRequest(t, http.MethodGet, "/v1/admin/users/export?format=xml", adminToken, nil)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("bad format: expected 400, got %d", w.Code)
+	}
+}
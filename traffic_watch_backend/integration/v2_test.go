@@ -0,0 +1,72 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/models"
+)
+
+func TestV2_OwnReportsPaginate(t *testing.T) {
+	token := createUser(t, "v2-contributor", "v2-contributor@example.com", models.RoleContributor)
+
+	for i := 0; i < 3; i++ {
+		w := doRequest(t, http.MethodPost, "/v1/reports", token, map[string]interface{}{
+			"title":       fmt.Sprintf("Speeding %d", i),
+			"description": "Speeding through the school zone",
+			"dateTime":    "2024-05-01T08:30:00Z",
+			"roadUsages":  []string{"Pedestrian"},
+			"eventTypes":  []string{"Speeding"},
+			"state":       "Ohio",
+		})
+		if w.Code != http.StatusCreated {
+			t.Fatalf("create %d: expected 201, got %d: %s", i, w.Code, w.Body.String())
+		}
+	}
+
+	seen := map[string]bool{}
+	path := "/v2/reports?limit=2"
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("pagination did not terminate")
+		}
+		w := doRequest(t, http.MethodGet, path, token, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("list: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var page models.PageResponse[models.TrafficReport]
+		decode(t, w, &page)
+		for _, r := range page.Data {
+			if seen[r.ID] {
+				t.Errorf("report %s returned twice", r.ID)
+			}
+			seen[r.ID] = true
+		}
+		if !page.Pagination.HasMore {
+			break
+		}
+		path = "/v2/reports?limit=2&cursor=" + page.Pagination.NextCursor
+	}
+	if len(seen) != 3 {
+		t.Errorf("expected 3 reports across pages, got %d", len(seen))
+	}
+}
+
+func TestV2_ErrorsAreProblems(t *testing.T) {
+	w := doRequest(t, http.MethodGet, "/v2/public/reports?cursor=not-a-cursor", "", nil)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != apierror.ProblemContentType {
+		t.Errorf("expected content type %q, got %q", apierror.ProblemContentType, ct)
+	}
+	var problem apierror.Problem
+	decode(t, w, &problem)
+	if problem.Status != http.StatusBadRequest || problem.Code != "validation_error" || problem.Instance != "/v2/public/reports" {
+		t.Errorf("unexpected problem body: %+v", problem)
+	}
+}
@@ -0,0 +1,49 @@
+//go:build integration
+
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestWidget_RecentApprovedReports(t *testing.T) {
+	token := createUser(t, "widget-user", "widget-user@example.com", models.RoleContributor)
+	adminToken := createUser(t, "widget-admin", "widget-admin@example.com", models.RoleAdmin)
+	report := createApprovedReport(t, token, adminToken)
+
+	widget := func(query string) models.WidgetResponse {
+		t.Helper()
+		w := doRequest(t, http.MethodGet, "/v1/public/widget"+query, "", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("widget%s: expected 200, got %d: %s", query, w.Code, w.Body.String())
+		}
+		if cc := w.Header().Get("Cache-Control"); !strings.HasPrefix(cc, "public") {
+			t.Errorf("Cache-Control = %q, want public caching", cc)
+		}
+		var resp models.WidgetResponse
+		decode(t, w, &resp)
+		return resp
+	}
+
+	// The report was just approved, so it is the newest in its state
+	ohio := widget("?state=Ohio&limit=1")
+	if ohio.Count != 1 || ohio.Items[0].ID != report.ID || ohio.Items[0].Title != report.Title {
+		t.Fatalf("Ohio widget = %+v, want just the new report", ohio.Items)
+	}
+	if !strings.HasSuffix(ohio.Items[0].Link, "/reports/"+report.ID) {
+		t.Errorf("link = %q", ohio.Items[0].Link)
+	}
+
+	for _, item := range widget("?state=Yukon").Items {
+		if item.ID == report.ID {
+			t.Error("Yukon widget should not include an Ohio report")
+		}
+	}
+	if got := widget("").Count; got > 5 {
+		t.Errorf("default widget has %d items, want at most 5", got)
+	}
+}
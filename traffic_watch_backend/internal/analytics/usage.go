@@ -0,0 +1,150 @@
+// Package analytics counts requests to the public API by route, consumer and
+// the filters used, so the team can see which feeds and filters are in use.
+// Counts are kept in memory per server instance and added to the stored daily
+// totals on each Flush. Nothing identifies a person: consumers are research
+// tokens or the origin of a web page, and only the names of query parameters
+// are kept, never their values.
+package analytics
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// DefaultMaxKeys bounds the distinct route/consumer/filter combinations held
+// between flushes, so clients sending made-up origins or parameters can't grow
+// memory without limit. Requests past it are counted under OtherConsumer.
+const DefaultMaxKeys = 10000
+
+// OtherConsumer counts requests once DefaultMaxKeys combinations are held
+const OtherConsumer = "other"
+
+// maxFilters is the most query parameter names kept per request
+const maxFilters = 10
+
+// pagingParams are query parameters that page through results rather than filter them
+var pagingParams = map[string]bool{
+	"limit": true, "offset": true, "cursor": true, "page": true, "pageSize": true,
+}
+
+// usageKey identifies one counter; it matches models.APIUsage without the counts
+type usageKey struct {
+	day, route, consumer, filters string
+}
+
+// Recorder accumulates request counts until they are flushed to storage.
+// A nil Recorder records nothing.
+type Recorder struct {
+	store   storage.Client
+	maxKeys int
+
+	mu     sync.Mutex
+	counts map[usageKey]*models.APIUsage
+}
+
+// NewRecorder creates a recorder that flushes to store
+func NewRecorder(store storage.Client) *Recorder {
+	return &Recorder{
+		store:   store,
+		maxKeys: DefaultMaxKeys,
+		counts:  map[usageKey]*models.APIUsage{},
+	}
+}
+
+// Record counts one request to route (method and route pattern) by consumer at
+// at, with the request's query and the response status
+func (r *Recorder) Record(at time.Time, route, consumer string, query url.Values, status int) {
+	if r == nil {
+		return
+	}
+	key := usageKey{day: models.UsageDay(at), route: route, consumer: consumer, filters: Filters(query)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.counts[key]
+	if !ok && len(r.counts) >= r.maxKeys {
+		key.consumer, key.filters = OtherConsumer, ""
+		u, ok = r.counts[key]
+	}
+	if !ok {
+		u = &models.APIUsage{Day: key.day, Route: key.route, Consumer: key.consumer, Filters: key.filters}
+		r.counts[key] = u
+	}
+	u.Requests++
+	if status >= 400 {
+		u.Errors++
+	}
+}
+
+// Flush adds the counts recorded since the last flush to storage. If that
+// fails they are kept for the next flush, as far as the key limit allows.
+func (r *Recorder) Flush(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	pending := r.counts
+	r.counts = map[usageKey]*models.APIUsage{}
+	r.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	usage := make([]models.APIUsage, 0, len(pending))
+	for _, u := range pending {
+		usage = append(usage, *u)
+	}
+	if err := r.store.AddAPIUsage(ctx, usage); err != nil {
+		r.restore(pending)
+		return err
+	}
+	return nil
+}
+
+// restore merges counts that failed to flush back into the recorder
+func (r *Recorder) restore(pending map[usageKey]*models.APIUsage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, u := range pending {
+		if current, ok := r.counts[key]; ok {
+			current.Requests += u.Requests
+			current.Errors += u.Errors
+		} else if len(r.counts) < r.maxKeys {
+			r.counts[key] = u
+		}
+	}
+}
+
+// Filters returns the sorted, comma-separated names of the query parameters
+// that filter a request, leaving out paging parameters and unusual names
+func Filters(query url.Values) string {
+	var names []string
+	for name := range query {
+		if pagingParams[name] || !plainName(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return strings.Join(names[:min(len(names), maxFilters)], ",")
+}
+
+// plainName reports whether a query parameter name is short and alphanumeric
+func plainName(name string) bool {
+	if name == "" || len(name) > 32 {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package analytics
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// usageStore collects flushed usage, failing while err is set
+type usageStore struct {
+	storage.Client
+	flushed []models.APIUsage
+	err     error
+}
+
+func (s *usageStore) AddAPIUsage(ctx context.Context, usage []models.APIUsage) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.flushed = append(s.flushed, usage...)
+	return nil
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"state=Ohio&severity=fatality", "severity,state"},
+		{"limit=5&cursor=abc&offset=10", ""},
+		{"state=Ohio&state=Texas", "state"},
+		{"%3Cscript%3E=1&city=x", "city"},
+		{"a=1&b=1&c=1&d=1&e=1&f=1&g=1&h=1&i=1&j=1&k=1", "a,b,c,d,e,f,g,h,i,j"},
+	}
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+		if got := Filters(query); got != tt.want {
+			t.Errorf("Filters(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestRecorder_CountsAndFlushes(t *testing.T) {
+	store := &usageStore{}
+	r := NewRecorder(store)
+	day := time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC)
+	ohio := url.Values{"state": {"Ohio"}}
+
+	r.Record(day, "GET /v1/public/reports", "direct", ohio, 200)
+	r.Record(day, "GET /v1/public/reports", "direct", ohio, 500)
+	r.Record(day.Add(2*time.Hour), "GET /v1/public/reports", "direct", ohio, 200) // Next UTC day
+	r.Record(day, "GET /v1/public/stats", "origin:example.com", nil, 200)
+
+	if err := r.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(store.flushed) != 3 {
+		t.Fatalf("flushed %d rows, want 3: %+v", len(store.flushed), store.flushed)
+	}
+	for _, u := range store.flushed {
+		if u.Route == "GET /v1/public/reports" && u.Day == "2024-05-01" && (u.Requests != 2 || u.Errors != 1 || u.Filters != "state") {
+			t.Errorf("reports usage = %+v", u)
+		}
+	}
+
+	// Nothing new to flush
+	store.flushed = nil
+	if err := r.Flush(context.Background()); err != nil || len(store.flushed) != 0 {
+		t.Errorf("empty flush wrote %+v, %v", store.flushed, err)
+	}
+}
+
+func TestRecorder_KeepsCountsWhenFlushFails(t *testing.T) {
+	store := &usageStore{err: errors.New("database down")}
+	r := NewRecorder(store)
+	now := time.Now()
+
+	r.Record(now, "GET /v1/public/stats", "direct", nil, 200)
+	if err := r.Flush(context.Background()); err == nil {
+		t.Fatal("expected the flush to fail")
+	}
+	r.Record(now, "GET /v1/public/stats", "direct", nil, 200)
+
+	store.err = nil
+	if err := r.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(store.flushed) != 1 || store.flushed[0].Requests != 2 {
+		t.Errorf("flushed = %+v", store.flushed)
+	}
+}
+
+func TestRecorder_CapsDistinctKeys(t *testing.T) {
+	store := &usageStore{}
+	r := NewRecorder(store)
+	r.maxKeys = 2
+	now := time.Now()
+
+	for _, origin := range []string{"a.example", "b.example", "c.example", "d.example"} {
+		r.Record(now, "GET /v1/public/stats", "origin:"+origin, nil, 200)
+	}
+	if err := r.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	var other int64
+	for _, u := range store.flushed {
+		if u.Consumer == OtherConsumer {
+			other += u.Requests
+		}
+	}
+	if len(store.flushed) != 3 || other != 2 {
+		t.Errorf("flushed = %+v", store.flushed)
+	}
+}
+
+func TestRecorder_Nil(t *testing.T) {
+	var r *Recorder
+	r.Record(time.Now(), "GET /v1/public/stats", "direct", nil, 200)
+	if err := r.Flush(context.Background()); err != nil {
+		t.Errorf("Flush on nil recorder: %v", err)
+	}
+}
@@ -0,0 +1,393 @@
+// Package api wires handlers and middleware into the HTTP router.
+package api
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/analytics"
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/errreport"
+	"donzhit_me_backend/internal/handlers"
+	"donzhit_me_backend/internal/metrics"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/ratelimit"
+	"donzhit_me_backend/internal/storage"
+)
+
+// Dependencies holds the services and handlers the routes are built from
+type Dependencies struct {
+	Storage        storage.Client
+	JWTService     *auth.JWTService
+	IAPValidator   *auth.IAPValidator
+	HealthHandler  *handlers.HealthHandler
+	ReportsHandler *handlers.ReportsHandler
+	AuthHandler    *handlers.AuthHandler
+	Announcements  *handlers.AnnouncementsHandler
+	FlagsHandler   *handlers.FlagsHandler
+	MetricsHandler *handlers.MetricsHandler // Optional
+	// ResearchRateLimit throttles each research token; nil leaves only the daily quota
+	ResearchRateLimit *ratelimit.Limiter
+	// ErrorReporter receives panics and 5xx responses; nil only logs them
+	ErrorReporter errreport.Reporter
+	// DebugLogging logs redacted request and response bodies; for troubleshooting only
+	DebugLogging bool
+	// APIUsage counts requests to the public, feed and research routes; nil counts nothing
+	APIUsage *analytics.Recorder
+	// DevBlobs serves the dev-mode blob store's signed URLs; nil outside dev mode
+	DevBlobs *storage.LocalBlobStore
+	Legacy   LegacyRoutes
+	CORS     CORSPolicies
+	// BodyLimits caps request body sizes; the zero value leaves them unlimited
+	BodyLimits BodyLimits
+}
+
+// BodyLimits caps request body sizes in bytes per kind of route. A zero limit
+// leaves that kind of route unlimited.
+type BodyLimits struct {
+	Default int64 // JSON and form endpoints; bodies are buffered to enforce it
+	Media   int64 // Routes that take media uploads (see mediaUploadRoutes); streamed
+}
+
+// mediaUploadRoutes are the routes whose bodies may carry media files
+var mediaUploadRoutes = map[string]bool{
+	"/v1/reports":                   true, // Multipart report creation
+	"/v1/legacy/reports":            true,
+	storage.LocalBlobPath + "*path": true, // Dev-mode signed upload URLs
+}
+
+// middleware picks the limit for the matched route
+func (l BodyLimits) middleware() gin.HandlerFunc {
+	var defaultLimit, mediaLimit gin.HandlerFunc
+	if l.Default > 0 {
+		defaultLimit = middleware.RequestSizeLimit(l.Default)
+	}
+	if l.Media > 0 {
+		mediaLimit = middleware.StreamingSizeLimit(l.Media)
+	}
+	return func(c *gin.Context) {
+		limit := defaultLimit
+		if mediaUploadRoutes[c.FullPath()] {
+			limit = mediaLimit
+		}
+		if limit == nil {
+			c.Next()
+			return
+		}
+		limit(c)
+	}
+}
+
+// CORSPolicies configures CORS per route group. A zero Default uses
+// middleware.DefaultCORSConfig(); nil group configs fall back to Default.
+type CORSPolicies struct {
+	Default middleware.CORSConfig
+	Admin   *middleware.CORSConfig // /v1/admin
+	Public  *middleware.CORSConfig // /v1/public and /v2/public
+}
+
+// middleware builds the CORS middleware with the per-group overrides
+func (p CORSPolicies) middleware() gin.HandlerFunc {
+	def := p.Default
+	if def.AllowedOrigins == nil {
+		def = middleware.DefaultCORSConfig()
+	}
+	var groups []middleware.CORSGroup
+	if p.Admin != nil {
+		groups = append(groups, middleware.CORSGroup{PathPrefix: "/v1/admin", Config: *p.Admin})
+	}
+	if p.Public != nil {
+		groups = append(groups,
+			middleware.CORSGroup{PathPrefix: "/v1/public", Config: *p.Public},
+			middleware.CORSGroup{PathPrefix: "/v2/public", Config: *p.Public},
+		)
+	}
+	return middleware.CORS(def, groups...)
+}
+
+// LegacyRoutes configures the deprecated /v1/legacy group (Google token auth)
+type LegacyRoutes struct {
+	Disabled bool              // Answer 410 Gone instead of serving the routes
+	Sunset   time.Time         // Advertised in the Sunset header when set
+	Metrics  *metrics.Registry // Optional per-route usage metrics
+}
+
+// NewRouter creates the Gin engine with global middleware and all API routes registered
+func NewRouter(deps Dependencies) *gin.Engine {
+	router := gin.New()
+
+	// Global middleware
+	reporter := deps.ErrorReporter
+	if reporter == nil {
+		reporter = errreport.LogReporter{}
+	}
+	router.Use(middleware.Recovery(reporter))
+	router.Use(gin.Logger())
+	if deps.DebugLogging {
+		router.Use(middleware.DebugLogging())
+	}
+	router.Use(deps.CORS.middleware())
+	router.Use(middleware.TrackDeadlines())
+	router.Use(deps.BodyLimits.middleware())
+
+	// Uploads and downloads of media kept on local disk in dev mode
+	if deps.DevBlobs != nil {
+		router.GET(storage.LocalBlobPath+"*path", gin.WrapH(deps.DevBlobs))
+		router.PUT(storage.LocalBlobPath+"*path", gin.WrapH(deps.DevBlobs))
+	}
+
+	// API v1 routes
+	v1 := router.Group("/v1")
+	{
+		// Health check (no auth required)
+		v1.GET("/health", deps.HealthHandler.Health)
+
+		// Public endpoints (no auth required)
+		publicGroup := v1.Group("/public")
+		publicGroup.Use(middleware.TrackAPIUsage(deps.APIUsage))
+		{
+			publicGroup.GET("/reports", deps.ReportsHandler.ListApprovedReports)
+			publicGroup.GET("/reports/clusters", deps.ReportsHandler.ListReportClusters)
+			publicGroup.GET("/reports/archive", deps.ReportsHandler.ListArchivedReports)
+			publicGroup.GET("/reports/:id/map", deps.ReportsHandler.GetReportMapImage)
+			publicGroup.GET("/reports/:id/share", deps.ReportsHandler.GetReportSharePage)
+			publicGroup.GET("/reports/:id/thumbnail", deps.ReportsHandler.GetReportThumbnail)
+			publicGroup.GET("/stats", deps.ReportsHandler.GetReportStats)
+			publicGroup.GET("/widget", deps.ReportsHandler.GetWidget)
+			publicGroup.GET("/config", deps.ReportsHandler.GetPublicConfig)
+			publicGroup.GET("/cities", deps.ReportsHandler.SuggestCities)
+			publicGroup.GET("/announcements", deps.Announcements.ListActive)
+			publicGroup.GET("/notifications/unsubscribe", deps.AuthHandler.ConfirmUnsubscribeDigest)
+			publicGroup.POST("/notifications/unsubscribe", deps.AuthHandler.UnsubscribeDigest)
+			publicGroup.GET("/drafts/discard", deps.ReportsHandler.ConfirmDiscardDraft)
+			publicGroup.POST("/drafts/discard", deps.ReportsHandler.DiscardDraft)
+		}
+
+		// Public endpoints with optional auth (for user-specific data like "did I react?")
+		publicOptionalAuth := v1.Group("/public")
+		publicOptionalAuth.Use(middleware.TrackAPIUsage(deps.APIUsage))
+		publicOptionalAuth.Use(middleware.OptionalJWTAuth(deps.JWTService, deps.Storage))
+		{
+			publicOptionalAuth.GET("/reports/:id/engagement", deps.ReportsHandler.GetReportEngagement)
+			publicOptionalAuth.GET("/reports/:id/comments", deps.ReportsHandler.GetComments)
+			publicOptionalAuth.GET("/flags", deps.FlagsHandler.Evaluate)
+			publicOptionalAuth.POST("/reports/engagement", deps.ReportsHandler.GetBulkEngagement)
+		}
+
+		// Personalized feed: local reports first for signed-in users, global feed otherwise
+		feedGroup := v1.Group("")
+		feedGroup.Use(middleware.TrackAPIUsage(deps.APIUsage))
+		feedGroup.Use(middleware.OptionalJWTAuth(deps.JWTService, deps.Storage))
+		{
+			feedGroup.GET("/reports/feed", deps.ReportsHandler.PersonalizedFeed)
+		}
+
+		// Auth endpoints (login requires Google token, not JWT)
+		authGroup := v1.Group("/auth")
+		{
+			authGroup.POST("/login", deps.AuthHandler.Login)
+		}
+
+		// Protected auth endpoints (requires JWT)
+		authProtected := v1.Group("/auth")
+		authProtected.Use(middleware.JWTAuth(deps.JWTService, deps.Storage))
+		{
+			authProtected.GET("/me", deps.AuthHandler.GetCurrentUser)
+			authProtected.GET("/me/comments", deps.ReportsHandler.ListMyComments)
+			authProtected.GET("/me/reactions", deps.ReportsHandler.ListMyReactions)
+			authProtected.GET("/me/notifications", deps.AuthHandler.GetNotificationPreferences)
+			authProtected.PATCH("/me/notifications", deps.AuthHandler.UpdateNotificationPreferences)
+			authProtected.PATCH("/me/profile", deps.AuthHandler.UpdateProfile)
+			authProtected.GET("/me/inbox", deps.AuthHandler.ListInbox)
+			authProtected.POST("/me/inbox/read", deps.AuthHandler.MarkInboxAllRead)
+			authProtected.POST("/me/inbox/:id/read", deps.AuthHandler.MarkInboxRead)
+			authProtected.POST("/me/deactivate", deps.AuthHandler.DeactivateAccount)
+			authProtected.POST("/invitations/accept", deps.AuthHandler.AcceptInvitation)
+			authProtected.POST("/logout", deps.AuthHandler.Logout)
+			authProtected.POST("/refresh", deps.AuthHandler.RefreshToken)
+		}
+
+		// Protected routes with JWT auth (for new JWT-based clients)
+		jwtProtected := v1.Group("")
+		jwtProtected.Use(middleware.JWTAuth(deps.JWTService, deps.Storage))
+		jwtProtected.Use(middleware.RequireRole(models.RoleContributor))
+		{
+			// Reports endpoints
+			jwtProtected.POST("/reports", deps.ReportsHandler.CreateReport)
+			jwtProtected.POST("/reports/sync", deps.ReportsHandler.SyncReports)
+			jwtProtected.GET("/reports", deps.ReportsHandler.ListReports)
+			jwtProtected.GET("/reports/count", deps.ReportsHandler.CountReports)
+			jwtProtected.GET("/reports/:id", deps.ReportsHandler.GetReport)
+			jwtProtected.DELETE("/reports/:id", deps.ReportsHandler.DeleteReport)
+			jwtProtected.POST("/reports/:id/seen", deps.ReportsHandler.MarkReportSeen)
+			jwtProtected.POST("/reports/:id/resubmit", deps.ReportsHandler.ResubmitReport)
+			jwtProtected.GET("/reports/:id/reviews", deps.ReportsHandler.ListReportReviews)
+			jwtProtected.POST("/reports/:id/media/batch-upload-urls", deps.ReportsHandler.BatchUploadURLs)
+			jwtProtected.GET("/uploads/:sessionId", deps.ReportsHandler.GetUploadProgress)
+			jwtProtected.GET("/reports/:id/media/:mediaId/download", deps.ReportsHandler.DownloadMedia)
+
+			// Reactions endpoints (requires auth)
+			jwtProtected.POST("/reports/:id/reactions", deps.ReportsHandler.AddReaction)
+			jwtProtected.PUT("/reports/:id/reactions/:type", deps.ReportsHandler.ToggleReaction)
+			jwtProtected.DELETE("/reports/:id/reactions/:type", deps.ReportsHandler.RemoveReaction)
+
+			// Comments endpoints (requires auth)
+			jwtProtected.POST("/reports/:id/comments", deps.ReportsHandler.AddComment)
+			jwtProtected.DELETE("/reports/:id/comments/:commentId", deps.ReportsHandler.DeleteComment)
+
+			// Report templates (requires auth)
+			jwtProtected.GET("/templates", deps.ReportsHandler.ListTemplates)
+			jwtProtected.POST("/templates", deps.ReportsHandler.CreateTemplate)
+			jwtProtected.PUT("/templates/:id", deps.ReportsHandler.UpdateTemplate)
+			jwtProtected.DELETE("/templates/:id", deps.ReportsHandler.DeleteTemplate)
+
+			// Report drafts (requires auth)
+			jwtProtected.GET("/drafts", deps.ReportsHandler.ListDrafts)
+			jwtProtected.POST("/drafts", deps.ReportsHandler.CreateDraft)
+			jwtProtected.PUT("/drafts/:id", deps.ReportsHandler.UpdateDraft)
+			jwtProtected.DELETE("/drafts/:id", deps.ReportsHandler.DeleteDraft)
+
+			// Blocking endpoints (requires auth)
+			jwtProtected.GET("/blocks", deps.ReportsHandler.ListBlockedUsers)
+			jwtProtected.POST("/blocks/:userId", deps.ReportsHandler.BlockUser)
+			jwtProtected.DELETE("/blocks/:userId", deps.ReportsHandler.UnblockUser)
+		}
+
+		// Moderation routes (requires JWT + moderator role; admins pass too)
+		moderatorGroup := v1.Group("/admin")
+		moderatorGroup.Use(middleware.JWTAuth(deps.JWTService, deps.Storage))
+		moderatorGroup.Use(middleware.RequireRole(models.RoleModerator))
+		{
+			moderatorGroup.GET("/reports/review", deps.ReportsHandler.ListReportsForReview)
+			moderatorGroup.GET("/reports/review/count", deps.ReportsHandler.CountReportsForReview)
+			moderatorGroup.POST("/reports/:id/review", deps.ReportsHandler.ReviewReport)
+			moderatorGroup.GET("/moderation/comments", deps.ReportsHandler.ListFlaggedComments)
+			moderatorGroup.POST("/moderation/comments/:commentId", deps.ReportsHandler.ResolveFlaggedComment)
+		}
+
+		// Admin routes (requires JWT + admin role)
+		adminGroup := v1.Group("/admin")
+		adminGroup.Use(middleware.JWTAuth(deps.JWTService, deps.Storage))
+		adminGroup.Use(middleware.RequireRole(models.RoleAdmin))
+		{
+			adminGroup.GET("/reports", deps.ReportsHandler.ListAllReportsAdmin)
+			adminGroup.GET("/search", deps.ReportsHandler.AdminSearch)
+			adminGroup.GET("/reports/export", deps.ReportsHandler.ExportReports)
+			adminGroup.POST("/reports/import", deps.ReportsHandler.ImportReports)
+			adminGroup.POST("/reports/:id/pin", deps.ReportsHandler.PinReport)
+			adminGroup.DELETE("/reports/:id/pin", deps.ReportsHandler.UnpinReport)
+			adminGroup.PATCH("/reports/:id/priority", deps.ReportsHandler.UpdatePriority)
+			adminGroup.POST("/reports/:id/priority/freeze", deps.ReportsHandler.FreezePriority)
+			adminGroup.DELETE("/reports/:id/priority/freeze", deps.ReportsHandler.UnfreezePriority)
+			adminGroup.GET("/reports/:id/related", deps.ReportsHandler.ListRelatedReports)
+			adminGroup.GET("/reports/:id/media/:mediaId/metadata", deps.ReportsHandler.GetMediaMetadata)
+			adminGroup.DELETE("/reports/:id/ocr", deps.ReportsHandler.PurgeMediaOCR)
+			adminGroup.POST("/reports/:id/merge/:otherId", deps.ReportsHandler.MergeReports)
+			adminGroup.POST("/incidents", deps.ReportsHandler.LinkIncident)
+			adminGroup.GET("/incidents/:id", deps.ReportsHandler.GetIncident)
+			adminGroup.GET("/moderation/words", deps.ReportsHandler.ListBlockedWords)
+			adminGroup.POST("/moderation/words", deps.ReportsHandler.UpsertBlockedWord)
+			adminGroup.DELETE("/moderation/words/:word", deps.ReportsHandler.DeleteBlockedWord)
+			adminGroup.GET("/moderation/comment-policy", deps.ReportsHandler.GetCommentPolicy)
+			adminGroup.PUT("/moderation/comment-policy", deps.ReportsHandler.UpdateCommentPolicy)
+			adminGroup.PUT("/users/:id/shadow-ban", deps.ReportsHandler.SetUserShadowBan)
+			adminGroup.PUT("/users/:id/suspend", deps.ReportsHandler.SetUserSuspended)
+			adminGroup.PUT("/users/:id/role", deps.AuthHandler.SetUserRole)
+			adminGroup.GET("/invitations", deps.AuthHandler.ListInvitations)
+			adminGroup.POST("/invitations", deps.AuthHandler.CreateInvitation)
+			adminGroup.GET("/audit", deps.AuthHandler.ListAuditLog)
+			adminGroup.GET("/research-tokens", deps.AuthHandler.ListResearchTokens)
+			adminGroup.POST("/research-tokens", deps.AuthHandler.CreateResearchToken)
+			adminGroup.DELETE("/research-tokens/:id", deps.AuthHandler.RevokeResearchToken)
+			adminGroup.GET("/research-tokens/:id/usage", deps.AuthHandler.GetResearchTokenUsage)
+			adminGroup.GET("/users/export", deps.ReportsHandler.ExportUsers)
+			adminGroup.GET("/media/failures", deps.ReportsHandler.ListMediaFailures)
+			adminGroup.POST("/media/failures/:id/retry", deps.ReportsHandler.RetryMediaFailure)
+			adminGroup.GET("/announcements", deps.Announcements.List)
+			adminGroup.POST("/announcements", deps.Announcements.Create)
+			adminGroup.PUT("/announcements/:id", deps.Announcements.Update)
+			adminGroup.DELETE("/announcements/:id", deps.Announcements.Delete)
+			adminGroup.GET("/reactions", deps.ReportsHandler.ListReactionTypes)
+			adminGroup.PUT("/reactions/:type", deps.ReportsHandler.UpsertReactionType)
+			adminGroup.DELETE("/reactions/:type", deps.ReportsHandler.RetireReactionType)
+			adminGroup.GET("/flags", deps.FlagsHandler.List)
+			adminGroup.PUT("/flags/:key", deps.FlagsHandler.Upsert)
+			adminGroup.DELETE("/flags/:key", deps.FlagsHandler.Delete)
+			adminGroup.GET("/analytics/usage", deps.ReportsHandler.GetAPIUsage)
+			if deps.MetricsHandler != nil {
+				adminGroup.GET("/metrics/storage", deps.MetricsHandler.StorageMetrics)
+				adminGroup.GET("/metrics/legacy", deps.MetricsHandler.LegacyMetrics)
+			}
+		}
+
+		// Read-only access to the approved dataset for research token holders
+		researchGroup := v1.Group("/research")
+		researchGroup.Use(middleware.TrackAPIUsage(deps.APIUsage))
+		researchGroup.Use(middleware.ResearchTokenAuth(deps.Storage, deps.ResearchRateLimit))
+		{
+			researchGroup.GET("/reports", deps.ReportsHandler.ListApprovedReports)
+			researchGroup.GET("/reports/export", deps.ReportsHandler.ExportResearchReports)
+			researchGroup.GET("/stats", deps.ReportsHandler.GetReportStats)
+		}
+
+		// Legacy protected routes with Google token auth (for backwards compatibility).
+		// Deprecated: responses carry Deprecation/Sunset headers pointing at the
+		// JWT routes, and the group can be switched off once its metrics show no traffic.
+		if deps.Legacy.Disabled {
+			v1.Any("/legacy/*path", legacyGone)
+		} else {
+			legacyProtected := v1.Group("/legacy")
+			if deps.Legacy.Metrics != nil {
+				legacyProtected.Use(middleware.RouteMetrics(deps.Legacy.Metrics))
+			}
+			legacyProtected.Use(middleware.Deprecated(deps.Legacy.Sunset, legacySuccessor))
+			legacyProtected.Use(middleware.IAPAuth(deps.IAPValidator))
+			{
+				legacyProtected.POST("/reports", deps.ReportsHandler.CreateReport)
+				legacyProtected.GET("/reports", deps.ReportsHandler.ListReports)
+				legacyProtected.GET("/reports/:id", deps.ReportsHandler.GetReport)
+				legacyProtected.DELETE("/reports/:id", deps.ReportsHandler.DeleteReport)
+			}
+		}
+	}
+
+	// API v2 routes: cursor-paginated list envelopes and RFC 7807 errors.
+	// Served by the same handler logic as /v1, which stays unchanged.
+	v2Handler := handlers.NewV2Handler(deps.ReportsHandler)
+	v2 := router.Group("/v2")
+	{
+		v2.GET("/health", deps.HealthHandler.Health)
+
+		v2Public := v2.Group("/public")
+		v2Public.Use(middleware.TrackAPIUsage(deps.APIUsage))
+		v2Public.Use(middleware.OptionalJWTAuth(deps.JWTService, deps.Storage))
+		{
+			v2Public.GET("/reports", v2Handler.ListApprovedReports)
+			v2Public.GET("/reports/:id/comments", v2Handler.GetComments)
+		}
+
+		v2Protected := v2.Group("")
+		v2Protected.Use(middleware.JWTAuth(deps.JWTService, deps.Storage))
+		v2Protected.Use(middleware.RequireRole(models.RoleContributor))
+		{
+			v2Protected.GET("/reports", v2Handler.ListReports)
+		}
+	}
+
+	return router
+}
+
+// legacySuccessor maps a /v1/legacy path to the equivalent JWT route
+func legacySuccessor(path string) string {
+	return strings.Replace(path, "/v1/legacy", "/v1", 1)
+}
+
+// legacyGone answers requests to the legacy routes once they are disabled
+func legacyGone(c *gin.Context) {
+	apierror.Respond(c, http.StatusGone, apierror.Gone, "Legacy Google token routes have been retired; sign in via /v1/auth and use "+legacySuccessor(c.Request.URL.Path))
+}
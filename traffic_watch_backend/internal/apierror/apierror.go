@@ -0,0 +1,105 @@
+// Package apierror is the catalog of machine-readable error codes returned by
+// the API, and the helpers that write them. Every error response has the shape
+//
+//	{"error": "<code>", "message": "<human-readable>", "details": [...]}
+//
+// Clients should branch on "error" (and, for validation failures, on each
+// detail's "reason"); "message" is for people and may change. Clients that
+// send "Accept: application/problem+json" get the same information as an
+// RFC 7807 problem instead.
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/deadline"
+)
+
+// Code identifies the kind of failure
+type Code string
+
+// Request errors (4xx)
+const (
+	Validation      Code = "validation_error"  // 400: request failed validation; see details
+	NotFound        Code = "not_found"         // 404: resource doesn't exist or isn't visible to the caller
+	Conflict        Code = "conflict"          // 409: request conflicts with current state
+	Gone            Code = "gone"              // 410: resource was used up (e.g. an accepted invitation)
+	Expired         Code = "expired"           // 410: resource has expired
+	RequestTooLarge Code = "request_too_large" // 413: body exceeds the size limit
+)
+
+// Authentication and authorization errors
+const (
+	Unauthorized       Code = "unauthorized"        // 401: missing, malformed or revoked credentials
+	InvalidToken       Code = "invalid_token"       // 401: identity token failed verification
+	SessionExpired     Code = "session_expired"     // 401: refresh token expired; sign in again
+	CSRFFailed         Code = "csrf_failed"         // 403: missing or mismatched CSRF token
+	Forbidden          Code = "forbidden"           // 403: authenticated but not allowed
+	AccountSuspended   Code = "account_suspended"   // 403: suspended by an admin
+	AccountDeactivated Code = "account_deactivated" // 403: deactivated; sign in again to reactivate
+)
+
+// Quota errors
+const (
+	RateLimited   Code = "rate_limited"   // 429: too many requests; retry after the Retry-After header
+	QuotaExceeded Code = "quota_exceeded" // 409: a per-user limit was reached; free something up first
+)
+
+// Server errors (5xx). Safe to retry unless noted.
+const (
+	FetchFailed           Code = "fetch_failed"
+	CreateFailed          Code = "create_failed"
+	UpdateFailed          Code = "update_failed"
+	DeleteFailed          Code = "delete_failed"
+	UploadFailed          Code = "upload_failed"
+	UserCreationFailed    Code = "user_creation_failed"
+	TokenGenerationFailed Code = "token_generation_failed"
+	LogoutFailed          Code = "logout_failed"
+	Unavailable           Code = "unavailable" // 503: a dependency isn't configured or reachable
+	Timeout               Code = "timeout"     // 504: a storage call took too long
+	Internal              Code = "internal_error"
+)
+
+// Body is the JSON error response
+type Body struct {
+	Error   Code         `json:"error"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// Respond writes an error response
+func Respond(c *gin.Context, status int, code Code, message string) {
+	write(c, status, Body{Error: code, Message: message}, false)
+}
+
+// Abort writes an error response and stops the handler chain
+func Abort(c *gin.Context, status int, code Code, message string) {
+	write(c, status, Body{Error: code, Message: message}, true)
+}
+
+// write sends body as plain JSON, or as an RFC 7807 problem when the
+// client's Accept header prefers one. A 500 caused by a storage timeout
+// becomes a 504 with the Timeout code. Server errors are also attached to
+// the context, for the error reporter to describe what failed.
+func write(c *gin.Context, status int, body Body, abort bool) {
+	if status == http.StatusInternalServerError && c.Request != nil && deadline.Exceeded(c.Request.Context()) {
+		status = http.StatusGatewayTimeout
+		body.Error = Timeout
+	}
+	if status >= http.StatusInternalServerError {
+		_ = c.Error(fmt.Errorf("%s: %s", body.Error, body.Message))
+	}
+	var payload interface{} = body
+	if WantsProblem(c) {
+		c.Header("Content-Type", ProblemContentType)
+		payload = newProblem(c, status, body)
+	}
+	if abort {
+		c.AbortWithStatusJSON(status, payload)
+		return
+	}
+	c.JSON(status, payload)
+}
@@ -0,0 +1,43 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/deadline"
+)
+
+func TestRespond_StorageTimeoutBecomes504(t *testing.T) {
+	respond := func(timedOut bool, status int) (int, Code) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/v1/reports", nil)
+		ctx := deadline.Track(c.Request.Context())
+		if timedOut {
+			deadline.MarkExceeded(ctx)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		Respond(c, status, FetchFailed, "failed to fetch reports")
+
+		var body Body
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatal(err)
+		}
+		return w.Code, body.Error
+	}
+
+	if status, code := respond(true, http.StatusInternalServerError); status != http.StatusGatewayTimeout || code != Timeout {
+		t.Errorf("timed out: got %d %q, want 504 %q", status, code, Timeout)
+	}
+	if status, code := respond(false, http.StatusInternalServerError); status != http.StatusInternalServerError || code != FetchFailed {
+		t.Errorf("no timeout: got %d %q, want 500 %q", status, code, FetchFailed)
+	}
+	// Only 500s are rewritten; e.g. a 503 for a missing dependency stays as is
+	if status, _ := respond(true, http.StatusServiceUnavailable); status != http.StatusServiceUnavailable {
+		t.Errorf("503 rewritten to %d", status)
+	}
+}
@@ -0,0 +1,48 @@
+package apierror
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// ProblemContentType is the media type for RFC 7807 error responses
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error body. Code carries the same value /v1 returns
+// in its "error" field; InvalidFields carries validation details.
+type Problem struct {
+	Type          string       `json:"type"`
+	Title         string       `json:"title"`
+	Status        int          `json:"status"`
+	Detail        string       `json:"detail,omitempty"`
+	Instance      string       `json:"instance,omitempty"`
+	Code          Code         `json:"code"`
+	InvalidFields []FieldError `json:"invalidFields,omitempty"`
+}
+
+// WantsProblem reports whether the client asked for problem+json ahead of
+// plain JSON. Clients that send no Accept header, or */*, get plain JSON.
+func WantsProblem(c *gin.Context) bool {
+	return c.NegotiateFormat(binding.MIMEJSON, ProblemContentType) == ProblemContentType
+}
+
+// AbortProblem aborts the request with an RFC 7807 body regardless of Accept
+func AbortProblem(c *gin.Context, status int, code Code, detail string, fields ...FieldError) {
+	c.Header("Content-Type", ProblemContentType)
+	c.AbortWithStatusJSON(status, newProblem(c, status, Body{Error: code, Message: detail, Details: fields}))
+}
+
+// newProblem converts an error body into a problem
+func newProblem(c *gin.Context, status int, body Body) Problem {
+	return Problem{
+		Type:          "about:blank",
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        body.Message,
+		Instance:      c.Request.URL.Path,
+		Code:          body.Error,
+		InvalidFields: body.Details,
+	}
+}
@@ -0,0 +1,66 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func respondWithAccept(accept string, respond func(c *gin.Context)) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/reports/abc", nil)
+	if accept != "" {
+		c.Request.Header.Set("Accept", accept)
+	}
+	respond(c)
+	return w
+}
+
+func TestRespond_NegotiatesProblemJSON(t *testing.T) {
+	notFound := func(c *gin.Context) { Respond(c, http.StatusNotFound, NotFound, "report not found") }
+
+	tests := []struct {
+		accept      string
+		wantProblem bool
+	}{
+		{"", false},
+		{"*/*", false},
+		{"application/json", false},
+		{ProblemContentType, true},
+		{"application/problem+json, application/json;q=0.9", true},
+	}
+	for _, tt := range tests {
+		w := respondWithAccept(tt.accept, notFound)
+		isProblem := w.Header().Get("Content-Type") == ProblemContentType
+		if isProblem != tt.wantProblem {
+			t.Errorf("Accept %q: problem = %v, want %v", tt.accept, isProblem, tt.wantProblem)
+		}
+	}
+
+	w := respondWithAccept(ProblemContentType, notFound)
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if p.Status != http.StatusNotFound || p.Code != NotFound || p.Title != "Not Found" ||
+		p.Detail != "report not found" || p.Instance != "/v1/reports/abc" {
+		t.Errorf("unexpected problem: %+v", p)
+	}
+}
+
+func TestRespondField_ProblemCarriesInvalidFields(t *testing.T) {
+	w := respondWithAccept(ProblemContentType, func(c *gin.Context) {
+		RespondField(c, "id", ReasonInvalidFormat, "invalid report ID format")
+	})
+	var p Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &p); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if len(p.InvalidFields) != 1 || p.InvalidFields[0].Field != "id" || p.InvalidFields[0].Reason != ReasonInvalidFormat {
+		t.Errorf("expected invalidFields for id, got %+v", p.InvalidFields)
+	}
+}
@@ -0,0 +1,125 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// Reason is the validation_error subcode for one field
+type Reason string
+
+// Validation reasons
+const (
+	ReasonRequired      Reason = "required"       // Missing or empty
+	ReasonInvalidFormat Reason = "invalid_format" // Wrong shape (UUID, timestamp, zone name, ...)
+	ReasonNotAllowed    Reason = "not_allowed"    // Not one of the allowed values
+	ReasonTooShort      Reason = "too_short"      // Below the minimum length or count
+	ReasonTooLong       Reason = "too_long"       // Above the maximum length or count
+	ReasonOutOfRange    Reason = "out_of_range"   // Numeric or time value outside the allowed range
+	ReasonInvalid       Reason = "invalid"        // Any other rule
+)
+
+// FieldError describes why one request field was rejected. Field uses the
+// JSON name, with indexes for list items (e.g. "reports[2].title").
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Reason  Reason `json:"reason"`
+	Message string `json:"message"`
+}
+
+// RespondField writes a validation_error for a single field
+func RespondField(c *gin.Context, field string, reason Reason, message string) {
+	write(c, http.StatusBadRequest, Body{
+		Error:   Validation,
+		Message: message,
+		Details: []FieldError{{Field: field, Reason: reason, Message: message}},
+	}, false)
+}
+
+// RespondValidation writes a validation_error for a binding failure, with a
+// detail per rejected field
+func RespondValidation(c *gin.Context, err error) {
+	details := FieldErrors(err)
+	messages := make([]string, len(details))
+	for i, d := range details {
+		messages[i] = d.Message
+	}
+	write(c, http.StatusBadRequest, Body{
+		Error:   Validation,
+		Message: strings.Join(messages, "; "),
+		Details: details,
+	}, false)
+}
+
+// FieldErrors converts a binding error into field details. Errors that
+// aren't about a particular field (e.g. malformed JSON) yield one detail
+// without a field.
+func FieldErrors(err error) []FieldError {
+	var verrs validator.ValidationErrors
+	if errors.As(err, &verrs) {
+		details := make([]FieldError, 0, len(verrs))
+		for _, fe := range verrs {
+			details = append(details, fieldError(fe))
+		}
+		return details
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Reason:  ReasonInvalidFormat,
+			Message: fmt.Sprintf("%s must be a %s", typeErr.Field, typeErr.Type),
+		}}
+	}
+
+	return []FieldError{{Reason: ReasonInvalidFormat, Message: err.Error()}}
+}
+
+// fieldError maps a validator rule to a reason and message
+func fieldError(fe validator.FieldError) FieldError {
+	field := fe.Namespace()
+	// Drop the struct name: "CreateReportRequest.title" -> "title"
+	if i := strings.Index(field, "."); i >= 0 {
+		field = field[i+1:]
+	}
+
+	d := FieldError{Field: field}
+	switch fe.Tag() {
+	case "required", "required_with":
+		d.Reason, d.Message = ReasonRequired, field+" is required"
+	case "min":
+		d.Reason, d.Message = ReasonTooShort, fmt.Sprintf("%s must be at least %s%s", field, fe.Param(), unit(fe))
+	case "max":
+		d.Reason, d.Message = ReasonTooLong, fmt.Sprintf("%s must be at most %s%s", field, fe.Param(), unit(fe))
+	case "gt", "gte", "lt", "lte":
+		d.Reason, d.Message = ReasonOutOfRange, fmt.Sprintf("%s is out of range (%s %s)", field, fe.Tag(), fe.Param())
+	case "notfuture":
+		d.Reason, d.Message = ReasonOutOfRange, field+" cannot be in the future"
+	case "oneof", "roadusage", "eventtype", "stateorprovince":
+		d.Reason, d.Message = ReasonNotAllowed, fmt.Sprintf("%s %q is not an allowed value", field, fmt.Sprint(fe.Value()))
+	case "uuid", "email", "url", "timezone", "datetime":
+		d.Reason, d.Message = ReasonInvalidFormat, fmt.Sprintf("%s must be a valid %s", field, fe.Tag())
+	default:
+		d.Reason, d.Message = ReasonInvalid, fmt.Sprintf("%s failed the %s rule", field, fe.Tag())
+	}
+	return d
+}
+
+// unit names what min/max count for the field's kind
+func unit(fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	}
+	return ""
+}
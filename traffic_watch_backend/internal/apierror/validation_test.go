@@ -0,0 +1,83 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/validation"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+	_ = validation.RegisterCustomValidators()
+}
+
+type testRequest struct {
+	Title string   `json:"title" binding:"required,max=5"`
+	State string   `json:"state" binding:"required,stateorprovince"`
+	Tags  []string `json:"tags" binding:"max=1"`
+}
+
+func bindAndRespond(t *testing.T, body string) Body {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var req testRequest
+	if err := c.ShouldBindJSON(&req); err == nil {
+		t.Fatal("expected binding to fail")
+	} else {
+		RespondValidation(c, err)
+	}
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+	var resp Body
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestRespondValidation_FieldDetails(t *testing.T) {
+	resp := bindAndRespond(t, `{"title": "too long", "state": "Atlantis", "tags": ["a", "b"]}`)
+	if resp.Error != Validation {
+		t.Errorf("expected %s, got %s", Validation, resp.Error)
+	}
+
+	want := map[string]Reason{
+		"title": ReasonTooLong,
+		"state": ReasonNotAllowed,
+		"tags":  ReasonTooLong,
+	}
+	if len(resp.Details) != len(want) {
+		t.Fatalf("expected %d details, got %+v", len(want), resp.Details)
+	}
+	for _, d := range resp.Details {
+		if want[d.Field] != d.Reason {
+			t.Errorf("field %q: expected reason %s, got %s", d.Field, want[d.Field], d.Reason)
+		}
+	}
+}
+
+func TestRespondValidation_Required(t *testing.T) {
+	resp := bindAndRespond(t, `{"state": "Ohio"}`)
+	if len(resp.Details) != 1 || resp.Details[0].Field != "title" || resp.Details[0].Reason != ReasonRequired {
+		t.Errorf("expected title required, got %+v", resp.Details)
+	}
+}
+
+func TestRespondValidation_MalformedBody(t *testing.T) {
+	resp := bindAndRespond(t, `{"title": 5}`)
+	if len(resp.Details) != 1 || resp.Details[0].Reason != ReasonInvalidFormat {
+		t.Errorf("expected one invalid_format detail, got %+v", resp.Details)
+	}
+}
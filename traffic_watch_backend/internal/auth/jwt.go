@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,33 +13,111 @@ import (
 )
 
 const (
-	// TokenExpiry is 1 year for long-lived tokens
-	TokenExpiry = 365 * 24 * time.Hour
+	// PlaceholderSecret is the development default for JWT_SECRET; it is rejected outside dev mode
+	PlaceholderSecret = "change-this-in-production-use-256-bit-key"
+
+	// MinSecretLength is the shortest HS256 secret accepted outside dev mode (256 bits)
+	MinSecretLength = 32
+	// MaxClockSkew bounds the allowed clock-skew tolerance outside dev mode
+	MaxClockSkew = 5 * time.Minute
 )
 
+// ErrSessionExpired is returned when a token can no longer be refreshed
+var ErrSessionExpired = errors.New("session expired")
+
 // JWTClaims represents the custom claims in the JWT
 type JWTClaims struct {
-	UserID       string          `json:"user_id"`
-	Email        string          `json:"email"`
-	Role         models.UserRole `json:"role"`
-	RefreshToken string          `json:"refresh_token"` // For invalidation
+	UserID       string           `json:"user_id"`
+	Email        string           `json:"email"`
+	Role         models.UserRole  `json:"role"`
+	RefreshToken string           `json:"refresh_token"`       // For invalidation
+	AuthTime     *jwt.NumericDate `json:"auth_time,omitempty"` // When the user signed in; bounds refreshes
 	jwt.RegisteredClaims
 }
 
+// JWTConfig controls token lifetimes and validation
+type JWTConfig struct {
+	Secret          string
+	Issuer          string
+	AccessTokenTTL  time.Duration // Lifetime of each issued token
+	RefreshTokenTTL time.Duration // How long after sign-in a token may still be refreshed
+	ClockSkew       time.Duration // Leeway applied to exp, nbf and iat checks
+	Cookies         CookieConfig  // Attributes of cookie-based session cookies
+}
+
+// DefaultJWTConfig returns the historical settings: year-long tokens and a minute of skew
+func DefaultJWTConfig(secret, issuer string) JWTConfig {
+	return JWTConfig{
+		Secret:          secret,
+		Issuer:          issuer,
+		AccessTokenTTL:  365 * 24 * time.Hour,
+		RefreshTokenTTL: 365 * 24 * time.Hour,
+		ClockSkew:       time.Minute,
+		Cookies:         DefaultCookieConfig(),
+	}
+}
+
+// Validate checks the configuration. Outside dev mode it also rejects
+// insecure combinations: placeholder or short secrets and excessive skew.
+func (c JWTConfig) Validate(devMode bool) error {
+	if c.Secret == "" {
+		return errors.New("JWT secret is required")
+	}
+	if c.Issuer == "" {
+		return errors.New("JWT issuer is required")
+	}
+	if c.AccessTokenTTL <= 0 {
+		return errors.New("access token lifetime must be positive")
+	}
+	if c.RefreshTokenTTL < c.AccessTokenTTL {
+		return fmt.Errorf("refresh lifetime %s is shorter than access token lifetime %s", c.RefreshTokenTTL, c.AccessTokenTTL)
+	}
+	if c.ClockSkew < 0 {
+		return errors.New("clock skew must not be negative")
+	}
+	if devMode {
+		return nil
+	}
+
+	if c.Secret == PlaceholderSecret {
+		return errors.New("JWT secret is the development placeholder")
+	}
+	if len(c.Secret) < MinSecretLength {
+		return fmt.Errorf("JWT secret must be at least %d bytes", MinSecretLength)
+	}
+	if c.ClockSkew > MaxClockSkew {
+		return fmt.Errorf("clock skew %s exceeds the maximum of %s", c.ClockSkew, MaxClockSkew)
+	}
+	if c.ClockSkew >= c.AccessTokenTTL {
+		return fmt.Errorf("clock skew %s must be shorter than the access token lifetime %s", c.ClockSkew, c.AccessTokenTTL)
+	}
+	return nil
+}
+
 // JWTService handles JWT operations
 type JWTService struct {
 	secretKey []byte
-	issuer    string
+	config    JWTConfig
 }
 
-// NewJWTService creates a new JWT service
+// NewJWTService creates a new JWT service with the default lifetimes
 func NewJWTService(secretKey string, issuer string) *JWTService {
+	return NewJWTServiceWithConfig(DefaultJWTConfig(secretKey, issuer))
+}
+
+// NewJWTServiceWithConfig creates a new JWT service; cfg should already be validated
+func NewJWTServiceWithConfig(cfg JWTConfig) *JWTService {
 	return &JWTService{
-		secretKey: []byte(secretKey),
-		issuer:    issuer,
+		secretKey: []byte(cfg.Secret),
+		config:    cfg,
 	}
 }
 
+// Config returns the service's configuration
+func (s *JWTService) Config() JWTConfig {
+	return s.config
+}
+
 // GenerateToken creates a new JWT for a user
 // Returns: token string, refresh token ID, expiry time, error
 func (s *JWTService) GenerateToken(user *models.User) (string, string, time.Time, error) {
@@ -48,29 +127,68 @@ func (s *JWTService) GenerateToken(user *models.User) (string, string, time.Time
 		return "", "", time.Time{}, err
 	}
 
-	expiresAt := time.Now().Add(TokenExpiry)
+	signedToken, expiresAt, err := s.sign(user, refreshToken, time.Now())
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return signedToken, refreshToken, expiresAt, nil
+}
+
+// RefreshToken re-issues a token for the same session (same refresh token ID
+// and sign-in time) with a fresh lifetime. Returns ErrSessionExpired once the
+// refresh lifetime since sign-in has passed.
+func (s *JWTService) RefreshToken(user *models.User, claims *JWTClaims) (string, time.Time, error) {
+	authTime := time.Now()
+	if claims.AuthTime != nil {
+		authTime = claims.AuthTime.Time
+	} else if claims.IssuedAt != nil {
+		authTime = claims.IssuedAt.Time
+	}
+	if time.Since(authTime) > s.config.RefreshTokenTTL {
+		return "", time.Time{}, ErrSessionExpired
+	}
+	return s.sign(user, claims.RefreshToken, authTime)
+}
+
+// NeedsRefresh reports whether more than half of a token's lifetime has
+// elapsed, so active sessions slide forward while abandoned ones expire
+func (s *JWTService) NeedsRefresh(claims *JWTClaims) bool {
+	if claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return false
+	}
+	lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time)
+	return time.Since(claims.IssuedAt.Time) > lifetime/2
+}
+
+// sign issues a token; the expiry never passes the session's refresh deadline
+func (s *JWTService) sign(user *models.User, refreshToken string, authTime time.Time) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.config.AccessTokenTTL)
+	if deadline := authTime.Add(s.config.RefreshTokenTTL); expiresAt.After(deadline) {
+		expiresAt = deadline
+	}
 
 	claims := JWTClaims{
 		UserID:       user.ID,
 		Email:        user.Email,
 		Role:         user.Role,
 		RefreshToken: refreshToken,
+		AuthTime:     jwt.NewNumericDate(authTime),
 		RegisteredClaims: jwt.RegisteredClaims{
-			Issuer:    s.issuer,
+			Issuer:    s.config.Issuer,
 			Subject:   user.ID,
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signedToken, err := token.SignedString(s.secretKey)
 	if err != nil {
-		return "", "", time.Time{}, err
+		return "", time.Time{}, err
 	}
-
-	return signedToken, refreshToken, expiresAt, nil
+	return signedToken, expiresAt, nil
 }
 
 // ValidateToken validates a JWT and returns the claims
@@ -80,7 +198,7 @@ func (s *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
 			return nil, errors.New("unexpected signing method")
 		}
 		return s.secretKey, nil
-	})
+	}, jwt.WithLeeway(s.config.ClockSkew), jwt.WithIssuedAt())
 
 	if err != nil {
 		return nil, err
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestJWTConfig_Validate(t *testing.T) {
+	strong := strings.Repeat("s", MinSecretLength)
+	tests := []struct {
+		name    string
+		mutate  func(*JWTConfig)
+		devMode bool
+		wantErr bool
+	}{
+		{name: "defaults with strong secret", mutate: func(c *JWTConfig) {}},
+		{name: "placeholder secret in dev", mutate: func(c *JWTConfig) { c.Secret = PlaceholderSecret }, devMode: true},
+		{name: "placeholder secret in prod", mutate: func(c *JWTConfig) { c.Secret = PlaceholderSecret }, wantErr: true},
+		{name: "short secret in prod", mutate: func(c *JWTConfig) { c.Secret = "short" }, wantErr: true},
+		{name: "refresh shorter than access", mutate: func(c *JWTConfig) { c.RefreshTokenTTL = time.Hour; c.AccessTokenTTL = 2 * time.Hour }, devMode: true, wantErr: true},
+		{name: "excessive skew in prod", mutate: func(c *JWTConfig) { c.ClockSkew = time.Hour }, wantErr: true},
+		{name: "skew exceeds access lifetime", mutate: func(c *JWTConfig) { c.AccessTokenTTL = time.Minute; c.ClockSkew = 2 * time.Minute }, wantErr: true},
+		{name: "missing issuer", mutate: func(c *JWTConfig) { c.Issuer = "" }, devMode: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultJWTConfig(strong, "donzhit.me")
+			tt.mutate(&cfg)
+			if err := cfg.Validate(tt.devMode); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJWTService_RefreshToken(t *testing.T) {
+	cfg := DefaultJWTConfig(strings.Repeat("s", MinSecretLength), "donzhit.me")
+	cfg.AccessTokenTTL = time.Hour
+	cfg.RefreshTokenTTL = 90 * time.Minute
+	svc := NewJWTServiceWithConfig(cfg)
+	user := &models.User{ID: "u1", Email: "u1@example.com", Role: models.RoleContributor}
+
+	token, refreshID, _, err := svc.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	claims, err := svc.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+
+	// Pretend the user signed in 80 minutes ago: the new token stops at the 90 minute deadline
+	claims.AuthTime = jwt.NewNumericDate(time.Now().Add(-80 * time.Minute))
+	refreshed, expiresAt, err := svc.RefreshToken(user, claims)
+	if err != nil {
+		t.Fatalf("RefreshToken: %v", err)
+	}
+	if until := time.Until(expiresAt); until > 11*time.Minute {
+		t.Errorf("expected expiry capped near the refresh deadline, got %s away", until)
+	}
+	refreshedClaims, err := svc.ValidateToken(refreshed)
+	if err != nil {
+		t.Fatalf("ValidateToken(refreshed): %v", err)
+	}
+	if refreshedClaims.RefreshToken != refreshID {
+		t.Error("expected refreshed token to keep the session's refresh token ID")
+	}
+
+	claims.AuthTime = jwt.NewNumericDate(time.Now().Add(-2 * time.Hour))
+	if _, _, err := svc.RefreshToken(user, claims); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("expected ErrSessionExpired past the refresh lifetime, got %v", err)
+	}
+}
+
+func TestJWTService_NeedsRefresh(t *testing.T) {
+	svc := NewJWTService(strings.Repeat("s", MinSecretLength), "donzhit.me")
+	now := time.Now()
+	claims := func(issuedAgo time.Duration) *JWTClaims {
+		return &JWTClaims{RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(now.Add(-issuedAgo)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(-issuedAgo).Add(time.Hour)),
+		}}
+	}
+
+	if svc.NeedsRefresh(claims(10 * time.Minute)) {
+		t.Error("expected a fresh token not to need refreshing")
+	}
+	if !svc.NeedsRefresh(claims(40 * time.Minute)) {
+		t.Error("expected a token past half its lifetime to need refreshing")
+	}
+}
@@ -0,0 +1,27 @@
+package auth
+
+import "testing"
+
+func TestNewResearchToken(t *testing.T) {
+	secret, hash, err := NewResearchToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsResearchToken(secret) {
+		t.Errorf("secret %q lacks the %q prefix", secret, ResearchTokenPrefix)
+	}
+	if hash != HashResearchToken(secret) || hash == secret {
+		t.Errorf("hash %q does not match the secret", hash)
+	}
+
+	other, _, err := NewResearchToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == secret {
+		t.Error("two tokens share a secret")
+	}
+	if IsResearchToken("eyJhbGciOiJIUzI1NiJ9.jwt") {
+		t.Error("a JWT was taken for a research token")
+	}
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SessionCookieName holds the JWT for cookie-based web sessions (HttpOnly)
+	SessionCookieName = "donzhit_session"
+	// CSRFCookieName holds the double-submit CSRF token; readable by the frontend
+	CSRFCookieName = "donzhit_csrf"
+	// CSRFHeader must echo the CSRF cookie on mutating requests authenticated by cookie
+	CSRFHeader = "X-CSRF-Token"
+
+	// RefreshedTokenHeader carries a re-issued bearer token when a session slides forward
+	RefreshedTokenHeader = "X-Refreshed-Token"
+	// TokenExpiresAtHeader carries the re-issued token's expiry as a Unix timestamp
+	TokenExpiresAtHeader = "X-Token-Expires-At"
+)
+
+// CookieConfig controls the attributes of the session and CSRF cookies
+type CookieConfig struct {
+	Domain   string // Empty scopes cookies to the API host
+	Secure   bool
+	SameSite http.SameSite
+}
+
+// DefaultCookieConfig returns secure, SameSite=Lax cookie settings
+func DefaultCookieConfig() CookieConfig {
+	return CookieConfig{
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// ParseSameSite maps "lax", "strict" or "none" to an http.SameSite value
+func ParseSameSite(s string) (http.SameSite, bool) {
+	switch strings.ToLower(s) {
+	case "lax":
+		return http.SameSiteLaxMode, true
+	case "strict":
+		return http.SameSiteStrictMode, true
+	case "none":
+		return http.SameSiteNoneMode, true
+	}
+	return http.SameSiteDefaultMode, false
+}
+
+// NewCSRFToken generates a random token for double-submit CSRF protection
+func NewCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// SetSessionCookies writes the session JWT and CSRF token cookies
+func SetSessionCookies(w http.ResponseWriter, cfg CookieConfig, token, csrfToken string, expiresAt time.Time) {
+	http.SetCookie(w, cfg.cookie(SessionCookieName, token, expiresAt, true))
+	http.SetCookie(w, cfg.cookie(CSRFCookieName, csrfToken, expiresAt, false))
+}
+
+// ClearSessionCookies expires the session and CSRF cookies
+func ClearSessionCookies(w http.ResponseWriter, cfg CookieConfig) {
+	http.SetCookie(w, cfg.cookie(SessionCookieName, "", time.Unix(0, 0), true))
+	http.SetCookie(w, cfg.cookie(CSRFCookieName, "", time.Unix(0, 0), false))
+}
+
+func (cfg CookieConfig) cookie(name, value string, expiresAt time.Time, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   cfg.Domain,
+		Expires:  expiresAt,
+		Secure:   cfg.Secure,
+		HttpOnly: httpOnly,
+		SameSite: cfg.SameSite,
+	}
+}
+
+// ValidCSRF reports whether the request's CSRF header matches its CSRF cookie
+func ValidCSRF(r *http.Request) bool {
+	header := r.Header.Get(CSRFHeader)
+	cookie, err := r.Cookie(CSRFCookieName)
+	if header == "" || err != nil || cookie.Value == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(header), []byte(cookie.Value)) == 1
+}
+
+// IsSafeMethod reports whether a method is read-only and exempt from CSRF checks
+func IsSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
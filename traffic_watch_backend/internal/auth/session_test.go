@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSessionCookies_CSRFRoundTrip(t *testing.T) {
+	csrf, err := NewCSRFToken()
+	if err != nil {
+		t.Fatalf("NewCSRFToken: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	SetSessionCookies(w, DefaultCookieConfig(), "jwt", csrf, time.Now().Add(time.Hour))
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 2 {
+		t.Fatalf("expected 2 cookies, got %d", len(cookies))
+	}
+	for _, ck := range cookies {
+		if !ck.Secure || ck.SameSite != http.SameSiteLaxMode {
+			t.Errorf("cookie %s: expected Secure and SameSite=Lax", ck.Name)
+		}
+		if want := ck.Name == SessionCookieName; ck.HttpOnly != want {
+			t.Errorf("cookie %s: HttpOnly = %v, want %v", ck.Name, ck.HttpOnly, want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/reports", nil)
+	for _, ck := range cookies {
+		req.AddCookie(ck)
+	}
+	if ValidCSRF(req) {
+		t.Error("expected request without CSRF header to fail")
+	}
+	req.Header.Set(CSRFHeader, "wrong")
+	if ValidCSRF(req) {
+		t.Error("expected mismatched CSRF header to fail")
+	}
+	req.Header.Set(CSRFHeader, csrf)
+	if !ValidCSRF(req) {
+		t.Error("expected matching CSRF header to pass")
+	}
+}
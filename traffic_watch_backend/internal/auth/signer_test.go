@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTokenSigner_TokenRoundTrip(t *testing.T) {
+	s := NewTokenSigner("secret", PurposeRoleInvitation)
+	token := s.Token("inv-123")
+
+	if id, ok := s.Verify(token); !ok || id != "inv-123" {
+		t.Errorf("expected token to verify for inv-123, got %q, %v", id, ok)
+	}
+	if _, ok := NewTokenSigner("other", PurposeRoleInvitation).Verify(token); ok {
+		t.Error("expected token signed with another secret to fail")
+	}
+	if _, ok := NewTokenSigner("secret", PurposeDigestUnsubscribe).Verify(token); ok {
+		t.Error("expected token issued for another purpose to fail")
+	}
+	forged := s.Token("inv-456")
+	if _, ok := s.Verify(strings.SplitN(forged, ".", 2)[0] + "." + strings.SplitN(token, ".", 2)[1]); ok {
+		t.Error("expected a signature for another ID to fail")
+	}
+	if _, ok := s.Verify(token + "x"); ok {
+		t.Error("expected tampered token to fail")
+	}
+	if _, ok := s.Verify("garbage"); ok {
+		t.Error("expected malformed token to fail")
+	}
+}
@@ -0,0 +1,34 @@
+// Package deadline remembers, per request, whether a backend call gave up because
+// it ran out of time. Storage marks the request's context when one of its own
+// timeouts fires, and the error writer turns that request's 500 into a 504, so
+// handlers don't each have to tell a slow database apart from a broken one.
+package deadline
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type trackerKey struct{}
+
+type tracker struct {
+	exceeded atomic.Bool
+}
+
+// Track returns a context whose timeouts can be recorded with MarkExceeded
+func Track(ctx context.Context) context.Context {
+	return context.WithValue(ctx, trackerKey{}, &tracker{})
+}
+
+// MarkExceeded records that a call made with ctx timed out; a no-op for untracked contexts
+func MarkExceeded(ctx context.Context) {
+	if t, ok := ctx.Value(trackerKey{}).(*tracker); ok {
+		t.exceeded.Store(true)
+	}
+}
+
+// Exceeded reports whether MarkExceeded was called on ctx or a context derived from it
+func Exceeded(ctx context.Context) bool {
+	t, ok := ctx.Value(trackerKey{}).(*tracker)
+	return ok && t.exceeded.Load()
+}
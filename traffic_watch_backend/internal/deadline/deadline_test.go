@@ -0,0 +1,28 @@
+package deadline
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMarkExceeded(t *testing.T) {
+	ctx := Track(context.Background())
+	if Exceeded(ctx) {
+		t.Fatal("new tracked context already exceeded")
+	}
+
+	// Calls mark the derived context they were given
+	call, cancel := context.WithTimeout(ctx, time.Second)
+	MarkExceeded(call)
+	cancel()
+	if !Exceeded(ctx) {
+		t.Error("mark on a derived context not seen by the request context")
+	}
+
+	untracked := context.Background()
+	MarkExceeded(untracked)
+	if Exceeded(untracked) {
+		t.Error("untracked context reported exceeded")
+	}
+}
@@ -0,0 +1,76 @@
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	clouderrorreporting "google.golang.org/api/clouderrorreporting/v1beta1"
+)
+
+// cloudReportTimeout bounds each call to the Error Reporting API
+const cloudReportTimeout = 10 * time.Second
+
+// CloudReporter sends events to Cloud Error Reporting, which groups them by stack
+// trace (or by handler for errors without one) and alerts on new groups. Events
+// are also logged, so they stay visible when the API is unreachable.
+type CloudReporter struct {
+	service *clouderrorreporting.Service
+	project string
+	release Release
+}
+
+// NewCloudReporter creates an Error Reporting client for projectID using
+// application default credentials
+func NewCloudReporter(ctx context.Context, projectID string, release Release) (*CloudReporter, error) {
+	service, err := clouderrorreporting.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create error reporting service: %w", err)
+	}
+	return &CloudReporter{service: service, project: "projects/" + projectID, release: release}, nil
+}
+
+// Report logs the event and sends it in the background
+func (r *CloudReporter) Report(ctx context.Context, e Event) {
+	LogReporter{}.Report(ctx, e)
+	event := cloudEvent(e, r.release, time.Now())
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cloudReportTimeout)
+		defer cancel()
+		if _, err := r.service.Projects.Events.Report(r.project, event).Context(ctx).Do(); err != nil {
+			log.Printf("Failed to send error report: %v", err)
+		}
+	}()
+}
+
+// cloudEvent converts e into an Error Reporting event. A panic's message carries its
+// stack in Go's own format, which Error Reporting parses; other errors have no stack,
+// so they are located (and grouped) by the handler or job that failed.
+func cloudEvent(e Event, release Release, at time.Time) *clouderrorreporting.ReportedErrorEvent {
+	errCtx := &clouderrorreporting.ErrorContext{User: e.UserID}
+	message := e.Err.Error()
+	if e.Panicked() {
+		message = fmt.Sprintf("panic: %v\n\n%s", e.Err, e.Stack)
+	} else {
+		errCtx.ReportLocation = &clouderrorreporting.SourceLocation{FunctionName: e.Location}
+	}
+	if e.Request != nil {
+		errCtx.HttpRequest = &clouderrorreporting.HttpRequestContext{
+			Method:             e.Request.Method,
+			Url:                e.Request.URL.Path, // Queries can carry tokens
+			UserAgent:          e.Request.UserAgent(),
+			RemoteIp:           e.ClientIP,
+			ResponseStatusCode: int64(e.Status),
+		}
+	}
+	return &clouderrorreporting.ReportedErrorEvent{
+		EventTime: at.UTC().Format(time.RFC3339Nano),
+		Message:   message,
+		Context:   errCtx,
+		ServiceContext: &clouderrorreporting.ServiceContext{
+			Service: release.Service,
+			Version: release.Version,
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package errreport
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCloudEvent(t *testing.T) {
+	release := Release{Service: "api", Version: "1.2.3"}
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	req := httptest.NewRequest("GET", "/v1/public/notifications/unsubscribe?token=secret", nil)
+	req.Header.Set("User-Agent", "test-agent")
+
+	panicked := cloudEvent(Event{
+		Err:      errors.New("nil map"),
+		Stack:    []byte("goroutine 1 [running]:\nmain.main()"),
+		Request:  req,
+		ClientIP: "203.0.113.9",
+		Status:   500,
+		UserID:   "user-1",
+		Location: "/v1/public/notifications/unsubscribe",
+	}, release, at)
+	if !strings.HasPrefix(panicked.Message, "panic: nil map\n\ngoroutine 1 [running]:") {
+		t.Errorf("panic message = %q, want Go's panic format", panicked.Message)
+	}
+	if panicked.Context.ReportLocation != nil {
+		t.Error("a panic is located by its stack, not a report location")
+	}
+	httpCtx := panicked.Context.HttpRequest
+	if httpCtx.Url != "/v1/public/notifications/unsubscribe" || httpCtx.UserAgent != "test-agent" || httpCtx.RemoteIp != "203.0.113.9" || httpCtx.ResponseStatusCode != 500 {
+		t.Errorf("http context = %+v (the query must be dropped)", httpCtx)
+	}
+	if panicked.Context.User != "user-1" || panicked.ServiceContext.Service != "api" || panicked.ServiceContext.Version != "1.2.3" {
+		t.Errorf("user %q, service context %+v", panicked.Context.User, panicked.ServiceContext)
+	}
+	if panicked.EventTime != "2026-05-01T12:00:00Z" {
+		t.Errorf("event time = %q", panicked.EventTime)
+	}
+
+	failed := cloudEvent(Event{Err: errors.New("connection refused"), Location: "job archive-old-reports"}, release, at)
+	if failed.Message != "connection refused" || failed.Context.HttpRequest != nil {
+		t.Errorf("job event = %+v", failed)
+	}
+	if loc := failed.Context.ReportLocation; loc == nil || loc.FunctionName != "job archive-old-reports" {
+		t.Errorf("report location = %+v, want the job", loc)
+	}
+}
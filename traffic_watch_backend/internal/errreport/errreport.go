@@ -0,0 +1,57 @@
+// Package errreport sends panics and internal errors to an error tracker with
+// the context needed to triage them: the request, the signed-in user, and the
+// release that was running. Reporter is the seam between the server and the
+// tracker; Cloud Error Reporting is built in, and another tracker (Sentry, say)
+// only needs its own Reporter.
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+)
+
+// Event is a panic or internal error to report
+type Event struct {
+	Err      error
+	Stack    []byte        // Stack of the panicking goroutine; nil for errors
+	Request  *http.Request // Request being served, if any
+	ClientIP string        // Caller's address, behind any proxies
+	Status   int           // Status the request was answered with
+	UserID   string        // Signed-in user, if any
+	Location string        // Handler or job that failed
+}
+
+// Panicked reports whether the event is a recovered panic
+func (e Event) Panicked() bool {
+	return e.Stack != nil
+}
+
+// Reporter sends events to an error tracker. Report must not block on the
+// tracker: it is called on the request path.
+type Reporter interface {
+	Report(ctx context.Context, e Event)
+}
+
+// Release identifies the running service in reports
+type Release struct {
+	Service string
+	Version string
+}
+
+// LogReporter writes events to the standard log. It is used when no tracker is configured.
+type LogReporter struct{}
+
+// Report logs the event, with the stack for panics
+func (LogReporter) Report(ctx context.Context, e Event) {
+	where := e.Location
+	if e.Request != nil {
+		where = fmt.Sprintf("%s %s (status %d, user %q)", e.Request.Method, e.Request.URL.Path, e.Status, e.UserID)
+	}
+	if e.Panicked() {
+		log.Printf("ERROR: panic in %s: %v\n%s", where, e.Err, e.Stack)
+		return
+	}
+	log.Printf("ERROR: %s: %v", where, e.Err)
+}
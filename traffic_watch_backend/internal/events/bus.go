@@ -0,0 +1,90 @@
+// Package events is the hook point for report lifecycle changes. Handlers
+// publish on the in-process bus after a change is persisted, for caches and
+// feeds; events bound for systems that must not miss them (Pub/Sub,
+// notifications) go through the outbox and the Relay instead.
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Type identifies what happened to a report
+type Type string
+
+const (
+	ReportCreated       Type = "report.created"
+	ReportApproved      Type = "report.approved"
+	ReportRejected      Type = "report.rejected"
+	ReportResubmitted   Type = "report.resubmitted" // The owner sent a rejected report back to review
+	ReportDeleted       Type = "report.deleted"
+	ReportReprioritized Type = "report.reprioritized"
+	ReportMerged        Type = "report.merged"
+	ReportArchived      Type = "report.archived"
+	ReportPinned        Type = "report.pinned"
+	ReportUnpinned      Type = "report.unpinned"
+	CommentAdded        Type = "comment.added" // A comment became visible, on posting or moderator approval
+)
+
+// Event describes a persisted change to a report
+type Event struct {
+	ID        string    `json:"id,omitempty"` // Outbox ID on relayed events; stable across redeliveries
+	Type      Type      `json:"type"`
+	ReportID  string    `json:"reportId"`
+	CommentID string    `json:"commentId,omitempty"` // Set on comment events
+	Actor     string    `json:"-"`                   // Email of the user who made the change; kept out of serialized payloads
+	At        time.Time `json:"at"`
+}
+
+// Handler receives published events
+type Handler func(Event)
+
+// Bus fans events out to subscribers synchronously, in subscription order.
+// Subscribers doing slow work should hand it off to a goroutine.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+// NewBus creates a bus with no subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for every future event
+func (b *Bus) Subscribe(h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+// Publish delivers an event to every subscriber. A panicking subscriber is
+// logged and skipped so it cannot fail the request that published. Publishing
+// on a nil bus is a no-op.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		deliver(h, e)
+	}
+}
+
+func deliver(h Handler, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Event subscriber panicked on %s for report %s: %v", e.Type, e.ReportID, r)
+		}
+	}()
+	h(e)
+}
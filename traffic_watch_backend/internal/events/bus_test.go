@@ -0,0 +1,50 @@
+package events
+
+import (
+	"testing"
+)
+
+func TestBus_PublishDeliversInOrder(t *testing.T) {
+	bus := NewBus()
+
+	var got []string
+	bus.Subscribe(func(e Event) { got = append(got, "first:"+string(e.Type)) })
+	bus.Subscribe(func(e Event) { got = append(got, "second:"+e.ReportID) })
+
+	bus.Publish(Event{Type: ReportApproved, ReportID: "r1"})
+
+	if len(got) != 2 || got[0] != "first:report.approved" || got[1] != "second:r1" {
+		t.Errorf("unexpected deliveries: %v", got)
+	}
+}
+
+func TestBus_PublishSetsTimestamp(t *testing.T) {
+	bus := NewBus()
+
+	var received Event
+	bus.Subscribe(func(e Event) { received = e })
+	bus.Publish(Event{Type: ReportDeleted, ReportID: "r1"})
+
+	if received.At.IsZero() {
+		t.Error("expected publish to set the event time")
+	}
+}
+
+func TestBus_PanickingSubscriberIsIsolated(t *testing.T) {
+	bus := NewBus()
+
+	delivered := false
+	bus.Subscribe(func(Event) { panic("boom") })
+	bus.Subscribe(func(Event) { delivered = true })
+
+	bus.Publish(Event{Type: ReportMerged, ReportID: "r1"})
+
+	if !delivered {
+		t.Error("expected later subscribers to receive the event")
+	}
+}
+
+func TestBus_NilIsNoop(t *testing.T) {
+	var bus *Bus
+	bus.Publish(Event{Type: ReportRejected, ReportID: "r1"})
+}
@@ -0,0 +1,19 @@
+package events
+
+import (
+	"context"
+)
+
+// EventPublisher sends events to systems outside the process so they can react
+// to report changes without polling the API
+type EventPublisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// NoopPublisher drops every event. It is the default when no external sink is configured.
+type NoopPublisher struct{}
+
+// Publish does nothing
+func (NoopPublisher) Publish(context.Context, Event) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestPubSubMessage(t *testing.T) {
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	msg, err := pubsubMessage(Event{Type: ReportCreated, ReportID: "r1", Actor: "user@example.com", At: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Attributes["type"] != "report.created" {
+		t.Errorf("type attribute = %q", msg.Attributes["type"])
+	}
+
+	data, err := base64.StdEncoding.DecodeString(msg.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatal(err)
+	}
+	if body["type"] != "report.created" || body["reportId"] != "r1" || body["at"] != "2026-05-01T12:00:00Z" {
+		t.Errorf("body = %v", body)
+	}
+	if _, ok := body["Actor"]; ok {
+		t.Error("actor email leaked into the message")
+	}
+	if _, ok := body["commentId"]; ok {
+		t.Error("empty comment ID should be omitted")
+	}
+}
+
+func TestNoopPublisher(t *testing.T) {
+	if err := (NoopPublisher{}).Publish(context.Background(), Event{Type: ReportDeleted}); err != nil {
+		t.Errorf("noop publish returned %v", err)
+	}
+}
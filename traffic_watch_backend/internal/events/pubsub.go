@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	pubsub "google.golang.org/api/pubsub/v1"
+)
+
+// PubSubPublisher publishes events to a Google Cloud Pub/Sub topic. The message body
+// is the event as JSON and the "type" attribute carries the event type, so
+// subscriptions can filter without decoding messages.
+type PubSubPublisher struct {
+	service *pubsub.Service
+	topic   string
+}
+
+// NewPubSubPublisher creates a publisher using application default credentials.
+// topic is the full topic name, e.g. "projects/my-project/topics/report-events".
+func NewPubSubPublisher(ctx context.Context, topic string) (*PubSubPublisher, error) {
+	service, err := pubsub.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pubsub service: %w", err)
+	}
+	return &PubSubPublisher{service: service, topic: topic}, nil
+}
+
+// Publish sends one event to the topic
+func (p *PubSubPublisher) Publish(ctx context.Context, e Event) error {
+	msg, err := pubsubMessage(e)
+	if err != nil {
+		return err
+	}
+	_, err = p.service.Projects.Topics.Publish(p.topic, &pubsub.PublishRequest{
+		Messages: []*pubsub.PubsubMessage{msg},
+	}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", p.topic, err)
+	}
+	return nil
+}
+
+// pubsubMessage encodes an event as a Pub/Sub message
+func pubsubMessage(e Event) (*pubsub.PubsubMessage, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+	return &pubsub.PubsubMessage{
+		Data:       base64.StdEncoding.EncodeToString(data),
+		Attributes: map[string]string{"type": string(e.Type)},
+	}, nil
+}
@@ -0,0 +1,144 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// Relay delivery settings
+const (
+	relayBatchSize   = 100
+	relayMaxAttempts = 20
+	relayBaseDelay   = 30 * time.Second
+	relayMaxDelay    = time.Hour
+	// relayRetention is how long delivered events are kept for inspection before being purged
+	relayRetention = 7 * 24 * time.Hour
+)
+
+// OutboxStore is the storage the relay needs
+type OutboxStore interface {
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+	MarkOutboxEventDelivered(ctx context.Context, id string) error
+	MarkOutboxEventFailed(ctx context.Context, id, lastError string, retryAt *time.Time) error
+	PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int, error)
+}
+
+// NewOutboxEvent converts e into an outbox entry with a new ID, stamped now if e has no time
+func NewOutboxEvent(e Event) *models.OutboxEvent {
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+	return &models.OutboxEvent{
+		ID:         uuid.New().String(),
+		Type:       string(e.Type),
+		ReportID:   e.ReportID,
+		CommentID:  e.CommentID,
+		Actor:      e.Actor,
+		OccurredAt: e.At,
+	}
+}
+
+// fromOutbox converts an outbox entry back into the event it was written for
+func fromOutbox(o models.OutboxEvent) Event {
+	return Event{
+		ID:        o.ID,
+		Type:      Type(o.Type),
+		ReportID:  o.ReportID,
+		CommentID: o.CommentID,
+		Actor:     o.Actor,
+		At:        o.OccurredAt,
+	}
+}
+
+// Relay delivers outbox events to every sink. An event is marked delivered only
+// once all sinks accept it; otherwise the whole event is retried with exponential
+// backoff, so sinks see each event at least once and should dedupe on Event.ID.
+// Events still failing after relayMaxAttempts are left in the outbox with their
+// last error and logged.
+type Relay struct {
+	store   OutboxStore
+	sinks   []EventPublisher
+	timeout time.Duration
+	now     func() time.Time
+}
+
+// NewRelay creates a relay delivering to sinks, giving each sink up to timeout per event
+func NewRelay(store OutboxStore, timeout time.Duration, sinks ...EventPublisher) *Relay {
+	return &Relay{store: store, sinks: sinks, timeout: timeout, now: time.Now}
+}
+
+// Run delivers the events that are due, then purges old delivered ones. It is meant to
+// be run periodically by the scheduler; a batch that fills up is followed by another.
+func (r *Relay) Run(ctx context.Context) error {
+	// Claimed events can't be claimed again until the lease runs out, which has to
+	// outlast delivering the whole batch
+	lease := time.Duration(relayBatchSize*len(r.sinks)+1) * r.timeout
+	for {
+		batch, err := r.store.ClaimOutboxEvents(ctx, relayBatchSize, lease)
+		if err != nil {
+			return err
+		}
+		for _, o := range batch {
+			r.deliver(ctx, o)
+		}
+		if len(batch) < relayBatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	if _, err := r.store.PurgeDeliveredOutboxEvents(ctx, r.now().Add(-relayRetention)); err != nil {
+		log.Printf("Failed to purge delivered outbox events: %v", err)
+	}
+	return nil
+}
+
+// deliver sends one claimed event to every sink and records the outcome
+func (r *Relay) deliver(ctx context.Context, o models.OutboxEvent) {
+	e := fromOutbox(o)
+	var errs []error
+	for _, sink := range r.sinks {
+		sinkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		if err := sink.Publish(sinkCtx, e); err != nil {
+			errs = append(errs, err)
+		}
+		cancel()
+	}
+
+	if len(errs) == 0 {
+		if err := r.store.MarkOutboxEventDelivered(ctx, o.ID); err != nil {
+			// The lease runs out and the event is sent again
+			log.Printf("Failed to mark outbox event %s delivered: %v", o.ID, err)
+		}
+		return
+	}
+
+	deliveryErr := errors.Join(errs...)
+	attempts := o.Attempts + 1
+	var retryAt *time.Time
+	if attempts < relayMaxAttempts {
+		next := r.now().Add(retryDelay(attempts))
+		retryAt = &next
+		log.Printf("Delivery of event %s (%s for report %s) failed, attempt %d: %v", o.ID, o.Type, o.ReportID, attempts, deliveryErr)
+	} else {
+		log.Printf("ERROR: Giving up on event %s (%s for report %s) after %d attempts: %v", o.ID, o.Type, o.ReportID, attempts, deliveryErr)
+	}
+	if err := r.store.MarkOutboxEventFailed(ctx, o.ID, deliveryErr.Error(), retryAt); err != nil {
+		log.Printf("Failed to record failed delivery of outbox event %s: %v", o.ID, err)
+	}
+}
+
+// retryDelay is the backoff before attempt number attempts+1: relayBaseDelay doubling
+// with every failure, capped at relayMaxDelay
+func retryDelay(attempts int) time.Duration {
+	delay := relayBaseDelay
+	for i := 1; i < attempts && delay < relayMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, relayMaxDelay)
+}
@@ -0,0 +1,133 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// fakeOutbox hands out its pending events once and records how each one ended
+type fakeOutbox struct {
+	pending   []models.OutboxEvent
+	delivered []string
+	failed    map[string]*time.Time
+	lastError map[string]string
+	purged    bool
+}
+
+func (f *fakeOutbox) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	n := min(limit, len(f.pending))
+	batch := f.pending[:n]
+	f.pending = f.pending[n:]
+	return batch, nil
+}
+
+func (f *fakeOutbox) MarkOutboxEventDelivered(ctx context.Context, id string) error {
+	f.delivered = append(f.delivered, id)
+	return nil
+}
+
+func (f *fakeOutbox) MarkOutboxEventFailed(ctx context.Context, id, lastError string, retryAt *time.Time) error {
+	f.failed[id] = retryAt
+	f.lastError[id] = lastError
+	return nil
+}
+
+func (f *fakeOutbox) PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int, error) {
+	f.purged = true
+	return 0, nil
+}
+
+// recordingSink records published events and fails those for failReport
+type recordingSink struct {
+	failReport string
+	got        []Event
+}
+
+func (s *recordingSink) Publish(ctx context.Context, e Event) error {
+	s.got = append(s.got, e)
+	if e.ReportID == s.failReport {
+		return errors.New("sink unavailable")
+	}
+	return nil
+}
+
+func TestRelay_Run(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	store := &fakeOutbox{
+		failed:    map[string]*time.Time{},
+		lastError: map[string]string{},
+	}
+	for i := 0; i < relayBatchSize; i++ {
+		store.pending = append(store.pending, models.OutboxEvent{ID: fmt.Sprintf("ok-%d", i), Type: string(ReportApproved), ReportID: "r1"})
+	}
+	store.pending = append(store.pending,
+		models.OutboxEvent{ID: "retry", Type: string(ReportRejected), ReportID: "bad", Attempts: 2},
+		models.OutboxEvent{ID: "last", Type: string(ReportRejected), ReportID: "bad", Attempts: relayMaxAttempts - 1},
+	)
+	good := &recordingSink{}
+	flaky := &recordingSink{failReport: "bad"}
+	relay := NewRelay(store, time.Second, good, flaky)
+	relay.now = func() time.Time { return now }
+
+	if err := relay.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	// A full batch is followed by another in the same run
+	if len(good.got) != relayBatchSize+2 || len(flaky.got) != relayBatchSize+2 {
+		t.Fatalf("sinks got %d and %d events, want %d each", len(good.got), len(flaky.got), relayBatchSize+2)
+	}
+	if good.got[0].ID == "" || good.got[0].Type != ReportApproved {
+		t.Errorf("relayed event = %+v, want the outbox ID and type", good.got[0])
+	}
+	if len(store.delivered) != relayBatchSize {
+		t.Errorf("%d events marked delivered, want %d", len(store.delivered), relayBatchSize)
+	}
+
+	// One failing sink fails the event, which is retried with backoff until the last attempt
+	if at := store.failed["retry"]; at == nil || !at.Equal(now.Add(retryDelay(3))) {
+		t.Errorf("retry scheduled at %v, want %v", at, now.Add(retryDelay(3)))
+	}
+	if at, ok := store.failed["last"]; !ok || at != nil {
+		t.Errorf("event on its last attempt: retryAt = %v, want nil (given up)", at)
+	}
+	if store.lastError["retry"] != "sink unavailable" {
+		t.Errorf("last error = %q", store.lastError["retry"])
+	}
+	if !store.purged {
+		t.Error("delivered events were not purged")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{4, 4 * time.Minute},
+		{8, time.Hour},
+		{relayMaxAttempts, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := retryDelay(tt.attempts); got != tt.want {
+			t.Errorf("retryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestNewOutboxEvent(t *testing.T) {
+	o := NewOutboxEvent(Event{Type: CommentAdded, ReportID: "r1", CommentID: "c1", Actor: "a@example.com"})
+	if o.ID == "" || o.OccurredAt.IsZero() {
+		t.Errorf("expected a new ID and time, got %+v", o)
+	}
+	if e := fromOutbox(*o); e.Type != CommentAdded || e.ReportID != "r1" || e.CommentID != "c1" || e.Actor != "a@example.com" || e.ID != o.ID {
+		t.Errorf("round trip = %+v", e)
+	}
+}
@@ -0,0 +1,179 @@
+// Package flags evaluates feature flags for gradual rollouts. Flags are
+// stored in the database and can be overridden per deployment through the
+// FEATURE_FLAGS environment variable.
+package flags
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// keyPattern restricts flag keys to lowercase identifiers such as "new_upload_flow"
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// ValidKey reports whether key is an acceptable flag key
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// Set holds the current flags and evaluates them per user. A nil Set has no flags.
+type Set struct {
+	mu        sync.RWMutex
+	flags     map[string]models.FeatureFlag
+	overrides map[string]models.FeatureFlag
+}
+
+// NewSet creates a set with the given environment overrides, which take
+// precedence over flags loaded from storage
+func NewSet(overrides []models.FeatureFlag) *Set {
+	s := &Set{
+		flags:     map[string]models.FeatureFlag{},
+		overrides: map[string]models.FeatureFlag{},
+	}
+	for _, f := range overrides {
+		s.overrides[f.Key] = f
+	}
+	return s
+}
+
+// Load replaces the stored flags
+func (s *Set) Load(flags []models.FeatureFlag) {
+	m := make(map[string]models.FeatureFlag, len(flags))
+	for _, f := range flags {
+		m[f.Key] = f
+	}
+
+	s.mu.Lock()
+	s.flags = m
+	s.mu.Unlock()
+}
+
+// Enabled reports whether key is on for userID ("" for anonymous users).
+// Unknown flags are off.
+func (s *Set) Enabled(key, userID string) bool {
+	if s == nil {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.overrides[key]
+	if !ok {
+		f, ok = s.flags[key]
+	}
+	return ok && evaluate(f, userID)
+}
+
+// Evaluate returns every known flag's value for userID
+func (s *Set) Evaluate(userID string) map[string]bool {
+	result := map[string]bool{}
+	if s == nil {
+		return result
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for key, f := range s.flags {
+		result[key] = evaluate(f, userID)
+	}
+	for key, f := range s.overrides {
+		result[key] = evaluate(f, userID)
+	}
+	return result
+}
+
+// Keys returns the keys of every known flag, sorted
+func (s *Set) Keys() []string {
+	values := s.Evaluate("")
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// OverriddenKeys returns the keys set by environment overrides, sorted
+func (s *Set) OverriddenKeys() []string {
+	keys := []string{}
+	if s == nil {
+		return keys
+	}
+	for k := range s.overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// evaluate applies targeting: allowlisted users always get the flag, then it must be
+// enabled and the user must fall inside the rollout bucket. Anonymous users only get
+// fully rolled out flags.
+func evaluate(f models.FeatureFlag, userID string) bool {
+	if userID != "" {
+		for _, id := range f.UserIDs {
+			if id == userID {
+				return true
+			}
+		}
+	}
+	if !f.Enabled {
+		return false
+	}
+	if f.RolloutPercent >= 100 {
+		return true
+	}
+	if userID == "" || f.RolloutPercent <= 0 {
+		return false
+	}
+	return bucket(f.Key, userID) < f.RolloutPercent
+}
+
+// bucket maps a user to a stable value in [0, 100) per flag, so raising the
+// rollout percentage only ever adds users
+func bucket(key, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key + ":" + userID))
+	return int(h.Sum32() % 100)
+}
+
+// ParseOverrides parses FEATURE_FLAGS, a comma-separated list of key=value
+// pairs where value is true/false/on/off or a rollout percentage (0-100),
+// e.g. "comments=on,new_upload_flow=25"
+func ParseOverrides(s string) ([]models.FeatureFlag, error) {
+	var overrides []models.FeatureFlag
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !ok || !ValidKey(key) {
+			return nil, fmt.Errorf("invalid feature flag %q", part)
+		}
+
+		f := models.FeatureFlag{Key: key}
+		switch value {
+		case "true", "on":
+			f.Enabled, f.RolloutPercent = true, 100
+		case "false", "off":
+		default:
+			pct, err := strconv.Atoi(strings.TrimSuffix(value, "%"))
+			if err != nil || pct < 0 || pct > 100 {
+				return nil, fmt.Errorf("invalid value for feature flag %q: %q", key, value)
+			}
+			f.Enabled, f.RolloutPercent = true, pct
+		}
+		overrides = append(overrides, f)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,97 @@
+package flags
+
+import (
+	"fmt"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestSetEnabled(t *testing.T) {
+	s := NewSet(nil)
+	s.Load([]models.FeatureFlag{
+		{Key: "comments", Enabled: true, RolloutPercent: 100},
+		{Key: "off", Enabled: false, RolloutPercent: 100, UserIDs: []string{"beta-user"}},
+		{Key: "half", Enabled: true, RolloutPercent: 50},
+		{Key: "none", Enabled: true, RolloutPercent: 0},
+	})
+
+	tests := []struct {
+		key, user string
+		want      bool
+	}{
+		{"comments", "", true},
+		{"comments", "u1", true},
+		{"off", "u1", false},
+		{"off", "beta-user", true},
+		{"half", "", false},
+		{"none", "u1", false},
+		{"unknown", "u1", false},
+	}
+	for _, tt := range tests {
+		if got := s.Enabled(tt.key, tt.user); got != tt.want {
+			t.Errorf("Enabled(%q, %q) = %v, want %v", tt.key, tt.user, got, tt.want)
+		}
+	}
+}
+
+func TestRolloutIsStableAndMonotonic(t *testing.T) {
+	var on25, on75 int
+	for i := 0; i < 1000; i++ {
+		user := fmt.Sprintf("user-%d", i)
+		a := evaluate(models.FeatureFlag{Key: "upload", Enabled: true, RolloutPercent: 25}, user)
+		b := evaluate(models.FeatureFlag{Key: "upload", Enabled: true, RolloutPercent: 75}, user)
+		if a && !b {
+			t.Fatalf("%s is in the 25%% rollout but not the 75%% rollout", user)
+		}
+		if a != evaluate(models.FeatureFlag{Key: "upload", Enabled: true, RolloutPercent: 25}, user) {
+			t.Fatalf("%s evaluated differently on repeat", user)
+		}
+		if a {
+			on25++
+		}
+		if b {
+			on75++
+		}
+	}
+	if on25 < 150 || on25 > 350 || on75 < 650 || on75 > 850 {
+		t.Errorf("rollout shares off: 25%% -> %d/1000, 75%% -> %d/1000", on25, on75)
+	}
+}
+
+func TestOverridesTakePrecedence(t *testing.T) {
+	overrides, err := ParseOverrides("comments=off, new_upload_flow=100%")
+	if err != nil {
+		t.Fatalf("ParseOverrides: %v", err)
+	}
+	s := NewSet(overrides)
+	s.Load([]models.FeatureFlag{{Key: "comments", Enabled: true, RolloutPercent: 100}})
+
+	if s.Enabled("comments", "u1") {
+		t.Error("env override should switch comments off")
+	}
+	if !s.Enabled("new_upload_flow", "") {
+		t.Error("env-only flag should be on")
+	}
+	if got := s.Keys(); len(got) != 2 || got[0] != "comments" || got[1] != "new_upload_flow" {
+		t.Errorf("Keys() = %v", got)
+	}
+}
+
+func TestParseOverridesErrors(t *testing.T) {
+	for _, input := range []string{"comments", "Bad-Key=on", "x=maybe", "x=150"} {
+		if _, err := ParseOverrides(input); err == nil {
+			t.Errorf("ParseOverrides(%q) expected error", input)
+		}
+	}
+	if got, err := ParseOverrides(""); err != nil || len(got) != 0 {
+		t.Errorf("ParseOverrides(\"\") = %v, %v", got, err)
+	}
+}
+
+func TestNilSet(t *testing.T) {
+	var s *Set
+	if s.Enabled("comments", "u1") || len(s.Evaluate("u1")) != 0 {
+		t.Error("nil set should have no flags")
+	}
+}
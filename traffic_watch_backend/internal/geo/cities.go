@@ -0,0 +1,177 @@
+package geo
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// City is a canonical city reports can be filed against
+type City struct {
+	ID    string `json:"id"` // "<state>/<city>" slug, e.g. "new-york/new-york"
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// stateCities lists the cities each state or province normalizes to, most
+// populous first. Each entry is the canonical name followed by "|"-separated
+// aliases. Cities not listed stay free text with no ID, so the list only has
+// to cover the places where spelling variants fragment the stats.
+var stateCities = map[string][]string{
+	"Alabama":              {"Birmingham", "Montgomery", "Huntsville", "Mobile", "Tuscaloosa"},
+	"Alaska":               {"Anchorage", "Fairbanks", "Juneau"},
+	"Arizona":              {"Phoenix|PHX", "Tucson", "Mesa", "Chandler", "Scottsdale", "Glendale", "Tempe", "Flagstaff"},
+	"Arkansas":             {"Little Rock", "Fort Smith", "Fayetteville"},
+	"California":           {"Los Angeles|LA|L.A.", "San Diego", "San Jose", "San Francisco|SF|San Fran|Frisco", "Fresno", "Sacramento", "Long Beach", "Oakland", "Bakersfield", "Anaheim", "Riverside", "Santa Ana", "Irvine", "Berkeley"},
+	"Colorado":             {"Denver", "Colorado Springs", "Aurora", "Fort Collins", "Boulder"},
+	"Connecticut":          {"Bridgeport", "New Haven", "Stamford", "Hartford"},
+	"Delaware":             {"Wilmington", "Dover", "Newark"},
+	"District of Columbia": {"Washington|DC|D.C.|Washington DC|Washington D.C."},
+	"Florida":              {"Jacksonville", "Miami", "Tampa", "Orlando", "Saint Petersburg|Saint Pete", "Tallahassee", "Fort Lauderdale", "Hialeah", "Gainesville"},
+	"Georgia":              {"Atlanta|ATL", "Augusta", "Columbus", "Macon", "Savannah", "Athens"},
+	"Hawaii":               {"Honolulu", "Hilo"},
+	"Idaho":                {"Boise", "Meridian", "Nampa", "Idaho Falls"},
+	"Illinois":             {"Chicago|Chi-Town|Chitown", "Aurora", "Naperville", "Joliet", "Rockford", "Springfield", "Peoria"},
+	"Indiana":              {"Indianapolis|Indy", "Fort Wayne", "Evansville", "South Bend", "Bloomington"},
+	"Iowa":                 {"Des Moines", "Cedar Rapids", "Davenport", "Iowa City"},
+	"Kansas":               {"Wichita", "Overland Park", "Kansas City|KCK", "Olathe", "Topeka", "Lawrence"},
+	"Kentucky":             {"Louisville", "Lexington", "Bowling Green"},
+	"Louisiana":            {"New Orleans|NOLA", "Baton Rouge", "Shreveport", "Lafayette"},
+	"Maine":                {"Portland", "Lewiston", "Bangor"},
+	"Maryland":             {"Baltimore", "Frederick", "Rockville", "Gaithersburg", "Annapolis"},
+	"Massachusetts":        {"Boston", "Worcester", "Springfield", "Cambridge", "Lowell"},
+	"Michigan":             {"Detroit", "Grand Rapids", "Warren", "Sterling Heights", "Ann Arbor", "Lansing", "Flint"},
+	"Minnesota":            {"Minneapolis", "Saint Paul", "Rochester", "Duluth", "Bloomington"},
+	"Mississippi":          {"Jackson", "Gulfport", "Southaven", "Hattiesburg"},
+	"Missouri":             {"Kansas City|KC|KCMO", "Saint Louis|STL", "Springfield", "Columbia", "Independence"},
+	"Montana":              {"Billings", "Missoula", "Great Falls", "Bozeman", "Helena"},
+	"Nebraska":             {"Omaha", "Lincoln", "Bellevue"},
+	"Nevada":               {"Las Vegas|Vegas", "Henderson", "Reno", "North Las Vegas", "Carson City"},
+	"New Hampshire":        {"Manchester", "Nashua", "Concord"},
+	"New Jersey":           {"Newark", "Jersey City", "Paterson", "Elizabeth", "Trenton", "Camden", "Hoboken"},
+	"New Mexico":           {"Albuquerque|ABQ", "Las Cruces", "Rio Rancho", "Santa Fe"},
+	"New York":             {"New York|New York City|NYC|NY City", "Brooklyn", "Queens", "The Bronx|Bronx", "Staten Island", "Buffalo", "Rochester", "Yonkers", "Syracuse", "Albany"},
+	"North Carolina":       {"Charlotte", "Raleigh", "Greensboro", "Durham", "Winston-Salem", "Fayetteville", "Cary", "Wilmington", "Asheville"},
+	"North Dakota":         {"Fargo", "Bismarck", "Grand Forks"},
+	"Ohio":                 {"Columbus", "Cleveland", "Cincinnati|Cincy", "Toledo", "Akron", "Dayton"},
+	"Oklahoma":             {"Oklahoma City|OKC", "Tulsa", "Norman", "Broken Arrow"},
+	"Oregon":               {"Portland|PDX", "Eugene", "Salem", "Gresham", "Hillsboro", "Beaverton", "Bend"},
+	"Pennsylvania":         {"Philadelphia|Philly", "Pittsburgh", "Allentown", "Erie", "Reading", "Scranton", "Harrisburg"},
+	"Rhode Island":         {"Providence", "Warwick", "Cranston", "Pawtucket"},
+	"South Carolina":       {"Charleston", "Columbia", "North Charleston", "Mount Pleasant", "Greenville"},
+	"South Dakota":         {"Sioux Falls", "Rapid City"},
+	"Tennessee":            {"Nashville", "Memphis", "Knoxville", "Chattanooga", "Clarksville", "Murfreesboro"},
+	"Texas":                {"Houston|HTX", "San Antonio", "Dallas", "Austin|ATX", "Fort Worth", "El Paso", "Arlington", "Corpus Christi", "Plano", "Laredo", "Lubbock", "Irving"},
+	"Utah":                 {"Salt Lake City|SLC", "West Valley City", "Provo", "West Jordan", "Orem", "Ogden"},
+	"Vermont":              {"Burlington", "Montpelier"},
+	"Virginia":             {"Virginia Beach", "Norfolk", "Chesapeake", "Richmond", "Newport News", "Alexandria", "Hampton", "Arlington"},
+	"Washington":           {"Seattle", "Spokane", "Tacoma", "Vancouver", "Bellevue", "Kent", "Everett", "Olympia"},
+	"West Virginia":        {"Charleston", "Huntington", "Morgantown"},
+	"Wisconsin":            {"Milwaukee", "Madison", "Green Bay", "Kenosha", "Racine"},
+	"Wyoming":              {"Cheyenne", "Casper", "Laramie"},
+
+	"Alberta":                   {"Calgary|YYC", "Edmonton|YEG", "Red Deer", "Lethbridge"},
+	"British Columbia":          {"Vancouver|YVR", "Surrey", "Burnaby", "Richmond", "Victoria", "Kelowna"},
+	"Manitoba":                  {"Winnipeg", "Brandon"},
+	"New Brunswick":             {"Moncton", "Saint John", "Fredericton"},
+	"Newfoundland and Labrador": {"Saint John's", "Corner Brook"},
+	"Northwest Territories":     {"Yellowknife"},
+	"Nova Scotia":               {"Halifax", "Sydney"},
+	"Nunavut":                   {"Iqaluit"},
+	"Ontario":                   {"Toronto|TO|T.O.", "Ottawa", "Mississauga", "Brampton", "Hamilton", "London", "Markham", "Kitchener", "Windsor"},
+	"Prince Edward Island":      {"Charlottetown"},
+	"Quebec":                    {"Montreal|MTL", "Quebec City|Quebec", "Laval", "Gatineau", "Longueuil", "Sherbrooke"},
+	"Saskatchewan":              {"Saskatoon", "Regina"},
+	"Yukon":                     {"Whitehorse"},
+}
+
+// cityIndex maps state, then normalized name or alias, to its city
+var cityIndex = buildCityIndex()
+
+func buildCityIndex() map[string]map[string]City {
+	index := make(map[string]map[string]City, len(stateCities))
+	for state, entries := range stateCities {
+		byName := make(map[string]City)
+		for _, entry := range entries {
+			names := strings.Split(entry, "|")
+			city := City{ID: cityID(state, names[0]), Name: names[0], State: state}
+			for _, name := range names {
+				byName[cityKey(name)] = city
+			}
+		}
+		index[state] = byName
+	}
+	return index
+}
+
+// NormalizeCity matches free-text city input against the state's city list,
+// ignoring case, accents, punctuation and "St."/"Ft." abbreviations. ok is
+// false when the city isn't listed for that state.
+func NormalizeCity(state, input string) (city City, ok bool) {
+	city, ok = cityIndex[state][cityKey(input)]
+	return city, ok
+}
+
+// SuggestCities returns up to limit listed cities of the state whose name or an
+// alias starts with query, ordered by the state's list. An empty query returns
+// the state's first cities.
+func SuggestCities(state, query string, limit int) []City {
+	prefix := cityKey(query)
+	rank := make(map[string]int) // City ID to its position in the state's list
+	for i, entry := range stateCities[state] {
+		rank[cityID(state, strings.SplitN(entry, "|", 2)[0])] = i
+	}
+
+	seen := make(map[string]bool)
+	suggestions := []City{}
+	for key, city := range cityIndex[state] {
+		if strings.HasPrefix(key, prefix) && !seen[city.ID] {
+			seen[city.ID] = true
+			suggestions = append(suggestions, city)
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		return rank[suggestions[i].ID] < rank[suggestions[j].ID]
+	})
+	if limit > 0 && len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+func cityID(state, name string) string {
+	return slug(state) + "/" + slug(name)
+}
+
+// accentFolder replaces the accented letters found in North American place
+// names and drops apostrophes, so "St. John's" matches "St Johns"
+var accentFolder = strings.NewReplacer(
+	"'", "", "’", "",
+	"à", "a", "â", "a", "ä", "a", "ç", "c", "é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "ô", "o", "ö", "o", "ù", "u", "û", "u", "ü", "u", "ñ", "n",
+)
+
+// cityKey reduces a city name to the form names and aliases are matched on:
+// lower case, accents folded, punctuation dropped and abbreviations expanded
+func cityKey(name string) string {
+	name = accentFolder.Replace(strings.ToLower(name))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		switch w {
+		case "st":
+			words[i] = "saint"
+		case "ft":
+			words[i] = "fort"
+		case "mt":
+			words[i] = "mount"
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// slug turns a name into the lower-case, hyphenated form used in city IDs
+func slug(name string) string {
+	return strings.ReplaceAll(cityKey(name), " ", "-")
+}
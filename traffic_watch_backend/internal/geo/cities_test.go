@@ -0,0 +1,88 @@
+package geo
+
+import (
+	"strings"
+	"testing"
+
+	"donzhit_me_backend/internal/validation"
+)
+
+func TestNormalizeCity(t *testing.T) {
+	tests := []struct {
+		state, input string
+		wantID       string // Empty when the city isn't listed
+		wantName     string
+	}{
+		{"New York", "NYC", "new-york/new-york", "New York"},
+		{"New York", "  new york city ", "new-york/new-york", "New York"},
+		{"New York", "New York", "new-york/new-york", "New York"},
+		{"Missouri", "St. Louis", "missouri/saint-louis", "Saint Louis"},
+		{"Texas", "Ft Worth", "texas/fort-worth", "Fort Worth"},
+		{"Quebec", "Montréal", "quebec/montreal", "Montreal"},
+		{"Newfoundland and Labrador", "St. Johns", "newfoundland-and-labrador/saint-johns", "Saint John's"},
+		{"District of Columbia", "Washington, D.C.", "district-of-columbia/washington", "Washington"},
+		{"Oregon", "Portland", "oregon/portland", "Portland"},
+		{"Maine", "Portland", "maine/portland", "Portland"},
+		{"Ohio", "NYC", "", ""},
+		{"Ohio", "Smallville", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state+"/"+tt.input, func(t *testing.T) {
+			city, ok := NormalizeCity(tt.state, tt.input)
+			if ok != (tt.wantID != "") || city.ID != tt.wantID || city.Name != tt.wantName {
+				t.Errorf("NormalizeCity = %+v, %v; want ID %q, name %q", city, ok, tt.wantID, tt.wantName)
+			}
+			if ok && city.State != tt.state {
+				t.Errorf("state = %q, want %q", city.State, tt.state)
+			}
+		})
+	}
+}
+
+func TestSuggestCities(t *testing.T) {
+	got := SuggestCities("Ohio", "c", 0)
+	var names []string
+	for _, city := range got {
+		names = append(names, city.Name)
+	}
+	// In list order, with Cincinnati matched once through its name and alias
+	want := []string{"Columbus", "Cleveland", "Cincinnati"}
+	if len(names) != len(want) {
+		t.Fatalf("SuggestCities = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("SuggestCities = %v, want %v", names, want)
+		}
+	}
+
+	if got := SuggestCities("California", "sf", 5); len(got) != 1 || got[0].Name != "San Francisco" {
+		t.Errorf("alias prefix: %+v", got)
+	}
+	if got := SuggestCities("California", "", 3); len(got) != 3 || got[0].Name != "Los Angeles" {
+		t.Errorf("empty query: %+v", got)
+	}
+	if got := SuggestCities("Atlantis", "a", 5); len(got) != 0 {
+		t.Errorf("unknown state: %+v", got)
+	}
+}
+
+func TestStateCities_Valid(t *testing.T) {
+	for state, entries := range stateCities {
+		if !validation.ValidStateOrProvince(state) {
+			t.Errorf("city list has unknown state or province %q", state)
+		}
+		// A name or alias shared by two cities would silently map to one of them
+		owners := map[string]string{}
+		for _, entry := range entries {
+			names := strings.Split(entry, "|")
+			for _, name := range names {
+				if owner, ok := owners[cityKey(name)]; ok {
+					t.Errorf("%s: %q is listed for both %s and %s", state, name, owner, names[0])
+				}
+				owners[cityKey(name)] = names[0]
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Package geo provides viewport parsing and grid clustering for the public report map.
+package geo
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const (
+	// MinZoom and MaxZoom bound the web-map zoom levels accepted by Cluster
+	MinZoom = 0
+	MaxZoom = 22
+
+	// cellsPerTile controls grid density: each 256px map tile is split into
+	// cellsPerTile x cellsPerTile cells, i.e. roughly one marker per 64px
+	cellsPerTile = 4
+)
+
+// ParseBBox parses a "minLng,minLat,maxLng,maxLat" viewport string
+func ParseBBox(s string) (models.BoundingBox, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return models.BoundingBox{}, errors.New("bbox must be minLng,minLat,maxLng,maxLat")
+	}
+
+	var values [4]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return models.BoundingBox{}, errors.New("bbox values must be numbers")
+		}
+		values[i] = v
+	}
+
+	box := models.BoundingBox{MinLng: values[0], MinLat: values[1], MaxLng: values[2], MaxLat: values[3]}
+	if box.MinLat < -90 || box.MaxLat > 90 || box.MinLat > box.MaxLat {
+		return models.BoundingBox{}, errors.New("bbox latitudes must be within -90..90 and minLat <= maxLat")
+	}
+	if box.MinLng < -180 || box.MinLng > 180 || box.MaxLng < -180 || box.MaxLng > 180 {
+		return models.BoundingBox{}, errors.New("bbox longitudes must be within -180..180")
+	}
+	return box, nil
+}
+
+// ParseCoordinates parses an optional latitude/longitude pair from form values.
+// Both must be empty or both must be valid.
+func ParseCoordinates(latStr, lngStr string) (*float64, *float64, error) {
+	latStr, lngStr = strings.TrimSpace(latStr), strings.TrimSpace(lngStr)
+	if latStr == "" && lngStr == "" {
+		return nil, nil, nil
+	}
+	if latStr == "" || lngStr == "" {
+		return nil, nil, errors.New("latitude and longitude must be provided together")
+	}
+
+	lat, err := strconv.ParseFloat(latStr, 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, nil, errors.New("latitude must be a number between -90 and 90")
+	}
+	lng, err := strconv.ParseFloat(lngStr, 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return nil, nil, errors.New("longitude must be a number between -180 and 180")
+	}
+	return &lat, &lng, nil
+}
+
+// Contains reports whether a point lies inside the box, handling boxes that cross the antimeridian
+func Contains(box models.BoundingBox, lat, lng float64) bool {
+	if lat < box.MinLat || lat > box.MaxLat {
+		return false
+	}
+	if box.MinLng <= box.MaxLng {
+		return lng >= box.MinLng && lng <= box.MaxLng
+	}
+	return lng >= box.MinLng || lng <= box.MaxLng
+}
+
+// CellSize returns the grid cell size in degrees for a zoom level
+func CellSize(zoom int) float64 {
+	return 360 / math.Pow(2, float64(zoom)) / cellsPerTile
+}
+
+// Cluster groups locations into grid cells sized for the zoom level.
+// Each cluster is positioned at the mean of its members; clusters are
+// ordered by descending count so the largest markers come first.
+func Cluster(locations []models.ReportLocation, zoom int) []models.ReportCluster {
+	type cellKey struct{ x, y int64 }
+	type cell struct {
+		latSum, lngSum float64
+		count          int
+		reportID       string
+	}
+
+	size := CellSize(zoom)
+	cells := make(map[cellKey]*cell)
+	for _, loc := range locations {
+		key := cellKey{
+			x: int64(math.Floor(loc.Longitude / size)),
+			y: int64(math.Floor(loc.Latitude / size)),
+		}
+		c, ok := cells[key]
+		if !ok {
+			c = &cell{reportID: loc.ID}
+			cells[key] = c
+		}
+		c.latSum += loc.Latitude
+		c.lngSum += loc.Longitude
+		c.count++
+	}
+
+	clusters := make([]models.ReportCluster, 0, len(cells))
+	for _, c := range cells {
+		cluster := models.ReportCluster{
+			Latitude:  c.latSum / float64(c.count),
+			Longitude: c.lngSum / float64(c.count),
+			Count:     c.count,
+		}
+		if c.count == 1 {
+			cluster.ReportID = c.reportID
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Count != clusters[j].Count {
+			return clusters[i].Count > clusters[j].Count
+		}
+		if clusters[i].Latitude != clusters[j].Latitude {
+			return clusters[i].Latitude < clusters[j].Latitude
+		}
+		return clusters[i].Longitude < clusters[j].Longitude
+	})
+	return clusters
+}
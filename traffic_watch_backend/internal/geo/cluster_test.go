@@ -0,0 +1,124 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestParseBBox(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"valid", "-84.6,39.0,-84.3,39.3", false},
+		{"antimeridian", "170,-20,-170,20", false},
+		{"spaces", " -84.6 , 39.0 , -84.3 , 39.3 ", false},
+		{"too few values", "-84.6,39.0,-84.3", true},
+		{"not a number", "a,39.0,-84.3,39.3", true},
+		{"latitude out of range", "-84.6,-91,-84.3,39.3", true},
+		{"latitudes inverted", "-84.6,39.3,-84.3,39.0", true},
+		{"longitude out of range", "-181,39.0,-84.3,39.3", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseBBox(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseBBox(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCoordinates(t *testing.T) {
+	lat, lng, err := ParseCoordinates("", "")
+	if err != nil || lat != nil || lng != nil {
+		t.Errorf("expected no coordinates for empty input, got %v %v %v", lat, lng, err)
+	}
+
+	lat, lng, err = ParseCoordinates("39.1", "-84.5")
+	if err != nil || lat == nil || lng == nil || *lat != 39.1 || *lng != -84.5 {
+		t.Errorf("expected parsed coordinates, got %v %v %v", lat, lng, err)
+	}
+
+	for _, pair := range [][2]string{{"39.1", ""}, {"", "-84.5"}, {"91", "0"}, {"0", "181"}, {"x", "0"}} {
+		if _, _, err := ParseCoordinates(pair[0], pair[1]); err == nil {
+			t.Errorf("expected error for %q,%q", pair[0], pair[1])
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	box := models.BoundingBox{MinLng: -85, MinLat: 39, MaxLng: -84, MaxLat: 40}
+	if !Contains(box, 39.5, -84.5) {
+		t.Error("expected point inside box")
+	}
+	if Contains(box, 41, -84.5) || Contains(box, 39.5, -83) {
+		t.Error("expected point outside box")
+	}
+
+	wrapped := models.BoundingBox{MinLng: 170, MinLat: -10, MaxLng: -170, MaxLat: 10}
+	if !Contains(wrapped, 0, 175) || !Contains(wrapped, 0, -175) {
+		t.Error("expected points on both sides of the antimeridian inside box")
+	}
+	if Contains(wrapped, 0, 0) {
+		t.Error("expected point outside antimeridian box")
+	}
+}
+
+func TestCluster(t *testing.T) {
+	locations := []models.ReportLocation{
+		{ID: "a", Latitude: 39.101, Longitude: -84.511},
+		{ID: "b", Latitude: 39.103, Longitude: -84.513},
+		{ID: "c", Latitude: 41.499, Longitude: -81.694},
+	}
+
+	t.Run("low zoom groups nearby reports", func(t *testing.T) {
+		clusters := Cluster(locations, 8)
+		if len(clusters) != 2 {
+			t.Fatalf("expected 2 clusters, got %d: %+v", len(clusters), clusters)
+		}
+		if clusters[0].Count != 2 || clusters[0].ReportID != "" {
+			t.Errorf("expected first cluster to hold 2 reports without ID, got %+v", clusters[0])
+		}
+		if math.Abs(clusters[0].Latitude-39.102) > 1e-9 {
+			t.Errorf("expected centroid latitude, got %v", clusters[0].Latitude)
+		}
+		if clusters[1].Count != 1 || clusters[1].ReportID != "c" {
+			t.Errorf("expected single-report cluster for c, got %+v", clusters[1])
+		}
+	})
+
+	t.Run("high zoom separates reports", func(t *testing.T) {
+		clusters := Cluster(locations, MaxZoom)
+		if len(clusters) != 3 {
+			t.Fatalf("expected 3 clusters, got %d", len(clusters))
+		}
+		for _, c := range clusters {
+			if c.Count != 1 || c.ReportID == "" {
+				t.Errorf("expected single-report cluster, got %+v", c)
+			}
+		}
+	})
+
+	t.Run("world zoom groups everything", func(t *testing.T) {
+		clusters := Cluster(locations, MinZoom)
+		total := 0
+		for _, c := range clusters {
+			total += c.Count
+		}
+		if total != len(locations) {
+			t.Errorf("expected counts to sum to %d, got %d", len(locations), total)
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		if clusters := Cluster(nil, 10); len(clusters) != 0 {
+			t.Errorf("expected no clusters, got %+v", clusters)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package geo
+
+import (
+	"math"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// regionPadding widens region boxes (in degrees) so points on a border
+// aren't treated as outside
+const regionPadding = 0.2
+
+const earthRadiusKm = 6371.0
+
+// regionBounds holds approximate bounding boxes for every state, district,
+// province and territory reports can be filed against. Boxes overlap their
+// neighbours, so they can rule a point out of a region but can't name the
+// region it's in.
+var regionBounds = map[string]models.BoundingBox{
+	"Alabama":              {MinLng: -88.47, MinLat: 30.22, MaxLng: -84.89, MaxLat: 35.01},
+	"Alaska":               {MinLng: 172.4, MinLat: 51.2, MaxLng: -129.99, MaxLat: 71.4}, // Aleutians cross the antimeridian
+	"Arizona":              {MinLng: -114.82, MinLat: 31.33, MaxLng: -109.04, MaxLat: 37.0},
+	"Arkansas":             {MinLng: -94.62, MinLat: 33.0, MaxLng: -89.64, MaxLat: 36.5},
+	"California":           {MinLng: -124.41, MinLat: 32.53, MaxLng: -114.13, MaxLat: 42.01},
+	"Colorado":             {MinLng: -109.06, MinLat: 36.99, MaxLng: -102.04, MaxLat: 41.0},
+	"Connecticut":          {MinLng: -73.73, MinLat: 40.98, MaxLng: -71.79, MaxLat: 42.05},
+	"Delaware":             {MinLng: -75.79, MinLat: 38.45, MaxLng: -75.05, MaxLat: 39.84},
+	"District of Columbia": {MinLng: -77.12, MinLat: 38.79, MaxLng: -76.91, MaxLat: 39.0},
+	"Florida":              {MinLng: -87.63, MinLat: 24.52, MaxLng: -80.03, MaxLat: 31.0},
+	"Georgia":              {MinLng: -85.61, MinLat: 30.36, MaxLng: -80.84, MaxLat: 35.0},
+	"Hawaii":               {MinLng: -178.33, MinLat: 18.91, MaxLng: -154.81, MaxLat: 28.4},
+	"Idaho":                {MinLng: -117.24, MinLat: 41.99, MaxLng: -111.04, MaxLat: 49.0},
+	"Illinois":             {MinLng: -91.51, MinLat: 36.97, MaxLng: -87.49, MaxLat: 42.51},
+	"Indiana":              {MinLng: -88.1, MinLat: 37.77, MaxLng: -84.78, MaxLat: 41.76},
+	"Iowa":                 {MinLng: -96.64, MinLat: 40.38, MaxLng: -90.14, MaxLat: 43.5},
+	"Kansas":               {MinLng: -102.05, MinLat: 36.99, MaxLng: -94.59, MaxLat: 40.0},
+	"Kentucky":             {MinLng: -89.57, MinLat: 36.5, MaxLng: -81.96, MaxLat: 39.15},
+	"Louisiana":            {MinLng: -94.04, MinLat: 28.93, MaxLng: -88.82, MaxLat: 33.02},
+	"Maine":                {MinLng: -71.08, MinLat: 42.98, MaxLng: -66.95, MaxLat: 47.46},
+	"Maryland":             {MinLng: -79.49, MinLat: 37.91, MaxLng: -75.05, MaxLat: 39.72},
+	"Massachusetts":        {MinLng: -73.51, MinLat: 41.24, MaxLng: -69.93, MaxLat: 42.89},
+	"Michigan":             {MinLng: -90.42, MinLat: 41.7, MaxLng: -82.41, MaxLat: 48.31},
+	"Minnesota":            {MinLng: -97.24, MinLat: 43.5, MaxLng: -89.49, MaxLat: 49.38},
+	"Mississippi":          {MinLng: -91.66, MinLat: 30.17, MaxLng: -88.1, MaxLat: 35.0},
+	"Missouri":             {MinLng: -95.77, MinLat: 35.99, MaxLng: -89.1, MaxLat: 40.61},
+	"Montana":              {MinLng: -116.05, MinLat: 44.36, MaxLng: -104.04, MaxLat: 49.0},
+	"Nebraska":             {MinLng: -104.05, MinLat: 40.0, MaxLng: -95.31, MaxLat: 43.0},
+	"Nevada":               {MinLng: -120.01, MinLat: 35.0, MaxLng: -114.04, MaxLat: 42.0},
+	"New Hampshire":        {MinLng: -72.56, MinLat: 42.7, MaxLng: -70.61, MaxLat: 45.31},
+	"New Jersey":           {MinLng: -75.56, MinLat: 38.93, MaxLng: -73.89, MaxLat: 41.36},
+	"New Mexico":           {MinLng: -109.05, MinLat: 31.33, MaxLng: -103.0, MaxLat: 37.0},
+	"New York":             {MinLng: -79.76, MinLat: 40.5, MaxLng: -71.86, MaxLat: 45.02},
+	"North Carolina":       {MinLng: -84.32, MinLat: 33.84, MaxLng: -75.46, MaxLat: 36.59},
+	"North Dakota":         {MinLng: -104.05, MinLat: 45.94, MaxLng: -96.55, MaxLat: 49.0},
+	"Ohio":                 {MinLng: -84.82, MinLat: 38.4, MaxLng: -80.52, MaxLat: 41.98},
+	"Oklahoma":             {MinLng: -103.0, MinLat: 33.62, MaxLng: -94.43, MaxLat: 37.0},
+	"Oregon":               {MinLng: -124.57, MinLat: 41.99, MaxLng: -116.46, MaxLat: 46.29},
+	"Pennsylvania":         {MinLng: -80.52, MinLat: 39.72, MaxLng: -74.69, MaxLat: 42.27},
+	"Rhode Island":         {MinLng: -71.86, MinLat: 41.15, MaxLng: -71.12, MaxLat: 42.02},
+	"South Carolina":       {MinLng: -83.35, MinLat: 32.03, MaxLng: -78.54, MaxLat: 35.22},
+	"South Dakota":         {MinLng: -104.06, MinLat: 42.48, MaxLng: -96.44, MaxLat: 45.95},
+	"Tennessee":            {MinLng: -90.31, MinLat: 34.98, MaxLng: -81.65, MaxLat: 36.68},
+	"Texas":                {MinLng: -106.65, MinLat: 25.84, MaxLng: -93.51, MaxLat: 36.5},
+	"Utah":                 {MinLng: -114.05, MinLat: 37.0, MaxLng: -109.04, MaxLat: 42.0},
+	"Vermont":              {MinLng: -73.44, MinLat: 42.73, MaxLng: -71.46, MaxLat: 45.02},
+	"Virginia":             {MinLng: -83.68, MinLat: 36.54, MaxLng: -75.24, MaxLat: 39.47},
+	"Washington":           {MinLng: -124.85, MinLat: 45.54, MaxLng: -116.92, MaxLat: 49.0},
+	"West Virginia":        {MinLng: -82.64, MinLat: 37.2, MaxLng: -77.72, MaxLat: 40.64},
+	"Wisconsin":            {MinLng: -92.89, MinLat: 42.49, MaxLng: -86.25, MaxLat: 47.31},
+	"Wyoming":              {MinLng: -111.06, MinLat: 40.99, MaxLng: -104.05, MaxLat: 45.01},
+
+	"Alberta":                   {MinLng: -120.0, MinLat: 49.0, MaxLng: -110.0, MaxLat: 60.0},
+	"British Columbia":          {MinLng: -139.06, MinLat: 48.3, MaxLng: -114.03, MaxLat: 60.0},
+	"Manitoba":                  {MinLng: -102.03, MinLat: 49.0, MaxLng: -88.94, MaxLat: 60.0},
+	"New Brunswick":             {MinLng: -69.06, MinLat: 44.6, MaxLng: -63.77, MaxLat: 48.07},
+	"Newfoundland and Labrador": {MinLng: -67.8, MinLat: 46.61, MaxLng: -52.62, MaxLat: 60.37},
+	"Northwest Territories":     {MinLng: -136.45, MinLat: 60.0, MaxLng: -101.98, MaxLat: 78.76},
+	"Nova Scotia":               {MinLng: -66.32, MinLat: 43.42, MaxLng: -59.69, MaxLat: 47.03},
+	"Nunavut":                   {MinLng: -120.68, MinLat: 51.64, MaxLng: -61.08, MaxLat: 83.11},
+	"Ontario":                   {MinLng: -95.15, MinLat: 41.68, MaxLng: -74.34, MaxLat: 56.86},
+	"Prince Edward Island":      {MinLng: -64.41, MinLat: 45.95, MaxLng: -61.98, MaxLat: 47.06},
+	"Quebec":                    {MinLng: -79.76, MinLat: 44.99, MaxLng: -57.1, MaxLat: 62.59},
+	"Saskatchewan":              {MinLng: -110.0, MinLat: 49.0, MaxLng: -101.36, MaxLat: 60.0},
+	"Yukon":                     {MinLng: -141.0, MinLat: 60.0, MaxLng: -123.81, MaxLat: 69.65},
+}
+
+// InRegion reports whether a point could lie in the named state or province.
+// ok is false when the region is unknown.
+func InRegion(region string, lat, lng float64) (in, ok bool) {
+	box, ok := regionBounds[region]
+	if !ok {
+		return false, false
+	}
+	box.MinLat -= regionPadding
+	box.MaxLat += regionPadding
+	box.MinLng -= regionPadding
+	box.MaxLng += regionPadding
+	return Contains(box, lat, lng), true
+}
+
+// DistanceKm returns the great-circle distance between two points
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
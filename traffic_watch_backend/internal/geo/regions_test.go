@@ -0,0 +1,58 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"donzhit_me_backend/internal/validation"
+)
+
+func TestInRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		region   string
+		lat, lng float64
+		want     bool
+	}{
+		{"Columbus in Ohio", "Ohio", 39.96, -83.0, true},
+		{"Chicago not in Ohio", "Ohio", 41.88, -87.63, false},
+		{"Toronto in Ontario", "Ontario", 43.65, -79.38, true},
+		{"Aleutians in Alaska", "Alaska", 52.0, 178.0, true},
+		{"just over the border", "Ohio", 39.1, -84.9, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in, ok := InRegion(tt.region, tt.lat, tt.lng)
+			if !ok {
+				t.Fatalf("expected %q to be known", tt.region)
+			}
+			if in != tt.want {
+				t.Errorf("InRegion(%q, %v, %v) = %v, want %v", tt.region, tt.lat, tt.lng, in, tt.want)
+			}
+		})
+	}
+
+	if _, ok := InRegion("Atlantis", 0, 0); ok {
+		t.Error("expected unknown region")
+	}
+}
+
+func TestInRegion_CoversAllowedRegions(t *testing.T) {
+	for _, region := range validation.GetAllowedStatesAndProvinces() {
+		if _, ok := regionBounds[region]; !ok {
+			t.Errorf("no bounds for %q", region)
+		}
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	// Cleveland to Columbus is roughly 200 km
+	d := DistanceKm(41.4993, -81.6944, 39.9612, -82.9988)
+	if math.Abs(d-203) > 5 {
+		t.Errorf("expected ~203 km, got %.1f", d)
+	}
+	if DistanceKm(10, 10, 10, 10) != 0 {
+		t.Error("expected zero distance for the same point")
+	}
+}
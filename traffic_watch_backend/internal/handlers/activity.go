@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/pagination"
+)
+
+// activityLimit parses ?limit= for the activity endpoints, writing a 400 on failure
+func activityLimit(c *gin.Context) (int, bool) {
+	limit, err := pagination.ParseLimit(c.Query("limit"))
+	if err != nil {
+		apierror.RespondField(c, "limit", apierror.ReasonOutOfRange, err.Error())
+		return 0, false
+	}
+	return limit, true
+}
+
+// ListMyComments handles GET /v1/auth/me/comments
+// Returns the current user's comments across reports, newest first
+func (h *ReportsHandler) ListMyComments(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+	limit, ok := activityLimit(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.storage.ListUserComments(c.Request.Context(), user.Subject, limit)
+	if err != nil {
+		log.Printf("Failed to list comments for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list comments")
+		return
+	}
+
+	for i := range comments {
+		comments[i].Report.Link = "/v1/public/reports/" + comments[i].Report.ID + "/comments"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments": comments,
+		"count":    len(comments),
+	})
+}
+
+// ListMyReactions handles GET /v1/auth/me/reactions
+// Returns the current user's reactions across reports, most recently changed first
+func (h *ReportsHandler) ListMyReactions(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+	limit, ok := activityLimit(c)
+	if !ok {
+		return
+	}
+
+	reactions, err := h.storage.ListUserReactions(c.Request.Context(), user.Subject, limit)
+	if err != nil {
+		log.Printf("Failed to list reactions for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list reactions")
+		return
+	}
+
+	for i := range reactions {
+		reactions[i].Report.Link = "/v1/public/reports/" + reactions[i].Report.ID + "/engagement"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reactions": reactions,
+		"count":     len(reactions),
+	})
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// AnnouncementsHandler serves admin-managed site-wide banners
+type AnnouncementsHandler struct {
+	storage storage.Client
+}
+
+// NewAnnouncementsHandler creates a new announcements handler
+func NewAnnouncementsHandler(storageClient storage.Client) *AnnouncementsHandler {
+	return &AnnouncementsHandler{storage: storageClient}
+}
+
+// ListActive handles GET /v1/public/announcements
+// Returns announcements that are currently active, most severe first
+func (h *AnnouncementsHandler) ListActive(c *gin.Context) {
+	announcements, err := h.storage.ListActiveAnnouncements(c.Request.Context(), time.Now())
+	if err != nil {
+		log.Printf("Failed to list active announcements: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch announcements")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"announcements": announcements,
+		"count":         len(announcements),
+	})
+}
+
+// List handles GET /v1/admin/announcements
+// Returns every announcement, including scheduled and expired ones
+func (h *AnnouncementsHandler) List(c *gin.Context) {
+	announcements, err := h.storage.ListAnnouncements(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list announcements: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch announcements")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"announcements": announcements,
+		"count":         len(announcements),
+	})
+}
+
+// Create handles POST /v1/admin/announcements
+func (h *AnnouncementsHandler) Create(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	announcement, ok := bindAnnouncement(c)
+	if !ok {
+		return
+	}
+	announcement.ID = uuid.New().String()
+	announcement.CreatedBy = user.Email
+
+	if err := h.storage.CreateAnnouncement(c.Request.Context(), announcement); err != nil {
+		log.Printf("Failed to create announcement: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create announcement")
+		return
+	}
+
+	log.Printf("Announcement %s created by %s", announcement.ID, user.Email)
+	c.JSON(http.StatusCreated, announcement)
+}
+
+// Update handles PUT /v1/admin/announcements/:id
+// Replaces an announcement's content and schedule
+func (h *AnnouncementsHandler) Update(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	announcementID := c.Param("id")
+	if !validation.ValidateUUID(announcementID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid announcement ID format")
+		return
+	}
+
+	announcement, ok := bindAnnouncement(c)
+	if !ok {
+		return
+	}
+	announcement.ID = announcementID
+
+	if err := h.storage.UpdateAnnouncement(c.Request.Context(), announcement); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "announcement not found")
+			return
+		}
+		log.Printf("Failed to update announcement %s: %v", announcementID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update announcement")
+		return
+	}
+
+	log.Printf("Announcement %s updated by %s", announcementID, user.Email)
+	c.JSON(http.StatusOK, announcement)
+}
+
+// Delete handles DELETE /v1/admin/announcements/:id
+func (h *AnnouncementsHandler) Delete(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	announcementID := c.Param("id")
+	if !validation.ValidateUUID(announcementID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid announcement ID format")
+		return
+	}
+
+	if err := h.storage.DeleteAnnouncement(c.Request.Context(), announcementID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "announcement not found")
+			return
+		}
+		log.Printf("Failed to delete announcement %s: %v", announcementID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to delete announcement")
+		return
+	}
+
+	log.Printf("Announcement %s deleted by %s", announcementID, user.Email)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "announcement deleted",
+	})
+}
+
+// bindAnnouncement parses and validates an announcement request body, writing a 400 on failure
+func bindAnnouncement(c *gin.Context) (*models.Announcement, bool) {
+	var req models.AnnouncementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return nil, false
+	}
+
+	startsAt := time.Now()
+	if req.StartsAt != nil {
+		startsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil && !req.EndsAt.After(startsAt) {
+		apierror.RespondField(c, "endsAt", apierror.ReasonOutOfRange, "endsAt must be after startsAt")
+		return nil, false
+	}
+
+	return &models.Announcement{
+		Title:    req.Title,
+		Message:  req.Message,
+		Severity: req.Severity,
+		LinkURL:  req.LinkURL,
+		StartsAt: startsAt,
+		EndsAt:   req.EndsAt,
+	}, true
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/models"
+)
+
+// maxAPIUsageDays is how far back GET /v1/admin/analytics/usage can look
+const maxAPIUsageDays = 366
+
+// GetAPIUsage handles GET /v1/admin/analytics/usage?days=30
+// Summarizes requests to the public, feed and research routes over the last
+// ?days= UTC days (today included): totals per route, consumer (research token
+// or web origin), filter parameter and day. Counts are flushed from each server
+// periodically, so the last few minutes may be missing.
+func (h *ReportsHandler) GetAPIUsage(c *gin.Context) {
+	days := 30
+	if s := c.Query("days"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxAPIUsageDays {
+			apierror.RespondField(c, "days", apierror.ReasonOutOfRange, "days must be between 1 and "+strconv.Itoa(maxAPIUsageDays))
+			return
+		}
+		days = n
+	}
+
+	since := models.UsageDay(time.Now().AddDate(0, 0, 1-days))
+	usage, err := h.storage.ListAPIUsage(c.Request.Context(), since)
+	if err != nil {
+		log.Printf("Failed to list API usage since %s: %v", since, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get API usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SummarizeAPIUsage(usage, since))
+}
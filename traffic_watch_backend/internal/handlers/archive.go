@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/models"
+)
+
+// DefaultArchiveAfter is how long approved reports stay in the main feed
+const DefaultArchiveAfter = 2 * 365 * 24 * time.Hour
+
+// SetArchiveAfter sets the age at which approved reports are archived; zero disables archiving
+func (h *ReportsHandler) SetArchiveAfter(age time.Duration) {
+	h.archiveAfter = age
+}
+
+// ArchiveOldReports moves approved reports older than the archive age out of the
+// main feed. Run periodically by the scheduler; each archived report is published
+// as a lifecycle event so the feed cache is cleared.
+func (h *ReportsHandler) ArchiveOldReports(ctx context.Context) error {
+	if h.archiveAfter <= 0 {
+		return nil
+	}
+	ids, err := h.storage.ArchiveReportsCreatedBefore(ctx, time.Now().Add(-h.archiveAfter))
+	for _, id := range ids {
+		h.announce(ctx, events.Event{Type: events.ReportArchived, ReportID: id})
+	}
+	if len(ids) > 0 {
+		log.Printf("Archived %d reports older than %s", len(ids), h.archiveAfter)
+	}
+	return err
+}
+
+// Media maintenance limits
+const (
+	MediaPartitionsAhead   = 3   // Months of media partitions created in advance
+	MediaArchiveBatchSize  = 500 // Media files moved to cold storage per statement
+	maxMediaArchiveBatches = 20  // Batches per run; the rest moves on the next run
+)
+
+// MaintainMedia creates the media partitions for the coming months and moves media
+// of archived reports to cold storage. Run periodically by the scheduler.
+func (h *ReportsHandler) MaintainMedia(ctx context.Context) error {
+	created, err := h.storage.EnsureMediaPartitions(ctx, time.Now().AddDate(0, MediaPartitionsAhead, 0))
+	if len(created) > 0 {
+		log.Printf("Created media partitions: %s", strings.Join(created, ", "))
+	}
+	if err != nil {
+		return err
+	}
+
+	total := 0
+	for i := 0; i < maxMediaArchiveBatches; i++ {
+		moved, err := h.storage.ArchiveMedia(ctx, MediaArchiveBatchSize)
+		total += moved
+		if err != nil {
+			return err
+		}
+		if moved < MediaArchiveBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Printf("Moved %d media files of archived reports to cold storage", total)
+	}
+	return nil
+}
+
+// ListArchivedReports handles GET /v1/public/reports/archive
+// Returns approved reports that have aged out of the public feed (no auth required)
+func (h *ReportsHandler) ListArchivedReports(c *gin.Context) {
+	reports, err := h.storage.ListArchivedReports(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list archived reports: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
+	}
+	if reports == nil {
+		reports = []models.TrafficReport{}
+	}
+
+	// Refresh signed URLs for GCS media files (skip YouTube URLs)
+	h.refreshMediaURLs(c.Request.Context(), reports, h.mediaURLs.Public)
+	h.maskPublicPII(reports)
+
+	c.JSON(http.StatusOK, models.ListReportsResponse{
+		Reports: reports,
+		Count:   len(reports),
+	})
+}
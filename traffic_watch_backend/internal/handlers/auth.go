@@ -1,23 +1,32 @@
 package handlers
 
 import (
+	"errors"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"donzhit_me_backend/internal/apierror"
 	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/middleware"
 	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/notify"
 	"donzhit_me_backend/internal/storage"
 )
 
-const adminEmail = "jeffarbaugh@gmail.com"
-
 // AuthHandler handles authentication endpoints
 type AuthHandler struct {
 	storage      storage.Client
 	iapValidator *auth.IAPValidator
 	jwtService   *auth.JWTService
+	unsubscriber *auth.TokenSigner
+	adminEmails  map[string]bool
+
+	invitations   *auth.TokenSigner
+	notifier      *notify.Notifier
+	invitationURL string
 }
 
 // NewAuthHandler creates a new auth handler
@@ -29,15 +38,29 @@ func NewAuthHandler(storage storage.Client, iapValidator *auth.IAPValidator, jwt
 	}
 }
 
+// SetAdminEmails sets the bootstrap admins: users signing in with one of these
+// emails are always given the admin role. Everyone else is promoted through
+// PUT /v1/admin/users/:id/role.
+func (h *AuthHandler) SetAdminEmails(emails []string) {
+	h.adminEmails = make(map[string]bool, len(emails))
+	for _, email := range emails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			h.adminEmails[email] = true
+		}
+	}
+}
+
+// isBootstrapAdmin reports whether email is one of the configured bootstrap admins
+func (h *AuthHandler) isBootstrapAdmin(email string) bool {
+	return h.adminEmails[strings.ToLower(email)]
+}
+
 // Login handles POST /v1/auth/login
 // Exchanges a Google token for a DonzHit.me JWT
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req models.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "googleToken is required",
-		})
+		apierror.RespondField(c, "googleToken", apierror.ReasonRequired, "googleToken is required")
 		return
 	}
 
@@ -47,21 +70,24 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	userInfo, err := h.iapValidator.ValidateToken(c.Request.Context(), req.GoogleToken)
 	if err != nil {
 		log.Printf("Google token validation failed: %v", err)
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "invalid_token",
-			"message": "Invalid Google token",
-		})
+		apierror.Respond(c, http.StatusUnauthorized, apierror.InvalidToken, "Invalid Google token")
 		return
 	}
 
 	log.Printf("Google token validated for user: %s (subject: %s)", userInfo.Email, userInfo.Subject)
 
-	// Check if user exists
+	// Check if user exists. Only a missing user is created: on any other error the
+	// upsert below would overwrite the stored role, suspension and trust level.
 	user, err := h.storage.GetUserByID(c.Request.Context(), userInfo.Subject)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Failed to get user %s: %v", userInfo.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "Failed to get user")
+		return
+	}
 	if err != nil {
 		// New user - determine role
 		role := models.RoleContributor
-		if userInfo.Email == adminEmail {
+		if h.isBootstrapAdmin(userInfo.Email) {
 			role = models.RoleAdmin
 		}
 
@@ -73,8 +99,24 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		log.Printf("Creating new user: %s with role: %s", userInfo.Email, role)
 	} else {
 		log.Printf("Existing user found: %s with role: %s", user.Email, user.Role)
-		// Existing user - ensure admin email always has admin role
-		if userInfo.Email == adminEmail && user.Role != models.RoleAdmin {
+		if user.IsSuspended() {
+			log.Printf("Login refused for suspended user: %s", user.Email)
+			apierror.Respond(c, http.StatusForbidden, apierror.AccountSuspended, "This account has been suspended")
+			return
+		}
+		// Signing in again reactivates a self-deactivated account
+		if user.IsDeactivated() {
+			if err := h.storage.ReactivateUser(c.Request.Context(), user.ID); err != nil {
+				log.Printf("Failed to reactivate user %s: %v", user.Email, err)
+				apierror.Respond(c, http.StatusInternalServerError, apierror.UserCreationFailed, "Failed to reactivate account")
+				return
+			}
+			user.DeactivatedAt = nil
+			user.DeactivationReason = ""
+			log.Printf("Reactivated user on sign-in: %s", user.Email)
+		}
+		// Existing user - ensure bootstrap admins always have the admin role
+		if h.isBootstrapAdmin(userInfo.Email) && user.Role != models.RoleAdmin {
 			user.Role = models.RoleAdmin
 			log.Printf("Upgrading user %s to admin role", userInfo.Email)
 		}
@@ -84,10 +126,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	token, refreshToken, expiresAt, err := h.jwtService.GenerateToken(user)
 	if err != nil {
 		log.Printf("Failed to generate JWT: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "token_generation_failed",
-			"message": "Failed to generate token",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.TokenGenerationFailed, "Failed to generate token")
 		return
 	}
 
@@ -97,10 +136,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	// Create or update user
 	if err := h.storage.CreateOrUpdateUser(c.Request.Context(), user); err != nil {
 		log.Printf("Failed to create/update user: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "user_creation_failed",
-			"message": "Failed to create/update user",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UserCreationFailed, "Failed to create/update user")
 		return
 	}
 
@@ -109,6 +145,22 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	log.Printf("Login successful for user: %s, token expires: %v", user.Email, expiresAt)
 
+	if req.UseCookie {
+		csrfToken, err := auth.NewCSRFToken()
+		if err != nil {
+			log.Printf("Failed to generate CSRF token: %v", err)
+			apierror.Respond(c, http.StatusInternalServerError, apierror.TokenGenerationFailed, "Failed to generate token")
+			return
+		}
+		auth.SetSessionCookies(c.Writer, h.jwtService.Config().Cookies, token, csrfToken, expiresAt)
+		c.JSON(http.StatusOK, models.AuthResponse{
+			ExpiresAt: expiresAt.Unix(),
+			User:      *user,
+			CSRFToken: csrfToken,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, models.AuthResponse{
 		Token:     token,
 		ExpiresAt: expiresAt.Unix(),
@@ -117,16 +169,15 @@ func (h *AuthHandler) Login(c *gin.Context) {
 }
 
 // GetCurrentUser handles GET /v1/auth/me
+// Returns the signed-in user with the capabilities their trust level unlocks
 func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	user, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "unauthorized",
-			"message": "Not authenticated",
-		})
+		apierror.Respond(c, http.StatusUnauthorized, apierror.Unauthorized, "Not authenticated")
 		return
 	}
-	c.JSON(http.StatusOK, user)
+	u := user.(*models.User)
+	c.JSON(http.StatusOK, models.CurrentUserResponse{User: u, Capabilities: u.TrustLevel.Capabilities()})
 }
 
 // Logout handles POST /v1/auth/logout
@@ -134,26 +185,62 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 func (h *AuthHandler) Logout(c *gin.Context) {
 	user, exists := c.Get("user")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error":   "unauthorized",
-			"message": "Not authenticated",
-		})
+		apierror.Respond(c, http.StatusUnauthorized, apierror.Unauthorized, "Not authenticated")
 		return
 	}
 
 	u := user.(*models.User)
 	if err := h.storage.RevokeUserToken(c.Request.Context(), u.ID); err != nil {
 		log.Printf("Failed to revoke token for user %s: %v", u.Email, err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "logout_failed",
-			"message": "Failed to logout",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.LogoutFailed, "Failed to logout")
 		return
 	}
 
+	auth.ClearSessionCookies(c.Writer, h.jwtService.Config().Cookies)
 	log.Printf("User logged out: %s", u.Email)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Logged out successfully",
 	})
 }
+
+// RefreshToken handles POST /v1/auth/refresh
+// Re-issues the current token with a fresh lifetime, up to the refresh
+// lifetime after sign-in. Cookie sessions get a new session cookie.
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	user, exists := c.Get("user")
+	claims, ok := middleware.GetClaimsFromContext(c)
+	if !exists || !ok {
+		apierror.Respond(c, http.StatusUnauthorized, apierror.Unauthorized, "Not authenticated")
+		return
+	}
+
+	u := user.(*models.User)
+	token, expiresAt, err := h.jwtService.RefreshToken(u, claims)
+	if errors.Is(err, auth.ErrSessionExpired) {
+		apierror.Respond(c, http.StatusUnauthorized, apierror.SessionExpired, "Session expired, please sign in again")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to refresh JWT for %s: %v", u.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.TokenGenerationFailed, "Failed to generate token")
+		return
+	}
+
+	if c.GetHeader("Authorization") == "" {
+		csrfToken, _ := c.Cookie(auth.CSRFCookieName)
+		auth.SetSessionCookies(c.Writer, h.jwtService.Config().Cookies, token, csrfToken, expiresAt)
+		c.JSON(http.StatusOK, models.AuthResponse{
+			ExpiresAt: expiresAt.Unix(),
+			User:      *u,
+			CSRFToken: csrfToken,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AuthResponse{
+		Token:     token,
+		ExpiresAt: expiresAt.Unix(),
+		User:      *u,
+	})
+}
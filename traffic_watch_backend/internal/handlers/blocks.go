@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/storage"
+)
+
+// validUserIDParam checks a user ID path parameter (Google subject IDs aren't UUIDs)
+func validUserIDParam(userID string) bool {
+	return userID != "" && len(userID) <= 255
+}
+
+// blockedByReportOwner writes a 403 and returns true if the report's owner has blocked userID.
+// Lookup failures are logged and let the request through.
+func (h *ReportsHandler) blockedByReportOwner(c *gin.Context, reportID, userID string) bool {
+	report, err := h.storage.GetReport(c.Request.Context(), reportID)
+	if err != nil || report.UserID == userID {
+		return false
+	}
+
+	blocked, err := h.storage.IsUserBlocked(c.Request.Context(), report.UserID, userID)
+	if err != nil {
+		log.Printf("Failed to check block for report %s: %v", reportID, err)
+		return false
+	}
+	if blocked {
+		apierror.Respond(c, http.StatusForbidden, apierror.Forbidden, "you can't interact with this report")
+		return true
+	}
+	return false
+}
+
+// ListBlockedUsers handles GET /v1/blocks
+// Returns the users the current user has blocked
+func (h *ReportsHandler) ListBlockedUsers(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	blocks, err := h.storage.ListBlockedUsers(c.Request.Context(), user.Subject)
+	if err != nil {
+		log.Printf("Failed to list blocked users for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list blocked users")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"blocks": blocks,
+		"count":  len(blocks),
+	})
+}
+
+// BlockUser handles POST /v1/blocks/:userId
+// Hides the user's comments from the current user and stops them engaging with the current user's reports
+func (h *ReportsHandler) BlockUser(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	blockedID := c.Param("userId")
+	if !validUserIDParam(blockedID) {
+		apierror.RespondField(c, "userId", apierror.ReasonInvalidFormat, "invalid user ID")
+		return
+	}
+	if blockedID == user.Subject {
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "cannot block yourself")
+		return
+	}
+
+	if _, err := h.storage.GetUserByID(c.Request.Context(), blockedID); err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "user not found")
+		return
+	}
+
+	if err := h.storage.BlockUser(c.Request.Context(), user.Subject, blockedID); err != nil {
+		log.Printf("Failed to block user %s for %s: %v", blockedID, user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to block user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "user blocked",
+		"userId":  blockedID,
+	})
+}
+
+// UnblockUser handles DELETE /v1/blocks/:userId
+func (h *ReportsHandler) UnblockUser(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	blockedID := c.Param("userId")
+	if !validUserIDParam(blockedID) {
+		apierror.RespondField(c, "userId", apierror.ReasonInvalidFormat, "invalid user ID")
+		return
+	}
+
+	if err := h.storage.UnblockUser(c.Request.Context(), user.Subject, blockedID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "user is not blocked")
+			return
+		}
+		log.Printf("Failed to unblock user %s for %s: %v", blockedID, user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to unblock user")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "user unblocked",
+		"userId":  blockedID,
+	})
+}
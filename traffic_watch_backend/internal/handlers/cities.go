@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/geo"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/pagination"
+	"donzhit_me_backend/internal/validation"
+)
+
+// normalizeCity replaces a listed city's free text with its canonical name and
+// records its ID, so "NYC" and "New York City" count as one place. Cities that
+// aren't listed are kept as entered, without an ID.
+func normalizeCity(report *models.TrafficReport) {
+	report.City = strings.TrimSpace(report.City)
+	report.CityID = ""
+	if city, ok := geo.NormalizeCity(report.State, report.City); ok {
+		report.City = city.Name
+		report.CityID = city.ID
+	}
+}
+
+// SuggestCities handles GET /v1/public/cities
+// Returns the listed cities of ?state= whose name or a common alias starts with
+// ?q=, for autocompleting the city field. Reports filed with a suggested city
+// get its canonical ID.
+func (h *ReportsHandler) SuggestCities(c *gin.Context) {
+	state := c.Query("state")
+	if !validation.ValidStateOrProvince(state) {
+		apierror.RespondField(c, "state", apierror.ReasonNotAllowed, "state must be a US state, DC, or Canadian province or territory")
+		return
+	}
+	limit, err := pagination.ParseLimit(c.Query("limit"))
+	if err != nil {
+		apierror.RespondField(c, "limit", apierror.ReasonOutOfRange, err.Error())
+		return
+	}
+
+	cities := geo.SuggestCities(state, c.Query("q"), limit)
+	c.JSON(http.StatusOK, gin.H{
+		"cities": cities,
+		"count":  len(cities),
+	})
+}
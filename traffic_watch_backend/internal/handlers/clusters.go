@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/geo"
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Map Endpoints
+// ============================================================================
+
+// ListReportClusters handles GET /v1/public/reports/clusters?bbox=minLng,minLat,maxLng,maxLat&zoom=N
+// Returns approved reports in the viewport grouped into grid clusters sized for the zoom level
+func (h *ReportsHandler) ListReportClusters(c *gin.Context) {
+	box, err := geo.ParseBBox(c.Query("bbox"))
+	if err != nil {
+		apierror.RespondField(c, "bbox", apierror.ReasonInvalidFormat, err.Error())
+		return
+	}
+
+	zoom, err := strconv.Atoi(c.Query("zoom"))
+	if err != nil || zoom < geo.MinZoom || zoom > geo.MaxZoom {
+		apierror.RespondField(c, "zoom", apierror.ReasonOutOfRange, "zoom must be an integer between 0 and 22")
+		return
+	}
+
+	locations, err := h.storage.ListApprovedReportLocations(c.Request.Context(), box)
+	if err != nil {
+		log.Printf("Failed to list report locations: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch report clusters")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.ReportClustersResponse{
+		Clusters: geo.Cluster(locations, zoom),
+		Zoom:     zoom,
+		Total:    len(locations),
+	})
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/moderation"
+	"donzhit_me_backend/internal/ratelimit"
+	"donzhit_me_backend/internal/storage"
+)
+
+// commentGuard is the in-memory copy of the comment policy, reloaded from
+// storage, with the rate limiter it configures. It starts with the defaults so
+// comments are throttled before the first load and when storage is unavailable.
+type commentGuard struct {
+	mu      sync.RWMutex
+	policy  models.CommentPolicy
+	limiter *ratelimit.Limiter
+}
+
+// newCommentGuard creates a guard enforcing the default policy
+func newCommentGuard() *commentGuard {
+	g := &commentGuard{}
+	g.load(models.DefaultCommentPolicy())
+	return g
+}
+
+// load replaces the policy. The rate limiter is only replaced when the rate
+// changes, so reloading an unchanged policy keeps users' recent comment counts.
+func (g *commentGuard) load(policy models.CommentPolicy) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if policy.RateLimit != g.policy.RateLimit || policy.RateWindowMinutes != g.policy.RateWindowMinutes {
+		g.limiter = nil
+		if policy.RateLimit > 0 {
+			g.limiter = ratelimit.New(policy.RateLimit, policy.RateWindow())
+		}
+	}
+	g.policy = policy
+}
+
+// current returns the policy in effect and its rate limiter (nil when unlimited)
+func (g *commentGuard) current() (models.CommentPolicy, *ratelimit.Limiter) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.policy, g.limiter
+}
+
+// ReloadCommentPolicy refreshes the comment policy from storage so changes made
+// on other instances take effect. Without a stored policy the defaults apply.
+func (h *ReportsHandler) ReloadCommentPolicy(ctx context.Context) error {
+	policy, err := h.storage.GetCommentPolicy(ctx)
+	if errors.Is(err, storage.ErrNotFound) {
+		h.comments.load(models.DefaultCommentPolicy())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	h.comments.load(*policy)
+	return nil
+}
+
+// allowComment counts a comment against the user's rate limit.
+// It writes a 429 response with Retry-After and returns false once the limit is hit.
+func (h *ReportsHandler) allowComment(c *gin.Context, userID string) bool {
+	_, limiter := h.comments.current()
+	ok, retryAfter := limiter.Allow(userID)
+	if !ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		apierror.Respond(c, http.StatusTooManyRequests, apierror.RateLimited, "too many comments; try again later")
+	}
+	return ok
+}
+
+// screenComment applies the link and duplicate rules of the comment policy to
+// content, as it will be stored. It writes a 400 response and returns ok=false
+// if the comment is refused; hold reports whether it should be held for
+// moderator review. Moderators and admins, who would review their own comments, are exempt.
+func (h *ReportsHandler) screenComment(c *gin.Context, author *models.User, content string) (hold, ok bool) {
+	if author.CanAccess(models.RoleModerator) {
+		return false, true
+	}
+	ctx := c.Request.Context()
+	policy, _ := h.comments.current()
+
+	if policy.LinkAction != models.LinkActionAllow && !author.TrustLevel.Capabilities().PostLinks && moderation.ContainsLink(content) {
+		if policy.LinkAction == models.LinkActionReject {
+			log.Printf("Comment from %s rejected: links are not allowed at trust level %q", author.Email, author.TrustLevel)
+			apierror.RespondField(c, "content", apierror.ReasonNotAllowed, "links are not allowed in comments until you have an approved report")
+			return false, false
+		}
+		log.Printf("Comment from %s held: contains a link at trust level %q", author.Email, author.TrustLevel)
+		hold = true
+	}
+
+	if policy.DuplicateLimit > 0 {
+		since := time.Now().Add(-policy.DuplicateWindow())
+		count, err := h.storage.CountIdenticalComments(ctx, author.ID, content, since)
+		if err != nil {
+			log.Printf("Failed to count identical comments by %s: %v", author.ID, err)
+		} else if count >= policy.DuplicateLimit {
+			log.Printf("Comment from %s held: %d identical comments in the last %s", author.Email, count, policy.DuplicateWindow())
+			hold = true
+		}
+	}
+	return hold, true
+}
+
+// GetCommentPolicy handles GET /v1/admin/moderation/comment-policy
+// Returns the comment policy in effect; updatedAt is absent while the defaults apply
+func (h *ReportsHandler) GetCommentPolicy(c *gin.Context) {
+	policy, err := h.storage.GetCommentPolicy(c.Request.Context())
+	if errors.Is(err, storage.ErrNotFound) {
+		defaults := models.DefaultCommentPolicy()
+		policy, err = &defaults, nil
+	}
+	if err != nil {
+		log.Printf("Failed to get comment policy: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get comment policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdateCommentPolicy handles PUT /v1/admin/moderation/comment-policy
+// Replaces the comment rate limit, link and duplicate thresholds
+func (h *ReportsHandler) UpdateCommentPolicy(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req models.UpdateCommentPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	policy := &models.CommentPolicy{
+		RateLimit:              req.RateLimit,
+		RateWindowMinutes:      req.RateWindowMinutes,
+		LinkAction:             req.LinkAction,
+		DuplicateLimit:         req.DuplicateLimit,
+		DuplicateWindowMinutes: req.DuplicateWindowMinutes,
+		UpdatedBy:              user.Email,
+	}
+	if err := h.storage.SaveCommentPolicy(c.Request.Context(), policy); err != nil {
+		log.Printf("Failed to save comment policy: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to save comment policy")
+		return
+	}
+
+	details := fmt.Sprintf("rate=%d/%dm links=%s duplicates=%d/%dm",
+		policy.RateLimit, policy.RateWindowMinutes, policy.LinkAction, policy.DuplicateLimit, policy.DuplicateWindowMinutes)
+	writeAuditEntry(c, h.storage, user.Subject, models.AuditCommentPolicyChanged, "", details)
+	log.Printf("Comment policy set (%s) by %s", details, user.Email)
+	h.comments.load(*policy)
+
+	c.JSON(http.StatusOK, policy)
+}
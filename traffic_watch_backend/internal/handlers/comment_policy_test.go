@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// commentStore answers the duplicate lookups the comment policy makes
+type commentStore struct {
+	storage.Client
+	identical int
+}
+
+func (s *commentStore) CountIdenticalComments(ctx context.Context, userID, content string, since time.Time) (int, error) {
+	return s.identical, nil
+}
+
+func TestReportsHandler_ScreenComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		linkAction string
+		role       models.UserRole
+		trust      models.TrustLevel
+		identical  int
+		content    string
+		wantHold   bool
+		wantStatus int // 0 when the comment is accepted
+	}{
+		{name: "plain comment", linkAction: models.LinkActionHold, content: "Saw this too"},
+		{name: "link from a new user is held", linkAction: models.LinkActionHold, content: "more at example.com", wantHold: true},
+		{name: "link from a new user is refused", linkAction: models.LinkActionReject, content: "https://spam.example.com", wantStatus: http.StatusBadRequest},
+		{name: "link from a basic user", linkAction: models.LinkActionReject, trust: models.TrustBasic, content: "https://example.org/map"},
+		{name: "link from an admin", linkAction: models.LinkActionReject, role: models.RoleAdmin, content: "https://example.org/map"},
+		{name: "links allowed", linkAction: models.LinkActionAllow, content: "https://example.org/map"},
+		{name: "under the duplicate limit", linkAction: models.LinkActionHold, identical: 1, content: "Saw this too"},
+		{name: "repeated comment is held", linkAction: models.LinkActionHold, identical: 2, content: "Saw this too", wantHold: true},
+		{name: "repeated comment by an admin", linkAction: models.LinkActionHold, role: models.RoleAdmin, identical: 2, content: "Saw this too"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewReportsHandler(&commentStore{identical: tt.identical}, nil, nil)
+			policy := models.DefaultCommentPolicy()
+			policy.LinkAction = tt.linkAction
+			handler.comments.load(policy)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/v1/reports/r/comments", nil)
+			author := &models.User{ID: "user-1", Email: "user@example.com", Role: tt.role, TrustLevel: tt.trust}
+
+			hold, ok := handler.screenComment(c, author, tt.content)
+			if tt.wantStatus != 0 {
+				if ok || w.Code != tt.wantStatus {
+					t.Fatalf("expected %d, got ok=%v status %d", tt.wantStatus, ok, w.Code)
+				}
+				return
+			}
+			if !ok || hold != tt.wantHold {
+				t.Errorf("screenComment = hold %v, ok %v; want hold %v", hold, ok, tt.wantHold)
+			}
+		})
+	}
+}
+
+func TestCommentGuard_Load(t *testing.T) {
+	g := newCommentGuard()
+	_, limiter := g.current()
+	if limiter == nil {
+		t.Fatal("the default policy should rate-limit comments")
+	}
+
+	policy := models.DefaultCommentPolicy()
+	policy.LinkAction = models.LinkActionReject
+	g.load(policy)
+	if _, same := g.current(); same != limiter {
+		t.Error("an unchanged rate should keep the limiter and its counts")
+	}
+
+	policy.RateLimit = 0
+	g.load(policy)
+	if current, unlimited := g.current(); unlimited != nil || current.LinkAction != models.LinkActionReject {
+		t.Errorf("rate limit 0: limiter %v, policy %+v", unlimited, current)
+	}
+}
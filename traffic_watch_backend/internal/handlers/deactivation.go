@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+)
+
+// DeactivateAccount handles POST /v1/auth/me/deactivate
+// Deactivates the caller's own account and ends their session. Nothing is
+// deleted: their reports and comments are hidden from others (the cached
+// public feed catches up within its TTL) and signing in again reactivates it.
+func (h *AuthHandler) DeactivateAccount(c *gin.Context) {
+	user, exists := c.Get("user")
+	if !exists {
+		apierror.Respond(c, http.StatusUnauthorized, apierror.Unauthorized, "Not authenticated")
+		return
+	}
+
+	u := user.(*models.User)
+	if err := h.storage.DeactivateUser(c.Request.Context(), u.ID, models.DeactivatedBySelf); err != nil {
+		log.Printf("Failed to deactivate user %s: %v", u.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "Failed to deactivate account")
+		return
+	}
+
+	auth.ClearSessionCookies(c.Writer, h.jwtService.Config().Cookies)
+	log.Printf("User deactivated their account: %s", u.Email)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Account deactivated; sign in again to reactivate it",
+	})
+}
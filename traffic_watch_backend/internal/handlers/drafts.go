@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// SetDraftDiscarder sets the verifier for the discard links in draft reminders
+func (h *ReportsHandler) SetDraftDiscarder(s *auth.TokenSigner) {
+	h.draftDiscarder = s
+}
+
+// ListDrafts handles GET /v1/drafts
+// Returns the current user's report drafts, most recently edited first
+func (h *ReportsHandler) ListDrafts(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	drafts, err := h.storage.ListReportDrafts(c.Request.Context(), user.Subject)
+	if err != nil {
+		log.Printf("Failed to list drafts for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch drafts")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"drafts": drafts,
+		"count":  len(drafts),
+	})
+}
+
+// CreateDraft handles POST /v1/drafts
+func (h *ReportsHandler) CreateDraft(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	draft, ok := bindDraft(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	existing, err := h.storage.ListReportDrafts(ctx, user.Subject)
+	if err != nil {
+		log.Printf("Failed to count drafts for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create draft")
+		return
+	}
+	if len(existing) >= models.MaxDraftsPerUser {
+		apierror.Respond(c, http.StatusConflict, apierror.QuotaExceeded, "draft limit reached; submit or delete one first")
+		return
+	}
+
+	draft.ID = uuid.New().String()
+	draft.UserID = user.Subject
+	if err := h.storage.CreateReportDraft(ctx, draft); err != nil {
+		log.Printf("Failed to create draft for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create draft")
+		return
+	}
+
+	c.JSON(http.StatusCreated, draft)
+}
+
+// UpdateDraft handles PUT /v1/drafts/:id
+// Replaces one of the current user's drafts
+func (h *ReportsHandler) UpdateDraft(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	draftID := c.Param("id")
+	if !validation.ValidateUUID(draftID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid draft ID format")
+		return
+	}
+
+	draft, ok := bindDraft(c)
+	if !ok {
+		return
+	}
+	draft.ID = draftID
+	draft.UserID = user.Subject
+
+	if err := h.storage.UpdateReportDraft(c.Request.Context(), draft); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "draft not found")
+			return
+		}
+		log.Printf("Failed to update draft %s: %v", draftID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update draft")
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// DeleteDraft handles DELETE /v1/drafts/:id
+func (h *ReportsHandler) DeleteDraft(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	draftID := c.Param("id")
+	if !validation.ValidateUUID(draftID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid draft ID format")
+		return
+	}
+
+	if err := h.storage.DeleteReportDraft(c.Request.Context(), draftID, user.Subject); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "draft not found")
+			return
+		}
+		log.Printf("Failed to delete draft %s: %v", draftID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to delete draft")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "draft deleted",
+	})
+}
+
+// bindDraft parses and validates a draft request body, writing a 400 on failure
+func bindDraft(c *gin.Context) (*models.ReportDraft, bool) {
+	var req models.ReportDraftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return nil, false
+	}
+
+	draft := &models.ReportDraft{
+		Title:       validation.SanitizeText(req.Title),
+		Description: validation.SanitizeText(req.Description),
+		RoadUsages:  req.RoadUsages,
+		EventTypes:  req.EventTypes,
+		State:       req.State,
+		City:        req.City,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+	}
+	if draft.RoadUsages == nil {
+		draft.RoadUsages = []string{}
+	}
+	if draft.EventTypes == nil {
+		draft.EventTypes = []string{}
+	}
+	return draft, true
+}
+
+// removeSubmittedDraft deletes the draft named by ?draftId= once a report has been
+// created from it. The report is already stored, so failures are only logged.
+func (h *ReportsHandler) removeSubmittedDraft(c *gin.Context, user *models.UserInfo) {
+	draftID := c.Query("draftId")
+	if draftID == "" || !validation.ValidateUUID(draftID) {
+		return
+	}
+	if err := h.storage.DeleteReportDraft(c.Request.Context(), draftID, user.Subject); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Failed to remove submitted draft %s: %v", draftID, err)
+	}
+}
+
+// discardDraftPageTemplate is the page draft reminder discard links open. As with
+// unsubscribe links, opening one only asks for confirmation; the form posts back.
+var discardDraftPageTemplate = template.Must(template.New("discard-draft").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>Discard draft - DonzHit.me</title>
+</head>
+<body>
+{{- if .Done}}
+<p>Your draft was discarded.</p>
+{{- else if .Token}}
+<p>Discard your unfinished report{{if .Subject}} about {{.Subject}}{{end}}? This can't be undone.</p>
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<button type="submit">Discard draft</button>
+</form>
+{{- else}}
+<p>This draft no longer exists, or the link is invalid.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// discardDraftPage is what the discard page template renders
+type discardDraftPage struct {
+	Action  string // Where the confirmation form posts
+	Token   string // Empty when the link's token is invalid or the draft is gone
+	Subject string
+	Done    bool
+}
+
+// ConfirmDiscardDraft handles GET /v1/public/drafts/discard?token=
+// Shows the draft a reminder's discard link names and asks the user to confirm;
+// nothing changes until they do, so scanners following the link can't discard it
+func (h *ReportsHandler) ConfirmDiscardDraft(c *gin.Context) {
+	if h.draftDiscarder == nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "draft discard links are not enabled")
+		return
+	}
+
+	token := c.Query("token")
+	page := discardDraftPage{Action: c.Request.URL.Path}
+	status := http.StatusBadRequest
+	if ref, ok := h.draftDiscarder.Verify(token); ok {
+		if userID, draftID, ok := models.ParseDraftRef(ref); ok {
+			draft, err := h.storage.GetReportDraft(c.Request.Context(), draftID, userID)
+			switch {
+			case err == nil:
+				page.Token, page.Subject = token, draft.Subject()
+				status = http.StatusOK
+			case errors.Is(err, storage.ErrNotFound):
+				status = http.StatusNotFound
+			default:
+				log.Printf("Failed to load draft %s for discard: %v", draftID, err)
+				apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to load draft")
+				return
+			}
+		}
+	}
+	renderDiscardDraftPage(c, status, page)
+}
+
+// DiscardDraft handles POST /v1/public/drafts/discard?token=
+// Deletes the draft a reminder's discard link names; no login needed. The token
+// may also come as a form field. Browsers get a page back, everyone else JSON.
+func (h *ReportsHandler) DiscardDraft(c *gin.Context) {
+	if h.draftDiscarder == nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "draft discard links are not enabled")
+		return
+	}
+
+	token := c.Query("token")
+	if token == "" {
+		token = c.PostForm("token")
+	}
+	ref, ok := h.draftDiscarder.Verify(token)
+	userID, draftID, refOK := models.ParseDraftRef(ref)
+	if !ok || !refOK {
+		apierror.RespondField(c, "token", apierror.ReasonInvalidFormat, "invalid discard token")
+		return
+	}
+
+	if err := h.storage.DeleteReportDraft(c.Request.Context(), draftID, userID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "draft not found")
+			return
+		}
+		log.Printf("Failed to discard draft %s: %v", draftID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to discard draft")
+		return
+	}
+
+	log.Printf("User %s discarded draft %s from a reminder", userID, draftID)
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		renderDiscardDraftPage(c, http.StatusOK, discardDraftPage{Done: true})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "draft discarded",
+	})
+}
+
+func renderDiscardDraftPage(c *gin.Context, status int, page discardDraftPage) {
+	var body strings.Builder
+	if err := discardDraftPageTemplate.Execute(&body, page); err != nil {
+		log.Printf("Failed to render discard draft page: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to render page")
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "text/html; charset=utf-8", []byte(body.String()))
+}
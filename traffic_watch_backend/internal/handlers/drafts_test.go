@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// draftStore holds drafts by ID
+type draftStore struct {
+	storage.Client
+	drafts map[string]models.ReportDraft
+}
+
+func (s *draftStore) GetReportDraft(ctx context.Context, draftID, userID string) (*models.ReportDraft, error) {
+	d, ok := s.drafts[draftID]
+	if !ok || d.UserID != userID {
+		return nil, storage.ErrNotFound
+	}
+	return &d, nil
+}
+
+func (s *draftStore) DeleteReportDraft(ctx context.Context, draftID, userID string) error {
+	if _, err := s.GetReportDraft(ctx, draftID, userID); err != nil {
+		return err
+	}
+	delete(s.drafts, draftID)
+	return nil
+}
+
+func TestReportsHandler_DiscardDraft(t *testing.T) {
+	const draftID = "7f9c2b1e-4a3d-4c5b-9e8f-1a2b3c4d5e6f"
+	store := &draftStore{drafts: map[string]models.ReportDraft{
+		draftID: {ID: draftID, UserID: "user-1", City: "Dayton", State: "Ohio"},
+	}}
+	discarder := auth.NewTokenSigner("secret", auth.PurposeDraftDiscard)
+	h := NewReportsHandler(store, nil, nil)
+	h.SetDraftDiscarder(discarder)
+	router := gin.New()
+	router.GET("/discard", h.ConfirmDiscardDraft)
+	router.POST("/discard", h.DiscardDraft)
+	link := "/discard?token=" + url.QueryEscape(discarder.Token(models.DraftRef("user-1", draftID)))
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Opening the link only asks for confirmation
+	w := serve(httptest.NewRequest(http.MethodGet, link, nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `<form method="post"`) || !strings.Contains(w.Body.String(), "Dayton, Ohio") {
+		t.Fatalf("GET = %d: %s", w.Code, w.Body.String())
+	}
+	if _, ok := store.drafts[draftID]; !ok {
+		t.Fatal("GET discarded the draft")
+	}
+
+	// Tokens are bound to the owner and the purpose
+	for _, token := range []string{
+		"forged",
+		discarder.Token(models.DraftRef("user-2", draftID)),
+		auth.NewTokenSigner("secret", auth.PurposeDigestUnsubscribe).Token(models.DraftRef("user-1", draftID)),
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/discard?token="+url.QueryEscape(token), nil)
+		if w := serve(req); w.Code == http.StatusOK {
+			t.Errorf("POST with token %q = 200", token)
+		}
+	}
+	if _, ok := store.drafts[draftID]; !ok {
+		t.Fatal("a bad token discarded the draft")
+	}
+
+	// One click discards it; the link is then spent
+	if w := serve(httptest.NewRequest(http.MethodPost, link, nil)); w.Code != http.StatusOK {
+		t.Fatalf("POST = %d: %s", w.Code, w.Body.String())
+	}
+	if _, ok := store.drafts[draftID]; ok {
+		t.Error("expected the draft discarded")
+	}
+	if w := serve(httptest.NewRequest(http.MethodGet, link, nil)); w.Code != http.StatusNotFound {
+		t.Errorf("GET after discarding = %d, want 404", w.Code)
+	}
+}
@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// withEngagement returns a copy of reports with each one's reaction counts,
+// comment count and newest comment attached, fetched in one batched call so
+// feed cards don't need a follow-up request per report. viewerID (optional)
+// adds the viewer's own reactions and hides comments by users they blocked.
+// The feed slice is shared, so it is never changed in place. If the lookup
+// fails the reports are returned without summaries.
+func (h *ReportsHandler) withEngagement(ctx context.Context, reports []models.TrafficReport, viewerID string) []models.TrafficReport {
+	if len(reports) == 0 {
+		return reports
+	}
+	ids := make([]string, len(reports))
+	for i := range reports {
+		ids[i] = reports[i].ID
+	}
+	engagements, err := h.storage.GetBulkReportEngagement(ctx, ids, viewerID)
+	if err != nil {
+		log.Printf("Failed to load engagement for %d reports: %v", len(reports), err)
+		return reports
+	}
+
+	summarized := slices.Clone(reports)
+	for i := range summarized {
+		summarized[i].Engagement = engagements[summarized[i].ID]
+	}
+	return summarized
+}
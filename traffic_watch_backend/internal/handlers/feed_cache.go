@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// feedCache holds the most recent public feed for a short TTL. It is cleared
+// through InvalidateFeedCache whenever a lifecycle event changes what the feed shows,
+// and expires early when an embargoed report is due to be published.
+type feedCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	reports []models.TrafficReport
+	expires time.Time
+}
+
+func (f *feedCache) get() ([]models.TrafficReport, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if f.reports == nil || time.Now().After(f.expires) {
+		return nil, false
+	}
+	return f.reports, true
+}
+
+// set caches reports until the TTL passes or nextPublish (if set), whichever is first
+func (f *feedCache) set(reports []models.TrafficReport, nextPublish *time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reports = reports
+	f.expires = time.Now().Add(f.ttl)
+	if nextPublish != nil && nextPublish.Before(f.expires) {
+		f.expires = *nextPublish
+	}
+}
+
+func (f *feedCache) clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reports = nil
+}
+
+// SetFeedCacheTTL enables caching of the public feed; a zero TTL disables it.
+// Keep the TTL well below the signed media URL lifetime.
+func (h *ReportsHandler) SetFeedCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		h.feedCache = nil
+		return
+	}
+	h.feedCache = &feedCache{ttl: ttl}
+}
+
+// InvalidateFeedCache drops the cached public feed so the next request reloads it
+func (h *ReportsHandler) InvalidateFeedCache() {
+	if h.feedCache != nil {
+		h.feedCache.clear()
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/flags"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// FlagsHandler serves feature flag evaluations and admin flag management
+type FlagsHandler struct {
+	storage storage.Client
+	flags   *flags.Set
+}
+
+// NewFlagsHandler creates a new flags handler evaluating against set
+func NewFlagsHandler(storageClient storage.Client, set *flags.Set) *FlagsHandler {
+	return &FlagsHandler{
+		storage: storageClient,
+		flags:   set,
+	}
+}
+
+// Reload refreshes the flag set from storage so changes made on other instances take effect
+func (h *FlagsHandler) Reload(ctx context.Context) error {
+	stored, err := h.storage.ListFeatureFlags(ctx)
+	if err != nil {
+		return err
+	}
+	h.flags.Load(stored)
+	return nil
+}
+
+// Evaluate handles GET /v1/public/flags
+// Returns every flag's value for the caller; signed-in users get their rollout bucket
+func (h *FlagsHandler) Evaluate(c *gin.Context) {
+	userID := ""
+	if user, ok := middleware.GetUserFromContext(c); ok && user != nil {
+		userID = user.Subject
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"flags": h.flags.Evaluate(userID),
+	})
+}
+
+// List handles GET /v1/admin/flags
+// Returns stored flags; keys overridden by FEATURE_FLAGS are reported separately
+func (h *FlagsHandler) List(c *gin.Context) {
+	stored, err := h.storage.ListFeatureFlags(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list feature flags: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch feature flags")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"flags":      stored,
+		"count":      len(stored),
+		"overridden": h.flags.OverriddenKeys(),
+	})
+}
+
+// Upsert handles PUT /v1/admin/flags/:key
+// Creates or replaces a flag
+func (h *FlagsHandler) Upsert(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	key := c.Param("key")
+	if !flags.ValidKey(key) {
+		apierror.RespondField(c, "key", apierror.ReasonInvalidFormat, "flag key must be lowercase letters, digits and underscores")
+		return
+	}
+
+	var req models.UpsertFeatureFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	rollout := 100
+	if req.RolloutPercent != nil {
+		rollout = *req.RolloutPercent
+	}
+	flag := &models.FeatureFlag{
+		Key:            key,
+		Description:    req.Description,
+		Enabled:        req.Enabled,
+		RolloutPercent: rollout,
+		UserIDs:        req.UserIDs,
+		UpdatedBy:      user.Email,
+	}
+
+	if err := h.storage.UpsertFeatureFlag(c.Request.Context(), flag); err != nil {
+		log.Printf("Failed to save feature flag %s: %v", key, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to save feature flag")
+		return
+	}
+
+	log.Printf("Feature flag %s set (enabled=%v, rollout=%d%%) by %s", key, flag.Enabled, flag.RolloutPercent, user.Email)
+	if err := h.Reload(c.Request.Context()); err != nil {
+		log.Printf("Failed to reload feature flags: %v", err)
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
+
+// Delete handles DELETE /v1/admin/flags/:key
+func (h *FlagsHandler) Delete(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	key := c.Param("key")
+	if err := h.storage.DeleteFeatureFlag(c.Request.Context(), key); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "feature flag not found")
+			return
+		}
+		log.Printf("Failed to delete feature flag %s: %v", key, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to delete feature flag")
+		return
+	}
+
+	log.Printf("Feature flag %s deleted by %s", key, user.Email)
+	if err := h.Reload(c.Request.Context()); err != nil {
+		log.Printf("Failed to reload feature flags: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "feature flag deleted",
+	})
+}
@@ -1,38 +1,130 @@
 package handlers
 
 import (
+	"context"
+	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// healthCheckTimeout bounds each dependency check in a verbose health check
+const healthCheckTimeout = 5 * time.Second
+
 // HealthResponse represents the health check response
 type HealthResponse struct {
-	Status    string `json:"status"`
-	Timestamp string `json:"timestamp"`
-	Version   string `json:"version"`
+	Status        string `json:"status"`
+	Timestamp     string `json:"timestamp"`
+	Version       string `json:"version"`
+	GitSHA        string `json:"gitSha,omitempty"`
+	BuildTime     string `json:"buildTime,omitempty"`
+	UptimeSeconds int64  `json:"uptimeSeconds"`
+	Storage       string `json:"storage,omitempty"` // Database backend: "postgres" or "firestore"
+
+	// Dependency checks, run only for ?verbose=true
+	Checks map[string]HealthCheckResult `json:"checks,omitempty"`
 }
 
+// HealthCheckResult is the outcome of one dependency check. The endpoint is
+// public, so errors are logged rather than returned.
+type HealthCheckResult struct {
+	Status    string `json:"status"` // "ok" or "error"
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+// HealthCheck checks that one dependency is reachable and usable
+type HealthCheck func(ctx context.Context) error
+
 // HealthHandler handles health check requests
 type HealthHandler struct {
-	version string
+	version   string
+	gitSHA    string
+	buildTime string
+	storage   string
+	started   time.Time
+	checks    map[string]HealthCheck
 }
 
 // NewHealthHandler creates a new health handler
 func NewHealthHandler(version string) *HealthHandler {
 	return &HealthHandler{
 		version: version,
+		started: time.Now(),
+		checks:  make(map[string]HealthCheck),
 	}
 }
 
-// Health returns the health status of the service
+// SetBuildInfo sets the commit and build time the binary was built from
+func (h *HealthHandler) SetBuildInfo(gitSHA, buildTime string) {
+	h.gitSHA = gitSHA
+	h.buildTime = buildTime
+}
+
+// SetStorageBackend sets the name of the configured database backend
+func (h *HealthHandler) SetStorageBackend(name string) {
+	h.storage = name
+}
+
+// AddCheck registers a dependency check, run for verbose health checks only
+func (h *HealthHandler) AddCheck(name string, check HealthCheck) {
+	h.checks[name] = check
+}
+
+// Health returns the health status of the service. The plain probe never touches
+// a dependency, so it stays cheap for load balancers; ?verbose=true also runs
+// every dependency check and answers 503 "degraded" if any fails.
 func (h *HealthHandler) Health(c *gin.Context) {
 	response := HealthResponse{
-		Status:    "healthy",
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
-		Version:   h.version,
+		Status:        "healthy",
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		Version:       h.version,
+		GitSHA:        h.gitSHA,
+		BuildTime:     h.buildTime,
+		UptimeSeconds: int64(time.Since(h.started).Seconds()),
+		Storage:       h.storage,
+	}
+
+	if c.Query("verbose") != "true" {
+		c.JSON(http.StatusOK, response)
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	response.Checks = h.runChecks(c.Request.Context())
+	status := http.StatusOK
+	for _, result := range response.Checks {
+		if result.Status != "ok" {
+			response.Status = "degraded"
+			status = http.StatusServiceUnavailable
+		}
+	}
+	c.JSON(status, response)
+}
+
+// runChecks runs every dependency check concurrently, each under healthCheckTimeout
+func (h *HealthHandler) runChecks(ctx context.Context) map[string]HealthCheckResult {
+	results := make(map[string]HealthCheckResult, len(h.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range h.checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			start := time.Now()
+			err := check(checkCtx)
+			result := HealthCheckResult{Status: "ok", LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Status = "error"
+				log.Printf("Health check %s failed: %v", name, err)
+			}
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+	return results
 }
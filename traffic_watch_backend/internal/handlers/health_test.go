@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -110,3 +112,51 @@ func TestNewHealthHandler(t *testing.T) {
 		t.Errorf("expected version 'test-version', got %q", handler.version)
 	}
 }
+
+func TestHealthHandler_Verbose(t *testing.T) {
+	handler := NewHealthHandler("1.0.0")
+	handler.SetBuildInfo("abc123", "2026-05-01T12:00:00Z")
+	handler.SetStorageBackend("postgres")
+	var checked int
+	handler.AddCheck("database", func(ctx context.Context) error {
+		checked++
+		return nil
+	})
+	youtubeErr := errors.New("token revoked")
+	handler.AddCheck("youtube", func(ctx context.Context) error { return youtubeErr })
+
+	router := gin.New()
+	router.GET("/v1/health", handler.Health)
+	get := func(path string) (int, HealthResponse) {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		var response HealthResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("failed to parse response: %v", err)
+		}
+		return w.Code, response
+	}
+
+	// The basic probe reports build info but runs no checks
+	code, response := get("/v1/health")
+	if code != http.StatusOK || response.Status != "healthy" || response.Checks != nil || checked != 0 {
+		t.Errorf("basic probe: %d %+v, %d checks run", code, response, checked)
+	}
+	if response.GitSHA != "abc123" || response.BuildTime != "2026-05-01T12:00:00Z" || response.Storage != "postgres" {
+		t.Errorf("build info = %+v", response)
+	}
+
+	code, response = get("/v1/health?verbose=true")
+	if code != http.StatusServiceUnavailable || response.Status != "degraded" {
+		t.Errorf("verbose with a failing check: expected 503 degraded, got %d %q", code, response.Status)
+	}
+	if response.Checks["database"].Status != "ok" || response.Checks["youtube"].Status != "error" {
+		t.Errorf("checks = %+v", response.Checks)
+	}
+
+	youtubeErr = nil
+	if code, response = get("/v1/health?verbose=true"); code != http.StatusOK || response.Status != "healthy" {
+		t.Errorf("verbose with passing checks: got %d %q", code, response.Status)
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/pagination"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// ListInbox handles GET /v1/auth/me/inbox
+// Returns the current user's in-app notifications, newest first, with the unread count.
+// Pass ?unread=true to list only unread entries.
+func (h *AuthHandler) ListInbox(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	limit, err := pagination.ParseLimit(c.Query("limit"))
+	if err != nil {
+		apierror.RespondField(c, "limit", apierror.ReasonOutOfRange, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	notifications, err := h.storage.ListNotifications(ctx, user.Subject, c.Query("unread") == "true", limit)
+	if err != nil {
+		log.Printf("Failed to list inbox for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list notifications")
+		return
+	}
+	unread, err := h.storage.CountUnreadNotifications(ctx, user.Subject)
+	if err != nil {
+		log.Printf("Failed to count unread notifications for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list notifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"notifications": notifications,
+		"count":         len(notifications),
+		"unreadCount":   unread,
+	})
+}
+
+// MarkInboxRead handles POST /v1/auth/me/inbox/:id/read
+func (h *AuthHandler) MarkInboxRead(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	notificationID := c.Param("id")
+	if !validation.ValidateUUID(notificationID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid notification ID format")
+		return
+	}
+
+	if err := h.storage.MarkNotificationRead(c.Request.Context(), user.Subject, notificationID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "notification not found")
+			return
+		}
+		log.Printf("Failed to mark notification %s read: %v", notificationID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to mark notification read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "notification marked read",
+	})
+}
+
+// MarkInboxAllRead handles POST /v1/auth/me/inbox/read
+// Marks every unread notification as read
+func (h *AuthHandler) MarkInboxAllRead(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	marked, err := h.storage.MarkAllNotificationsRead(c.Request.Context(), user.Subject)
+	if err != nil {
+		log.Printf("Failed to mark inbox read for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to mark notifications read")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"marked": marked,
+	})
+}
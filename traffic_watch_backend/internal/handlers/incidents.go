@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+	"donzhit_me_backend/internal/vehicle"
+)
+
+// ============================================================================
+// Vehicle Matching and Incident Endpoints (admin only)
+// ============================================================================
+
+// ListRelatedReports handles GET /v1/admin/reports/:id/related
+// Returns other reports possibly involving the same vehicle (matched by plate or vehicle hash)
+func (h *ReportsHandler) ListRelatedReports(c *gin.Context) {
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	report, err := h.storage.GetReport(c.Request.Context(), reportID)
+	if err != nil || report.Status == models.StatusDeleted {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+
+	candidates, err := h.storage.ListReportsByVehicleHashes(c.Request.Context(), report.VehicleHashes, report.ID)
+	if err != nil {
+		log.Printf("Failed to list related reports for %s: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch related reports")
+		return
+	}
+
+	h.refreshMediaURLs(c.Request.Context(), candidates, h.mediaURLs.Admin)
+
+	related := make([]models.RelatedReport, 0, len(candidates))
+	for _, candidate := range candidates {
+		related = append(related, models.RelatedReport{
+			Report:    candidate,
+			MatchedOn: vehicle.MatchKinds(report.VehicleHashes, candidate.VehicleHashes),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reportId":   report.ID,
+		"incidentId": report.IncidentID,
+		"related":    related,
+		"count":      len(related),
+	})
+}
+
+// LinkIncident handles POST /v1/admin/incidents
+// Groups reports into an incident cluster, merging clusters the reports already belong to
+func (h *ReportsHandler) LinkIncident(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req models.LinkIncidentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	incident, err := h.storage.LinkReportsToIncident(c.Request.Context(), req.IncidentID, req.ReportIDs, req.Note, user.Email)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "one or more reports not found")
+			return
+		}
+		log.Printf("Failed to link reports %v to incident: %v", req.ReportIDs, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to link reports")
+		return
+	}
+
+	log.Printf("Reports %v linked to incident %s by %s", req.ReportIDs, incident.ID, user.Email)
+
+	c.JSON(http.StatusOK, incident)
+}
+
+// GetIncident handles GET /v1/admin/incidents/:id
+// Returns an incident with its linked reports
+func (h *ReportsHandler) GetIncident(c *gin.Context) {
+	incidentID := c.Param("id")
+	if !validation.ValidateUUID(incidentID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid incident ID format")
+		return
+	}
+
+	incident, err := h.storage.GetIncident(c.Request.Context(), incidentID)
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "incident not found")
+		return
+	}
+
+	reports := make([]models.TrafficReport, 0, len(incident.ReportIDs))
+	for _, id := range incident.ReportIDs {
+		report, err := h.storage.GetReport(c.Request.Context(), id)
+		if err != nil {
+			log.Printf("Failed to load report %s for incident %s: %v", id, incidentID, err)
+			continue
+		}
+		reports = append(reports, *report)
+	}
+	h.refreshMediaURLs(c.Request.Context(), reports, h.mediaURLs.Admin)
+
+	c.JSON(http.StatusOK, gin.H{
+		"incident": incident,
+		"reports":  reports,
+	})
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/notify"
+	"donzhit_me_backend/internal/pagination"
+	"donzhit_me_backend/internal/storage"
+)
+
+// invitationTTL is how long a role invitation can be accepted
+const invitationTTL = 7 * 24 * time.Hour
+
+// SetInvitations enables role invitations: tokens are signed by signer, mailed
+// through notifier, and link to acceptURL with the token as ?token=
+func (h *AuthHandler) SetInvitations(signer *auth.TokenSigner, notifier *notify.Notifier, acceptURL string) {
+	h.invitations = signer
+	h.notifier = notifier
+	h.invitationURL = acceptURL
+}
+
+// audit records an admin action; failures are logged, never surfaced to the caller
+func (h *AuthHandler) audit(c *gin.Context, actorID, action, targetID, details string) {
+	writeAuditEntry(c, h.storage, actorID, action, targetID, details)
+}
+
+// writeAuditEntry appends an entry to the audit log; failures are logged, never
+// surfaced to the caller
+func writeAuditEntry(c *gin.Context, store storage.Client, actorID, action, targetID, details string) {
+	entry := &models.AuditEntry{
+		ID:       uuid.New().String(),
+		ActorID:  actorID,
+		Action:   action,
+		TargetID: targetID,
+		Details:  details,
+	}
+	if err := store.CreateAuditEntry(c.Request.Context(), entry); err != nil {
+		log.Printf("Failed to write audit entry %s by %s: %v", action, actorID, err)
+	}
+}
+
+// CreateInvitation handles POST /v1/admin/invitations
+// Emails a signed invitation granting a role. The invitee accepts it by signing
+// in with the invited email and posting the token to /v1/auth/invitations/accept.
+func (h *AuthHandler) CreateInvitation(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+	if h.invitations == nil {
+		apierror.Respond(c, http.StatusServiceUnavailable, apierror.Unavailable, "invitations are not configured")
+		return
+	}
+
+	var req models.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+	if !req.Role.Valid() || req.Role == models.RoleViewer {
+		apierror.RespondField(c, "role", apierror.ReasonNotAllowed, "role must be contributor, moderator or admin")
+		return
+	}
+
+	inv := &models.RoleInvitation{
+		ID:        uuid.New().String(),
+		Email:     strings.ToLower(strings.TrimSpace(req.Email)),
+		Role:      req.Role,
+		InvitedBy: user.Subject,
+		ExpiresAt: time.Now().Add(invitationTTL),
+	}
+	if err := h.storage.CreateRoleInvitation(c.Request.Context(), inv); err != nil {
+		log.Printf("Failed to create invitation for %s: %v", inv.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create invitation")
+		return
+	}
+	h.audit(c, user.Subject, models.AuditInvitationCreated, inv.ID, "email="+inv.Email+" role="+string(inv.Role))
+
+	acceptURL := h.invitationURL + "?token=" + url.QueryEscape(h.invitations.Token(inv.ID))
+	if err := h.notifier.SendEmail(c.Request.Context(), &models.User{Email: inv.Email}, notify.Message{
+		Kind:      notify.KindInvitation,
+		Title:     "You've been invited to DonzHit.me as " + string(inv.Role),
+		Body:      "Sign in with this email address and open the link to accept. It expires in 7 days.",
+		ActionURL: acceptURL,
+	}); err != nil {
+		// The invitation stands; the admin can share acceptUrl from the response
+		log.Printf("Failed to email invitation %s to %s: %v", inv.ID, inv.Email, err)
+	}
+
+	log.Printf("Invitation %s (%s) for %s created by %s", inv.ID, inv.Role, inv.Email, user.Email)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"invitation": inv,
+		"acceptUrl":  acceptURL,
+	})
+}
+
+// ListInvitations handles GET /v1/admin/invitations
+// Returns pending (unaccepted, unexpired) invitations, newest first.
+func (h *AuthHandler) ListInvitations(c *gin.Context) {
+	invitations, err := h.storage.ListPendingRoleInvitations(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list invitations: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list invitations")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invitations": invitations,
+		"count":       len(invitations),
+	})
+}
+
+// AcceptInvitation handles POST /v1/auth/invitations/accept
+// Grants the invited role to the signed-in user, whose email must match the invitation.
+func (h *AuthHandler) AcceptInvitation(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req models.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondField(c, "token", apierror.ReasonRequired, "token is required")
+		return
+	}
+
+	var invitationID string
+	var ok bool
+	if h.invitations != nil {
+		invitationID, ok = h.invitations.Verify(req.Token)
+	}
+	if !ok {
+		apierror.RespondField(c, "token", apierror.ReasonInvalidFormat, "invalid invitation token")
+		return
+	}
+
+	ctx := c.Request.Context()
+	inv, err := h.storage.GetRoleInvitation(ctx, invitationID)
+	if err == nil && !strings.EqualFold(inv.Email, user.Email) {
+		apierror.Respond(c, http.StatusForbidden, apierror.Forbidden, "this invitation was sent to a different email address")
+		return
+	}
+	if err == nil {
+		err = h.storage.AcceptRoleInvitation(ctx, invitationID, user.Subject)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "invitation not found")
+		case errors.Is(err, storage.ErrInvitationExpired):
+			apierror.Respond(c, http.StatusGone, apierror.Expired, "invitation has expired")
+		case errors.Is(err, storage.ErrRoleDowngrade):
+			apierror.Respond(c, http.StatusConflict, apierror.Conflict, "accepting this invitation would lower your role")
+		case errors.Is(err, storage.ErrConflict):
+			apierror.Respond(c, http.StatusConflict, apierror.Conflict, "invitation has already been accepted")
+		default:
+			log.Printf("Failed to accept invitation %s for %s: %v", invitationID, user.Email, err)
+			apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to accept invitation")
+		}
+		return
+	}
+
+	log.Printf("Invitation %s accepted by %s, role now %s", invitationID, user.Email, inv.Role)
+
+	c.JSON(http.StatusOK, gin.H{
+		"userId": user.Subject,
+		"role":   inv.Role,
+	})
+}
+
+// ListAuditLog handles GET /v1/admin/audit
+// Returns the most recent admin actions, newest first.
+func (h *AuthHandler) ListAuditLog(c *gin.Context) {
+	limit, err := pagination.ParseLimit(c.Query("limit"))
+	if err != nil {
+		apierror.RespondField(c, "limit", apierror.ReasonOutOfRange, err.Error())
+		return
+	}
+
+	entries, err := h.storage.ListAuditEntries(c.Request.Context(), limit)
+	if err != nil {
+		log.Printf("Failed to list audit log: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list audit log")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"count":   len(entries),
+	})
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/staticmap"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+const (
+	// MapImageBatchSize caps how many maps one run of the map image job renders
+	MapImageBatchSize = 16
+	// mapImageRetryAfter is how long a report whose map failed to render waits before another try
+	mapImageRetryAfter = 24 * time.Hour
+	// mapImageFileID names the map in the report's folder of the blob store, next
+	// to its media (which have UUIDs), so deleting the report's files deletes it too
+	mapImageFileID = "map"
+	// mapImageMaxAge is how long clients may cache the redirect to a signed map URL
+	mapImageMaxAge = 300
+)
+
+// SetMapRenderer enables static map images of report locations, rendered by the RenderMapImages job
+func (h *ReportsHandler) SetMapRenderer(r staticmap.Renderer) {
+	h.mapRenderer = r
+}
+
+// RenderMapImages is the media worker stage for static maps. Run periodically by the
+// scheduler, it renders a map of each new report with coordinates and stores it in
+// the blob store, so the map API is called once per report.
+func (h *ReportsHandler) RenderMapImages(ctx context.Context) error {
+	if h.mapRenderer == nil || h.gcs == nil {
+		return nil
+	}
+	reports, err := h.storage.ListReportsForMapImage(ctx, time.Now().Add(-mapImageRetryAfter), MapImageBatchSize)
+	if err != nil || len(reports) == 0 {
+		return err
+	}
+
+	var failed int
+	for _, r := range reports {
+		objectPath, err := h.renderMapImage(ctx, &r)
+		if err != nil {
+			log.Printf("Failed to render map of report %s: %v", r.ID, err)
+			failed++
+		}
+		if err := h.storage.SetReportMapImage(ctx, r.ID, objectPath); err != nil {
+			log.Printf("Failed to store map of report %s: %v", r.ID, err)
+		}
+	}
+	log.Printf("Rendered maps of %d reports (%d failed)", len(reports), failed)
+	return nil
+}
+
+// renderMapImage renders a report's map and uploads it, returning its object path
+func (h *ReportsHandler) renderMapImage(ctx context.Context, r *models.TrafficReport) (string, error) {
+	image, contentType, err := h.mapRenderer.Render(ctx, *r.Latitude, *r.Longitude)
+	if err != nil {
+		return "", err
+	}
+	return h.gcs.UploadFile(ctx, r.UserID, r.ID, mapImageFileID, contentType, bytes.NewReader(image))
+}
+
+// publiclyVisible reports whether anyone may see the report: it is approved or
+// archived and not embargoed
+func publiclyVisible(r *models.TrafficReport, now time.Time) bool {
+	return (r.Status == models.StatusReviewedPass || r.Status == models.StatusArchived) && !r.Embargoed(now)
+}
+
+// publicReport gets a report anyone may see, following a merged duplicate to its
+// canonical report. Reports that aren't public are storage.ErrNotFound.
+func (h *ReportsHandler) publicReport(ctx context.Context, reportID string) (*models.TrafficReport, error) {
+	report, err := h.storage.GetReport(ctx, h.canonicalReportID(ctx, reportID))
+	if err != nil {
+		return nil, err
+	}
+	if !publiclyVisible(report, time.Now()) {
+		return nil, storage.ErrNotFound
+	}
+	return report, nil
+}
+
+// GetReportMapImage handles GET /v1/public/reports/:id/map
+// Redirects to a freshly signed URL of a public report's static map. Digest emails
+// and embeds link here, since signed URLs expire long before they stop being read.
+func (h *ReportsHandler) GetReportMapImage(c *gin.Context) {
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.publicReport(ctx, reportID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Failed to get report %s for map: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get map")
+		return
+	}
+	if err != nil || report.MapImagePath == "" || h.gcs == nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "map not found")
+		return
+	}
+
+	signedURL, err := h.gcs.GetSignedURL(ctx, report.MapImagePath, h.mediaURLs.Public)
+	if err != nil {
+		log.Printf("Failed to sign map of report %s: %v", report.ID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get map")
+		return
+	}
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(mapImageMaxAge))
+	c.Redirect(http.StatusFound, signedURL)
+}
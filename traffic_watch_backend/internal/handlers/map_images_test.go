@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// mapStore serves reports by ID and records static map attempts
+type mapStore struct {
+	storage.Client
+	reports  map[string]*models.TrafficReport
+	attempts map[string]string
+}
+
+func (s *mapStore) ListReportsForMapImage(ctx context.Context, retryBefore time.Time, limit int) ([]models.TrafficReport, error) {
+	var pending []models.TrafficReport
+	for _, r := range s.reports {
+		if r.Latitude != nil && r.MapImagePath == "" {
+			pending = append(pending, *r)
+		}
+	}
+	return pending, nil
+}
+
+func (s *mapStore) SetReportMapImage(ctx context.Context, reportID, objectPath string) error {
+	s.attempts[reportID] = objectPath
+	s.reports[reportID].MapImagePath = objectPath
+	return nil
+}
+
+func (s *mapStore) GetReport(ctx context.Context, reportID string) (*models.TrafficReport, error) {
+	r, ok := s.reports[reportID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	copied := *r
+	return &copied, nil
+}
+
+func (s *mapStore) GetReportRedirect(ctx context.Context, reportID string) (string, error) {
+	return "", nil
+}
+
+// fakeRenderer renders a placeholder image, failing for one latitude
+type fakeRenderer struct {
+	failLat float64
+}
+
+func (r *fakeRenderer) Render(ctx context.Context, lat, lng float64) ([]byte, string, error) {
+	if lat == r.failLat {
+		return nil, "", errors.New("quota exceeded")
+	}
+	return []byte("png"), "image/png", nil
+}
+
+func TestReportsHandler_RenderMapImages(t *testing.T) {
+	lat, failLat, lng := 39.75, 41.5, -84.19
+	store := &mapStore{
+		reports: map[string]*models.TrafficReport{
+			"located": {ID: "located", UserID: "u1", Latitude: &lat, Longitude: &lng},
+			"failing": {ID: "failing", UserID: "u2", Latitude: &failLat, Longitude: &lng},
+			"nowhere": {ID: "nowhere", UserID: "u3"},
+		},
+		attempts: map[string]string{},
+	}
+	blobs := &mockBlobStore{uploads: map[string][]byte{}}
+	h := NewReportsHandler(store, blobs, nil)
+
+	// Without a renderer nothing is rendered
+	if err := h.RenderMapImages(context.Background()); err != nil || len(store.attempts) != 0 {
+		t.Fatalf("RenderMapImages without a renderer = %v, attempts %v", err, store.attempts)
+	}
+
+	h.SetMapRenderer(&fakeRenderer{failLat: failLat})
+	if err := h.RenderMapImages(context.Background()); err != nil {
+		t.Fatalf("RenderMapImages: %v", err)
+	}
+	if got := store.attempts["located"]; got != "users/u1/reports/located/map" || string(blobs.uploads[got]) != "png" {
+		t.Errorf("map of located report stored at %q (%q)", got, blobs.uploads[got])
+	}
+	if got, ok := store.attempts["failing"]; !ok || got != "" {
+		t.Errorf("expected a failed attempt recorded for the failing report, got %q, %v", got, ok)
+	}
+	if _, ok := store.attempts["nowhere"]; ok {
+		t.Error("a report without coordinates got a map")
+	}
+}
+
+func TestReportsHandler_GetReportMapImage(t *testing.T) {
+	const (
+		approvedID = "6f1c0a52-3b7e-4d8a-9c1e-2f4b5a6d7e80"
+		pendingID  = "7a2d1b63-4c8f-4e9b-8d2f-3a5c6b7e8f91"
+		nomapID    = "8b3e2c74-5d90-4fac-9e30-4b6d7c8f9a02"
+	)
+	mapPath := func(id string) string { return "users/u/reports/" + id + "/map" }
+	store := &mapStore{reports: map[string]*models.TrafficReport{
+		approvedID: {ID: approvedID, Status: models.StatusReviewedPass, MapImagePath: mapPath(approvedID)},
+		pendingID:  {ID: pendingID, Status: models.StatusSubmitted, MapImagePath: mapPath(pendingID)},
+		nomapID:    {ID: nomapID, Status: models.StatusReviewedPass},
+	}}
+	h := NewReportsHandler(store, &mockBlobStore{}, nil)
+	router := gin.New()
+	router.GET("/v1/public/reports/:id/map", h.GetReportMapImage)
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus int
+	}{
+		{"approved report redirects to its map", approvedID, http.StatusFound},
+		{"unapproved report is hidden", pendingID, http.StatusNotFound},
+		{"report without a map", nomapID, http.StatusNotFound},
+		{"unknown report", "9c4f3d85-6ea1-4b0d-8f41-5c7e8d9a0b13", http.StatusNotFound},
+		{"invalid ID", "not-a-uuid", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/public/reports/"+tt.id+"/map", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusFound {
+				if got, want := w.Header().Get("Location"), "https://blobs.test/"+mapPath(tt.id)+"?signed"; got != want {
+					t.Errorf("Location = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/validation"
+)
+
+// DownloadMedia handles GET /v1/reports/:id/media/:mediaId/download
+// Streams a media file through the API instead of handing out a signed URL,
+// for cases (such as legal requests) where a URL that anyone holding it can
+// use isn't acceptable. Only the report's owner and admins can download, and
+// every download is written to the audit log. Videos hosted on YouTube have no
+// stored file to stream.
+func (h *ReportsHandler) DownloadMedia(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	// Reports the caller may not download are reported as missing, like GetReport
+	report, err := h.storage.GetReport(c.Request.Context(), reportID)
+	if err != nil || report.Status == models.StatusDeleted || (report.UserID != user.Subject && !isAdmin(c)) {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+
+	media := reportMedia(report, c.Param("mediaId"))
+	if media == nil || media.EffectiveState() != models.MediaReady {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "media not found")
+		return
+	}
+	if isYouTubeURL(media.URL) {
+		apierror.Respond(c, http.StatusConflict, apierror.Conflict, "media is hosted on YouTube and has no file to download")
+		return
+	}
+	if h.gcs == nil {
+		apierror.Respond(c, http.StatusServiceUnavailable, apierror.Unavailable, "media storage is not configured")
+		return
+	}
+
+	objectPath := mediaObjectPath(report, media)
+	size, exists, err := h.gcs.ObjectSize(c.Request.Context(), objectPath)
+	if err == nil && !exists {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "media not found")
+		return
+	}
+	reader, err := h.gcs.OpenFile(c.Request.Context(), objectPath)
+	if err != nil {
+		log.Printf("Failed to open %s for download: %v", objectPath, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to read media")
+		return
+	}
+	defer reader.Close()
+
+	writeAuditEntry(c, h.storage, user.Subject, models.AuditMediaDownloaded, media.ID, "report="+report.ID)
+	log.Printf("User %s downloaded media %s of report %s", user.Subject, media.ID, report.ID)
+
+	if !exists {
+		size = -1 // Unknown, so the response is chunked
+	}
+	c.DataFromReader(http.StatusOK, size, media.ContentType, reader, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": media.FileName}),
+		"Cache-Control":       "private, no-store",
+	})
+}
+
+// isAdmin reports whether the authenticated caller is an admin
+func isAdmin(c *gin.Context) bool {
+	value, ok := c.Get("user")
+	if !ok {
+		return false
+	}
+	user, ok := value.(*models.User)
+	return ok && user.IsAdmin()
+}
+
+// reportMedia returns the report's media file with the given ID, or nil.
+// Media IDs are UUIDs for GCS files and video IDs for YouTube uploads.
+func reportMedia(report *models.TrafficReport, mediaID string) *models.MediaFile {
+	for i := range report.MediaFiles {
+		if report.MediaFiles[i].ID == mediaID {
+			return &report.MediaFiles[i]
+		}
+	}
+	return nil
+}
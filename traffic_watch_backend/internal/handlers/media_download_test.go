@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// downloadStore serves one report and records audit entries
+type downloadStore struct {
+	storage.Client
+	report *models.TrafficReport
+	audit  []models.AuditEntry
+}
+
+func (s *downloadStore) GetReport(ctx context.Context, reportID string) (*models.TrafficReport, error) {
+	if s.report.ID != reportID {
+		return nil, storage.ErrNotFound
+	}
+	copied := *s.report
+	return &copied, nil
+}
+
+func (s *downloadStore) CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	s.audit = append(s.audit, *entry)
+	return nil
+}
+
+func TestReportsHandler_DownloadMedia(t *testing.T) {
+	const reportID = "6f1c0a52-3b7e-4d8a-9c1e-2f4b5a6d7e80"
+	report := &models.TrafficReport{
+		ID:     reportID,
+		UserID: "owner",
+		Status: models.StatusReviewedPass,
+		MediaFiles: []models.MediaFile{
+			{ID: "photo", FileName: "red light.png", ContentType: "image/png", State: models.MediaReady},
+			{ID: "pending", FileName: "late.png", ContentType: "image/png", State: models.MediaProcessing},
+			{ID: "vid-1", FileName: "clip.mp4", ContentType: "video/mp4", URL: "https://www.youtube.com/watch?v=vid-1", State: models.MediaReady},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		userID     string
+		role       models.UserRole
+		mediaID    string
+		wantStatus int
+	}{
+		{name: "owner downloads", userID: "owner", role: models.RoleContributor, mediaID: "photo", wantStatus: http.StatusOK},
+		{name: "admin downloads", userID: "admin", role: models.RoleAdmin, mediaID: "photo", wantStatus: http.StatusOK},
+		{name: "other contributor can't see the report", userID: "other", role: models.RoleContributor, mediaID: "photo", wantStatus: http.StatusNotFound},
+		{name: "media that isn't ready", userID: "owner", role: models.RoleContributor, mediaID: "pending", wantStatus: http.StatusNotFound},
+		{name: "unknown media", userID: "owner", role: models.RoleContributor, mediaID: "missing", wantStatus: http.StatusNotFound},
+		{name: "hosted video", userID: "owner", role: models.RoleContributor, mediaID: "vid-1", wantStatus: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &downloadStore{report: report}
+			blobs := &mockBlobStore{uploads: map[string][]byte{
+				"users/owner/reports/" + reportID + "/photo": pngBytes,
+			}}
+			handler := NewReportsHandler(store, blobs, nil)
+
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user", &models.User{ID: tt.userID, Role: tt.role})
+				c.Set(middleware.UserContextKey, &models.UserInfo{Subject: tt.userID})
+				c.Next()
+			})
+			router.GET("/v1/reports/:id/media/:mediaId/download", handler.DownloadMedia)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/reports/"+reportID+"/media/"+tt.mediaID+"/download", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if len(store.audit) != 0 {
+					t.Errorf("refused download should not be audited, got %+v", store.audit)
+				}
+				return
+			}
+
+			if w.Body.String() != string(pngBytes) || w.Header().Get("Content-Type") != "image/png" {
+				t.Errorf("streamed %q as %q", w.Body.String(), w.Header().Get("Content-Type"))
+			}
+			if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="red light.png"` {
+				t.Errorf("Content-Disposition = %q", got)
+			}
+			if len(store.audit) != 1 || store.audit[0].ActorID != tt.userID || store.audit[0].Action != models.AuditMediaDownloaded || store.audit[0].TargetID != "photo" {
+				t.Errorf("audit log = %+v", store.audit)
+			}
+		})
+	}
+}
+
+func (s *downloadStore) GetReportByIDAndUser(ctx context.Context, reportID, userID string) (*models.TrafficReport, error) {
+	if s.report.UserID != userID {
+		return nil, storage.ErrNotFound
+	}
+	return s.GetReport(ctx, reportID)
+}
+
+func TestReportsHandler_MediaURLLifetimes(t *testing.T) {
+	const reportID = "6f1c0a52-3b7e-4d8a-9c1e-2f4b5a6d7e80"
+	store := &downloadStore{report: &models.TrafficReport{
+		ID:         reportID,
+		UserID:     "owner",
+		Status:     models.StatusSubmitted,
+		MediaFiles: []models.MediaFile{{ID: "photo", ContentType: "image/png", State: models.MediaReady}},
+	}}
+	handler := NewReportsHandler(store, &mockBlobStore{}, nil)
+	handler.SetMediaURLLifetimes(MediaURLLifetimes{Owner: 2 * time.Hour, Admin: 30 * 24 * time.Hour})
+	if handler.mediaURLs.Public != DefaultMediaURLLifetimes().Public || handler.mediaURLs.Admin != storage.MaxMediaURLExpiration {
+		t.Errorf("lifetimes = %+v, want the public default and admin capped at %v", handler.mediaURLs, storage.MaxMediaURLExpiration)
+	}
+
+	router := gin.New()
+	router.Use(mockUserMiddleware("owner", "owner@example.com"))
+	router.GET("/v1/reports/:id", handler.GetReport)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/reports/"+reportID, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var report models.TrafficReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("failed to decode report: %v", err)
+	}
+	expiresAt := report.MediaFiles[0].URLExpiresAt
+	if expiresAt == nil || time.Until(*expiresAt) <= time.Hour || time.Until(*expiresAt) > 2*time.Hour {
+		t.Errorf("owner media urlExpiresAt = %v, want about 2h from now", expiresAt)
+	}
+}
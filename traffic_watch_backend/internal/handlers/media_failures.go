@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/validation"
+)
+
+// recordMediaFailure adds a permanently failed media job to the dead-letter queue.
+// Recording is best effort so it never changes the outcome of the upload request.
+func (h *ReportsHandler) recordMediaFailure(ctx context.Context, failure models.MediaFailure) {
+	failure.ID = uuid.New().String()
+	failure.CreatedAt = time.Now()
+	if err := h.storage.RecordMediaFailure(ctx, &failure); err != nil {
+		log.Printf("Failed to record %s failure of %s on report %s: %v", failure.Job, failure.FileName, failure.ReportID, err)
+	}
+}
+
+// ListMediaFailures handles GET /v1/admin/media/failures
+// Returns failed media jobs, newest first; ?includeResolved=true also lists ones fixed by a retry
+func (h *ReportsHandler) ListMediaFailures(c *gin.Context) {
+	failures, err := h.storage.ListMediaFailures(c.Request.Context(), c.Query("includeResolved") == "true")
+	if err != nil {
+		log.Printf("Failed to list media failures: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list media failures")
+		return
+	}
+	for i := range failures {
+		failures[i].Retryable = failures[i].CanRetry()
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"failures": failures,
+		"count":    len(failures),
+	})
+}
+
+// RetryMediaFailure handles POST /v1/admin/media/failures/:id/retry
+// Runs a failed media job again. Videos that fell back to GCS are uploaded to YouTube
+// and the report is pointed at the new video; failed GCS uploads can't be retried
+// because the file was never stored.
+func (h *ReportsHandler) RetryMediaFailure(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	id := c.Param("id")
+	if !validation.ValidateUUID(id) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid media failure ID format")
+		return
+	}
+
+	ctx := c.Request.Context()
+	failure, err := h.storage.GetMediaFailure(ctx, id)
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "media failure not found")
+		return
+	}
+	if failure.ResolvedAt != nil {
+		apierror.Respond(c, http.StatusConflict, apierror.Conflict, "media failure is already resolved")
+		return
+	}
+	if !failure.CanRetry() {
+		apierror.Respond(c, http.StatusConflict, apierror.Conflict, "the file was never stored; the submitter has to upload it again")
+		return
+	}
+	if h.youtube == nil || h.gcs == nil {
+		apierror.Respond(c, http.StatusServiceUnavailable, apierror.Unavailable, "YouTube uploads are not configured")
+		return
+	}
+
+	report, err := h.storage.GetReport(ctx, failure.ReportID)
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+
+	retryErr := h.moveVideoToYouTube(ctx, report, failure.MediaID)
+	if err := h.storage.RecordMediaRetry(ctx, id, retryErr); err != nil {
+		log.Printf("Failed to record retry of media failure %s: %v", id, err)
+	}
+	if retryErr != nil {
+		log.Printf("Retry of media failure %s by %s failed: %v", id, user.Email, retryErr)
+		apierror.Respond(c, http.StatusBadGateway, apierror.UploadFailed, "retry failed: "+retryErr.Error())
+		return
+	}
+	log.Printf("Media failure %s resolved by %s", id, user.Email)
+
+	if updated, err := h.storage.GetMediaFailure(ctx, id); err == nil {
+		failure = updated
+	}
+	c.JSON(http.StatusOK, failure)
+}
+
+// moveVideoToYouTube uploads a report video kept in GCS to YouTube, points the report
+// at the YouTube copy, and removes the GCS copy
+func (h *ReportsHandler) moveVideoToYouTube(ctx context.Context, report *models.TrafficReport, mediaID string) error {
+	var media *models.MediaFile
+	for i := range report.MediaFiles {
+		if report.MediaFiles[i].ID == mediaID {
+			media = &report.MediaFiles[i]
+		}
+	}
+	if media == nil {
+		return errors.New("media file no longer exists on the report")
+	}
+
+	objectPath := mediaObjectPath(report, media)
+	reader, err := h.gcs.OpenFile(ctx, objectPath)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	title, description := youtubeVideoText(report.Title, report.Description, media.FileName)
+	result, err := h.youtube.UploadVideo(ctx, title, description, reader, media.ContentType)
+	if err != nil {
+		return err
+	}
+	if err := h.storage.MoveMediaFile(ctx, report.ID, media.ID, result.VideoID, result.URL); err != nil {
+		h.deleteYouTubeVideo(ctx, report.ID, media, result.VideoID)
+		return fmt.Errorf("uploaded as %s but failed to update the report: %w", result.VideoID, err)
+	}
+	h.InvalidateFeedCache()
+
+	if err := h.gcs.DeleteFile(ctx, objectPath); err != nil {
+		log.Printf("Failed to delete GCS copy %s after moving it to YouTube: %v", objectPath, err)
+	}
+	return nil
+}
+
+// deleteYouTubeVideo removes a video no report points to any more. Unlisted videos are
+// never cleaned up otherwise, so a failed deletion is recorded for admins to finish on
+// the channel.
+func (h *ReportsHandler) deleteYouTubeVideo(ctx context.Context, reportID string, media *models.MediaFile, videoID string) {
+	if err := h.youtube.DeleteVideo(ctx, videoID); err != nil {
+		log.Printf("Failed to delete YouTube video %s of report %s: %v", videoID, reportID, err)
+		h.recordMediaFailure(ctx, models.MediaFailure{
+			Job:      models.MediaJobYouTubeDelete,
+			ReportID: reportID,
+			MediaID:  media.ID,
+			FileName: media.FileName,
+			Error:    err.Error(),
+		})
+	}
+}
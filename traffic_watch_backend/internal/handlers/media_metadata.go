@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/metadata"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/validation"
+)
+
+// GetMediaMetadata handles GET /v1/admin/reports/:id/media/:mediaId/metadata
+// Returns the EXIF/MP4 metadata extracted at upload, with the GPS, device and
+// timestamp fields pulled out so reviewers can check authenticity without
+// downloading the file. Location and capture times are absent when the
+// submitter opted out of retaining them. The metadata and the text read from
+// images by OCR, including plate candidates, are only ever returned here.
+func (h *ReportsHandler) GetMediaMetadata(c *gin.Context) {
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	report, err := h.storage.GetReport(c.Request.Context(), reportID)
+	if err != nil || report.Status == models.StatusDeleted {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+
+	media := reportMedia(report, c.Param("mediaId"))
+	if media == nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "media not found")
+		return
+	}
+
+	raw := media.Metadata
+	if raw == nil {
+		raw = models.MediaMetadata{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reportId":            report.ID,
+		"mediaId":             media.ID,
+		"fileName":            media.FileName,
+		"contentType":         media.ContentType,
+		"retainMediaMetadata": report.RetainMediaMetadata,
+		"summary":             metadata.Summarize(raw),
+		"metadata":            raw,
+		"ocr":                 media.OCR,
+	})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// UploadCheckBatchSize caps how many media files one run of the check-uploads job looks at
+const UploadCheckBatchSize = 50
+
+// UploadArrivalGrace is how long after its signed URL expires an upload may take to
+// arrive before the media file is marked failed
+const UploadArrivalGrace = 15 * time.Minute
+
+// CheckUploads is the media worker stage for signed-URL uploads. Run periodically by
+// the scheduler, it quarantines processing media once its object is in GCS, then checks
+// the object's size and leading bytes against the declared file type and marks it
+// ready or failed. Failed objects are deleted and recorded in the dead-letter queue.
+func (h *ReportsHandler) CheckUploads(ctx context.Context) error {
+	if h.gcs == nil {
+		return nil
+	}
+	jobs, err := h.storage.ListPendingMedia(ctx, UploadCheckBatchSize)
+	if err != nil || len(jobs) == 0 {
+		return err
+	}
+
+	var ready, failed int
+	for _, job := range jobs {
+		state, err := h.checkUpload(ctx, job)
+		if err != nil {
+			log.Printf("Failed to check upload of media %s on report %s: %v", job.Media.ID, job.ReportID, err)
+			continue
+		}
+		switch state {
+		case models.MediaReady:
+			ready++
+		case models.MediaFailed:
+			failed++
+		}
+	}
+	// Reports whose last pending media became ready enter the feed
+	if ready > 0 {
+		h.InvalidateFeedCache()
+	}
+	log.Printf("Checked %d uploads (%d ready, %d failed)", len(jobs), ready, failed)
+	return nil
+}
+
+// checkUpload moves one pending media file along and returns its new state
+func (h *ReportsHandler) checkUpload(ctx context.Context, job models.MediaStateJob) (string, error) {
+	mf := &job.Media
+	objectPath := mediaObjectPath(&models.TrafficReport{ID: job.ReportID, UserID: job.UserID}, mf)
+	size, exists, err := h.gcs.ObjectSize(ctx, objectPath)
+	if err != nil {
+		return "", err
+	}
+
+	if !exists {
+		switch {
+		case mf.State == models.MediaQuarantined:
+			return h.failUpload(ctx, job, objectPath, "uploaded file disappeared before it was checked")
+		case time.Since(mf.UploadedAt) > storage.UploadURLExpiration+UploadArrivalGrace:
+			return h.failUpload(ctx, job, objectPath, "upload never arrived")
+		}
+		return mf.State, nil
+	}
+
+	if mf.State == models.MediaProcessing {
+		if err := h.storage.SetMediaState(ctx, job.ReportID, mf.ID, models.MediaProcessing, models.MediaQuarantined); err != nil {
+			return "", err
+		}
+		mf.State = models.MediaQuarantined
+	}
+
+	if valid, errMsg := validation.ValidateFileSpec(mf.FileName, mf.ContentType, size); !valid {
+		return h.failUpload(ctx, job, objectPath, errMsg)
+	}
+	head, err := h.readHead(ctx, objectPath)
+	if err != nil {
+		return "", err
+	}
+	if !validation.ContentMatches(mf.ContentType, head) {
+		return h.failUpload(ctx, job, objectPath, fmt.Sprintf("file content does not match %s", mf.ContentType))
+	}
+
+	if err := h.storage.SetMediaState(ctx, job.ReportID, mf.ID, models.MediaQuarantined, models.MediaReady); err != nil {
+		return "", err
+	}
+	return models.MediaReady, nil
+}
+
+// readHead reads the leading bytes of a GCS object for ContentMatches
+func (h *ReportsHandler) readHead(ctx context.Context, objectPath string) ([]byte, error) {
+	reader, err := h.gcs.OpenFile(ctx, objectPath)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	head := make([]byte, validation.SniffLength)
+	n, err := io.ReadFull(reader, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	return head[:n], nil
+}
+
+// failUpload marks a pending media file failed, deletes whatever was uploaded, and
+// records the failure for admins
+func (h *ReportsHandler) failUpload(ctx context.Context, job models.MediaStateJob, objectPath, reason string) (string, error) {
+	if err := h.storage.SetMediaState(ctx, job.ReportID, job.Media.ID, job.Media.State, models.MediaFailed); err != nil {
+		return "", err
+	}
+	if err := h.gcs.DeleteFile(ctx, objectPath); err != nil {
+		log.Printf("Failed to delete rejected upload %s: %v", objectPath, err)
+	}
+	h.recordMediaFailure(ctx, models.MediaFailure{
+		Job:      models.MediaJobUploadCheck,
+		ReportID: job.ReportID,
+		MediaID:  job.Media.ID,
+		FileName: job.Media.FileName,
+		Error:    reason,
+	})
+	return models.MediaFailed, nil
+}
@@ -0,0 +1,346 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+var (
+	pngBytes = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+	mp4Bytes = []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+)
+
+// uploadStore is a storage.Client for the multipart create flow; methods it
+// doesn't override panic through the nil embedded interface
+type uploadStore struct {
+	storage.Client
+	reports  []*models.TrafficReport
+	failures []models.MediaFailure
+}
+
+func (s *uploadStore) WithTransaction(ctx context.Context, fn func(tx storage.Client) error) error {
+	return fn(s)
+}
+
+func (s *uploadStore) CreateReport(ctx context.Context, report *models.TrafficReport) error {
+	s.reports = append(s.reports, report)
+	return nil
+}
+
+func (s *uploadStore) EnqueueOutboxEvent(ctx context.Context, e *models.OutboxEvent) error {
+	return nil
+}
+
+func (s *uploadStore) RecordMediaFailure(ctx context.Context, failure *models.MediaFailure) error {
+	s.failures = append(s.failures, *failure)
+	return nil
+}
+
+// GetUserByID finds no user, so uploads get the standard media limits
+func (s *uploadStore) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	return nil, storage.ErrNotFound
+}
+
+type mockBlobStore struct {
+	uploads map[string][]byte
+	err     error
+}
+
+func (m *mockBlobStore) UploadFile(ctx context.Context, userID, reportID, fileID string, contentType string, reader io.Reader) (string, error) {
+	if m.err != nil {
+		return "", m.err
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	objectPath := "users/" + userID + "/reports/" + reportID + "/" + fileID
+	m.uploads[objectPath] = data
+	return objectPath, nil
+}
+
+func (m *mockBlobStore) GetSignedURL(ctx context.Context, objectPath string, expiration time.Duration) (string, error) {
+	return "https://blobs.test/" + objectPath + "?signed", nil
+}
+
+func (m *mockBlobStore) GetUploadSignedURL(ctx context.Context, userID, reportID, fileID, contentType string) (string, string, error) {
+	return "", "", errors.New("not used")
+}
+
+func (m *mockBlobStore) OpenFile(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m.uploads[objectPath])), nil
+}
+
+func (m *mockBlobStore) DeleteFile(ctx context.Context, objectPath string) error {
+	delete(m.uploads, objectPath)
+	return nil
+}
+
+func (m *mockBlobStore) ObjectSize(ctx context.Context, objectPath string) (int64, bool, error) {
+	data, ok := m.uploads[objectPath]
+	return int64(len(data)), ok, nil
+}
+
+func (m *mockBlobStore) GetBucketName() string {
+	return "test-bucket"
+}
+
+type mockVideoHost struct {
+	err error
+}
+
+func (m *mockVideoHost) UploadVideo(ctx context.Context, title, description string, reader io.Reader, contentType string) (*storage.YouTubeUploadResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &storage.YouTubeUploadResult{VideoID: "vid-1", URL: "https://www.youtube.com/watch?v=vid-1"}, nil
+}
+
+func (m *mockVideoHost) DeleteVideo(ctx context.Context, videoID string) error {
+	return nil
+}
+
+// multipartReport builds a multipart create request with one attached file
+func multipartReport(t *testing.T, fileName, contentType string, data []byte) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	fields := map[string]string{
+		"title":       "Ran the light",
+		"description": "At Main and 5th",
+		"dateTime":    "2024-05-01T08:00:00Z",
+		"roadUsages":  "Auto",
+		"eventTypes":  "Speeding",
+		"state":       "Ohio",
+	}
+	for name, value := range fields {
+		if err := writer.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="files"; filename="`+fileName+`"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/reports", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestReportsHandler_CreateReportMultipart_Uploads(t *testing.T) {
+	tests := []struct {
+		name         string
+		file         string
+		data         []byte
+		contentType  string
+		blobErr      error
+		video        *mockVideoHost // nil leaves the handler without a video host
+		wantStatus   int
+		wantURL      string
+		wantBlob     bool
+		wantFailures []string
+	}{
+		{
+			name:        "image goes to the blob store",
+			file:        "photo.png",
+			data:        pngBytes,
+			contentType: "image/png",
+			video:       &mockVideoHost{},
+			wantStatus:  http.StatusCreated,
+			wantURL:     "https://blobs.test/",
+			wantBlob:    true,
+		},
+		{
+			name:        "video goes to the video host",
+			file:        "clip.mp4",
+			data:        mp4Bytes,
+			contentType: "video/mp4",
+			video:       &mockVideoHost{},
+			wantStatus:  http.StatusCreated,
+			wantURL:     "https://www.youtube.com/watch?v=vid-1",
+		},
+		{
+			name:        "video goes to the blob store without a video host",
+			file:        "clip.mp4",
+			data:        mp4Bytes,
+			contentType: "video/mp4",
+			wantStatus:  http.StatusCreated,
+			wantURL:     "https://blobs.test/",
+			wantBlob:    true,
+		},
+		{
+			name:         "failed video upload falls back to the blob store and is queued for retry",
+			file:         "clip.mp4",
+			data:         mp4Bytes,
+			contentType:  "video/mp4",
+			video:        &mockVideoHost{err: errors.New("quota exceeded")},
+			wantStatus:   http.StatusCreated,
+			wantURL:      "https://blobs.test/",
+			wantBlob:     true,
+			wantFailures: []string{models.MediaJobYouTubeUpload},
+		},
+		{
+			name:         "failed blob upload fails the request",
+			file:         "photo.png",
+			data:         pngBytes,
+			contentType:  "image/png",
+			blobErr:      errors.New("bucket unavailable"),
+			wantStatus:   http.StatusInternalServerError,
+			wantFailures: []string{models.MediaJobGCSUpload},
+		},
+		{
+			name:        "content that doesn't match its type is rejected",
+			file:        "photo.png",
+			data:        []byte("not an image"),
+			contentType: "image/png",
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &uploadStore{}
+			blobs := &mockBlobStore{uploads: map[string][]byte{}, err: tt.blobErr}
+			var handler *ReportsHandler
+			if tt.video != nil {
+				handler = NewReportsHandler(store, blobs, tt.video)
+			} else {
+				handler = NewReportsHandler(store, blobs, nil)
+			}
+
+			router := gin.New()
+			router.Use(mockUserMiddleware("user-123", "user@example.com"))
+			router.POST("/v1/reports", handler.CreateReport)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, multipartReport(t, tt.file, tt.contentType, tt.data))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if got := len(blobs.uploads) > 0; got != tt.wantBlob {
+				t.Errorf("blob store upload = %v, want %v", got, tt.wantBlob)
+			}
+			var jobs []string
+			for _, failure := range store.failures {
+				jobs = append(jobs, failure.Job)
+			}
+			if len(jobs) != len(tt.wantFailures) || (len(jobs) > 0 && jobs[0] != tt.wantFailures[0]) {
+				t.Errorf("recorded failures %v, want %v", jobs, tt.wantFailures)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				if len(store.reports) != 0 {
+					t.Error("no report should be created")
+				}
+				return
+			}
+
+			var report models.TrafficReport
+			if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(store.reports) != 1 || len(report.MediaFiles) != 1 {
+				t.Fatalf("expected one stored report with one media file, got %d reports: %+v", len(store.reports), report.MediaFiles)
+			}
+			media := report.MediaFiles[0]
+			if !strings.HasPrefix(media.URL, tt.wantURL) || media.State != models.MediaReady || media.FileName != tt.file {
+				t.Errorf("media = %+v, want URL starting %q", media, tt.wantURL)
+			}
+			// Only signed blob URLs expire
+			if tt.wantBlob {
+				if media.URLExpiresAt == nil || time.Until(*media.URLExpiresAt) <= 0 || time.Until(*media.URLExpiresAt) > storage.MediaURLExpiration {
+					t.Errorf("urlExpiresAt = %v, want within %v", media.URLExpiresAt, storage.MediaURLExpiration)
+				}
+			} else if media.URLExpiresAt != nil {
+				t.Errorf("hosted video should have no urlExpiresAt, got %v", media.URLExpiresAt)
+			}
+		})
+	}
+}
+
+func TestReportsHandler_UploadProgress(t *testing.T) {
+	const sessionID = "0d9b6a3e-7f21-4c55-8e0a-5b1f2c3d4e6f"
+	store := &uploadStore{}
+	handler := NewReportsHandler(store, &mockBlobStore{uploads: map[string][]byte{}}, nil)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		userID := c.GetHeader("X-Test-User")
+		mockUserMiddleware(userID, userID+"@example.com")(c)
+	})
+	router.POST("/v1/reports", handler.CreateReport)
+	router.GET("/v1/uploads/:sessionId", handler.GetUploadProgress)
+
+	submit := func(data []byte) *models.UploadProgress {
+		t.Helper()
+		req := multipartReport(t, "photo.png", "image/png", data)
+		req.Header.Set(UploadSessionHeader, sessionID)
+		req.Header.Set("X-Test-User", "user-123")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		req = httptest.NewRequest(http.MethodGet, "/v1/uploads/"+sessionID, nil)
+		req.Header.Set("X-Test-User", "user-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("progress: expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var progress models.UploadProgress
+		if err := json.Unmarshal(w.Body.Bytes(), &progress); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return &progress
+	}
+
+	progress := submit(pngBytes)
+	if progress.Stage != models.UploadComplete || progress.ReportID == "" || progress.ReportID != store.reports[0].ID {
+		t.Errorf("completed upload: %+v", progress)
+	}
+	if progress.BytesTotal <= 0 || progress.BytesReceived != progress.BytesTotal || progress.FilesTotal != 1 || progress.FilesProcessed != 1 {
+		t.Errorf("completed upload counts: %+v", progress)
+	}
+
+	// Reusing the session ID starts over
+	progress = submit([]byte("not an image"))
+	if progress.Stage != models.UploadFailed || progress.ReportID != "" || progress.FilesProcessed != 0 {
+		t.Errorf("rejected upload: %+v", progress)
+	}
+
+	// Other users can't see the session
+	req := httptest.NewRequest(http.MethodGet, "/v1/uploads/"+sessionID, nil)
+	req.Header.Set("X-Test-User", "user-456")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("another user's session: expected 404, got %d", w.Code)
+	}
+
+	req = multipartReport(t, "photo.png", "image/png", pngBytes)
+	req.Header.Set(UploadSessionHeader, "not-a-uuid")
+	req.Header.Set("X-Test-User", "user-123")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("malformed session ID: expected 400, got %d", w.Code)
+	}
+}
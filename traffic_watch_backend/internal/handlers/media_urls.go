@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"time"
+
+	"donzhit_me_backend/internal/storage"
+)
+
+// MediaURLLifetimes sets how long the signed media URLs handed out in each
+// context stay valid. Admin links are kept short since review screens are
+// reloaded often and their URLs can reach reports that aren't public; feed
+// URLs live longer so cached feed pages and scrolled-back cards keep loading.
+type MediaURLLifetimes struct {
+	Public time.Duration // Public feed and archive
+	Owner  time.Duration // The owner's reports and upload responses
+	Admin  time.Duration // Review queue, search, incidents and merges
+}
+
+// DefaultMediaURLLifetimes returns the lifetimes used unless configured otherwise
+func DefaultMediaURLLifetimes() MediaURLLifetimes {
+	return MediaURLLifetimes{
+		Public: 6 * time.Hour,
+		Owner:  storage.MediaURLExpiration,
+		Admin:  15 * time.Minute,
+	}
+}
+
+// SetMediaURLLifetimes sets the signed media URL lifetimes. Zero or negative
+// lifetimes keep the default, and none may exceed storage.MaxMediaURLExpiration.
+func (h *ReportsHandler) SetMediaURLLifetimes(l MediaURLLifetimes) {
+	defaults := DefaultMediaURLLifetimes()
+	lifetime := func(d, fallback time.Duration) time.Duration {
+		if d <= 0 {
+			return fallback
+		}
+		return min(d, storage.MaxMediaURLExpiration)
+	}
+	h.mediaURLs = MediaURLLifetimes{
+		Public: lifetime(l.Public, defaults.Public),
+		Owner:  lifetime(l.Owner, defaults.Owner),
+		Admin:  lifetime(l.Admin, defaults.Admin),
+	}
+}
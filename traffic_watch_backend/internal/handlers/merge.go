@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// MergeReports handles POST /v1/admin/reports/:id/merge/:otherId
+// Merges a duplicate report (:otherId) into the canonical report (:id)
+func (h *ReportsHandler) MergeReports(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	canonicalID := c.Param("id")
+	duplicateID := c.Param("otherId")
+	if !validation.ValidateUUID(canonicalID) || !validation.ValidateUUID(duplicateID) {
+		apierror.RespondField(c, "otherId", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+	if canonicalID == duplicateID {
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "cannot merge a report into itself")
+		return
+	}
+
+	merged := events.Event{Type: events.ReportMerged, ReportID: duplicateID, Actor: user.Email}
+	if err := h.changeWithEvent(c.Request.Context(), merged, func(tx storage.Client) error {
+		return tx.MergeReports(c.Request.Context(), canonicalID, duplicateID, user.Email)
+	}); err != nil {
+		switch {
+		case errors.Is(err, storage.ErrNotFound):
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		case errors.Is(err, storage.ErrConflict), errors.Is(err, models.ErrInvalidStatusTransition):
+			apierror.Respond(c, http.StatusConflict, apierror.Conflict, "one of the reports has already been merged")
+		default:
+			log.Printf("Failed to merge report %s into %s: %v", duplicateID, canonicalID, err)
+			apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to merge reports")
+		}
+		return
+	}
+
+	log.Printf("Report %s merged into %s by %s", duplicateID, canonicalID, user.Email)
+
+	report, err := h.storage.GetReport(c.Request.Context(), canonicalID)
+	if err != nil {
+		log.Printf("Failed to load merged report %s: %v", canonicalID, err)
+		c.JSON(http.StatusOK, gin.H{
+			"message":     "reports merged successfully",
+			"canonicalId": canonicalID,
+			"mergedId":    duplicateID,
+		})
+		return
+	}
+	h.refreshReportMediaURLs(c.Request.Context(), report, h.mediaURLs.Admin)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "reports merged successfully",
+		"canonicalId": canonicalID,
+		"mergedId":    duplicateID,
+		"report":      report,
+	})
+}
+
+// canonicalReportID resolves a merged report's ID to the report it was merged into
+func (h *ReportsHandler) canonicalReportID(ctx context.Context, reportID string) string {
+	target, err := h.storage.GetReportRedirect(ctx, reportID)
+	if err != nil || target == "" {
+		return reportID
+	}
+	return target
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/metrics"
+)
+
+// MetricsHandler exposes in-process operation metrics
+type MetricsHandler struct {
+	storage *metrics.Registry
+	legacy  *metrics.Registry
+}
+
+// NewMetricsHandler creates a new metrics handler for the storage and legacy route registries
+func NewMetricsHandler(storage, legacy *metrics.Registry) *MetricsHandler {
+	return &MetricsHandler{storage: storage, legacy: legacy}
+}
+
+// StorageMetrics handles GET /v1/admin/metrics/storage
+// Returns call counts, error and timeout counts, and latency histograms per storage method
+func (h *MetricsHandler) StorageMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"operations": h.storage.Snapshot(),
+	})
+}
+
+// LegacyMetrics handles GET /v1/admin/metrics/legacy
+// Returns per-route usage of the deprecated /v1/legacy routes, so they can be
+// disabled once traffic reaches zero
+func (h *MetricsHandler) LegacyMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"routes": h.legacy.Snapshot(),
+	})
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/moderation"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// SetWordFilter enables the blocked-word filter on report and comment submission
+func (h *ReportsHandler) SetWordFilter(filter *moderation.WordFilter) {
+	h.wordFilter = filter
+}
+
+// ReloadWordFilter refreshes the filter from storage so changes made on other
+// instances take effect (no-op without a filter)
+func (h *ReportsHandler) ReloadWordFilter(ctx context.Context) error {
+	if h.wordFilter == nil {
+		return nil
+	}
+	words, err := h.storage.ListBlockedWords(ctx)
+	if err != nil {
+		return err
+	}
+	h.wordFilter.Load(words)
+	return nil
+}
+
+// applyWordFilter strips markup from submitted text fields and runs the word filter over
+// them, rewriting them in place. It writes a 400 response and returns ok=false if a field
+// was nothing but markup or a reject word matched; flagged reports whether the submission
+// should be held for moderator review.
+func (h *ReportsHandler) applyWordFilter(c *gin.Context, user *models.UserInfo, fields ...*string) (flagged, ok bool) {
+	texts := make([]string, len(fields))
+	for i, f := range fields {
+		texts[i] = validation.SanitizeText(*f)
+		if texts[i] == "" && strings.TrimSpace(*f) != "" {
+			apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "submission contains no text once markup is removed")
+			return false, false
+		}
+	}
+
+	masked, result := h.wordFilter.CheckAll(texts...)
+	if result.Action == models.WordActionReject {
+		log.Printf("Submission from %s rejected by word filter (matched %v)", user.Email, result.Matches)
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "submission contains blocked language")
+		return false, false
+	}
+
+	for i, f := range fields {
+		*f = masked[i]
+	}
+	if result.Action == models.WordActionFlag {
+		log.Printf("Submission from %s flagged by word filter (matched %v)", user.Email, result.Matches)
+		return true, true
+	}
+	return false, true
+}
+
+// ListBlockedWords handles GET /v1/admin/moderation/words
+func (h *ReportsHandler) ListBlockedWords(c *gin.Context) {
+	words, err := h.storage.ListBlockedWords(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list blocked words: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list blocked words")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"words": words,
+		"count": len(words),
+	})
+}
+
+// UpsertBlockedWord handles POST /v1/admin/moderation/words
+// Adds a blocked word or changes its action
+func (h *ReportsHandler) UpsertBlockedWord(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req models.UpsertBlockedWordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+	if strings.TrimSpace(req.Word) == "" || strings.Contains(req.Word, "/") {
+		apierror.RespondField(c, "word", apierror.ReasonInvalidFormat, "word must not be blank or contain '/'")
+		return
+	}
+
+	word := &models.BlockedWord{
+		Word:      req.Word,
+		Action:    req.Action,
+		CreatedBy: user.Email,
+	}
+	if err := h.storage.UpsertBlockedWord(c.Request.Context(), word); err != nil {
+		log.Printf("Failed to save blocked word: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to save blocked word")
+		return
+	}
+
+	log.Printf("Blocked word %q set to %s by %s", word.Word, word.Action, user.Email)
+	if err := h.ReloadWordFilter(c.Request.Context()); err != nil {
+		log.Printf("Failed to reload word filter: %v", err)
+	}
+
+	c.JSON(http.StatusOK, word)
+}
+
+// DeleteBlockedWord handles DELETE /v1/admin/moderation/words/:word
+func (h *ReportsHandler) DeleteBlockedWord(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	word := c.Param("word")
+	if err := h.storage.DeleteBlockedWord(c.Request.Context(), word); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "blocked word not found")
+			return
+		}
+		log.Printf("Failed to delete blocked word: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to delete blocked word")
+		return
+	}
+
+	log.Printf("Blocked word %q removed by %s", word, user.Email)
+	if err := h.ReloadWordFilter(c.Request.Context()); err != nil {
+		log.Printf("Failed to reload word filter: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "blocked word removed",
+	})
+}
+
+// ListFlaggedComments handles GET /v1/admin/moderation/comments
+// Returns comments held back by the word filter or the comment policy, oldest first, with their authors' emails
+func (h *ReportsHandler) ListFlaggedComments(c *gin.Context) {
+	comments, err := h.storage.ListFlaggedComments(c.Request.Context(), includeShadowBanned(c))
+	if err != nil {
+		log.Printf("Failed to list flagged comments: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list flagged comments")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"comments": models.AdminComments(comments),
+		"count":    len(comments),
+	})
+}
+
+// ResolveFlaggedComment handles POST /v1/admin/moderation/comments/:commentId
+// Publishes the comment when approved, otherwise deletes it
+func (h *ReportsHandler) ResolveFlaggedComment(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	commentID := c.Param("commentId")
+	if !validation.ValidateUUID(commentID) {
+		apierror.RespondField(c, "commentId", apierror.ReasonInvalidFormat, "invalid comment ID format")
+		return
+	}
+
+	var req models.ResolveCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	comment, err := h.storage.GetCommentByID(c.Request.Context(), commentID)
+	if err != nil || !comment.Flagged {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "flagged comment not found")
+		return
+	}
+
+	// An approved comment is announced through the outbox together with its publication
+	added := events.Event{Type: events.CommentAdded, ReportID: comment.ReportID, CommentID: commentID, Actor: user.Email}
+	err = h.storage.WithTransaction(c.Request.Context(), func(tx storage.Client) error {
+		if err := tx.ResolveFlaggedComment(c.Request.Context(), commentID, req.Approve); err != nil {
+			return err
+		}
+		if !req.Approve {
+			return nil
+		}
+		return enqueueEvent(c.Request.Context(), tx, added)
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "flagged comment not found")
+			return
+		}
+		log.Printf("Failed to resolve flagged comment %s: %v", commentID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to resolve flagged comment")
+		return
+	}
+
+	if req.Approve {
+		// Held comments only count towards priority once published, and only if
+		// DeleteComment would take the score back off
+		published := *comment
+		published.Flagged = false
+		if h.commentCounted(c.Request.Context(), &published) {
+			if err := h.storage.AdjustReportPriority(c.Request.Context(), comment.ReportID, ScoreComment); err != nil {
+				log.Printf("Failed to adjust priority for report %s: %v", comment.ReportID, err)
+			}
+		}
+		h.events.Publish(added)
+		log.Printf("Flagged comment %s approved by %s", commentID, user.Email)
+		c.JSON(http.StatusOK, gin.H{
+			"message": "comment published",
+		})
+		return
+	}
+
+	log.Printf("Flagged comment %s removed by %s", commentID, user.Email)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "comment removed",
+	})
+}
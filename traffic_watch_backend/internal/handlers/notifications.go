@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// SetUnsubscriber sets the verifier for digest unsubscribe links
+func (h *AuthHandler) SetUnsubscriber(u *auth.TokenSigner) {
+	h.unsubscriber = u
+}
+
+// unsubscribePageTemplate is the page digest unsubscribe links open. Mail scanners and
+// link prefetchers follow links in emails, so opening one only asks for confirmation;
+// the form posts back to the same link.
+var unsubscribePageTemplate = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex">
+<title>Unsubscribe - DonzHit.me</title>
+</head>
+<body>
+{{- if .Done}}
+<p>You're unsubscribed from the weekly DonzHit.me digest.</p>
+{{- else if .Token}}
+<p>Stop getting the weekly DonzHit.me digest?</p>
+<form method="post" action="{{.Action}}">
+<input type="hidden" name="token" value="{{.Token}}">
+<button type="submit">Unsubscribe</button>
+</form>
+{{- else}}
+<p>This unsubscribe link is invalid.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+// unsubscribePage is what the unsubscribe page template renders
+type unsubscribePage struct {
+	Action string // Where the confirmation form posts
+	Token  string // Empty when the link's token is invalid
+	Done   bool
+}
+
+// ConfirmUnsubscribeDigest handles GET /v1/public/notifications/unsubscribe?token=
+// Checks the token and shows a page asking the user to confirm; nothing changes until
+// they do, so scanners following the link can't unsubscribe anyone
+func (h *AuthHandler) ConfirmUnsubscribeDigest(c *gin.Context) {
+	if h.unsubscriber == nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "unsubscribe links are not enabled")
+		return
+	}
+
+	token := c.Query("token")
+	page := unsubscribePage{Action: c.Request.URL.Path}
+	status := http.StatusBadRequest
+	if _, ok := h.unsubscriber.Verify(token); ok {
+		page.Token = token
+		status = http.StatusOK
+	}
+	renderUnsubscribePage(c, status, page)
+}
+
+// UnsubscribeDigest handles POST /v1/public/notifications/unsubscribe?token=
+// Turns off the weekly digest for the user the token was issued to; no login needed.
+// The token may also come as a form field. This is the RFC 8058 one-click endpoint
+// that List-Unsubscribe-Post points mail clients at, and the target of the
+// confirmation page; browsers get a page back, everyone else JSON.
+func (h *AuthHandler) UnsubscribeDigest(c *gin.Context) {
+	if h.unsubscriber == nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "unsubscribe links are not enabled")
+		return
+	}
+
+	token := c.Query("token")
+	if token == "" {
+		token = c.PostForm("token")
+	}
+	userID, ok := h.unsubscriber.Verify(token)
+	if !ok {
+		apierror.RespondField(c, "token", apierror.ReasonInvalidFormat, "invalid unsubscribe token")
+		return
+	}
+
+	ctx := c.Request.Context()
+	user, err := h.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "user not found")
+		return
+	}
+
+	prefs := user.NotificationSettings()
+	prefs.WeeklyDigest = false
+	if err := h.storage.UpdateNotificationPreferences(ctx, userID, prefs); err != nil {
+		log.Printf("Failed to unsubscribe %s from digest: %v", userID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to unsubscribe")
+		return
+	}
+
+	log.Printf("User %s unsubscribed from the weekly digest", userID)
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		renderUnsubscribePage(c, http.StatusOK, unsubscribePage{Done: true})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": "unsubscribed from the weekly digest",
+	})
+}
+
+func renderUnsubscribePage(c *gin.Context, status int, page unsubscribePage) {
+	var body strings.Builder
+	if err := unsubscribePageTemplate.Execute(&body, page); err != nil {
+		log.Printf("Failed to render unsubscribe page: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to render page")
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Data(status, "text/html; charset=utf-8", []byte(body.String()))
+}
+
+// GetNotificationPreferences handles GET /v1/auth/me/notifications
+// Returns the current user's notification settings
+func (h *AuthHandler) GetNotificationPreferences(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	stored, err := h.storage.GetUserByID(c.Request.Context(), user.Subject)
+	if err != nil {
+		log.Printf("Failed to load user %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to load notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, stored.NotificationSettings())
+}
+
+// UpdateNotificationPreferences handles PATCH /v1/auth/me/notifications
+// Updates the settings present in the body and returns the full result
+func (h *AuthHandler) UpdateNotificationPreferences(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req models.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	stored, err := h.storage.GetUserByID(c.Request.Context(), user.Subject)
+	if err != nil {
+		log.Printf("Failed to load user %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to load notification preferences")
+		return
+	}
+
+	prefs := req.Apply(stored.NotificationSettings())
+	if err := h.storage.UpdateNotificationPreferences(c.Request.Context(), user.Subject, prefs); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "user not found")
+			return
+		}
+		log.Printf("Failed to update notification preferences for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// prefsStore holds one user's notification preferences
+type prefsStore struct {
+	storage.Client
+	prefs models.NotificationPreferences
+}
+
+func (s *prefsStore) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	if userID != "user-1" {
+		return nil, storage.ErrNotFound
+	}
+	prefs := s.prefs
+	return &models.User{ID: userID, Notifications: &prefs}, nil
+}
+
+func (s *prefsStore) UpdateNotificationPreferences(ctx context.Context, userID string, prefs models.NotificationPreferences) error {
+	s.prefs = prefs
+	return nil
+}
+
+func TestAuthHandler_UnsubscribeDigest(t *testing.T) {
+	store := &prefsStore{prefs: models.NotificationPreferences{WeeklyDigest: true, EmailOnReview: true}}
+	unsubscriber := auth.NewTokenSigner("secret", auth.PurposeDigestUnsubscribe)
+	h := NewAuthHandler(store, nil, nil)
+	h.SetUnsubscriber(unsubscriber)
+	router := gin.New()
+	router.GET("/unsubscribe", h.ConfirmUnsubscribeDigest)
+	router.POST("/unsubscribe", h.UnsubscribeDigest)
+	link := "/unsubscribe?token=" + url.QueryEscape(unsubscriber.Token("user-1"))
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Opening the link, as a mail scanner would, only asks for confirmation
+	w := serve(httptest.NewRequest(http.MethodGet, link, nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `<form method="post"`) {
+		t.Fatalf("GET = %d: %s", w.Code, w.Body.String())
+	}
+	if !store.prefs.WeeklyDigest {
+		t.Fatal("GET turned off the digest")
+	}
+	if w := serve(httptest.NewRequest(http.MethodGet, "/unsubscribe?token=forged", nil)); w.Code != http.StatusBadRequest {
+		t.Errorf("GET with a bad token = %d, want 400", w.Code)
+	}
+
+	// RFC 8058 one-click: the token in the link, List-Unsubscribe=One-Click in the body
+	req := httptest.NewRequest(http.MethodPost, link, strings.NewReader("List-Unsubscribe=One-Click"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if w := serve(req); w.Code != http.StatusOK {
+		t.Fatalf("one-click POST = %d: %s", w.Code, w.Body.String())
+	}
+	if store.prefs.WeeklyDigest || !store.prefs.EmailOnReview {
+		t.Errorf("expected only the digest turned off, got %+v", store.prefs)
+	}
+
+	// The confirmation form posts the token as a field and gets a page back
+	store.prefs.WeeklyDigest = true
+	req = httptest.NewRequest(http.MethodPost, "/unsubscribe", strings.NewReader("token="+url.QueryEscape(unsubscriber.Token("user-1"))))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+	w = serve(req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "unsubscribed") || store.prefs.WeeklyDigest {
+		t.Errorf("form POST = %d, digest %v: %s", w.Code, store.prefs.WeeklyDigest, w.Body.String())
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/ocr"
+	"donzhit_me_backend/internal/validation"
+	"donzhit_me_backend/internal/vehicle"
+)
+
+// OCRBatchSize caps how many images one run of the OCR job reads
+const OCRBatchSize = 16
+
+// SetTextReader enables OCR of report images by the ReadMediaText job
+func (h *ReportsHandler) SetTextReader(r ocr.Reader) {
+	h.textReader = r
+}
+
+// ReadMediaText is the media worker stage for OCR. Run periodically by the scheduler,
+// it reads the text in GCS-hosted images that haven't been read yet and stores it,
+// with plate candidates, as admin-only media metadata.
+func (h *ReportsHandler) ReadMediaText(ctx context.Context) error {
+	if h.textReader == nil || h.gcs == nil {
+		return nil
+	}
+	jobs, err := h.storage.ListMediaForOCR(ctx, OCRBatchSize)
+	if err != nil || len(jobs) == 0 {
+		return err
+	}
+
+	uris := make([]string, len(jobs))
+	for i, job := range jobs {
+		report := &models.TrafficReport{ID: job.ReportID, UserID: job.UserID}
+		uris[i] = fmt.Sprintf("gs://%s/%s", h.gcs.GetBucketName(), mediaObjectPath(report, &job.Media))
+	}
+	results, err := h.textReader.ReadText(ctx, uris)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var failed int
+	for i, job := range jobs {
+		result := &models.MediaOCR{Status: models.OCRCompleted, ProcessedAt: now}
+		if results[i].Err != nil {
+			result.Status = models.OCRFailed
+			result.Error = results[i].Err.Error()
+			failed++
+		} else {
+			result.Text = results[i].Text
+			result.PlateCandidates = vehicle.PlateCandidates(results[i].Text)
+		}
+		if err := h.storage.SetMediaOCR(ctx, job.ReportID, job.Media.ID, result); err != nil {
+			log.Printf("Failed to store OCR of media %s on report %s: %v", job.Media.ID, job.ReportID, err)
+		}
+	}
+	log.Printf("OCR read %d images (%d failed)", len(jobs), failed)
+	return nil
+}
+
+// PurgeMediaOCR handles DELETE /v1/admin/reports/:id/ocr
+// Deletes the OCR text and plate candidates of every image on a report, e.g. at the
+// submitter's request. A tombstone is kept so the images aren't read again.
+func (h *ReportsHandler) PurgeMediaOCR(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	// Deleted reports are included: purging is about the data, not the report's visibility
+	ctx := c.Request.Context()
+	report, err := h.storage.GetReport(ctx, reportID)
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+
+	now := time.Now()
+	purged := 0
+	for _, mf := range report.MediaFiles {
+		if mf.OCR == nil || mf.OCR.Status == models.OCRPurged {
+			continue
+		}
+		tombstone := &models.MediaOCR{Status: models.OCRPurged, ProcessedAt: mf.OCR.ProcessedAt, PurgedBy: user.Email, PurgedAt: &now}
+		if err := h.storage.SetMediaOCR(ctx, reportID, mf.ID, tombstone); err != nil {
+			log.Printf("Failed to purge OCR of media %s on report %s: %v", mf.ID, reportID, err)
+			apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to purge OCR results")
+			return
+		}
+		purged++
+	}
+
+	log.Printf("OCR results of %d media files on report %s purged by %s", purged, reportID, user.Email)
+	c.JSON(http.StatusOK, gin.H{
+		"reportId": reportID,
+		"purged":   purged,
+	})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/models"
+)
+
+// PersonalizedFeed handles GET /v1/reports/feed
+// Returns the public feed with reports from the signed-in user's default city, then
+// state, ahead of the rest. Anonymous users and users without a default location
+// get the global feed. ?severity= filters like the public feed.
+func (h *ReportsHandler) PersonalizedFeed(c *gin.Context) {
+	severities, err := models.ParseSeverityFilter(c.Query("severity"))
+	if err != nil {
+		apierror.RespondField(c, "severity", apierror.ReasonNotAllowed, err.Error())
+		return
+	}
+
+	reports, err := h.approvedFeed(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list approved reports: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
+	}
+	reports = severityFeed(reports, severities)
+
+	if value, ok := c.Get("user"); ok {
+		if user, ok := value.(*models.User); ok && user.DefaultState != "" {
+			reports = localFirst(reports, user.DefaultState, user.DefaultCity)
+		}
+	}
+	reports = h.withEngagement(c.Request.Context(), reports, viewerID(c))
+
+	c.JSON(http.StatusOK, models.ListReportsResponse{
+		Reports: reports,
+		Count:   len(reports),
+	})
+}
+
+// localFirst returns a copy of the feed with pinned reports first, then reports from
+// city (when set) in state, then the rest of state, then everything else. Each group
+// keeps the feed's order.
+func localFirst(reports []models.TrafficReport, state, city string) []models.TrafficReport {
+	tier := func(r *models.TrafficReport) int {
+		switch {
+		case r.Pinned:
+			return 0
+		case r.State != state:
+			return 3
+		case city != "" && strings.EqualFold(strings.TrimSpace(r.City), city):
+			return 1
+		}
+		return 2
+	}
+
+	ordered := make([]models.TrafficReport, 0, len(reports))
+	for t := 0; t <= 3; t++ {
+		for i := range reports {
+			if tier(&reports[i]) == t {
+				ordered = append(ordered, reports[i])
+			}
+		}
+	}
+	return ordered
+}
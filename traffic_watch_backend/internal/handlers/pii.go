@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/moderation"
+)
+
+// SetPIIPolicy sets what happens when submitted report text contains phone numbers,
+// emails, or names; the zero policy is off
+func (h *ReportsHandler) SetPIIPolicy(policy moderation.PIIPolicy) {
+	h.piiPolicy = policy
+}
+
+// applyPIIPolicy scans a new report's text for personal information. Unless the
+// policy is off, the kinds found are returned to the submitter as warnings; under
+// the flag policy the report is also held for moderator review.
+func (h *ReportsHandler) applyPIIPolicy(report *models.TrafficReport) {
+	if h.piiPolicy == "" || h.piiPolicy == moderation.PIIPolicyOff {
+		return
+	}
+	kinds := moderation.DetectPII(report.Title, report.Description, report.Injuries)
+	if len(kinds) == 0 {
+		return
+	}
+	report.PIIWarnings = kinds
+	if h.piiPolicy == moderation.PIIPolicyFlag {
+		report.ContentFlagged = true
+	}
+}
+
+// maskPublicPII masks personal information in reports bound for public responses
+// under the mask policy. The stored text is untouched, so owners and admins still
+// see what was submitted.
+func (h *ReportsHandler) maskPublicPII(reports []models.TrafficReport) {
+	if h.piiPolicy != moderation.PIIPolicyMask {
+		return
+	}
+	for i := range reports {
+		r := &reports[i]
+		r.Title = moderation.MaskPII(r.Title)
+		r.Description = moderation.MaskPII(r.Description)
+		r.Injuries = moderation.MaskPII(r.Injuries)
+	}
+}
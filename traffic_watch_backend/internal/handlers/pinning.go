@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// PinReport handles POST /v1/admin/reports/:id/pin
+// Keeps an approved report at the top of the public feed regardless of priority or date
+func (h *ReportsHandler) PinReport(c *gin.Context) {
+	h.setPinned(c, true)
+}
+
+// UnpinReport handles DELETE /v1/admin/reports/:id/pin
+// Returns a pinned report to its normal place in the public feed
+func (h *ReportsHandler) UnpinReport(c *gin.Context) {
+	h.setPinned(c, false)
+}
+
+func (h *ReportsHandler) setPinned(c *gin.Context, pinned bool) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.storage.GetReport(ctx, reportID)
+	if err != nil || report.Status == models.StatusDeleted {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+	// Only the public feed honours pins, so there is nothing to pin elsewhere
+	if pinned && report.Status != models.StatusReviewedPass {
+		apierror.Respond(c, http.StatusConflict, apierror.Conflict, "only approved reports can be pinned")
+		return
+	}
+
+	changed := events.Event{Type: events.ReportUnpinned, ReportID: reportID, Actor: user.Email}
+	if pinned {
+		changed.Type = events.ReportPinned
+	}
+	if err := h.changeWithEvent(ctx, changed, func(tx storage.Client) error {
+		return tx.SetReportPinned(ctx, reportID, pinned)
+	}); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+			return
+		}
+		log.Printf("Failed to update pin on report %s: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update report")
+		return
+	}
+
+	log.Printf("Report %s pinned=%v by %s", reportID, pinned, user.Email)
+
+	c.JSON(http.StatusOK, gin.H{
+		"reportId": reportID,
+		"pinned":   pinned,
+	})
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// UpdatePriorityRequest is the body of PATCH /v1/admin/reports/:id/priority.
+// Exactly one of Priority and Delta must be set.
+type UpdatePriorityRequest struct {
+	Priority *int `json:"priority"` // New absolute priority
+	Delta    *int `json:"delta"`    // Amount to raise (or, if negative, lower) the current priority by
+}
+
+// UpdatePriority handles PATCH /v1/admin/reports/:id/priority
+// Sets a report's priority, or adjusts it by a delta, and returns the new priority.
+// An absolute priority outside MinPriority..MaxPriority is rejected; a delta that
+// would leave that range stops at its edge.
+func (h *ReportsHandler) UpdatePriority(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	var req UpdatePriorityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	span := models.MaxPriority - models.MinPriority
+	var change models.PriorityChange
+	switch {
+	case (req.Priority == nil) == (req.Delta == nil):
+		apierror.RespondField(c, "priority", apierror.ReasonInvalid, "set exactly one of priority and delta")
+		return
+	case req.Priority != nil:
+		if *req.Priority < models.MinPriority || *req.Priority > models.MaxPriority {
+			apierror.RespondField(c, "priority", apierror.ReasonOutOfRange,
+				fmt.Sprintf("priority must be between %d and %d", models.MinPriority, models.MaxPriority))
+			return
+		}
+		change.Set = req.Priority
+	default:
+		if *req.Delta < -span || *req.Delta > span {
+			apierror.RespondField(c, "delta", apierror.ReasonOutOfRange,
+				fmt.Sprintf("delta must be between %d and %d", -span, span))
+			return
+		}
+		change.Delta = *req.Delta
+	}
+
+	var priority int
+	reprioritized := events.Event{Type: events.ReportReprioritized, ReportID: reportID, Actor: user.Email}
+	err := h.changeWithEvent(c.Request.Context(), reprioritized, func(tx storage.Client) error {
+		var err error
+		priority, err = tx.ChangeReportPriority(c.Request.Context(), reportID, change)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+			return
+		}
+		log.Printf("Failed to change priority of report %s: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update report")
+		return
+	}
+
+	log.Printf("Report %s priority changed to %d by %s", reportID, priority, user.Email)
+
+	c.JSON(http.StatusOK, gin.H{
+		"reportId": reportID,
+		"priority": priority,
+	})
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// DefaultPriorityDecayPercent is how much of a report's priority above the default
+// is removed on each decay run
+const DefaultPriorityDecayPercent = 10
+
+// SetPriorityDecayPercent sets the share (0-100) of boosted priority removed per decay run; zero disables decay
+func (h *ReportsHandler) SetPriorityDecayPercent(percent int) {
+	h.priorityDecayPercent = min(max(percent, 0), 100)
+}
+
+// DecayPriorities moves the priority of approved reports back toward the default so old
+// high-priority items don't dominate the public feed. Run periodically by the scheduler;
+// reports with a frozen priority are left alone.
+func (h *ReportsHandler) DecayPriorities(ctx context.Context) error {
+	if h.priorityDecayPercent == 0 {
+		return nil
+	}
+	ids, err := h.storage.DecayReportPriorities(ctx, h.priorityDecayPercent)
+	for _, id := range ids {
+		h.announce(ctx, events.Event{Type: events.ReportReprioritized, ReportID: id})
+	}
+	if len(ids) > 0 {
+		log.Printf("Decayed priority of %d reports by %d%%", len(ids), h.priorityDecayPercent)
+	}
+	return err
+}
+
+// FreezePriority handles POST /v1/admin/reports/:id/priority/freeze
+// Exempts a report from priority decay
+func (h *ReportsHandler) FreezePriority(c *gin.Context) {
+	h.setPriorityFrozen(c, true)
+}
+
+// UnfreezePriority handles DELETE /v1/admin/reports/:id/priority/freeze
+// Makes a report subject to priority decay again
+func (h *ReportsHandler) UnfreezePriority(c *gin.Context) {
+	h.setPriorityFrozen(c, false)
+}
+
+func (h *ReportsHandler) setPriorityFrozen(c *gin.Context, frozen bool) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	if err := h.storage.SetReportPriorityFrozen(c.Request.Context(), reportID, frozen); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+			return
+		}
+		log.Printf("Failed to update priority freeze on report %s: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update report")
+		return
+	}
+
+	log.Printf("Report %s priorityFrozen=%v by %s", reportID, frozen, user.Email)
+
+	c.JSON(http.StatusOK, gin.H{
+		"reportId":       reportID,
+		"priorityFrozen": frozen,
+	})
+}
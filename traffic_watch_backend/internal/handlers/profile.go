@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/geo"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// UpdateProfile handles PATCH /v1/auth/me/profile
+// Sets the default state/province and city used to personalize the feed, and the
+// display name shown on the user's comments
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req models.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	stored, err := h.storage.GetUserByID(c.Request.Context(), user.Subject)
+	if err != nil {
+		log.Printf("Failed to load user %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to load profile")
+		return
+	}
+
+	state, city := stored.DefaultState, stored.DefaultCity
+	if req.DefaultState != nil {
+		state = *req.DefaultState
+	}
+	if req.DefaultCity != nil {
+		city = strings.TrimSpace(*req.DefaultCity)
+	}
+	// Listed cities are stored by their canonical name so they match reports' cities
+	if listed, ok := geo.NormalizeCity(state, city); ok {
+		city = listed.Name
+	}
+	displayName := stored.DisplayName
+	if req.DisplayName != nil {
+		displayName = strings.TrimSpace(*req.DisplayName)
+		// A display name must not pass for another user's pseudonym or expose an email
+		if strings.HasPrefix(strings.ToLower(displayName), "contributor #") || strings.Contains(displayName, "@") {
+			apierror.RespondField(c, "displayName", apierror.ReasonNotAllowed, "displayName cannot be a contributor pseudonym or an email address")
+			return
+		}
+	}
+
+	err = h.storage.UpdateUserDefaultLocation(c.Request.Context(), user.Subject, state, city)
+	if err == nil && displayName != stored.DisplayName {
+		err = h.storage.UpdateUserDisplayName(c.Request.Context(), user.Subject, displayName)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "user not found")
+			return
+		}
+		log.Printf("Failed to update profile for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"defaultState": state,
+		"defaultCity":  city,
+		"displayName":  displayName,
+		"authorName":   models.AuthorName(user.Subject, displayName),
+	})
+}
@@ -0,0 +1,345 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/ratelimit"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// reactionTypePattern matches acceptable reaction type keys; reactions store
+// the key in a VARCHAR(50) column and clients use it in URL paths
+var reactionTypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// reactionSet is the in-memory copy of the configured reaction types, reloaded
+// from storage. It starts with the built-in defaults so reactions work before
+// the first load and when storage is unavailable.
+type reactionSet struct {
+	mu    sync.RWMutex
+	types []models.ReactionType
+	byKey map[string]models.ReactionType
+}
+
+// newReactionSet creates a set holding the built-in reaction types
+func newReactionSet() *reactionSet {
+	s := &reactionSet{}
+	s.load(models.DefaultReactionTypes())
+	return s
+}
+
+// load replaces the set. An empty list is ignored so a blank table can't turn reactions off.
+func (s *reactionSet) load(types []models.ReactionType) {
+	if len(types) == 0 {
+		return
+	}
+	byKey := make(map[string]models.ReactionType, len(types))
+	for _, t := range types {
+		byKey[t.Type] = t
+	}
+
+	s.mu.Lock()
+	s.types = types
+	s.byKey = byKey
+	s.mu.Unlock()
+}
+
+// lookup returns a reaction type, retired or not
+func (s *reactionSet) lookup(reactionType string) (models.ReactionType, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.byKey[reactionType]
+	return t, ok
+}
+
+// score returns the priority delta of a reaction type. Retired types keep their
+// score so removing an old reaction still reverses what adding it did.
+func (s *reactionSet) score(reactionType string) int {
+	t, _ := s.lookup(reactionType)
+	return t.Score
+}
+
+// priorityDelta is how much a report's priority changes when a user's reaction goes from
+// removed to added (either "" for none). counts are the report's reaction counts after
+// the change. Each type's contribution is capped by its MaxScore, so once a type has
+// reached its cap further reactions of it leave the priority alone.
+func (s *reactionSet) priorityDelta(counts []models.ReactionCount, removed, added string) int {
+	if removed == added {
+		return 0
+	}
+	after := map[string]int{}
+	for _, rc := range counts {
+		after[rc.ReactionType] = rc.Count
+	}
+
+	delta := 0
+	if removed != "" {
+		t, _ := s.lookup(removed)
+		delta += t.Contribution(after[removed]) - t.Contribution(after[removed]+1)
+	}
+	if added != "" {
+		t, _ := s.lookup(added)
+		delta += t.Contribution(after[added]) - t.Contribution(after[added]-1)
+	}
+	return delta
+}
+
+// adjustReactionPriority applies priorityDelta for a reaction change to the report's
+// priority. Call it with the transaction that saved the change so the counts include it.
+func (h *ReportsHandler) adjustReactionPriority(ctx context.Context, tx storage.Client, reportID, removed, added string) error {
+	counts, err := tx.GetReactionCounts(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	delta := h.reactions.priorityDelta(counts, removed, added)
+	if delta == 0 {
+		return nil
+	}
+	return tx.AdjustReportPriority(ctx, reportID, delta)
+}
+
+// active returns the reaction types users can currently add, in sort order
+func (s *reactionSet) active() []models.ReactionType {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	active := make([]models.ReactionType, 0, len(s.types))
+	for _, t := range s.types {
+		if t.Active() {
+			active = append(active, t)
+		}
+	}
+	return active
+}
+
+// ReloadReactionTypes refreshes the reaction set from storage so changes made on
+// other instances take effect
+func (h *ReportsHandler) ReloadReactionTypes(ctx context.Context) error {
+	types, err := h.storage.ListReactionTypes(ctx)
+	if err != nil {
+		return err
+	}
+	h.reactions.load(types)
+	return nil
+}
+
+// SetReactionRateLimit caps how many reaction changes (adds, removals and toggles)
+// each user can make; nil removes the cap
+func (h *ReportsHandler) SetReactionRateLimit(limiter *ratelimit.Limiter) {
+	h.reactionLimiter = limiter
+}
+
+// allowReactionChange counts a reaction change against the user's rate limit.
+// It writes a 429 response with Retry-After and returns false once the limit is hit.
+func (h *ReportsHandler) allowReactionChange(c *gin.Context, userID string) bool {
+	ok, retryAfter := h.reactionLimiter.Allow(userID)
+	if !ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		apierror.Respond(c, http.StatusTooManyRequests, apierror.RateLimited, "too many reaction changes; try again later")
+	}
+	return ok
+}
+
+// ToggleReaction handles PUT /v1/reports/:id/reactions/:type
+// Removes the user's reaction if it is already of this type, and otherwise adds it
+// (replacing any other type), in one atomic step (requires auth)
+func (h *ReportsHandler) ToggleReaction(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+	// Engagement on a merged duplicate applies to the canonical report
+	reportID = h.canonicalReportID(c.Request.Context(), reportID)
+	if h.blockedByReportOwner(c, reportID, user.Subject) {
+		return
+	}
+
+	// Retired types can only be toggled off by users who still hold one
+	reactionType := c.Param("type")
+	t, ok := h.reactions.lookup(reactionType)
+	if ok && !t.Active() {
+		current, _ := h.storage.GetUserReactionType(c.Request.Context(), reportID, user.Subject)
+		ok = current == reactionType
+	}
+	if !ok {
+		apierror.RespondField(c, "type", apierror.ReasonNotAllowed, "invalid reaction type")
+		return
+	}
+	if !h.allowReactionChange(c, user.Subject) {
+		return
+	}
+
+	userEmail := user.Email
+	if storedUser, err := h.storage.GetUserByID(c.Request.Context(), user.Subject); err == nil && storedUser != nil {
+		userEmail = storedUser.Email
+	}
+
+	reaction := &models.Reaction{
+		ID:           uuid.New().String(),
+		ReportID:     reportID,
+		UserID:       user.Subject,
+		UserEmail:    userEmail,
+		ReactionType: reactionType,
+		CreatedAt:    time.Now(),
+	}
+	var added bool
+	ctx := c.Request.Context()
+	err := h.storage.WithTransaction(ctx, func(tx storage.Client) error {
+		previousType, toggledOn, err := tx.ToggleReaction(ctx, reaction)
+		if err != nil {
+			return err
+		}
+		added = toggledOn
+
+		// Reverse the previous reaction's score and apply the new one, if any
+		newType := ""
+		if added {
+			newType = reactionType
+		}
+		return h.adjustReactionPriority(ctx, tx, reportID, previousType, newType)
+	})
+	if err != nil {
+		log.Printf("Failed to toggle reaction: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to toggle reaction")
+		return
+	}
+
+	message := "reaction removed"
+	if added {
+		message = "reaction added"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":      message,
+		"reactionType": reactionType,
+		"reacted":      added,
+	})
+}
+
+// publicReactionType is a reaction type as clients render it; scores stay server-side
+type publicReactionType struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// GetPublicConfig handles GET /v1/public/config
+// Returns the settings clients need to render the app, currently the active reaction types
+func (h *ReportsHandler) GetPublicConfig(c *gin.Context) {
+	active := h.reactions.active()
+	reactions := make([]publicReactionType, len(active))
+	for i, t := range active {
+		reactions[i] = publicReactionType{Type: t.Type, Label: t.Label, Emoji: t.Emoji}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reactions": reactions,
+	})
+}
+
+// ListReactionTypes handles GET /v1/admin/reactions
+// Returns every reaction type, retired ones included
+func (h *ReportsHandler) ListReactionTypes(c *gin.Context) {
+	types, err := h.storage.ListReactionTypes(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list reaction types: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list reaction types")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reactionTypes": types,
+		"count":         len(types),
+	})
+}
+
+// UpsertReactionType handles PUT /v1/admin/reactions/:type
+// Adds a reaction type or changes one; saving a retired type brings it back.
+// A changed score or cap only applies to reactions added or removed afterwards.
+func (h *ReportsHandler) UpsertReactionType(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	key := c.Param("type")
+	if !reactionTypePattern.MatchString(key) {
+		apierror.RespondField(c, "type", apierror.ReasonInvalidFormat, "reaction type must be lowercase letters, digits and underscores")
+		return
+	}
+
+	var req models.UpsertReactionTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	reactionType := &models.ReactionType{
+		Type:      key,
+		Label:     req.Label,
+		Emoji:     req.Emoji,
+		Score:     req.Score,
+		MaxScore:  req.MaxScore,
+		SortOrder: req.SortOrder,
+		UpdatedBy: user.Email,
+	}
+	if err := h.storage.UpsertReactionType(c.Request.Context(), reactionType); err != nil {
+		log.Printf("Failed to save reaction type %s: %v", key, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to save reaction type")
+		return
+	}
+
+	log.Printf("Reaction type %s set (score %d, max %d) by %s", key, reactionType.Score, reactionType.MaxScore, user.Email)
+	if err := h.ReloadReactionTypes(c.Request.Context()); err != nil {
+		log.Printf("Failed to reload reaction types: %v", err)
+	}
+
+	c.JSON(http.StatusOK, reactionType)
+}
+
+// RetireReactionType handles DELETE /v1/admin/reactions/:type
+// Stops the type being offered or added; existing reactions of the type are kept
+func (h *ReportsHandler) RetireReactionType(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	key := c.Param("type")
+	if err := h.storage.RetireReactionType(c.Request.Context(), key, user.Email); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "reaction type not found")
+			return
+		}
+		log.Printf("Failed to retire reaction type %s: %v", key, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to retire reaction type")
+		return
+	}
+
+	log.Printf("Reaction type %s retired by %s", key, user.Email)
+	if err := h.ReloadReactionTypes(c.Request.Context()); err != nil {
+		log.Printf("Failed to reload reaction types: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "reaction type retired",
+	})
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+)
+
+// reportExportFlushEvery is how many reports are buffered before flushing to the client
+const reportExportFlushEvery = 100
+
+// ExportReports handles GET /v1/admin/reports/export?format=ndjson
+// Streams every non-deleted report, oldest first, as one JSON object per line so
+// pipelines can ingest the dataset without paging. ?engagement=true adds each
+// report's reaction counts and comment count.
+func (h *ReportsHandler) ExportReports(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	if format := c.DefaultQuery("format", "ndjson"); format != "ndjson" {
+		apierror.RespondField(c, "format", apierror.ReasonNotAllowed, "format must be ndjson")
+		return
+	}
+	includeEngagement := c.Query("engagement") == "true"
+
+	h.streamReportExport(c, models.ReportExportOptions{IncludeEngagement: includeEngagement}, user.Email)
+}
+
+// ExportResearchReports handles GET /v1/research/reports/export
+// Streams the approved dataset, oldest first, as NDJSON for research token holders.
+// Reports are filtered as in the public feed and personal information is masked.
+func (h *ReportsHandler) ExportResearchReports(c *gin.Context) {
+	token := middleware.RequireResearchToken(c)
+	if token == nil {
+		return
+	}
+	opts := models.ReportExportOptions{
+		IncludeEngagement: c.Query("engagement") == "true",
+		ApprovedOnly:      true,
+	}
+	h.streamReportExport(c, opts, "research token "+token.Name)
+}
+
+// streamReportExport writes the reports selected by opts as NDJSON. requester
+// only identifies who ran the export in the log.
+func (h *ReportsHandler) streamReportExport(c *gin.Context, opts models.ReportExportOptions, requester string) {
+	filename := fmt.Sprintf("reports-%s.ndjson", time.Now().UTC().Format("20060102"))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Status(http.StatusOK)
+
+	enc := json.NewEncoder(c.Writer)
+	count := 0
+	err := h.storage.ExportReports(c.Request.Context(), opts, func(row models.ReportExportRow) error {
+		if opts.ApprovedOnly {
+			masked := []models.TrafficReport{row.Report}
+			h.maskPublicPII(masked)
+			row.Report = masked[0]
+		}
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+		count++
+		if count%reportExportFlushEvery == 0 {
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	c.Writer.Flush()
+
+	if err != nil {
+		// Headers are already sent, so the truncated body is the only signal to the client
+		log.Printf("Report export by %s failed after %d rows: %v", requester, count, err)
+		return
+	}
+	log.Printf("Report export by %s: %d reports (engagement %v)", requester, count, opts.IncludeEngagement)
+}
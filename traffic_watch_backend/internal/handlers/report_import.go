@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/importer"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+)
+
+// SetMediaBucket sets the name of the media bucket, which gs:// media in imported
+// rows must be in
+func (h *ReportsHandler) SetMediaBucket(bucket string) {
+	h.mediaBucket = bucket
+}
+
+// ImportReports handles POST /v1/admin/reports/import
+// Imports reports from the old spreadsheet workflow, sent as a multipart "file"
+// or as the request body, in CSV or JSON (taken from the file name or
+// Content-Type unless ?format=csv|json is given). Rows keep their status and
+// timestamps, belong to their userId or else the importing admin, and are
+// stored without notifying anyone. Rows imported before are skipped, so a
+// partly failed file can be fixed and sent again. ?dryRun=true only checks the
+// rows. Files over the request size limit can be imported with cmd/import.
+func (h *ReportsHandler) ImportReports(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	format := c.Query("format")
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, header, err := c.Request.FormFile("file")
+		if err != nil {
+			apierror.RespondField(c, "file", apierror.ReasonRequired, "file is required")
+			return
+		}
+		defer file.Close()
+		body = file
+		format = cmp.Or(format, importer.FormatOf(header.Filename, header.Header.Get("Content-Type")))
+	} else {
+		format = cmp.Or(format, importer.FormatOf("", c.ContentType()))
+	}
+	if format != importer.FormatCSV && format != importer.FormatJSON {
+		apierror.RespondField(c, "format", apierror.ReasonNotAllowed, "format must be csv or json")
+		return
+	}
+
+	rows, err := importer.Parse(body, format)
+	if err != nil {
+		apierror.RespondField(c, "file", apierror.ReasonInvalid, err.Error())
+		return
+	}
+
+	opts := importer.Options{UserID: user.Subject, Actor: user.Email, DryRun: c.Query("dryRun") == "true", MediaBucket: h.mediaBucket}
+	result, err := importer.Import(c.Request.Context(), h.storage, rows, opts)
+	if err != nil {
+		// The client went away; rows stored so far stay imported
+		log.Printf("Report import by %s stopped after %d rows: %v", user.Email, result.Imported, err)
+		return
+	}
+	if opts.DryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	if result.Imported > 0 {
+		h.InvalidateFeedCache()
+	}
+	writeAuditEntry(c, h.storage, user.Subject, models.AuditReportsImported, "",
+		fmt.Sprintf("format=%s imported=%d skipped=%d failed=%d", format, result.Imported, result.Skipped, len(result.Errors)))
+	log.Printf("Report import by %s: %d imported, %d skipped, %d failed", user.Email, result.Imported, result.Skipped, len(result.Errors))
+	c.JSON(http.StatusOK, result)
+}
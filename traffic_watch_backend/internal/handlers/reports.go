@@ -2,6 +2,9 @@ package handlers
 
 import (
 	"bytes"
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -12,59 +15,144 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/geo"
 	"donzhit_me_backend/internal/metadata"
 	"donzhit_me_backend/internal/middleware"
 	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/moderation"
+	"donzhit_me_backend/internal/ocr"
+	"donzhit_me_backend/internal/ratelimit"
+	"donzhit_me_backend/internal/staticmap"
 	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/transcribe"
+	"donzhit_me_backend/internal/translate"
 	"donzhit_me_backend/internal/validation"
+	"donzhit_me_backend/internal/vehicle"
 )
 
 // ReportsHandler handles report-related requests
 type ReportsHandler struct {
-	storage storage.Client
-	gcs     *storage.GCSClient
-	youtube *storage.YouTubeClient
+	storage              storage.Client
+	gcs                  storage.BlobStore
+	youtube              storage.VideoHost
+	vehicleHasher        *vehicle.Hasher
+	events               *events.Bus
+	feedCache            *feedCache
+	wordFilter           *moderation.WordFilter
+	mediaLimits          validation.MediaLimits
+	trustedMediaLimits   validation.MediaLimits
+	trust                models.TrustThresholds
+	archiveAfter         time.Duration
+	priorityDecayPercent int
+	translator           *translate.Service
+	transcriber          transcribe.Transcriber
+	textReader           ocr.Reader
+	mapRenderer          staticmap.Renderer
+	piiPolicy            moderation.PIIPolicy
+	reactions            *reactionSet
+	reactionLimiter      *ratelimit.Limiter
+	publicAppURL         string
+	publicAPIURL         string
+	mediaBucket          string
+	uploads              *uploadTracker
+	maxResubmissions     int
+	mediaURLs            MediaURLLifetimes
+	comments             *commentGuard
+	draftDiscarder       *auth.TokenSigner
 }
 
 // Engagement scoring constants
-// These values affect how reactions and comments impact report priority
+// These values affect how comments impact report priority; reaction scores
+// are configured per reaction type (see models.ReactionType)
 const (
-	ScoreThumbsUp        = 2  // +2 for thumbs up
-	ScoreThumbsDown      = -1 // -1 for thumbs down
-	ScoreAngryCar        = 3  // +3 for angry car
-	ScoreAngryPedestrian = 3  // +3 for angry pedestrian
-	ScoreAngryBicycle    = 3  // +3 for angry bicycle
-	ScoreComment         = 3  // +3 for comment
+	ScoreComment = 3 // +3 for comment
 )
 
-// getReactionScore returns the priority score delta for a reaction type
-func getReactionScore(reactionType string) int {
-	switch reactionType {
-	case models.ReactionThumbsUp:
-		return ScoreThumbsUp
-	case models.ReactionThumbsDown:
-		return ScoreThumbsDown
-	case models.ReactionAngryCar:
-		return ScoreAngryCar
-	case models.ReactionAngryPedestrian:
-		return ScoreAngryPedestrian
-	case models.ReactionAngryBicycle:
-		return ScoreAngryBicycle
-	default:
-		return 0
+// NewReportsHandler creates a new reports handler
+func NewReportsHandler(storageClient storage.Client, gcs storage.BlobStore, youtube storage.VideoHost) *ReportsHandler {
+	return &ReportsHandler{
+		storage:              storageClient,
+		gcs:                  gcs,
+		youtube:              youtube,
+		mediaLimits:          validation.DefaultMediaLimits(),
+		trustedMediaLimits:   validation.DefaultTrustedMediaLimits(),
+		trust:                models.DefaultTrustThresholds(),
+		archiveAfter:         DefaultArchiveAfter,
+		priorityDecayPercent: DefaultPriorityDecayPercent,
+		reactions:            newReactionSet(),
+		uploads:              newUploadTracker(),
+		maxResubmissions:     DefaultMaxResubmissions,
+		mediaURLs:            DefaultMediaURLLifetimes(),
+		comments:             newCommentGuard(),
 	}
 }
 
-// NewReportsHandler creates a new reports handler
-func NewReportsHandler(storageClient storage.Client, gcs *storage.GCSClient, youtube *storage.YouTubeClient) *ReportsHandler {
-	return &ReportsHandler{
-		storage: storageClient,
-		gcs:     gcs,
-		youtube: youtube,
+// SetVehicleHasher enables vehicle match keys on newly created reports
+func (h *ReportsHandler) SetVehicleHasher(hasher *vehicle.Hasher) {
+	h.vehicleHasher = hasher
+}
+
+// SetEventBus sets the bus lifecycle events are published on after moderation actions
+func (h *ReportsHandler) SetEventBus(bus *events.Bus) {
+	h.events = bus
+}
+
+// SetMediaLimits sets the per-report cap on media file count and combined size
+func (h *ReportsHandler) SetMediaLimits(limits validation.MediaLimits) {
+	h.mediaLimits = limits
+}
+
+// publish announces a persisted report change to in-process subscribers (no-op without a bus)
+func (h *ReportsHandler) publish(eventType events.Type, reportID, actor string) {
+	h.events.Publish(events.Event{Type: eventType, ReportID: reportID, Actor: actor})
+}
+
+// enqueueEvent writes e to the outbox through tx, for the relay to deliver to
+// Pub/Sub and the notifier. Written in the transaction that makes the change, the
+// event exists exactly when the change commits.
+func enqueueEvent(ctx context.Context, tx storage.Client, e events.Event) error {
+	return tx.EnqueueOutboxEvent(ctx, events.NewOutboxEvent(e))
+}
+
+// changeWithEvent runs change and enqueues e in one transaction, then publishes e
+// in-process once it has committed
+func (h *ReportsHandler) changeWithEvent(ctx context.Context, e events.Event, change func(tx storage.Client) error) error {
+	err := h.storage.WithTransaction(ctx, func(tx storage.Client) error {
+		if err := change(tx); err != nil {
+			return err
+		}
+		return enqueueEvent(ctx, tx, e)
+	})
+	if err != nil {
+		return err
+	}
+	h.events.Publish(e)
+	return nil
+}
+
+// announce enqueues and publishes e for a change that has already committed, as
+// batch jobs do for every report one storage call changed. A failed enqueue is
+// logged: the event then misses the external sinks.
+func (h *ReportsHandler) announce(ctx context.Context, e events.Event) {
+	if err := enqueueEvent(ctx, h.storage, e); err != nil {
+		log.Printf("Failed to enqueue %s event for report %s: %v", e.Type, e.ReportID, err)
 	}
+	h.events.Publish(e)
+}
+
+// vehicleHashes derives match keys for a report's vehicle details, if hashing is enabled
+func (h *ReportsHandler) vehicleHashes(d vehicle.Descriptor) []string {
+	if h.vehicleHasher == nil {
+		return nil
+	}
+	return h.vehicleHasher.Hashes(d)
 }
 
 // CreateReport handles POST /v1/reports
+// ?templateId= prefills the report from a template; ?draftId= removes the draft it was written in
 func (h *ReportsHandler) CreateReport(c *gin.Context) {
 	user := middleware.RequireUser(c)
 	if user == nil {
@@ -85,54 +173,115 @@ func (h *ReportsHandler) CreateReport(c *gin.Context) {
 
 // createReportJSON handles JSON report creation
 func (h *ReportsHandler) createReportJSON(c *gin.Context, user *models.UserInfo) {
+	template, ok := h.reportTemplate(c, user)
+	if !ok {
+		return
+	}
+
+	// Fields the client sends are decoded over the template's
 	var req models.CreateReportRequest
+	if template != nil {
+		template.Prefill(&req)
+	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Printf("Validation error for user %s: %v", user.Email, err)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": err.Error(),
-			"details": fmt.Sprintf("%v", err),
-		})
+		apierror.RespondValidation(c, err)
 		return
 	}
+	middleware.DeprecatedFields(c, req.NormalizeLegacyFields())
 
+	flagged, ok := h.applyWordFilter(c, user, &req.Title, &req.Description, &req.Injuries)
+	if !ok {
+		return
+	}
+
+	report := h.newReport(user, &req, flagged)
+	created := events.Event{Type: events.ReportCreated, ReportID: report.ID, Actor: user.Email}
+	if err := h.changeWithEvent(c.Request.Context(), created, func(tx storage.Client) error {
+		return tx.CreateReport(c.Request.Context(), report)
+	}); err != nil {
+		if errors.Is(err, storage.ErrConflict) {
+			// A retried submission with a client-generated ID gets the stored report back
+			if existing, getErr := h.storage.GetReport(c.Request.Context(), report.ID); getErr == nil && existing.UserID == user.Subject {
+				c.JSON(http.StatusOK, existing)
+				return
+			}
+			apierror.Respond(c, http.StatusConflict, apierror.Conflict, "report ID is already in use")
+			return
+		}
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create report")
+		return
+	}
+
+	h.removeSubmittedDraft(c, user)
+	c.JSON(http.StatusCreated, report)
+}
+
+// newReport builds a report from a validated, word-filtered create request.
+// A client-generated ID is kept so offline clients can retry idempotently.
+func (h *ReportsHandler) newReport(user *models.UserInfo, req *models.CreateReportRequest, flagged bool) *models.TrafficReport {
+	id := strings.ToLower(req.ID)
+	if id == "" {
+		id = uuid.New().String()
+	}
 	report := &models.TrafficReport{
-		ID:                  uuid.New().String(),
+		ID:                  id,
 		UserID:              user.Subject,
 		Title:               req.Title,
 		Description:         req.Description,
-		DateTime:            req.DateTime,
 		RoadUsages:          req.RoadUsages,
 		EventTypes:          req.EventTypes,
 		State:               req.State,
 		City:                req.City,
 		Injuries:            req.Injuries,
+		Severity:            cmp.Or(req.Severity, models.SeverityFromInjuries(req.Injuries)),
 		RetainMediaMetadata: req.RetainMediaMetadata,
 		MediaFiles:          []models.MediaFile{},
 		Status:              models.StatusSubmitted,
-	}
-
-	if err := h.storage.CreateReport(c.Request.Context(), report); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "create_failed",
-			"message": "failed to create report",
-		})
-		return
-	}
-
-	c.JSON(http.StatusCreated, report)
+		Latitude:            req.Latitude,
+		Longitude:           req.Longitude,
+		ContentFlagged:      flagged,
+		VehicleHashes: h.vehicleHashes(vehicle.Descriptor{
+			State:        req.State,
+			LicensePlate: req.LicensePlate,
+			Color:        req.VehicleColor,
+			Make:         req.VehicleMake,
+			Model:        req.VehicleModel,
+		}),
+	}
+	report.SetDateTime(req.DateTime, req.TimeZone)
+	normalizeCity(report)
+	h.applyPIIPolicy(report)
+	return report
 }
 
 // createReportMultipart handles multipart form data report creation
 func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.UserInfo) {
+	// Track progress before the form is parsed, as parsing reads the whole body
+	progress, ok := h.trackUpload(c, user)
+	if !ok {
+		return
+	}
+	var reportID string
+	defer func() { progress.finish(c, reportID) }()
+
 	// Parse form values
 	title := c.PostForm("title")
 	description := c.PostForm("description")
 	dateTimeStr := c.PostForm("dateTime")
+	timeZone := c.PostForm("timeZone")
 	state := c.PostForm("state")
 	city := c.PostForm("city")
 	injuries := c.PostForm("injuries")
+	severity := strings.TrimSpace(c.PostForm("severity"))
 	retainMediaMetadataStr := c.PostForm("retainMediaMetadata")
+	vehicleDescriptor := vehicle.Descriptor{
+		State:        state,
+		LicensePlate: c.PostForm("licensePlate"),
+		Color:        c.PostForm("vehicleColor"),
+		Make:         c.PostForm("vehicleMake"),
+		Model:        c.PostForm("vehicleModel"),
+	}
 
 	// Parse retainMediaMetadata - defaults to true if not provided
 	retainMediaMetadata := true
@@ -167,31 +316,66 @@ func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.User
 		eventTypes = c.PostFormArray("eventTypes[]")
 	}
 
+	// Old clients send a single roadUsage and eventType
+	legacy := models.CreateReportRequest{
+		RoadUsages: roadUsages,
+		EventTypes: eventTypes,
+		RoadUsage:  strings.TrimSpace(c.PostForm("roadUsage")),
+		EventType:  strings.TrimSpace(c.PostForm("eventType")),
+	}
+	middleware.DeprecatedFields(c, legacy.NormalizeLegacyFields())
+	roadUsages, eventTypes = legacy.RoadUsages, legacy.EventTypes
+
+	// Blank fields fall back to the template's
+	template, ok := h.reportTemplate(c, user)
+	if !ok {
+		return
+	}
+	if template != nil {
+		title = cmp.Or(title, template.Title)
+		description = cmp.Or(description, template.Description)
+		state = cmp.Or(state, template.State)
+		city = cmp.Or(city, template.City)
+		if len(roadUsages) == 0 {
+			roadUsages = template.RoadUsages
+		}
+		if len(eventTypes) == 0 {
+			eventTypes = template.EventTypes
+		}
+	}
+
 	log.Printf("Multipart form received - title: %s, roadUsages: %v, eventTypes: %v, state: %s, dateTime: %s",
 		title, roadUsages, eventTypes, state, dateTimeStr)
 
+	// Times without an offset are read in the submitter's zone, or UTC
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		apierror.RespondField(c, "timeZone", apierror.ReasonInvalidFormat, fmt.Sprintf("invalid timeZone: %s", timeZone))
+		return
+	}
+
 	// Parse datetime - try multiple formats
 	var dateTime time.Time
-	var err error
 	dateFormats := []string{
 		time.RFC3339,
 		time.RFC3339Nano,
-		"2006-01-02T15:04:05.999999999",  // ISO8601 without timezone
-		"2006-01-02T15:04:05.999999",     // ISO8601 with microseconds
-		"2006-01-02T15:04:05",            // ISO8601 basic
+		"2006-01-02T15:04:05.999999999", // ISO8601 without timezone
+		"2006-01-02T15:04:05.999999",    // ISO8601 with microseconds
+		"2006-01-02T15:04:05",           // ISO8601 basic
 	}
 	for _, format := range dateFormats {
-		dateTime, err = time.Parse(format, dateTimeStr)
+		dateTime, err = time.ParseInLocation(format, dateTimeStr, loc)
 		if err == nil {
 			break
 		}
 	}
 	if err != nil {
 		log.Printf("DateTime parse error for user %s: %v (received: %s)", user.Email, err, dateTimeStr)
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": fmt.Sprintf("invalid dateTime format: %s", dateTimeStr),
-		})
+		apierror.RespondField(c, "dateTime", apierror.ReasonInvalidFormat, fmt.Sprintf("invalid dateTime format: %s", dateTimeStr))
+		return
+	}
+	if validation.IsFuture(dateTime) {
+		apierror.RespondField(c, "dateTime", apierror.ReasonOutOfRange, "dateTime cannot be in the future")
 		return
 	}
 
@@ -199,39 +383,63 @@ func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.User
 	if title == "" || description == "" || len(roadUsages) == 0 || len(eventTypes) == 0 || state == "" {
 		log.Printf("Missing required fields for user %s - title:%v desc:%v roadUsages:%v eventTypes:%v state:%v",
 			user.Email, title != "", description != "", len(roadUsages) > 0, len(eventTypes) > 0, state != "")
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "missing required fields",
-		})
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "missing required fields")
 		return
 	}
 
 	// Validate field lengths
 	if len(title) > 200 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "title exceeds maximum length of 200 characters",
-		})
+		apierror.RespondField(c, "title", apierror.ReasonTooLong, "title exceeds maximum length of 200 characters")
 		return
 	}
 	if len(description) > 5000 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "description exceeds maximum length of 5000 characters",
-		})
+		apierror.RespondField(c, "description", apierror.ReasonTooLong, "description exceeds maximum length of 5000 characters")
+		return
+	}
+	if len(vehicleDescriptor.LicensePlate) > 15 || len(vehicleDescriptor.Color) > 50 ||
+		len(vehicleDescriptor.Make) > 50 || len(vehicleDescriptor.Model) > 50 {
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "vehicle details exceed maximum length")
+		return
+	}
+	if severity != "" && !models.ValidSeverity(severity) {
+		apierror.RespondField(c, "severity", apierror.ReasonNotAllowed, models.ErrInvalidSeverity.Error())
+		return
+	}
+	latitude, longitude, err := geo.ParseCoordinates(c.PostForm("latitude"), c.PostForm("longitude"))
+	if err != nil {
+		apierror.RespondField(c, "latitude", apierror.ReasonInvalidFormat, err.Error())
+		return
+	}
+	if latitude == nil && template != nil {
+		latitude, longitude = template.Latitude, template.Longitude
+	}
+
+	// Filter text before any media is uploaded so rejected submissions leave nothing behind
+	contentFlagged, ok := h.applyWordFilter(c, user, &title, &description, &injuries)
+	if !ok {
 		return
 	}
 
-	reportID := uuid.New().String()
+	reportID = uuid.New().String()
 
 	// Handle file uploads
 	form, err := c.MultipartForm()
 	var mediaFiles []models.MediaFile
+	var failures []models.MediaFailure // Recorded in the dead-letter queue once the report exists
 
 	log.Printf("Processing file uploads for user %s", user.Email)
 	if err == nil && form != nil && form.File != nil {
 		files := form.File["files"]
 		log.Printf("Found %d files to upload", len(files))
+		progress.processing(len(files))
+		sizes := make([]int64, len(files))
+		for i, fileHeader := range files {
+			sizes[i] = fileHeader.Size
+		}
+		if valid, errMsg := h.mediaLimitsFor(c.Request.Context(), user.Subject).Check(sizes); !valid {
+			apierror.RespondField(c, "files", apierror.ReasonOutOfRange, errMsg)
+			return
+		}
 		for i, fileHeader := range files {
 			log.Printf("Processing file %d: %s (size: %d, content-type: %s)",
 				i, fileHeader.Filename, fileHeader.Size, fileHeader.Header.Get("Content-Type"))
@@ -239,20 +447,14 @@ func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.User
 			valid, errMsg := validation.ValidateFile(fileHeader)
 			if !valid {
 				log.Printf("File validation failed for %s: %s", fileHeader.Filename, errMsg)
-				c.JSON(http.StatusBadRequest, gin.H{
-					"error":   "validation_error",
-					"message": errMsg,
-				})
+				apierror.RespondField(c, "files", apierror.ReasonInvalid, errMsg)
 				return
 			}
 
 			// Open file
 			file, err := fileHeader.Open()
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error":   "upload_failed",
-					"message": "failed to process uploaded file",
-				})
+				apierror.Respond(c, http.StatusInternalServerError, apierror.UploadFailed, "failed to process uploaded file")
 				return
 			}
 
@@ -268,14 +470,16 @@ func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.User
 			var buf bytes.Buffer
 			if _, err := io.Copy(&buf, file); err != nil {
 				file.Close()
-				c.JSON(http.StatusInternalServerError, gin.H{
-					"error":   "upload_failed",
-					"message": "failed to read uploaded file",
-				})
+				apierror.Respond(c, http.StatusInternalServerError, apierror.UploadFailed, "failed to read uploaded file")
 				return
 			}
 			file.Close()
 			fileData := buf.Bytes()
+			if !validation.ContentMatches(contentType, fileData) {
+				log.Printf("Content of %s does not match %s", fileHeader.Filename, contentType)
+				apierror.RespondField(c, "files", apierror.ReasonInvalid, fmt.Sprintf("%s: file content does not match its type", safeFileName))
+				return
+			}
 
 			// Extract metadata from file
 			var fileMetadata map[string]interface{}
@@ -300,19 +504,23 @@ func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.User
 			if storage.IsVideoContentType(contentType) && h.youtube != nil {
 				log.Printf("Uploading video %s to YouTube", fileHeader.Filename)
 
-				// Create video title and description
-				videoTitle := fmt.Sprintf("%s - %s", title, safeFileName)
-				videoDesc := fmt.Sprintf("Traffic incident report: %s\n\nUploaded via DonzHit.me", description)
-
-				result, err := h.youtube.UploadVideo(c.Request.Context(), videoTitle, videoDesc, bytes.NewReader(fileData), contentType)
+				videoTitle, videoDesc := youtubeVideoText(title, description, safeFileName)
+				result, ytErr := h.youtube.UploadVideo(c.Request.Context(), videoTitle, videoDesc, bytes.NewReader(fileData), contentType)
 
-				if err != nil {
-					log.Printf("YouTube upload failed for %s: %v, falling back to GCS", fileHeader.Filename, err)
-					// Fall back to GCS on YouTube failure
+				if ytErr != nil {
+					log.Printf("YouTube upload failed for %s: %v, falling back to GCS", fileHeader.Filename, ytErr)
+					// Keep the video in GCS and queue the YouTube upload for an admin retry
 					mediaFile, err = h.uploadToGCS(c, user, reportID, fileID, contentType, safeFileName, fileHeader.Size, bytes.NewReader(fileData))
 					if err != nil {
 						return // Error response already sent
 					}
+					failures = append(failures, models.MediaFailure{
+						Job:      models.MediaJobYouTubeUpload,
+						ReportID: reportID,
+						MediaID:  fileID,
+						FileName: safeFileName,
+						Error:    ytErr.Error(),
+					})
 				} else {
 					log.Printf("Video uploaded to YouTube: %s", result.URL)
 					mediaFile = models.MediaFile{
@@ -322,6 +530,7 @@ func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.User
 						Size:        fileHeader.Size,
 						URL:         result.URL,
 						UploadedAt:  time.Now(),
+						State:       models.MediaReady,
 					}
 				}
 			} else {
@@ -353,6 +562,7 @@ func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.User
 			}
 
 			mediaFiles = append(mediaFiles, mediaFile)
+			progress.fileDone()
 		}
 	}
 
@@ -361,28 +571,39 @@ func (h *ReportsHandler) createReportMultipart(c *gin.Context, user *models.User
 		UserID:              user.Subject,
 		Title:               title,
 		Description:         description,
-		DateTime:            dateTime,
 		RoadUsages:          roadUsages,
 		EventTypes:          eventTypes,
 		State:               state,
 		City:                city,
 		Injuries:            injuries,
+		Severity:            cmp.Or(severity, models.SeverityFromInjuries(injuries)),
 		RetainMediaMetadata: retainMediaMetadata,
 		MediaFiles:          mediaFiles,
 		Status:              models.StatusSubmitted,
+		VehicleHashes:       h.vehicleHashes(vehicleDescriptor),
+		Latitude:            latitude,
+		Longitude:           longitude,
+		ContentFlagged:      contentFlagged,
 	}
+	report.SetDateTime(dateTime, timeZone)
+	normalizeCity(report)
+	h.applyPIIPolicy(report)
 
 	log.Printf("Creating report %s in storage for user %s", reportID, user.Email)
-	if err := h.storage.CreateReport(c.Request.Context(), report); err != nil {
+	created := events.Event{Type: events.ReportCreated, ReportID: reportID, Actor: user.Email}
+	if err := h.changeWithEvent(c.Request.Context(), created, func(tx storage.Client) error {
+		return tx.CreateReport(c.Request.Context(), report)
+	}); err != nil {
 		log.Printf("Storage create failed for report %s: %v", reportID, err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "create_failed",
-			"message": "failed to create report",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create report")
 		return
 	}
 
 	log.Printf("Report %s created successfully", reportID)
+	for _, failure := range failures {
+		h.recordMediaFailure(c.Request.Context(), failure)
+	}
+	h.removeSubmittedDraft(c, user)
 	c.JSON(http.StatusCreated, report)
 }
 
@@ -392,10 +613,7 @@ func (h *ReportsHandler) uploadToGCS(c *gin.Context, user *models.UserInfo, repo
 
 	reader, ok := file.(interface{ Read([]byte) (int, error) })
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "upload_failed",
-			"message": "failed to read file",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UploadFailed, "failed to read file")
 		return models.MediaFile{}, fmt.Errorf("invalid file reader")
 	}
 
@@ -409,28 +627,35 @@ func (h *ReportsHandler) uploadToGCS(c *gin.Context, user *models.UserInfo, repo
 	)
 	if err != nil {
 		log.Printf("GCS upload failed for %s: %v", safeFileName, err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "upload_failed",
-			"message": "failed to upload file to storage",
+		h.recordMediaFailure(c.Request.Context(), models.MediaFailure{
+			Job:      models.MediaJobGCSUpload,
+			ReportID: reportID,
+			MediaID:  fileID,
+			FileName: safeFileName,
+			Error:    err.Error(),
 		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UploadFailed, "failed to upload file to storage")
 		return models.MediaFile{}, err
 	}
 	log.Printf("File uploaded successfully to %s", objectPath)
 
-	// Generate signed URL
-	signedURL, err := h.gcs.GetSignedURL(c.Request.Context(), objectPath, 0)
-	if err != nil {
-		signedURL = "" // URL will be generated on demand
-	}
-
-	return models.MediaFile{
+	mediaFile := models.MediaFile{
 		ID:          fileID,
 		FileName:    safeFileName,
 		ContentType: contentType,
 		Size:        size,
-		URL:         signedURL,
 		UploadedAt:  time.Now(),
-	}, nil
+		State:       models.MediaReady,
+	}
+	// Without a signed URL the URL is left empty and generated on demand
+	h.signMediaURL(c.Request.Context(), objectPath, &mediaFile, h.mediaURLs.Owner)
+	return mediaFile, nil
+}
+
+// youtubeVideoText returns the title and description of a report video uploaded to YouTube
+func youtubeVideoText(title, description, fileName string) (string, string) {
+	return fmt.Sprintf("%s - %s", title, fileName),
+		fmt.Sprintf("Traffic incident report: %s\n\nUploaded via DonzHit.me", description)
 }
 
 // isYouTubeURL checks if a URL is a YouTube URL
@@ -438,45 +663,81 @@ func isYouTubeURL(url string) bool {
 	return strings.Contains(url, "youtube.com") || strings.Contains(url, "youtu.be")
 }
 
-// ListReports handles GET /v1/reports
-func (h *ReportsHandler) ListReports(c *gin.Context) {
-	user := middleware.RequireUser(c)
-	if user == nil {
+// mediaObjectPath returns the GCS object path for a report's media file.
+// Media moved between reports (e.g. by a merge) keeps its original path in StoragePath.
+func mediaObjectPath(report *models.TrafficReport, mf *models.MediaFile) string {
+	if mf.StoragePath != "" {
+		return mf.StoragePath
+	}
+	return fmt.Sprintf("users/%s/reports/%s/%s", report.UserID, report.ID, mf.ID)
+}
+
+// refreshReportMediaURLs replaces stored GCS URLs of ready media with fresh signed URLs valid
+// for lifetime, one of h.mediaURLs (YouTube URLs are left as-is), and signs the static map
+func (h *ReportsHandler) refreshReportMediaURLs(ctx context.Context, report *models.TrafficReport, lifetime time.Duration) {
+	if h.gcs == nil {
 		return
 	}
+	for i := range report.MediaFiles {
+		// Media that isn't ready may not exist yet or hasn't been checked, so it is never served
+		if isYouTubeURL(report.MediaFiles[i].URL) || report.MediaFiles[i].EffectiveState() != models.MediaReady {
+			continue
+		}
+		h.signMediaURL(ctx, mediaObjectPath(report, &report.MediaFiles[i]), &report.MediaFiles[i], lifetime)
+	}
+	if report.MapImagePath != "" {
+		if signedURL, err := h.gcs.GetSignedURL(ctx, report.MapImagePath, lifetime); err == nil {
+			report.MapImageURL = signedURL
+		}
+	}
+}
 
-	reports, err := h.storage.ListReportsByUser(c.Request.Context(), user.Subject)
+// signMediaURL points mf at a fresh signed URL for objectPath and records when it
+// expires, so clients can re-fetch before it does; mf is left unchanged if signing fails
+func (h *ReportsHandler) signMediaURL(ctx context.Context, objectPath string, mf *models.MediaFile, lifetime time.Duration) {
+	// Taken before signing so the hint never outlives the URL
+	expiresAt := time.Now().Add(lifetime)
+	signedURL, err := h.gcs.GetSignedURL(ctx, objectPath, lifetime)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "fetch_failed",
-			"message": "failed to fetch reports",
-		})
 		return
 	}
+	mf.URL = signedURL
+	mf.URLExpiresAt = &expiresAt
+}
 
-	if reports == nil {
-		reports = []models.TrafficReport{}
+// refreshMediaURLs refreshes signed URLs for every report in the list
+func (h *ReportsHandler) refreshMediaURLs(ctx context.Context, reports []models.TrafficReport, lifetime time.Duration) {
+	for i := range reports {
+		h.refreshReportMediaURLs(ctx, &reports[i], lifetime)
 	}
+}
 
-	// Refresh signed URLs for GCS media files (skip YouTube URLs)
-	for i := range reports {
-		for j := range reports[i].MediaFiles {
-			// Skip YouTube URLs - they don't need signed URLs
-			if isYouTubeURL(reports[i].MediaFiles[j].URL) {
-				continue
-			}
+// ListReports handles GET /v1/reports
+// ?status=submitted|reviewed_pass|reviewed_fail lists only reports in that status.
+// ?sort=createdAt|updatedAt|priority|engagement&order=asc|desc changes the order (default newest first).
+// Each report carries its engagement summary.
+func (h *ReportsHandler) ListReports(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+	status, ok := ownReportStatus(c)
+	if !ok {
+		apierror.RespondField(c, "status", apierror.ReasonNotAllowed, "status must be one of: submitted, reviewed_pass, reviewed_fail")
+		return
+	}
+	sort, param, err := reportSort(c, models.SortCreatedAt)
+	if err != nil {
+		apierror.RespondField(c, param, apierror.ReasonNotAllowed, err.Error())
+		return
+	}
 
-			objectPath := fmt.Sprintf("users/%s/reports/%s/%s",
-				user.Subject,
-				reports[i].ID,
-				reports[i].MediaFiles[j].ID,
-			)
-			signedURL, err := h.gcs.GetSignedURL(c.Request.Context(), objectPath, 0)
-			if err == nil {
-				reports[i].MediaFiles[j].URL = signedURL
-			}
-		}
+	reports, err := h.ownReports(c.Request.Context(), user.Subject, status, sort)
+	if err != nil {
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
 	}
+	reports = h.withEngagement(c.Request.Context(), reports, user.Subject)
 
 	c.JSON(http.StatusOK, models.ListReportsResponse{
 		Reports: reports,
@@ -484,6 +745,29 @@ func (h *ReportsHandler) ListReports(c *gin.Context) {
 	})
 }
 
+// CountReports handles GET /v1/reports/count
+// Returns how many reports ListReports would return, honouring the same ?status= filter
+func (h *ReportsHandler) CountReports(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+	status, ok := ownReportStatus(c)
+	if !ok {
+		apierror.RespondField(c, "status", apierror.ReasonNotAllowed, "status must be one of: submitted, reviewed_pass, reviewed_fail")
+		return
+	}
+
+	count, err := h.storage.CountUserReports(c.Request.Context(), user.Subject, status)
+	if err != nil {
+		log.Printf("Failed to count reports for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to count reports")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CountReportsResponse{Count: count})
+}
+
 // GetReport handles GET /v1/reports/:id
 func (h *ReportsHandler) GetReport(c *gin.Context) {
 	user := middleware.RequireUser(c)
@@ -493,39 +777,18 @@ func (h *ReportsHandler) GetReport(c *gin.Context) {
 
 	reportID := c.Param("id")
 	if !validation.ValidateUUID(reportID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid report ID format",
-		})
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
 		return
 	}
 
 	report, err := h.storage.GetReportByIDAndUser(c.Request.Context(), reportID, user.Subject)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "not_found",
-			"message": "report not found",
-		})
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
 		return
 	}
 
 	// Refresh signed URLs for GCS media files (skip YouTube URLs)
-	for i := range report.MediaFiles {
-		// Skip YouTube URLs - they don't need signed URLs
-		if isYouTubeURL(report.MediaFiles[i].URL) {
-			continue
-		}
-
-		objectPath := fmt.Sprintf("users/%s/reports/%s/%s",
-			user.Subject,
-			report.ID,
-			report.MediaFiles[i].ID,
-		)
-		signedURL, err := h.gcs.GetSignedURL(c.Request.Context(), objectPath, 0)
-		if err == nil {
-			report.MediaFiles[i].URL = signedURL
-		}
-	}
+	h.refreshReportMediaURLs(c.Request.Context(), report, h.mediaURLs.Owner)
 
 	c.JSON(http.StatusOK, report)
 }
@@ -539,19 +802,16 @@ func (h *ReportsHandler) DeleteReport(c *gin.Context) {
 
 	reportID := c.Param("id")
 	if !validation.ValidateUUID(reportID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid report ID format",
-		})
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
 		return
 	}
 
 	// Verify ownership and delete
-	if err := h.storage.DeleteReport(c.Request.Context(), reportID, user.Subject); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "not_found",
-			"message": "report not found",
-		})
+	deleted := events.Event{Type: events.ReportDeleted, ReportID: reportID, Actor: user.Email}
+	if err := h.changeWithEvent(c.Request.Context(), deleted, func(tx storage.Client) error {
+		return tx.DeleteReport(c.Request.Context(), reportID, user.Subject)
+	}); err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
 		return
 	}
 
@@ -568,35 +828,37 @@ func (h *ReportsHandler) DeleteReport(c *gin.Context) {
 // ============================================================================
 
 // ListApprovedReports handles GET /v1/public/reports
-// Returns all approved reports for the public feed (no auth required)
+// Returns all approved reports for the public feed (no auth required).
+// ?sort=createdAt|updatedAt|priority|engagement&order=asc|desc changes the order (default
+// priority, highest first); pinned reports stay first. "hot" still means engagement.
+// ?lang=es attaches machine translations of titles and descriptions.
+// ?severity=minor_injury,major_injury keeps only reports of those severities.
+// Each report carries its engagement summary: reaction counts, comment count and newest comment.
 func (h *ReportsHandler) ListApprovedReports(c *gin.Context) {
-	reports, err := h.storage.ListApprovedReports(c.Request.Context())
+	sort, param, err := reportSort(c, models.SortPriority)
 	if err != nil {
-		log.Printf("Failed to list approved reports: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "fetch_failed",
-			"message": "failed to fetch reports",
-		})
+		apierror.RespondField(c, param, apierror.ReasonNotAllowed, err.Error())
+		return
+	}
+	severities, err := models.ParseSeverityFilter(c.Query("severity"))
+	if err != nil {
+		apierror.RespondField(c, "severity", apierror.ReasonNotAllowed, err.Error())
+		return
+	}
+	lang := c.Query("lang")
+	if !validFeedLanguage(lang) {
+		apierror.RespondField(c, "lang", apierror.ReasonInvalidFormat, "lang must be a language code such as es or zh-TW")
 		return
 	}
 
-	// Refresh signed URLs for GCS media files (skip YouTube URLs)
-	for i := range reports {
-		for j := range reports[i].MediaFiles {
-			if isYouTubeURL(reports[i].MediaFiles[j].URL) {
-				continue
-			}
-			objectPath := fmt.Sprintf("users/%s/reports/%s/%s",
-				reports[i].UserID,
-				reports[i].ID,
-				reports[i].MediaFiles[j].ID,
-			)
-			signedURL, err := h.gcs.GetSignedURL(c.Request.Context(), objectPath, 0)
-			if err == nil {
-				reports[i].MediaFiles[j].URL = signedURL
-			}
-		}
+	reports, err := h.approvedFeed(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list approved reports: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
 	}
+	reports = h.translatedFeed(c.Request.Context(), sortedFeed(severityFeed(reports, severities), sort), lang)
+	reports = h.withEngagement(c.Request.Context(), reports, viewerID(c))
 
 	c.JSON(http.StatusOK, models.ListReportsResponse{
 		Reports: reports,
@@ -609,76 +871,114 @@ func (h *ReportsHandler) ListApprovedReports(c *gin.Context) {
 // ============================================================================
 
 // ListAllReportsAdmin handles GET /v1/admin/reports
-// Returns all non-deleted reports for admin dashboard
+// Returns a page of non-deleted reports for admin dashboard, newest first unless ?sort= and ?order= say otherwise.
+// ?limit= (default 20, at most 100) and ?offset= select the page; total counts every report.
 func (h *ReportsHandler) ListAllReportsAdmin(c *gin.Context) {
 	user := middleware.RequireUser(c)
 	if user == nil {
 		return
 	}
+	sort, param, err := reportSort(c, models.SortCreatedAt)
+	if err != nil {
+		apierror.RespondField(c, param, apierror.ReasonNotAllowed, err.Error())
+		return
+	}
+	limit, offset, param, err := offsetPage(c)
+	if err != nil {
+		apierror.RespondField(c, param, apierror.ReasonOutOfRange, err.Error())
+		return
+	}
 
-	reports, err := h.storage.ListAllReports(c.Request.Context())
+	ctx := c.Request.Context()
+	total, err := h.storage.CountAllReports(ctx, includeShadowBanned(c))
+	if err != nil {
+		log.Printf("Failed to count all reports (admin): %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
+	}
+	reports, err := h.storage.ListAllReports(ctx, includeShadowBanned(c), sort, limit, offset)
 	if err != nil {
 		log.Printf("Failed to list all reports (admin): %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "fetch_failed",
-			"message": "failed to fetch reports",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
 		return
 	}
 
-	c.JSON(http.StatusOK, models.ListReportsResponse{
+	c.JSON(http.StatusOK, models.ReportPageResponse{
 		Reports: reports,
 		Count:   len(reports),
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
 	})
 }
 
 // ListReportsForReview handles GET /v1/admin/reports/review
-// Returns reports awaiting admin review (status = "submitted")
+// Returns a page of reports awaiting admin review (status = "submitted"), paged like ListAllReportsAdmin
 func (h *ReportsHandler) ListReportsForReview(c *gin.Context) {
 	user := middleware.RequireUser(c)
 	if user == nil {
 		return
 	}
+	limit, offset, param, err := offsetPage(c)
+	if err != nil {
+		apierror.RespondField(c, param, apierror.ReasonOutOfRange, err.Error())
+		return
+	}
 
-	reports, err := h.storage.ListReportsAwaitingReview(c.Request.Context())
+	ctx := c.Request.Context()
+	total, err := h.storage.CountReportsAwaitingReview(ctx, includeShadowBanned(c))
+	if err != nil {
+		log.Printf("Failed to count reports for review: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
+	}
+	reports, err := h.storage.ListReportsAwaitingReview(ctx, includeShadowBanned(c), limit, offset)
 	if err != nil {
 		log.Printf("Failed to list reports for review: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "fetch_failed",
-			"message": "failed to fetch reports",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
 		return
 	}
 
-	// Refresh signed URLs for GCS media files
+	// Refresh signed URLs for GCS media files (skip YouTube URLs)
+	h.refreshMediaURLs(c.Request.Context(), reports, h.mediaURLs.Admin)
+
 	for i := range reports {
-		for j := range reports[i].MediaFiles {
-			if isYouTubeURL(reports[i].MediaFiles[j].URL) {
-				continue
-			}
-			objectPath := fmt.Sprintf("users/%s/reports/%s/%s",
-				reports[i].UserID,
-				reports[i].ID,
-				reports[i].MediaFiles[j].ID,
-			)
-			signedURL, err := h.gcs.GetSignedURL(c.Request.Context(), objectPath, 0)
-			if err == nil {
-				reports[i].MediaFiles[j].URL = signedURL
-			}
-		}
+		reports[i].ReviewHints = reviewHints(&reports[i])
 	}
 
-	c.JSON(http.StatusOK, models.ListReportsResponse{
+	c.JSON(http.StatusOK, models.ReportPageResponse{
 		Reports: reports,
 		Count:   len(reports),
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
 	})
 }
 
+// CountReportsForReview handles GET /v1/admin/reports/review/count
+// Returns the size of the review queue, for the admin badge counter
+func (h *ReportsHandler) CountReportsForReview(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	count, err := h.storage.CountReportsAwaitingReview(c.Request.Context(), includeShadowBanned(c))
+	if err != nil {
+		log.Printf("Failed to count reports for review: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to count reports")
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CountReportsResponse{Count: count})
+}
+
 // ReviewReportRequest represents the request body for reviewing a report
 type ReviewReportRequest struct {
-	Status   string `json:"status" binding:"required,oneof=reviewed_pass reviewed_fail"`
-	Reason   string `json:"reason"`
-	Priority *int   `json:"priority"` // 1-5, only used when approving (1=highest priority)
+	Status    string     `json:"status" binding:"required,oneof=reviewed_pass reviewed_fail"`
+	Reason    string     `json:"reason"`
+	Priority  *int       `json:"priority"`  // 1-5, only used when approving (1=highest priority)
+	PublishAt *time.Time `json:"publishAt"` // Only used when approving; keeps the report out of the public feed until then
 }
 
 // ReviewReport handles POST /v1/admin/reports/:id/review
@@ -691,61 +991,83 @@ func (h *ReportsHandler) ReviewReport(c *gin.Context) {
 
 	reportID := c.Param("id")
 	if !validation.ValidateUUID(reportID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid report ID format",
-		})
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
 		return
 	}
 
 	var req ReviewReportRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": err.Error(),
-		})
+		apierror.RespondValidation(c, err)
 		return
 	}
 
 	// Validate that rejected reports have a reason
 	if req.Status == models.StatusReviewedFail && req.Reason == "" {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "reason is required when rejecting a report",
-		})
+		apierror.RespondField(c, "reason", apierror.ReasonRequired, "reason is required when rejecting a report")
 		return
 	}
-
-	// Use UpdateReportStatusWithPriority when approving with priority
-	var err error
-	if req.Status == models.StatusReviewedPass && req.Priority != nil {
-		err = h.storage.UpdateReportStatusWithPriority(c.Request.Context(), reportID, req.Status, req.Reason, req.Priority, user.Email)
-	} else {
-		err = h.storage.UpdateReportStatus(c.Request.Context(), reportID, req.Status, req.Reason, user.Email)
+	if req.Status != models.StatusReviewedPass && req.PublishAt != nil {
+		apierror.RespondField(c, "publishAt", apierror.ReasonNotAllowed, "publishAt can only be set when approving a report")
+		return
 	}
 
+	// The publish time, the review and its event are saved together, so a review that
+	// fails (e.g. on an invalid transition) doesn't leave a changed embargo behind, and
+	// one that succeeds always reaches the owner
+	ctx := c.Request.Context()
+	reviewed := events.Event{Type: events.ReportRejected, ReportID: reportID, Actor: user.Email}
+	if req.Status == models.StatusReviewedPass {
+		reviewed.Type = events.ReportApproved
+	}
+	err := h.changeWithEvent(ctx, reviewed, func(tx storage.Client) error {
+		// Set the publish time before approving so an embargoed report never
+		// shows up in the feed; approving without one clears any earlier embargo
+		if req.Status == models.StatusReviewedPass {
+			if err := tx.SetReportPublishAt(ctx, reportID, req.PublishAt); err != nil {
+				return err
+			}
+		}
+
+		// Use UpdateReportStatusWithPriority when approving with priority
+		var err error
+		if req.Status == models.StatusReviewedPass && req.Priority != nil {
+			err = tx.UpdateReportStatusWithPriority(ctx, reportID, req.Status, req.Reason, req.Priority, user.Email)
+		} else {
+			err = tx.UpdateReportStatus(ctx, reportID, req.Status, req.Reason, user.Email)
+		}
+		if err != nil {
+			return err
+		}
+		return tx.CreateReportReview(ctx, &models.ReportReview{
+			ID:       uuid.New().String(),
+			ReportID: reportID,
+			Status:   req.Status,
+			Reason:   req.Reason,
+			Actor:    user.Email,
+		})
+	})
+
 	if err != nil {
-		if err.Error() == "report not found" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "not_found",
-				"message": "report not found",
-			})
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidStatusTransition) {
+			apierror.Respond(c, http.StatusConflict, apierror.Conflict, err.Error())
 			return
 		}
 		log.Printf("Failed to update report status: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "update_failed",
-			"message": "failed to update report status",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update report status")
 		return
 	}
 
-	log.Printf("Report %s reviewed by %s: status=%s, priority=%v", reportID, user.Email, req.Status, req.Priority)
+	log.Printf("Report %s reviewed by %s: status=%s, priority=%v, publishAt=%v", reportID, user.Email, req.Status, req.Priority, req.PublishAt)
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":  "report reviewed successfully",
-		"status":   req.Status,
-		"priority": req.Priority,
+		"message":   "report reviewed successfully",
+		"status":    req.Status,
+		"priority":  req.Priority,
+		"publishAt": req.PublishAt,
 	})
 }
 
@@ -763,35 +1085,27 @@ func (h *ReportsHandler) AddReaction(c *gin.Context) {
 
 	reportID := c.Param("id")
 	if !validation.ValidateUUID(reportID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid report ID format",
-		})
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+	// Engagement on a merged duplicate applies to the canonical report
+	reportID = h.canonicalReportID(c.Request.Context(), reportID)
+	if h.blockedByReportOwner(c, reportID, user.Subject) {
 		return
 	}
 
 	var req models.AddReactionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": err.Error(),
-		})
+		apierror.RespondValidation(c, err)
 		return
 	}
-
-	// Validate reaction type
-	validReactions := map[string]bool{
-		models.ReactionThumbsUp:        true,
-		models.ReactionThumbsDown:      true,
-		models.ReactionAngryCar:        true,
-		models.ReactionAngryPedestrian: true,
-		models.ReactionAngryBicycle:    true,
+	if !h.allowReactionChange(c, user.Subject) {
+		return
 	}
-	if !validReactions[req.ReactionType] {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid reaction type",
-		})
+
+	// Validate reaction type against the configured set; retired types can't be added
+	if t, ok := h.reactions.lookup(req.ReactionType); !ok || !t.Active() {
+		apierror.RespondField(c, "reactionType", apierror.ReasonNotAllowed, "invalid reaction type")
 		return
 	}
 
@@ -814,25 +1128,20 @@ func (h *ReportsHandler) AddReaction(c *gin.Context) {
 		CreatedAt:    time.Now(),
 	}
 
-	if err := h.storage.AddReaction(c.Request.Context(), reaction); err != nil {
-		log.Printf("Failed to add reaction: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "create_failed",
-			"message": "failed to add reaction",
-		})
-		return
-	}
-
-	// Adjust report priority based on reaction change
+	// Adjust report priority based on reaction change, in the same transaction so
+	// the priority never counts a reaction that wasn't saved (or misses one that was).
 	// Remove old reaction score (if any) and add new reaction score
-	oldScore := getReactionScore(existingReactionType)
-	newScore := getReactionScore(req.ReactionType)
-	scoreDelta := newScore - oldScore
-	if scoreDelta != 0 {
-		if err := h.storage.AdjustReportPriority(c.Request.Context(), reportID, scoreDelta); err != nil {
-			log.Printf("Failed to adjust priority for report %s: %v", reportID, err)
-			// Don't fail the request, reaction was already added
+	ctx := c.Request.Context()
+	err = h.storage.WithTransaction(ctx, func(tx storage.Client) error {
+		if err := tx.AddReaction(ctx, reaction); err != nil {
+			return err
 		}
+		return h.adjustReactionPriority(ctx, tx, reportID, existingReactionType, req.ReactionType)
+	})
+	if err != nil {
+		log.Printf("Failed to add reaction: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to add reaction")
+		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
@@ -853,10 +1162,11 @@ func (h *ReportsHandler) RemoveReaction(c *gin.Context) {
 	reactionType := c.Param("type")
 
 	if !validation.ValidateUUID(reportID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid report ID format",
-		})
+		apierror.RespondField(c, "type", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	if !h.allowReactionChange(c, user.Subject) {
 		return
 	}
 
@@ -870,24 +1180,20 @@ func (h *ReportsHandler) RemoveReaction(c *gin.Context) {
 		return
 	}
 
-	if err := h.storage.RemoveReaction(c.Request.Context(), reportID, user.Subject, reactionType); err != nil {
+	// Reverse the priority adjustment in the same transaction as the removal
+	ctx := c.Request.Context()
+	err := h.storage.WithTransaction(ctx, func(tx storage.Client) error {
+		if err := tx.RemoveReaction(ctx, reportID, user.Subject, reactionType); err != nil {
+			return err
+		}
+		return h.adjustReactionPriority(ctx, tx, reportID, currentReactionType, "")
+	})
+	if err != nil {
 		log.Printf("Failed to remove reaction: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "delete_failed",
-			"message": "failed to remove reaction",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to remove reaction")
 		return
 	}
 
-	// Reverse the priority adjustment when reaction is removed
-	scoreDelta := getReactionScore(currentReactionType)
-	if scoreDelta != 0 {
-		if err := h.storage.AdjustReportPriority(c.Request.Context(), reportID, -scoreDelta); err != nil {
-			log.Printf("Failed to reverse priority for report %s: %v", reportID, err)
-			// Don't fail the request, reaction was already removed
-		}
-	}
-
 	c.JSON(http.StatusOK, gin.H{
 		"message": "reaction removed",
 	})
@@ -898,12 +1204,11 @@ func (h *ReportsHandler) RemoveReaction(c *gin.Context) {
 func (h *ReportsHandler) GetReportEngagement(c *gin.Context) {
 	reportID := c.Param("id")
 	if !validation.ValidateUUID(reportID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid report ID format",
-		})
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
 		return
 	}
+	// Engagement on a merged duplicate applies to the canonical report
+	reportID = h.canonicalReportID(c.Request.Context(), reportID)
 
 	// Check for optional auth
 	userID := ""
@@ -914,10 +1219,7 @@ func (h *ReportsHandler) GetReportEngagement(c *gin.Context) {
 	engagement, err := h.storage.GetReportEngagement(c.Request.Context(), reportID, userID)
 	if err != nil {
 		log.Printf("Failed to get engagement: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "fetch_failed",
-			"message": "failed to get engagement data",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get engagement data")
 		return
 	}
 
@@ -931,20 +1233,14 @@ func (h *ReportsHandler) GetBulkEngagement(c *gin.Context) {
 		ReportIDs []string `json:"reportIds" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": err.Error(),
-		})
+		apierror.RespondValidation(c, err)
 		return
 	}
 
 	// Validate all report IDs
 	for _, id := range req.ReportIDs {
 		if !validation.ValidateUUID(id) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error":   "validation_error",
-				"message": "invalid report ID format",
-			})
+			apierror.RespondField(c, "reportIds", apierror.ReasonInvalidFormat, "invalid report ID format")
 			return
 		}
 	}
@@ -958,10 +1254,7 @@ func (h *ReportsHandler) GetBulkEngagement(c *gin.Context) {
 	engagements, err := h.storage.GetBulkReportEngagement(c.Request.Context(), req.ReportIDs, userID)
 	if err != nil {
 		log.Printf("Failed to get bulk engagement: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "fetch_failed",
-			"message": "failed to get engagement data",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get engagement data")
 		return
 	}
 
@@ -984,77 +1277,99 @@ func (h *ReportsHandler) AddComment(c *gin.Context) {
 
 	reportID := c.Param("id")
 	if !validation.ValidateUUID(reportID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid report ID format",
-		})
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+	// Engagement on a merged duplicate applies to the canonical report
+	reportID = h.canonicalReportID(c.Request.Context(), reportID)
+	if h.blockedByReportOwner(c, reportID, user.Subject) {
 		return
 	}
 
 	var req models.AddCommentRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": err.Error(),
-		})
+		apierror.RespondValidation(c, err)
+		return
+	}
+	if !h.allowComment(c, user.Subject) {
 		return
 	}
 
-	// Get user email from stored user info
-	storedUser, err := h.storage.GetUserByID(c.Request.Context(), user.Subject)
-	userEmail := user.Email
-	if err == nil && storedUser != nil {
-		userEmail = storedUser.Email
+	flagged, ok := h.applyWordFilter(c, user, &req.Content)
+	if !ok {
+		return
 	}
 
-	now := time.Now()
-	comment := &models.Comment{
-		ID:        uuid.New().String(),
-		ReportID:  reportID,
-		UserID:    user.Subject,
-		UserEmail: userEmail,
-		Content:   req.Content,
-		CreatedAt: now,
-		UpdatedAt: now,
+	// Get user email and display name from stored user info
+	author := &models.User{ID: user.Subject, Email: user.Email}
+	if storedUser, err := h.storage.GetUserByID(c.Request.Context(), user.Subject); err == nil && storedUser != nil {
+		author = storedUser
 	}
 
-	if err := h.storage.AddComment(c.Request.Context(), comment); err != nil {
+	// Links from untrusted users and repeated identical comments are held (or refused) per the comment policy
+	held, ok := h.screenComment(c, author, req.Content)
+	if !ok {
+		return
+	}
+	flagged = flagged || held
+
+	now := time.Now()
+	comment := &models.Comment{
+		ID:         uuid.New().String(),
+		ReportID:   reportID,
+		UserID:     user.Subject,
+		UserEmail:  author.Email,
+		AuthorName: models.AuthorName(user.Subject, author.DisplayName),
+		Content:    req.Content,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Flagged:    flagged,
+	}
+
+	// Visible comments are announced (and their owners notified) through the outbox;
+	// held comments once a moderator approves them, and comments by shadow-banned users never
+	visible := !flagged && !author.ShadowBanned
+	added := events.Event{Type: events.CommentAdded, ReportID: reportID, CommentID: comment.ID, Actor: user.Email}
+	err := h.storage.WithTransaction(c.Request.Context(), func(tx storage.Client) error {
+		if err := tx.AddComment(c.Request.Context(), comment); err != nil {
+			return err
+		}
+		if !visible {
+			return nil
+		}
+		return enqueueEvent(c.Request.Context(), tx, added)
+	})
+	if err != nil {
 		log.Printf("Failed to add comment: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "create_failed",
-			"message": "failed to add comment",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to add comment")
 		return
 	}
 
-	// Adjust report priority for new comment
-	if err := h.storage.AdjustReportPriority(c.Request.Context(), reportID, ScoreComment); err != nil {
-		log.Printf("Failed to adjust priority for report %s: %v", reportID, err)
-		// Don't fail the request, comment was already added
+	// Adjust report priority for new comment; held comments count once a moderator approves them,
+	// and comments by shadow-banned users never do
+	if visible {
+		if err := h.storage.AdjustReportPriority(c.Request.Context(), reportID, ScoreComment); err != nil {
+			log.Printf("Failed to adjust priority for report %s: %v", reportID, err)
+			// Don't fail the request, comment was already added
+		}
+		h.events.Publish(added)
 	}
 
 	c.JSON(http.StatusCreated, comment)
 }
 
-// GetComments handles GET /v1/reports/:id/comments
-// Gets all comments for a report (public)
+// GetComments handles GET /v1/public/reports/:id/comments
+// Gets all comments for a report (public, optional auth)
 func (h *ReportsHandler) GetComments(c *gin.Context) {
 	reportID := c.Param("id")
 	if !validation.ValidateUUID(reportID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid report ID format",
-		})
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
 		return
 	}
-
-	comments, err := h.storage.GetComments(c.Request.Context(), reportID)
+	comments, err := h.visibleComments(c.Request.Context(), reportID, viewerID(c))
 	if err != nil {
 		log.Printf("Failed to get comments: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "fetch_failed",
-			"message": "failed to get comments",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get comments")
 		return
 	}
 
@@ -1064,6 +1379,21 @@ func (h *ReportsHandler) GetComments(c *gin.Context) {
 	})
 }
 
+// commentCounted reports whether a comment counts towards its report's priority:
+// it is published and its author isn't shadow-banned. When the author can't be
+// looked up it is treated as not counted, so priority is never lowered by mistake.
+func (h *ReportsHandler) commentCounted(ctx context.Context, comment *models.Comment) bool {
+	if comment.Flagged {
+		return false
+	}
+	author, err := h.storage.GetUserByID(ctx, comment.UserID)
+	if err != nil {
+		log.Printf("Failed to get author of comment %s: %v", comment.ID, err)
+		return false
+	}
+	return !author.ShadowBanned
+}
+
 // DeleteComment handles DELETE /v1/reports/:id/comments/:commentId
 // Deletes a comment (requires auth, only owner can delete)
 func (h *ReportsHandler) DeleteComment(c *gin.Context) {
@@ -1074,43 +1404,35 @@ func (h *ReportsHandler) DeleteComment(c *gin.Context) {
 
 	commentID := c.Param("commentId")
 	if !validation.ValidateUUID(commentID) {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "validation_error",
-			"message": "invalid comment ID format",
-		})
+		apierror.RespondField(c, "commentId", apierror.ReasonInvalidFormat, "invalid comment ID format")
 		return
 	}
 
 	// Get the comment first to know the report ID for priority adjustment
 	comment, err := h.storage.GetCommentByID(c.Request.Context(), commentID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "not_found",
-			"message": "comment not found",
-		})
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "comment not found")
 		return
 	}
 
 	if err := h.storage.DeleteComment(c.Request.Context(), commentID, user.Subject); err != nil {
-		if err.Error() == "comment not found or not authorized" {
-			c.JSON(http.StatusNotFound, gin.H{
-				"error":   "not_found",
-				"message": "comment not found or not authorized to delete",
-			})
+		if errors.Is(err, storage.ErrForbidden) {
+			apierror.Respond(c, http.StatusForbidden, apierror.Forbidden, "not authorized to delete this comment")
 			return
 		}
 		log.Printf("Failed to delete comment: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "delete_failed",
-			"message": "failed to delete comment",
-		})
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to delete comment")
 		return
 	}
 
-	// Reverse the priority adjustment when comment is deleted
-	if err := h.storage.AdjustReportPriority(c.Request.Context(), comment.ReportID, -ScoreComment); err != nil {
-		log.Printf("Failed to reverse priority for report %s: %v", comment.ReportID, err)
-		// Don't fail the request, comment was already deleted
+	// Reverse the priority adjustment when comment is deleted. Only visible comments
+	// added to it, so deleting a held comment or one by a shadow-banned user can't
+	// be used to push someone else's report down.
+	if h.commentCounted(c.Request.Context(), comment) {
+		if err := h.storage.AdjustReportPriority(c.Request.Context(), comment.ReportID, -ScoreComment); err != nil {
+			log.Printf("Failed to reverse priority for report %s: %v", comment.ReportID, err)
+			// Don't fail the request, comment was already deleted
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{
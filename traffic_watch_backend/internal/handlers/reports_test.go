@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +16,10 @@ import (
 
 	"donzhit_me_backend/internal/middleware"
 	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/moderation"
+	"donzhit_me_backend/internal/pagination"
+	"donzhit_me_backend/internal/ratelimit"
+	"donzhit_me_backend/internal/storage"
 	"donzhit_me_backend/internal/validation"
 )
 
@@ -33,7 +41,7 @@ func mockUserMiddleware(userID, email string) gin.HandlerFunc {
 }
 
 func TestReportsHandler_CreateReport_NoAuth(t *testing.T) {
-	handler := NewReportsHandler(nil, nil)
+	handler := NewReportsHandler(nil, nil, nil)
 
 	router := gin.New()
 	router.POST("/v1/reports", handler.CreateReport)
@@ -52,7 +60,7 @@ func TestReportsHandler_CreateReport_NoAuth(t *testing.T) {
 }
 
 func TestReportsHandler_CreateReport_ValidationError(t *testing.T) {
-	handler := NewReportsHandler(nil, nil)
+	handler := NewReportsHandler(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(mockUserMiddleware("user-123", "user@example.com"))
@@ -64,23 +72,27 @@ func TestReportsHandler_CreateReport_ValidationError(t *testing.T) {
 	}{
 		{
 			name: "missing title",
-			body: `{"description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsage": "Auto", "eventType": "Speeding", "state": "California"}`,
+			body: `{"description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsages": ["Auto"], "eventTypes": ["Speeding"], "state": "California"}`,
 		},
 		{
 			name: "missing description",
-			body: `{"title": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsage": "Auto", "eventType": "Speeding", "state": "California"}`,
+			body: `{"title": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsages": ["Auto"], "eventTypes": ["Speeding"], "state": "California"}`,
 		},
 		{
 			name: "invalid roadUsage",
-			body: `{"title": "Test", "description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsage": "Invalid", "eventType": "Speeding", "state": "California"}`,
+			body: `{"title": "Test", "description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsages": ["Invalid"], "eventTypes": ["Speeding"], "state": "California"}`,
 		},
 		{
 			name: "invalid eventType",
-			body: `{"title": "Test", "description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsage": "Auto", "eventType": "Invalid", "state": "California"}`,
+			body: `{"title": "Test", "description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsages": ["Auto"], "eventTypes": ["Invalid"], "state": "California"}`,
+		},
+		{
+			name: "invalid legacy roadUsage",
+			body: `{"title": "Test", "description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsage": "Invalid", "eventTypes": ["Speeding"], "state": "California"}`,
 		},
 		{
 			name: "invalid state",
-			body: `{"title": "Test", "description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsage": "Auto", "eventType": "Speeding", "state": "InvalidState"}`,
+			body: `{"title": "Test", "description": "Test", "dateTime": "2026-01-21T12:00:00Z", "roadUsages": ["Auto"], "eventTypes": ["Speeding"], "state": "InvalidState"}`,
 		},
 		{
 			name: "empty body",
@@ -108,7 +120,7 @@ func TestReportsHandler_CreateReport_ValidationError(t *testing.T) {
 }
 
 func TestReportsHandler_ListReports_NoAuth(t *testing.T) {
-	handler := NewReportsHandler(nil, nil)
+	handler := NewReportsHandler(nil, nil, nil)
 
 	router := gin.New()
 	router.GET("/v1/reports", handler.ListReports)
@@ -123,8 +135,49 @@ func TestReportsHandler_ListReports_NoAuth(t *testing.T) {
 	}
 }
 
+func TestReportsHandler_ListReports_InvalidStatus(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.Use(mockUserMiddleware("user-123", "user@example.com"))
+	router.GET("/v1/reports", handler.ListReports)
+
+	for _, status := range []string{"deleted", "merged", "REVIEWED_FAIL", "pending"} {
+		req, _ := http.NewRequest(http.MethodGet, "/v1/reports?status="+status, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("status=%s: expected status %d, got %d", status, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestReportsHandler_CountReports(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.GET("/v1/reports/count", handler.CountReports)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/reports/count", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("no auth: expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+
+	router = gin.New()
+	router.Use(mockUserMiddleware("user-123", "user@example.com"))
+	router.GET("/v1/reports/count", handler.CountReports)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/v1/reports/count?status=deleted", nil)
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status=deleted: expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
 func TestReportsHandler_GetReport_NoAuth(t *testing.T) {
-	handler := NewReportsHandler(nil, nil)
+	handler := NewReportsHandler(nil, nil, nil)
 
 	router := gin.New()
 	router.GET("/v1/reports/:id", handler.GetReport)
@@ -140,7 +193,7 @@ func TestReportsHandler_GetReport_NoAuth(t *testing.T) {
 }
 
 func TestReportsHandler_GetReport_InvalidID(t *testing.T) {
-	handler := NewReportsHandler(nil, nil)
+	handler := NewReportsHandler(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(mockUserMiddleware("user-123", "user@example.com"))
@@ -171,7 +224,7 @@ func TestReportsHandler_GetReport_InvalidID(t *testing.T) {
 }
 
 func TestReportsHandler_DeleteReport_NoAuth(t *testing.T) {
-	handler := NewReportsHandler(nil, nil)
+	handler := NewReportsHandler(nil, nil, nil)
 
 	router := gin.New()
 	router.DELETE("/v1/reports/:id", handler.DeleteReport)
@@ -187,7 +240,7 @@ func TestReportsHandler_DeleteReport_NoAuth(t *testing.T) {
 }
 
 func TestReportsHandler_DeleteReport_InvalidID(t *testing.T) {
-	handler := NewReportsHandler(nil, nil)
+	handler := NewReportsHandler(nil, nil, nil)
 
 	router := gin.New()
 	router.Use(mockUserMiddleware("user-123", "user@example.com"))
@@ -215,8 +268,8 @@ func TestCreateReportRequest_Validation(t *testing.T) {
 				Title:       "Test Report",
 				Description: "Test description",
 				DateTime:    time.Now(),
-				RoadUsage:   "Auto",
-				EventType:   "Speeding",
+				RoadUsages:  []string{"Auto"},
+				EventTypes:  []string{"Speeding"},
 				State:       "California",
 				Injuries:    "",
 			},
@@ -228,8 +281,8 @@ func TestCreateReportRequest_Validation(t *testing.T) {
 				Title:       string(make([]byte, 201)), // 201 chars
 				Description: "Test",
 				DateTime:    time.Now(),
-				RoadUsage:   "Auto",
-				EventType:   "Speeding",
+				RoadUsages:  []string{"Auto"},
+				EventTypes:  []string{"Speeding"},
 				State:       "California",
 			},
 			wantErr: true,
@@ -240,8 +293,8 @@ func TestCreateReportRequest_Validation(t *testing.T) {
 				Title:       "Test",
 				Description: string(make([]byte, 5001)), // 5001 chars
 				DateTime:    time.Now(),
-				RoadUsage:   "Auto",
-				EventType:   "Speeding",
+				RoadUsages:  []string{"Auto"},
+				EventTypes:  []string{"Speeding"},
 				State:       "California",
 			},
 			wantErr: true,
@@ -258,6 +311,37 @@ func TestCreateReportRequest_Validation(t *testing.T) {
 	}
 }
 
+func TestCreateReportRequest_NormalizeLegacyFields(t *testing.T) {
+	templateUsages := make([]string, 1, 4) // Spare capacity an in-place append would write into
+	templateUsages[0] = "Auto"
+	req := models.CreateReportRequest{
+		RoadUsages: templateUsages,
+		RoadUsage:  "Pedestrian",
+		EventType:  "Speeding",
+	}
+	used := req.NormalizeLegacyFields()
+	if used["roadUsage"] != "roadUsages" || used["eventType"] != "eventTypes" || len(used) != 2 {
+		t.Errorf("unexpected deprecated fields %v", used)
+	}
+	if !slices.Equal(req.RoadUsages, []string{"Auto", "Pedestrian"}) || !slices.Equal(req.EventTypes, []string{"Speeding"}) {
+		t.Errorf("got roadUsages %v, eventTypes %v", req.RoadUsages, req.EventTypes)
+	}
+	if req.RoadUsage != "" || req.EventType != "" {
+		t.Error("legacy fields should be cleared once folded in")
+	}
+	if templateUsages[:2][1] != "" {
+		t.Error("the template's slice must not be modified")
+	}
+
+	current := models.CreateReportRequest{RoadUsages: []string{"Auto"}, EventType: "Speeding", EventTypes: []string{"Speeding"}}
+	if used := current.NormalizeLegacyFields(); len(used) != 1 || len(current.EventTypes) != 1 {
+		t.Errorf("a repeated value should not be duplicated: %v, %v", used, current.EventTypes)
+	}
+	if used := (&models.CreateReportRequest{RoadUsages: []string{"Auto"}}).NormalizeLegacyFields(); len(used) != 0 {
+		t.Errorf("current clients use no deprecated fields, got %v", used)
+	}
+}
+
 func TestTrafficReport_JSONSerialization(t *testing.T) {
 	report := models.TrafficReport{
 		ID:          "test-id",
@@ -265,14 +349,14 @@ func TestTrafficReport_JSONSerialization(t *testing.T) {
 		Title:       "Test Report",
 		Description: "Test description",
 		DateTime:    time.Date(2026, 1, 21, 12, 0, 0, 0, time.UTC),
-		RoadUsage:   "Auto",
-		EventType:   "Speeding",
+		RoadUsages:  []string{"Auto"},
+		EventTypes:  []string{"Speeding"},
 		State:       "California",
 		Injuries:    "None",
 		MediaFiles:  []models.MediaFile{},
 		CreatedAt:   time.Date(2026, 1, 21, 12, 0, 0, 0, time.UTC),
 		UpdatedAt:   time.Date(2026, 1, 21, 12, 0, 0, 0, time.UTC),
-		Status:      "active",
+		Status:      models.StatusSubmitted,
 	}
 
 	// Test serialization
@@ -299,18 +383,52 @@ func TestTrafficReport_JSONSerialization(t *testing.T) {
 	}
 }
 
+func TestTrafficReport_LocalDateTime(t *testing.T) {
+	incident := time.Date(2024, 7, 4, 21, 30, 0, 0, time.UTC) // 17:30 EDT
+
+	var report models.TrafficReport
+	report.SetDateTime(incident, "America/New_York")
+	if report.UTCOffsetMinutes != -240 {
+		t.Errorf("expected EDT offset -240, got %d", report.UTCOffsetMinutes)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("failed to marshal report: %v", err)
+	}
+	var body struct {
+		DateTime string `json:"dateTime"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	if body.DateTime != "2024-07-04T17:30:00-04:00" {
+		t.Errorf("expected local time with offset, got %s", body.DateTime)
+	}
+
+	// Without a zone name the client's own offset is kept
+	var offsetOnly models.TrafficReport
+	offsetOnly.SetDateTime(time.Date(2024, 7, 4, 17, 30, 0, 0, time.FixedZone("", 5*3600+1800)), "")
+	if got := offsetOnly.LocalDateTime().Format(time.RFC3339); got != "2024-07-04T17:30:00+05:30" {
+		t.Errorf("expected client offset to be kept, got %s", got)
+	}
+	if !offsetOnly.DateTime.Equal(time.Date(2024, 7, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected DateTime stored as UTC instant, got %v", offsetOnly.DateTime)
+	}
+}
+
 func TestListReportsResponse_JSONSerialization(t *testing.T) {
 	response := models.ListReportsResponse{
 		Reports: []models.TrafficReport{
 			{
 				ID:     "report-1",
 				Title:  "Report 1",
-				Status: "active",
+				Status: models.StatusSubmitted,
 			},
 			{
 				ID:     "report-2",
 				Title:  "Report 2",
-				Status: "active",
+				Status: models.StatusSubmitted,
 			},
 		},
 		Count: 2,
@@ -366,10 +484,707 @@ func TestMediaFile_JSONSerialization(t *testing.T) {
 }
 
 func TestReportStatus_Constants(t *testing.T) {
-	if models.StatusActive != "active" {
-		t.Errorf("StatusActive should be 'active', got %q", models.StatusActive)
+	if models.StatusSubmitted != "submitted" {
+		t.Errorf("StatusSubmitted should be 'submitted', got %q", models.StatusSubmitted)
 	}
 	if models.StatusDeleted != "deleted" {
 		t.Errorf("StatusDeleted should be 'deleted', got %q", models.StatusDeleted)
 	}
 }
+
+func TestReportsHandler_ListReportClusters_InvalidParams(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.GET("/v1/public/reports/clusters", handler.ListReportClusters)
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing bbox", "zoom=10"},
+		{"malformed bbox", "bbox=1,2,3&zoom=10"},
+		{"missing zoom", "bbox=-85,39,-84,40"},
+		{"zoom too high", "bbox=-85,39,-84,40&zoom=23"},
+		{"negative zoom", "bbox=-85,39,-84,40&zoom=-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/v1/public/reports/clusters?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", w.Code)
+			}
+		})
+	}
+}
+
+func TestSortedFeed_Hot(t *testing.T) {
+	now := time.Now()
+	feed := []models.TrafficReport{
+		{ID: "pinned", Pinned: true, HotScore: 0.1, CreatedAt: now},
+		{ID: "old", HotScore: 0.1, CreatedAt: now.Add(-time.Hour)},
+		{ID: "new", HotScore: 0.1, CreatedAt: now},
+		{ID: "hot", HotScore: 5, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	got := sortedFeed(feed, models.ReportSort{Field: models.SortEngagement})
+	want := []string{"pinned", "hot", "new", "old"}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Fatalf("position %d = %s, want %s (order %v)", i, got[i].ID, id, got)
+		}
+	}
+	if feed[1].ID != "old" {
+		t.Error("hot sort should not reorder the cached feed")
+	}
+	if sortedFeed(feed, defaultFeedSort)[1].ID != "old" {
+		t.Error("default sort should keep the feed order")
+	}
+}
+
+func TestSortedFeed_AscendingKeepsPinnedFirst(t *testing.T) {
+	now := time.Now()
+	low, high := 50, 150
+	feed := []models.TrafficReport{
+		{ID: "pinned", Pinned: true, Priority: &high, CreatedAt: now},
+		{ID: "high", Priority: &high, CreatedAt: now},
+		{ID: "default", CreatedAt: now},
+		{ID: "low", Priority: &low, CreatedAt: now},
+	}
+
+	sort := models.ReportSort{Field: models.SortPriority, Ascending: true}
+	got := sortedFeed(feed, sort)
+	want := []string{"pinned", "low", "default", "high"}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Fatalf("position %d = %s, want %s (order %v)", i, got[i].ID, id, got)
+		}
+	}
+
+	// Cursors follow the same order, so paging one at a time walks the whole feed
+	keyOf := reportKey(sort, true)
+	var after *pagination.Key
+	for i, id := range want {
+		page, next := pagination.Slice(got, keyOf, !sort.Ascending, after, 1)
+		if len(page) != 1 || page[0].ID != id {
+			t.Fatalf("page %d = %v, want %s", i, page, id)
+		}
+		if next == "" {
+			break
+		}
+		var err error
+		if after, err = pagination.Decode(next); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseReportSort(t *testing.T) {
+	tests := []struct {
+		sort, order string
+		want        models.ReportSort
+		err         error
+	}{
+		{"", "", models.ReportSort{Field: models.SortPriority}, nil},
+		{"createdAt", "asc", models.ReportSort{Field: models.SortCreatedAt, Ascending: true}, nil},
+		{"updatedAt", "desc", models.ReportSort{Field: models.SortUpdatedAt}, nil},
+		{"hot", "", models.ReportSort{Field: models.SortEngagement}, nil},
+		{"", "asc", models.ReportSort{Field: models.SortPriority, Ascending: true}, nil},
+		{"created_at", "", models.ReportSort{}, models.ErrInvalidSortField},
+		{"engagement", "up", models.ReportSort{}, models.ErrInvalidSortOrder},
+	}
+	for _, tt := range tests {
+		got, err := models.ParseReportSort(tt.sort, tt.order, models.SortPriority)
+		if got != tt.want || err != tt.err {
+			t.Errorf("ParseReportSort(%q, %q) = %+v, %v; want %+v, %v", tt.sort, tt.order, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestLocalFirst(t *testing.T) {
+	feed := []models.TrafficReport{
+		{ID: "elsewhere", State: "Texas", City: "Austin"},
+		{ID: "state", State: "Ohio", City: "Dayton"},
+		{ID: "pinned", State: "Texas", Pinned: true},
+		{ID: "city", State: "Ohio", City: " columbus"},
+		{ID: "same-city-other-state", State: "Georgia", City: "Columbus"},
+	}
+
+	got := localFirst(feed, "Ohio", "Columbus")
+	want := []string{"pinned", "city", "state", "elsewhere", "same-city-other-state"}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Fatalf("position %d = %s, want %s", i, got[i].ID, id)
+		}
+	}
+
+	got = localFirst(feed, "Ohio", "")
+	if got[1].ID != "state" || got[2].ID != "city" {
+		t.Errorf("without a city, state reports should keep feed order: %v", got)
+	}
+}
+
+func TestReportsHandler_FeedCache(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+	handler.SetFeedCacheTTL(time.Minute)
+
+	reports := []models.TrafficReport{{ID: "r1"}}
+	handler.feedCache.set(reports, nil)
+	if got, ok := handler.feedCache.get(); !ok || len(got) != 1 {
+		t.Fatalf("expected cached feed, got %v %v", got, ok)
+	}
+
+	handler.InvalidateFeedCache()
+	if _, ok := handler.feedCache.get(); ok {
+		t.Error("expected cache to be empty after invalidation")
+	}
+
+	handler.SetFeedCacheTTL(time.Nanosecond)
+	handler.feedCache.set(reports, nil)
+	time.Sleep(time.Millisecond)
+	if _, ok := handler.feedCache.get(); ok {
+		t.Error("expected cache entry to expire")
+	}
+
+	// An embargoed report due before the TTL ends the cache entry early
+	handler.SetFeedCacheTTL(time.Minute)
+	nextPublish := time.Now().Add(time.Millisecond)
+	handler.feedCache.set(reports, &nextPublish)
+	time.Sleep(2 * time.Millisecond)
+	if _, ok := handler.feedCache.get(); ok {
+		t.Error("expected cache entry to expire at the next publish time")
+	}
+
+	handler.SetFeedCacheTTL(0)
+	handler.InvalidateFeedCache() // no-op when disabled
+	if handler.feedCache != nil {
+		t.Error("expected zero TTL to disable the cache")
+	}
+}
+
+func TestReportsHandler_PIIPolicy(t *testing.T) {
+	newReport := func() *models.TrafficReport {
+		return &models.TrafficReport{Title: "Near miss", Description: "Driver gave his number, 614-555-0123"}
+	}
+
+	handler := NewReportsHandler(nil, nil, nil)
+	report := newReport()
+	handler.applyPIIPolicy(report)
+	if report.PIIWarnings != nil || report.ContentFlagged {
+		t.Errorf("unset policy should not scan, got %+v", report)
+	}
+
+	handler.SetPIIPolicy(moderation.PIIPolicyWarn)
+	report = newReport()
+	handler.applyPIIPolicy(report)
+	if len(report.PIIWarnings) != 1 || report.PIIWarnings[0] != moderation.PIIPhone || report.ContentFlagged {
+		t.Errorf("warn: expected a phone warning only, got %+v", report)
+	}
+
+	handler.SetPIIPolicy(moderation.PIIPolicyFlag)
+	report = newReport()
+	handler.applyPIIPolicy(report)
+	if !report.ContentFlagged {
+		t.Error("flag: expected the report to be held for review")
+	}
+
+	// Mask leaves the stored report alone and masks only the public copy
+	handler.SetPIIPolicy(moderation.PIIPolicyMask)
+	report = newReport()
+	handler.applyPIIPolicy(report)
+	if report.ContentFlagged || report.Description != newReport().Description {
+		t.Errorf("mask: submission should be stored as is, got %+v", report)
+	}
+	public := []models.TrafficReport{*report}
+	handler.maskPublicPII(public)
+	if public[0].Description != "Driver gave his number, [phone]" {
+		t.Errorf("mask: public description = %q", public[0].Description)
+	}
+}
+
+func TestTrafficReport_RenderedMarkdown(t *testing.T) {
+	report := models.TrafficReport{ID: "r1", Description: "**Red** car <script>"}
+	data, err := json.Marshal(&report)
+	if err != nil {
+		t.Fatalf("failed to marshal report: %v", err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("failed to unmarshal report: %v", err)
+	}
+	if parsed["description"] != report.Description {
+		t.Errorf("raw description = %v, want %q", parsed["description"], report.Description)
+	}
+	if want := "<p><strong>Red</strong> car &lt;script&gt;</p>"; parsed["descriptionHtml"] != want {
+		t.Errorf("descriptionHtml = %v, want %q", parsed["descriptionHtml"], want)
+	}
+	if parsed["id"] != "r1" {
+		t.Errorf("expected report fields alongside descriptionHtml, got %v", parsed)
+	}
+
+	data, err = json.Marshal(models.Comment{Content: "see _this_"})
+	if err != nil {
+		t.Fatalf("failed to marshal comment: %v", err)
+	}
+	var comment struct {
+		Content     string `json:"content"`
+		ContentHTML string `json:"contentHtml"`
+	}
+	if err := json.Unmarshal(data, &comment); err != nil {
+		t.Fatalf("failed to unmarshal comment: %v", err)
+	}
+	if comment.Content != "see _this_" || comment.ContentHTML != "<p>see <em>this</em></p>" {
+		t.Errorf("comment = %+v, want raw and rendered content", comment)
+	}
+}
+
+func TestReactionSet_PriorityDelta(t *testing.T) {
+	set := newReactionSet()
+	set.load([]models.ReactionType{
+		{Type: "up", Score: 2, MaxScore: 5},
+		{Type: "down", Score: -1},
+	})
+	counts := func(up, down int) []models.ReactionCount {
+		return []models.ReactionCount{{ReactionType: "up", Count: up}, {ReactionType: "down", Count: down}}
+	}
+
+	tests := []struct {
+		name           string
+		counts         []models.ReactionCount
+		removed, added string
+		want           int
+	}{
+		{"first up", counts(1, 0), "", "up", 2},
+		{"up reaching the cap", counts(3, 0), "", "up", 1},
+		{"up past the cap", counts(4, 0), "", "up", 0},
+		{"removing back under the cap", counts(2, 0), "up", "", -1},
+		{"uncapped down", counts(0, 7), "", "down", -1},
+		{"switching down to up", counts(1, 0), "down", "up", 3},
+		{"re-adding the same type", counts(9, 0), "up", "up", 0},
+		{"unknown type", counts(0, 0), "", "honk", 0},
+	}
+	for _, tt := range tests {
+		if got := set.priorityDelta(tt.counts, tt.removed, tt.added); got != tt.want {
+			t.Errorf("%s: priorityDelta = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReportsHandler_PublicConfigReactions(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+	if got := handler.reactions.score(models.ReactionThumbsDown); got != -1 {
+		t.Errorf("default thumbs_down score = %d, want -1", got)
+	}
+
+	retired := time.Now()
+	handler.reactions.load([]models.ReactionType{
+		{Type: "honk", Label: "Honk", Score: 1, SortOrder: 1},
+		{Type: models.ReactionThumbsUp, Label: "Thumbs up", Score: 2, SortOrder: 2, RetiredAt: &retired},
+	})
+	handler.reactions.load(nil) // an empty load keeps the current set
+
+	if got := handler.reactions.score(models.ReactionThumbsUp); got != 2 {
+		t.Errorf("retired type score = %d, want 2 so removal still reverses it", got)
+	}
+	if got := handler.reactions.score(models.ReactionAngryCar); got != 0 {
+		t.Errorf("unconfigured type score = %d, want 0", got)
+	}
+
+	router := gin.New()
+	router.GET("/v1/public/config", handler.GetPublicConfig)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/v1/public/config", nil)
+	router.ServeHTTP(w, req)
+
+	var resp struct {
+		Reactions []map[string]interface{} `json:"reactions"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Reactions) != 1 || resp.Reactions[0]["type"] != "honk" {
+		t.Fatalf("reactions = %v, want only the active honk type", resp.Reactions)
+	}
+	if _, ok := resp.Reactions[0]["score"]; ok {
+		t.Error("public config should not expose scores")
+	}
+}
+
+func TestReportsHandler_ReactionRateLimit(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+	handler.SetReactionRateLimit(ratelimit.New(2, time.Minute))
+
+	router := gin.New()
+	router.PUT("/react/:user", func(c *gin.Context) {
+		if handler.allowReactionChange(c, c.Param("user")) {
+			c.Status(http.StatusOK)
+		}
+	})
+	react := func(user string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/react/"+user, nil)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < 2; i++ {
+		if w := react("user-1"); w.Code != http.StatusOK {
+			t.Fatalf("change %d: expected 200, got %d", i+1, w.Code)
+		}
+	}
+	w := react("user-1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("over limit: expected 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "60" {
+		t.Errorf("Retry-After = %q, want 60", w.Header().Get("Retry-After"))
+	}
+	if w := react("user-2"); w.Code != http.StatusOK {
+		t.Errorf("other user: expected 200, got %d", w.Code)
+	}
+}
+
+func TestReportsHandler_ListReports_InvalidSort(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.Use(mockUserMiddleware("user-123", "user@example.com"))
+	router.GET("/v1/reports", handler.ListReports)
+	router.GET("/v1/admin/reports", handler.ListAllReportsAdmin)
+	router.GET("/v1/public/reports", handler.ListApprovedReports)
+
+	for _, path := range []string{"/v1/reports", "/v1/admin/reports", "/v1/public/reports"} {
+		for _, query := range []string{"sort=title", "sort=createdAt&order=newest"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, path+"?"+query, nil)
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("%s?%s: expected status %d, got %d", path, query, http.StatusBadRequest, w.Code)
+			}
+		}
+	}
+}
+
+func TestReportsHandler_AdminLists_InvalidPage(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.Use(mockUserMiddleware("admin-123", "admin@example.com"))
+	router.GET("/v1/admin/reports", handler.ListAllReportsAdmin)
+	router.GET("/v1/admin/reports/review", handler.ListReportsForReview)
+
+	for _, path := range []string{"/v1/admin/reports", "/v1/admin/reports/review"} {
+		for _, query := range []string{"limit=0", "limit=ten", "offset=-5", "offset=100001"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, path+"?"+query, nil)
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("%s?%s: expected status %d, got %d", path, query, http.StatusBadRequest, w.Code)
+			}
+		}
+	}
+}
+
+func TestReportsHandler_UpdatePriority_Validation(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.Use(mockUserMiddleware("admin-123", "admin@example.com"))
+	router.PATCH("/v1/admin/reports/:id/priority", handler.UpdatePriority)
+
+	reportID := "123e4567-e89b-12d3-a456-426614174000"
+	tests := []struct {
+		name string
+		id   string
+		body string
+	}{
+		{"invalid id", "not-a-uuid", `{"priority": 100}`},
+		{"neither field", reportID, `{}`},
+		{"both fields", reportID, `{"priority": 100, "delta": 5}`},
+		{"priority below range", reportID, `{"priority": -1}`},
+		{"priority above range", reportID, `{"priority": 1001}`},
+		{"delta above range", reportID, `{"delta": 1001}`},
+		{"delta below range", reportID, `{"delta": -1001}`},
+		{"not a number", reportID, `{"priority": "high"}`},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPatch, "/v1/admin/reports/"+tt.id+"/priority", bytes.NewBufferString(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", tt.name, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestPriorityChange_Apply(t *testing.T) {
+	set := func(n int) *int { return &n }
+	tests := []struct {
+		change  models.PriorityChange
+		current int
+		want    int
+	}{
+		{models.PriorityChange{Set: set(250)}, 100, 250},
+		{models.PriorityChange{Delta: 25}, 100, 125},
+		{models.PriorityChange{Delta: -500}, 100, models.MinPriority},
+		{models.PriorityChange{Delta: 50}, 990, models.MaxPriority},
+	}
+	for _, tt := range tests {
+		if got := tt.change.Apply(tt.current); got != tt.want {
+			t.Errorf("%+v.Apply(%d) = %d, want %d", tt.change, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestTrafficReport_UnseenStatusChange(t *testing.T) {
+	changed := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	before, after := changed.Add(-time.Minute), changed.Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		changed *time.Time
+		seen    *time.Time
+		want    bool
+	}{
+		{"never changed", nil, nil, false},
+		{"changed, never seen", &changed, nil, true},
+		{"seen before the change", &changed, &before, true},
+		{"seen after the change", &changed, &after, false},
+	}
+	for _, tt := range tests {
+		r := models.TrafficReport{StatusChangedAt: tt.changed, StatusSeenAt: tt.seen}
+		if got := r.UnseenStatusChange(); got != tt.want {
+			t.Errorf("%s: UnseenStatusChange() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReportsHandler_AdminSearch_InvalidQuery(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.Use(mockUserMiddleware("admin-1", "admin@example.com"))
+	router.GET("/v1/admin/search", handler.AdminSearch)
+
+	for _, query := range []string{"", "q=", "q=%20%20", "q=a", "q=" + strings.Repeat("x", 201), "q=truck&limit=0"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/v1/admin/search?"+query, nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%q: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestReportsHandler_RetryMediaFailure_InvalidID(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.Use(mockUserMiddleware("admin-1", "admin@example.com"))
+	router.POST("/v1/admin/media/failures/:id/retry", handler.RetryMediaFailure)
+
+	req, _ := http.NewRequest(http.MethodPost, "/v1/admin/media/failures/not-a-uuid/retry", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestTrafficReport_MediaPending(t *testing.T) {
+	report := models.TrafficReport{MediaFiles: []models.MediaFile{
+		{ID: "legacy"}, // Stored before states were tracked
+		{ID: "failed", State: models.MediaFailed},
+	}}
+	if report.MediaPending() {
+		t.Error("ready and failed media reported as pending")
+	}
+	report.MediaFiles = append(report.MediaFiles, models.MediaFile{ID: "new", State: models.MediaQuarantined})
+	if !report.MediaPending() {
+		t.Error("quarantined media not reported as pending")
+	}
+
+	allowed := [][2]string{
+		{models.MediaProcessing, models.MediaQuarantined},
+		{models.MediaProcessing, models.MediaFailed},
+		{models.MediaQuarantined, models.MediaReady},
+		{models.MediaQuarantined, models.MediaFailed},
+	}
+	for _, tr := range allowed {
+		if !models.ValidMediaTransition(tr[0], tr[1]) {
+			t.Errorf("%s -> %s rejected", tr[0], tr[1])
+		}
+	}
+	for _, tr := range [][2]string{
+		{models.MediaProcessing, models.MediaReady},
+		{models.MediaReady, models.MediaQuarantined},
+		{models.MediaFailed, models.MediaReady},
+	} {
+		if models.ValidMediaTransition(tr[0], tr[1]) {
+			t.Errorf("%s -> %s allowed", tr[0], tr[1])
+		}
+	}
+}
+
+func TestReportStatusTransitions(t *testing.T) {
+	allowed := [][2]string{
+		{models.StatusSubmitted, models.StatusReviewedPass},
+		{models.StatusSubmitted, models.StatusReviewedFail},
+		{models.StatusReviewedPass, models.StatusReviewedPass},
+		{models.StatusReviewedFail, models.StatusReviewedPass},
+		{models.StatusReviewedPass, models.StatusArchived},
+		{models.StatusArchived, models.StatusDeleted},
+		{models.StatusMerged, models.StatusDeleted},
+		{models.StatusReviewedFail, models.StatusSubmitted},
+	}
+	for _, tr := range allowed {
+		if err := models.CheckStatusTransition(tr[0], tr[1]); err != nil {
+			t.Errorf("%s -> %s: %v", tr[0], tr[1], err)
+		}
+	}
+
+	for _, tr := range [][2]string{
+		{models.StatusDeleted, models.StatusSubmitted},
+		{models.StatusMerged, models.StatusReviewedPass},
+		{models.StatusArchived, models.StatusReviewedPass},
+		{models.StatusSubmitted, models.StatusArchived},
+		{models.StatusReviewedPass, models.StatusSubmitted},
+	} {
+		err := models.CheckStatusTransition(tr[0], tr[1])
+		var transitionErr *models.StatusTransitionError
+		if !errors.Is(err, models.ErrInvalidStatusTransition) || !errors.As(err, &transitionErr) || transitionErr.From != tr[0] {
+			t.Errorf("%s -> %s: err = %v, want a StatusTransitionError", tr[0], tr[1], err)
+		}
+	}
+
+	from := models.StatusesTransitioningTo(models.StatusArchived)
+	if len(from) != 1 || from[0] != models.StatusReviewedPass {
+		t.Errorf("StatusesTransitioningTo(archived) = %v", from)
+	}
+}
+
+func TestReportsHandler_GetWidget_InvalidQuery(t *testing.T) {
+	handler := NewReportsHandler(nil, nil, nil)
+
+	router := gin.New()
+	router.GET("/v1/public/widget", handler.GetWidget)
+
+	for _, query := range []string{"state=Narnia", "state=ohio", "limit=0", "limit=21", "limit=five"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/v1/public/widget?"+query, nil)
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("?%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
+
+func TestReportThumbnail(t *testing.T) {
+	tests := []struct {
+		name  string
+		media []models.MediaFile
+		want  string
+	}{
+		{"no media", nil, ""},
+		{"image", []models.MediaFile{
+			{ContentType: "video/mp4", URL: "https://www.youtube.com/watch?v=abc123"},
+			{ContentType: "image/jpeg", URL: "https://storage.example.com/a.jpg"},
+		}, "https://storage.example.com/a.jpg"},
+		{"youtube watch", []models.MediaFile{{ContentType: "video/mp4", URL: "https://www.youtube.com/watch?v=abc123"}}, "https://i.ytimg.com/vi/abc123/hqdefault.jpg"},
+		{"youtu.be", []models.MediaFile{{ContentType: "video/mp4", URL: "https://youtu.be/xyz789"}}, "https://i.ytimg.com/vi/xyz789/hqdefault.jpg"},
+		{"quarantined image skipped", []models.MediaFile{{ContentType: "image/png", URL: "https://storage.example.com/b.png", State: models.MediaQuarantined}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reportThumbnail(&models.TrafficReport{MediaFiles: tt.media}); got != tt.want {
+				t.Errorf("reportThumbnail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// deleteCommentStore holds comments and their authors, and sums priority adjustments
+type deleteCommentStore struct {
+	storage.Client
+	comments map[string]*models.Comment
+	users    map[string]*models.User
+	priority int
+}
+
+func (s *deleteCommentStore) GetCommentByID(ctx context.Context, commentID string) (*models.Comment, error) {
+	comment, ok := s.comments[commentID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return comment, nil
+}
+
+func (s *deleteCommentStore) DeleteComment(ctx context.Context, commentID, userID string) error {
+	delete(s.comments, commentID)
+	return nil
+}
+
+func (s *deleteCommentStore) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	user, ok := s.users[userID]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return user, nil
+}
+
+func (s *deleteCommentStore) AdjustReportPriority(ctx context.Context, reportID string, delta int) error {
+	s.priority += delta
+	return nil
+}
+
+func TestReportsHandler_DeleteComment_ReversesOnlyCountedComments(t *testing.T) {
+	const (
+		visibleID  = "6f1c0a52-3b7e-4d8a-9c1e-2f4b5a6d7e80"
+		flaggedID  = "7a2d1b63-4c8f-4e9b-8d2f-3a5c6b7e8f91"
+		shadowedID = "8b3e2c74-5d90-4fac-9e30-4b6d7c8f9a02"
+	)
+	tests := []struct {
+		name         string
+		comment      *models.Comment
+		wantPriority int
+	}{
+		{"visible comment", &models.Comment{ID: visibleID, ReportID: "r", UserID: "user-1"}, -ScoreComment},
+		{"flagged comment", &models.Comment{ID: flaggedID, ReportID: "r", UserID: "user-1", Flagged: true}, 0},
+		{"shadow-banned author", &models.Comment{ID: shadowedID, ReportID: "r", UserID: "banned"}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &deleteCommentStore{
+				comments: map[string]*models.Comment{tt.comment.ID: tt.comment},
+				users: map[string]*models.User{
+					"user-1": {ID: "user-1"},
+					"banned": {ID: "banned", ShadowBanned: true},
+				},
+			}
+			h := NewReportsHandler(store, nil, nil)
+			router := gin.New()
+			router.DELETE("/v1/reports/:id/comments/:commentId", func(c *gin.Context) {
+				c.Set(middleware.UserContextKey, &models.UserInfo{Subject: tt.comment.UserID})
+				h.DeleteComment(c)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/reports/r/comments/"+tt.comment.ID, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d: %s", w.Code, w.Body.String())
+			}
+			if store.priority != tt.wantPriority {
+				t.Errorf("priority adjusted by %d, want %d", store.priority, tt.wantPriority)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+const (
+	// defaultResearchQuota is the daily request quota of tokens created without one
+	defaultResearchQuota = 1000
+	// maxResearchUsageDays is how far back GET .../usage can look
+	maxResearchUsageDays = 366
+)
+
+// CreateResearchToken handles POST /v1/admin/research-tokens
+// Issues a read-only research token. The secret is only ever returned here.
+func (h *AuthHandler) CreateResearchToken(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req models.CreateResearchTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	secret, hash, err := auth.NewResearchToken()
+	if err != nil {
+		log.Printf("Failed to generate research token: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create research token")
+		return
+	}
+	token := &models.ResearchToken{
+		ID:         uuid.New().String(),
+		Name:       strings.TrimSpace(req.Name),
+		Email:      strings.ToLower(strings.TrimSpace(req.Email)),
+		TokenHash:  hash,
+		DailyQuota: req.DailyQuota,
+		CreatedBy:  user.Subject,
+	}
+	if token.DailyQuota == 0 {
+		token.DailyQuota = defaultResearchQuota
+	}
+	if req.ExpiresInDays > 0 {
+		expires := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		token.ExpiresAt = &expires
+	}
+
+	if err := h.storage.CreateResearchToken(c.Request.Context(), token); err != nil {
+		log.Printf("Failed to create research token for %s: %v", token.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create research token")
+		return
+	}
+	h.audit(c, user.Subject, models.AuditResearchTokenCreated, token.ID,
+		"name="+token.Name+" email="+token.Email+" quota="+strconv.Itoa(token.DailyQuota))
+
+	log.Printf("Research token %s for %s created by %s", token.ID, token.Email, user.Email)
+
+	c.JSON(http.StatusCreated, models.CreateResearchTokenResponse{Token: *token, Secret: secret})
+}
+
+// ListResearchTokens handles GET /v1/admin/research-tokens
+// Returns every research token, revoked and expired ones included, with its request counts.
+func (h *AuthHandler) ListResearchTokens(c *gin.Context) {
+	tokens, err := h.storage.ListResearchTokens(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list research tokens: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to list research tokens")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+		"count":  len(tokens),
+	})
+}
+
+// RevokeResearchToken handles DELETE /v1/admin/research-tokens/:id
+func (h *AuthHandler) RevokeResearchToken(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	tokenID := c.Param("id")
+	if !validation.ValidateUUID(tokenID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid research token ID")
+		return
+	}
+
+	if err := h.storage.RevokeResearchToken(c.Request.Context(), tokenID); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "research token not found")
+			return
+		}
+		log.Printf("Failed to revoke research token %s: %v", tokenID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to revoke research token")
+		return
+	}
+	h.audit(c, user.Subject, models.AuditResearchTokenRevoked, tokenID, "")
+
+	log.Printf("Research token %s revoked by %s", tokenID, user.Email)
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokenId": tokenID,
+		"revoked": true,
+	})
+}
+
+// GetResearchTokenUsage handles GET /v1/admin/research-tokens/:id/usage?days=30
+// Returns the token's requests served and rejected per UTC day, newest first.
+// Days without requests are left out.
+func (h *AuthHandler) GetResearchTokenUsage(c *gin.Context) {
+	tokenID := c.Param("id")
+	if !validation.ValidateUUID(tokenID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid research token ID")
+		return
+	}
+
+	days := 30
+	if s := c.Query("days"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxResearchUsageDays {
+			apierror.RespondField(c, "days", apierror.ReasonOutOfRange, "days must be between 1 and "+strconv.Itoa(maxResearchUsageDays))
+			return
+		}
+		days = n
+	}
+
+	since := models.UsageDay(time.Now().AddDate(0, 0, 1-days))
+	usage, err := h.storage.ListResearchTokenUsage(c.Request.Context(), tokenID, since)
+	if err != nil {
+		log.Printf("Failed to get usage of research token %s: %v", tokenID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get research token usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokenId": tokenID,
+		"usage":   usage,
+		"count":   len(usage),
+	})
+}
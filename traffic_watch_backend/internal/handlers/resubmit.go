@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// DefaultMaxResubmissions is how many times an owner may send a rejected report back to review
+const DefaultMaxResubmissions = 2
+
+// SetMaxResubmissions sets how many times a rejected report may be resubmitted; zero disables resubmission
+func (h *ReportsHandler) SetMaxResubmissions(n int) {
+	h.maxResubmissions = max(n, 0)
+}
+
+// ResubmitReport handles POST /v1/reports/:id/resubmit
+// Lets the owner of a rejected report fix it and send it back to the review
+// queue. The body may carry edits (see models.ResubmitReportRequest); fields
+// left empty keep their value. Each report can be resubmitted a limited number
+// of times, and every resubmission is recorded in its review history.
+func (h *ReportsHandler) ResubmitReport(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	// An empty body resubmits without edits
+	var req models.ResubmitReportRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.storage.GetReportByIDAndUser(ctx, reportID, user.Subject)
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+	if report.Status != models.StatusReviewedFail {
+		apierror.Respond(c, http.StatusConflict, apierror.Conflict, "only rejected reports can be resubmitted")
+		return
+	}
+
+	history, err := h.storage.ListReportReviews(ctx, reportID)
+	if err != nil {
+		log.Printf("Failed to load review history of report %s: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch review history")
+		return
+	}
+	left := h.maxResubmissions - models.Resubmissions(history)
+	if left <= 0 {
+		apierror.Respond(c, http.StatusConflict, apierror.QuotaExceeded, "report has been resubmitted the maximum number of times")
+		return
+	}
+
+	// The report goes back to review either way, so a flag-action match needs no extra hold
+	if _, ok := h.applyWordFilter(c, user, &req.Title, &req.Description, &req.Injuries, &req.Note); !ok {
+		return
+	}
+	applyResubmitEdits(report, &req)
+	if report.Title == "" || report.Description == "" {
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "title and description cannot be empty")
+		return
+	}
+	h.applyPIIPolicy(report)
+	report.Status = models.StatusSubmitted
+
+	resubmitted := events.Event{Type: events.ReportResubmitted, ReportID: reportID, Actor: user.Email}
+	err = h.changeWithEvent(ctx, resubmitted, func(tx storage.Client) error {
+		if err := tx.UpdateReport(ctx, report); err != nil {
+			return err
+		}
+		return tx.CreateReportReview(ctx, &models.ReportReview{
+			ID:       uuid.New().String(),
+			ReportID: reportID,
+			Status:   models.StatusSubmitted,
+			Reason:   req.Note,
+			Actor:    user.Email,
+		})
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidStatusTransition) {
+			apierror.Respond(c, http.StatusConflict, apierror.Conflict, "only rejected reports can be resubmitted")
+			return
+		}
+		log.Printf("Failed to resubmit report %s: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to resubmit report")
+		return
+	}
+
+	log.Printf("Report %s resubmitted by %s (%d resubmissions left)", reportID, user.Email, left-1)
+	c.JSON(http.StatusOK, models.ResubmitReportResponse{Report: report, ResubmissionsLeft: left - 1})
+}
+
+// applyResubmitEdits copies the fields set in req onto the report. A severity
+// is only guessed from edited injuries when the report has none.
+func applyResubmitEdits(report *models.TrafficReport, req *models.ResubmitReportRequest) {
+	if req.Title != "" {
+		report.Title = req.Title
+	}
+	if req.Description != "" {
+		report.Description = req.Description
+	}
+	if len(req.RoadUsages) > 0 {
+		report.RoadUsages = req.RoadUsages
+	}
+	if len(req.EventTypes) > 0 {
+		report.EventTypes = req.EventTypes
+	}
+	if req.City != "" {
+		report.City = req.City
+		normalizeCity(report)
+	}
+	if req.Injuries != "" {
+		report.Injuries = req.Injuries
+	}
+	switch {
+	case req.Severity != "":
+		report.Severity = req.Severity
+	case report.Severity == "":
+		report.Severity = models.SeverityFromInjuries(report.Injuries)
+	}
+}
+
+// ListReportReviews handles GET /v1/reports/:id/reviews
+// Returns a report's review history, oldest first: each admin decision with its
+// reason, and each resubmission by the owner. Only the owner and admins can see it.
+func (h *ReportsHandler) ListReportReviews(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	report, err := h.storage.GetReport(c.Request.Context(), reportID)
+	if err != nil || report.Status == models.StatusDeleted || (report.UserID != user.Subject && !isAdmin(c)) {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+
+	reviews, err := h.storage.ListReportReviews(c.Request.Context(), reportID)
+	if err != nil {
+		log.Printf("Failed to list review history of report %s: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch review history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": reviews,
+		"count":   len(reviews),
+	})
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/geo"
+	"donzhit_me_backend/internal/metadata"
+	"donzhit_me_backend/internal/models"
+)
+
+// Consistency thresholds for review hints. The time tolerance absorbs the
+// missing timezone on EXIF capture times.
+const (
+	hintTimeTolerance   = 72 * time.Hour
+	hintDistanceLimitKm = 50.0
+)
+
+// reviewHints compares each media file's capture time and GPS against the
+// report's dateTime, coordinates and state. Media without metadata, or whose
+// metadata was stripped at the submitter's request, yields no hints.
+func reviewHints(report *models.TrafficReport) []models.ReviewHint {
+	var hints []models.ReviewHint
+	for _, media := range report.MediaFiles {
+		if len(media.Metadata) == 0 {
+			continue
+		}
+		summary := metadata.Summarize(media.Metadata)
+
+		if captured, ok := summary.Timestamps.CapturedAt(); ok {
+			if gap := report.DateTime.Sub(captured).Abs(); gap > hintTimeTolerance {
+				direction := "before"
+				if captured.After(report.DateTime) {
+					direction = "after"
+				}
+				hints = append(hints, models.ReviewHint{
+					Kind:    models.HintMediaTimeMismatch,
+					MediaID: media.ID,
+					Message: fmt.Sprintf("%s was captured %s %s the reported incident time", media.FileName, formatGap(gap), direction),
+				})
+			}
+		}
+
+		if summary.GPS == nil {
+			continue
+		}
+		lat, lng := summary.GPS.Latitude, summary.GPS.Longitude
+		if report.Latitude != nil && report.Longitude != nil {
+			if d := geo.DistanceKm(lat, lng, *report.Latitude, *report.Longitude); d > hintDistanceLimitKm {
+				hints = append(hints, models.ReviewHint{
+					Kind:    models.HintMediaLocationMismatch,
+					MediaID: media.ID,
+					Message: fmt.Sprintf("%s was captured %.0f km from the reported location", media.FileName, d),
+				})
+			}
+		}
+		if in, ok := geo.InRegion(report.State, lat, lng); ok && !in {
+			hints = append(hints, models.ReviewHint{
+				Kind:    models.HintMediaRegionMismatch,
+				MediaID: media.ID,
+				Message: fmt.Sprintf("%s was captured outside %s", media.FileName, report.State),
+			})
+		}
+	}
+	return hints
+}
+
+// formatGap renders a duration in days, or hours when under two days
+func formatGap(d time.Duration) string {
+	if d < 48*time.Hour {
+		return fmt.Sprintf("%.0f hours", d.Hours())
+	}
+	return fmt.Sprintf("%.0f days", d.Hours()/24)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestReviewHints(t *testing.T) {
+	lat, lng := 41.4993, -81.6944 // Cleveland
+	report := &models.TrafficReport{
+		DateTime:  time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+		State:     "Ohio",
+		Latitude:  &lat,
+		Longitude: &lng,
+		MediaFiles: []models.MediaFile{
+			{ID: "consistent", FileName: "a.jpg", Metadata: map[string]interface{}{
+				"gps_latitude": 41.5, "gps_longitude": -81.7, "date_time_original": "2024-06-01T09:58:00",
+			}},
+			{ID: "old", FileName: "b.jpg", Metadata: map[string]interface{}{
+				"date_time_original": "2024-03-01T10:00:00",
+			}},
+			{ID: "elsewhere", FileName: "c.mp4", Metadata: map[string]interface{}{
+				"gps_latitude": 41.88, "gps_longitude": -87.63, // Chicago
+			}},
+			{ID: "stripped", FileName: "d.jpg"},
+		},
+	}
+
+	hints := reviewHints(report)
+	got := make(map[string][]string)
+	for _, h := range hints {
+		got[h.MediaID] = append(got[h.MediaID], h.Kind)
+	}
+
+	if len(got["consistent"]) != 0 || len(got["stripped"]) != 0 {
+		t.Errorf("expected no hints for consistent or stripped media, got %v", got)
+	}
+	if len(got["old"]) != 1 || got["old"][0] != models.HintMediaTimeMismatch {
+		t.Errorf("expected time mismatch for old media, got %v", got["old"])
+	}
+	want := []string{models.HintMediaLocationMismatch, models.HintMediaRegionMismatch}
+	if len(got["elsewhere"]) != 2 || got["elsewhere"][0] != want[0] || got["elsewhere"][1] != want[1] {
+		t.Errorf("expected %v for out-of-state media, got %v", want, got["elsewhere"])
+	}
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// SetUserRole handles PUT /v1/admin/users/:id/role
+// Grants or revokes admin (or any other role). Admins can't change their own
+// role, so the last admin can't lock everyone out. Bootstrap admins from
+// ADMIN_EMAILS get the admin role back on their next sign-in.
+func (h *AuthHandler) SetUserRole(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	userID := c.Param("id")
+	if userID == "" || len(userID) > 255 {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid user ID")
+		return
+	}
+	if userID == user.Subject {
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "cannot change your own role")
+		return
+	}
+
+	var req models.SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+	if !req.Role.Valid() {
+		apierror.RespondField(c, "role", apierror.ReasonNotAllowed, "role must be viewer, contributor, moderator or admin")
+		return
+	}
+
+	if err := h.storage.UpdateUserRole(c.Request.Context(), userID, req.Role); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "user not found")
+			return
+		}
+		log.Printf("Failed to update role for user %s: %v", userID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update role")
+		return
+	}
+
+	log.Printf("User %s role set to %s by %s", userID, req.Role, user.Email)
+	h.audit(c, user.Subject, models.AuditRoleChanged, userID, "role="+string(req.Role))
+
+	c.JSON(http.StatusOK, gin.H{
+		"userId": userID,
+		"role":   req.Role,
+	})
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/pagination"
+)
+
+// Admin search query length bounds, in characters
+const (
+	MinSearchQueryLength = 2
+	MaxSearchQueryLength = 200
+)
+
+// AdminSearch handles GET /v1/admin/search?q=
+// Searches reports (title, description, location, submitter email) and users (email),
+// returning up to ?limit= results per group. Shadow-banned users' reports are included.
+func (h *ReportsHandler) AdminSearch(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	query := strings.TrimSpace(c.Query("q"))
+	switch n := utf8.RuneCountInString(query); {
+	case n == 0:
+		apierror.RespondField(c, "q", apierror.ReasonRequired, "q is required")
+		return
+	case n < MinSearchQueryLength:
+		apierror.RespondField(c, "q", apierror.ReasonTooShort, "q must be at least 2 characters")
+		return
+	case n > MaxSearchQueryLength:
+		apierror.RespondField(c, "q", apierror.ReasonTooLong, "q must be at most 200 characters")
+		return
+	}
+	limit, err := pagination.ParseLimit(c.Query("limit"))
+	if err != nil {
+		apierror.RespondField(c, "limit", apierror.ReasonOutOfRange, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	hits, err := h.storage.SearchReports(ctx, query, limit)
+	if err != nil {
+		log.Printf("Failed to search reports for %q: %v", query, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to search reports")
+		return
+	}
+	users, err := h.storage.SearchUsers(ctx, query, limit)
+	if err != nil {
+		log.Printf("Failed to search users for %q: %v", query, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to search users")
+		return
+	}
+
+	for i := range hits {
+		h.refreshReportMediaURLs(ctx, &hits[i].Report, h.mediaURLs.Admin)
+	}
+
+	c.JSON(http.StatusOK, models.AdminSearchResponse{
+		Query:   query,
+		Reports: models.ReportSearchGroup{Count: len(hits), Results: hits},
+		Users:   models.UserSearchGroup{Count: len(users), Results: users},
+	})
+}
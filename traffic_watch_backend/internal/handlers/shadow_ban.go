@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// includeShadowBanned reports whether an admin listing asked for content by
+// shadow-banned users (?includeShadowBanned=true), which is hidden by default
+func includeShadowBanned(c *gin.Context) bool {
+	return c.Query("includeShadowBanned") == "true"
+}
+
+// SetUserShadowBan handles PUT /v1/admin/users/:id/shadow-ban
+// Sets or clears a user's shadow ban. The user's content stays visible to them
+// but is dropped from public listings and default admin queues.
+func (h *ReportsHandler) SetUserShadowBan(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	userID := c.Param("id")
+	if userID == "" || len(userID) > 255 {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid user ID")
+		return
+	}
+	if userID == user.Subject {
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "cannot shadow-ban yourself")
+		return
+	}
+
+	var req models.SetShadowBanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	if err := h.storage.SetUserShadowBanned(c.Request.Context(), userID, req.ShadowBanned); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "user not found")
+			return
+		}
+		log.Printf("Failed to update shadow ban for user %s: %v", userID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update shadow ban")
+		return
+	}
+
+	log.Printf("User %s shadow ban set to %v by %s", userID, req.ShadowBanned, user.Email)
+	// The public feed may include (or be missing) this user's reports
+	h.InvalidateFeedCache()
+
+	c.JSON(http.StatusOK, gin.H{
+		"userId":       userID,
+		"shadowBanned": req.ShadowBanned,
+	})
+}
+
+// SetUserSuspended handles PUT /v1/admin/users/:id/suspend
+// Suspends a user, or lifts a suspension. A suspended user is signed out, can't
+// sign back in, and their reports and comments are hidden until reinstated.
+func (h *ReportsHandler) SetUserSuspended(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	userID := c.Param("id")
+	if userID == "" || len(userID) > 255 {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid user ID")
+		return
+	}
+	if userID == user.Subject {
+		apierror.Respond(c, http.StatusBadRequest, apierror.Validation, "cannot suspend yourself")
+		return
+	}
+
+	var req models.SetSuspendedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	var err error
+	if req.Suspended {
+		err = h.storage.DeactivateUser(c.Request.Context(), userID, models.DeactivatedByAdmin)
+	} else {
+		err = h.storage.ReactivateUser(c.Request.Context(), userID)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "user not found")
+			return
+		}
+		log.Printf("Failed to update suspension for user %s: %v", userID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update suspension")
+		return
+	}
+
+	log.Printf("User %s suspension set to %v by %s", userID, req.Suspended, user.Email)
+	h.InvalidateFeedCache()
+
+	c.JSON(http.StatusOK, gin.H{
+		"userId":    userID,
+		"suspended": req.Suspended,
+	})
+}
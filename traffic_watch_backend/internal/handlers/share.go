@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+const (
+	// shareDescriptionLength is where og:description is cut; platforms show about this much
+	shareDescriptionLength = 200
+	// shareMaxAge is how long crawlers and CDNs may cache a share page
+	shareMaxAge   = 300
+	shareSiteName = "DonzHit.me"
+	// thumbnailMaxAge is how long clients may cache the redirect to a signed thumbnail URL
+	thumbnailMaxAge = 300
+)
+
+// SetPublicAPIURL sets this API's public base URL (e.g. https://api.donzhit.me),
+// which links that must outlive a signed URL, like share page images, are built
+// from. Without it they are built from the request's host.
+func (h *ReportsHandler) SetPublicAPIURL(apiURL string) {
+	h.publicAPIURL = strings.TrimSuffix(apiURL, "/")
+}
+
+// apiBaseURL returns the public base URL of this API
+func (h *ReportsHandler) apiBaseURL(c *gin.Context) string {
+	if h.publicAPIURL != "" {
+		return h.publicAPIURL
+	}
+	scheme := "https"
+	if c.Request.TLS == nil && c.GetHeader("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// sharePage is what the share page template renders
+type sharePage struct {
+	SiteName    string
+	Title       string
+	Description string
+	Image       string // Stable API link to the preview image; empty when there is none
+	Link        string // The report on the web app
+}
+
+// sharePageTemplate carries the Open Graph and Twitter card tags crawlers read, and
+// sends people who open the share link on to the web app
+var sharePageTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} - {{.SiteName}}</title>
+<meta name="description" content="{{.Description}}">
+<meta property="og:type" content="article">
+<meta property="og:site_name" content="{{.SiteName}}">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Description}}">
+<meta property="og:url" content="{{.Link}}">
+{{- if .Image}}
+<meta property="og:image" content="{{.Image}}">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:image" content="{{.Image}}">
+{{- else}}
+<meta name="twitter:card" content="summary">
+{{- end}}
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Description}}">
+<link rel="canonical" href="{{.Link}}">
+<meta http-equiv="refresh" content="0; url={{.Link}}">
+</head>
+<body>
+<p><a href="{{.Link}}">{{.Title}}</a></p>
+</body>
+</html>
+`))
+
+// GetReportSharePage handles GET /v1/public/reports/:id/share
+// Serves a small HTML page with Open Graph and Twitter card metadata for a public
+// report, so links shared on social platforms render a rich preview. The preview
+// image is the report's thumbnail (as in the widget), else its static map, linked
+// through this API's redirects rather than signed URLs, since platforms cache
+// preview images far longer than a signed URL lives. Browsers following the link
+// are redirected to the report on the web app.
+func (h *ReportsHandler) GetReportSharePage(c *gin.Context) {
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	// Shares of a merged duplicate preview the canonical report
+	ctx := c.Request.Context()
+	report, err := h.publicReport(ctx, reportID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Failed to get report %s for share page: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch report")
+		return
+	}
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+
+	h.refreshReportMediaURLs(ctx, report, h.mediaURLs.Public)
+	reports := []models.TrafficReport{*report}
+	h.maskPublicPII(reports)
+	report = &reports[0]
+
+	page := sharePage{
+		SiteName:    shareSiteName,
+		Title:       report.Title,
+		Description: shareDescription(report),
+		Link:        h.publicAppURL + "/reports/" + url.PathEscape(report.ID),
+	}
+	imageBase := h.apiBaseURL(c) + "/v1/public/reports/" + url.PathEscape(report.ID)
+	if reportThumbnail(report) != "" {
+		page.Image = imageBase + "/thumbnail"
+	} else if report.MapImageURL != "" {
+		page.Image = imageBase + "/map"
+	}
+
+	var body strings.Builder
+	if err := sharePageTemplate.Execute(&body, page); err != nil {
+		log.Printf("Failed to render share page for report %s: %v", report.ID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to render share page")
+		return
+	}
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(shareMaxAge))
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(body.String()))
+}
+
+// GetReportThumbnail handles GET /v1/public/reports/:id/thumbnail
+// Redirects to the current URL of a public report's thumbnail, as chosen for the
+// widget: a freshly signed URL of its first image, else its video's YouTube
+// thumbnail. Share pages link here so their previews keep loading.
+func (h *ReportsHandler) GetReportThumbnail(c *gin.Context) {
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.publicReport(ctx, reportID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("Failed to get report %s for thumbnail: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get thumbnail")
+		return
+	}
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "thumbnail not found")
+		return
+	}
+
+	h.refreshReportMediaURLs(ctx, report, h.mediaURLs.Public)
+	thumbnail := reportThumbnail(report)
+	if thumbnail == "" {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "thumbnail not found")
+		return
+	}
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(thumbnailMaxAge))
+	c.Redirect(http.StatusFound, thumbnail)
+}
+
+// shareDescription is the report's place followed by its description, with whitespace
+// collapsed and cut to shareDescriptionLength characters
+func shareDescription(r *models.TrafficReport) string {
+	place := r.State
+	if r.City != "" {
+		place = r.City + ", " + r.State
+	}
+	text := strings.Join(strings.Fields(r.Description), " ")
+	if place != "" {
+		text = place + ": " + text
+	}
+	runes := []rune(text)
+	if len(runes) <= shareDescriptionLength {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:shareDescriptionLength-1])) + "…"
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func TestReportsHandler_GetReportSharePage(t *testing.T) {
+	const (
+		photoID    = "6f1c0a52-3b7e-4d8a-9c1e-2f4b5a6d7e80"
+		mapOnlyID  = "7a2d1b63-4c8f-4e9b-8d2f-3a5c6b7e8f91"
+		embargoID  = "8b3e2c74-5d90-4fac-9e30-4b6d7c8f9a02"
+		rejectedID = "9c4f3d85-6ea1-4b0d-8f41-5c7e8d9a0b13"
+	)
+	later := time.Now().Add(time.Hour)
+	store := &mapStore{reports: map[string]*models.TrafficReport{
+		photoID: {
+			ID: photoID, UserID: "u", Status: models.StatusReviewedPass, City: "Dayton", State: "Ohio",
+			Title:       `Ran the "red" light <again>`,
+			Description: "Northbound on Main St,\n\nnearly hit a cyclist. " + strings.Repeat("More detail. ", 40),
+			MediaFiles:  []models.MediaFile{{ID: "photo", ContentType: "image/jpeg", State: models.MediaReady}},
+		},
+		mapOnlyID:  {ID: mapOnlyID, UserID: "u", Status: models.StatusArchived, State: "Iowa", Title: "Blocked crosswalk", MapImagePath: "users/u/reports/" + mapOnlyID + "/map"},
+		embargoID:  {ID: embargoID, Status: models.StatusReviewedPass, Title: "Not yet", PublishAt: &later},
+		rejectedID: {ID: rejectedID, Status: models.StatusReviewedFail, Title: "Rejected"},
+	}}
+	h := NewReportsHandler(store, &mockBlobStore{}, nil)
+	h.SetPublicAppURL("https://donzhit.example/")
+	h.SetPublicAPIURL("https://api.donzhit.example")
+	router := gin.New()
+	router.GET("/v1/public/reports/:id/share", h.GetReportSharePage)
+	router.GET("/v1/public/reports/:id/thumbnail", h.GetReportThumbnail)
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	get := func(id string) *httptest.ResponseRecorder {
+		return serve("/v1/public/reports/" + id + "/share")
+	}
+
+	w := get(photoID)
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("status = %d, content type %q: %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+	page := w.Body.String()
+	for _, want := range []string{
+		`<meta property="og:title" content="Ran the &#34;red&#34; light &lt;again&gt;">`,
+		`<meta property="og:description" content="Dayton, Ohio: Northbound on Main St, nearly hit a cyclist. More detail.`,
+		`<meta property="og:image" content="https://api.donzhit.example/v1/public/reports/` + photoID + `/thumbnail">`,
+		`<meta property="og:url" content="https://donzhit.example/reports/` + photoID + `">`,
+		`<meta name="twitter:card" content="summary_large_image">`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("share page is missing %s:\n%s", want, page)
+		}
+	}
+	if desc := page[strings.Index(page, `og:description" content="`):]; !strings.Contains(desc[:strings.Index(desc, `">`)], "…") {
+		t.Errorf("expected the long description to be cut, got %s", desc[:strings.Index(desc, `">`)])
+	}
+
+	// The preview image is a stable link that redirects to a freshly signed URL
+	w = serve("/v1/public/reports/" + photoID + "/thumbnail")
+	if got, want := w.Header().Get("Location"), "https://blobs.test/users/u/reports/"+photoID+"/photo?signed"; w.Code != http.StatusFound || got != want {
+		t.Errorf("thumbnail = %d, Location %q, want %q", w.Code, got, want)
+	}
+	if w := serve("/v1/public/reports/" + mapOnlyID + "/thumbnail"); w.Code != http.StatusNotFound {
+		t.Errorf("thumbnail of a report without images = %d, want 404", w.Code)
+	}
+
+	// Without a photo the static map is the preview image
+	if page := get(mapOnlyID).Body.String(); !strings.Contains(page, `og:image" content="https://api.donzhit.example/v1/public/reports/`+mapOnlyID+`/map"`) {
+		t.Errorf("expected the map as the preview image:\n%s", page)
+	}
+
+	for _, id := range []string{embargoID, rejectedID, "0d5a4e96-7fb2-4c1e-9a52-6d8f9e0a1c24"} {
+		if w := get(id); w.Code != http.StatusNotFound {
+			t.Errorf("report %s: status = %d, want 404", id, w.Code)
+		}
+		if w := serve("/v1/public/reports/" + id + "/thumbnail"); w.Code != http.StatusNotFound {
+			t.Errorf("thumbnail of report %s: status = %d, want 404", id, w.Code)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/pagination"
+)
+
+// Business logic shared by the /v1 and /v2 handlers. Versioned handlers only
+// parse requests and shape responses; anything that decides what a caller
+// sees lives here.
+
+// viewerID returns the signed-in user's ID, or "" for anonymous requests
+func viewerID(c *gin.Context) string {
+	if user, ok := middleware.GetUserFromContext(c); ok && user != nil {
+		return user.Subject
+	}
+	return ""
+}
+
+// approvedFeed returns the public feed (pinned, priority, then newest first) with fresh media URLs,
+// served from the feed cache when enabled. Embargoed reports are left out by storage, and
+// personal information is masked under the mask PII policy.
+func (h *ReportsHandler) approvedFeed(ctx context.Context) ([]models.TrafficReport, error) {
+	if h.feedCache != nil {
+		if reports, ok := h.feedCache.get(); ok {
+			return reports, nil
+		}
+	}
+
+	reports, err := h.storage.ListApprovedReports(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refresh signed URLs for GCS media files (skip YouTube URLs)
+	h.refreshMediaURLs(ctx, reports, h.mediaURLs.Public)
+	h.maskPublicPII(reports)
+	if h.feedCache != nil {
+		// Don't cache past the moment the next embargoed report should appear
+		nextPublish, err := h.storage.NextScheduledPublication(ctx)
+		if err != nil {
+			log.Printf("Failed to get next scheduled publication, not caching feed: %v", err)
+		} else {
+			h.feedCache.set(reports, nextPublish)
+		}
+	}
+	return reports, nil
+}
+
+// reportSort reads the ?sort= and ?order= of a report list, defaulting to defaultField,
+// descending. On error it also returns the name of the offending parameter.
+func reportSort(c *gin.Context, defaultField string) (models.ReportSort, string, error) {
+	sort, err := models.ParseReportSort(c.Query("sort"), c.Query("order"), defaultField)
+	if errors.Is(err, models.ErrInvalidSortOrder) {
+		return sort, "order", err
+	}
+	return sort, "sort", err
+}
+
+// offsetPage reads the ?limit= and ?offset= of an offset-paginated admin list. The
+// limit is capped at pagination.MaxLimit. On error it also returns the name of the
+// offending parameter.
+func offsetPage(c *gin.Context) (limit, offset int, param string, err error) {
+	if limit, err = pagination.ParseLimit(c.Query("limit")); err != nil {
+		return 0, 0, "limit", err
+	}
+	if offset, err = pagination.ParseOffset(c.Query("offset")); err != nil {
+		return 0, 0, "offset", err
+	}
+	return limit, offset, "", nil
+}
+
+// defaultFeedSort is the order the public feed is stored and cached in
+var defaultFeedSort = models.ReportSort{Field: models.SortPriority}
+
+// sortedFeed returns the feed in the requested order with pinned reports kept first.
+// Other orderings are a sorted copy so the cached feed keeps its default order.
+func sortedFeed(reports []models.TrafficReport, sort models.ReportSort) []models.TrafficReport {
+	if sort == defaultFeedSort {
+		return reports
+	}
+	sorted := slices.Clone(reports)
+	slices.SortStableFunc(sorted, func(a, b models.TrafficReport) int {
+		if a.Pinned != b.Pinned {
+			if a.Pinned {
+				return -1
+			}
+			return 1
+		}
+		return sort.Compare(a, b)
+	})
+	return sorted
+}
+
+// severityFeed returns the feed reports with one of severities, or the feed
+// itself when severities is empty. The feed is shared, so it isn't filtered in place.
+func severityFeed(reports []models.TrafficReport, severities []string) []models.TrafficReport {
+	if len(severities) == 0 {
+		return reports
+	}
+	filtered := make([]models.TrafficReport, 0, len(reports))
+	for _, r := range reports {
+		if slices.Contains(severities, r.Severity) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// ownReportStatuses are the statuses the owner's report list can be filtered to with ?status=
+var ownReportStatuses = []string{models.StatusSubmitted, models.StatusReviewedPass, models.StatusReviewedFail}
+
+// ownReportStatus reads the ?status= filter of the owner's report list ("" for all)
+// and reports whether it is one of ownReportStatuses
+func ownReportStatus(c *gin.Context) (string, bool) {
+	status := c.Query("status")
+	return status, status == "" || slices.Contains(ownReportStatuses, status)
+}
+
+// ownReports returns a user's non-deleted reports in sort order, with fresh media URLs
+// and hasUnseenUpdate set on those whose status changed since the user last looked.
+// A non-empty status limits the list to reports in that status.
+func (h *ReportsHandler) ownReports(ctx context.Context, userID, status string, sort models.ReportSort) ([]models.TrafficReport, error) {
+	reports, err := h.storage.ListUserReports(ctx, userID, status, sort)
+	if err != nil {
+		return nil, err
+	}
+	if reports == nil {
+		reports = []models.TrafficReport{}
+	}
+	for i := range reports {
+		reports[i].HasUnseenUpdate = reports[i].UnseenStatusChange()
+	}
+
+	// Refresh signed URLs for GCS media files (skip YouTube URLs)
+	h.refreshMediaURLs(ctx, reports, h.mediaURLs.Owner)
+	return reports, nil
+}
+
+// visibleComments returns a report's comments as viewerID may see them, oldest first.
+// Authors see their own comments even when shadow-banned; signed-in viewers don't see
+// users they blocked. Comments on a merged duplicate are read from the canonical report.
+func (h *ReportsHandler) visibleComments(ctx context.Context, reportID, viewerID string) ([]models.Comment, error) {
+	reportID = h.canonicalReportID(ctx, reportID)
+	return h.storage.GetComments(ctx, reportID, viewerID)
+}
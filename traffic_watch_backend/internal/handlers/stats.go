@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+)
+
+const (
+	defaultStatsDays = 30
+	maxStatsDays     = 365
+)
+
+// ============================================================================
+// Statistics Endpoints
+// ============================================================================
+
+// GetReportStats handles GET /v1/public/stats?days=N
+// Returns pre-aggregated counts of approved reports per day (last N days), state and event type
+func (h *ReportsHandler) GetReportStats(c *gin.Context) {
+	days := defaultStatsDays
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > maxStatsDays {
+			apierror.RespondField(c, "days", apierror.ReasonOutOfRange, "days must be an integer between 1 and 365")
+			return
+		}
+		days = parsed
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -(days - 1))
+	stats, err := h.storage.GetReportStats(c.Request.Context(), since)
+	if err != nil {
+		log.Printf("Failed to get report stats: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch statistics")
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
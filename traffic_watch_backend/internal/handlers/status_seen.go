@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// MarkReportSeen handles POST /v1/reports/:id/seen
+// Clears the report's hasUnseenUpdate flag in the owner's list (requires auth)
+func (h *ReportsHandler) MarkReportSeen(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	if err := h.storage.MarkReportStatusSeen(c.Request.Context(), reportID, user.Subject); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+			return
+		}
+		log.Printf("Failed to mark report %s seen: %v", reportID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to mark report seen")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "report marked seen",
+	})
+}
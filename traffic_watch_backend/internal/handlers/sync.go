@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// SyncReports handles POST /v1/reports/sync
+// Upserts reports queued by an offline client. Every item needs a
+// client-generated ID; items already stored are reported as duplicates, so a
+// batch can be resent after a dropped connection. Items are resolved
+// independently and results come back in request order.
+func (h *ReportsHandler) SyncReports(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	var req models.SyncReportsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	results := make([]models.SyncResult, len(req.Reports))
+	counts := make(map[string]int)
+	for i := range req.Reports {
+		results[i] = h.syncReport(c.Request.Context(), user, &req.Reports[i])
+		counts[results[i].Status]++
+	}
+
+	log.Printf("Sync from %s: %d reports %v", user.Email, len(results), counts)
+
+	c.JSON(http.StatusOK, gin.H{
+		"results": results,
+		"count":   len(results),
+	})
+}
+
+// syncReport stores one queued report and reports the outcome
+func (h *ReportsHandler) syncReport(ctx context.Context, user *models.UserInfo, req *models.CreateReportRequest) models.SyncResult {
+	result := models.SyncResult{ID: req.ID}
+	if req.ID == "" {
+		result.Status = models.SyncInvalid
+		result.Message = "id is required for sync"
+		return result
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		result.Status = models.SyncInvalid
+		result.Message = err.Error()
+		return result
+	}
+
+	req.Title = validation.SanitizeText(req.Title)
+	req.Description = validation.SanitizeText(req.Description)
+	req.Injuries = validation.SanitizeText(req.Injuries)
+	if req.Title == "" || req.Description == "" {
+		result.Status = models.SyncInvalid
+		result.Message = "submission contains no text once markup is removed"
+		return result
+	}
+
+	masked, filter := h.wordFilter.CheckAll(req.Title, req.Description, req.Injuries)
+	if filter.Action == models.WordActionReject {
+		result.Status = models.SyncInvalid
+		result.Message = "submission contains blocked language"
+		return result
+	}
+	req.Title, req.Description, req.Injuries = masked[0], masked[1], masked[2]
+
+	report := h.newReport(user, req, filter.Action == models.WordActionFlag)
+	result.ID = report.ID
+	created := events.Event{Type: events.ReportCreated, ReportID: report.ID, Actor: user.Email}
+	err := h.changeWithEvent(ctx, created, func(tx storage.Client) error {
+		return tx.CreateReport(ctx, report)
+	})
+	if err == nil {
+		result.Status = models.SyncCreated
+		result.Report = report
+		return result
+	}
+	if !errors.Is(err, storage.ErrConflict) {
+		log.Printf("Sync of report %s for %s failed: %v", report.ID, user.Email, err)
+		result.Status = models.SyncFailed
+		result.Message = "failed to create report"
+		return result
+	}
+
+	existing, err := h.storage.GetReport(ctx, report.ID)
+	if err != nil {
+		log.Printf("Sync of report %s for %s failed to load existing report: %v", report.ID, user.Email, err)
+		result.Status = models.SyncFailed
+		result.Message = "failed to load existing report"
+		return result
+	}
+	if existing.UserID != user.Subject {
+		result.Status = models.SyncConflict
+		result.Message = "report ID is already in use"
+		return result
+	}
+	result.Status = models.SyncDuplicate
+	result.Report = existing
+	return result
+}
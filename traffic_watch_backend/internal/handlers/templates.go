@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// ListTemplates handles GET /v1/templates
+// Returns the current user's report templates, by name
+func (h *ReportsHandler) ListTemplates(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	templates, err := h.storage.ListReportTemplates(c.Request.Context(), user.Subject)
+	if err != nil {
+		log.Printf("Failed to list templates for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch templates")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"templates": templates,
+		"count":     len(templates),
+	})
+}
+
+// CreateTemplate handles POST /v1/templates
+func (h *ReportsHandler) CreateTemplate(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	template, ok := bindTemplate(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	existing, err := h.storage.ListReportTemplates(ctx, user.Subject)
+	if err != nil {
+		log.Printf("Failed to count templates for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create template")
+		return
+	}
+	if len(existing) >= models.MaxTemplatesPerUser {
+		apierror.Respond(c, http.StatusConflict, apierror.QuotaExceeded, "template limit reached; delete one first")
+		return
+	}
+
+	template.ID = uuid.New().String()
+	template.UserID = user.Subject
+	if err := h.storage.CreateReportTemplate(ctx, template); err != nil {
+		log.Printf("Failed to create template for %s: %v", user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create template")
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+// UpdateTemplate handles PUT /v1/templates/:id
+// Replaces one of the current user's templates
+func (h *ReportsHandler) UpdateTemplate(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	templateID := c.Param("id")
+	if !validation.ValidateUUID(templateID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid template ID format")
+		return
+	}
+
+	template, ok := bindTemplate(c)
+	if !ok {
+		return
+	}
+	template.ID = templateID
+	template.UserID = user.Subject
+
+	if err := h.storage.UpdateReportTemplate(c.Request.Context(), template); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "template not found")
+			return
+		}
+		log.Printf("Failed to update template %s: %v", templateID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to update template")
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate handles DELETE /v1/templates/:id
+func (h *ReportsHandler) DeleteTemplate(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	templateID := c.Param("id")
+	if !validation.ValidateUUID(templateID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid template ID format")
+		return
+	}
+
+	if err := h.storage.DeleteReportTemplate(c.Request.Context(), templateID, user.Subject); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "template not found")
+			return
+		}
+		log.Printf("Failed to delete template %s: %v", templateID, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.DeleteFailed, "failed to delete template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "template deleted",
+	})
+}
+
+// bindTemplate parses and validates a template request body, writing a 400 on failure
+func bindTemplate(c *gin.Context) (*models.ReportTemplate, bool) {
+	var req models.ReportTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return nil, false
+	}
+
+	template := &models.ReportTemplate{
+		Name:        validation.SanitizeText(req.Name),
+		Title:       validation.SanitizeText(req.Title),
+		Description: validation.SanitizeText(req.Description),
+		RoadUsages:  req.RoadUsages,
+		EventTypes:  req.EventTypes,
+		State:       req.State,
+		City:        req.City,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+	}
+	if template.RoadUsages == nil {
+		template.RoadUsages = []string{}
+	}
+	if template.EventTypes == nil {
+		template.EventTypes = []string{}
+	}
+	return template, true
+}
+
+// reportTemplate loads the template named by ?templateId= on report creation.
+// It returns nil when none was given, and writes a 400/404 when it can't be used.
+func (h *ReportsHandler) reportTemplate(c *gin.Context, user *models.UserInfo) (*models.ReportTemplate, bool) {
+	templateID := c.Query("templateId")
+	if templateID == "" {
+		return nil, true
+	}
+	if !validation.ValidateUUID(templateID) {
+		apierror.RespondField(c, "templateId", apierror.ReasonInvalidFormat, "invalid template ID format")
+		return nil, false
+	}
+
+	template, err := h.storage.GetReportTemplate(c.Request.Context(), templateID, user.Subject)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "template not found")
+			return nil, false
+		}
+		log.Printf("Failed to load template %s for %s: %v", templateID, user.Email, err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to load template")
+		return nil, false
+	}
+	return template, true
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/transcribe"
+)
+
+// TranscriptBatchSize caps how many videos one run of the transcription job starts or polls
+const TranscriptBatchSize = 20
+
+// SetTranscriber enables transcription of uploaded videos by the TranscribeVideos job
+func (h *ReportsHandler) SetTranscriber(t transcribe.Transcriber) {
+	h.transcriber = t
+}
+
+// TranscribeVideos is the media worker for video transcripts. Run periodically by the
+// scheduler, it starts transcribing GCS-hosted videos that have no transcript yet and
+// stores the transcripts of ones started on earlier runs once they finish. Videos
+// hosted on YouTube are skipped since their audio isn't in our bucket.
+func (h *ReportsHandler) TranscribeVideos(ctx context.Context) error {
+	if h.transcriber == nil || h.gcs == nil {
+		return nil
+	}
+	jobs, err := h.storage.ListMediaForTranscription(ctx, TranscriptBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var started, completed, failed int
+	for _, job := range jobs {
+		if job.Media.TranscriptStatus == models.TranscriptPending && job.Media.TranscriptOperation != "" {
+			switch done, err := h.pollTranscript(ctx, job); {
+			case errors.Is(err, transcribe.ErrFailed):
+				failed++
+			case err != nil:
+				log.Printf("Failed to poll transcript of media %s on report %s: %v", job.Media.ID, job.ReportID, err)
+			case done:
+				completed++
+			}
+			continue
+		}
+
+		report := &models.TrafficReport{ID: job.ReportID, UserID: job.UserID}
+		uri := fmt.Sprintf("gs://%s/%s", h.gcs.GetBucketName(), mediaObjectPath(report, &job.Media))
+		operation, err := h.transcriber.Start(ctx, uri)
+		if err != nil {
+			log.Printf("Failed to start transcript of media %s on report %s: %v", job.Media.ID, job.ReportID, err)
+			continue
+		}
+		if err := h.storage.UpdateMediaTranscript(ctx, job.ReportID, job.Media.ID, models.TranscriptPending, "", operation); err != nil {
+			log.Printf("Failed to record transcript operation for media %s: %v", job.Media.ID, err)
+			continue
+		}
+		started++
+	}
+
+	if started+completed+failed > 0 {
+		log.Printf("Video transcripts: %d started, %d completed, %d failed", started, completed, failed)
+	}
+	return nil
+}
+
+// pollTranscript checks a running transcription and stores its outcome once finished
+func (h *ReportsHandler) pollTranscript(ctx context.Context, job models.TranscriptJob) (bool, error) {
+	result, err := h.transcriber.Poll(ctx, job.Media.TranscriptOperation)
+	if errors.Is(err, transcribe.ErrFailed) {
+		log.Printf("Transcript of media %s on report %s failed: %v", job.Media.ID, job.ReportID, err)
+		if err := h.storage.UpdateMediaTranscript(ctx, job.ReportID, job.Media.ID, models.TranscriptFailed, "", ""); err != nil {
+			return false, err
+		}
+		return true, transcribe.ErrFailed
+	}
+	if err != nil || !result.Done {
+		return false, err
+	}
+	return true, h.storage.UpdateMediaTranscript(ctx, job.ReportID, job.Media.ID, models.TranscriptCompleted, result.Transcript, "")
+}
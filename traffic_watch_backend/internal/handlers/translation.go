@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"slices"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/translate"
+)
+
+// SetTranslator enables machine translation of the public feed with ?lang=
+func (h *ReportsHandler) SetTranslator(t *translate.Service) {
+	h.translator = t
+}
+
+// validFeedLanguage reports whether lang is a usable ?lang= value ("" means untranslated)
+func validFeedLanguage(lang string) bool {
+	return lang == "" || translate.ValidLanguage(lang)
+}
+
+// translatedFeed returns a copy of reports with translations for lang attached.
+// Translation is best-effort: without a translator, or when the API fails, the
+// reports are returned untranslated rather than failing the feed.
+func (h *ReportsHandler) translatedFeed(ctx context.Context, reports []models.TrafficReport, lang string) []models.TrafficReport {
+	if lang == "" || h.translator == nil || len(reports) == 0 {
+		return reports
+	}
+	// Copy so translations never leak into the shared feed cache
+	translated := slices.Clone(reports)
+	if err := h.translator.TranslateReports(ctx, translated, lang); err != nil {
+		log.Printf("Failed to translate feed into %q: %v", lang, err)
+		return reports
+	}
+	return translated
+}
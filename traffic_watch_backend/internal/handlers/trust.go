@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/validation"
+)
+
+// trustUpdateTimeout bounds the lookups made to recompute a trust level after a review
+const trustUpdateTimeout = 10 * time.Second
+
+// SetTrustThresholds sets the review history needed for each trust level
+func (h *ReportsHandler) SetTrustThresholds(thresholds models.TrustThresholds) {
+	h.trust = thresholds
+}
+
+// SetTrustedMediaLimits sets the per-report media caps for users whose trust level
+// allows higher upload limits; everyone else gets the limits from SetMediaLimits
+func (h *ReportsHandler) SetTrustedMediaLimits(limits validation.MediaLimits) {
+	h.trustedMediaLimits = limits
+}
+
+// mediaLimitsFor returns the per-report media caps for a user. Users whose trust
+// level can't be looked up get the standard limits.
+func (h *ReportsHandler) mediaLimitsFor(ctx context.Context, userID string) validation.MediaLimits {
+	user, err := h.storage.GetUserByID(ctx, userID)
+	if err != nil || !user.TrustLevel.Capabilities().HigherUploadLimits {
+		return h.mediaLimits
+	}
+	return h.trustedMediaLimits
+}
+
+// RecomputeTrustLevel derives a user's trust level from their approved and
+// rejected reports and stores it if it changed
+func (h *ReportsHandler) RecomputeTrustLevel(ctx context.Context, userID string) (models.TrustLevel, error) {
+	user, err := h.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	approved, err := h.storage.CountUserReports(ctx, userID, models.StatusReviewedPass)
+	if err != nil {
+		return "", err
+	}
+	rejected, err := h.storage.CountUserReports(ctx, userID, models.StatusReviewedFail)
+	if err != nil {
+		return "", err
+	}
+
+	level := h.trust.Level(approved, rejected)
+	if level == user.TrustLevel {
+		return level, nil
+	}
+	if err := h.storage.SetUserTrustLevel(ctx, userID, level); err != nil {
+		return "", err
+	}
+	log.Printf("Trust level of %s changed from %q to %q (%d approved, %d rejected)", user.Email, user.TrustLevel, level, approved, rejected)
+	return level, nil
+}
+
+// UpdateTrustOnReview recomputes the trust level of a report's owner when a
+// review changes their history. Subscribe it to the event bus.
+func (h *ReportsHandler) UpdateTrustOnReview(e events.Event) {
+	switch e.Type {
+	case events.ReportApproved, events.ReportRejected, events.ReportResubmitted:
+	default:
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), trustUpdateTimeout)
+	defer cancel()
+	report, err := h.storage.GetReport(ctx, e.ReportID)
+	if err != nil {
+		log.Printf("Failed to get report %s to update its owner's trust level: %v", e.ReportID, err)
+		return
+	}
+	if _, err := h.RecomputeTrustLevel(ctx, report.UserID); err != nil {
+		log.Printf("Failed to update trust level of %s: %v", report.UserID, err)
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// trustStore holds one user and their review counts
+type trustStore struct {
+	storage.Client
+	user               models.User
+	approved, rejected int
+	sets               int
+}
+
+func (s *trustStore) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	if userID != s.user.ID {
+		return nil, storage.ErrNotFound
+	}
+	u := s.user
+	return &u, nil
+}
+
+func (s *trustStore) GetReport(ctx context.Context, reportID string) (*models.TrafficReport, error) {
+	return &models.TrafficReport{ID: reportID, UserID: s.user.ID}, nil
+}
+
+func (s *trustStore) CountUserReports(ctx context.Context, userID, status string) (int, error) {
+	if status == models.StatusReviewedPass {
+		return s.approved, nil
+	}
+	return s.rejected, nil
+}
+
+func (s *trustStore) SetUserTrustLevel(ctx context.Context, userID string, level models.TrustLevel) error {
+	s.user.TrustLevel = level
+	s.sets++
+	return nil
+}
+
+func TestTrustThresholds_Level(t *testing.T) {
+	thresholds := models.DefaultTrustThresholds()
+	tests := []struct {
+		approved, rejected int
+		want               models.TrustLevel
+	}{
+		{0, 0, models.TrustNew},
+		{0, 3, models.TrustNew},
+		{1, 0, models.TrustBasic},
+		{4, 0, models.TrustBasic},
+		{5, 0, models.TrustTrusted},
+		{8, 2, models.TrustTrusted}, // 20% rejected
+		{8, 3, models.TrustBasic},   // 27% rejected
+	}
+	for _, tt := range tests {
+		if got := thresholds.Level(tt.approved, tt.rejected); got != tt.want {
+			t.Errorf("Level(%d approved, %d rejected) = %q, want %q", tt.approved, tt.rejected, got, tt.want)
+		}
+	}
+}
+
+func TestReportsHandler_UpdateTrustOnReview(t *testing.T) {
+	store := &trustStore{user: models.User{ID: "user-1", Email: "user@example.com"}, approved: 5}
+	handler := NewReportsHandler(store, nil, nil)
+
+	handler.UpdateTrustOnReview(events.Event{Type: events.ReportPinned, ReportID: "r1"})
+	if store.sets != 0 {
+		t.Fatal("a non-review event should not recompute trust")
+	}
+
+	handler.UpdateTrustOnReview(events.Event{Type: events.ReportApproved, ReportID: "r1"})
+	if store.user.TrustLevel != models.TrustTrusted {
+		t.Fatalf("trust level = %q, want trusted", store.user.TrustLevel)
+	}
+	if got := handler.mediaLimitsFor(context.Background(), "user-1"); got != handler.trustedMediaLimits {
+		t.Errorf("trusted user media limits = %+v, want %+v", got, handler.trustedMediaLimits)
+	}
+
+	// An unchanged level isn't written again
+	handler.UpdateTrustOnReview(events.Event{Type: events.ReportApproved, ReportID: "r2"})
+	if store.sets != 1 {
+		t.Errorf("trust level written %d times, want 1", store.sets)
+	}
+
+	store.rejected = 3
+	handler.UpdateTrustOnReview(events.Event{Type: events.ReportRejected, ReportID: "r3"})
+	if store.user.TrustLevel != models.TrustBasic {
+		t.Errorf("after rejections trust level = %q, want basic", store.user.TrustLevel)
+	}
+	if got := handler.mediaLimitsFor(context.Background(), "user-1"); got != handler.mediaLimits {
+		t.Errorf("basic user media limits = %+v, want %+v", got, handler.mediaLimits)
+	}
+}
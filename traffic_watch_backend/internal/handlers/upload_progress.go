@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/validation"
+)
+
+// UploadSessionHeader carries the client-chosen UUID a multipart report
+// submission's progress is tracked under
+const UploadSessionHeader = "X-Upload-Session"
+
+// uploadProgressTTL is how long an upload's progress stays readable after its last change
+const uploadProgressTTL = 10 * time.Minute
+
+// uploadTracker holds the progress of multipart submissions, keyed by user and
+// upload session ID so users only see their own. It lives in memory, so progress
+// is only visible on the instance receiving the upload.
+type uploadTracker struct {
+	mu       sync.Mutex
+	sessions map[string]*models.UploadProgress
+}
+
+func newUploadTracker() *uploadTracker {
+	return &uploadTracker{sessions: make(map[string]*models.UploadProgress)}
+}
+
+func uploadKey(userID, sessionID string) string {
+	return userID + "/" + sessionID
+}
+
+// start begins tracking a session, replacing any earlier upload under the same ID
+func (t *uploadTracker) start(userID, sessionID string, bytesTotal int64) *uploadSession {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	for key, p := range t.sessions {
+		if now.Sub(p.UpdatedAt) > uploadProgressTTL {
+			delete(t.sessions, key)
+		}
+	}
+	key := uploadKey(userID, sessionID)
+	t.sessions[key] = &models.UploadProgress{
+		SessionID:  sessionID,
+		Stage:      models.UploadReceiving,
+		BytesTotal: bytesTotal,
+		UpdatedAt:  now,
+	}
+	return &uploadSession{tracker: t, key: key}
+}
+
+// get returns a copy of the session's progress
+func (t *uploadTracker) get(userID, sessionID string) (models.UploadProgress, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.sessions[uploadKey(userID, sessionID)]
+	if !ok || time.Since(p.UpdatedAt) > uploadProgressTTL {
+		return models.UploadProgress{}, false
+	}
+	return *p, true
+}
+
+// uploadSession updates one tracked upload. A nil session (no session header)
+// ignores all updates.
+type uploadSession struct {
+	tracker *uploadTracker
+	key     string
+}
+
+func (s *uploadSession) update(fn func(p *models.UploadProgress)) {
+	if s == nil {
+		return
+	}
+	s.tracker.mu.Lock()
+	defer s.tracker.mu.Unlock()
+	if p, ok := s.tracker.sessions[s.key]; ok {
+		fn(p)
+		p.UpdatedAt = time.Now()
+	}
+}
+
+// received counts n more bytes of the request body
+func (s *uploadSession) received(n int) {
+	s.update(func(p *models.UploadProgress) { p.BytesReceived += int64(n) })
+}
+
+// processing marks the body as received and sets how many files it holds
+func (s *uploadSession) processing(files int) {
+	s.update(func(p *models.UploadProgress) {
+		p.Stage = models.UploadProcessing
+		p.FilesTotal = files
+	})
+}
+
+// fileDone counts one more file checked and stored
+func (s *uploadSession) fileDone() {
+	s.update(func(p *models.UploadProgress) { p.FilesProcessed++ })
+}
+
+// finish records the outcome from the response status once the handler has responded
+func (s *uploadSession) finish(c *gin.Context, reportID string) {
+	s.update(func(p *models.UploadProgress) {
+		if c.Writer.Status() == http.StatusCreated {
+			p.Stage = models.UploadComplete
+			p.ReportID = reportID
+			return
+		}
+		p.Stage = models.UploadFailed
+		p.Error = http.StatusText(c.Writer.Status())
+	})
+}
+
+// progressReader counts body bytes into an upload session as the form is parsed
+type progressReader struct {
+	io.ReadCloser
+	session *uploadSession
+}
+
+func (r *progressReader) Read(b []byte) (int, error) {
+	n, err := r.ReadCloser.Read(b)
+	r.session.received(n)
+	return n, err
+}
+
+// trackUpload starts tracking the request if it carries an upload session ID.
+// It returns nil, which ignores updates, for untracked requests, and false after
+// responding if the session ID is malformed.
+func (h *ReportsHandler) trackUpload(c *gin.Context, user *models.UserInfo) (*uploadSession, bool) {
+	sessionID := c.GetHeader(UploadSessionHeader)
+	if sessionID == "" {
+		return nil, true
+	}
+	if !validation.ValidateUUID(sessionID) {
+		apierror.RespondField(c, UploadSessionHeader, apierror.ReasonInvalidFormat, "upload session ID must be a UUID")
+		return nil, false
+	}
+	session := h.uploads.start(user.Subject, sessionID, c.Request.ContentLength)
+	c.Request.Body = &progressReader{ReadCloser: c.Request.Body, session: session}
+	return session, true
+}
+
+// GetUploadProgress handles GET /v1/uploads/:sessionId
+// Returns the progress of the caller's multipart report submission sent with
+// the X-Upload-Session header, until 10 minutes after it last changed
+func (h *ReportsHandler) GetUploadProgress(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	sessionID := c.Param("sessionId")
+	if !validation.ValidateUUID(sessionID) {
+		apierror.RespondField(c, "sessionId", apierror.ReasonInvalidFormat, "invalid upload session ID format")
+		return
+	}
+
+	progress, ok := h.uploads.get(user.Subject, sessionID)
+	if !ok {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "upload session not found")
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, progress)
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// BatchUploadURLs handles POST /v1/reports/:id/media/batch-upload-urls
+// Issues signed PUT URLs for several files in one round trip. Every file is
+// validated before any URL is issued, so a bad entry rejects the whole batch.
+// Each file is attached to the report straight away; its URL is refreshed on
+// read like any other GCS media once the check-uploads job has marked it
+// ready; until then the report stays out of the review queue and the feed.
+// The report's media limits count files already attached.
+func (h *ReportsHandler) BatchUploadURLs(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.RespondField(c, "id", apierror.ReasonInvalidFormat, "invalid report ID format")
+		return
+	}
+
+	var req models.BatchUploadURLsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondValidation(c, err)
+		return
+	}
+
+	for i := range req.Files {
+		file := &req.Files[i]
+		if valid, errMsg := validation.ValidateFileSpec(file.FileName, file.ContentType, file.Size); !valid {
+			apierror.RespondField(c, fmt.Sprintf("files[%d]", i), apierror.ReasonInvalid, fmt.Sprintf("files[%d] (%s): %s", i, file.FileName, errMsg))
+			return
+		}
+		// The signed URL is bound to the content type, so resolve it the
+		// same way validation did
+		if file.ContentType == "" || file.ContentType == "application/octet-stream" {
+			file.ContentType = validation.DetectContentType(file.FileName)
+		}
+	}
+
+	if h.gcs == nil {
+		apierror.Respond(c, http.StatusServiceUnavailable, apierror.Unavailable, "media storage is not configured")
+		return
+	}
+
+	ctx := c.Request.Context()
+	report, err := h.storage.GetReportByIDAndUser(ctx, reportID, user.Subject)
+	if err != nil {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "report not found")
+		return
+	}
+
+	// The per-report limits cover media already attached as well as this batch
+	sizes := make([]int64, 0, len(report.MediaFiles)+len(req.Files))
+	for _, media := range report.MediaFiles {
+		sizes = append(sizes, media.Size)
+	}
+	for _, file := range req.Files {
+		sizes = append(sizes, file.Size)
+	}
+	if valid, errMsg := h.mediaLimitsFor(ctx, user.Subject).Check(sizes); !valid {
+		apierror.RespondField(c, "files", apierror.ReasonOutOfRange, errMsg)
+		return
+	}
+
+	uploads := make([]models.UploadURL, 0, len(req.Files))
+	for _, file := range req.Files {
+		fileID := uuid.New().String()
+		uploadURL, _, err := h.gcs.GetUploadSignedURL(ctx, user.Subject, reportID, fileID, file.ContentType)
+		if err != nil {
+			log.Printf("Failed to sign upload URL for report %s: %v", reportID, err)
+			apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create upload URLs")
+			return
+		}
+
+		mediaFile := models.MediaFile{
+			ID:          fileID,
+			FileName:    validation.SanitizeFileName(file.FileName),
+			ContentType: file.ContentType,
+			Size:        file.Size,
+			UploadedAt:  time.Now(),
+			State:       models.MediaProcessing,
+		}
+		if err := h.storage.AddMediaFileToReport(ctx, reportID, mediaFile); err != nil {
+			log.Printf("Failed to attach media %s to report %s: %v", fileID, reportID, err)
+			apierror.Respond(c, http.StatusInternalServerError, apierror.CreateFailed, "failed to create upload URLs")
+			return
+		}
+
+		uploads = append(uploads, models.UploadURL{
+			MediaID:     fileID,
+			FileName:    mediaFile.FileName,
+			ContentType: file.ContentType,
+			UploadURL:   uploadURL,
+			Method:      http.MethodPut,
+			ExpiresAt:   time.Now().Add(storage.UploadURLExpiration),
+		})
+	}
+
+	log.Printf("Issued %d upload URLs for report %s", len(uploads), reportID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"uploads": uploads,
+		"count":   len(uploads),
+	})
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+)
+
+// userExportFlushEvery is how many rows are buffered before flushing to the client
+const userExportFlushEvery = 100
+
+// userExportColumns is the CSV header, in column order
+var userExportColumns = []string{"id", "email", "role", "signupDate", "lastLoginAt", "reportCount", "approvedReportCount"}
+
+// redactUserExportRow replaces identifying fields: the ID becomes a stable
+// pseudonym (so rows can still be joined across exports) and the email keeps
+// only its first character and domain
+func redactUserExportRow(row models.UserExportRow) models.UserExportRow {
+	sum := sha256.Sum256([]byte(row.ID))
+	row.ID = hex.EncodeToString(sum[:8])
+	if at := strings.LastIndex(row.Email, "@"); at > 0 {
+		row.Email = row.Email[:1] + "***" + row.Email[at:]
+	} else {
+		row.Email = "***"
+	}
+	return row
+}
+
+func userExportRecord(row models.UserExportRow) []string {
+	lastLogin := ""
+	if row.LastLoginAt != nil {
+		lastLogin = row.LastLoginAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		row.ID,
+		row.Email,
+		string(row.Role),
+		row.CreatedAt.UTC().Format(time.RFC3339),
+		lastLogin,
+		strconv.Itoa(row.ReportCount),
+		strconv.Itoa(row.ApprovedReportCount),
+	}
+}
+
+// ExportUsers handles GET /v1/admin/users/export
+// Streams every user with role, signup date, last login and report counts as
+// CSV (default) or NDJSON (?format=ndjson). ?redact=true pseudonymizes IDs and
+// masks emails for sharing outside the admin team.
+func (h *ReportsHandler) ExportUsers(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "ndjson" {
+		apierror.RespondField(c, "format", apierror.ReasonNotAllowed, "format must be csv or ndjson")
+		return
+	}
+	redact := c.Query("redact") == "true"
+
+	filename := fmt.Sprintf("users-%s.%s", time.Now().UTC().Format("20060102"), format)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Header("Cache-Control", "no-store")
+
+	var write func(models.UserExportRow) error
+	var flush func()
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		w := csv.NewWriter(c.Writer)
+		write = func(row models.UserExportRow) error { return w.Write(userExportRecord(row)) }
+		flush = func() { w.Flush(); c.Writer.Flush() }
+		if err := w.Write(userExportColumns); err != nil {
+			return
+		}
+	} else {
+		c.Header("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(c.Writer)
+		write = func(row models.UserExportRow) error { return enc.Encode(row) }
+		flush = c.Writer.Flush
+	}
+	c.Status(http.StatusOK)
+
+	count := 0
+	err := h.storage.ExportUsers(c.Request.Context(), func(row models.UserExportRow) error {
+		if redact {
+			row = redactUserExportRow(row)
+		}
+		if err := write(row); err != nil {
+			return err
+		}
+		count++
+		if count%userExportFlushEvery == 0 {
+			flush()
+		}
+		return nil
+	})
+	flush()
+
+	if err != nil {
+		// Headers are already sent, so the truncated body is the only signal to the client
+		log.Printf("User export by %s failed after %d rows: %v", user.Email, count, err)
+		return
+	}
+	log.Printf("User export by %s: %d users (format %s, redacted %v)", user.Email, count, format, redact)
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/middleware"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/pagination"
+	"donzhit_me_backend/internal/validation"
+)
+
+// V2Handler serves the /v2 API: every list is cursor-paginated inside a
+// {data, pagination} envelope and errors are RFC 7807 problems. It reuses the
+// /v1 business logic in shared.go and only differs in request/response shape.
+type V2Handler struct {
+	reports *ReportsHandler
+}
+
+// NewV2Handler creates a /v2 handler on top of the reports handler
+func NewV2Handler(reports *ReportsHandler) *V2Handler {
+	return &V2Handler{reports: reports}
+}
+
+// pageParams parses ?limit= and ?cursor=, writing a problem response on failure
+func pageParams(c *gin.Context) (int, *pagination.Key, bool) {
+	limit, err := pagination.ParseLimit(c.Query("limit"))
+	if err != nil {
+		apierror.AbortProblem(c, http.StatusBadRequest, apierror.Validation, err.Error(),
+			apierror.FieldError{Field: "limit", Reason: apierror.ReasonOutOfRange, Message: err.Error()})
+		return 0, nil, false
+	}
+	after, err := pagination.Decode(c.Query("cursor"))
+	if err != nil {
+		apierror.AbortProblem(c, http.StatusBadRequest, apierror.Validation, err.Error(),
+			apierror.FieldError{Field: "cursor", Reason: apierror.ReasonInvalidFormat, Message: err.Error()})
+		return 0, nil, false
+	}
+	return limit, after, true
+}
+
+// writePage writes a page of items in the /v2 envelope
+func writePage[T any](c *gin.Context, items []T, nextCursor string, limit int) {
+	if items == nil {
+		items = []T{}
+	}
+	c.JSON(http.StatusOK, models.PageResponse[T]{
+		Data: items,
+		Pagination: models.Pagination{
+			Limit:      limit,
+			NextCursor: nextCursor,
+			HasMore:    nextCursor != "",
+		},
+	})
+}
+
+// reportKey returns the cursor key of reports ordered by sort. With pinnedFirst the key
+// leads with the pinned flag, inverted for ascending orders so pinned reports still come first.
+func reportKey(sort models.ReportSort, pinnedFirst bool) func(models.TrafficReport) pagination.Key {
+	return func(r models.TrafficReport) pagination.Key {
+		k := pagination.Key{At: r.CreatedAt, ID: r.ID}
+		if pinnedFirst {
+			k.Pinned = r.Pinned != sort.Ascending
+		}
+		switch sort.Field {
+		case models.SortUpdatedAt:
+			k.At = r.UpdatedAt
+		case models.SortPriority:
+			k.Rank = r.PriorityRank()
+		case models.SortEngagement:
+			k.Score = r.HotScore
+		}
+		return k
+	}
+}
+
+func commentKey(cm models.Comment) pagination.Key {
+	return pagination.Key{At: cm.CreatedAt, ID: cm.ID}
+}
+
+// ListApprovedReports handles GET /v2/public/reports
+// ?sort=createdAt|updatedAt|priority|engagement&order=asc|desc changes the order (default
+// priority, highest first; pinned reports stay first); ?lang=es attaches machine translations
+// of titles and descriptions to the page; ?severity=minor_injury,major_injury keeps only
+// reports of those severities. Each report on the page carries its engagement summary.
+func (h *V2Handler) ListApprovedReports(c *gin.Context) {
+	limit, after, ok := pageParams(c)
+	if !ok {
+		return
+	}
+	sort, param, err := reportSort(c, models.SortPriority)
+	if err != nil {
+		apierror.AbortProblem(c, http.StatusBadRequest, apierror.Validation, err.Error(),
+			apierror.FieldError{Field: param, Reason: apierror.ReasonNotAllowed, Message: err.Error()})
+		return
+	}
+	lang := c.Query("lang")
+	if !validFeedLanguage(lang) {
+		apierror.AbortProblem(c, http.StatusBadRequest, apierror.Validation, "lang must be a language code such as es or zh-TW",
+			apierror.FieldError{Field: "lang", Reason: apierror.ReasonInvalidFormat, Message: "lang must be a language code such as es or zh-TW"})
+		return
+	}
+	severities, err := models.ParseSeverityFilter(c.Query("severity"))
+	if err != nil {
+		apierror.AbortProblem(c, http.StatusBadRequest, apierror.Validation, err.Error(),
+			apierror.FieldError{Field: "severity", Reason: apierror.ReasonNotAllowed, Message: err.Error()})
+		return
+	}
+
+	reports, err := h.reports.approvedFeed(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list approved reports: %v", err)
+		apierror.AbortProblem(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
+	}
+
+	page, next := pagination.Slice(sortedFeed(severityFeed(reports, severities), sort), reportKey(sort, true), !sort.Ascending, after, limit)
+	page = h.reports.withEngagement(c.Request.Context(), page, viewerID(c))
+	writePage(c, h.reports.translatedFeed(c.Request.Context(), page, lang), next, limit)
+}
+
+// ListReports handles GET /v2/reports
+// Returns the current user's reports, newest first unless ?sort= and ?order= say otherwise,
+// optionally filtered by ?status=, each with its engagement summary
+func (h *V2Handler) ListReports(c *gin.Context) {
+	user := middleware.RequireUser(c)
+	if user == nil {
+		return
+	}
+	limit, after, ok := pageParams(c)
+	if !ok {
+		return
+	}
+	status, ok := ownReportStatus(c)
+	if !ok {
+		apierror.AbortProblem(c, http.StatusBadRequest, apierror.Validation, "status must be one of: submitted, reviewed_pass, reviewed_fail",
+			apierror.FieldError{Field: "status", Reason: apierror.ReasonNotAllowed, Message: "status must be one of: submitted, reviewed_pass, reviewed_fail"})
+		return
+	}
+	sort, param, err := reportSort(c, models.SortCreatedAt)
+	if err != nil {
+		apierror.AbortProblem(c, http.StatusBadRequest, apierror.Validation, err.Error(),
+			apierror.FieldError{Field: param, Reason: apierror.ReasonNotAllowed, Message: err.Error()})
+		return
+	}
+
+	reports, err := h.reports.ownReports(c.Request.Context(), user.Subject, status, sort)
+	if err != nil {
+		log.Printf("Failed to list reports for %s: %v", user.Email, err)
+		apierror.AbortProblem(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
+	}
+
+	page, next := pagination.Slice(reports, reportKey(sort, false), !sort.Ascending, after, limit)
+	writePage(c, h.reports.withEngagement(c.Request.Context(), page, viewerID(c)), next, limit)
+}
+
+// GetComments handles GET /v2/public/reports/:id/comments
+// Returns a report's comments, oldest first
+func (h *V2Handler) GetComments(c *gin.Context) {
+	reportID := c.Param("id")
+	if !validation.ValidateUUID(reportID) {
+		apierror.AbortProblem(c, http.StatusBadRequest, apierror.Validation, "invalid report ID format")
+		return
+	}
+	limit, after, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	comments, err := h.reports.visibleComments(c.Request.Context(), reportID, viewerID(c))
+	if err != nil {
+		log.Printf("Failed to get comments: %v", err)
+		apierror.AbortProblem(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to get comments")
+		return
+	}
+
+	page, next := pagination.Slice(comments, commentKey, false, after, limit)
+	writePage(c, page, next, limit)
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+const (
+	defaultWidgetLimit = 5
+	maxWidgetLimit     = 20
+	// widgetMaxAge is how long embedding sites and CDNs may cache the widget; it has to
+	// stay well under the signed media URL lifetime so cached thumbnails still load
+	widgetMaxAge = 300
+)
+
+// SetPublicAppURL sets the web app's base URL (e.g. https://donzhit.me), which
+// links to reports are built from
+func (h *ReportsHandler) SetPublicAppURL(appURL string) {
+	h.publicAppURL = strings.TrimSuffix(appURL, "/")
+}
+
+// GetWidget handles GET /v1/public/widget?state=...&limit=5
+// Returns a compact list of the most recent approved reports, optionally in one
+// state, for third-party sites embedding a "recent incidents" widget. The payload
+// is public and cacheable; CORS is the public group's.
+func (h *ReportsHandler) GetWidget(c *gin.Context) {
+	state := c.Query("state")
+	if state != "" && !validation.ValidStateOrProvince(state) {
+		apierror.RespondField(c, "state", apierror.ReasonNotAllowed, "state must be a US state, DC, or Canadian province or territory")
+		return
+	}
+	limit := defaultWidgetLimit
+	if s := c.Query("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxWidgetLimit {
+			apierror.RespondField(c, "limit", apierror.ReasonOutOfRange, "limit must be between 1 and "+strconv.Itoa(maxWidgetLimit))
+			return
+		}
+		limit = n
+	}
+
+	reports, err := h.approvedFeed(c.Request.Context())
+	if err != nil {
+		log.Printf("Failed to list approved reports for widget: %v", err)
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch reports")
+		return
+	}
+
+	// Newest first regardless of pinning; the widget shows what just happened
+	newest := models.ReportSort{Field: models.SortCreatedAt}
+	reports = slices.Clone(reports)
+	slices.SortStableFunc(reports, newest.Compare)
+
+	items := []models.WidgetItem{}
+	for i := range reports {
+		if len(items) == limit {
+			break
+		}
+		r := &reports[i]
+		if state != "" && r.State != state {
+			continue
+		}
+		items = append(items, models.WidgetItem{
+			ID:          r.ID,
+			Title:       r.Title,
+			City:        r.City,
+			State:       r.State,
+			DateTime:    r.DateTime,
+			Thumbnail:   reportThumbnail(r),
+			MapImageURL: r.MapImageURL,
+			Link:        h.publicAppURL + "/reports/" + url.PathEscape(r.ID),
+		})
+	}
+
+	c.Header("Cache-Control", "public, max-age="+strconv.Itoa(widgetMaxAge))
+	c.JSON(http.StatusOK, models.WidgetResponse{
+		Items: items,
+		Count: len(items),
+	})
+}
+
+// reportThumbnail returns a preview image URL for a report: its first ready image,
+// else the YouTube thumbnail of its first video. It returns "" when there is neither.
+func reportThumbnail(r *models.TrafficReport) string {
+	var video string
+	for _, mf := range r.MediaFiles {
+		if mf.URL == "" || mf.EffectiveState() != models.MediaReady {
+			continue
+		}
+		if storage.IsImageContentType(mf.ContentType) && !isYouTubeURL(mf.URL) {
+			return mf.URL
+		}
+		if video == "" && isYouTubeURL(mf.URL) {
+			video = youTubeThumbnail(mf.URL)
+		}
+	}
+	return video
+}
+
+// youTubeThumbnail returns the standard thumbnail of a youtube.com/watch?v= or
+// youtu.be/ video URL, or "" if the video ID can't be found
+func youTubeThumbnail(videoURL string) string {
+	u, err := url.Parse(videoURL)
+	if err != nil {
+		return ""
+	}
+	id := u.Query().Get("v")
+	if strings.HasSuffix(u.Host, "youtu.be") {
+		id = strings.TrimPrefix(u.Path, "/")
+	}
+	if id == "" || strings.ContainsAny(id, "/?#") {
+		return ""
+	}
+	return "https://i.ytimg.com/vi/" + url.PathEscape(id) + "/hqdefault.jpg"
+}
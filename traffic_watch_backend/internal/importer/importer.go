@@ -0,0 +1,462 @@
+// Package importer migrates reports from the spreadsheets used before the app.
+// Rows are read from CSV or JSON and stored with their original status and
+// timestamps; the admin import endpoint and cmd/import share it.
+package importer
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/geo"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+	"donzhit_me_backend/internal/validation"
+)
+
+// Supported import file formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// MaxRows is the most rows a single import may contain
+const MaxRows = 5000
+
+// ListSeparator separates the values of list columns (roadUsages, eventTypes, media) in CSV files
+const ListSeparator = ";"
+
+// columns are the CSV header names; JSON files use them as object keys
+var columns = []string{
+	"legacyId", "userId", "title", "description", "dateTime", "roadUsages", "eventTypes",
+	"state", "city", "injuries", "severity", "latitude", "longitude",
+	"status", "reviewReason", "reviewedBy", "priority", "createdAt", "updatedAt", "media",
+}
+
+var requiredColumns = []string{"legacyId", "title", "description", "dateTime", "state"}
+
+// importableStatuses are the statuses a row may have; merges and deletions
+// weren't tracked in the spreadsheets
+var importableStatuses = []string{
+	models.StatusSubmitted, models.StatusReviewedPass, models.StatusReviewedFail, models.StatusArchived,
+}
+
+// timeLayouts are accepted for CSV dates, as spreadsheets export them. Dates
+// without a zone are taken as UTC.
+var timeLayouts = []string{
+	time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02", "1/2/2006 15:04:05", "1/2/2006 15:04", "1/2/2006",
+}
+
+// importNamespace derives report and media IDs from legacy IDs, so running an
+// import again skips the rows already imported
+var importNamespace = uuid.MustParse("0f8e5a2c-3d71-4b9e-8a64-7c2d19e4b350")
+
+// ReportID returns the ID a row with legacyID is imported under
+func ReportID(legacyID string) string {
+	return uuid.NewSHA1(importNamespace, []byte("report/"+legacyID)).String()
+}
+
+// Row is one report in an import file
+type Row struct {
+	LegacyID     string    `json:"legacyId"` // The row's ID in the spreadsheet
+	UserID       string    `json:"userId"`   // Owner; Options.UserID when empty
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	DateTime     time.Time `json:"dateTime"`
+	RoadUsages   []string  `json:"roadUsages"`
+	EventTypes   []string  `json:"eventTypes"`
+	State        string    `json:"state"`
+	City         string    `json:"city"`
+	Injuries     string    `json:"injuries"`
+	Severity     string    `json:"severity"` // Guessed from Injuries when empty
+	Latitude     *float64  `json:"latitude"`
+	Longitude    *float64  `json:"longitude"`
+	Status       string    `json:"status"` // Submitted when empty
+	ReviewReason string    `json:"reviewReason"`
+	ReviewedBy   string    `json:"reviewedBy"` // Options.Actor when empty on a reviewed row
+	Priority     *int      `json:"priority"`   // Only for approved and archived rows
+	CreatedAt    time.Time `json:"createdAt"`  // DateTime when empty
+	UpdatedAt    time.Time `json:"updatedAt"`  // CreatedAt when empty
+	Media        []string  `json:"media"`      // YouTube URLs, or gs:// objects already in the media bucket
+
+	err error // First CSV cell that couldn't be parsed
+}
+
+// Options control how rows are stored
+type Options struct {
+	UserID string // Owner of rows without a userId
+	Actor  string // Reviewer recorded on reviewed rows without reviewedBy
+	DryRun bool   // Check and count the rows without storing anything
+	// MediaBucket is the media bucket; gs:// references must name objects in it
+	MediaBucket string
+}
+
+// FormatOf returns the import format for a file name or content type, or "" if neither names one
+func FormatOf(fileName, contentType string) string {
+	switch {
+	case strings.EqualFold(path.Ext(fileName), ".csv"), strings.HasPrefix(contentType, "text/csv"):
+		return FormatCSV
+	case strings.EqualFold(path.Ext(fileName), ".json"), strings.HasPrefix(contentType, "application/json"):
+		return FormatJSON
+	}
+	return ""
+}
+
+// Parse reads the rows of an import file. Unparseable CSV cells are reported
+// per row by Import; a malformed file, unknown columns or too many rows fail
+// the whole file.
+func Parse(r io.Reader, format string) ([]Row, error) {
+	var rows []Row
+	var err error
+	switch format {
+	case FormatCSV:
+		rows, err = parseCSV(r)
+	case FormatJSON:
+		rows, err = parseJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected csv or json", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("the file has no rows")
+	}
+	if len(rows) > MaxRows {
+		return nil, fmt.Errorf("the file has %d rows, more than the limit of %d", len(rows), MaxRows)
+	}
+	return rows, nil
+}
+
+func parseJSON(r io.Reader) ([]Row, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields() // A misspelled key would otherwise drop its data silently
+	var rows []Row
+	if err := dec.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("invalid JSON, expected an array of reports: %w", err)
+	}
+	return rows, nil
+}
+
+func parseCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")) // Spreadsheet exports may start with a BOM
+		j := slices.IndexFunc(columns, func(c string) bool { return strings.EqualFold(c, name) })
+		if j < 0 {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		if _, dup := index[columns[j]]; dup {
+			return nil, fmt.Errorf("column %q appears twice", columns[j])
+		}
+		index[columns[j]] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var rows []Row
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf("the file has more than the limit of %d rows", MaxRows)
+		}
+		cell := func(name string) string {
+			if i, ok := index[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		rows = append(rows, csvRow(cell))
+	}
+	return rows, nil
+}
+
+// csvRow builds a row from the cells of one CSV record
+func csvRow(cell func(name string) string) Row {
+	row := Row{
+		LegacyID:     cell("legacyId"),
+		UserID:       cell("userId"),
+		Title:        cell("title"),
+		Description:  cell("description"),
+		RoadUsages:   splitList(cell("roadUsages")),
+		EventTypes:   splitList(cell("eventTypes")),
+		State:        cell("state"),
+		City:         cell("city"),
+		Injuries:     cell("injuries"),
+		Severity:     cell("severity"),
+		Status:       cell("status"),
+		ReviewReason: cell("reviewReason"),
+		ReviewedBy:   cell("reviewedBy"),
+		Media:        splitList(cell("media")),
+	}
+	fail := func(err error) {
+		if row.err == nil {
+			row.err = err
+		}
+	}
+
+	dates := []struct {
+		name string
+		dest *time.Time
+	}{{"dateTime", &row.DateTime}, {"createdAt", &row.CreatedAt}, {"updatedAt", &row.UpdatedAt}}
+	for _, d := range dates {
+		if v := cell(d.name); v != "" {
+			parsed, err := parseTime(v)
+			if err != nil {
+				fail(fmt.Errorf("%s %q is not a date", d.name, v))
+			}
+			*d.dest = parsed
+		}
+	}
+	lat, lng, err := geo.ParseCoordinates(cell("latitude"), cell("longitude"))
+	if err != nil {
+		fail(err)
+	}
+	row.Latitude, row.Longitude = lat, lng
+	if v := cell("priority"); v != "" {
+		priority, err := strconv.Atoi(v)
+		if err != nil {
+			fail(fmt.Errorf("priority %q is not a whole number", v))
+		}
+		row.Priority = &priority
+	}
+	return row
+}
+
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unrecognized date format")
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ListSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Import stores rows through client. Rows imported by an earlier run are
+// skipped; rows that are invalid or can't be stored are listed in the result's
+// errors while the rest are still imported. The error is only set when ctx
+// ends before every row was handled.
+func Import(ctx context.Context, client storage.Client, rows []Row, opts Options) (models.ReportImportResult, error) {
+	result := models.ReportImportResult{Errors: []models.ReportImportError{}, DryRun: opts.DryRun}
+	seen := make(map[string]bool, len(rows))
+	for i := range rows {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		row := &rows[i]
+		fail := func(msg string) {
+			result.Errors = append(result.Errors, models.ReportImportError{Row: i + 1, LegacyID: row.LegacyID, Message: msg})
+		}
+
+		report, err := row.report(opts, time.Now())
+		if err != nil {
+			fail(err.Error())
+			continue
+		}
+		if seen[row.LegacyID] {
+			fail("legacyId appears more than once in the file")
+			continue
+		}
+		seen[row.LegacyID] = true
+
+		if opts.DryRun {
+			_, err = client.GetReport(ctx, report.ID)
+		} else {
+			err = client.ImportReport(ctx, report)
+		}
+		switch {
+		case opts.DryRun && errors.Is(err, storage.ErrNotFound), !opts.DryRun && err == nil:
+			result.Imported++
+		case opts.DryRun && err == nil, errors.Is(err, storage.ErrConflict):
+			result.Skipped++
+		default:
+			log.Printf("Failed to import report %s (legacy ID %s): %v", report.ID, row.LegacyID, err)
+			fail("failed to save report")
+		}
+	}
+	return result, nil
+}
+
+// report checks the row and builds the report it is imported as
+func (row *Row) report(opts Options, now time.Time) (*models.TrafficReport, error) {
+	if row.err != nil {
+		return nil, row.err
+	}
+	if row.LegacyID == "" {
+		return nil, errors.New("legacyId is required")
+	}
+
+	report := &models.TrafficReport{
+		ID:           ReportID(row.LegacyID),
+		UserID:       cmp.Or(strings.TrimSpace(row.UserID), opts.UserID),
+		Title:        validation.SanitizeText(row.Title),
+		Description:  validation.SanitizeText(row.Description),
+		DateTime:     row.DateTime,
+		RoadUsages:   row.RoadUsages,
+		EventTypes:   row.EventTypes,
+		State:        strings.TrimSpace(row.State),
+		City:         strings.TrimSpace(row.City),
+		Injuries:     validation.SanitizeText(row.Injuries),
+		Severity:     row.Severity,
+		Latitude:     row.Latitude,
+		Longitude:    row.Longitude,
+		Status:       cmp.Or(row.Status, models.StatusSubmitted),
+		ReviewReason: validation.SanitizeText(row.ReviewReason),
+		ReviewedBy:   row.ReviewedBy,
+		Priority:     row.Priority,
+		CreatedAt:    cmp.Or(row.CreatedAt, row.DateTime),
+		MediaFiles:   []models.MediaFile{},
+	}
+	report.UpdatedAt = cmp.Or(row.UpdatedAt, report.CreatedAt)
+
+	switch {
+	case report.UserID == "":
+		return nil, errors.New("userId is required")
+	case report.Title == "" || utf8.RuneCountInString(report.Title) > 200:
+		return nil, errors.New("title must be 1 to 200 characters")
+	case report.Description == "" || utf8.RuneCountInString(report.Description) > 5000:
+		return nil, errors.New("description must be 1 to 5000 characters")
+	case utf8.RuneCountInString(report.Injuries) > 1000:
+		return nil, errors.New("injuries must be at most 1000 characters")
+	case report.DateTime.IsZero():
+		return nil, errors.New("dateTime is required")
+	case report.DateTime.After(now), report.CreatedAt.After(now), report.UpdatedAt.After(now):
+		return nil, errors.New("dates may not be in the future")
+	case report.UpdatedAt.Before(report.CreatedAt):
+		return nil, errors.New("updatedAt is before createdAt")
+	case !validation.ValidStateOrProvince(report.State):
+		return nil, fmt.Errorf("%q is not a US state or Canadian province", report.State)
+	case (report.Latitude == nil) != (report.Longitude == nil):
+		return nil, errors.New("latitude and longitude must be provided together")
+	case report.Latitude != nil && (*report.Latitude < -90 || *report.Latitude > 90 || *report.Longitude < -180 || *report.Longitude > 180):
+		return nil, errors.New("latitude or longitude is out of range")
+	case !slices.Contains(importableStatuses, report.Status):
+		return nil, fmt.Errorf("status must be one of: %s", strings.Join(importableStatuses, ", "))
+	case report.Priority != nil && report.Status != models.StatusReviewedPass && report.Status != models.StatusArchived:
+		return nil, errors.New("priority is only kept on approved or archived reports")
+	case report.Priority != nil && (*report.Priority < models.MinPriority || *report.Priority > models.MaxPriority):
+		return nil, fmt.Errorf("priority must be between %d and %d", models.MinPriority, models.MaxPriority)
+	}
+	for _, u := range report.RoadUsages {
+		if !slices.Contains(validation.GetAllowedRoadUsages(), u) {
+			return nil, fmt.Errorf("%q is not a road usage", u)
+		}
+	}
+	for _, e := range report.EventTypes {
+		if !slices.Contains(validation.GetAllowedEventTypes(), e) {
+			return nil, fmt.Errorf("%q is not an event type", e)
+		}
+	}
+
+	if report.Severity == "" {
+		report.Severity = models.SeverityFromInjuries(report.Injuries)
+	} else if !models.ValidSeverity(report.Severity) {
+		return nil, fmt.Errorf("severity must be one of: %s", strings.Join(models.Severities, ", "))
+	}
+	if city, ok := geo.NormalizeCity(report.State, report.City); ok {
+		report.City, report.CityID = city.Name, city.ID
+	}
+	if report.Status != models.StatusSubmitted {
+		report.ReviewedBy = cmp.Or(strings.TrimSpace(report.ReviewedBy), opts.Actor)
+		report.StatusChangedAt = &report.UpdatedAt
+	}
+
+	for i, ref := range row.Media {
+		mf, err := mediaFile(ref, opts.MediaBucket)
+		if err != nil {
+			return nil, err
+		}
+		mf.ID = uuid.NewSHA1(importNamespace, []byte(fmt.Sprintf("media/%s/%d", row.LegacyID, i))).String()
+		mf.UploadedAt = report.CreatedAt
+		report.MediaFiles = append(report.MediaFiles, mf)
+	}
+	return report, nil
+}
+
+// mediaFile describes a media reference from an import row. Files are not
+// copied: YouTube links are kept as they are, and gs:// references must name
+// objects already in the media bucket, which are then signed like uploads.
+func mediaFile(ref, mediaBucket string) (models.MediaFile, error) {
+	switch {
+	case isYouTubeURL(ref):
+		return models.MediaFile{FileName: "video", ContentType: "video/mp4", URL: ref, State: models.MediaReady}, nil
+	case strings.HasPrefix(ref, "gs://"):
+		bucket, object, ok := strings.Cut(strings.TrimPrefix(ref, "gs://"), "/")
+		name := path.Base(object)
+		if !ok || object == "" {
+			return models.MediaFile{}, fmt.Errorf("media %q has no object path", ref)
+		}
+		// Objects are signed by path in the media bucket, so another bucket's
+		// object would silently become a different file (or none)
+		if bucket != mediaBucket {
+			return models.MediaFile{}, fmt.Errorf("media %q is not in the media bucket %q", ref, mediaBucket)
+		}
+		contentType := validation.DetectContentType(name)
+		if ok, msg := validation.ValidateFileSpec(name, contentType, 0); !ok {
+			return models.MediaFile{}, fmt.Errorf("media %q: %s", ref, msg)
+		}
+		return models.MediaFile{FileName: name, ContentType: contentType, URL: ref, StoragePath: object, State: models.MediaReady}, nil
+	}
+	return models.MediaFile{}, fmt.Errorf("media %q must be a YouTube URL or a gs:// object", ref)
+}
+
+// youTubeHosts are the hosts of YouTube video links
+var youTubeHosts = map[string]bool{
+	"youtube.com":     true,
+	"www.youtube.com": true,
+	"m.youtube.com":   true,
+	"youtu.be":        true,
+}
+
+// isYouTubeURL reports whether ref is an http(s) link to YouTube, judged by its
+// host rather than by "youtube.com" appearing anywhere in it
+func isYouTubeURL(ref string) bool {
+	u, err := url.Parse(ref)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return false
+	}
+	return youTubeHosts[strings.ToLower(u.Hostname())]
+}
@@ -0,0 +1,175 @@
+package importer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// importStore keeps imported reports in memory
+type importStore struct {
+	storage.Client
+	reports map[string]*models.TrafficReport
+}
+
+func (s *importStore) GetReport(ctx context.Context, reportID string) (*models.TrafficReport, error) {
+	if r, ok := s.reports[reportID]; ok {
+		return r, nil
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (s *importStore) ImportReport(ctx context.Context, report *models.TrafficReport) error {
+	if _, ok := s.reports[report.ID]; ok {
+		return storage.ErrConflict
+	}
+	s.reports[report.ID] = report
+	return nil
+}
+
+const sheet = "\ufeffLegacyID,title,description,dateTime,roadUsages,eventTypes,state,city,injuries,status,reviewReason,priority,createdAt,media\n" +
+	`17,Red light at Vine,"SUV ran the light, nearly hit a cyclist",2021-03-04 17:30,Auto; Cyclist,Red Light,Ohio,cincinnati,Broken arm,reviewed_pass,,3,2021-03-05,https://youtu.be/abc; gs://media/legacy/17/photo.jpg` + "\n" +
+	`18,Bad driver,Swerved,3/6/2021,Auto,Reckless,Ohio,,,reviewed_fail,Not enough detail,,,` + "\n" +
+	`19,Speeding,Doing 60 in a 25,not a date,Auto,Speeding,Ohio,,,,,,,` + "\n"
+
+func TestParseCSV(t *testing.T) {
+	rows, err := Parse(strings.NewReader(sheet), FormatCSV)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+
+	r := rows[0]
+	if r.LegacyID != "17" || r.Description != "SUV ran the light, nearly hit a cyclist" ||
+		len(r.RoadUsages) != 2 || r.RoadUsages[1] != "Cyclist" || len(r.Media) != 2 ||
+		r.Priority == nil || *r.Priority != 3 {
+		t.Errorf("row 1 = %+v", r)
+	}
+	if want := time.Date(2021, 3, 4, 17, 30, 0, 0, time.UTC); !r.DateTime.Equal(want) {
+		t.Errorf("dateTime = %v, want %v", r.DateTime, want)
+	}
+	if want := time.Date(2021, 3, 6, 0, 0, 0, 0, time.UTC); !rows[1].DateTime.Equal(want) {
+		t.Errorf("spreadsheet date = %v, want %v", rows[1].DateTime, want)
+	}
+	if rows[2].err == nil {
+		t.Error("expected an error for an unparseable date")
+	}
+}
+
+func TestParse_RejectsMalformedFiles(t *testing.T) {
+	tests := []struct {
+		name, format, body string
+	}{
+		{"unknown column", FormatCSV, "legacyId,title,description,dateTime,state,colour\n"},
+		{"missing column", FormatCSV, "legacyId,title,dateTime,state\n1,a,2021-01-01,Ohio\n"},
+		{"duplicate column", FormatCSV, "legacyId,title,title,description,dateTime,state\n"},
+		{"no rows", FormatCSV, "legacyId,title,description,dateTime,state\n"},
+		{"unknown key", FormatJSON, `[{"legacyId": "1", "colour": "red"}]`},
+		{"not an array", FormatJSON, `{"legacyId": "1"}`},
+		{"unknown format", "xlsx", "anything"},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(strings.NewReader(tt.body), tt.format); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestImport(t *testing.T) {
+	rows, err := Parse(strings.NewReader(sheet), FormatCSV)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	store := &importStore{reports: map[string]*models.TrafficReport{}}
+	opts := Options{UserID: "admin-1", Actor: "admin@example.com", MediaBucket: "media"}
+
+	result, err := Import(context.Background(), store, rows, opts)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if result.Imported != 2 || result.Skipped != 0 || len(result.Errors) != 1 || result.Errors[0].Row != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+
+	approved := store.reports[ReportID("17")]
+	if approved == nil {
+		t.Fatal("row 17 was not imported")
+	}
+	created := time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC)
+	if approved.Status != models.StatusReviewedPass || !approved.CreatedAt.Equal(created) || !approved.UpdatedAt.Equal(created) ||
+		approved.StatusChangedAt == nil || approved.ReviewedBy != "admin@example.com" || approved.UserID != "admin-1" {
+		t.Errorf("approved report = %+v", approved)
+	}
+	if approved.City != "Cincinnati" || approved.CityID == "" || approved.Severity != models.SeverityMajorInjury {
+		t.Errorf("city %q (%q), severity %q", approved.City, approved.CityID, approved.Severity)
+	}
+	if len(approved.MediaFiles) != 2 || approved.MediaFiles[1].StoragePath != "legacy/17/photo.jpg" ||
+		approved.MediaFiles[1].ContentType != "image/jpeg" || !approved.MediaFiles[0].UploadedAt.Equal(created) {
+		t.Errorf("media = %+v", approved.MediaFiles)
+	}
+	if rejected := store.reports[ReportID("18")]; rejected == nil || rejected.ReviewReason != "Not enough detail" || !rejected.CreatedAt.Equal(rejected.DateTime) {
+		t.Errorf("rejected report = %+v", rejected)
+	}
+
+	// Importing the file again skips what is already there
+	again, err := Import(context.Background(), store, rows, Options{DryRun: true, UserID: "admin-1", MediaBucket: "media"})
+	if err != nil || again.Imported != 0 || again.Skipped != 2 {
+		t.Errorf("dry run after import = %+v, %v", again, err)
+	}
+}
+
+func TestRowReport_Validation(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := func() Row {
+		return Row{
+			LegacyID: "1", Title: "Speeding", Description: "Fast", State: "Ohio",
+			DateTime: now.AddDate(-1, 0, 0), Status: models.StatusSubmitted,
+		}
+	}
+	priority := 5
+	lat := 39.1
+	tests := []struct {
+		name   string
+		change func(r *Row)
+	}{
+		{"missing legacy ID", func(r *Row) { r.LegacyID = "" }},
+		{"markup-only title", func(r *Row) { r.Title = "<b></b>" }},
+		{"future date", func(r *Row) { r.DateTime = now.Add(time.Hour) }},
+		{"updated before created", func(r *Row) { r.UpdatedAt = r.DateTime.Add(-time.Hour) }},
+		{"unknown state", func(r *Row) { r.State = "Atlantis" }},
+		{"unknown road usage", func(r *Row) { r.RoadUsages = []string{"Hovercraft"} }},
+		{"unknown severity", func(r *Row) { r.Severity = "bad" }},
+		{"merged status", func(r *Row) { r.Status = models.StatusMerged }},
+		{"priority on a submitted report", func(r *Row) { r.Priority = &priority }},
+		{"latitude alone", func(r *Row) { r.Latitude = &lat }},
+		{"media link elsewhere", func(r *Row) { r.Media = []string{"https://example.com/video.mp4"} }},
+		{"media of a disallowed type", func(r *Row) { r.Media = []string{"gs://media/notes.txt"} }},
+		{"media in another bucket", func(r *Row) { r.Media = []string{"gs://elsewhere/legacy/photo.jpg"} }},
+		{"youtube.com in the path only", func(r *Row) { r.Media = []string{"https://example.com/youtube.com/watch?v=abc"} }},
+		{"youtube look-alike host", func(r *Row) { r.Media = []string{"https://notyoutube.com/watch?v=abc"} }},
+	}
+
+	if _, err := (&Row{}).report(Options{UserID: "u"}, now); err == nil {
+		t.Error("expected an error for an empty row")
+	}
+	base := valid()
+	if _, err := base.report(Options{UserID: "u"}, now); err != nil {
+		t.Fatalf("valid row: %v", err)
+	}
+	if _, err := base.report(Options{}, now); err == nil {
+		t.Error("expected an error for a row without an owner")
+	}
+	for _, tt := range tests {
+		row := valid()
+		tt.change(&row)
+		if _, err := row.report(Options{UserID: "u", MediaBucket: "media"}, now); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Package jobs runs periodic background work alongside the HTTP server.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"donzhit_me_backend/internal/errreport"
+)
+
+// Func is a unit of background work; errors are reported and the job keeps its
+// schedule, as it does after a panic
+type Func func(ctx context.Context) error
+
+// Scheduler runs named jobs on fixed intervals until stopped
+type Scheduler struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	reporter errreport.Reporter
+}
+
+// NewScheduler creates a scheduler with no jobs that logs failed runs
+func NewScheduler() *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{ctx: ctx, cancel: cancel, reporter: errreport.LogReporter{}}
+}
+
+// SetReporter sets where failed and panicking runs are reported. Call it before
+// scheduling any jobs.
+func (s *Scheduler) SetReporter(r errreport.Reporter) {
+	s.reporter = r
+}
+
+// Every runs fn once immediately and then every interval. A run that is still
+// in progress when the next tick fires delays that tick rather than overlapping.
+func (s *Scheduler) Every(name string, interval time.Duration, fn Func) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			s.run(name, fn)
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Printf("Scheduled job %s every %s", name, interval)
+}
+
+func (s *Scheduler) run(name string, fn Func) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	start := time.Now()
+	location := "job " + name
+	defer func() {
+		if rec := recover(); rec != nil {
+			s.reporter.Report(s.ctx, errreport.Event{Err: fmt.Errorf("%v", rec), Stack: debug.Stack(), Location: location})
+		}
+	}()
+	if err := fn(s.ctx); err != nil {
+		if s.ctx.Err() != nil {
+			log.Printf("Job %s stopped after %s: %v", name, time.Since(start), err)
+			return // Cancelled by Stop, not a failure
+		}
+		s.reporter.Report(s.ctx, errreport.Event{Err: fmt.Errorf("failed after %s: %w", time.Since(start), err), Location: location})
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
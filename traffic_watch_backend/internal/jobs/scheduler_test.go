@@ -0,0 +1,111 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/errreport"
+)
+
+func TestScheduler_Every(t *testing.T) {
+	s := NewScheduler()
+
+	var runs int32
+	s.Every("counter", 10*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return errors.New("errors do not stop the schedule")
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for atomic.LoadInt32(&runs) < 3 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	s.Stop()
+
+	got := atomic.LoadInt32(&runs)
+	if got < 3 {
+		t.Fatalf("expected at least 3 runs, got %d", got)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if after := atomic.LoadInt32(&runs); after != got {
+		t.Errorf("expected no runs after Stop, got %d more", after-got)
+	}
+}
+
+func TestScheduler_StopCancelsContext(t *testing.T) {
+	s := NewScheduler()
+
+	started := make(chan struct{})
+	s.Every("blocking", time.Hour, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	<-started
+	done := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not return after cancelling the running job")
+	}
+}
+
+type recordingReporter struct {
+	mu     sync.Mutex
+	events []errreport.Event
+}
+
+func (r *recordingReporter) Report(ctx context.Context, e errreport.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *recordingReporter) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+func TestScheduler_ReportsFailuresAndSurvivesPanics(t *testing.T) {
+	s := NewScheduler()
+	reporter := &recordingReporter{}
+	s.SetReporter(reporter)
+	var runs int32
+	s.Every("flaky", 10*time.Millisecond, func(ctx context.Context) error {
+		if atomic.AddInt32(&runs, 1) == 1 {
+			panic("boom")
+		}
+		return errors.New("still failing")
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for reporter.count() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	s.Stop()
+
+	reporter.mu.Lock()
+	defer reporter.mu.Unlock()
+	if len(reporter.events) < 2 {
+		t.Fatalf("expected the panic and a failure to be reported, got %d events", len(reporter.events))
+	}
+	if e := reporter.events[0]; !e.Panicked() || e.Location != "job flaky" {
+		t.Errorf("first event = %+v, want the panic", e)
+	}
+	if e := reporter.events[1]; e.Panicked() || !strings.Contains(e.Err.Error(), "still failing") || e.Location != "job flaky" {
+		t.Errorf("second event = %+v, want the returned error", e)
+	}
+}
@@ -0,0 +1,73 @@
+// Package markdown renders the restricted Markdown subset accepted in report
+// descriptions and comments to HTML, so clients can show formatting without
+// each shipping their own parser and sanitizer.
+//
+// Parsing is done by goldmark with only the block and inline parsers for the
+// subset enabled, so raw HTML, headings, quotes and code blocks stay plain text.
+// Its output is then passed through a bluemonday allowlist of the tags below,
+// so anything else (images, non-http(s) links) is dropped and links are marked
+// nofollow noreferrer.
+//
+// Supported: paragraphs (blank-line separated) with line breaks, "-"/"*" and
+// numbered lists, **bold**, *italic* or _italic_, `code`, and [text](https://url).
+package markdown
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+var (
+	renderer = goldmark.New(
+		goldmark.WithParser(parser.NewParser(
+			parser.WithBlockParsers(
+				util.Prioritized(parser.NewListParser(), 300),
+				util.Prioritized(parser.NewListItemParser(), 400),
+				util.Prioritized(paragraphParser{parser.NewParagraphParser()}, 1000),
+			),
+			parser.WithInlineParsers(
+				util.Prioritized(parser.NewCodeSpanParser(), 100),
+				util.Prioritized(parser.NewLinkParser(), 200),
+				util.Prioritized(parser.NewEmphasisParser(), 500),
+			),
+		)),
+		goldmark.WithRendererOptions(html.WithHardWraps()),
+	)
+
+	policy = newPolicy()
+)
+
+// paragraphParser also starts paragraphs on lines indented four or more spaces,
+// which would otherwise be code blocks and, with that parser disabled, dropped
+type paragraphParser struct{ parser.BlockParser }
+
+func (paragraphParser) CanAcceptIndentedLine() bool { return true }
+
+// newPolicy allows exactly the markup the subset renders to
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "ul", "ol", "li", "strong", "em", "code")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("href").OnElements("a")
+	p.AllowURLSchemes("http", "https")
+	p.RequireParseableURLs(true)
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	return p
+}
+
+// Render converts Markdown text to safe HTML. Empty input renders as "".
+func Render(text string) string {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(text), &buf); err != nil {
+		// Rendering only fails on a write error, which a buffer never returns
+		return ""
+	}
+	return strings.TrimSpace(policy.Sanitize(buf.String()))
+}
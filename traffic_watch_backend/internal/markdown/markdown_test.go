@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "", ""},
+		{"plain paragraph", "Ran the red light", "<p>Ran the red light</p>"},
+		{"line breaks and paragraphs", "line one\nline two\n\nnext", "<p>line one<br>\nline two</p>\n<p>next</p>"},
+		{"emphasis", "**very** close, *really* _close_", "<p><strong>very</strong> close, <em>really</em> <em>close</em></p>"},
+		{"snake case is not italic", "see plate_number_here", "<p>see plate_number_here</p>"},
+		{"code span keeps markup literal", "type `**not bold**`", "<p>type <code>**not bold**</code></p>"},
+		{"bullet list", "Vehicles:\n- red sedan\n- blue truck", "<p>Vehicles:</p>\n<ul>\n<li>red sedan</li>\n<li>blue truck</li>\n</ul>"},
+		{"numbered list", "1. stopped\n2. went", "<ol>\n<li>stopped</li>\n<li>went</li>\n</ol>"},
+		{"numbered list start", "3. third", "<ol start=\"3\">\n<li>third</li>\n</ol>"},
+		{"link", "[map](https://example.com/a_b_c?x=1&y=2)", `<p><a href="https://example.com/a_b_c?x=1&amp;y=2" rel="nofollow noreferrer">map</a></p>`},
+		{"non-http link keeps only its text", "[x](ftp://example.com)", "<p>x</p>"},
+		{"javascript link keeps only its text", "[x](javascript:alert(1))", "<p>x</p>"},
+		{"html escaped", `<img src=x onerror="alert(1)"> & speed < 30`, "<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt; &amp; speed &lt; 30</p>"},
+		{"quote cannot break out of href", `[x](https://e.com/"onmouseover=alert(1))`, `<p><a href="https://e.com/%22onmouseover=alert(1)" rel="nofollow noreferrer">x</a></p>`},
+		{"headings are plain text", "# Title", "<p># Title</p>"},
+		{"quotes are plain text", "> said", "<p>&gt; said</p>"},
+		{"indented text is a paragraph", "    stopped", "<p>stopped</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.in); got != tt.want {
+				t.Errorf("Render(%q) =\n %q\nwant\n %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	// allowedMarkup is every tag Render may emit; hrefs are checked separately
+	allowedMarkup = regexp.MustCompile(`</?(?:p|ul|ol|li|strong|em|code|a)>|<br/?>|<ol start="\d+">|<a href="([^"<>]*)" rel="nofollow noreferrer">`)
+	// escapedEntity is what html.EscapeString produces; any other & is unescaped input
+	escapedEntity = regexp.MustCompile(`&(?:amp|lt|gt|#34|#39);`)
+)
+
+// FuzzRender checks that nothing in the input survives as markup: with the
+// allowed tags removed, no <, >, " or bare & is left, and every link is http(s)
+func FuzzRender(f *testing.F) {
+	for _, seed := range []string{
+		"**bold** *italic* _italic_ `code`",
+		"- item\n1. item\n\npara",
+		"[x](https://e.com/a_b?c=1&d=2)",
+		`[x](https://e.com/"onmouseover=alert(1))`,
+		`[**x**](javascript:alert(1)) [y](http://e.com/<script>)`,
+		`<img src=x onerror="alert(1)"> &lt; &amp;`,
+		"`<b>` **`</b>`** _[a](https://e.com/`)_`",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, in string) {
+		out := Render(in)
+		for _, m := range allowedMarkup.FindAllStringSubmatch(out, -1) {
+			if href := strings.ToLower(html.UnescapeString(m[1])); strings.HasPrefix(m[0], "<a ") && !strings.HasPrefix(href, "http:") && !strings.HasPrefix(href, "https:") {
+				t.Fatalf("Render(%q) linked to %q", in, href)
+			}
+		}
+		text := escapedEntity.ReplaceAllString(allowedMarkup.ReplaceAllString(out, ""), "")
+		if i := strings.IndexAny(text, `<>"&`); i >= 0 {
+			t.Fatalf("Render(%q) = %q leaves %q unescaped", in, out, text[i])
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package metadata
+
+import "time"
+
+// Summary groups the fields reviewers look at when judging whether media is
+// authentic. Fields the file didn't carry (or the submitter opted to strip)
+// are left empty.
+type Summary struct {
+	GPS        *GPS       `json:"gps,omitempty"`
+	Device     Device     `json:"device"`
+	Timestamps Timestamps `json:"timestamps"`
+}
+
+// GPS is where the media says it was captured
+type GPS struct {
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Altitude  *float64 `json:"altitude,omitempty"`
+}
+
+// Device identifies the camera or phone that produced the media
+type Device struct {
+	Make     string `json:"make,omitempty"`
+	Model    string `json:"model,omitempty"`
+	Software string `json:"software,omitempty"`
+}
+
+// Timestamps are reported as recorded in the file; EXIF times carry no zone
+type Timestamps struct {
+	Captured  string `json:"captured,omitempty"`
+	Digitized string `json:"digitized,omitempty"`
+	Modified  string `json:"modified,omitempty"`
+}
+
+// Summarize picks the GPS, device and timestamp fields out of metadata
+// produced by ExtractMetadata
+func Summarize(metadata map[string]interface{}) Summary {
+	var s Summary
+
+	lat, latOK := toFloat(metadata["gps_latitude"])
+	lon, lonOK := toFloat(metadata["gps_longitude"])
+	if latOK && lonOK {
+		s.GPS = &GPS{Latitude: lat, Longitude: lon}
+		if alt, ok := toFloat(metadata["g_p_s_altitude"]); ok {
+			s.GPS.Altitude = &alt
+		}
+	}
+
+	s.Device = Device{
+		Make:     toString(metadata["make"]),
+		Model:    toString(metadata["model"]),
+		Software: toString(metadata["software"]),
+	}
+
+	s.Timestamps = Timestamps{
+		Captured:  firstString(metadata, "date_time_original", "creation_time"),
+		Digitized: toString(metadata["date_time_digitized"]),
+		Modified:  toString(metadata["date_time"]),
+	}
+
+	return s
+}
+
+// toFloat reads a number that may have been round-tripped through JSON or
+// Firestore, which don't preserve the original Go type
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func toString(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
+
+func firstString(metadata map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if s := toString(metadata[key]); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// capturedLayouts are the capture-time formats ExtractMetadata produces
+var capturedLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006:01:02 15:04:05"}
+
+// CapturedAt parses the capture time. EXIF times carry no zone and are
+// read as UTC, so they can be off by up to a day.
+func (t Timestamps) CapturedAt() (time.Time, bool) {
+	for _, layout := range capturedLayouts {
+		if parsed, err := time.Parse(layout, t.Captured); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
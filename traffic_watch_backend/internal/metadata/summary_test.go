@@ -0,0 +1,47 @@
+package metadata
+
+import "testing"
+
+func TestSummarize_Image(t *testing.T) {
+	s := Summarize(map[string]interface{}{
+		"gps_latitude":        41.5,
+		"gps_longitude":       int64(-81),
+		"g_p_s_altitude":      212.0,
+		"make":                "Apple",
+		"model":               "iPhone 15",
+		"date_time_original":  "2024-06-01T10:00:00",
+		"date_time_digitized": "2024:06:01 10:00:00",
+	})
+
+	if s.GPS == nil || s.GPS.Latitude != 41.5 || s.GPS.Longitude != -81 {
+		t.Fatalf("unexpected GPS: %+v", s.GPS)
+	}
+	if s.GPS.Altitude == nil || *s.GPS.Altitude != 212 {
+		t.Errorf("expected altitude 212, got %v", s.GPS.Altitude)
+	}
+	if s.Device.Make != "Apple" || s.Device.Model != "iPhone 15" {
+		t.Errorf("unexpected device: %+v", s.Device)
+	}
+	if s.Timestamps.Captured != "2024-06-01T10:00:00" {
+		t.Errorf("unexpected captured time: %q", s.Timestamps.Captured)
+	}
+}
+
+func TestSummarize_VideoAndStripped(t *testing.T) {
+	s := Summarize(map[string]interface{}{
+		"media_type":    "video",
+		"creation_time": "2024-06-01T10:00:00Z",
+	})
+	if s.Timestamps.Captured != "2024-06-01T10:00:00Z" {
+		t.Errorf("expected creation_time as captured, got %q", s.Timestamps.Captured)
+	}
+
+	// Half a coordinate isn't a location
+	s = Summarize(map[string]interface{}{"gps_latitude": 41.5})
+	if s.GPS != nil {
+		t.Errorf("expected no GPS, got %+v", s.GPS)
+	}
+	if Summarize(nil).GPS != nil {
+		t.Error("expected empty summary for nil metadata")
+	}
+}
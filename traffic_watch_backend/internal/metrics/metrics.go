@@ -0,0 +1,143 @@
+// Package metrics keeps in-process latency histograms for named operations.
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are histogram upper bounds suited to database calls
+var DefaultBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// Registry holds one histogram per operation name
+type Registry struct {
+	mu         sync.Mutex
+	buckets    []time.Duration
+	histograms map[string]*histogram
+}
+
+type histogram struct {
+	counts   []uint64 // counts[i] = observations <= buckets[i]; last slot is +Inf
+	count    uint64
+	errors   uint64
+	timeouts uint64 // Errors that were timeouts; also counted in errors
+	sum      time.Duration
+	max      time.Duration
+	last     time.Time // When the most recent observation was recorded
+}
+
+// NewRegistry creates an empty registry using DefaultBuckets
+func NewRegistry() *Registry {
+	return &Registry{
+		buckets:    DefaultBuckets,
+		histograms: make(map[string]*histogram),
+	}
+}
+
+// Observe records one operation duration and whether it failed
+func (r *Registry) Observe(name string, d time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	h := r.histogram(name)
+	i := sort.Search(len(r.buckets), func(i int) bool { return d <= r.buckets[i] })
+	h.counts[i]++
+	h.count++
+	h.sum += d
+	if d > h.max {
+		h.max = d
+	}
+	if failed {
+		h.errors++
+	}
+	h.last = time.Now()
+}
+
+// ObserveTimeout counts a failure of the operation that was caused by a timeout.
+// The call itself must still be recorded with Observe.
+func (r *Registry) ObserveTimeout(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.histogram(name).timeouts++
+}
+
+// histogram returns the named histogram, creating it if needed; r.mu must be held
+func (r *Registry) histogram(name string) *histogram {
+	h, ok := r.histograms[name]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets)+1)}
+		r.histograms[name] = h
+	}
+	return h
+}
+
+// Bucket is one histogram bucket; LE is the upper bound in milliseconds (0 means +Inf)
+type Bucket struct {
+	LE    float64 `json:"le"`
+	Count uint64  `json:"count"`
+}
+
+// OperationStats is a point-in-time view of one operation's histogram
+type OperationStats struct {
+	Name     string    `json:"name"`
+	Count    uint64    `json:"count"`
+	Errors   uint64    `json:"errors"`
+	Timeouts uint64    `json:"timeouts"` // Subset of Errors
+	TotalMs  float64   `json:"totalMs"`
+	MeanMs   float64   `json:"meanMs"`
+	MaxMs    float64   `json:"maxMs"`
+	Buckets  []Bucket  `json:"buckets"` // Cumulative counts
+	LastAt   time.Time `json:"lastAt"`
+}
+
+// Snapshot returns stats for every operation, sorted by name
+func (r *Registry) Snapshot() []OperationStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]OperationStats, 0, len(r.histograms))
+	for name, h := range r.histograms {
+		s := OperationStats{
+			Name:     name,
+			Count:    h.count,
+			Errors:   h.errors,
+			Timeouts: h.timeouts,
+			TotalMs:  millis(h.sum),
+			MaxMs:    millis(h.max),
+			LastAt:   h.last,
+			Buckets:  make([]Bucket, 0, len(h.counts)),
+		}
+		if h.count > 0 {
+			s.MeanMs = s.TotalMs / float64(h.count)
+		}
+		var cumulative uint64
+		for i, c := range h.counts {
+			cumulative += c
+			b := Bucket{Count: cumulative}
+			if i < len(r.buckets) {
+				b.LE = millis(r.buckets[i])
+			}
+			s.Buckets = append(s.Buckets, b)
+		}
+		stats = append(stats, s)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRegistry_ObserveAndSnapshot(t *testing.T) {
+	r := NewRegistry()
+	r.Observe("GetReport", 3*time.Millisecond, false)
+	r.Observe("GetReport", 40*time.Millisecond, false)
+	r.Observe("GetReport", 10*time.Second, true)
+	r.Observe("ListApprovedReports", 120*time.Millisecond, false)
+
+	snap := r.Snapshot()
+	if len(snap) != 2 {
+		t.Fatalf("expected 2 operations, got %d", len(snap))
+	}
+	if snap[0].Name != "GetReport" || snap[1].Name != "ListApprovedReports" {
+		t.Errorf("expected operations sorted by name, got %q, %q", snap[0].Name, snap[1].Name)
+	}
+
+	get := snap[0]
+	if get.Count != 3 || get.Errors != 1 {
+		t.Errorf("expected count 3 and 1 error, got %d and %d", get.Count, get.Errors)
+	}
+	if get.MaxMs != 10000 {
+		t.Errorf("expected max 10000ms, got %v", get.MaxMs)
+	}
+
+	// Buckets are cumulative and end with +Inf covering every observation
+	if len(get.Buckets) != len(DefaultBuckets)+1 {
+		t.Fatalf("expected %d buckets, got %d", len(DefaultBuckets)+1, len(get.Buckets))
+	}
+	if get.Buckets[0].LE != 5 || get.Buckets[0].Count != 1 {
+		t.Errorf("expected 1 observation <= 5ms, got %+v", get.Buckets[0])
+	}
+	if b := get.Buckets[3]; b.LE != 50 || b.Count != 2 {
+		t.Errorf("expected 2 observations <= 50ms, got %+v", b)
+	}
+	last := get.Buckets[len(get.Buckets)-1]
+	if last.LE != 0 || last.Count != 3 {
+		t.Errorf("expected +Inf bucket with all 3 observations, got %+v", last)
+	}
+}
+
+func TestRegistry_ConcurrentObserve(t *testing.T) {
+	r := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.Observe("op", time.Millisecond, false)
+		}()
+	}
+	wg.Wait()
+
+	if snap := r.Snapshot(); snap[0].Count != 50 {
+		t.Errorf("expected 50 observations, got %d", snap[0].Count)
+	}
+}
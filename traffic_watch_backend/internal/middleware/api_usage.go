@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/analytics"
+	"donzhit_me_backend/internal/models"
+)
+
+// maxConsumerLength caps the consumer key kept for a request
+const maxConsumerLength = 300
+
+// TrackAPIUsage counts each request in recorder by route pattern, consumer and
+// filter parameter names. Register it ahead of authentication so rejected
+// requests are counted too.
+func TrackAPIUsage(recorder *analytics.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		recorder.Record(time.Now(), c.Request.Method+" "+route, apiConsumer(c), c.Request.URL.Query(), c.Writer.Status())
+	}
+}
+
+// apiConsumer identifies who is using the API without identifying a person: the
+// research token for research routes, otherwise the host of the page that made
+// the request (from Origin, or Referer), or "direct" for apps and scripts
+func apiConsumer(c *gin.Context) string {
+	if token, ok := c.Get(ResearchTokenContextKey); ok {
+		if t, ok := token.(*models.ResearchToken); ok {
+			return "research:" + t.ID
+		}
+	}
+	for _, header := range []string{"Origin", "Referer"} {
+		if u, err := url.Parse(c.GetHeader(header)); err == nil && u.Host != "" {
+			consumer := "origin:" + u.Host
+			return consumer[:min(len(consumer), maxConsumerLength)]
+		}
+	}
+	return "direct"
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/analytics"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// usageStore collects flushed usage
+type usageStore struct {
+	storage.Client
+	flushed []models.APIUsage
+}
+
+func (s *usageStore) AddAPIUsage(ctx context.Context, usage []models.APIUsage) error {
+	s.flushed = append(s.flushed, usage...)
+	return nil
+}
+
+func TestTrackAPIUsage_RecordsRoutePatternAndConsumer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	store := &usageStore{}
+	recorder := analytics.NewRecorder(store)
+	router := gin.New()
+	router.Use(TrackAPIUsage(recorder))
+	router.GET("/v1/public/reports/:id", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/public/reports/abc?state=Ohio&limit=5", nil)
+	req.Header.Set("Origin", "https://embed.example.com")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	req = httptest.NewRequest(http.MethodGet, "/v1/public/reports/def", nil)
+	req.Header.Set("Referer", "https://blog.example.org/post?id=1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/nowhere", nil))
+
+	if err := recorder.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	want := map[string]models.APIUsage{
+		"origin:embed.example.com": {Route: "GET /v1/public/reports/:id", Filters: "state", Requests: 1, Errors: 1},
+		"origin:blog.example.org":  {Route: "GET /v1/public/reports/:id", Requests: 1, Errors: 1},
+		"direct":                   {Route: "GET unmatched", Requests: 1, Errors: 1},
+	}
+	if len(store.flushed) != len(want) {
+		t.Fatalf("flushed = %+v", store.flushed)
+	}
+	for _, u := range store.flushed {
+		w, ok := want[u.Consumer]
+		if !ok || u.Route != w.Route || u.Filters != w.Filters || u.Requests != w.Requests || u.Errors != w.Errors {
+			t.Errorf("unexpected usage %+v", u)
+		}
+	}
+}
+
+func TestAPIConsumer_PrefersResearchToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/v1/research/stats", nil)
+	c.Request.Header.Set("Origin", "https://embed.example.com")
+	c.Set(ResearchTokenContextKey, &models.ResearchToken{ID: "tok-1"})
+
+	if got := apiConsumer(c); got != "research:tok-1" {
+		t.Errorf("apiConsumer = %q, want research:tok-1", got)
+	}
+}
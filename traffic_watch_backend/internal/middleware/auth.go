@@ -3,8 +3,10 @@ package middleware
 import (
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"donzhit_me_backend/internal/apierror"
 	"donzhit_me_backend/internal/auth"
 	"donzhit_me_backend/internal/models"
 	"donzhit_me_backend/internal/storage"
@@ -17,6 +19,8 @@ const (
 	UserContextKey = "userInfo"
 	// FullUserContextKey is the key used to store the full User object
 	FullUserContextKey = "user"
+	// ClaimsContextKey is the key used to store the validated JWT claims
+	ClaimsContextKey = "jwtClaims"
 )
 
 // IAPAuth returns a middleware that validates IAP JWT tokens or Google Sign-In ID tokens
@@ -35,10 +39,7 @@ func IAPAuth(validator *auth.IAPValidator) gin.HandlerFunc {
 
 		userInfo, err := validator.ValidateToken(c.Request.Context(), token)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": "invalid or missing authentication token",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "invalid or missing authentication token")
 			return
 		}
 
@@ -63,44 +64,56 @@ func GetUserFromContext(c *gin.Context) (*models.UserInfo, bool) {
 func RequireUser(c *gin.Context) *models.UserInfo {
 	userInfo, ok := GetUserFromContext(c)
 	if !ok {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-			"error":   "unauthorized",
-			"message": "user not authenticated",
-		})
+		apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "user not authenticated")
 		return nil
 	}
 	return userInfo
 }
 
-// JWTAuth middleware validates DonzHit.me JWT tokens
+// sessionCookieToken returns the JWT from the session cookie, if any
+func sessionCookieToken(c *gin.Context) string {
+	token, err := c.Cookie(auth.SessionCookieName)
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+// cookieCSRFOK reports whether a cookie-authenticated request passes the
+// double-submit CSRF check; read-only methods are exempt
+func cookieCSRFOK(c *gin.Context) bool {
+	return auth.IsSafeMethod(c.Request.Method) || auth.ValidCSRF(c.Request)
+}
+
+// JWTAuth middleware validates DonzHit.me JWT tokens, sent either as a bearer
+// token or in the session cookie. Cookie sessions must pass the CSRF check on
+// mutating requests.
 func JWTAuth(jwtService *auth.JWTService, storageClient storage.Client) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		var token string
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": "missing authorization header",
-			})
-			return
-		}
-
-		if !strings.HasPrefix(authHeader, "Bearer ") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": "invalid authorization format",
-			})
-			return
+			token = sessionCookieToken(c)
+			if token == "" {
+				apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "missing authorization header")
+				return
+			}
+			if !cookieCSRFOK(c) {
+				apierror.Abort(c, http.StatusForbidden, apierror.CSRFFailed, "missing or invalid CSRF token")
+				return
+			}
+		} else {
+			if !strings.HasPrefix(authHeader, "Bearer ") {
+				apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "invalid authorization format")
+				return
+			}
+			token = authHeader[7:]
 		}
 
-		token := authHeader[7:]
-
 		claims, err := jwtService.ValidateToken(token)
 		if err != nil {
 			log.Printf("JWT validation failed: %v", err)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": "invalid or expired token",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "invalid or expired token")
 			return
 		}
 
@@ -108,25 +121,28 @@ func JWTAuth(jwtService *auth.JWTService, storageClient storage.Client) gin.Hand
 		user, err := storageClient.GetUserByID(c.Request.Context(), claims.UserID)
 		if err != nil {
 			log.Printf("User not found for JWT: %s", claims.UserID)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": "user not found",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "user not found")
+			return
+		}
+
+		// Deactivated and suspended accounts can't use the API until reactivated
+		if user.IsDeactivated() {
+			log.Printf("Rejected JWT for deactivated user: %s", user.Email)
+			apierror.Abort(c, http.StatusForbidden, apierror.AccountDeactivated, "account is deactivated")
 			return
 		}
 
 		// Check if token has been revoked
 		if user.JWTRefreshToken != claims.RefreshToken {
 			log.Printf("Token revoked for user: %s", user.Email)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": "token has been revoked",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "token has been revoked")
 			return
 		}
 
 		// Store full user in context
 		c.Set("user", user)
+		c.Set(ClaimsContextKey, claims)
+		slideSession(c, jwtService, user, claims, authHeader == "")
 		// Also store UserInfo for backwards compatibility with existing handlers
 		c.Set(UserContextKey, &models.UserInfo{
 			Email:   user.Email,
@@ -143,10 +159,7 @@ func RequireRole(requiredRole models.UserRole) gin.HandlerFunc {
 		user, exists := c.Get("user")
 		if !exists {
 			log.Printf("RequireRole: 'user' key not found in context")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "unauthorized",
-				"message": "not authenticated",
-			})
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "not authenticated")
 			return
 		}
 
@@ -155,19 +168,13 @@ func RequireRole(requiredRole models.UserRole) gin.HandlerFunc {
 		u, ok := user.(*models.User)
 		if !ok {
 			log.Printf("RequireRole: type assertion to *models.User failed, actual type: %T", user)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-				"error":   "internal_error",
-				"message": "invalid user context",
-			})
+			apierror.Abort(c, http.StatusInternalServerError, apierror.Internal, "invalid user context")
 			return
 		}
 
 		if !u.CanAccess(requiredRole) {
 			log.Printf("Access denied for user %s (role: %s, required: %s)", u.Email, u.Role, requiredRole)
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error":   "forbidden",
-				"message": "insufficient permissions",
-			})
+			apierror.Abort(c, http.StatusForbidden, apierror.Forbidden, "insufficient permissions")
 			return
 		}
 
@@ -178,14 +185,19 @@ func RequireRole(requiredRole models.UserRole) gin.HandlerFunc {
 // OptionalJWTAuth middleware allows both authenticated and unauthenticated requests
 func OptionalJWTAuth(jwtService *auth.JWTService, storageClient storage.Client) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		var token string
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
+		if strings.HasPrefix(authHeader, "Bearer ") {
+			token = authHeader[7:]
+		} else if authHeader == "" && cookieCSRFOK(c) {
+			token = sessionCookieToken(c)
+		}
+		if token == "" {
 			// No auth - continue as anonymous
 			c.Next()
 			return
 		}
 
-		token := authHeader[7:]
 		claims, err := jwtService.ValidateToken(token)
 		if err != nil {
 			// Invalid token - continue as anonymous
@@ -194,17 +206,53 @@ func OptionalJWTAuth(jwtService *auth.JWTService, storageClient storage.Client)
 		}
 
 		user, err := storageClient.GetUserByID(c.Request.Context(), claims.UserID)
-		if err != nil || user.JWTRefreshToken != claims.RefreshToken {
-			// User not found or token revoked - continue as anonymous
+		if err != nil || user.JWTRefreshToken != claims.RefreshToken || user.IsDeactivated() {
+			// User not found, token revoked or account deactivated - continue as anonymous
 			c.Next()
 			return
 		}
 
 		c.Set("user", user)
+		c.Set(ClaimsContextKey, claims)
 		c.Set(UserContextKey, &models.UserInfo{
 			Email:   user.Email,
 			Subject: user.ID,
 		})
+		slideSession(c, jwtService, user, claims, authHeader == "")
 		c.Next()
 	}
 }
+
+// GetClaimsFromContext retrieves the JWT claims stored by JWTAuth
+func GetClaimsFromContext(c *gin.Context) (*auth.JWTClaims, bool) {
+	value, exists := c.Get(ClaimsContextKey)
+	if !exists {
+		return nil, false
+	}
+
+	claims, ok := value.(*auth.JWTClaims)
+	return claims, ok
+}
+
+// slideSession re-issues the token once more than half its lifetime has passed.
+// The new token keeps the stored refresh token ID, so revocation still applies;
+// cookie sessions get a new cookie, bearer clients a response header to adopt.
+func slideSession(c *gin.Context, jwtService *auth.JWTService, user *models.User, claims *auth.JWTClaims, fromCookie bool) {
+	if !jwtService.NeedsRefresh(claims) {
+		return
+	}
+
+	token, expiresAt, err := jwtService.RefreshToken(user, claims)
+	if err != nil {
+		// Past the refresh lifetime: the current token stays valid until it expires
+		return
+	}
+
+	if fromCookie {
+		csrfToken, _ := c.Cookie(auth.CSRFCookieName)
+		auth.SetSessionCookies(c.Writer, jwtService.Config().Cookies, token, csrfToken, expiresAt)
+		return
+	}
+	c.Header(auth.RefreshedTokenHeader, token)
+	c.Header(auth.TokenExpiresAtHeader, strconv.FormatInt(expiresAt.Unix(), 10))
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -14,10 +15,17 @@ type CORSConfig struct {
 	AllowedHeaders   []string
 	ExposedHeaders   []string
 	AllowCredentials bool
-	MaxAge           int
+	// CredentialedOrigins narrows AllowCredentials to these origins; nil allows
+	// credentials from any allowed origin. Bearer tokens don't need credentials,
+	// so other origins can still call the API, just not with session cookies.
+	CredentialedOrigins []string
+	MaxAge              int
 }
 
-// DefaultCORSConfig returns a sensible default CORS configuration
+// DefaultCORSConfig returns a sensible default CORS configuration. Any Firebase
+// Hosting site may call the API, but only localhost may send credentials:
+// session cookies are SameSite=None when the web app is on another site, so
+// credentialed requests from third-party hosted apps would carry them.
 func DefaultCORSConfig() CORSConfig {
 	return CORSConfig{
 		AllowedOrigins: []string{
@@ -41,42 +49,74 @@ func DefaultCORSConfig() CORSConfig {
 			"Authorization",
 			"X-Requested-With",
 			"X-Goog-IAP-JWT-Assertion",
+			"X-CSRF-Token",
+			"X-Upload-Session",
 		},
 		ExposedHeaders: []string{
 			"Content-Length",
 			"Content-Type",
+			"X-Refreshed-Token",
+			"X-Token-Expires-At",
 		},
 		AllowCredentials: true,
-		MaxAge:           86400, // 24 hours
+		CredentialedOrigins: []string{
+			"http://localhost:*",
+			"https://localhost:*",
+		},
+		MaxAge: 86400, // 24 hours
 	}
 }
 
-// CORS returns a middleware that handles CORS
-func CORS(config CORSConfig) gin.HandlerFunc {
+// AdminCORSConfig returns the default configuration restricted to the given
+// first-party origins, with no wildcard hosting domains
+func AdminCORSConfig(origins []string) CORSConfig {
+	config := DefaultCORSConfig()
+	config.AllowedOrigins = origins
+	config.CredentialedOrigins = nil
+	return config
+}
+
+// PublicCORSConfig returns a configuration for read-mostly public endpoints
+// that any site may call, without credentials
+func PublicCORSConfig() CORSConfig {
+	config := DefaultCORSConfig()
+	config.AllowedOrigins = []string{"*"}
+	config.AllowedMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodOptions,
+	}
+	config.AllowCredentials = false
+	return config
+}
+
+// CORSGroup applies its own configuration to requests under PathPrefix
+type CORSGroup struct {
+	PathPrefix string
+	Config     CORSConfig
+}
+
+// CORS returns a middleware that handles CORS. Requests under one of the
+// groups' path prefixes use that group's config instead (longest prefix wins).
+// Groups are matched by path rather than mounted on the route groups because
+// preflight OPTIONS requests match no route and never reach group middleware.
+func CORS(defaultConfig CORSConfig, groups ...CORSGroup) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		config := corsConfigFor(c.Request.URL.Path, defaultConfig, groups)
 		origin := c.GetHeader("Origin")
 
-		// Check if origin is allowed
-		allowed := false
-		for _, pattern := range config.AllowedOrigins {
-			if matchOrigin(origin, pattern) {
-				allowed = true
-				break
-			}
-		}
-
-		if allowed {
+		if matchAnyOrigin(origin, config.AllowedOrigins) {
 			c.Header("Access-Control-Allow-Origin", origin)
 		}
 
-		if config.AllowCredentials {
+		if config.AllowCredentials && (config.CredentialedOrigins == nil || matchAnyOrigin(origin, config.CredentialedOrigins)) {
 			c.Header("Access-Control-Allow-Credentials", "true")
 		}
 
 		c.Header("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
 		c.Header("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
 		c.Header("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ", "))
-		c.Header("Access-Control-Max-Age", "86400")
+		c.Header("Access-Control-Max-Age", strconv.Itoa(config.MaxAge))
 
 		// Handle preflight requests
 		if c.Request.Method == http.MethodOptions {
@@ -88,6 +128,34 @@ func CORS(config CORSConfig) gin.HandlerFunc {
 	}
 }
 
+// corsConfigFor picks the config of the group with the longest prefix
+// containing path, falling back to the default
+func corsConfigFor(path string, defaultConfig CORSConfig, groups []CORSGroup) CORSConfig {
+	config := defaultConfig
+	longest := -1
+	for _, g := range groups {
+		prefix := strings.TrimSuffix(g.PathPrefix, "/")
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		if len(prefix) > longest {
+			config = g.Config
+			longest = len(prefix)
+		}
+	}
+	return config
+}
+
+// matchAnyOrigin checks if an origin matches any of the patterns
+func matchAnyOrigin(origin string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matchOrigin(origin, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // matchOrigin checks if an origin matches a pattern
 // Supports * as a wildcard
 func matchOrigin(origin, pattern string) bool {
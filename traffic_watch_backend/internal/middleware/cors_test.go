@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCORSGroups(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(CORS(DefaultCORSConfig(),
+		CORSGroup{PathPrefix: "/v1/admin", Config: AdminCORSConfig([]string{"https://donzhit.me"})},
+		CORSGroup{PathPrefix: "/v1/public", Config: PublicCORSConfig()},
+	))
+	router.GET("/v1/admin/reports", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/v1/public/reports", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/v1/reports", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		origin     string
+		wantOrigin string
+		wantCreds  string
+		wantStatus int
+	}{
+		{"admin first-party", http.MethodGet, "/v1/admin/reports", "https://donzhit.me", "https://donzhit.me", "true", http.StatusOK},
+		{"admin hosted app rejected", http.MethodGet, "/v1/admin/reports", "https://other.web.app", "", "true", http.StatusOK},
+		{"admin preflight rejected", http.MethodOptions, "/v1/admin/reports", "https://other.web.app", "", "true", http.StatusNoContent},
+		{"public any origin", http.MethodGet, "/v1/public/reports", "https://news.example", "https://news.example", "", http.StatusOK},
+		{"public preflight", http.MethodOptions, "/v1/public/reports", "https://news.example", "https://news.example", "", http.StatusNoContent},
+		{"default hosted app without credentials", http.MethodGet, "/v1/reports", "https://other.web.app", "https://other.web.app", "", http.StatusOK},
+		{"default localhost with credentials", http.MethodGet, "/v1/reports", "http://localhost:8080", "http://localhost:8080", "true", http.StatusOK},
+		{"prefix needs segment boundary", http.MethodOptions, "/v1/administrators", "https://other.web.app", "https://other.web.app", "", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials"); got != tt.wantCreds {
+				t.Errorf("Allow-Credentials = %q, want %q", got, tt.wantCreds)
+			}
+		})
+	}
+}
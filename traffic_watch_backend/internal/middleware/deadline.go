@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/deadline"
+)
+
+// TrackDeadlines lets storage calls that time out during the request turn its
+// error response into a 504 (see apierror.Timeout)
+func TrackDeadlines() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(deadline.Track(c.Request.Context()))
+		c.Next()
+	}
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// debugLogBodyLimit is the most of each body DebugLogging logs. Longer JSON bodies
+// can't be parsed to redact them, so only their size is logged.
+const debugLogBodyLimit = 8 << 10
+
+const redacted = "[REDACTED]"
+
+// Headers and JSON fields that carry credentials, compared case-insensitively
+var (
+	sensitiveHeaders = map[string]bool{
+		"authorization":            true,
+		"cookie":                   true,
+		"set-cookie":               true,
+		"x-csrf-token":             true,
+		"x-goog-iap-jwt-assertion": true,
+	}
+	sensitiveFields = map[string]bool{
+		"googletoken":   true,
+		"idtoken":       true,
+		"token":         true,
+		"accesstoken":   true,
+		"access_token":  true,
+		"refreshtoken":  true,
+		"refresh_token": true,
+		"csrftoken":     true,
+		"secret":        true,
+	}
+)
+
+// credentialParam matches credential query parameters in a URL or in any text
+// containing one, such as the signed media URLs in report responses
+var credentialParam = regexp.MustCompile(`(?i)([?&](?:x-goog-signature|x-goog-credential|signature|token)=)[^&\s"]+`)
+
+// DebugLogging logs each request and response, headers and JSON bodies included,
+// for troubleshooting. Credentials are redacted before anything is logged:
+// Authorization and cookie headers, token and secret fields, and the signature
+// of signed URLs. Other bodies (uploads, HTML) are logged by type and size only.
+// It is opt-in: bodies hold user content that shouldn't be logged routinely.
+func DebugLogging() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reqBody := peekRequestBody(c.Request)
+		writer := &debugLogWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		log.Printf("DEBUG %s %s -> %d\n  request headers: %s\n  request body: %s\n  response headers: %s\n  response body: %s",
+			c.Request.Method, credentialParam.ReplaceAllString(c.Request.URL.RequestURI(), "${1}"+redacted),
+			writer.Status(),
+			redactHeaders(c.Request.Header),
+			describeBody(c.Request.Header.Get("Content-Type"), reqBody.Bytes(), reqBody.truncated),
+			redactHeaders(writer.Header()),
+			describeBody(writer.Header().Get("Content-Type"), writer.body.Bytes(), writer.body.truncated),
+		)
+	}
+}
+
+// limitedBuffer keeps the first debugLogBodyLimit bytes written to it
+type limitedBuffer struct {
+	bytes.Buffer
+	truncated bool
+}
+
+func (b *limitedBuffer) keep(p []byte) {
+	if room := debugLogBodyLimit - b.Len(); len(p) > room {
+		p = p[:max(room, 0)]
+		b.truncated = true
+	}
+	b.Buffer.Write(p)
+}
+
+// peekRequestBody reads the start of a JSON request body and puts it back for the handler
+func peekRequestBody(r *http.Request) *limitedBuffer {
+	var buf limitedBuffer
+	if r.Body == nil || !strings.Contains(r.Header.Get("Content-Type"), "json") {
+		return &buf
+	}
+	head, err := io.ReadAll(io.LimitReader(r.Body, debugLogBodyLimit+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+	if err == nil {
+		buf.keep(head)
+	}
+	return &buf
+}
+
+// debugLogWriter copies the start of the response body as it is written
+type debugLogWriter struct {
+	gin.ResponseWriter
+	body limitedBuffer
+}
+
+func (w *debugLogWriter) Write(data []byte) (int, error) {
+	w.body.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *debugLogWriter) WriteString(s string) (int, error) {
+	w.body.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// describeBody renders a body for the log: redacted JSON, or just its type and size
+func describeBody(contentType string, body []byte, truncated bool) string {
+	switch {
+	case len(body) == 0:
+		return "(empty)"
+	case !strings.Contains(contentType, "json"):
+		return fmt.Sprintf("(%s, %d bytes)", contentType, len(body))
+	case truncated:
+		return fmt.Sprintf("(JSON over %d bytes, not logged)", debugLogBodyLimit)
+	}
+	return redactJSON(body)
+}
+
+// redactJSON replaces credential fields and signed URL signatures in a JSON document
+func redactJSON(body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return "(unparseable JSON, not logged)"
+	}
+	out, err := json.Marshal(redactValue(data))
+	if err != nil {
+		return "(unparseable JSON, not logged)"
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return credentialParam.ReplaceAllString(val, "${1}"+redacted)
+	case map[string]interface{}:
+		for k, field := range val {
+			if sensitiveFields[strings.ToLower(k)] {
+				val[k] = redacted
+			} else {
+				val[k] = redactValue(field)
+			}
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+// redactHeaders renders headers in a stable order with credentials replaced
+func redactHeaders(h http.Header) string {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(h[name], ", ")
+		if sensitiveHeaders[strings.ToLower(name)] {
+			value = redacted
+		}
+		parts = append(parts, name+": "+value)
+	}
+	return strings.Join(parts, "; ")
+}
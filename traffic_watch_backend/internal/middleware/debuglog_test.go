@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDebugLogging_RedactsCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var logs bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logs)
+
+	var handlerSaw string
+	router := gin.New()
+	router.Use(DebugLogging())
+	router.POST("/v1/auth/login", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		handlerSaw = string(body)
+		c.SetCookie("refresh_token", "cookie-secret", 60, "/", "", true, true)
+		c.JSON(http.StatusOK, gin.H{
+			"token":        "jwt-secret",
+			"refreshToken": "refresh-secret",
+			"user":         gin.H{"email": "ann@example.com"},
+			"mediaUrl":     "https://storage.googleapis.com/b/o.jpg?X-Goog-Algorithm=GOOG4-RSA-SHA256&X-Goog-Signature=sig-secret&X-Goog-Expires=900",
+		})
+	})
+
+	reqBody := `{"googleToken":"google-secret","deviceName":"Pixel"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth/login?token=query-secret&lang=es", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer header-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if handlerSaw != reqBody {
+		t.Errorf("handler read %q, want the untouched body", handlerSaw)
+	}
+	if !strings.Contains(w.Body.String(), "jwt-secret") {
+		t.Error("the response sent to the client must not be redacted")
+	}
+
+	out := logs.String()
+	for _, secret := range []string{"google-secret", "header-secret", "query-secret", "jwt-secret", "refresh-secret", "cookie-secret", "sig-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("%s leaked into the log:\n%s", secret, out)
+		}
+	}
+	for _, kept := range []string{"Pixel", "ann@example.com", "lang=es", "X-Goog-Expires=900", "POST /v1/auth/login"} {
+		if !strings.Contains(out, kept) {
+			t.Errorf("expected %q in the log:\n%s", kept, out)
+		}
+	}
+}
+
+func TestDescribeBody(t *testing.T) {
+	if got := describeBody("multipart/form-data; boundary=x", []byte("binary"), false); got != "(multipart/form-data; boundary=x, 6 bytes)" {
+		t.Errorf("multipart body = %q", got)
+	}
+	if got := describeBody("application/json", []byte(`{"secret":"s"`), true); strings.Contains(got, `"s"`) {
+		t.Errorf("truncated JSON was logged: %q", got)
+	}
+	if got := describeBody("application/json", nil, false); got != "(empty)" {
+		t.Errorf("empty body = %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/metrics"
+)
+
+// Deprecated marks every response as deprecated (RFC 9745). A non-zero sunset
+// adds a Sunset header (RFC 8594); successor, when set, maps the request path
+// to its replacement, advertised as a successor-version Link.
+func Deprecated(sunset time.Time, successor func(path string) string) gin.HandlerFunc {
+	sunsetHeader := ""
+	if !sunset.IsZero() {
+		sunsetHeader = sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		if sunsetHeader != "" {
+			c.Header("Sunset", sunsetHeader)
+		}
+		if successor != nil {
+			c.Header("Link", "<"+successor(c.Request.URL.Path)+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
+
+// DeprecatedFields marks a response as deprecated (RFC 9745) because the request
+// used deprecated fields, with a Warning naming each field's replacement.
+// replacements maps each deprecated field the client sent to its successor.
+func DeprecatedFields(c *gin.Context, replacements map[string]string) {
+	if len(replacements) == 0 {
+		return
+	}
+	c.Header("Deprecation", "true")
+	for _, field := range slices.Sorted(maps.Keys(replacements)) {
+		c.Writer.Header().Add("Warning", fmt.Sprintf(`299 - "%s is deprecated, use %s"`, field, replacements[field]))
+	}
+}
+
+// RouteMetrics records each request's latency in registry, keyed by method and
+// route pattern. Responses with a 5xx status count as errors.
+func RouteMetrics(registry *metrics.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		registry.Observe(c.Request.Method+" "+route, time.Since(start), c.Writer.Status() >= http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/metrics"
+)
+
+func TestDeprecatedAndRouteMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	registry := metrics.NewRegistry()
+	sunset := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	router := gin.New()
+	legacy := router.Group("/v1/legacy")
+	legacy.Use(RouteMetrics(registry))
+	legacy.Use(Deprecated(sunset, func(path string) string {
+		return strings.Replace(path, "/v1/legacy", "/v1", 1)
+	}))
+	legacy.GET("/reports/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/legacy/reports/abc", nil))
+
+	if got := w.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("expected Deprecation: true, got %q", got)
+	}
+	if got := w.Header().Get("Sunset"); got != "Sun, 01 Mar 2026 00:00:00 GMT" {
+		t.Errorf("unexpected Sunset header %q", got)
+	}
+	if got := w.Header().Get("Link"); got != `</v1/reports/abc>; rel="successor-version"` {
+		t.Errorf("unexpected Link header %q", got)
+	}
+
+	snap := registry.Snapshot()
+	if len(snap) != 1 || snap[0].Name != "GET /v1/legacy/reports/:id" || snap[0].Count != 1 {
+		t.Errorf("expected one observation for the route pattern, got %+v", snap)
+	}
+}
+
+func TestDeprecatedFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	DeprecatedFields(c, nil)
+	if got := w.Header().Get("Deprecation"); got != "" {
+		t.Errorf("no deprecated fields should leave no Deprecation header, got %q", got)
+	}
+
+	DeprecatedFields(c, map[string]string{"roadUsage": "roadUsages", "eventType": "eventTypes"})
+	if got := w.Header().Get("Deprecation"); got != "true" {
+		t.Errorf("expected Deprecation: true, got %q", got)
+	}
+	want := []string{`299 - "eventType is deprecated, use eventTypes"`, `299 - "roadUsage is deprecated, use roadUsages"`}
+	if got := w.Header().Values("Warning"); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("unexpected Warning headers %q", got)
+	}
+}
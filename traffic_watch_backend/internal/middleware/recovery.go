@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/errreport"
+)
+
+// Recovery replaces gin.Recovery: a panicking handler is answered with a 500 and
+// the panic is reported with its stack. Requests that end in any other 5xx are
+// reported too, with the errors handlers attached via c.Error. 503 and 504 are
+// left out: they mean a dependency is switched off or slow, not a bug.
+func Recovery(reporter errreport.Reporter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec) // net/http's signal to drop the connection quietly
+			}
+			err, ok := rec.(error)
+			if !ok {
+				err = fmt.Errorf("%v", rec)
+			}
+			reporter.Report(c.Request.Context(), requestEvent(c, err, debug.Stack(), http.StatusInternalServerError))
+			if c.Writer.Written() {
+				c.Abort() // Too late to change the response
+				return
+			}
+			apierror.Abort(c, http.StatusInternalServerError, apierror.Internal, "internal server error")
+		}()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout {
+			return
+		}
+		err := errors.New(http.StatusText(status))
+		if len(c.Errors) > 0 {
+			errs := make([]error, len(c.Errors))
+			for i, e := range c.Errors {
+				errs[i] = e.Err
+			}
+			err = errors.Join(errs...)
+		}
+		reporter.Report(c.Request.Context(), requestEvent(c, err, nil, status))
+	}
+}
+
+// requestEvent describes a failure of the request being served
+func requestEvent(c *gin.Context, err error, stack []byte, status int) errreport.Event {
+	e := errreport.Event{
+		Err:      err,
+		Stack:    stack,
+		Request:  c.Request,
+		ClientIP: c.ClientIP(),
+		Status:   status,
+		Location: c.FullPath(),
+	}
+	if user, ok := GetUserFromContext(c); ok {
+		e.UserID = user.Subject
+	}
+	return e
+}
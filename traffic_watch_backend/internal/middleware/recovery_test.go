@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/errreport"
+	"donzhit_me_backend/internal/models"
+)
+
+type recordingReporter struct {
+	events []errreport.Event
+}
+
+func (r *recordingReporter) Report(ctx context.Context, e errreport.Event) {
+	r.events = append(r.events, e)
+}
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reporter := &recordingReporter{}
+	router := gin.New()
+	router.Use(Recovery(reporter))
+	router.Use(func(c *gin.Context) {
+		c.Set(UserContextKey, &models.UserInfo{Subject: "user-1"})
+	})
+	router.GET("/panic/:id", func(c *gin.Context) { panic("boom") })
+	router.GET("/fail", func(c *gin.Context) {
+		_ = c.Error(errors.New("connection refused"))
+		apierror.Respond(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to fetch")
+	})
+	router.GET("/unavailable", func(c *gin.Context) {
+		apierror.Respond(c, http.StatusServiceUnavailable, apierror.Unavailable, "not configured")
+	})
+	router.GET("/missing", func(c *gin.Context) {
+		apierror.Respond(c, http.StatusNotFound, apierror.NotFound, "not found")
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := serve("/panic/42")
+	if w.Code != http.StatusInternalServerError || !strings.Contains(w.Body.String(), string(apierror.Internal)) {
+		t.Fatalf("panic: got %d %s", w.Code, w.Body.String())
+	}
+	if len(reporter.events) != 1 {
+		t.Fatalf("expected the panic to be reported, got %d events", len(reporter.events))
+	}
+	e := reporter.events[0]
+	if !e.Panicked() || e.Err.Error() != "boom" || e.UserID != "user-1" || e.Location != "/panic/:id" || e.Status != http.StatusInternalServerError {
+		t.Errorf("panic event = %+v", e)
+	}
+
+	serve("/fail")
+	if len(reporter.events) != 2 {
+		t.Fatalf("expected the 500 to be reported, got %d events", len(reporter.events))
+	}
+	e = reporter.events[1]
+	if e.Panicked() || !strings.Contains(e.Err.Error(), "connection refused") || !strings.Contains(e.Err.Error(), "fetch_failed") {
+		t.Errorf("500 event = %+v, want the attached error and the response code", e)
+	}
+
+	serve("/unavailable")
+	serve("/missing")
+	if len(reporter.events) != 2 {
+		t.Errorf("503 and 404 should not be reported, got %+v", reporter.events[2:])
+	}
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"donzhit_me_backend/internal/apierror"
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/ratelimit"
+	"donzhit_me_backend/internal/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ResearchTokenContextKey is the key used to store the ResearchToken in the context
+const ResearchTokenContextKey = "researchToken"
+
+// ResearchTokenAuth authenticates requests with a research token sent as a bearer
+// token. Each token is throttled by limiter and counted against its daily quota;
+// requests over either limit get a 429 with Retry-After.
+func ResearchTokenAuth(storageClient storage.Client, limiter *ratelimit.Limiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || !auth.IsResearchToken(secret) {
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "missing or invalid research token")
+			return
+		}
+
+		ctx := c.Request.Context()
+		token, err := storageClient.GetResearchTokenByHash(ctx, auth.HashResearchToken(secret))
+		if err != nil {
+			if !errors.Is(err, storage.ErrNotFound) {
+				log.Printf("Failed to look up research token: %v", err)
+				apierror.Abort(c, http.StatusInternalServerError, apierror.FetchFailed, "failed to verify research token")
+				return
+			}
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "missing or invalid research token")
+			return
+		}
+		now := time.Now()
+		if !token.Active(now) {
+			apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "research token has been revoked or has expired")
+			return
+		}
+
+		if ok, retryAfter := limiter.Allow(token.ID); !ok {
+			abortRateLimited(c, retryAfter, "too many requests; try again later")
+			return
+		}
+
+		allowed, err := storageClient.UseResearchToken(ctx, token.ID, models.UsageDay(now), token.DailyQuota)
+		if err != nil {
+			log.Printf("Failed to record research token usage for %s: %v", token.ID, err)
+			apierror.Abort(c, http.StatusInternalServerError, apierror.UpdateFailed, "failed to record research token usage")
+			return
+		}
+		if !allowed {
+			tomorrow := now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+			abortRateLimited(c, tomorrow.Sub(now), "daily research token quota exhausted")
+			return
+		}
+
+		c.Set(ResearchTokenContextKey, token)
+		c.Next()
+	}
+}
+
+// abortRateLimited stops the request with a 429 and a Retry-After in whole seconds
+func abortRateLimited(c *gin.Context, retryAfter time.Duration, message string) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	apierror.Abort(c, http.StatusTooManyRequests, apierror.RateLimited, message)
+}
+
+// RequireResearchToken returns the request's research token or aborts with 401
+func RequireResearchToken(c *gin.Context) *models.ResearchToken {
+	if value, exists := c.Get(ResearchTokenContextKey); exists {
+		if token, ok := value.(*models.ResearchToken); ok {
+			return token
+		}
+	}
+	apierror.Abort(c, http.StatusUnauthorized, apierror.Unauthorized, "research token required")
+	return nil
+}
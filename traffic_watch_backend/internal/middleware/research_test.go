@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestResearchTokenAuth_RejectsNonResearchCredentials(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// No storage: anything that isn't shaped like a research token must be refused before lookup
+	router.GET("/v1/research/stats", ResearchTokenAuth(nil, nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for name, header := range map[string]string{
+		"missing":      "",
+		"not bearer":   "Basic dzr_abc",
+		"jwt":          "Bearer eyJhbGciOiJIUzI1NiJ9.e30.sig",
+		"empty bearer": "Bearer ",
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/research/stats", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", name, w.Code)
+		}
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+
+	"donzhit_me_backend/internal/apierror"
 )
 
 // Common XSS patterns to strip
@@ -21,7 +23,9 @@ var xssPatterns = []*regexp.Regexp{
 	regexp.MustCompile(`(?i)data:\s*text/html`),
 }
 
-// SanitizeOutput returns a middleware that sanitizes JSON responses
+// SanitizeOutput returns a middleware that HTML-escapes every string in JSON responses.
+// It is not mounted: user text is sanitized once, before it is stored
+// (validation.SanitizeText), and escaping responses as well would double-escape it.
 func SanitizeOutput() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Create a response writer wrapper
@@ -146,7 +150,9 @@ func SanitizeURL(urlStr string) string {
 	return urlStr
 }
 
-// RequestSizeLimit returns a middleware that limits request body size
+// RequestSizeLimit returns a middleware that limits request body size. It reads
+// the whole body up front so oversized requests always get a 413; use
+// StreamingSizeLimit for routes that take large uploads.
 func RequestSizeLimit(maxBytes int64) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
@@ -154,10 +160,7 @@ func RequestSizeLimit(maxBytes int64) gin.HandlerFunc {
 		// Try to read a single byte to trigger the limit check
 		bodyBytes, err := io.ReadAll(c.Request.Body)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
-				"error":   "request_too_large",
-				"message": "request body exceeds maximum allowed size",
-			})
+			apierror.Abort(c, http.StatusRequestEntityTooLarge, apierror.RequestTooLarge, "request body exceeds maximum allowed size")
 			return
 		}
 
@@ -166,3 +169,17 @@ func RequestSizeLimit(maxBytes int64) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// StreamingSizeLimit returns a middleware that limits request body size without
+// buffering the body. Requests declaring a larger Content-Length get a 413 up
+// front; a body without one fails to read once it passes the limit.
+func StreamingSizeLimit(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > maxBytes {
+			apierror.Abort(c, http.StatusRequestEntityTooLarge, apierror.RequestTooLarge, "request body exceeds maximum allowed size")
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
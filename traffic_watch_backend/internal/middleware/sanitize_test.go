@@ -1,7 +1,13 @@
 package middleware
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/gin-gonic/gin"
 )
 
 func TestSanitizeString(t *testing.T) {
@@ -323,3 +329,38 @@ func TestMatchOrigin(t *testing.T) {
 		})
 	}
 }
+
+func TestStreamingSizeLimit(t *testing.T) {
+	router := gin.New()
+	router.Use(StreamingSizeLimit(10))
+	router.POST("/upload", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.String(http.StatusBadRequest, "read failed")
+			return
+		}
+		c.String(http.StatusOK, string(body))
+	})
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64 // -1 sends the body without a Content-Length
+		wantStatus    int
+	}{
+		{name: "within the limit", body: "0123456789", contentLength: 10, wantStatus: http.StatusOK},
+		{name: "declared length over the limit", body: "0123456789a", contentLength: 11, wantStatus: http.StatusRequestEntityTooLarge},
+		{name: "undeclared length over the limit", body: "0123456789a", contentLength: -1, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(tt.body))
+			req.ContentLength = tt.contentLength
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
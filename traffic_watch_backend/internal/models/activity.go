@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ReportRef identifies the report an activity item belongs to
+type ReportRef struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+	Link   string `json:"link"` // API path for the engagement on the report
+}
+
+// CommentActivity is one of the current user's comments with its report
+type CommentActivity struct {
+	Comment
+	Report ReportRef `json:"report"`
+}
+
+// ReactionActivity is one of the current user's reactions with its report
+type ReactionActivity struct {
+	ReactionType string     `json:"reactionType"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ModifiedAt   *time.Time `json:"modifiedAt,omitempty"`
+	Report       ReportRef  `json:"report"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Announcement severity constants, used by clients to style the banner
+const (
+	AnnouncementInfo     = "info"
+	AnnouncementWarning  = "warning"
+	AnnouncementCritical = "critical"
+)
+
+// Announcement is an admin-managed site-wide banner (maintenance windows, policy changes)
+type Announcement struct {
+	ID        string     `json:"id" firestore:"id"`
+	Title     string     `json:"title" firestore:"title"`
+	Message   string     `json:"message" firestore:"message"`
+	Severity  string     `json:"severity" firestore:"severity"`
+	LinkURL   string     `json:"linkUrl,omitempty" firestore:"linkUrl"`
+	StartsAt  time.Time  `json:"startsAt" firestore:"startsAt"`
+	EndsAt    *time.Time `json:"endsAt,omitempty" firestore:"endsAt"` // Nil shows the banner until it is deleted
+	CreatedBy string     `json:"-" firestore:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// IsActive reports whether the announcement should be shown at t
+func (a *Announcement) IsActive(t time.Time) bool {
+	return !a.StartsAt.After(t) && (a.EndsAt == nil || a.EndsAt.After(t))
+}
+
+// AnnouncementRequest represents a request to create or replace an announcement.
+// StartsAt defaults to now when omitted.
+type AnnouncementRequest struct {
+	Title    string     `json:"title" binding:"required,min=1,max=200"`
+	Message  string     `json:"message" binding:"required,min=1,max=2000"`
+	Severity string     `json:"severity" binding:"required,oneof=info warning critical"`
+	LinkURL  string     `json:"linkUrl" binding:"omitempty,url,max=500"`
+	StartsAt *time.Time `json:"startsAt"`
+	EndsAt   *time.Time `json:"endsAt"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// APIUsage counts the requests to one public route by one consumer with one
+// set of filters on a UTC day (see UsageDay)
+type APIUsage struct {
+	Day      string `json:"day" firestore:"day"`           // YYYY-MM-DD
+	Route    string `json:"route" firestore:"route"`       // Method and route pattern, e.g. "GET /v1/public/reports"
+	Consumer string `json:"consumer" firestore:"consumer"` // "research:<token ID>", "origin:<host>" or "direct"
+	Filters  string `json:"filters" firestore:"filters"`   // Sorted query parameter names, comma-separated; values are never kept
+	Requests int64  `json:"requests" firestore:"requests"`
+	Errors   int64  `json:"errors" firestore:"errors"` // Responses with a 4xx or 5xx status
+}
+
+// APIUsageCount totals requests for one route, consumer, filter or day in an APIUsageSummary
+type APIUsageCount struct {
+	Key      string `json:"key"`
+	Requests int64  `json:"requests"`
+	Errors   int64  `json:"errors"`
+}
+
+// APIUsageSummary totals public API usage from Since on
+type APIUsageSummary struct {
+	Since     string          `json:"since"` // YYYY-MM-DD
+	Requests  int64           `json:"requests"`
+	Errors    int64           `json:"errors"`
+	Routes    []APIUsageCount `json:"routes"`    // Busiest first
+	Consumers []APIUsageCount `json:"consumers"` // Busiest first
+	Filters   []APIUsageCount `json:"filters"`   // "<route>?<filter>" per filter used with the route, busiest first
+	Days      []APIUsageCount `json:"days"`      // Oldest first
+}
+
+// SummarizeAPIUsage totals daily usage rows by route, consumer, filter and day
+func SummarizeAPIUsage(usage []APIUsage, since string) APIUsageSummary {
+	routes, consumers, filters, days := map[string]*APIUsageCount{}, map[string]*APIUsageCount{}, map[string]*APIUsageCount{}, map[string]*APIUsageCount{}
+	add := func(counts map[string]*APIUsageCount, key string, u APIUsage) {
+		c, ok := counts[key]
+		if !ok {
+			c = &APIUsageCount{Key: key}
+			counts[key] = c
+		}
+		c.Requests += u.Requests
+		c.Errors += u.Errors
+	}
+
+	summary := APIUsageSummary{Since: since}
+	for _, u := range usage {
+		summary.Requests += u.Requests
+		summary.Errors += u.Errors
+		add(routes, u.Route, u)
+		add(consumers, u.Consumer, u)
+		add(days, u.Day, u)
+		if u.Filters != "" {
+			for _, f := range strings.Split(u.Filters, ",") {
+				add(filters, u.Route+"?"+f, u)
+			}
+		}
+	}
+
+	busiest := func(a, b APIUsageCount) int {
+		return cmp.Or(cmp.Compare(b.Requests, a.Requests), strings.Compare(a.Key, b.Key))
+	}
+	summary.Routes = sortedCounts(routes, busiest)
+	summary.Consumers = sortedCounts(consumers, busiest)
+	summary.Filters = sortedCounts(filters, busiest)
+	summary.Days = sortedCounts(days, func(a, b APIUsageCount) int { return strings.Compare(a.Key, b.Key) })
+	return summary
+}
+
+func sortedCounts(counts map[string]*APIUsageCount, compare func(a, b APIUsageCount) int) []APIUsageCount {
+	sorted := make([]APIUsageCount, 0, len(counts))
+	for _, c := range counts {
+		sorted = append(sorted, *c)
+	}
+	slices.SortFunc(sorted, compare)
+	return sorted
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"fmt"
+)
+
+// ContributorName is the pseudonym shown for a user without a display name:
+// "Contributor #" and four digits derived from the user ID, so it is stable but
+// doesn't reveal who the user is. Migration 040 computes the same number in SQL
+// to backfill stored comments; change both together.
+func ContributorName(userID string) string {
+	sum := md5.Sum([]byte(userID))
+	return fmt.Sprintf("Contributor #%04d", binary.BigEndian.Uint32(sum[:4])%10000)
+}
+
+// AuthorName is how a user is named next to their public content: their
+// display name if they set one, otherwise their contributor pseudonym
+func AuthorName(userID, displayName string) string {
+	if displayName != "" {
+		return displayName
+	}
+	return ContributorName(userID)
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Actions for comments containing links from untrusted users
+const (
+	LinkActionAllow  = "allow"  // Publish the comment as usual
+	LinkActionHold   = "hold"   // Accept the comment but hold it for moderator review
+	LinkActionReject = "reject" // Refuse the comment
+)
+
+// CommentPolicy holds the admin-configurable thresholds that keep comment spam out
+type CommentPolicy struct {
+	RateLimit              int        `json:"rateLimit" firestore:"rateLimit"`                           // Comments a user may post per rate window; 0 is unlimited
+	RateWindowMinutes      int        `json:"rateWindowMinutes" firestore:"rateWindowMinutes"`           // Length of the rate window
+	LinkAction             string     `json:"linkAction" firestore:"linkAction"`                         // What happens to comments with links from users whose trust level doesn't allow them
+	DuplicateLimit         int        `json:"duplicateLimit" firestore:"duplicateLimit"`                 // Identical comments a user may post per duplicate window before the rest are held; 0 is unlimited
+	DuplicateWindowMinutes int        `json:"duplicateWindowMinutes" firestore:"duplicateWindowMinutes"` // Length of the duplicate window
+	UpdatedBy              string     `json:"updatedBy,omitempty" firestore:"updatedBy"`
+	UpdatedAt              *time.Time `json:"updatedAt,omitempty" firestore:"updatedAt"` // Nil while the defaults are in effect
+}
+
+// RateWindow returns the rate window as a duration
+func (p CommentPolicy) RateWindow() time.Duration {
+	return time.Duration(p.RateWindowMinutes) * time.Minute
+}
+
+// DuplicateWindow returns the duplicate window as a duration
+func (p CommentPolicy) DuplicateWindow() time.Duration {
+	return time.Duration(p.DuplicateWindowMinutes) * time.Minute
+}
+
+// DefaultCommentPolicy returns the policy in effect until an admin changes it
+func DefaultCommentPolicy() CommentPolicy {
+	return CommentPolicy{
+		RateLimit:              10,
+		RateWindowMinutes:      10,
+		LinkAction:             LinkActionHold,
+		DuplicateLimit:         2,
+		DuplicateWindowMinutes: 24 * 60,
+	}
+}
+
+// UpdateCommentPolicyRequest represents a request to change the comment policy
+type UpdateCommentPolicyRequest struct {
+	RateLimit              int    `json:"rateLimit" binding:"gte=0,lte=1000"`
+	RateWindowMinutes      int    `json:"rateWindowMinutes" binding:"gte=1,lte=10080"`
+	LinkAction             string `json:"linkAction" binding:"required,oneof=allow hold reject"`
+	DuplicateLimit         int    `json:"duplicateLimit" binding:"gte=0,lte=1000"`
+	DuplicateWindowMinutes int    `json:"duplicateWindowMinutes" binding:"gte=1,lte=10080"`
+}
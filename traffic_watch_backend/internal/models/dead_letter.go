@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Media jobs recorded in the dead-letter queue
+const (
+	MediaJobYouTubeUpload = "youtube_upload" // The video was kept in GCS instead; a retry moves it to YouTube
+	MediaJobGCSUpload     = "gcs_upload"     // The file was never stored, so the submitter has to upload it again
+	MediaJobUploadCheck   = "upload_check"   // A signed-URL upload never arrived or failed its check and was deleted
+	MediaJobYouTubeDelete = "youtube_delete" // A video no report uses is still on the channel; the error names it
+)
+
+// MediaFailure is a media job that failed permanently, kept for admins to inspect and retry
+type MediaFailure struct {
+	ID            string     `json:"id"`
+	Job           string     `json:"job"`
+	ReportID      string     `json:"reportId"`
+	MediaID       string     `json:"mediaId,omitempty"`
+	FileName      string     `json:"fileName"`
+	Error         string     `json:"error"` // From the latest attempt
+	Attempts      int        `json:"attempts"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	LastAttemptAt time.Time  `json:"lastAttemptAt"`
+	ResolvedAt    *time.Time `json:"resolvedAt,omitempty"` // Set once a retry succeeds
+	Retryable     bool       `json:"retryable" firestore:"-"`
+}
+
+// CanRetry reports whether the failed job can be run again: the file has to have been kept
+func (f *MediaFailure) CanRetry() bool {
+	return f.Job == MediaJobYouTubeUpload && f.ResolvedAt == nil
+}
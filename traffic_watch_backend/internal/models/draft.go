@@ -0,0 +1,65 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// MaxDraftsPerUser caps how many unfinished reports one user can keep
+const MaxDraftsPerUser = 20
+
+// ReportDraft is a report the user started but hasn't submitted. Every field is
+// optional; validation happens when the report is created from it.
+type ReportDraft struct {
+	ID          string     `json:"id" firestore:"id"`
+	UserID      string     `json:"-" firestore:"userId"`
+	Title       string     `json:"title,omitempty" firestore:"title"`
+	Description string     `json:"description,omitempty" firestore:"description"`
+	RoadUsages  []string   `json:"roadUsages" firestore:"roadUsages"`
+	EventTypes  []string   `json:"eventTypes" firestore:"eventTypes"`
+	State       string     `json:"state,omitempty" firestore:"state"`
+	City        string     `json:"city,omitempty" firestore:"city"`
+	Latitude    *float64   `json:"latitude,omitempty" firestore:"latitude"`
+	Longitude   *float64   `json:"longitude,omitempty" firestore:"longitude"`
+	CreatedAt   time.Time  `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt" firestore:"updatedAt"`
+	RemindedAt  *time.Time `json:"-" firestore:"remindedAt"` // Last reminder; a later UpdatedAt re-arms it
+}
+
+// ReportDraftRequest represents a request to save a report draft
+type ReportDraftRequest struct {
+	Title       string   `json:"title" binding:"max=200"`
+	Description string   `json:"description" binding:"max=5000"`
+	RoadUsages  []string `json:"roadUsages" binding:"dive,roadusage"`
+	EventTypes  []string `json:"eventTypes" binding:"dive,eventtype"`
+	State       string   `json:"state" binding:"omitempty,stateorprovince"`
+	City        string   `json:"city" binding:"max=100"`
+	Latitude    *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude   *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+// Subject is how reminders refer to the draft: its title, else where it happened
+func (d *ReportDraft) Subject() string {
+	if d.Title != "" {
+		return d.Title
+	}
+	if d.City != "" && d.State != "" {
+		return d.City + ", " + d.State
+	}
+	return d.City + d.State
+}
+
+// DraftRef names a draft in signed links, which carry no login: the owner and
+// the draft, so the link can only ever discard that user's draft
+func DraftRef(userID, draftID string) string {
+	return userID + "/" + draftID
+}
+
+// ParseDraftRef splits a DraftRef. Draft IDs never contain "/", so it splits at the last one.
+func ParseDraftRef(ref string) (userID, draftID string, ok bool) {
+	i := strings.LastIndex(ref, "/")
+	if i <= 0 || i == len(ref)-1 {
+		return "", "", false
+	}
+	return ref[:i], ref[i+1:], true
+}
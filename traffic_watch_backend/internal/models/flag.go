@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// FeatureFlag controls gradual rollout of a client or server feature
+type FeatureFlag struct {
+	Key            string    `json:"key" firestore:"key"`
+	Description    string    `json:"description,omitempty" firestore:"description"`
+	Enabled        bool      `json:"enabled" firestore:"enabled"`
+	RolloutPercent int       `json:"rolloutPercent" firestore:"rolloutPercent"` // Share of signed-in users (0-100) who get the flag when enabled
+	UserIDs        []string  `json:"userIds" firestore:"userIds"`               // Always on for these users, even when disabled
+	UpdatedBy      string    `json:"updatedBy,omitempty" firestore:"updatedBy"`
+	UpdatedAt      time.Time `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// UpsertFeatureFlagRequest represents a request to create or replace a feature flag
+type UpsertFeatureFlagRequest struct {
+	Description    string   `json:"description" binding:"max=500"`
+	Enabled        bool     `json:"enabled"`
+	RolloutPercent *int     `json:"rolloutPercent" binding:"omitempty,gte=0,lte=100"` // Defaults to 100
+	UserIDs        []string `json:"userIds" binding:"max=1000,dive,min=1,max=255"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Incident groups reports that admins have linked as describing the same event
+type Incident struct {
+	ID        string    `json:"id" firestore:"id"`
+	Note      string    `json:"note,omitempty" firestore:"note"`
+	CreatedBy string    `json:"createdBy" firestore:"createdBy"`
+	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" firestore:"updatedAt"`
+	ReportIDs []string  `json:"reportIds" firestore:"-"`
+}
+
+// RelatedReport is a report that shares a vehicle match key with another report
+type RelatedReport struct {
+	Report    TrafficReport `json:"report"`
+	MatchedOn []string      `json:"matchedOn"` // "plate" and/or "vehicle"
+}
+
+// LinkIncidentRequest represents a request to group reports into an incident
+type LinkIncidentRequest struct {
+	ReportIDs  []string `json:"reportIds" binding:"required,min=1,dive,uuid"`
+	IncidentID string   `json:"incidentId" binding:"omitempty,uuid"`
+	Note       string   `json:"note" binding:"max=1000"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// RoleInvitation grants Role to whoever signs in as Email and accepts it before ExpiresAt
+type RoleInvitation struct {
+	ID         string     `json:"id" firestore:"id"`
+	Email      string     `json:"email" firestore:"email"`
+	Role       UserRole   `json:"role" firestore:"role"`
+	InvitedBy  string     `json:"invitedBy" firestore:"invitedBy"`
+	CreatedAt  time.Time  `json:"createdAt" firestore:"createdAt"`
+	ExpiresAt  time.Time  `json:"expiresAt" firestore:"expiresAt"`
+	AcceptedAt *time.Time `json:"acceptedAt,omitempty" firestore:"acceptedAt"`
+	AcceptedBy string     `json:"acceptedBy,omitempty" firestore:"acceptedBy"`
+}
+
+// CreateInvitationRequest represents an admin request to invite someone to a role
+type CreateInvitationRequest struct {
+	Email string   `json:"email" binding:"required,email,max=255"`
+	Role  UserRole `json:"role" binding:"required"`
+}
+
+// AcceptInvitationRequest carries the signed token from an invitation email
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// Audit log actions
+const (
+	AuditRoleChanged          = "role_changed"
+	AuditInvitationCreated    = "invitation_created"
+	AuditInvitationAccepted   = "invitation_accepted"
+	AuditResearchTokenCreated = "research_token_created"
+	AuditResearchTokenRevoked = "research_token_revoked"
+	AuditMediaDownloaded      = "media_downloaded"
+	AuditReportsImported      = "reports_imported"
+	AuditCommentPolicyChanged = "comment_policy_changed"
+)
+
+// AuditEntry records an administrative action or an access to sensitive data
+type AuditEntry struct {
+	ID        string    `json:"id" firestore:"id"`
+	ActorID   string    `json:"actorId" firestore:"actorId"`
+	Action    string    `json:"action" firestore:"action"`
+	TargetID  string    `json:"targetId,omitempty" firestore:"targetId"`
+	Details   string    `json:"details,omitempty" firestore:"details"`
+	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
+}
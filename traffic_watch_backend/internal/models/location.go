@@ -0,0 +1,32 @@
+package models
+
+// BoundingBox is a map viewport in degrees. MinLng may exceed MaxLng when the
+// viewport crosses the antimeridian.
+type BoundingBox struct {
+	MinLng float64 `json:"minLng"`
+	MinLat float64 `json:"minLat"`
+	MaxLng float64 `json:"maxLng"`
+	MaxLat float64 `json:"maxLat"`
+}
+
+// ReportLocation is the minimal projection of a report needed to place it on a map
+type ReportLocation struct {
+	ID        string  `json:"id"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// ReportCluster is a group of nearby reports rendered as a single map marker
+type ReportCluster struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Count     int     `json:"count"`
+	ReportID  string  `json:"reportId,omitempty"` // Set only when the cluster holds a single report
+}
+
+// ReportClustersResponse represents the response for the public map clusters endpoint
+type ReportClustersResponse struct {
+	Clusters []ReportCluster `json:"clusters"`
+	Zoom     int             `json:"zoom"`
+	Total    int             `json:"total"`
+}
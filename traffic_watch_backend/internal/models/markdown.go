@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+
+	"donzhit_me_backend/internal/markdown"
+)
+
+// Descriptions and comments are stored as the Markdown the user wrote. Every JSON
+// response carries it raw alongside a server-rendered, safe HTML copy, so clients
+// can display formatting without parsing or sanitizing it themselves.
+
+// MarshalJSON adds descriptionHtml, the description rendered from Markdown, and
+// renders dateTime with the submitter's offset so clients can show the local
+// time without a zone lookup (see LocalDateTime)
+func (r TrafficReport) MarshalJSON() ([]byte, error) {
+	type plain TrafficReport
+	p := plain(r)
+	p.DateTime = r.LocalDateTime()
+	return json.Marshal(struct {
+		plain
+		DescriptionHTML string `json:"descriptionHtml"`
+	}{p, markdown.Render(r.Description)})
+}
+
+// MarshalJSON adds contentHtml, the comment rendered from Markdown
+func (c Comment) MarshalJSON() ([]byte, error) {
+	type comment Comment
+	return json.Marshal(struct {
+		comment
+		ContentHTML string `json:"contentHtml"`
+	}{comment(c), markdown.Render(c.Content)})
+}
@@ -0,0 +1,60 @@
+package models
+
+// Media state constants. Media uploaded through the server is checked before the
+// report is stored and starts out ready; files uploaded with signed URLs start out
+// processing and are moved along by the check-uploads job.
+const (
+	MediaProcessing  = "processing"  // Attached, waiting for the upload to arrive
+	MediaQuarantined = "quarantined" // Uploaded, content not checked yet; never served
+	MediaReady       = "ready"       // Checked and served
+	MediaFailed      = "failed"      // Never arrived or failed the check; not retried
+)
+
+// mediaTransitions lists the states each media state may move to
+var mediaTransitions = map[string][]string{
+	MediaProcessing:  {MediaQuarantined, MediaFailed},
+	MediaQuarantined: {MediaReady, MediaFailed},
+}
+
+// ValidMediaTransition reports whether media in state from may move to state to
+func ValidMediaTransition(from, to string) bool {
+	for _, next := range mediaTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectiveState returns the media file's state, counting media stored before
+// states were tracked as ready
+func (m *MediaFile) EffectiveState() string {
+	if m.State == "" {
+		return MediaReady
+	}
+	return m.State
+}
+
+// Pending reports whether the media file is still being processed or checked
+func (m *MediaFile) Pending() bool {
+	state := m.EffectiveState()
+	return state == MediaProcessing || state == MediaQuarantined
+}
+
+// MediaPending reports whether any of the report's media is still being processed
+// or checked. Such reports are kept out of the review queue and the public feed.
+func (r *TrafficReport) MediaPending() bool {
+	for i := range r.MediaFiles {
+		if r.MediaFiles[i].Pending() {
+			return true
+		}
+	}
+	return false
+}
+
+// MediaStateJob is a media file the check-uploads job still has work for
+type MediaStateJob struct {
+	ReportID string
+	UserID   string
+	Media    MediaFile
+}
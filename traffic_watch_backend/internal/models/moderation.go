@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Blocked word actions, from weakest to strongest
+const (
+	WordActionMask   = "mask"   // Replace the word with asterisks and accept the text
+	WordActionFlag   = "flag"   // Accept the text but hold it for moderator review
+	WordActionReject = "reject" // Refuse the submission
+)
+
+// BlockedWord is an entry in the configurable word filter
+type BlockedWord struct {
+	Word      string    `json:"word" firestore:"word"`
+	Action    string    `json:"action" firestore:"action"`
+	CreatedBy string    `json:"createdBy,omitempty" firestore:"createdBy"`
+	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
+}
+
+// UpsertBlockedWordRequest represents a request to add or change a blocked word
+type UpsertBlockedWordRequest struct {
+	Word   string `json:"word" binding:"required,min=1,max=100"`
+	Action string `json:"action" binding:"required,oneof=mask flag reject"`
+}
+
+// ResolveCommentRequest represents a moderator decision on a flagged comment
+type ResolveCommentRequest struct {
+	Approve bool `json:"approve"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Notification is an entry in a user's in-app inbox. Inbox entries are kept
+// regardless of email/push preferences so clients can always show them.
+type Notification struct {
+	ID        string     `json:"id" firestore:"id"`
+	UserID    string     `json:"-" firestore:"userId"`
+	Kind      string     `json:"kind" firestore:"kind"`
+	ReportID  string     `json:"reportId,omitempty" firestore:"reportId"`
+	Title     string     `json:"title" firestore:"title"`
+	Body      string     `json:"body" firestore:"body"`
+	ReadAt    *time.Time `json:"readAt,omitempty" firestore:"readAt"`
+	CreatedAt time.Time  `json:"createdAt" firestore:"createdAt"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// OCR status constants
+const (
+	OCRCompleted = "completed" // Text read (may be empty if the image has none)
+	OCRFailed    = "failed"    // Text detection failed; not retried
+	OCRPurged    = "purged"    // Results deleted on request; the image is not read again
+)
+
+// MediaOCR is the text read from an image by OCR. It can contain raw plate numbers,
+// so it is admin-only: MediaFile never serializes it, and it is only returned by
+// the admin media metadata endpoint.
+type MediaOCR struct {
+	Status          string     `json:"status" firestore:"status"`
+	Text            string     `json:"text,omitempty" firestore:"text"`
+	PlateCandidates []string   `json:"plateCandidates,omitempty" firestore:"plateCandidates"`
+	Error           string     `json:"error,omitempty" firestore:"error"`
+	ProcessedAt     time.Time  `json:"processedAt" firestore:"processedAt"`
+	PurgedBy        string     `json:"purgedBy,omitempty" firestore:"purgedBy"`
+	PurgedAt        *time.Time `json:"purgedAt,omitempty" firestore:"purgedAt"`
+}
+
+// OCRJob is an image the OCR job has not read yet
+type OCRJob struct {
+	ReportID string
+	UserID   string
+	Media    MediaFile
+}
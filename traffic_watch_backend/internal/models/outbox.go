@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// OutboxEvent is a report lifecycle event waiting in the outbox for the relay to
+// deliver it to external sinks (see events.Relay)
+type OutboxEvent struct {
+	ID            string     `json:"id" firestore:"id"`
+	Type          string     `json:"type" firestore:"type"`
+	ReportID      string     `json:"reportId" firestore:"reportId"`
+	CommentID     string     `json:"commentId,omitempty" firestore:"commentId"`
+	Actor         string     `json:"actor,omitempty" firestore:"actor"` // Email of the user who made the change
+	OccurredAt    time.Time  `json:"occurredAt" firestore:"occurredAt"`
+	Attempts      int        `json:"attempts" firestore:"attempts"`
+	LastError     string     `json:"lastError,omitempty" firestore:"lastError"`
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty" firestore:"nextAttemptAt"` // Nil once delivered or given up on
+	DeliveredAt   *time.Time `json:"deliveredAt,omitempty" firestore:"deliveredAt"`
+}
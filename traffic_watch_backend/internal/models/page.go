@@ -0,0 +1,14 @@
+package models
+
+// Pagination describes where a /v2 page sits in its list
+type Pagination struct {
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"nextCursor,omitempty"` // Pass as ?cursor= to fetch the next page
+	HasMore    bool   `json:"hasMore"`
+}
+
+// PageResponse is the /v2 list envelope
+type PageResponse[T any] struct {
+	Data       []T        `json:"data"`
+	Pagination Pagination `json:"pagination"`
+}
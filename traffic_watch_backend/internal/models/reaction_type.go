@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// ReactionType is a reaction users can leave on a report. Types are managed by
+// admins and retired rather than deleted, so existing reactions keep their meaning.
+type ReactionType struct {
+	Type      string     `json:"type" firestore:"type"`
+	Label     string     `json:"label" firestore:"label"`
+	Emoji     string     `json:"emoji,omitempty" firestore:"emoji"`
+	Score     int        `json:"score" firestore:"score"`                 // Priority delta a reaction of this type adds to its report
+	MaxScore  int        `json:"maxScore,omitempty" firestore:"maxScore"` // Cap on the total priority change reactions of this type make to one report; 0 is uncapped
+	SortOrder int        `json:"sortOrder" firestore:"sortOrder"`         // Position clients render the type at, ascending
+	RetiredAt *time.Time `json:"retiredAt,omitempty" firestore:"retiredAt"`
+	UpdatedBy string     `json:"updatedBy,omitempty" firestore:"updatedBy"`
+	UpdatedAt time.Time  `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// Active reports whether users can still add reactions of this type
+func (t ReactionType) Active() bool {
+	return t.RetiredAt == nil
+}
+
+// Contribution is the priority change count reactions of this type make to a report,
+// limited to MaxScore in either direction when a cap is set
+func (t ReactionType) Contribution(count int) int {
+	total := t.Score * count
+	if t.MaxScore > 0 {
+		total = min(max(total, -t.MaxScore), t.MaxScore)
+	}
+	return total
+}
+
+// UpsertReactionTypeRequest represents a request to add a reaction type or change an existing one.
+// Saving a retired type brings it back.
+type UpsertReactionTypeRequest struct {
+	Label     string `json:"label" binding:"required,min=1,max=50"`
+	Emoji     string `json:"emoji" binding:"max=16"`
+	Score     int    `json:"score" binding:"gte=-10,lte=10"`
+	MaxScore  int    `json:"maxScore" binding:"gte=0,lte=1000"`
+	SortOrder int    `json:"sortOrder" binding:"gte=0,lte=1000"`
+}
+
+// DefaultReactionTypes returns the built-in reaction set, used until storage has been seeded
+func DefaultReactionTypes() []ReactionType {
+	return []ReactionType{
+		{Type: ReactionThumbsUp, Label: "Thumbs up", Emoji: "👍", Score: 2, SortOrder: 10},
+		{Type: ReactionThumbsDown, Label: "Thumbs down", Emoji: "👎", Score: -1, SortOrder: 20},
+		{Type: ReactionAngryCar, Label: "Angry driver", Emoji: "🚗", Score: 3, SortOrder: 30},
+		{Type: ReactionAngryPedestrian, Label: "Angry pedestrian", Emoji: "🚶", Score: 3, SortOrder: 40},
+		{Type: ReactionAngryBicycle, Label: "Angry cyclist", Emoji: "🚲", Score: 3, SortOrder: 50},
+	}
+}
@@ -1,61 +1,200 @@
 package models
 
 import (
+	"slices"
+	"strings"
 	"time"
 )
 
 // MediaFile represents an uploaded media file (image or video)
 type MediaFile struct {
-	ID          string                 `json:"id" firestore:"id"`
-	FileName    string                 `json:"fileName" firestore:"fileName"`
-	ContentType string                 `json:"contentType" firestore:"contentType"`
-	Size        int64                  `json:"size" firestore:"size"`
-	URL         string                 `json:"url" firestore:"url"`
-	UploadedAt  time.Time              `json:"uploadedAt" firestore:"uploadedAt"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty" firestore:"metadata"`
+	ID                  string        `json:"id" firestore:"id"`
+	FileName            string        `json:"fileName" firestore:"fileName"`
+	ContentType         string        `json:"contentType" firestore:"contentType"`
+	Size                int64         `json:"size" firestore:"size"`
+	URL                 string        `json:"url" firestore:"url"`
+	URLExpiresAt        *time.Time    `json:"urlExpiresAt,omitempty" firestore:"-"` // When a signed URL stops working; unset for URLs that don't expire
+	UploadedAt          time.Time     `json:"uploadedAt" firestore:"uploadedAt"`
+	Metadata            MediaMetadata `json:"-" firestore:"metadata"`                                // Admin-only, see GetMediaMetadata; may hold GPS and capture times
+	StoragePath         string        `json:"-" firestore:"storagePath,omitempty"`                   // Set when the object lives outside the report's own path (e.g. after a merge)
+	Transcript          string        `json:"transcript,omitempty" firestore:"transcript,omitempty"` // Speech in a video, once transcribed
+	TranscriptStatus    string        `json:"transcriptStatus,omitempty" firestore:"transcriptStatus,omitempty"`
+	TranscriptOperation string        `json:"-" firestore:"transcriptOperation,omitempty"` // Running transcription while pending
+	OCR                 *MediaOCR     `json:"-" firestore:"ocr,omitempty"`                 // Admin-only text read from images, see GetMediaMetadata
+	State               string        `json:"state,omitempty" firestore:"state,omitempty"` // Processing state, see MediaReady; empty on media stored before states were tracked
+}
+
+// MediaMetadata is the EXIF/MP4 metadata extracted from a file at upload, keyed
+// by snake_case tag name. It is stored as JSONB in Postgres and a map in
+// Firestore. It can locate the submitter, so it never appears in report JSON.
+type MediaMetadata map[string]interface{}
+
+// Transcript status constants for video media files
+const (
+	TranscriptPending   = "pending"   // Transcription running
+	TranscriptCompleted = "completed" // Transcript stored (may be empty if nothing was said)
+	TranscriptFailed    = "failed"    // Transcription failed; not retried
+)
+
+// TranscriptJob is a video media file the transcription job still has work for
+type TranscriptJob struct {
+	ReportID string
+	UserID   string
+	Media    MediaFile
 }
 
 // TrafficReport represents a traffic incident report
 type TrafficReport struct {
-	ID                  string      `json:"id" firestore:"id"`
-	UserID              string      `json:"userId" firestore:"userId"`
-	Title               string      `json:"title" binding:"required,min=1,max=200" firestore:"title"`
-	Description         string      `json:"description" binding:"required,min=1,max=5000" firestore:"description"`
-	DateTime            time.Time   `json:"dateTime" binding:"required" firestore:"dateTime"`
-	RoadUsages          []string    `json:"roadUsages" firestore:"roadUsages"`
-	EventTypes          []string    `json:"eventTypes" firestore:"eventTypes"`
-	State               string      `json:"state" binding:"required,stateorprovince" firestore:"state"`
-	City                string      `json:"city" firestore:"city"`
-	Injuries            string      `json:"injuries" binding:"max=1000" firestore:"injuries"`
-	RetainMediaMetadata bool        `json:"retainMediaMetadata" firestore:"retainMediaMetadata"`
-	MediaFiles          []MediaFile `json:"mediaFiles" firestore:"mediaFiles"`
-	CreatedAt           time.Time   `json:"createdAt" firestore:"createdAt"`
-	UpdatedAt           time.Time   `json:"updatedAt" firestore:"updatedAt"`
-	Status              string      `json:"status" firestore:"status"`
-	ReviewReason        string      `json:"reviewReason,omitempty" firestore:"review_reason"`
-	ReviewedBy          string      `json:"reviewedBy,omitempty" firestore:"reviewed_by"`
-	Priority            *int        `json:"priority,omitempty" firestore:"priority"`
+	ID                  string             `json:"id" firestore:"id"`
+	UserID              string             `json:"userId" firestore:"userId"`
+	Title               string             `json:"title" binding:"required,min=1,max=200" firestore:"title"`
+	Description         string             `json:"description" binding:"required,min=1,max=5000" firestore:"description"`
+	DateTime            time.Time          `json:"dateTime" binding:"required" firestore:"dateTime"`
+	RoadUsages          []string           `json:"roadUsages" firestore:"roadUsages"`
+	EventTypes          []string           `json:"eventTypes" firestore:"eventTypes"`
+	State               string             `json:"state" binding:"required,stateorprovince" firestore:"state"`
+	City                string             `json:"city" firestore:"city"`
+	CityID              string             `json:"cityId,omitempty" firestore:"cityId,omitempty"` // Canonical city when City is on the city list, see geo.NormalizeCity
+	Injuries            string             `json:"injuries" binding:"max=1000" firestore:"injuries"`
+	Severity            string             `json:"severity,omitempty" firestore:"severity,omitempty"` // One of Severities; Injuries is free-text detail
+	RetainMediaMetadata bool               `json:"retainMediaMetadata" firestore:"retainMediaMetadata"`
+	MediaFiles          []MediaFile        `json:"mediaFiles" firestore:"mediaFiles"`
+	CreatedAt           time.Time          `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt           time.Time          `json:"updatedAt" firestore:"updatedAt"`
+	Status              string             `json:"status" firestore:"status"`
+	ReviewReason        string             `json:"reviewReason,omitempty" firestore:"review_reason"`
+	ReviewedBy          string             `json:"reviewedBy,omitempty" firestore:"reviewed_by"`
+	Priority            *int               `json:"priority,omitempty" firestore:"priority"`
+	VehicleHashes       []string           `json:"-" firestore:"vehicleHashes"` // Keyed hashes of plate/vehicle descriptors, never exposed
+	IncidentID          string             `json:"incidentId,omitempty" firestore:"incidentId"`
+	MergedInto          string             `json:"mergedInto,omitempty" firestore:"mergedInto"` // Canonical report ID when this report was merged as a duplicate
+	Latitude            *float64           `json:"latitude,omitempty" firestore:"latitude"`
+	Longitude           *float64           `json:"longitude,omitempty" firestore:"longitude"`
+	MapImagePath        string             `json:"-" firestore:"mapImagePath,omitempty"`                // Blob store object of the static map of the location, see staticmap
+	MapImageAttemptedAt *time.Time         `json:"-" firestore:"mapImageAttemptedAt"`                   // Last time rendering the static map was tried
+	MapImageURL         string             `json:"mapImageUrl,omitempty" firestore:"-"`                 // Signed URL of MapImagePath, refreshed with the media URLs
+	ContentFlagged      bool               `json:"contentFlagged,omitempty" firestore:"contentFlagged"` // Matched a flag-action blocked word on submission
+	ReviewHints         []ReviewHint       `json:"reviewHints,omitempty" firestore:"-"`                 // Computed for the admin review queue, never stored
+	TimeZone            string             `json:"timeZone,omitempty" firestore:"timeZone"`             // Submitter's IANA zone, if known
+	UTCOffsetMinutes    int                `json:"utcOffsetMinutes" firestore:"utcOffsetMinutes"`       // Submitter's UTC offset at DateTime
+	Pinned              bool               `json:"pinned" firestore:"pinned"`                           // Kept at the top of the public feed by an admin
+	PinnedAt            *time.Time         `json:"pinnedAt,omitempty" firestore:"pinnedAt"`
+	PublishAt           *time.Time         `json:"publishAt,omitempty" firestore:"publishAt"`             // Approved reports stay out of the public feed until then
+	PriorityFrozen      bool               `json:"priorityFrozen,omitempty" firestore:"priorityFrozen"`   // Exempt from priority decay
+	HotScore            float64            `json:"hotScore" firestore:"hotScore"`                         // Engagement ranking, refreshed periodically
+	Translation         *ReportTranslation `json:"translation,omitempty" firestore:"-"`                   // Machine translation, only on ?lang= requests
+	PIIWarnings         []string           `json:"piiWarnings,omitempty" firestore:"-"`                   // Kinds of personal information found on submission, never stored
+	StatusChangedAt     *time.Time         `json:"statusChangedAt,omitempty" firestore:"statusChangedAt"` // Last review, archive or merge
+	StatusSeenAt        *time.Time         `json:"-" firestore:"statusSeenAt"`                            // When the owner last marked the report seen
+	HasUnseenUpdate     bool               `json:"hasUnseenUpdate,omitempty" firestore:"-"`               // Status changed since the owner last looked; only on the owner's list
+	Engagement          *ReportEngagement  `json:"engagement,omitempty" firestore:"-"`                    // Summary for feed cards, only on list responses
+}
+
+// ReportTranslation is a machine translation of a report's title and description
+type ReportTranslation struct {
+	Language    string `json:"language"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// UnseenStatusChange reports whether the status changed since the owner last marked the report seen
+func (r *TrafficReport) UnseenStatusChange() bool {
+	return r.StatusChangedAt != nil && (r.StatusSeenAt == nil || r.StatusChangedAt.After(*r.StatusSeenAt))
+}
+
+// Embargoed reports whether the report is scheduled to be published after now
+func (r *TrafficReport) Embargoed(now time.Time) bool {
+	return r.PublishAt != nil && r.PublishAt.After(now)
+}
+
+// PriorityRank returns the report's priority for ordering, counting unset as the default of 100
+func (r *TrafficReport) PriorityRank() int {
+	if r.Priority == nil {
+		return 100
+	}
+	return *r.Priority
+}
+
+// Bounds of a priority set through the admin priority endpoint
+const (
+	MinPriority = 0
+	MaxPriority = 1000
+)
+
+// PriorityChange is an admin edit of a report's priority: Set replaces it, otherwise Delta is added
+type PriorityChange struct {
+	Set   *int
+	Delta int
+}
+
+// Apply returns the priority after the change, kept within MinPriority..MaxPriority
+func (c PriorityChange) Apply(current int) int {
+	next := current + c.Delta
+	if c.Set != nil {
+		next = *c.Set
+	}
+	return min(max(next, MinPriority), MaxPriority)
 }
 
 // ReportStatus constants
 const (
-	StatusSubmitted    = "submitted"      // New report awaiting review
-	StatusReviewedPass = "reviewed_pass"  // Admin approved
-	StatusReviewedFail = "reviewed_fail"  // Admin rejected
-	StatusDeleted      = "deleted"        // Soft deleted
+	StatusSubmitted    = "submitted"     // New report awaiting review
+	StatusReviewedPass = "reviewed_pass" // Admin approved
+	StatusReviewedFail = "reviewed_fail" // Admin rejected
+	StatusDeleted      = "deleted"       // Soft deleted
+	StatusMerged       = "merged"        // Merged into another report as a duplicate
+	StatusArchived     = "archived"      // Approved, but old enough to leave the main feed
 )
 
 // CreateReportRequest represents the request body for creating a report
 type CreateReportRequest struct {
+	// Optional client-generated UUID, so offline clients can retry without creating duplicates
+	ID                  string    `json:"id" binding:"omitempty,uuid"`
 	Title               string    `json:"title" binding:"required,min=1,max=200"`
 	Description         string    `json:"description" binding:"required,min=1,max=5000"`
-	DateTime            time.Time `json:"dateTime" binding:"required"`
-	RoadUsages          []string  `json:"roadUsages"`
-	EventTypes          []string  `json:"eventTypes"`
+	DateTime            time.Time `json:"dateTime" binding:"required,notfuture"`
+	TimeZone            string    `json:"timeZone" binding:"omitempty,timezone,max=64"` // Optional IANA zone; otherwise dateTime's own offset is kept
+	RoadUsages          []string  `json:"roadUsages" binding:"dive,roadusage"`
+	EventTypes          []string  `json:"eventTypes" binding:"dive,eventtype"`
 	State               string    `json:"state" binding:"required,stateorprovince"`
 	City                string    `json:"city"`
 	Injuries            string    `json:"injuries" binding:"max=1000"`
+	Severity            string    `json:"severity" binding:"omitempty,severity"` // Guessed from Injuries when empty
 	RetainMediaMetadata bool      `json:"retainMediaMetadata"`
+	// Vehicle details are only used to derive match hashes and are never stored as submitted
+	LicensePlate string `json:"licensePlate" binding:"max=15"`
+	VehicleColor string `json:"vehicleColor" binding:"max=50"`
+	VehicleMake  string `json:"vehicleMake" binding:"max=50"`
+	VehicleModel string `json:"vehicleModel" binding:"max=50"`
+	// Optional incident location; latitude and longitude must be provided together
+	Latitude  *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	// Deprecated: single-value fields sent by old clients, folded into
+	// RoadUsages and EventTypes by NormalizeLegacyFields
+	RoadUsage string `json:"roadUsage" binding:"omitempty,roadusage"`
+	EventType string `json:"eventType" binding:"omitempty,eventtype"`
+}
+
+// NormalizeLegacyFields folds the deprecated roadUsage and eventType fields into
+// RoadUsages and EventTypes. It returns the deprecated fields the client sent,
+// each mapped to the field that replaces it.
+func (r *CreateReportRequest) NormalizeLegacyFields() map[string]string {
+	used := map[string]string{}
+	if r.RoadUsage != "" {
+		if !slices.Contains(r.RoadUsages, r.RoadUsage) {
+			r.RoadUsages = append(slices.Clone(r.RoadUsages), r.RoadUsage)
+		}
+		r.RoadUsage = ""
+		used["roadUsage"] = "roadUsages"
+	}
+	if r.EventType != "" {
+		if !slices.Contains(r.EventTypes, r.EventType) {
+			r.EventTypes = append(slices.Clone(r.EventTypes), r.EventType)
+		}
+		r.EventType = ""
+		used["eventType"] = "eventTypes"
+	}
+	return used
 }
 
 // ListReportsResponse represents the response for listing reports
@@ -64,13 +203,28 @@ type ListReportsResponse struct {
 	Count   int             `json:"count"`
 }
 
+// ReportPageResponse is one page of an offset-paginated admin report list
+type ReportPageResponse struct {
+	Reports []TrafficReport `json:"reports"`
+	Count   int             `json:"count"` // Reports on this page
+	Total   int             `json:"total"` // Reports across all pages
+	Limit   int             `json:"limit"`
+	Offset  int             `json:"offset"`
+}
+
+// CountReportsResponse is the response of the count-only report endpoints
+type CountReportsResponse struct {
+	Count int `json:"count"`
+}
+
 // UserInfo represents authenticated user information from IAP JWT
 type UserInfo struct {
 	Email   string `json:"email"`
 	Subject string `json:"sub"`
 }
 
-// ReactionType constants
+// ReactionType constants for the built-in reaction set (see DefaultReactionTypes).
+// Admins can add and retire types at runtime, so these are not exhaustive.
 const (
 	ReactionThumbsUp        = "thumbs_up"
 	ReactionThumbsDown      = "thumbs_down"
@@ -93,13 +247,30 @@ type Reaction struct {
 
 // Comment represents a user comment on a report
 type Comment struct {
-	ID        string    `json:"id"`
-	ReportID  string    `json:"reportId"`
-	UserID    string    `json:"userId"`
-	UserEmail string    `json:"userEmail"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID         string    `json:"id"`
+	ReportID   string    `json:"reportId"`
+	UserID     string    `json:"userId"`
+	UserEmail  string    `json:"-"`          // Admin-only, see AdminComment
+	AuthorName string    `json:"authorName"` // Public identity of the author, see AuthorName
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Flagged    bool      `json:"flagged,omitempty"` // Held for moderator review; hidden from public listings and counts
+}
+
+// AdminComment is a comment as moderators see it, with its author's email
+type AdminComment struct {
+	Comment
+	AuthorEmail string `json:"userEmail"`
+}
+
+// AdminComments adds the author's email to each comment, for admin responses only
+func AdminComments(comments []Comment) []AdminComment {
+	out := make([]AdminComment, len(comments))
+	for i, c := range comments {
+		out[i] = AdminComment{Comment: c, AuthorEmail: c.UserEmail}
+	}
+	return out
 }
 
 // ReactionCount represents the count of a specific reaction type
@@ -115,6 +286,28 @@ type ReportEngagement struct {
 	UserReactions  []string        `json:"userReactions"` // Reaction types the current user has made
 	CommentCount   int             `json:"commentCount"`
 	Comments       []Comment       `json:"comments,omitempty"`
+	LatestComment  *CommentPreview `json:"latestComment,omitempty"` // Newest visible comment, on bulk engagement
+}
+
+// CommentSnippetLength is the most characters of a comment a CommentPreview carries
+const CommentSnippetLength = 140
+
+// CommentPreview is the start of a report's newest comment, for feed cards
+type CommentPreview struct {
+	ID         string    `json:"id"`
+	AuthorName string    `json:"authorName"`
+	Snippet    string    `json:"snippet"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// CommentSnippet collapses a comment's whitespace and cuts it to CommentSnippetLength
+// characters, ending in "…" when anything was cut
+func CommentSnippet(content string) string {
+	runes := []rune(strings.Join(strings.Fields(content), " "))
+	if len(runes) <= CommentSnippetLength {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:CommentSnippetLength-1])) + "…"
 }
 
 // AddReactionRequest represents a request to add a reaction
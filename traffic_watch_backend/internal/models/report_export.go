@@ -0,0 +1,20 @@
+package models
+
+// ReportExportOptions selects what a report export contains
+type ReportExportOptions struct {
+	IncludeEngagement bool // Add each report's reaction and comment totals
+	ApprovedOnly      bool // Only reports visible in the public feed, as in the research export
+}
+
+// ReportExportRow is one report in the admin report export. Media files are
+// left out; Engagement is only set when the export includes it.
+type ReportExportRow struct {
+	Report     TrafficReport     `json:"report"`
+	Engagement *ExportEngagement `json:"engagement,omitempty"`
+}
+
+// ExportEngagement is a report's reaction and comment totals in the report export
+type ExportEngagement struct {
+	ReactionCounts map[string]int `json:"reactionCounts"` // By reaction type
+	CommentCount   int            `json:"commentCount"`   // Flagged comments are not counted
+}
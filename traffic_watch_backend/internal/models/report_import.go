@@ -0,0 +1,16 @@
+package models
+
+// ReportImportResult summarizes a bulk import of historical reports
+type ReportImportResult struct {
+	Imported int                 `json:"imported"` // With dryRun, how many would be imported
+	Skipped  int                 `json:"skipped"`  // Already imported by an earlier run
+	Errors   []ReportImportError `json:"errors"`   // Rows that were not imported
+	DryRun   bool                `json:"dryRun,omitempty"`
+}
+
+// ReportImportError explains why one row of an import file was not imported
+type ReportImportError struct {
+	Row      int    `json:"row"` // 1-based, not counting the CSV header
+	LegacyID string `json:"legacyId,omitempty"`
+	Message  string `json:"message"`
+}
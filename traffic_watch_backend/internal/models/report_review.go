@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// ReportReview is one entry in a report's review history: an admin's decision,
+// or the owner resubmitting a rejected report (Status is then StatusSubmitted)
+type ReportReview struct {
+	ID        string    `json:"id" firestore:"id"`
+	ReportID  string    `json:"reportId" firestore:"reportId"`
+	Status    string    `json:"status" firestore:"status"`           // Status the report moved to
+	Reason    string    `json:"reason,omitempty" firestore:"reason"` // Rejection reason, or the owner's note on resubmission
+	Actor     string    `json:"actor" firestore:"actor"`             // Email of the reviewer or resubmitting owner
+	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
+}
+
+// Resubmissions counts the entries where the owner resubmitted the report
+func Resubmissions(history []ReportReview) int {
+	n := 0
+	for _, r := range history {
+		if r.Status == StatusSubmitted {
+			n++
+		}
+	}
+	return n
+}
+
+// ResubmitReportRequest carries the edits made to a rejected report before it
+// goes back to review. Empty fields keep the report's current value.
+type ResubmitReportRequest struct {
+	Title       string   `json:"title" binding:"max=200"`
+	Description string   `json:"description" binding:"max=5000"`
+	RoadUsages  []string `json:"roadUsages" binding:"dive,roadusage"`
+	EventTypes  []string `json:"eventTypes" binding:"dive,eventtype"`
+	City        string   `json:"city" binding:"max=100"`
+	Injuries    string   `json:"injuries" binding:"max=1000"`
+	Severity    string   `json:"severity" binding:"omitempty,severity"`
+	Note        string   `json:"note" binding:"max=500"` // What was fixed, shown to reviewers in the history
+}
+
+// ResubmitReportResponse is returned after a rejected report goes back to review
+type ResubmitReportResponse struct {
+	Report            *TrafficReport `json:"report"`
+	ResubmissionsLeft int            `json:"resubmissionsLeft"`
+}
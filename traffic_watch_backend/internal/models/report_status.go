@@ -0,0 +1,63 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidStatusTransition is wrapped by every StatusTransitionError
+var ErrInvalidStatusTransition = errors.New("invalid report status transition")
+
+// statusTransitions lists the statuses each report status may move to. Reviewed reports
+// can be reviewed again, keeping or flipping the decision (e.g. to change the priority or
+// publish time of an approved report). A rejected report can go back to submitted when
+// its owner resubmits it. Deleted is final.
+var statusTransitions = map[string][]string{
+	StatusSubmitted:    {StatusReviewedPass, StatusReviewedFail, StatusMerged, StatusDeleted},
+	StatusReviewedPass: {StatusReviewedPass, StatusReviewedFail, StatusArchived, StatusMerged, StatusDeleted},
+	StatusReviewedFail: {StatusReviewedPass, StatusReviewedFail, StatusSubmitted, StatusMerged, StatusDeleted},
+	StatusArchived:     {StatusReviewedFail, StatusMerged, StatusDeleted},
+	StatusMerged:       {StatusDeleted},
+}
+
+// StatusTransitionError is returned when a report can't move from its current status to another
+type StatusTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("cannot change report status from %s to %s", e.From, e.To)
+}
+
+func (e *StatusTransitionError) Unwrap() error {
+	return ErrInvalidStatusTransition
+}
+
+// CanTransitionStatus reports whether a report in status from may move to status to
+func CanTransitionStatus(from, to string) bool {
+	return slices.Contains(statusTransitions[from], to)
+}
+
+// CheckStatusTransition returns a *StatusTransitionError unless a report in status from
+// may move to status to
+func CheckStatusTransition(from, to string) error {
+	if !CanTransitionStatus(from, to) {
+		return &StatusTransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// StatusesTransitioningTo returns the statuses a report may be in to move to status to,
+// for guarding updates in a single statement
+func StatusesTransitioningTo(to string) []string {
+	var from []string
+	for status, next := range statusTransitions {
+		if slices.Contains(next, to) {
+			from = append(from, status)
+		}
+	}
+	slices.Sort(from)
+	return from
+}
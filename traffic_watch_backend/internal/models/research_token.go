@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// ResearchToken grants a researcher or journalist read-only access to the approved
+// dataset under /v1/research. Only a hash of the secret is stored; the secret itself
+// is shown once, when the token is created.
+type ResearchToken struct {
+	ID            string     `json:"id" firestore:"id"`
+	Name          string     `json:"name" firestore:"name"` // Who the token was issued to, e.g. "Jane Doe, City Gazette"
+	Email         string     `json:"email" firestore:"email"`
+	TokenHash     string     `json:"-" firestore:"tokenHash"`
+	DailyQuota    int        `json:"dailyQuota" firestore:"dailyQuota"` // Requests allowed per UTC day
+	CreatedBy     string     `json:"createdBy" firestore:"createdBy"`
+	CreatedAt     time.Time  `json:"createdAt" firestore:"createdAt"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty" firestore:"expiresAt"`
+	RevokedAt     *time.Time `json:"revokedAt,omitempty" firestore:"revokedAt"`
+	LastUsedAt    *time.Time `json:"lastUsedAt,omitempty" firestore:"lastUsedAt"`
+	RequestsToday int        `json:"requestsToday" firestore:"-"` // Filled in when listing tokens
+	TotalRequests int        `json:"totalRequests" firestore:"-"` // Filled in when listing tokens
+}
+
+// Active reports whether the token can be used at now
+func (t *ResearchToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+// ResearchTokenUsage is one token's request count for a UTC day
+type ResearchTokenUsage struct {
+	Day      string `json:"day" firestore:"day"`           // YYYY-MM-DD
+	Requests int    `json:"requests" firestore:"requests"` // Requests served
+	Rejected int    `json:"rejected" firestore:"rejected"` // Requests refused for being over the daily quota
+}
+
+// UsageDay returns the UTC day a research token request at t is counted against
+func UsageDay(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// CreateResearchTokenRequest represents an admin request to issue a research token
+type CreateResearchTokenRequest struct {
+	Name          string `json:"name" binding:"required,min=1,max=100"`
+	Email         string `json:"email" binding:"required,email,max=255"`
+	DailyQuota    int    `json:"dailyQuota" binding:"gte=0,lte=100000"` // 0 uses the default
+	ExpiresInDays int    `json:"expiresInDays" binding:"gte=0,lte=730"` // 0 never expires
+}
+
+// CreateResearchTokenResponse carries the new token's secret, which can't be retrieved again
+type CreateResearchTokenResponse struct {
+	Token  ResearchToken `json:"token"`
+	Secret string        `json:"secret"`
+}
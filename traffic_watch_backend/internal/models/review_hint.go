@@ -0,0 +1,16 @@
+package models
+
+// Review hint kinds
+const (
+	HintMediaTimeMismatch     = "media_time_mismatch"     // Media was captured well before or after the reported incident time
+	HintMediaLocationMismatch = "media_location_mismatch" // Media GPS is far from the reported coordinates
+	HintMediaRegionMismatch   = "media_region_mismatch"   // Media GPS lies outside the reported state/province
+)
+
+// ReviewHint points a reviewer at a discrepancy between a report's claims and
+// the metadata of its media. Hints are advisory; they never block review.
+type ReviewHint struct {
+	Kind    string `json:"kind"`
+	MediaID string `json:"mediaId"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// ReportSearchHit is a report found by admin search, with its submitter's email
+type ReportSearchHit struct {
+	Report         TrafficReport `json:"report"`
+	SubmitterEmail string        `json:"submitterEmail,omitempty"`
+}
+
+// ReportSearchGroup holds the report matches of an admin search, best first
+type ReportSearchGroup struct {
+	Count   int               `json:"count"`
+	Results []ReportSearchHit `json:"results"`
+}
+
+// UserSearchGroup holds the user matches of an admin search
+type UserSearchGroup struct {
+	Count   int    `json:"count"`
+	Results []User `json:"results"`
+}
+
+// AdminSearchResponse is the response of GET /v1/admin/search, grouped by result type
+type AdminSearchResponse struct {
+	Query   string            `json:"query"`
+	Reports ReportSearchGroup `json:"reports"`
+	Users   UserSearchGroup   `json:"users"`
+}
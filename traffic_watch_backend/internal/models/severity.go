@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Incident severity levels, least to most severe
+const (
+	SeverityNearMiss       = "near_miss"
+	SeverityPropertyDamage = "property_damage"
+	SeverityMinorInjury    = "minor_injury"
+	SeverityMajorInjury    = "major_injury"
+	SeverityFatality       = "fatality"
+)
+
+// Severities lists the severity levels, least to most severe
+var Severities = []string{SeverityNearMiss, SeverityPropertyDamage, SeverityMinorInjury, SeverityMajorInjury, SeverityFatality}
+
+// ErrInvalidSeverity is returned by ParseSeverityFilter for an unknown severity
+var ErrInvalidSeverity = errors.New("severity must be one of: " + strings.Join(Severities, ", "))
+
+// ValidSeverity reports whether s is a severity level
+func ValidSeverity(s string) bool {
+	return slices.Contains(Severities, s)
+}
+
+// ParseSeverityFilter reads a comma-separated ?severity= value. An empty value
+// returns no severities, which filters nothing.
+func ParseSeverityFilter(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var severities []string
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if !ValidSeverity(s) {
+			return nil, ErrInvalidSeverity
+		}
+		severities = append(severities, s)
+	}
+	return severities, nil
+}
+
+// damageWords mark property damage in free-text injuries
+var damageWords = []string{"damage", "dented", "scratch"}
+
+// severityRules map words in free-text injuries to a severity, first match wins.
+// Negations come before the injury words so "no injuries" isn't a minor injury.
+var severityRules = []struct {
+	words    []string
+	severity string
+}{
+	{[]string{"fatal", "died", "dead", "killed", "death"}, SeverityFatality},
+	{[]string{"no injur", "not injur", "uninjured", "not hurt", "nobody", "no one", "none"}, SeverityNearMiss},
+	{[]string{"hospital", "ambulance", "serious", "severe", "major", "broken", "fracture"}, SeverityMajorInjury},
+	{[]string{"injur", "hurt", "minor", "bruise", "scrape", "whiplash"}, SeverityMinorInjury},
+	{damageWords, SeverityPropertyDamage},
+}
+
+// SeverityFromInjuries guesses the severity of a report that only has the legacy
+// free-text injuries field, or returns "" when the text doesn't say. A report
+// saying nobody was hurt is a near miss, or property damage if it mentions any.
+// Migration 042 backfills existing reports with the same rules; change both together.
+func SeverityFromInjuries(injuries string) string {
+	text := strings.ToLower(injuries)
+	mentions := func(words []string) bool {
+		return slices.ContainsFunc(words, func(w string) bool { return strings.Contains(text, w) })
+	}
+	for _, rule := range severityRules {
+		if !mentions(rule.words) {
+			continue
+		}
+		if rule.severity == SeverityNearMiss && mentions(damageWords) {
+			return SeverityPropertyDamage
+		}
+		return rule.severity
+	}
+	return ""
+}
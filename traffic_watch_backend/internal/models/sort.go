@@ -0,0 +1,83 @@
+package models
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Report list orderings selected with ?sort=
+const (
+	SortCreatedAt  = "createdAt"
+	SortUpdatedAt  = "updatedAt"
+	SortPriority   = "priority"   // Unset priority counts as 100
+	SortEngagement = "engagement" // Precomputed hot score
+)
+
+// ReportSortFields are the accepted ?sort= values
+var ReportSortFields = []string{SortCreatedAt, SortUpdatedAt, SortPriority, SortEngagement}
+
+// Errors returned by ParseReportSort
+var (
+	ErrInvalidSortField = errors.New("sort must be one of: " + strings.Join(ReportSortFields, ", "))
+	ErrInvalidSortOrder = errors.New("order must be one of: asc, desc")
+)
+
+// ReportSort orders a report list by Field, then creation time, then ID, all in the
+// same direction
+type ReportSort struct {
+	Field     string
+	Ascending bool
+}
+
+// ParseReportSort reads ?sort= and ?order= values. An empty sort uses defaultField and an
+// empty order is descending. "hot" is accepted as the public feed's older name for engagement.
+func ParseReportSort(sort, order, defaultField string) (ReportSort, error) {
+	s := ReportSort{Field: defaultField}
+	switch {
+	case sort == "hot":
+		s.Field = SortEngagement
+	case slices.Contains(ReportSortFields, sort):
+		s.Field = sort
+	case sort != "":
+		return ReportSort{}, ErrInvalidSortField
+	}
+
+	switch order {
+	case "", "desc":
+	case "asc":
+		s.Ascending = true
+	default:
+		return ReportSort{}, ErrInvalidSortOrder
+	}
+	return s, nil
+}
+
+// Compare orders a before b (negative), after b (positive) or level with b (zero)
+func (s ReportSort) Compare(a, b TrafficReport) int {
+	c := 0
+	switch s.Field {
+	case SortUpdatedAt:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	case SortPriority:
+		c = cmp.Compare(a.PriorityRank(), b.PriorityRank())
+	case SortEngagement:
+		c = cmp.Compare(a.HotScore, b.HotScore)
+	}
+	if c == 0 && s.Field != SortUpdatedAt {
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if c == 0 {
+		c = cmp.Compare(a.ID, b.ID)
+	}
+	if !s.Ascending {
+		c = -c
+	}
+	return c
+}
+
+// SortReports sorts reports in place by s
+func SortReports(reports []TrafficReport, s ReportSort) {
+	slices.SortStableFunc(reports, s.Compare)
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// DailyCount is the number of approved reports for one incident day (UTC)
+type DailyCount struct {
+	Date  string `json:"date" firestore:"date"` // YYYY-MM-DD
+	Count int    `json:"count" firestore:"count"`
+}
+
+// KeyCount is the number of approved reports for one state or event type
+type KeyCount struct {
+	Key   string `json:"key" firestore:"key"`
+	Count int    `json:"count" firestore:"count"`
+}
+
+// ReportStats holds pre-aggregated counts of approved reports
+type ReportStats struct {
+	Total       int          `json:"total" firestore:"total"`
+	ByDay       []DailyCount `json:"byDay" firestore:"byDay"`
+	ByState     []KeyCount   `json:"byState" firestore:"byState"`
+	ByEventType []KeyCount   `json:"byEventType" firestore:"byEventType"`
+	RefreshedAt *time.Time   `json:"refreshedAt,omitempty" firestore:"refreshedAt"` // Set when served from a periodic snapshot rather than live aggregates
+}
@@ -0,0 +1,27 @@
+package models
+
+// MaxSyncBatch caps how many queued reports one sync request can carry
+const MaxSyncBatch = 50
+
+// SyncReportsRequest carries reports queued by a client while offline. Each
+// must have a client-generated ID so the batch can be retried safely.
+type SyncReportsRequest struct {
+	Reports []CreateReportRequest `json:"reports" binding:"required,min=1,max=50"`
+}
+
+// Sync outcomes for one queued report
+const (
+	SyncCreated   = "created"   // Stored now
+	SyncDuplicate = "duplicate" // Already stored by an earlier sync or upload; Report is the stored copy
+	SyncConflict  = "conflict"  // The ID belongs to another user's report; generate a new ID and retry
+	SyncInvalid   = "invalid"   // Failed validation; retrying unchanged won't help
+	SyncFailed    = "failed"    // Server error; safe to retry
+)
+
+// SyncResult reports what happened to one queued report, in request order
+type SyncResult struct {
+	ID      string         `json:"id"`
+	Status  string         `json:"status"`
+	Report  *TrafficReport `json:"report,omitempty"`
+	Message string         `json:"message,omitempty"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// MaxTemplatesPerUser caps how many report templates one user can keep
+const MaxTemplatesPerUser = 50
+
+// ReportTemplate is a user's preset for reports they file often, such as a
+// problem intersection they monitor. Empty fields are left for the report to fill.
+type ReportTemplate struct {
+	ID          string    `json:"id" firestore:"id"`
+	UserID      string    `json:"-" firestore:"userId"`
+	Name        string    `json:"name" firestore:"name"`
+	Title       string    `json:"title,omitempty" firestore:"title"`
+	Description string    `json:"description,omitempty" firestore:"description"`
+	RoadUsages  []string  `json:"roadUsages" firestore:"roadUsages"`
+	EventTypes  []string  `json:"eventTypes" firestore:"eventTypes"`
+	State       string    `json:"state,omitempty" firestore:"state"`
+	City        string    `json:"city,omitempty" firestore:"city"`
+	Latitude    *float64  `json:"latitude,omitempty" firestore:"latitude"`
+	Longitude   *float64  `json:"longitude,omitempty" firestore:"longitude"`
+	CreatedAt   time.Time `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" firestore:"updatedAt"`
+}
+
+// ReportTemplateRequest represents a request to create or replace a report template
+type ReportTemplateRequest struct {
+	Name        string   `json:"name" binding:"required,min=1,max=100"`
+	Title       string   `json:"title" binding:"max=200"`
+	Description string   `json:"description" binding:"max=5000"`
+	RoadUsages  []string `json:"roadUsages" binding:"dive,roadusage"`
+	EventTypes  []string `json:"eventTypes" binding:"dive,eventtype"`
+	State       string   `json:"state" binding:"omitempty,stateorprovince"`
+	City        string   `json:"city" binding:"max=100"`
+	Latitude    *float64 `json:"latitude" binding:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude   *float64 `json:"longitude" binding:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+// Prefill copies the template's fields into req, which is then overlaid with
+// the fields the client actually sent
+func (t *ReportTemplate) Prefill(req *CreateReportRequest) {
+	req.Title = t.Title
+	req.Description = t.Description
+	req.RoadUsages = t.RoadUsages
+	req.EventTypes = t.EventTypes
+	req.State = t.State
+	req.City = t.City
+	req.Latitude = t.Latitude
+	req.Longitude = t.Longitude
+}
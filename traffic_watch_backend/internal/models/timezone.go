@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+	_ "time/tzdata" // The runtime image ships without zoneinfo
+)
+
+// SetDateTime records the incident time together with the submitter's zone.
+// With an IANA zone the offset is taken from it (so DST is applied);
+// otherwise the offset the client sent in the timestamp is kept.
+func (r *TrafficReport) SetDateTime(dateTime time.Time, timeZone string) {
+	if loc, err := time.LoadLocation(timeZone); err == nil && timeZone != "" {
+		dateTime = dateTime.In(loc)
+		r.TimeZone = timeZone
+	}
+	_, offset := dateTime.Zone()
+	r.DateTime = dateTime.UTC()
+	r.UTCOffsetMinutes = offset / 60
+}
+
+// LocalDateTime returns the incident time in the submitter's zone
+func (r TrafficReport) LocalDateTime() time.Time {
+	if r.TimeZone != "" {
+		if loc, err := time.LoadLocation(r.TimeZone); err == nil {
+			return r.DateTime.In(loc)
+		}
+	}
+	return r.DateTime.In(time.FixedZone("", r.UTCOffsetMinutes*60))
+}
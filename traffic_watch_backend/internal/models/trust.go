@@ -0,0 +1,65 @@
+package models
+
+// TrustLevel is a tier computed from a user's review history that unlocks capabilities
+type TrustLevel string
+
+// Trust levels, from least to most trusted
+const (
+	TrustNew     TrustLevel = "new"     // Not enough approved reports yet
+	TrustBasic   TrustLevel = "basic"   // Some approved reports: may post links in comments
+	TrustTrusted TrustLevel = "trusted" // A consistent record: higher upload limits and auto-approval eligibility
+)
+
+// TrustThresholds are the review history a user needs for each trust level
+type TrustThresholds struct {
+	BasicApproved             int // Approved reports needed for basic
+	TrustedApproved           int // Approved reports needed for trusted
+	TrustedMaxRejectedPercent int // Largest share of reviewed reports that may have been rejected for trusted
+}
+
+// DefaultTrustThresholds returns the thresholds used unless configured otherwise
+func DefaultTrustThresholds() TrustThresholds {
+	return TrustThresholds{
+		BasicApproved:             1,
+		TrustedApproved:           5,
+		TrustedMaxRejectedPercent: 20,
+	}
+}
+
+// Level returns the trust level for a user with the given numbers of approved and rejected reports
+func (t TrustThresholds) Level(approved, rejected int) TrustLevel {
+	reviewed := approved + rejected
+	if approved >= t.TrustedApproved && rejected*100 <= t.TrustedMaxRejectedPercent*reviewed {
+		return TrustTrusted
+	}
+	if approved >= t.BasicApproved {
+		return TrustBasic
+	}
+	return TrustNew
+}
+
+// TrustCapabilities are what a user's trust level allows
+type TrustCapabilities struct {
+	PostLinks            bool `json:"postLinks"`            // Comments with links are published like any other
+	HigherUploadLimits   bool `json:"higherUploadLimits"`   // Reports may carry more and larger media files
+	AutoApprovalEligible bool `json:"autoApprovalEligible"` // Reports may skip manual review where auto-approval is used
+}
+
+// Capabilities returns what the level allows. Unknown levels, including the
+// empty level of users never assessed, count as new.
+func (l TrustLevel) Capabilities() TrustCapabilities {
+	switch l {
+	case TrustTrusted:
+		return TrustCapabilities{PostLinks: true, HigherUploadLimits: true, AutoApprovalEligible: true}
+	case TrustBasic:
+		return TrustCapabilities{PostLinks: true}
+	default:
+		return TrustCapabilities{}
+	}
+}
+
+// CurrentUserResponse is the signed-in user with what their trust level allows
+type CurrentUserResponse struct {
+	*User
+	Capabilities TrustCapabilities `json:"capabilities"`
+}
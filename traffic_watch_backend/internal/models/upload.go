@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// MaxBatchUploadFiles caps how many upload URLs one batch request can issue
+const MaxBatchUploadFiles = 20
+
+// UploadFileSpec describes a file the client is about to upload
+type UploadFileSpec struct {
+	FileName    string `json:"fileName" binding:"required,max=255"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size" binding:"required,min=1"`
+}
+
+// BatchUploadURLsRequest asks for signed upload URLs for several files at once
+type BatchUploadURLsRequest struct {
+	Files []UploadFileSpec `json:"files" binding:"required,min=1,max=20,dive"`
+}
+
+// UploadURL is a signed URL the client PUTs one file to. The request must
+// send the returned ContentType as its Content-Type header.
+type UploadURL struct {
+	MediaID     string    `json:"mediaId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	UploadURL   string    `json:"uploadUrl"`
+	Method      string    `json:"method"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
+
+// Upload progress stages of a multipart report submission
+const (
+	UploadReceiving  = "receiving"  // Request body is arriving
+	UploadProcessing = "processing" // Body received; files are being checked and stored
+	UploadComplete   = "complete"   // Report created, see ReportID
+	UploadFailed     = "failed"     // Submission was rejected or failed, see Error
+)
+
+// UploadProgress is how far a multipart report submission has got. Clients tag
+// the submission with an upload session ID and poll this while it uploads.
+type UploadProgress struct {
+	SessionID      string    `json:"sessionId"`
+	Stage          string    `json:"stage"`
+	BytesReceived  int64     `json:"bytesReceived"`
+	BytesTotal     int64     `json:"bytesTotal"` // -1 when the request has no Content-Length
+	FilesProcessed int       `json:"filesProcessed"`
+	FilesTotal     int       `json:"filesTotal"`
+	ReportID       string    `json:"reportId,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
@@ -8,28 +8,149 @@ type UserRole string
 const (
 	RoleViewer      UserRole = "viewer"
 	RoleContributor UserRole = "contributor"
+	RoleModerator   UserRole = "moderator" // Reviews reports and flagged comments
 	RoleAdmin       UserRole = "admin"
 )
 
+// Valid reports whether r is one of the known roles
+func (r UserRole) Valid() bool {
+	switch r {
+	case RoleViewer, RoleContributor, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+// Rank orders roles by what they can do; unknown roles rank with viewers
+func (r UserRole) Rank() int {
+	switch r {
+	case RoleContributor:
+		return 1
+	case RoleModerator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
 // User represents an authenticated user in the system
 type User struct {
-	ID              string     `json:"id"`                        // Google subject ID
-	Email           string     `json:"email"`
-	Role            UserRole   `json:"role"`
-	JWTRefreshToken string     `json:"-"`                         // Not exposed in JSON responses
-	CreatedAt       time.Time  `json:"createdAt"`
-	UpdatedAt       time.Time  `json:"updatedAt"`
-	LastLoginAt     *time.Time `json:"lastLoginAt,omitempty"`
+	ID                 string                   `json:"id"` // Google subject ID
+	Email              string                   `json:"email"`
+	Role               UserRole                 `json:"role"`
+	JWTRefreshToken    string                   `json:"-"` // Not exposed in JSON responses
+	CreatedAt          time.Time                `json:"createdAt"`
+	UpdatedAt          time.Time                `json:"updatedAt"`
+	LastLoginAt        *time.Time               `json:"lastLoginAt,omitempty"`
+	ShadowBanned       bool                     `json:"-"`                       // Never exposed, so the user can't tell their content is hidden
+	Notifications      *NotificationPreferences `json:"-"`                       // Nil when never stored; use NotificationSettings
+	LastDigestAt       *time.Time               `json:"-"`                       // When the last weekly digest was sent
+	DeactivatedAt      *time.Time               `json:"deactivatedAt,omitempty"` // Set while the account is deactivated or suspended
+	DeactivationReason DeactivationReason       `json:"deactivationReason,omitempty"`
+	DefaultState       string                   `json:"defaultState,omitempty"` // Home state/province for the personalized feed
+	DefaultCity        string                   `json:"defaultCity,omitempty"`
+	DisplayName        string                   `json:"displayName,omitempty"` // Shown on the user's comments instead of a pseudonym
+	TrustLevel         TrustLevel               `json:"trustLevel,omitempty"`  // Computed from review history; see TrustThresholds
+}
+
+// DeactivationReason records why an account is deactivated
+type DeactivationReason string
+
+const (
+	// DeactivatedBySelf accounts were deactivated by their owner and reactivate on the next sign-in
+	DeactivatedBySelf DeactivationReason = "self"
+	// DeactivatedByAdmin accounts were suspended by an admin and stay locked until an admin lifts it
+	DeactivatedByAdmin DeactivationReason = "suspended"
+)
+
+// IsDeactivated reports whether the account is deactivated or suspended
+func (u *User) IsDeactivated() bool {
+	return u.DeactivatedAt != nil
+}
+
+// IsSuspended reports whether an admin has suspended the account
+func (u *User) IsSuspended() bool {
+	return u.IsDeactivated() && u.DeactivationReason == DeactivatedByAdmin
+}
+
+// NotificationPreferences are a user's notification settings
+type NotificationPreferences struct {
+	EmailOnReview  bool `json:"emailOnReview"`  // Email when an admin approves or rejects one of their reports
+	PushOnComment  bool `json:"pushOnComment"`  // Push when someone comments on one of their reports
+	WeeklyDigest   bool `json:"weeklyDigest"`   // Weekly email summary
+	DraftReminders bool `json:"draftReminders"` // Email and push about drafts left unfinished
+}
+
+// DefaultNotificationPreferences returns the settings for users who never changed them
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		EmailOnReview:  true,
+		PushOnComment:  true,
+		WeeklyDigest:   false,
+		DraftReminders: false, // Opt-in, so preferences stored before it existed read the same on every backend
+	}
+}
+
+// NotificationSettings returns the user's notification preferences, falling back to the defaults
+func (u *User) NotificationSettings() NotificationPreferences {
+	if u.Notifications == nil {
+		return DefaultNotificationPreferences()
+	}
+	return *u.Notifications
+}
+
+// UpdateNotificationPreferencesRequest is a partial update; omitted fields keep their value
+type UpdateNotificationPreferencesRequest struct {
+	EmailOnReview  *bool `json:"emailOnReview"`
+	PushOnComment  *bool `json:"pushOnComment"`
+	WeeklyDigest   *bool `json:"weeklyDigest"`
+	DraftReminders *bool `json:"draftReminders"`
+}
+
+// Apply returns prefs with the request's fields applied
+func (r UpdateNotificationPreferencesRequest) Apply(prefs NotificationPreferences) NotificationPreferences {
+	if r.EmailOnReview != nil {
+		prefs.EmailOnReview = *r.EmailOnReview
+	}
+	if r.PushOnComment != nil {
+		prefs.PushOnComment = *r.PushOnComment
+	}
+	if r.WeeklyDigest != nil {
+		prefs.WeeklyDigest = *r.WeeklyDigest
+	}
+	if r.DraftReminders != nil {
+		prefs.DraftReminders = *r.DraftReminders
+	}
+	return prefs
+}
+
+// UpdateProfileRequest is a partial update of a user's profile; omitted fields keep their value
+// and an empty string clears one
+type UpdateProfileRequest struct {
+	DefaultState *string `json:"defaultState" binding:"omitempty,stateorprovince"`
+	DefaultCity  *string `json:"defaultCity" binding:"omitempty,max=100"`
+	DisplayName  *string `json:"displayName" binding:"omitempty,max=50"`
+}
+
+// SetShadowBanRequest represents an admin request to toggle a user's shadow ban
+type SetShadowBanRequest struct {
+	ShadowBanned bool `json:"shadowBanned"`
+}
+
+// SetSuspendedRequest represents an admin request to suspend or reinstate a user
+type SetSuspendedRequest struct {
+	Suspended bool `json:"suspended"`
+}
+
+// SetRoleRequest represents an admin request to change a user's role
+type SetRoleRequest struct {
+	Role UserRole `json:"role" binding:"required"`
 }
 
 // CanAccess checks if the user has the required role or higher
 func (u *User) CanAccess(requiredRole UserRole) bool {
-	roleHierarchy := map[UserRole]int{
-		RoleViewer:      0,
-		RoleContributor: 1,
-		RoleAdmin:       2,
-	}
-	return roleHierarchy[u.Role] >= roleHierarchy[requiredRole]
+	return u.Role.Rank() >= requiredRole.Rank()
 }
 
 // IsAdmin checks if the user has admin role
@@ -39,17 +160,26 @@ func (u *User) IsAdmin() bool {
 
 // IsContributor checks if the user has contributor role or higher
 func (u *User) IsContributor() bool {
-	return u.Role == RoleContributor || u.Role == RoleAdmin
+	return u.CanAccess(RoleContributor)
 }
 
 // AuthResponse represents the response from the login endpoint
 type AuthResponse struct {
-	Token     string `json:"token"`
-	ExpiresAt int64  `json:"expiresAt"` // Unix timestamp
+	Token     string `json:"token,omitempty"` // Omitted for cookie sessions
+	ExpiresAt int64  `json:"expiresAt"`       // Unix timestamp
 	User      User   `json:"user"`
+	CSRFToken string `json:"csrfToken,omitempty"` // Cookie sessions only; send as X-CSRF-Token
 }
 
 // LoginRequest represents the request body for login
 type LoginRequest struct {
 	GoogleToken string `json:"googleToken" binding:"required"`
+	UseCookie   bool   `json:"useCookie"` // Issue the JWT in an HttpOnly cookie instead of the body
+}
+
+// UserBlock records that one user has blocked another
+type UserBlock struct {
+	BlockerID string    `json:"-" firestore:"blockerId"`
+	BlockedID string    `json:"userId" firestore:"blockedId"`
+	CreatedAt time.Time `json:"createdAt" firestore:"createdAt"`
 }
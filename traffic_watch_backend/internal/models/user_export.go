@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// UserExportRow is one user in the admin user export
+type UserExportRow struct {
+	ID                  string     `json:"id"`
+	Email               string     `json:"email"`
+	Role                UserRole   `json:"role"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	LastLoginAt         *time.Time `json:"lastLoginAt"`
+	ReportCount         int        `json:"reportCount"`         // Non-deleted reports
+	ApprovedReportCount int        `json:"approvedReportCount"` // Reports that passed review
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// WidgetItem is one report in the embeddable "recent incidents" widget
+type WidgetItem struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	City        string    `json:"city,omitempty"`
+	State       string    `json:"state"`
+	DateTime    time.Time `json:"dateTime"`
+	Thumbnail   string    `json:"thumbnail,omitempty"`   // Image or video preview; omitted when the report has none
+	MapImageURL string    `json:"mapImageUrl,omitempty"` // Static map of the location; omitted until one is rendered
+	Link        string    `json:"link"`                  // The report on the web app
+}
+
+// WidgetResponse is the payload of GET /v1/public/widget
+type WidgetResponse struct {
+	Items []WidgetItem `json:"items"`
+	Count int          `json:"count"`
+}
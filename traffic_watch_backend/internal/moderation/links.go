@@ -0,0 +1,17 @@
+package moderation
+
+import "regexp"
+
+// linkPattern matches URLs and bare domains ("example.com/deal", "www.example.com").
+// Bare domains need a common top-level domain so "e.g." or "St.Louis" don't count.
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.(?:com|net|org|info|biz|io|co|ly|me|xyz|top|site|online|shop|ru|cn)\b`)
+
+// ContainsLink reports whether any of the texts contains a link
+func ContainsLink(texts ...string) bool {
+	for _, t := range texts {
+		if linkPattern.MatchString(t) {
+			return true
+		}
+	}
+	return false
+}
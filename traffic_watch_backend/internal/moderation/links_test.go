@@ -0,0 +1,23 @@
+package moderation
+
+import "testing"
+
+func TestContainsLink(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Same driver runs this light every morning", false},
+		{"See https://example.org/video", true},
+		{"cheap parts at www.deals4u.biz", true},
+		{"visit carsforless.com today", true},
+		{"bit.ly/abc", true},
+		{"Happened near St.Louis, e.g. by the mall", false},
+		{"Speed was 45.5 mph", false},
+	}
+	for _, tt := range tests {
+		if got := ContainsLink(tt.text); got != tt.want {
+			t.Errorf("ContainsLink(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
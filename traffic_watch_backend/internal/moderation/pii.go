@@ -0,0 +1,79 @@
+package moderation
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// PIIPolicy is what a deployment does when submitted report text contains personal information
+type PIIPolicy string
+
+// PII policies, from least to most intrusive for the submitter
+const (
+	PIIPolicyOff  PIIPolicy = "off"  // Don't scan
+	PIIPolicyWarn PIIPolicy = "warn" // Store as submitted; tell the submitter what was found
+	PIIPolicyMask PIIPolicy = "mask" // Store as submitted; mask it in public responses
+	PIIPolicyFlag PIIPolicy = "flag" // Hold the report for moderator review
+)
+
+// ParsePIIPolicy validates a configured policy name
+func ParsePIIPolicy(s string) (PIIPolicy, error) {
+	switch p := PIIPolicy(s); p {
+	case PIIPolicyOff, PIIPolicyWarn, PIIPolicyMask, PIIPolicyFlag:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown PII policy %q (want off, warn, mask, or flag)", s)
+	}
+}
+
+// Kinds of personal information detected
+const (
+	PIIEmail = "email"
+	PIIPhone = "phone"
+	PIIName  = "name"
+)
+
+type piiDetector struct {
+	kind    string
+	pattern *regexp.Regexp
+	group   int // Submatch holding the PII itself; 0 for the whole match
+}
+
+// Names are only detected after a cue ("my name is", "Mr.") since two capitalized
+// words are as likely to be a street or a business as a person. Detection is
+// deliberately conservative: it catches the common cases without masking "Main Street".
+var piiDetectors = []piiDetector{
+	{PIIEmail, regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), 0},
+	{PIIPhone, regexp.MustCompile(`(?:\+?1[\s.-]?)?(?:\(\d{3}\)|\b\d{3})[\s.-]?\d{3}[\s.-]?\d{4}\b`), 0},
+	{PIIName, regexp.MustCompile(`\b(?:(?:Mr|Mrs|Ms|Miss|Dr)\.?|(?i:name is|named|called))\s+([A-Z][a-z]+(?:[ -][A-Z][a-z]+){0,2})`), 1},
+}
+
+// DetectPII returns the kinds of personal information found in the texts, in a fixed order
+func DetectPII(texts ...string) []string {
+	var kinds []string
+	for _, d := range piiDetectors {
+		for _, t := range texts {
+			if d.pattern.MatchString(t) {
+				kinds = append(kinds, d.kind)
+				break
+			}
+		}
+	}
+	return kinds
+}
+
+// MaskPII replaces personal information in text with a placeholder naming its kind, e.g. "[phone]".
+// Name cues are kept ("my name is [name]") so the sentence still reads.
+func MaskPII(text string) string {
+	for _, d := range piiDetectors {
+		placeholder := "[" + d.kind + "]"
+		text = d.pattern.ReplaceAllStringFunc(text, func(m string) string {
+			if d.group == 0 {
+				return placeholder
+			}
+			loc := d.pattern.FindStringSubmatchIndex(m)
+			return m[:loc[2*d.group]] + placeholder + m[loc[2*d.group+1]:]
+		})
+	}
+	return text
+}
@@ -0,0 +1,47 @@
+package moderation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectPII(t *testing.T) {
+	tests := []struct {
+		name  string
+		texts []string
+		want  []string
+	}{
+		{"clean", []string{"Red sedan ran the light on Main Street", "Ohio State game traffic"}, nil},
+		{"email", []string{"Contact witness@example.com"}, []string{PIIEmail}},
+		{"phone formats", []string{"Call (614) 555-0123"}, []string{PIIPhone}},
+		{"phone with country code", []string{"+1 614.555.0123 saw it"}, []string{PIIPhone}},
+		{"name after cue", []string{"The driver said his name is John Smith"}, []string{PIIName}},
+		{"honorific", []string{"Mrs. Alvarez was crossing"}, []string{PIIName}},
+		{"across fields in fixed order", []string{"Dr. Jane Doe", "jane@example.org, 555-123-4567"}, []string{PIIEmail, PIIPhone, PIIName}},
+		{"short numbers are not phones", []string{"Route 33, 45 mph in a 25"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectPII(tt.texts...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectPII(%q) = %v, want %v", tt.texts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaskPII(t *testing.T) {
+	in := "My name is John Smith, call 614-555-0123 or email john@example.com. Mr. Lee saw it on Main Street."
+	want := "My name is [name], call [phone] or email [email]. Mr. [name] saw it on Main Street."
+	if got := MaskPII(in); got != want {
+		t.Errorf("MaskPII() =\n %q\nwant\n %q", got, want)
+	}
+}
+
+func TestParsePIIPolicy(t *testing.T) {
+	if p, err := ParsePIIPolicy("mask"); err != nil || p != PIIPolicyMask {
+		t.Errorf("ParsePIIPolicy(mask) = %q, %v", p, err)
+	}
+	if _, err := ParsePIIPolicy("redact"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
@@ -0,0 +1,126 @@
+// Package moderation applies the admin-managed blocked-word filter to
+// user-submitted text before it is stored.
+package moderation
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// Result is the outcome of checking a piece of text
+type Result struct {
+	Text    string   // Input with masked words replaced by asterisks
+	Action  string   // Strongest action matched, or "" if nothing matched
+	Matches []string // Blocked words found, lowercased
+}
+
+type rule struct {
+	word    string
+	action  string
+	pattern *regexp.Regexp
+}
+
+// WordFilter matches blocked words case-insensitively on word boundaries.
+// The word list can be replaced at any time; a nil filter matches nothing.
+type WordFilter struct {
+	mu    sync.RWMutex
+	rules []rule
+}
+
+// NewWordFilter creates a filter for the given words
+func NewWordFilter(words []models.BlockedWord) *WordFilter {
+	f := &WordFilter{}
+	f.Load(words)
+	return f
+}
+
+// Load replaces the filter's word list. Entries with an empty word or an unknown action are skipped.
+func (f *WordFilter) Load(words []models.BlockedWord) {
+	rules := make([]rule, 0, len(words))
+	for _, w := range words {
+		word := strings.ToLower(strings.TrimSpace(w.Word))
+		if word == "" || actionRank(w.Action) == 0 {
+			continue
+		}
+		rules = append(rules, rule{
+			word:    word,
+			action:  w.Action,
+			pattern: regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(word) + `\b`),
+		})
+	}
+
+	f.mu.Lock()
+	f.rules = rules
+	f.mu.Unlock()
+}
+
+// Len returns the number of words in the filter
+func (f *WordFilter) Len() int {
+	if f == nil {
+		return 0
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return len(f.rules)
+}
+
+// Check scans text for blocked words. Words whose action is mask are replaced
+// in Result.Text; the caller decides what to do with flag and reject.
+func (f *WordFilter) Check(text string) Result {
+	result := Result{Text: text}
+	if f == nil {
+		return result
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for _, r := range f.rules {
+		if !r.pattern.MatchString(result.Text) {
+			continue
+		}
+		result.Matches = append(result.Matches, r.word)
+		if actionRank(r.action) > actionRank(result.Action) {
+			result.Action = r.action
+		}
+		if r.action == models.WordActionMask {
+			result.Text = r.pattern.ReplaceAllStringFunc(result.Text, func(m string) string {
+				return strings.Repeat("*", len([]rune(m)))
+			})
+		}
+	}
+	return result
+}
+
+// CheckAll checks several fields together, returning the masked texts in order
+// and the strongest action across all of them
+func (f *WordFilter) CheckAll(texts ...string) ([]string, Result) {
+	out := make([]string, len(texts))
+	var combined Result
+	for i, t := range texts {
+		r := f.Check(t)
+		out[i] = r.Text
+		combined.Matches = append(combined.Matches, r.Matches...)
+		if actionRank(r.Action) > actionRank(combined.Action) {
+			combined.Action = r.Action
+		}
+	}
+	return out, combined
+}
+
+// actionRank orders actions by severity; unknown actions rank 0
+func actionRank(action string) int {
+	switch action {
+	case models.WordActionMask:
+		return 1
+	case models.WordActionFlag:
+		return 2
+	case models.WordActionReject:
+		return 3
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,82 @@
+package moderation
+
+import (
+	"reflect"
+	"testing"
+
+	"donzhit_me_backend/internal/models"
+)
+
+func testFilter() *WordFilter {
+	return NewWordFilter([]models.BlockedWord{
+		{Word: "darn", Action: models.WordActionMask},
+		{Word: "Heck", Action: models.WordActionFlag},
+		{Word: "scam", Action: models.WordActionReject},
+		{Word: "  ", Action: models.WordActionReject},
+		{Word: "ignored", Action: "delete"},
+	})
+}
+
+func TestWordFilterCheck(t *testing.T) {
+	f := testFilter()
+	if f.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3 (blank and unknown-action entries skipped)", f.Len())
+	}
+
+	tests := []struct {
+		name       string
+		input      string
+		wantText   string
+		wantAction string
+	}{
+		{"clean", "Ran the red light at Main St", "Ran the red light at Main St", ""},
+		{"mask", "That darn truck", "That **** truck", models.WordActionMask},
+		{"mask case-insensitive", "DARN it, Darn", "**** it, ****", models.WordActionMask},
+		{"whole words only", "darned scammers", "darned scammers", ""},
+		{"flag", "what the heck", "what the heck", models.WordActionFlag},
+		{"reject beats others", "darn scam heck", "**** scam heck", models.WordActionReject},
+		{"ignored action", "ignored", "ignored", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := f.Check(tt.input)
+			if got.Text != tt.wantText {
+				t.Errorf("Text = %q, want %q", got.Text, tt.wantText)
+			}
+			if got.Action != tt.wantAction {
+				t.Errorf("Action = %q, want %q", got.Action, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestWordFilterCheckAll(t *testing.T) {
+	f := testFilter()
+	texts, result := f.CheckAll("darn title", "fine description", "heck")
+	if want := []string{"**** title", "fine description", "heck"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("texts = %q, want %q", texts, want)
+	}
+	if result.Action != models.WordActionFlag {
+		t.Errorf("Action = %q, want %q", result.Action, models.WordActionFlag)
+	}
+	if want := []string{"darn", "heck"}; !reflect.DeepEqual(result.Matches, want) {
+		t.Errorf("Matches = %q, want %q", result.Matches, want)
+	}
+}
+
+func TestWordFilterLoadAndNil(t *testing.T) {
+	f := testFilter()
+	f.Load(nil)
+	if got := f.Check("scam"); got.Action != "" {
+		t.Errorf("Action after Load(nil) = %q, want none", got.Action)
+	}
+
+	var nilFilter *WordFilter
+	if got := nilFilter.Check("scam"); got.Text != "scam" || got.Action != "" {
+		t.Errorf("nil filter Check = %+v, want passthrough", got)
+	}
+	if nilFilter.Len() != 0 {
+		t.Errorf("nil filter Len = %d, want 0", nilFilter.Len())
+	}
+}
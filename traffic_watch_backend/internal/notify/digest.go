@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+)
+
+const (
+	// DigestPeriod is how often each subscriber gets a digest
+	DigestPeriod = 7 * 24 * time.Hour
+	// digestTopReports caps the "top in your state" section
+	digestTopReports = 5
+)
+
+// DigestStore is the storage the digest job needs
+type DigestStore interface {
+	ListDigestRecipients(ctx context.Context, sentBefore time.Time) ([]models.User, error)
+	MarkDigestSent(ctx context.Context, userID string, at time.Time) error
+	ListReportsByUser(ctx context.Context, userID string) ([]models.TrafficReport, error)
+	ListApprovedReports(ctx context.Context) ([]models.TrafficReport, error)
+}
+
+// DigestJob compiles and sends weekly digests. Run it more often than weekly:
+// each run only sends to subscribers whose last digest is a DigestPeriod old.
+type DigestJob struct {
+	store        DigestStore
+	notifier     *Notifier
+	unsubscriber *auth.TokenSigner
+	baseURL      string // Prefix for unsubscribe and map links, e.g. "https://api.donzhit.me"
+}
+
+// NewDigestJob creates a digest job
+func NewDigestJob(store DigestStore, notifier *Notifier, unsubscriber *auth.TokenSigner, baseURL string) *DigestJob {
+	return &DigestJob{
+		store:        store,
+		notifier:     notifier,
+		unsubscriber: unsubscriber,
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Run sends every due digest
+func (j *DigestJob) Run(ctx context.Context) error {
+	now := time.Now()
+	recipients, err := j.store.ListDigestRecipients(ctx, now.Add(-DigestPeriod))
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	approved, err := j.store.ListApprovedReports(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list approved reports: %w", err)
+	}
+
+	sent := 0
+	for _, user := range recipients {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		own, err := j.store.ListReportsByUser(ctx, user.ID)
+		if err != nil {
+			log.Printf("Failed to list reports for digest of %s: %v", user.ID, err)
+			continue
+		}
+
+		if body := digestBody(own, topInState(approved, homeState(own), now.Add(-DigestPeriod)), j.baseURL); body != "" {
+			if err := j.notifier.Notify(ctx, Message{
+				Kind:           KindWeeklyDigest,
+				UserID:         user.ID,
+				Title:          "Your weekly DonzHit.me digest",
+				Body:           body,
+				UnsubscribeURL: j.unsubscribeURL(user.ID),
+			}); err != nil {
+				log.Printf("Failed to send digest to %s: %v", user.ID, err)
+				continue
+			}
+			sent++
+		}
+
+		// Recorded even when there was nothing to say, so the user isn't retried every run
+		if err := j.store.MarkDigestSent(ctx, user.ID, now); err != nil {
+			log.Printf("Failed to record digest for %s: %v", user.ID, err)
+		}
+	}
+
+	log.Printf("Weekly digest: sent %d of %d due", sent, len(recipients))
+	return nil
+}
+
+func (j *DigestJob) unsubscribeURL(userID string) string {
+	return j.baseURL + "/v1/public/notifications/unsubscribe?token=" + url.QueryEscape(j.unsubscriber.Token(userID))
+}
+
+// homeState is the state of the user's most recent report
+func homeState(own []models.TrafficReport) string {
+	var latest *models.TrafficReport
+	for i := range own {
+		if latest == nil || own[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &own[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.State
+}
+
+// topInState returns the highest-priority approved reports in state created since since
+func topInState(approved []models.TrafficReport, state string, since time.Time) []models.TrafficReport {
+	if state == "" {
+		return nil
+	}
+	var top []models.TrafficReport
+	for _, r := range approved {
+		if r.State == state && r.CreatedAt.After(since) {
+			top = append(top, r)
+		}
+	}
+	sort.SliceStable(top, func(a, b int) bool { return priority(top[a]) > priority(top[b]) })
+	if len(top) > digestTopReports {
+		top = top[:digestTopReports]
+	}
+	return top
+}
+
+func priority(r models.TrafficReport) int {
+	if r.Priority == nil {
+		return 0
+	}
+	return *r.Priority
+}
+
+// digestBody renders the digest text; empty when there is nothing to report.
+// Top reports with a static map link to it through the API at baseURL.
+func digestBody(own, top []models.TrafficReport, baseURL string) string {
+	if len(own) == 0 && len(top) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	if len(own) > 0 {
+		b.WriteString("Your reports:\n")
+		for _, r := range own {
+			fmt.Fprintf(&b, "- %s: %s\n", r.Title, statusLabel(r.Status))
+		}
+	}
+	if len(top) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "Top reports in %s this week:\n", top[0].State)
+		for _, r := range top {
+			if r.City != "" {
+				fmt.Fprintf(&b, "- %s (%s)\n", r.Title, r.City)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", r.Title)
+			}
+			if r.MapImagePath != "" {
+				fmt.Fprintf(&b, "  Map: %s/v1/public/reports/%s/map\n", baseURL, url.PathEscape(r.ID))
+			}
+		}
+	}
+	return b.String()
+}
+
+func statusLabel(status string) string {
+	switch status {
+	case models.StatusSubmitted:
+		return "awaiting review"
+	case models.StatusReviewedPass:
+		return "approved"
+	case models.StatusReviewedFail:
+		return "not approved"
+	case models.StatusMerged:
+		return "merged into another report"
+	case models.StatusArchived:
+		return "archived"
+	}
+	return status
+}
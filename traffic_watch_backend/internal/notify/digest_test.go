@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+)
+
+type fakeDigestStore struct {
+	recipients []models.User
+	reports    map[string][]models.TrafficReport
+	approved   []models.TrafficReport
+	marked     []string
+}
+
+func (f *fakeDigestStore) ListDigestRecipients(ctx context.Context, sentBefore time.Time) ([]models.User, error) {
+	return f.recipients, nil
+}
+
+func (f *fakeDigestStore) MarkDigestSent(ctx context.Context, userID string, at time.Time) error {
+	f.marked = append(f.marked, userID)
+	return nil
+}
+
+func (f *fakeDigestStore) ListReportsByUser(ctx context.Context, userID string) ([]models.TrafficReport, error) {
+	return f.reports[userID], nil
+}
+
+func (f *fakeDigestStore) ListApprovedReports(ctx context.Context) ([]models.TrafficReport, error) {
+	return f.approved, nil
+}
+
+func TestDigestJob_Run(t *testing.T) {
+	now := time.Now()
+	high, low := 50, 10
+	digestOn := models.NotificationPreferences{WeeklyDigest: true}
+	users := &fakeStore{users: map[string]*models.User{
+		"active": {ID: "active", Notifications: &digestOn},
+		"quiet":  {ID: "quiet", Notifications: &digestOn},
+	}}
+	store := &fakeDigestStore{
+		recipients: []models.User{{ID: "active"}, {ID: "quiet"}},
+		reports: map[string][]models.TrafficReport{
+			"active": {{Title: "Red light", State: "Ohio", Status: models.StatusSubmitted, CreatedAt: now}},
+		},
+		approved: []models.TrafficReport{
+			{Title: "Minor", State: "Ohio", Priority: &low, CreatedAt: now},
+			{ID: "r-major", Title: "Major", State: "Ohio", City: "Dayton", Priority: &high, CreatedAt: now, MapImagePath: "users/u/reports/r-major/map"},
+			{Title: "Elsewhere", State: "Iowa", Priority: &high, CreatedAt: now},
+			{Title: "Old", State: "Ohio", Priority: &high, CreatedAt: now.Add(-30 * 24 * time.Hour)},
+		},
+	}
+	email := &recordingSender{channel: ChannelEmail}
+	unsub := auth.NewTokenSigner("secret", auth.PurposeDigestUnsubscribe)
+	job := NewDigestJob(store, NewNotifier(users, email), unsub, "https://api.example.com/")
+
+	if err := job.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(email.sent) != 1 {
+		t.Fatalf("expected one digest (the quiet user has nothing to report), got %d", len(email.sent))
+	}
+	body := email.sent[0].Body
+	if !strings.Contains(body, "Red light: awaiting review") {
+		t.Errorf("expected the user's report status in the digest, got:\n%s", body)
+	}
+	if strings.Index(body, "Major (Dayton)") > strings.Index(body, "Minor") || strings.Contains(body, "Elsewhere") || strings.Contains(body, "Old") {
+		t.Errorf("expected this week's Ohio reports by priority only, got:\n%s", body)
+	}
+	if !strings.Contains(body, "Major (Dayton)\n  Map: https://api.example.com/v1/public/reports/r-major/map\n") || strings.Count(body, "Map:") != 1 {
+		t.Errorf("expected a map link only for the report with a map, got:\n%s", body)
+	}
+	if !strings.HasPrefix(email.sent[0].UnsubscribeURL, "https://api.example.com/v1/public/notifications/unsubscribe?token=") {
+		t.Errorf("unexpected unsubscribe URL %q", email.sent[0].UnsubscribeURL)
+	}
+	if len(store.marked) != 2 {
+		t.Errorf("expected both recipients marked as sent, got %v", store.marked)
+	}
+	if len(users.inbox) != 0 {
+		t.Errorf("digests should not go to the inbox, got %d entries", len(users.inbox))
+	}
+}
@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// DraftReminderStore is the storage the draft reminder job needs
+type DraftReminderStore interface {
+	ListDraftsToRemind(ctx context.Context, updatedBefore time.Time) ([]models.ReportDraft, error)
+	MarkDraftReminded(ctx context.Context, draftID string, at time.Time) error
+	GetUserByID(ctx context.Context, userID string) (*models.User, error)
+}
+
+// DraftReminderJob reminds users about drafts they haven't touched for a while.
+// Each draft gets one reminder per edit; the notifier applies the owner's
+// DraftReminders preference.
+type DraftReminderJob struct {
+	store     DraftReminderStore
+	notifier  *Notifier
+	discarder *auth.TokenSigner
+	baseURL   string // Prefix for discard links, e.g. "https://api.donzhit.me"
+	after     time.Duration
+}
+
+// NewDraftReminderJob creates a job reminding about drafts unedited for after
+func NewDraftReminderJob(store DraftReminderStore, notifier *Notifier, discarder *auth.TokenSigner, baseURL string, after time.Duration) *DraftReminderJob {
+	return &DraftReminderJob{
+		store:     store,
+		notifier:  notifier,
+		discarder: discarder,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		after:     after,
+	}
+}
+
+// Run sends every due draft reminder
+func (j *DraftReminderJob) Run(ctx context.Context) error {
+	now := time.Now()
+	drafts, err := j.store.ListDraftsToRemind(ctx, now.Add(-j.after))
+	if err != nil {
+		return err
+	}
+
+	sent := 0
+	for _, draft := range drafts {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		owner, err := j.store.GetUserByID(ctx, draft.UserID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Failed to load owner of draft %s: %v", draft.ID, err)
+			continue
+		}
+
+		if owner != nil && !owner.IsDeactivated() {
+			if err := j.notifier.Notify(ctx, Message{
+				Kind:       KindDraftReminder,
+				UserID:     draft.UserID,
+				Title:      draftReminderTitle(&draft),
+				Body:       draftReminderBody(&draft),
+				DiscardURL: j.discardURL(&draft),
+			}); err != nil {
+				log.Printf("Failed to send reminder for draft %s: %v", draft.ID, err)
+				continue
+			}
+			sent++
+		}
+
+		// Recorded for skipped owners too, so the draft isn't retried every run
+		if err := j.store.MarkDraftReminded(ctx, draft.ID, now); err != nil {
+			log.Printf("Failed to record reminder for draft %s: %v", draft.ID, err)
+		}
+	}
+
+	if len(drafts) > 0 {
+		log.Printf("Draft reminders: sent %d of %d due", sent, len(drafts))
+	}
+	return nil
+}
+
+func (j *DraftReminderJob) discardURL(draft *models.ReportDraft) string {
+	token := j.discarder.Token(models.DraftRef(draft.UserID, draft.ID))
+	return j.baseURL + "/v1/public/drafts/discard?token=" + url.QueryEscape(token)
+}
+
+func draftReminderTitle(draft *models.ReportDraft) string {
+	if subject := draft.Subject(); subject != "" {
+		return fmt.Sprintf("Finish your report about %s", subject)
+	}
+	return "Finish your report"
+}
+
+func draftReminderBody(draft *models.ReportDraft) string {
+	return fmt.Sprintf("You started this report on %s and haven't submitted it yet. "+
+		"Open DonzHit.me to finish it, or discard it if it's no longer needed.",
+		draft.CreatedAt.UTC().Format("January 2"))
+}
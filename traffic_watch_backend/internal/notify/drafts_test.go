@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/auth"
+	"donzhit_me_backend/internal/models"
+)
+
+type fakeDraftStore struct {
+	*fakeStore
+	due           []models.ReportDraft
+	updatedBefore time.Time
+	marked        []string
+}
+
+func (f *fakeDraftStore) ListDraftsToRemind(ctx context.Context, updatedBefore time.Time) ([]models.ReportDraft, error) {
+	f.updatedBefore = updatedBefore
+	return f.due, nil
+}
+
+func (f *fakeDraftStore) MarkDraftReminded(ctx context.Context, draftID string, at time.Time) error {
+	f.marked = append(f.marked, draftID)
+	return nil
+}
+
+func TestDraftReminderJob_Run(t *testing.T) {
+	remindersOn := models.NotificationPreferences{DraftReminders: true}
+	deactivatedAt := time.Now()
+	store := &fakeDraftStore{
+		fakeStore: &fakeStore{users: map[string]*models.User{
+			"opted-in":    {ID: "opted-in", Notifications: &remindersOn},
+			"default":     {ID: "default"},
+			"deactivated": {ID: "deactivated", Notifications: &remindersOn, DeactivatedAt: &deactivatedAt},
+		}},
+		due: []models.ReportDraft{
+			{ID: "d1", UserID: "opted-in", City: "Main St", State: "Ohio"},
+			{ID: "d2", UserID: "default", Title: "Red light"},
+			{ID: "d3", UserID: "deactivated", Title: "Speeding"},
+		},
+	}
+	email := &recordingSender{channel: ChannelEmail}
+	push := &recordingSender{channel: ChannelPush}
+	discarder := auth.NewTokenSigner("secret", auth.PurposeDraftDiscard)
+	job := NewDraftReminderJob(store, NewNotifier(store.fakeStore, email, push), discarder, "https://api.example.com/", 72*time.Hour)
+
+	if err := job.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if age := time.Since(store.updatedBefore); age < 72*time.Hour || age > 73*time.Hour {
+		t.Errorf("expected drafts unedited for 72h, asked for those before %v", store.updatedBefore)
+	}
+	// Reminders are opt-in, and deactivated owners get none
+	if len(email.sent) != 1 || len(push.sent) != 1 {
+		t.Fatalf("expected one email and one push, got %d and %d", len(email.sent), len(push.sent))
+	}
+	msg := email.sent[0]
+	if msg.Kind != KindDraftReminder || msg.UserID != "opted-in" || msg.Title != "Finish your report about Main St, Ohio" {
+		t.Errorf("unexpected reminder %+v", msg)
+	}
+	prefix := "https://api.example.com/v1/public/drafts/discard?token="
+	if !strings.HasPrefix(msg.DiscardURL, prefix) {
+		t.Fatalf("unexpected discard URL %q", msg.DiscardURL)
+	}
+	token, _ := url.QueryUnescape(strings.TrimPrefix(msg.DiscardURL, prefix))
+	if ref, ok := discarder.Verify(token); !ok || ref != models.DraftRef("opted-in", "d1") {
+		t.Errorf("discard token names %q, want the opted-in user's draft d1", ref)
+	}
+	if len(store.inbox) != 0 {
+		t.Errorf("draft reminders should not go to the inbox, got %d entries", len(store.inbox))
+	}
+
+	// Every due draft is marked, so none is retried on the next run
+	if strings.Join(store.marked, ",") != "d1,d2,d3" {
+		t.Errorf("expected every draft marked as reminded, got %v", store.marked)
+	}
+}
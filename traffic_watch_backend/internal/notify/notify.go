@@ -0,0 +1,222 @@
+// Package notify delivers user notifications to the in-app inbox and over
+// email and push. Every delivery goes through Notifier, which checks the
+// recipient's notification preferences first, so individual senders never need to.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+// Kind identifies why a user is being notified
+type Kind string
+
+const (
+	KindReportReviewed Kind = "report_reviewed" // An admin approved or rejected the user's report
+	KindComment        Kind = "comment"         // Someone commented on the user's report
+	KindWeeklyDigest   Kind = "weekly_digest"   // Weekly summary email
+	KindInvitation     Kind = "invitation"      // An admin invited the recipient to a role
+	KindDraftReminder  Kind = "draft_reminder"  // One of the user's drafts has sat unfinished
+)
+
+// Channel is a delivery mechanism
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelPush  Channel = "push"
+)
+
+// Message is a notification for one user
+type Message struct {
+	Kind     Kind
+	UserID   string
+	ReportID string // Optional
+	Title    string
+	Body     string
+
+	// Email senders should include it as a link, and as a List-Unsubscribe header with
+	// "List-Unsubscribe-Post: List-Unsubscribe=One-Click" (RFC 8058): opening the link
+	// only asks for confirmation, a POST to it unsubscribes
+	UnsubscribeURL string
+	ActionURL      string // Optional link for the recipient to act on, e.g. accepting an invitation
+	DiscardURL     string // Optional one-click link that discards what the message is about, e.g. a draft
+}
+
+// Sender delivers messages over one channel
+type Sender interface {
+	Channel() Channel
+	Send(ctx context.Context, user *models.User, msg Message) error
+}
+
+// Allowed reports whether prefs permit delivering kind over channel
+func Allowed(prefs models.NotificationPreferences, kind Kind, channel Channel) bool {
+	switch {
+	case kind == KindReportReviewed && channel == ChannelEmail:
+		return prefs.EmailOnReview
+	case kind == KindComment && channel == ChannelPush:
+		return prefs.PushOnComment
+	case kind == KindWeeklyDigest && channel == ChannelEmail:
+		return prefs.WeeklyDigest
+	case kind == KindDraftReminder:
+		return prefs.DraftReminders
+	}
+	return false
+}
+
+// InApp reports whether kind is also recorded in the recipient's inbox.
+// Inbox entries ignore email/push preferences.
+func InApp(kind Kind) bool {
+	return kind == KindReportReviewed || kind == KindComment
+}
+
+// Store is the storage the notifier needs
+type Store interface {
+	GetUserByID(ctx context.Context, userID string) (*models.User, error)
+	GetReport(ctx context.Context, id string) (*models.TrafficReport, error)
+	GetCommentByID(ctx context.Context, commentID string) (*models.Comment, error)
+	CreateNotification(ctx context.Context, n *models.Notification) error
+}
+
+// Notifier routes messages to the senders the recipient has enabled
+type Notifier struct {
+	store   Store
+	senders []Sender
+}
+
+// NewNotifier creates a notifier delivering through senders
+func NewNotifier(store Store, senders ...Sender) *Notifier {
+	return &Notifier{store: store, senders: senders}
+}
+
+// Notify records msg in the recipient's inbox (for in-app kinds) and delivers
+// it on every channel the recipient allows for its kind. Notifying through a
+// nil notifier is a no-op.
+func (n *Notifier) Notify(ctx context.Context, msg Message) error {
+	if n == nil {
+		return nil
+	}
+
+	user, err := n.store.GetUserByID(ctx, msg.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to load recipient %s: %w", msg.UserID, err)
+	}
+
+	if InApp(msg.Kind) {
+		if err := n.store.CreateNotification(ctx, &models.Notification{
+			ID:       uuid.New().String(),
+			UserID:   user.ID,
+			Kind:     string(msg.Kind),
+			ReportID: msg.ReportID,
+			Title:    msg.Title,
+			Body:     msg.Body,
+		}); err != nil {
+			log.Printf("Failed to add %s notification to inbox of %s: %v", msg.Kind, user.ID, err)
+		}
+	}
+	prefs := user.NotificationSettings()
+
+	for _, s := range n.senders {
+		if !Allowed(prefs, msg.Kind, s.Channel()) {
+			continue
+		}
+		if err := s.Send(ctx, user, msg); err != nil {
+			log.Printf("Failed to send %s %s notification to %s: %v", s.Channel(), msg.Kind, user.ID, err)
+		}
+	}
+	return nil
+}
+
+// SendEmail delivers a transactional email such as an invitation. It skips
+// preferences and the inbox, since the recipient may not have an account yet;
+// only recipient.Email needs to be set. Sending through a nil notifier is a no-op.
+func (n *Notifier) SendEmail(ctx context.Context, recipient *models.User, msg Message) error {
+	if n == nil {
+		return nil
+	}
+	for _, s := range n.senders {
+		if s.Channel() != ChannelEmail {
+			continue
+		}
+		if err := s.Send(ctx, recipient, msg); err != nil {
+			return fmt.Errorf("failed to send %s email: %w", msg.Kind, err)
+		}
+	}
+	return nil
+}
+
+// Publish notifies the owner of the report an event is about: of its review, or of
+// a new comment by someone else. Other events are ignored. It makes Notifier an
+// events.EventPublisher, so the outbox relay delivers these notifications and
+// retries them when the report or recipient can't be loaded.
+func (n *Notifier) Publish(ctx context.Context, e events.Event) error {
+	msg := Message{ReportID: e.ReportID}
+	switch e.Type {
+	case events.ReportApproved:
+		msg.Kind, msg.Title = KindReportReviewed, "Your report was approved"
+	case events.ReportRejected:
+		msg.Kind, msg.Title = KindReportReviewed, "Your report was not approved"
+	case events.CommentAdded:
+		msg.Kind, msg.Title = KindComment, "New comment on your report"
+	default:
+		return nil
+	}
+
+	report, err := n.store.GetReport(ctx, e.ReportID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil // Gone since; nobody to tell
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load report %s for %s notification: %w", e.ReportID, msg.Kind, err)
+	}
+	if e.Type == events.CommentAdded {
+		comment, err := n.store.GetCommentByID(ctx, e.CommentID)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load comment %s for notification: %w", e.CommentID, err)
+		}
+		// Owners commenting on their own report are not notified
+		if comment.UserID == report.UserID {
+			return nil
+		}
+	}
+	msg.UserID = report.UserID
+	msg.Body = report.Title
+	return n.Notify(ctx, msg)
+}
+
+// LogSender is a Sender that only logs; used until a real email or push
+// provider is configured
+type LogSender struct {
+	channel Channel
+}
+
+// NewLogSender creates a logging sender for channel
+func NewLogSender(channel Channel) *LogSender {
+	return &LogSender{channel: channel}
+}
+
+// Channel returns the channel this sender stands in for
+func (s *LogSender) Channel() Channel {
+	return s.channel
+}
+
+// Send logs the message instead of delivering it
+func (s *LogSender) Send(ctx context.Context, user *models.User, msg Message) error {
+	recipient := user.ID
+	if recipient == "" {
+		recipient = user.Email
+	}
+	log.Printf("Notification (%s, %s) for %s: %s", s.channel, msg.Kind, recipient, msg.Title)
+	return nil
+}
@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"donzhit_me_backend/internal/events"
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/storage"
+)
+
+type fakeStore struct {
+	users    map[string]*models.User
+	reports  map[string]*models.TrafficReport
+	comments map[string]*models.Comment
+	inbox    []models.Notification
+}
+
+func (f *fakeStore) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	if u, ok := f.users[userID]; ok {
+		return u, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+func (f *fakeStore) GetReport(ctx context.Context, id string) (*models.TrafficReport, error) {
+	if r, ok := f.reports[id]; ok {
+		return r, nil
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (f *fakeStore) GetCommentByID(ctx context.Context, commentID string) (*models.Comment, error) {
+	if c, ok := f.comments[commentID]; ok {
+		return c, nil
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (f *fakeStore) CreateNotification(ctx context.Context, n *models.Notification) error {
+	f.inbox = append(f.inbox, *n)
+	return nil
+}
+
+type recordingSender struct {
+	channel Channel
+	sent    []Message
+}
+
+func (s *recordingSender) Channel() Channel { return s.channel }
+
+func (s *recordingSender) Send(ctx context.Context, user *models.User, msg Message) error {
+	s.sent = append(s.sent, msg)
+	return nil
+}
+
+func TestNotifier_RespectsPreferences(t *testing.T) {
+	optedOut := models.NotificationPreferences{EmailOnReview: false, PushOnComment: true, WeeklyDigest: true}
+	store := &fakeStore{users: map[string]*models.User{
+		"default":   {ID: "default"},
+		"opted-out": {ID: "opted-out", Notifications: &optedOut},
+	}}
+	email := &recordingSender{channel: ChannelEmail}
+	push := &recordingSender{channel: ChannelPush}
+	n := NewNotifier(store, email, push)
+	ctx := context.Background()
+
+	n.Notify(ctx, Message{Kind: KindReportReviewed, UserID: "default"})
+	n.Notify(ctx, Message{Kind: KindReportReviewed, UserID: "opted-out"})
+	n.Notify(ctx, Message{Kind: KindWeeklyDigest, UserID: "default"})
+	n.Notify(ctx, Message{Kind: KindWeeklyDigest, UserID: "opted-out"})
+	n.Notify(ctx, Message{Kind: KindComment, UserID: "default"})
+
+	if len(email.sent) != 2 {
+		t.Errorf("expected 2 emails (default review, opted-out digest), got %d", len(email.sent))
+	}
+	if len(push.sent) != 1 || push.sent[0].Kind != KindComment {
+		t.Errorf("expected 1 comment push, got %+v", push.sent)
+	}
+
+	// Review and comment notifications reach the inbox even when email/push is off; digests don't
+	if len(store.inbox) != 3 {
+		t.Errorf("expected 3 inbox entries, got %d", len(store.inbox))
+	}
+
+	if err := n.Notify(ctx, Message{Kind: KindComment, UserID: "missing"}); err == nil {
+		t.Error("expected an error for an unknown recipient")
+	}
+
+	var nilNotifier *Notifier
+	if err := nilNotifier.Notify(ctx, Message{Kind: KindComment, UserID: "default"}); err != nil {
+		t.Errorf("nil notifier should be a no-op, got %v", err)
+	}
+}
+
+func TestNotifier_Publish(t *testing.T) {
+	store := &fakeStore{
+		users:   map[string]*models.User{"owner": {ID: "owner"}},
+		reports: map[string]*models.TrafficReport{"r1": {ID: "r1", UserID: "owner", Title: "Red light"}},
+		comments: map[string]*models.Comment{
+			"by-owner": {ID: "by-owner", UserID: "owner"},
+			"by-other": {ID: "by-other", UserID: "other"},
+		},
+	}
+	push := &recordingSender{channel: ChannelPush}
+	n := NewNotifier(store, push)
+	ctx := context.Background()
+
+	for _, e := range []events.Event{
+		{Type: events.ReportApproved, ReportID: "r1"},
+		{Type: events.CommentAdded, ReportID: "r1", CommentID: "by-owner"},
+		{Type: events.CommentAdded, ReportID: "r1", CommentID: "by-other"},
+		{Type: events.ReportPinned, ReportID: "r1"},
+		{Type: events.ReportApproved, ReportID: "deleted"},
+		{Type: events.CommentAdded, ReportID: "r1", CommentID: "deleted"},
+	} {
+		if err := n.Publish(ctx, e); err != nil {
+			t.Errorf("Publish(%s): %v", e.Type, err)
+		}
+	}
+
+	if len(store.inbox) != 2 || store.inbox[0].Kind != string(KindReportReviewed) || store.inbox[1].Kind != string(KindComment) {
+		t.Errorf("expected a review and a comment notification, got %+v", store.inbox)
+	}
+	if len(push.sent) != 1 || push.sent[0].Body != "Red light" {
+		t.Errorf("expected one comment push about the report, got %+v", push.sent)
+	}
+
+	// An unknown recipient is an error, so the relay retries
+	store.reports["r2"] = &models.TrafficReport{ID: "r2", UserID: "missing"}
+	if err := n.Publish(ctx, events.Event{Type: events.ReportRejected, ReportID: "r2"}); err == nil {
+		t.Error("expected an error for an unknown recipient")
+	}
+}
@@ -0,0 +1,83 @@
+// Package ocr reads the text in report images (street signs, storefronts,
+// license plates) with the Cloud Vision API so admins can cross-check a
+// report's stated location and vehicle during review.
+package ocr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	vision "google.golang.org/api/vision/v1"
+)
+
+// Result is the text read from one image, or why it couldn't be read
+type Result struct {
+	Text string
+	Err  error
+}
+
+// Reader reads the text in GCS-hosted images, returning one result per URI in order
+type Reader interface {
+	ReadText(ctx context.Context, gcsURIs []string) ([]Result, error)
+}
+
+// maxImagesPerRequest is the most images Cloud Vision accepts in one batch
+const maxImagesPerRequest = 16
+
+// VisionReader reads text with Cloud Vision text detection
+type VisionReader struct {
+	service *vision.Service
+}
+
+// NewVisionReader creates a Cloud Vision client using application default credentials
+func NewVisionReader(ctx context.Context) (*VisionReader, error) {
+	service, err := vision.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vision service: %w", err)
+	}
+	return &VisionReader{service: service}, nil
+}
+
+// ReadText runs text detection on each image (gs://bucket/object). An error is
+// returned only when a whole batch fails; per-image failures are in the results.
+func (r *VisionReader) ReadText(ctx context.Context, gcsURIs []string) ([]Result, error) {
+	results := make([]Result, 0, len(gcsURIs))
+	for start := 0; start < len(gcsURIs); start += maxImagesPerRequest {
+		end := min(start+maxImagesPerRequest, len(gcsURIs))
+		requests := make([]*vision.AnnotateImageRequest, 0, end-start)
+		for _, uri := range gcsURIs[start:end] {
+			requests = append(requests, &vision.AnnotateImageRequest{
+				Image:    &vision.Image{Source: &vision.ImageSource{GcsImageUri: uri}},
+				Features: []*vision.Feature{{Type: "TEXT_DETECTION"}},
+			})
+		}
+
+		resp, err := r.service.Images.Annotate(&vision.BatchAnnotateImagesRequest{Requests: requests}).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect text: %w", err)
+		}
+		if len(resp.Responses) != end-start {
+			return nil, fmt.Errorf("text detection returned %d results, want %d", len(resp.Responses), end-start)
+		}
+		for _, res := range resp.Responses {
+			results = append(results, toResult(res))
+		}
+	}
+	return results, nil
+}
+
+// toResult extracts the full text of an image; images without text yield an empty result
+func toResult(res *vision.AnnotateImageResponse) Result {
+	if res.Error != nil {
+		return Result{Err: fmt.Errorf("text detection failed: %s", res.Error.Message)}
+	}
+	if res.FullTextAnnotation != nil {
+		return Result{Text: strings.TrimSpace(res.FullTextAnnotation.Text)}
+	}
+	// The first text annotation covers the whole image
+	if len(res.TextAnnotations) > 0 {
+		return Result{Text: strings.TrimSpace(res.TextAnnotations[0].Description)}
+	}
+	return Result{}
+}
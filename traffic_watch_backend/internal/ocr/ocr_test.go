@@ -0,0 +1,32 @@
+package ocr
+
+import (
+	"testing"
+
+	vision "google.golang.org/api/vision/v1"
+)
+
+func TestToResult(t *testing.T) {
+	full := toResult(&vision.AnnotateImageResponse{
+		FullTextAnnotation: &vision.TextAnnotation{Text: "STOP\nGHT 4821\n"},
+		TextAnnotations:    []*vision.EntityAnnotation{{Description: "ignored"}},
+	})
+	if full.Err != nil || full.Text != "STOP\nGHT 4821" {
+		t.Errorf("full text result = %+v", full)
+	}
+
+	fallback := toResult(&vision.AnnotateImageResponse{
+		TextAnnotations: []*vision.EntityAnnotation{{Description: "MAIN ST"}, {Description: "MAIN"}},
+	})
+	if fallback.Text != "MAIN ST" {
+		t.Errorf("fallback result = %+v", fallback)
+	}
+
+	if empty := toResult(&vision.AnnotateImageResponse{}); empty.Err != nil || empty.Text != "" {
+		t.Errorf("image without text = %+v", empty)
+	}
+
+	if failed := toResult(&vision.AnnotateImageResponse{Error: &vision.Status{Message: "bad image"}}); failed.Err == nil {
+		t.Error("expected an error for a failed image")
+	}
+}
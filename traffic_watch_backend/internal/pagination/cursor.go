@@ -0,0 +1,128 @@
+// Package pagination implements the opaque keyset cursors used by /v2 list
+// endpoints. A cursor records the sort key of the last item returned, so pages
+// stay stable when items are inserted ahead of the reader.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// Page size limits for list endpoints
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// ErrInvalidCursor is returned for cursors that weren't issued by this server
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Key is the sort position of an item: Pinned first, then Rank (e.g. priority), then Score,
+// then At, then ID
+type Key struct {
+	Pinned bool      `json:"p,omitempty"`
+	Rank   int       `json:"r,omitempty"`
+	Score  float64   `json:"s,omitempty"`
+	At     time.Time `json:"t"`
+	ID     string    `json:"i"`
+}
+
+// Encode returns the opaque cursor for k
+func (k Key) Encode() string {
+	b, _ := json.Marshal(k)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode parses a cursor produced by Encode. An empty cursor decodes to nil (first page).
+func Decode(cursor string) (*Key, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var k Key
+	if err := json.Unmarshal(b, &k); err != nil || k.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &k, nil
+}
+
+// ParseLimit parses a ?limit= value, defaulting to DefaultLimit. Values above MaxLimit are clamped.
+func ParseLimit(s string) (int, error) {
+	if s == "" {
+		return DefaultLimit, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, errors.New("limit must be a positive integer")
+	}
+	if n > MaxLimit {
+		n = MaxLimit
+	}
+	return n, nil
+}
+
+// compare orders keys by Pinned, then Rank, then Score, then At, then ID
+func compare(a, b Key) int {
+	switch {
+	case a.Pinned != b.Pinned:
+		if b.Pinned {
+			return -1
+		}
+		return 1
+	case a.Rank != b.Rank:
+		if a.Rank < b.Rank {
+			return -1
+		}
+		return 1
+	case a.Score != b.Score:
+		if a.Score < b.Score {
+			return -1
+		}
+		return 1
+	case !a.At.Equal(b.At):
+		if a.At.Before(b.At) {
+			return -1
+		}
+		return 1
+	case a.ID < b.ID:
+		return -1
+	case a.ID > b.ID:
+		return 1
+	}
+	return 0
+}
+
+// Slice returns the page of items following cursor and the cursor for the next
+// page ("" when there are no more). items must already be sorted by keyOf,
+// descending when desc is set. If the cursor's item is still present, paging
+// resumes right after it; otherwise it resumes at the first item past its key.
+func Slice[T any](items []T, keyOf func(T) Key, desc bool, after *Key, limit int) ([]T, string) {
+	start := 0
+	if after != nil {
+		start = len(items)
+		for i, item := range items {
+			k := keyOf(item)
+			if k.ID == after.ID {
+				start = i + 1
+				break
+			}
+			c := compare(k, *after)
+			if (desc && c < 0) || (!desc && c > 0) {
+				start = i
+				break
+			}
+		}
+	}
+
+	end := start + limit
+	if end >= len(items) {
+		return items[start:], ""
+	}
+	return items[start:end], keyOf(items[end-1]).Encode()
+}
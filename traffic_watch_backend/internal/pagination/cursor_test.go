@@ -0,0 +1,120 @@
+package pagination
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type item struct {
+	id string
+	at time.Time
+}
+
+func itemKey(it item) Key { return Key{At: it.at, ID: it.id} }
+
+func makeItems(n int) []item {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]item, n)
+	for i := range items {
+		// Newest first
+		items[i] = item{id: fmt.Sprintf("r%02d", i), at: base.Add(time.Duration(n-i) * time.Minute)}
+	}
+	return items
+}
+
+func TestSliceWalksAllPages(t *testing.T) {
+	items := makeItems(7)
+
+	var seen []string
+	var cursor *Key
+	for page := 0; page < 10; page++ {
+		got, next := Slice(items, itemKey, true, cursor, 3)
+		for _, it := range got {
+			seen = append(seen, it.id)
+		}
+		if next == "" {
+			break
+		}
+		var err error
+		if cursor, err = Decode(next); err != nil {
+			t.Fatalf("Decode(%q): %v", next, err)
+		}
+	}
+
+	if len(seen) != 7 || seen[0] != "r00" || seen[6] != "r06" {
+		t.Errorf("walked %v, want r00..r06", seen)
+	}
+}
+
+func TestSliceResumesAfterMissingItem(t *testing.T) {
+	items := makeItems(5)
+	after := itemKey(items[1])
+
+	// r01 is deleted between pages
+	remaining := append([]item{}, items[:1]...)
+	remaining = append(remaining, items[2:]...)
+
+	got, _ := Slice(remaining, itemKey, true, &after, 10)
+	if len(got) != 3 || got[0].id != "r02" {
+		t.Errorf("got %v, want to resume at r02", got)
+	}
+}
+
+func TestSliceAscending(t *testing.T) {
+	items := makeItems(4)
+	// Reverse into oldest-first order
+	for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+		items[i], items[j] = items[j], items[i]
+	}
+	after := Key{At: items[1].at.Add(time.Second), ID: "gone"}
+
+	got, next := Slice(items, itemKey, false, &after, 10)
+	if len(got) != 2 || got[0].id != items[2].id || next != "" {
+		t.Errorf("got %v (next %q), want the last two items", got, next)
+	}
+}
+
+func TestSliceResumesPastPinnedItems(t *testing.T) {
+	items := makeItems(4)
+	pinned := map[string]bool{items[3].id: true}
+	pinnedKey := func(it item) Key { return Key{Pinned: pinned[it.id], At: it.at, ID: it.id} }
+	// The pinned (oldest) item sorts ahead of the rest
+	ordered := append([]item{items[3]}, items[:3]...)
+
+	// A cursor on the pinned item whose row was unpinned and deleted still resumes at the unpinned items
+	after := Key{Pinned: true, At: items[3].at, ID: "gone"}
+	got, _ := Slice(ordered[1:], pinnedKey, true, &after, 10)
+	if len(got) != 3 || got[0].id != items[0].id {
+		t.Errorf("got %v, want the three unpinned items", got)
+	}
+}
+
+func TestDecodeAndParseLimit(t *testing.T) {
+	if k, err := Decode(""); k != nil || err != nil {
+		t.Errorf("Decode(\"\") = %v, %v; want nil, nil", k, err)
+	}
+	for _, bad := range []string{"!!!", "bm90LWpzb24", Key{}.Encode()} {
+		if _, err := Decode(bad); err != ErrInvalidCursor {
+			t.Errorf("Decode(%q) err = %v, want ErrInvalidCursor", bad, err)
+		}
+	}
+
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"", DefaultLimit, false},
+		{"5", 5, false},
+		{"1000", MaxLimit, false},
+		{"0", 0, true},
+		{"abc", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLimit(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLimit(%q) = %d, %v", tt.in, got, err)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package pagination
+
+import (
+	"errors"
+	"strconv"
+)
+
+// MaxOffset bounds ?offset= on the offset-paginated admin lists, so a crafted
+// request can't make the database walk past millions of rows
+const MaxOffset = 100000
+
+// ParseOffset parses an ?offset= value, defaulting to 0
+func ParseOffset(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > MaxOffset {
+		return 0, errors.New("offset must be an integer between 0 and " + strconv.Itoa(MaxOffset))
+	}
+	return n, nil
+}
+
+// Window returns at most limit items starting at offset, for backends that page in memory
+func Window[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return items[len(items):]
+	}
+	items = items[offset:]
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
@@ -0,0 +1,45 @@
+package pagination
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseOffset(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"0", 0, false},
+		{"40", 40, false},
+		{"-1", 0, true},
+		{"abc", 0, true},
+		{"100001", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseOffset(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseOffset(%q) = %d, %v", tt.in, got, err)
+		}
+	}
+}
+
+func TestWindow(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		offset, limit int
+		want          []int
+	}{
+		{0, 2, []int{1, 2}},
+		{3, 10, []int{4, 5}},
+		{5, 2, []int{}},
+		{9, 2, []int{}},
+	}
+	for _, tt := range tests {
+		if got := Window(items, tt.offset, tt.limit); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Window(offset=%d, limit=%d) = %v, want %v", tt.offset, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package ranking computes the "hot" score used to order the public feed by
+// engagement. Scores are precomputed by a background job and stored on reports.
+package ranking
+
+import (
+	"math"
+	"time"
+)
+
+// Weights of each engagement signal in the hot score
+const (
+	ReactionWeight = 1.0
+	CommentWeight  = 2.0
+	PriorityWeight = 1.0 // Per point of priority above the default (100)
+
+	// Gravity controls how quickly scores fall with age; higher sinks old reports faster
+	Gravity = 1.5
+)
+
+// Inputs are the signals a report's hot score is computed from
+type Inputs struct {
+	Priority  int // Effective priority (default 100)
+	Reactions int
+	Comments  int
+	CreatedAt time.Time
+}
+
+// HotScore ranks a report by engagement divided by a power of its age in hours,
+// so new reports with a little engagement outrank old ones with a lot.
+// Priority below the default doesn't subtract from the score.
+func HotScore(in Inputs, now time.Time) float64 {
+	points := 1 +
+		PriorityWeight*float64(max(in.Priority-100, 0)) +
+		ReactionWeight*float64(in.Reactions) +
+		CommentWeight*float64(in.Comments)
+	ageHours := max(now.Sub(in.CreatedAt).Hours(), 0)
+	return points / math.Pow(ageHours+2, Gravity)
+}
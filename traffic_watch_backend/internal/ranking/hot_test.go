@@ -0,0 +1,32 @@
+package ranking
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHotScore(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	score := func(priority, reactions, comments int, age time.Duration) float64 {
+		return HotScore(Inputs{Priority: priority, Reactions: reactions, Comments: comments, CreatedAt: now.Add(-age)}, now)
+	}
+
+	if a, b := score(100, 5, 0, time.Hour), score(100, 5, 0, 48*time.Hour); a <= b {
+		t.Errorf("newer report should score higher: %f <= %f", a, b)
+	}
+	if a, b := score(100, 10, 2, time.Hour), score(100, 0, 0, time.Hour); a <= b {
+		t.Errorf("engaged report should score higher: %f <= %f", a, b)
+	}
+	if a, b := score(120, 0, 0, time.Hour), score(100, 0, 0, time.Hour); a <= b {
+		t.Errorf("boosted priority should score higher: %f <= %f", a, b)
+	}
+	if a, b := score(1, 0, 0, time.Hour), score(100, 0, 0, time.Hour); a != b {
+		t.Errorf("priority below the default should not lower the score: %f != %f", a, b)
+	}
+	if a, b := score(100, 3, 0, 2*time.Hour), score(100, 50, 10, 30*24*time.Hour); a <= b {
+		t.Errorf("a month-old report should not outrank a fresh one: %f <= %f", a, b)
+	}
+	if s := score(100, 0, 0, -time.Hour); s <= 0 {
+		t.Errorf("future createdAt should still score positive, got %f", s)
+	}
+}
@@ -0,0 +1,77 @@
+// Package ratelimit throttles per-key actions with an in-memory sliding window.
+// Limits are per server instance, which is enough to stop scripted churn from a
+// single client without adding a shared store.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter allows up to limit events per key in any window-long period.
+// A nil Limiter allows everything.
+type Limiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	events    map[string][]time.Time
+	lastSweep time.Time
+}
+
+// New creates a limiter allowing limit events per key per window
+func New(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:  limit,
+		window: window,
+		now:    time.Now,
+		events: map[string][]time.Time{},
+	}
+}
+
+// Allow records an event for key and reports whether it is within the limit.
+// Rejected events are not recorded; retryAfter is how long until the next one would be allowed.
+func (l *Limiter) Allow(key string) (ok bool, retryAfter time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	now := l.now()
+	cutoff := now.Add(-l.window)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= l.window {
+		l.sweep(cutoff)
+		l.lastSweep = now
+	}
+
+	recent := prune(l.events[key], cutoff)
+	if len(recent) >= l.limit {
+		l.events[key] = recent
+		return false, recent[0].Sub(cutoff)
+	}
+	l.events[key] = append(recent, now)
+	return true, 0
+}
+
+// sweep drops keys with no events in the window so idle keys don't accumulate
+func (l *Limiter) sweep(cutoff time.Time) {
+	for key, times := range l.events {
+		if recent := prune(times, cutoff); len(recent) == 0 {
+			delete(l.events, key)
+		} else {
+			l.events[key] = recent
+		}
+	}
+}
+
+// prune drops events at or before cutoff; times are in ascending order
+func prune(times []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
@@ -0,0 +1,52 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	l := New(2, time.Minute)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("user-1"); !ok {
+			t.Fatalf("event %d should be allowed", i+1)
+		}
+		now = now.Add(10 * time.Second)
+	}
+	ok, retryAfter := l.Allow("user-1")
+	if ok {
+		t.Fatal("third event inside the window should be rejected")
+	}
+	if retryAfter != 40*time.Second {
+		t.Errorf("retryAfter = %s, want 40s", retryAfter)
+	}
+
+	if ok, _ := l.Allow("user-2"); !ok {
+		t.Error("limits are per key")
+	}
+
+	// The first event leaves the window, making room for one more
+	now = start.Add(time.Minute + time.Second)
+	if ok, _ := l.Allow("user-1"); !ok {
+		t.Error("event after the oldest one expired should be allowed")
+	}
+	if ok, _ := l.Allow("user-1"); ok {
+		t.Error("only one slot should have freed up")
+	}
+
+	// Idle keys are swept
+	now = start.Add(10 * time.Minute)
+	l.Allow("user-3")
+	if _, ok := l.events["user-2"]; ok {
+		t.Error("idle key should have been swept")
+	}
+
+	var nilLimiter *Limiter
+	if ok, _ := nilLimiter.Allow("anyone"); !ok {
+		t.Error("nil limiter should allow everything")
+	}
+}
@@ -0,0 +1,108 @@
+// Package staticmap renders small map images of a report's location with the
+// Google Static Maps API. The images are rendered once per report and kept in
+// the blob store, so PDFs, digests and social embeds can show where an incident
+// happened without loading an interactive map or calling the API again.
+package staticmap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Renderer renders a map image centred on a location, returning the image and its content type
+type Renderer interface {
+	Render(ctx context.Context, lat, lng float64) ([]byte, string, error)
+}
+
+// Defaults for GoogleRenderer images: wide enough for a social card, close
+// enough to show the streets around the incident
+const (
+	DefaultWidth  = 600
+	DefaultHeight = 315
+	DefaultZoom   = 15
+)
+
+// maxImageBytes bounds the image read from the API; a PNG at the largest
+// Static Maps size is well under it
+const maxImageBytes = 4 << 20
+
+const staticMapsURL = "https://maps.googleapis.com/maps/api/staticmap"
+
+// GoogleRenderer renders maps with the Google Static Maps API
+type GoogleRenderer struct {
+	apiKey     string
+	baseURL    string
+	width      int
+	height     int
+	zoom       int
+	httpClient *http.Client
+}
+
+// NewGoogleRenderer creates a Static Maps client with an API key and the default size and zoom
+func NewGoogleRenderer(apiKey string) *GoogleRenderer {
+	return &GoogleRenderer{
+		apiKey:     apiKey,
+		baseURL:    staticMapsURL,
+		width:      DefaultWidth,
+		height:     DefaultHeight,
+		zoom:       DefaultZoom,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// Render fetches a PNG map centred on lat, lng with a marker at the location
+func (r *GoogleRenderer) Render(ctx context.Context, lat, lng float64) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.mapURL(lat, lng), nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create static map request: %w", err)
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch static map: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The API answers errors with a text body (or an error image with X-Staticmap-API-Warning)
+	contentType := resp.Header.Get("Content-Type")
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, "", fmt.Errorf("static map request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil, "", fmt.Errorf("static map response has content type %q, want an image", contentType)
+	}
+
+	image, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read static map: %w", err)
+	}
+	if len(image) > maxImageBytes {
+		return nil, "", fmt.Errorf("static map is larger than %d bytes", maxImageBytes)
+	}
+	return image, contentType, nil
+}
+
+// mapURL is the Static Maps request for a location. The API key is part of the
+// URL, so it must never be handed to clients.
+func (r *GoogleRenderer) mapURL(lat, lng float64) string {
+	center := formatCoordinate(lat) + "," + formatCoordinate(lng)
+	q := url.Values{}
+	q.Set("center", center)
+	q.Set("zoom", strconv.Itoa(r.zoom))
+	q.Set("size", fmt.Sprintf("%dx%d", r.width, r.height))
+	q.Set("format", "png")
+	q.Set("markers", "color:red|"+center)
+	q.Set("key", r.apiKey)
+	return r.baseURL + "?" + q.Encode()
+}
+
+// formatCoordinate keeps six decimal places (about 10cm), more than the map can show
+func formatCoordinate(v float64) string {
+	return strconv.FormatFloat(v, 'f', 6, 64)
+}
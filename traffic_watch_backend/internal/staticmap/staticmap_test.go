@@ -0,0 +1,68 @@
+package staticmap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGoogleRenderer_Render(t *testing.T) {
+	var query map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("\x89PNG"))
+	}))
+	defer server.Close()
+
+	r := NewGoogleRenderer("key-123")
+	r.baseURL = server.URL
+
+	image, contentType, err := r.Render(context.Background(), 39.7589, -84.19161)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if string(image) != "\x89PNG" || contentType != "image/png" {
+		t.Errorf("Render = %q, %q", image, contentType)
+	}
+	want := map[string]string{
+		"center":  "39.758900,-84.191610",
+		"markers": "color:red|39.758900,-84.191610",
+		"size":    "600x315",
+		"zoom":    "15",
+		"key":     "key-123",
+	}
+	for param, value := range want {
+		if got := query[param]; len(got) != 1 || got[0] != value {
+			t.Errorf("%s = %v, want %q", param, got, value)
+		}
+	}
+}
+
+func TestGoogleRenderer_RenderErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+	}{
+		{"rejected key", http.StatusForbidden, "text/plain"},
+		{"not an image", http.StatusOK, "text/html"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(tt.status)
+				w.Write([]byte("The provided API key is invalid."))
+			}))
+			defer server.Close()
+
+			r := NewGoogleRenderer("bad")
+			r.baseURL = server.URL
+			if _, _, err := r.Render(context.Background(), 1, 2); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// BlobStore stores report media files. GCSClient implements it, and
+// LocalBlobStore stands in for it in local development.
+type BlobStore interface {
+	// UploadFile stores a report's media file and returns its object path
+	UploadFile(ctx context.Context, userID, reportID, fileID string, contentType string, reader io.Reader) (string, error)
+
+	// GetSignedURL returns a URL that can read the object until it expires (0 for the default)
+	GetSignedURL(ctx context.Context, objectPath string, expiration time.Duration) (string, error)
+
+	// GetUploadSignedURL returns a URL a client can PUT a media file to, and the object path it is stored at
+	GetUploadSignedURL(ctx context.Context, userID, reportID, fileID, contentType string) (string, string, error)
+
+	// OpenFile opens an object for reading; the caller closes it
+	OpenFile(ctx context.Context, objectPath string) (io.ReadCloser, error)
+
+	// DeleteFile deletes an object; one that is already gone is not an error
+	DeleteFile(ctx context.Context, objectPath string) error
+
+	// ObjectSize returns the size of an object; exists is false if there is no such object
+	ObjectSize(ctx context.Context, objectPath string) (size int64, exists bool, err error)
+
+	// GetBucketName returns the name of the bucket objects are stored in
+	GetBucketName() string
+}
+
+// VideoHost hosts report videos outside the blob store. YouTubeClient implements
+// it, and LocalVideoHost stands in for it in local development.
+type VideoHost interface {
+	// UploadVideo uploads a video and returns its ID and watch URL
+	UploadVideo(ctx context.Context, title, description string, reader io.Reader, contentType string) (*YouTubeUploadResult, error)
+
+	// DeleteVideo removes a video; one that is already gone is not an error
+	DeleteVideo(ctx context.Context, videoID string) error
+}
+
+var (
+	_ BlobStore = (*GCSClient)(nil)
+	_ BlobStore = (*LocalBlobStore)(nil)
+	_ VideoHost = (*YouTubeClient)(nil)
+	_ VideoHost = (*LocalVideoHost)(nil)
+)
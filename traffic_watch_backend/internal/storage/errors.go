@@ -0,0 +1,48 @@
+package storage
+
+import "errors"
+
+// Sentinel errors returned by both storage backends. They are wrapped with what the
+// operation was about (e.g. "report not found"), so handlers map them to status codes
+// with errors.Is and can still show the message.
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrForbidden = errors.New("forbidden")
+	ErrConflict  = errors.New("conflict")
+)
+
+// ErrInvitationExpired is the conflict returned when accepting an expired role invitation
+var ErrInvitationExpired = conflict("invitation expired")
+
+// ErrRoleDowngrade is the conflict returned when accepting a role invitation would
+// lower the user's role, e.g. an admin accepting a stale contributor invitation
+var ErrRoleDowngrade = conflict("invitation would lower the user's role")
+
+// storageError is a sentinel error with a more specific message
+type storageError struct {
+	msg  string
+	kind error
+}
+
+func (e *storageError) Error() string {
+	return e.msg
+}
+
+func (e *storageError) Unwrap() error {
+	return e.kind
+}
+
+// notFound returns ErrNotFound for a missing entity, e.g. notFound("report") reads "report not found"
+func notFound(entity string) error {
+	return &storageError{msg: entity + " not found", kind: ErrNotFound}
+}
+
+// forbidden returns ErrForbidden with msg as its message
+func forbidden(msg string) error {
+	return &storageError{msg: msg, kind: ErrForbidden}
+}
+
+// conflict returns ErrConflict with msg as its message
+func conflict(msg string) error {
+	return &storageError{msg: msg, kind: ErrConflict}
+}
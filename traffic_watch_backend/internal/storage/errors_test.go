@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		msg  string
+		kind error
+	}{
+		{notFound("report"), "report not found", ErrNotFound},
+		{forbidden("comment not found or not authorized"), "comment not found or not authorized", ErrForbidden},
+		{conflict("report already exists"), "report already exists", ErrConflict},
+		{ErrInvitationExpired, "invitation expired", ErrConflict},
+	}
+	for _, tt := range tests {
+		if tt.err.Error() != tt.msg {
+			t.Errorf("message = %q, want %q", tt.err.Error(), tt.msg)
+		}
+		// Still matched once wrapped again by a caller
+		if wrapped := fmt.Errorf("sync: %w", tt.err); !errors.Is(wrapped, tt.kind) {
+			t.Errorf("%q does not match %v", tt.msg, tt.kind)
+		}
+	}
+	if errors.Is(notFound("user"), ErrConflict) {
+		t.Error("not found matched ErrConflict")
+	}
+}
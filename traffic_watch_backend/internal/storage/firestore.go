@@ -7,8 +7,11 @@ import (
 
 	"cloud.google.com/go/firestore"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/pagination"
 )
 
 const (
@@ -20,6 +23,10 @@ const (
 type FirestoreClient struct {
 	client    *firestore.Client
 	projectID string
+
+	// Set on the client WithTransaction hands to its callback
+	tx     *firestore.Transaction
+	staged map[string]*models.TrafficReport // Report writes held until the transaction commits
 }
 
 // NewFirestoreClient creates a new Firestore client
@@ -40,6 +47,15 @@ func (f *FirestoreClient) Close() error {
 	return f.client.Close()
 }
 
+// Ping reads at most one user document; Firestore has no cheaper round trip
+func (f *FirestoreClient) Ping(ctx context.Context) error {
+	_, err := f.client.Collection(usersCollection).Limit(1).Documents(ctx).Next()
+	if err == iterator.Done {
+		return nil
+	}
+	return err
+}
+
 // CreateReport creates a new report in Firestore
 func (f *FirestoreClient) CreateReport(ctx context.Context, report *models.TrafficReport) error {
 	if report.ID == "" {
@@ -50,13 +66,39 @@ func (f *FirestoreClient) CreateReport(ctx context.Context, report *models.Traff
 	report.UpdatedAt = time.Now()
 	report.Status = models.StatusSubmitted
 
-	_, err := f.client.Collection(reportsCollection).Doc(report.ID).Set(ctx, report)
+	return f.createReport(ctx, report)
+}
+
+// ImportReport stores a report from a historical import as given, keeping its
+// status, review fields and timestamps
+func (f *FirestoreClient) ImportReport(ctx context.Context, report *models.TrafficReport) error {
+	if report.ID == "" {
+		return errors.New("report ID is required")
+	}
+	if report.Status == "" || report.CreatedAt.IsZero() || report.UpdatedAt.IsZero() {
+		return errors.New("imported reports need a status and timestamps")
+	}
+	return f.createReport(ctx, report)
+}
+
+func (f *FirestoreClient) createReport(ctx context.Context, report *models.TrafficReport) error {
+	_, err := f.client.Collection(reportsCollection).Doc(report.ID).Create(ctx, report)
+	if status.Code(err) == codes.AlreadyExists {
+		return conflict("report already exists")
+	}
 	return err
 }
 
 // GetReport retrieves a report by ID
 func (f *FirestoreClient) GetReport(ctx context.Context, reportID string) (*models.TrafficReport, error) {
+	if f.tx != nil {
+		return f.getReportInTransaction(reportID)
+	}
+
 	doc, err := f.client.Collection(reportsCollection).Doc(reportID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, notFound("report")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -77,11 +119,11 @@ func (f *FirestoreClient) GetReportByIDAndUser(ctx context.Context, reportID, us
 	}
 
 	if report.UserID != userID {
-		return nil, errors.New("report not found")
+		return nil, notFound("report")
 	}
 
 	if report.Status == models.StatusDeleted {
-		return nil, errors.New("report not found")
+		return nil, notFound("report")
 	}
 
 	return report, nil
@@ -118,11 +160,81 @@ func (f *FirestoreClient) ListReportsByUser(ctx context.Context, userID string)
 	return reports, nil
 }
 
-// UpdateReport updates an existing report
+// ListUserReports retrieves a user's non-deleted reports in sort order, only those in
+// status when it is non-empty. Sorting happens in memory: ordering by priority in
+// Firestore would leave out reports that have none.
+func (f *FirestoreClient) ListUserReports(ctx context.Context, userID, status string, sort models.ReportSort) ([]models.TrafficReport, error) {
+	query := f.client.Collection(reportsCollection).Where("userId", "==", userID)
+	if status != "" {
+		query = query.Where("status", "==", status)
+	}
+	iter := query.Documents(ctx)
+
+	var reports []models.TrafficReport
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		if report.Status == models.StatusDeleted {
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	models.SortReports(reports, sort)
+	return reports, nil
+}
+
+// CountUserReports counts the reports ListUserReports would return, reading only their status
+func (f *FirestoreClient) CountUserReports(ctx context.Context, userID, status string) (int, error) {
+	query := f.client.Collection(reportsCollection).Where("userId", "==", userID)
+	if status != "" {
+		query = query.Where("status", "==", status)
+	}
+	iter := query.Select("status").Documents(ctx)
+	defer iter.Stop()
+
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if s, _ := doc.Data()["status"].(string); s != models.StatusDeleted {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// UpdateReport updates an existing report. A changed status must be allowed by the
+// status state machine.
 func (f *FirestoreClient) UpdateReport(ctx context.Context, report *models.TrafficReport) error {
+	current, err := f.GetReport(ctx, report.ID)
+	if err != nil {
+		return err
+	}
+	if current.Status != report.Status {
+		if err := models.CheckStatusTransition(current.Status, report.Status); err != nil {
+			return err
+		}
+	}
+
 	report.UpdatedAt = time.Now()
 
-	_, err := f.client.Collection(reportsCollection).Doc(report.ID).Set(ctx, report)
+	err = f.saveReport(ctx, report.ID, report)
 	return err
 }
 
@@ -133,10 +245,13 @@ func (f *FirestoreClient) DeleteReport(ctx context.Context, reportID, userID str
 		return err
 	}
 
+	if err := models.CheckStatusTransition(report.Status, models.StatusDeleted); err != nil {
+		return err
+	}
 	report.Status = models.StatusDeleted
 	report.UpdatedAt = time.Now()
 
-	_, err = f.client.Collection(reportsCollection).Doc(reportID).Set(ctx, report)
+	err = f.saveReport(ctx, reportID, report)
 	return err
 }
 
@@ -150,7 +265,7 @@ func (f *FirestoreClient) AddMediaFileToReport(ctx context.Context, reportID str
 	report.MediaFiles = append(report.MediaFiles, mediaFile)
 	report.UpdatedAt = time.Now()
 
-	_, err = f.client.Collection(reportsCollection).Doc(reportID).Set(ctx, report)
+	err = f.saveReport(ctx, reportID, report)
 	return err
 }
 
@@ -158,11 +273,55 @@ func (f *FirestoreClient) AddMediaFileToReport(ctx context.Context, reportID str
 // Admin Report Methods (Firestore implementation)
 // ============================================================================
 
-// ListAllReports retrieves all non-deleted reports (for admin dashboard)
-func (f *FirestoreClient) ListAllReports(ctx context.Context) ([]models.TrafficReport, error) {
+// ListAllReports retrieves a page of non-deleted reports in sort order (for admin dashboard).
+// Sorted and paged in memory like ListUserReports, so this still reads every report.
+func (f *FirestoreClient) ListAllReports(ctx context.Context, includeShadowBanned bool, sort models.ReportSort, limit, offset int) ([]models.TrafficReport, error) {
+	reports, err := f.allReports(ctx, includeShadowBanned, sort)
+	if err != nil {
+		return nil, err
+	}
+	return pagination.Window(reports, offset, limit), nil
+}
+
+// CountAllReports counts the reports ListAllReports pages through, reading only their
+// authors and statuses
+func (f *FirestoreClient) CountAllReports(ctx context.Context, includeShadowBanned bool) (int, error) {
+	banned := map[string]bool{}
+	if !includeShadowBanned {
+		var err error
+		if banned, err = f.shadowBannedUserIDs(ctx); err != nil {
+			return 0, err
+		}
+	}
+
 	iter := f.client.Collection(reportsCollection).
-		OrderBy("createdAt", firestore.Desc).
+		Select("userId", "status").
 		Documents(ctx)
+	defer iter.Stop()
+
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		if report.Status != models.StatusDeleted && !banned[report.UserID] {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// allReports reads every non-deleted report in sort order
+func (f *FirestoreClient) allReports(ctx context.Context, includeShadowBanned bool, sort models.ReportSort) ([]models.TrafficReport, error) {
+	iter := f.client.Collection(reportsCollection).Documents(ctx)
 
 	var reports []models.TrafficReport
 	for {
@@ -184,11 +343,16 @@ func (f *FirestoreClient) ListAllReports(ctx context.Context) ([]models.TrafficR
 		reports = append(reports, report)
 	}
 
-	return reports, nil
+	models.SortReports(reports, sort)
+	if includeShadowBanned {
+		return reports, nil
+	}
+	return f.withoutShadowBanned(ctx, reports)
 }
 
-// ListReportsAwaitingReview retrieves reports with "submitted" status (for admin review queue)
-func (f *FirestoreClient) ListReportsAwaitingReview(ctx context.Context) ([]models.TrafficReport, error) {
+// ListReportsAwaitingReview retrieves a page of reports with "submitted" status (for admin review queue).
+// Media and shadow-ban filtering happen after the query, so paging is done in memory.
+func (f *FirestoreClient) ListReportsAwaitingReview(ctx context.Context, includeShadowBanned bool, limit, offset int) ([]models.TrafficReport, error) {
 	iter := f.client.Collection(reportsCollection).
 		Where("status", "==", models.StatusSubmitted).
 		OrderBy("createdAt", firestore.Asc).
@@ -208,13 +372,58 @@ func (f *FirestoreClient) ListReportsAwaitingReview(ctx context.Context) ([]mode
 		if err := doc.DataTo(&report); err != nil {
 			continue
 		}
-		reports = append(reports, report)
+		if !report.MediaPending() {
+			reports = append(reports, report)
+		}
 	}
 
-	return reports, nil
+	if !includeShadowBanned {
+		var err error
+		if reports, err = f.withoutShadowBanned(ctx, reports); err != nil {
+			return nil, err
+		}
+	}
+	return pagination.Window(reports, offset, limit), nil
+}
+
+// CountReportsAwaitingReview counts the reports ListReportsAwaitingReview would return,
+// reading only their authors and media
+func (f *FirestoreClient) CountReportsAwaitingReview(ctx context.Context, includeShadowBanned bool) (int, error) {
+	banned := map[string]bool{}
+	if !includeShadowBanned {
+		var err error
+		if banned, err = f.shadowBannedUserIDs(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "==", models.StatusSubmitted).
+		Select("userId", "mediaFiles").
+		Documents(ctx)
+	defer iter.Stop()
+
+	count := 0
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		if !banned[report.UserID] && !report.MediaPending() {
+			count++
+		}
+	}
+	return count, nil
 }
 
-// ListApprovedReports retrieves reports with "reviewed_pass" status (for public feed)
+// ListApprovedReports retrieves reports with "reviewed_pass" status (for public feed), pinned reports first
 func (f *FirestoreClient) ListApprovedReports(ctx context.Context) ([]models.TrafficReport, error) {
 	iter := f.client.Collection(reportsCollection).
 		Where("status", "==", models.StatusReviewedPass).
@@ -238,58 +447,69 @@ func (f *FirestoreClient) ListApprovedReports(ctx context.Context) ([]models.Tra
 		reports = append(reports, report)
 	}
 
-	return reports, nil
-}
+	reports = withoutEmbargoed(reports)
+	reports = withoutPendingMedia(reports)
+	pinnedFirst(reports)
 
-// UpdateReportStatus updates a report's status and optional review reason
-func (f *FirestoreClient) UpdateReportStatus(ctx context.Context, reportID, status, reviewReason, reviewedBy string) error {
-	report, err := f.GetReport(ctx, reportID)
+	reports, err := f.withoutShadowBanned(ctx, reports)
 	if err != nil {
-		return err
-	}
-
-	if report.Status == models.StatusDeleted {
-		return errors.New("report not found")
-	}
-
-	report.Status = status
-	report.ReviewReason = reviewReason
-	report.UpdatedAt = time.Now()
-	// Append reviewedBy to existing value
-	if report.ReviewedBy == "" {
-		report.ReviewedBy = reviewedBy
-	} else {
-		report.ReviewedBy = report.ReviewedBy + "," + reviewedBy
+		return nil, err
 	}
+	return f.withoutDeactivated(ctx, reports)
+}
 
-	_, err = f.client.Collection(reportsCollection).Doc(reportID).Set(ctx, report)
-	return err
+// UpdateReportStatus updates a report's status and optional review reason. The
+// read, transition check and write share a transaction, so concurrent reviews
+// can't both pass the check.
+func (f *FirestoreClient) UpdateReportStatus(ctx context.Context, reportID, status, reviewReason, reviewedBy string) error {
+	return f.runTransaction(ctx, func(t *FirestoreClient) error {
+		report, err := t.reviewedReport(ctx, reportID, status, reviewReason, reviewedBy)
+		if err != nil {
+			return err
+		}
+		return t.saveReport(ctx, reportID, report)
+	})
 }
 
 // UpdateReportStatusWithPriority updates a report's status, review reason, and priority
+// in one transaction, like UpdateReportStatus
 func (f *FirestoreClient) UpdateReportStatusWithPriority(ctx context.Context, reportID, status, reviewReason string, priority *int, reviewedBy string) error {
+	return f.runTransaction(ctx, func(t *FirestoreClient) error {
+		report, err := t.reviewedReport(ctx, reportID, status, reviewReason, reviewedBy)
+		if err != nil {
+			return err
+		}
+		report.Priority = priority
+		return t.saveReport(ctx, reportID, report)
+	})
+}
+
+// reviewedReport reads a report and applies a status change to it, checking the
+// transition; f must be bound to the transaction the result is saved in
+func (f *FirestoreClient) reviewedReport(ctx context.Context, reportID, status, reviewReason, reviewedBy string) (*models.TrafficReport, error) {
 	report, err := f.GetReport(ctx, reportID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if report.Status == models.StatusDeleted {
-		return errors.New("report not found")
+		return nil, notFound("report")
+	}
+	if err := models.CheckStatusTransition(report.Status, status); err != nil {
+		return nil, err
 	}
 
 	report.Status = status
 	report.ReviewReason = reviewReason
-	report.Priority = priority
 	report.UpdatedAt = time.Now()
+	report.StatusChangedAt = &report.UpdatedAt
 	// Append reviewedBy to existing value
 	if report.ReviewedBy == "" {
 		report.ReviewedBy = reviewedBy
 	} else {
 		report.ReviewedBy = report.ReviewedBy + "," + reviewedBy
 	}
-
-	_, err = f.client.Collection(reportsCollection).Doc(reportID).Set(ctx, report)
-	return err
+	return report, nil
 }
 
 // ============================================================================
@@ -316,8 +536,11 @@ func (f *FirestoreClient) CreateOrUpdateUser(ctx context.Context, user *models.U
 // GetUserByID retrieves a user by their ID (Google subject)
 func (f *FirestoreClient) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
 	doc, err := f.client.Collection(usersCollection).Doc(userID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, notFound("user")
+	}
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, err
 	}
 
 	var user models.User
@@ -337,7 +560,7 @@ func (f *FirestoreClient) GetUserByEmail(ctx context.Context, email string) (*mo
 
 	doc, err := iter.Next()
 	if err == iterator.Done {
-		return nil, errors.New("user not found")
+		return nil, notFound("user")
 	}
 	if err != nil {
 		return nil, err
@@ -400,6 +623,11 @@ func (f *FirestoreClient) GetUserReactionType(ctx context.Context, reportID, use
 	return "", errors.New("reactions not implemented for Firestore backend")
 }
 
+// ToggleReaction adds or removes a user's reaction (stub - not implemented for Firestore)
+func (f *FirestoreClient) ToggleReaction(ctx context.Context, reaction *models.Reaction) (string, bool, error) {
+	return "", false, errors.New("reactions not implemented for Firestore backend")
+}
+
 // GetReactionCounts gets the count of each reaction type for a report (stub)
 func (f *FirestoreClient) GetReactionCounts(ctx context.Context, reportID string) ([]models.ReactionCount, error) {
 	return []models.ReactionCount{}, nil
@@ -444,7 +672,7 @@ func (f *FirestoreClient) AddComment(ctx context.Context, comment *models.Commen
 }
 
 // GetComments gets all comments for a report (stub)
-func (f *FirestoreClient) GetComments(ctx context.Context, reportID string) ([]models.Comment, error) {
+func (f *FirestoreClient) GetComments(ctx context.Context, reportID, viewerID string) ([]models.Comment, error) {
 	return []models.Comment{}, nil
 }
 
@@ -466,7 +694,7 @@ func (f *FirestoreClient) AdjustReportPriority(ctx context.Context, reportID str
 	}
 
 	if report.Status == models.StatusDeleted {
-		return errors.New("report not found")
+		return notFound("report")
 	}
 
 	// Default priority to 100 if nil
@@ -478,6 +706,27 @@ func (f *FirestoreClient) AdjustReportPriority(ctx context.Context, reportID str
 	report.Priority = &newPriority
 	report.UpdatedAt = time.Now()
 
-	_, err = f.client.Collection(reportsCollection).Doc(reportID).Set(ctx, report)
+	err = f.saveReport(ctx, reportID, report)
 	return err
 }
+
+// ChangeReportPriority sets or adjusts a report's priority within MinPriority..MaxPriority
+// and returns the new priority
+func (f *FirestoreClient) ChangeReportPriority(ctx context.Context, reportID string, change models.PriorityChange) (int, error) {
+	var priority int
+	err := f.runTransaction(ctx, func(t *FirestoreClient) error {
+		report, err := t.GetReport(ctx, reportID)
+		if err != nil {
+			return err
+		}
+		if report.Status == models.StatusDeleted {
+			return notFound("report")
+		}
+
+		priority = change.Apply(report.PriorityRank())
+		report.Priority = &priority
+		report.UpdatedAt = time.Now()
+		return t.saveReport(ctx, reportID, report)
+	})
+	return priority, err
+}
@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// User Activity Methods (Firestore stub implementations)
+// ============================================================================
+
+// ListUserComments retrieves a user's comments across reports (stub - comments
+// are not implemented for Firestore, so there is never any activity)
+func (f *FirestoreClient) ListUserComments(ctx context.Context, userID string, limit int) ([]models.CommentActivity, error) {
+	return []models.CommentActivity{}, nil
+}
+
+// ListUserReactions retrieves a user's reactions across reports (stub - reactions
+// are not implemented for Firestore, so there is never any activity)
+func (f *FirestoreClient) ListUserReactions(ctx context.Context, userID string, limit int) ([]models.ReactionActivity, error) {
+	return []models.ReactionActivity{}, nil
+}
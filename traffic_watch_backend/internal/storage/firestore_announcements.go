@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const announcementsCollection = "announcements"
+
+// ============================================================================
+// Announcement Methods (Firestore implementation)
+// ============================================================================
+
+// CreateAnnouncement stores a new announcement
+func (f *FirestoreClient) CreateAnnouncement(ctx context.Context, a *models.Announcement) error {
+	if a.ID == "" {
+		return errors.New("announcement ID is required")
+	}
+	now := time.Now()
+	a.CreatedAt = now
+	a.UpdatedAt = now
+
+	if _, err := f.client.Collection(announcementsCollection).Doc(a.ID).Set(ctx, a); err != nil {
+		return fmt.Errorf("failed to create announcement: %w", err)
+	}
+	return nil
+}
+
+// UpdateAnnouncement replaces an announcement's content and schedule
+func (f *FirestoreClient) UpdateAnnouncement(ctx context.Context, a *models.Announcement) error {
+	ref := f.client.Collection(announcementsCollection).Doc(a.ID)
+	doc, err := ref.Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return notFound("announcement")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get announcement: %w", err)
+	}
+
+	var existing models.Announcement
+	if err := doc.DataTo(&existing); err != nil {
+		return fmt.Errorf("failed to decode announcement: %w", err)
+	}
+	a.CreatedBy = existing.CreatedBy
+	a.CreatedAt = existing.CreatedAt
+	a.UpdatedAt = time.Now()
+
+	if _, err := ref.Set(ctx, a); err != nil {
+		return fmt.Errorf("failed to update announcement: %w", err)
+	}
+	return nil
+}
+
+// DeleteAnnouncement removes an announcement
+func (f *FirestoreClient) DeleteAnnouncement(ctx context.Context, announcementID string) error {
+	ref := f.client.Collection(announcementsCollection).Doc(announcementID)
+	if _, err := ref.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return notFound("announcement")
+		}
+		return fmt.Errorf("failed to get announcement: %w", err)
+	}
+	if _, err := ref.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	return nil
+}
+
+// ListAnnouncements retrieves every announcement, newest first
+func (f *FirestoreClient) ListAnnouncements(ctx context.Context) ([]models.Announcement, error) {
+	return f.queryAnnouncements(ctx, f.client.Collection(announcementsCollection).OrderBy("startsAt", firestore.Desc))
+}
+
+// ListActiveAnnouncements retrieves announcements whose window contains at, most severe first.
+// Firestore can't combine the start and optional end conditions, so the end is checked in memory.
+func (f *FirestoreClient) ListActiveAnnouncements(ctx context.Context, at time.Time) ([]models.Announcement, error) {
+	started, err := f.queryAnnouncements(ctx, f.client.Collection(announcementsCollection).
+		Where("startsAt", "<=", at).
+		OrderBy("startsAt", firestore.Desc))
+	if err != nil {
+		return nil, err
+	}
+
+	active := []models.Announcement{}
+	for _, a := range started {
+		if a.IsActive(at) {
+			active = append(active, a)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool {
+		return severityRank(active[i].Severity) < severityRank(active[j].Severity)
+	})
+	return active, nil
+}
+
+func (f *FirestoreClient) queryAnnouncements(ctx context.Context, q firestore.Query) ([]models.Announcement, error) {
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	announcements := []models.Announcement{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list announcements: %w", err)
+		}
+		var a models.Announcement
+		if err := doc.DataTo(&a); err != nil {
+			continue
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, nil
+}
+
+// severityRank orders announcements most severe first, matching the Postgres query
+func severityRank(severity string) int {
+	switch severity {
+	case models.AnnouncementCritical:
+		return 0
+	case models.AnnouncementWarning:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const apiUsageCollection = "apiUsage"
+
+// firestoreBatchLimit is the most writes Firestore accepts in one batch
+const firestoreBatchLimit = 500
+
+// apiUsageDocID derives a document ID from the fields that identify a usage row,
+// which may contain slashes and so can't be used directly
+func apiUsageDocID(u models.APIUsage) string {
+	sum := sha256.Sum256([]byte(u.Day + "\x00" + u.Route + "\x00" + u.Consumer + "\x00" + u.Filters))
+	return hex.EncodeToString(sum[:16])
+}
+
+// ============================================================================
+// API Usage Methods (Firestore implementation)
+// ============================================================================
+
+// AddAPIUsage adds request counts to the daily API usage totals, incrementing
+// existing documents in batches
+func (f *FirestoreClient) AddAPIUsage(ctx context.Context, usage []models.APIUsage) error {
+	for start := 0; start < len(usage); start += firestoreBatchLimit {
+		batch := f.client.Batch()
+		for _, u := range usage[start:min(start+firestoreBatchLimit, len(usage))] {
+			batch.Set(f.client.Collection(apiUsageCollection).Doc(apiUsageDocID(u)), map[string]interface{}{
+				"day":      u.Day,
+				"route":    u.Route,
+				"consumer": u.Consumer,
+				"filters":  u.Filters,
+				"requests": firestore.Increment(u.Requests),
+				"errors":   firestore.Increment(u.Errors),
+			}, firestore.MergeAll)
+		}
+		if _, err := batch.Commit(ctx); err != nil {
+			return fmt.Errorf("failed to add API usage: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListAPIUsage retrieves the daily API usage totals from since (YYYY-MM-DD) on
+func (f *FirestoreClient) ListAPIUsage(ctx context.Context, since string) ([]models.APIUsage, error) {
+	iter := f.client.Collection(apiUsageCollection).Where("day", ">=", since).OrderBy("day", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	usage := []models.APIUsage{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list API usage: %w", err)
+		}
+		var u models.APIUsage
+		if err := doc.DataTo(&u); err != nil {
+			return nil, fmt.Errorf("failed to decode API usage: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	return usage, nil
+}
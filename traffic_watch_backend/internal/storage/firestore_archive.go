@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Archive Methods (Firestore implementation)
+// ============================================================================
+
+// ArchiveReportsCreatedBefore moves approved reports created before cutoff to "archived"
+// and returns their IDs
+func (f *FirestoreClient) ArchiveReportsCreatedBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "==", models.StatusReviewedPass).
+		Where("createdAt", "<", cutoff).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var ids []string
+	now := time.Now()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return ids, fmt.Errorf("failed to list reports to archive: %w", err)
+		}
+		if _, err := doc.Ref.Update(ctx, []firestore.Update{
+			{Path: "status", Value: models.StatusArchived},
+			{Path: "updatedAt", Value: now},
+			{Path: "statusChangedAt", Value: now},
+		}); err != nil {
+			return ids, fmt.Errorf("failed to archive report %s: %w", doc.Ref.ID, err)
+		}
+		ids = append(ids, doc.Ref.ID)
+	}
+	return ids, nil
+}
+
+// ListArchivedReports retrieves archived reports, newest first
+func (f *FirestoreClient) ListArchivedReports(ctx context.Context) ([]models.TrafficReport, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "==", models.StatusArchived).
+		OrderBy("createdAt", firestore.Desc).
+		Documents(ctx)
+
+	var reports []models.TrafficReport
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	reports, err := f.withoutShadowBanned(ctx, reports)
+	if err != nil {
+		return nil, err
+	}
+	return f.withoutDeactivated(ctx, reports)
+}
+
+// EnsureMediaPartitions is a no-op: Firestore stores media inside the report document
+func (f *FirestoreClient) EnsureMediaPartitions(ctx context.Context, through time.Time) ([]string, error) {
+	return nil, nil
+}
+
+// ArchiveMedia is a no-op: Firestore stores media inside the report document
+func (f *FirestoreClient) ArchiveMedia(ctx context.Context, limit int) (int, error) {
+	return 0, nil
+}
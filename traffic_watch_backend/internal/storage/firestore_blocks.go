@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const userBlocksCollection = "userBlocks"
+
+// ============================================================================
+// Blocking Methods (Firestore implementation)
+// ============================================================================
+
+// userBlockDoc returns the document for a block, keyed by both user IDs
+func (f *FirestoreClient) userBlockDoc(blockerID, blockedID string) *firestore.DocumentRef {
+	return f.client.Collection(userBlocksCollection).Doc(blockerID + "_" + blockedID)
+}
+
+// BlockUser records that blockerID has blocked blockedID (idempotent)
+func (f *FirestoreClient) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	_, err := f.userBlockDoc(blockerID, blockedID).Create(ctx, models.UserBlock{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+		CreatedAt: time.Now(),
+	})
+	if err != nil && status.Code(err) != codes.AlreadyExists {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+	return nil
+}
+
+// UnblockUser removes a block
+func (f *FirestoreClient) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	ref := f.userBlockDoc(blockerID, blockedID)
+	if _, err := ref.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return notFound("block")
+		}
+		return fmt.Errorf("failed to get block: %w", err)
+	}
+	if _, err := ref.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	return nil
+}
+
+// ListBlockedUsers retrieves the users blockerID has blocked, newest first
+func (f *FirestoreClient) ListBlockedUsers(ctx context.Context, blockerID string) ([]models.UserBlock, error) {
+	iter := f.client.Collection(userBlocksCollection).
+		Where("blockerId", "==", blockerID).
+		OrderBy("createdAt", firestore.Desc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	blocks := []models.UserBlock{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blocked users: %w", err)
+		}
+		var b models.UserBlock
+		if err := doc.DataTo(&b); err != nil {
+			return nil, fmt.Errorf("failed to decode block: %w", err)
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
+
+// IsUserBlocked reports whether blockerID has blocked blockedID
+func (f *FirestoreClient) IsUserBlocked(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	_, err := f.userBlockDoc(blockerID, blockedID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	return true, nil
+}
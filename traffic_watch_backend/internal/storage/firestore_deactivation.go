@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Account Deactivation Methods (Firestore implementation)
+// ============================================================================
+
+// DeactivateUser marks a user's account deactivated and revokes their session
+func (f *FirestoreClient) DeactivateUser(ctx context.Context, userID string, reason models.DeactivationReason) error {
+	now := time.Now()
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "DeactivatedAt", Value: now},
+		{Path: "DeactivationReason", Value: reason},
+		{Path: "JWTRefreshToken", Value: ""},
+		{Path: "UpdatedAt", Value: now},
+	})
+	if status.Code(err) == codes.NotFound {
+		return notFound("user")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+	return nil
+}
+
+// ReactivateUser clears a user's deactivation
+func (f *FirestoreClient) ReactivateUser(ctx context.Context, userID string) error {
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "DeactivatedAt", Value: nil},
+		{Path: "DeactivationReason", Value: ""},
+		{Path: "UpdatedAt", Value: time.Now()},
+	})
+	if status.Code(err) == codes.NotFound {
+		return notFound("user")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+	return nil
+}
+
+// deactivatedUserIDs returns the set of deactivated user IDs, for filtering query results in memory
+func (f *FirestoreClient) deactivatedUserIDs(ctx context.Context) (map[string]bool, error) {
+	iter := f.client.Collection(usersCollection).
+		Where("DeactivatedAt", "!=", nil).
+		Documents(ctx)
+	defer iter.Stop()
+
+	deactivated := make(map[string]bool)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list deactivated users: %w", err)
+		}
+		deactivated[doc.Ref.ID] = true
+	}
+	return deactivated, nil
+}
+
+// withoutDeactivated drops reports whose author is deactivated
+func (f *FirestoreClient) withoutDeactivated(ctx context.Context, reports []models.TrafficReport) ([]models.TrafficReport, error) {
+	deactivated, err := f.deactivatedUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(deactivated) == 0 {
+		return reports, nil
+	}
+
+	visible := reports[:0]
+	for _, r := range reports {
+		if !deactivated[r.UserID] {
+			visible = append(visible, r)
+		}
+	}
+	return visible, nil
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Dead Letter Methods (Firestore implementation)
+// ============================================================================
+
+const deadLetterCollection = "deadLetter"
+
+// RecordMediaFailure adds a permanently failed media job to the dead-letter queue
+func (f *FirestoreClient) RecordMediaFailure(ctx context.Context, failure *models.MediaFailure) error {
+	stored := *failure
+	stored.Attempts = 1
+	stored.LastAttemptAt = failure.CreatedAt
+	if _, err := f.client.Collection(deadLetterCollection).Doc(failure.ID).Set(ctx, stored); err != nil {
+		return fmt.Errorf("failed to record media failure: %w", err)
+	}
+	return nil
+}
+
+// ListMediaFailures retrieves failed media jobs, newest first. Resolved ones are left out
+// unless includeResolved is set.
+func (f *FirestoreClient) ListMediaFailures(ctx context.Context, includeResolved bool) ([]models.MediaFailure, error) {
+	iter := f.client.Collection(deadLetterCollection).OrderBy("CreatedAt", firestore.Desc).Documents(ctx)
+	defer iter.Stop()
+
+	failures := []models.MediaFailure{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return failures, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list media failures: %w", err)
+		}
+		var failure models.MediaFailure
+		if err := doc.DataTo(&failure); err != nil {
+			continue
+		}
+		if failure.ResolvedAt != nil && !includeResolved {
+			continue
+		}
+		failures = append(failures, failure)
+	}
+}
+
+// GetMediaFailure retrieves one failed media job
+func (f *FirestoreClient) GetMediaFailure(ctx context.Context, id string) (*models.MediaFailure, error) {
+	doc, err := f.client.Collection(deadLetterCollection).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, notFound("media failure")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media failure: %w", err)
+	}
+	var failure models.MediaFailure
+	if err := doc.DataTo(&failure); err != nil {
+		return nil, fmt.Errorf("failed to decode media failure: %w", err)
+	}
+	return &failure, nil
+}
+
+// RecordMediaRetry counts a retry of a failed media job. A nil retryErr marks the job
+// resolved; otherwise its error is kept as the latest one.
+func (f *FirestoreClient) RecordMediaRetry(ctx context.Context, id string, retryErr error) error {
+	failure, err := f.GetMediaFailure(ctx, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	failure.Attempts++
+	failure.LastAttemptAt = now
+	if retryErr == nil {
+		failure.ResolvedAt = &now
+	} else {
+		failure.Error = retryErr.Error()
+	}
+	if _, err := f.client.Collection(deadLetterCollection).Doc(id).Set(ctx, failure); err != nil {
+		return fmt.Errorf("failed to record media retry: %w", err)
+	}
+	return nil
+}
+
+// MoveMediaFile points a report's media file at a new ID and URL, e.g. once a video
+// kept in GCS has been uploaded to YouTube
+func (f *FirestoreClient) MoveMediaFile(ctx context.Context, reportID, mediaID, newID, url string) error {
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+	for i := range report.MediaFiles {
+		mf := &report.MediaFiles[i]
+		if mf.ID != mediaID {
+			continue
+		}
+		mf.ID = newID
+		mf.URL = url
+		err = f.saveReport(ctx, reportID, report)
+		return err
+	}
+	return notFound("media file")
+}
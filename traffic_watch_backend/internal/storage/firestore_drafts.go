@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const reportDraftsCollection = "reportDrafts"
+
+// ============================================================================
+// Report Draft Methods (Firestore implementation)
+// ============================================================================
+
+// CreateReportDraft stores a new report draft
+func (f *FirestoreClient) CreateReportDraft(ctx context.Context, d *models.ReportDraft) error {
+	if d.ID == "" {
+		return errors.New("draft ID is required")
+	}
+	now := time.Now()
+	d.CreatedAt = now
+	d.UpdatedAt = now
+
+	if _, err := f.client.Collection(reportDraftsCollection).Doc(d.ID).Set(ctx, d); err != nil {
+		return fmt.Errorf("failed to create draft: %w", err)
+	}
+	return nil
+}
+
+// listDrafts decodes every draft a query matches
+func listDrafts(iter *firestore.DocumentIterator) ([]models.ReportDraft, error) {
+	defer iter.Stop()
+	drafts := []models.ReportDraft{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list drafts: %w", err)
+		}
+		var d models.ReportDraft
+		if err := doc.DataTo(&d); err != nil {
+			return nil, fmt.Errorf("failed to decode draft: %w", err)
+		}
+		drafts = append(drafts, d)
+	}
+	return drafts, nil
+}
+
+// ListReportDrafts retrieves a user's report drafts, most recently edited first
+func (f *FirestoreClient) ListReportDrafts(ctx context.Context, userID string) ([]models.ReportDraft, error) {
+	drafts, err := listDrafts(f.client.Collection(reportDraftsCollection).
+		Where("userId", "==", userID).
+		Documents(ctx))
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(drafts, func(i, j int) bool {
+		return drafts[i].UpdatedAt.After(drafts[j].UpdatedAt)
+	})
+	return drafts, nil
+}
+
+// GetReportDraft retrieves one of a user's report drafts
+func (f *FirestoreClient) GetReportDraft(ctx context.Context, draftID, userID string) (*models.ReportDraft, error) {
+	doc, err := f.client.Collection(reportDraftsCollection).Doc(draftID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, notFound("draft")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get draft: %w", err)
+	}
+
+	var d models.ReportDraft
+	if err := doc.DataTo(&d); err != nil {
+		return nil, fmt.Errorf("failed to decode draft: %w", err)
+	}
+	if d.UserID != userID {
+		return nil, notFound("draft")
+	}
+	return &d, nil
+}
+
+// UpdateReportDraft replaces one of a user's report drafts
+func (f *FirestoreClient) UpdateReportDraft(ctx context.Context, d *models.ReportDraft) error {
+	existing, err := f.GetReportDraft(ctx, d.ID, d.UserID)
+	if err != nil {
+		return err
+	}
+	d.CreatedAt = existing.CreatedAt
+	d.UpdatedAt = time.Now()
+	d.RemindedAt = existing.RemindedAt
+
+	if _, err := f.client.Collection(reportDraftsCollection).Doc(d.ID).Set(ctx, d); err != nil {
+		return fmt.Errorf("failed to update draft: %w", err)
+	}
+	return nil
+}
+
+// DeleteReportDraft removes one of a user's report drafts
+func (f *FirestoreClient) DeleteReportDraft(ctx context.Context, draftID, userID string) error {
+	if _, err := f.GetReportDraft(ctx, draftID, userID); err != nil {
+		return err
+	}
+	if _, err := f.client.Collection(reportDraftsCollection).Doc(draftID).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+	return nil
+}
+
+// ListDraftsToRemind retrieves drafts last edited before updatedBefore that haven't been reminded about since.
+// Firestore can't compare two fields, so the reminder time is checked in memory.
+func (f *FirestoreClient) ListDraftsToRemind(ctx context.Context, updatedBefore time.Time) ([]models.ReportDraft, error) {
+	drafts, err := listDrafts(f.client.Collection(reportDraftsCollection).
+		Where("updatedAt", "<", updatedBefore).
+		OrderBy("updatedAt", firestore.Asc).
+		Documents(ctx))
+	if err != nil {
+		return nil, err
+	}
+	due := drafts[:0]
+	for _, d := range drafts {
+		if d.RemindedAt == nil || d.RemindedAt.Before(d.UpdatedAt) {
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+// MarkDraftReminded records when the owner was reminded about a draft
+func (f *FirestoreClient) MarkDraftReminded(ctx context.Context, draftID string, at time.Time) error {
+	_, err := f.client.Collection(reportDraftsCollection).Doc(draftID).Update(ctx, []firestore.Update{
+		{Path: "remindedAt", Value: at},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark draft reminded: %w", err)
+	}
+	return nil
+}
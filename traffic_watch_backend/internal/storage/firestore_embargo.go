@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Scheduled Publication Methods (Firestore implementation)
+// ============================================================================
+
+// SetReportPublishAt sets or clears the time an approved report enters the public feed
+func (f *FirestoreClient) SetReportPublishAt(ctx context.Context, reportID string, publishAt *time.Time) error {
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	if report.Status == models.StatusDeleted {
+		return notFound("report")
+	}
+
+	report.PublishAt = publishAt
+	report.UpdatedAt = time.Now()
+
+	err = f.saveReport(ctx, reportID, report)
+	return err
+}
+
+// NextScheduledPublication returns the earliest future publish time of an approved report (nil if none)
+func (f *FirestoreClient) NextScheduledPublication(ctx context.Context) (*time.Time, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "==", models.StatusReviewedPass).
+		Where("publishAt", ">", time.Now()).
+		OrderBy("publishAt", firestore.Asc).
+		Limit(1).
+		Documents(ctx)
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next scheduled publication: %w", err)
+	}
+
+	var report models.TrafficReport
+	if err := doc.DataTo(&report); err != nil {
+		return nil, fmt.Errorf("failed to decode report: %w", err)
+	}
+	return report.PublishAt, nil
+}
+
+// withoutEmbargoed drops reports whose publish time hasn't arrived yet
+func withoutEmbargoed(reports []models.TrafficReport) []models.TrafficReport {
+	now := time.Now()
+	visible := reports[:0]
+	for _, r := range reports {
+		if !r.Embargoed(now) {
+			visible = append(visible, r)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const featureFlagsCollection = "featureFlags"
+
+// ============================================================================
+// Feature Flag Methods (Firestore implementation)
+// ============================================================================
+
+// ListFeatureFlags retrieves every stored feature flag
+func (f *FirestoreClient) ListFeatureFlags(ctx context.Context) ([]models.FeatureFlag, error) {
+	iter := f.client.Collection(featureFlagsCollection).Documents(ctx)
+	defer iter.Stop()
+
+	flags := []models.FeatureFlag{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list feature flags: %w", err)
+		}
+		var flag models.FeatureFlag
+		if err := doc.DataTo(&flag); err != nil {
+			return nil, fmt.Errorf("failed to decode feature flag: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+	return flags, nil
+}
+
+// UpsertFeatureFlag creates or replaces a feature flag, keyed by flag key
+func (f *FirestoreClient) UpsertFeatureFlag(ctx context.Context, flag *models.FeatureFlag) error {
+	if flag.UserIDs == nil {
+		flag.UserIDs = []string{}
+	}
+	flag.UpdatedAt = time.Now()
+	if _, err := f.client.Collection(featureFlagsCollection).Doc(flag.Key).Set(ctx, flag); err != nil {
+		return fmt.Errorf("failed to upsert feature flag: %w", err)
+	}
+	return nil
+}
+
+// DeleteFeatureFlag removes a feature flag
+func (f *FirestoreClient) DeleteFeatureFlag(ctx context.Context, key string) error {
+	ref := f.client.Collection(featureFlagsCollection).Doc(key)
+	if _, err := ref.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return notFound("feature flag")
+		}
+		return fmt.Errorf("failed to get feature flag: %w", err)
+	}
+	if _, err := ref.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/ranking"
+)
+
+// ============================================================================
+// Hot Score Methods (Firestore implementation)
+// Note: reactions and comments are not stored in Firestore, so scores come
+// from priority and age alone.
+// ============================================================================
+
+// RefreshHotScores recomputes the stored hot score of every approved report
+func (f *FirestoreClient) RefreshHotScores(ctx context.Context) error {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "==", models.StatusReviewedPass).
+		Documents(ctx)
+	defer iter.Stop()
+
+	now := time.Now()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list reports to score: %w", err)
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		in := ranking.Inputs{Priority: 100, CreatedAt: report.CreatedAt}
+		if report.Priority != nil {
+			in.Priority = *report.Priority
+		}
+		if _, err := doc.Ref.Update(ctx, []firestore.Update{
+			{Path: "hotScore", Value: ranking.HotScore(in, now)},
+		}); err != nil {
+			return fmt.Errorf("failed to store hot score of report %s: %w", doc.Ref.ID, err)
+		}
+	}
+	return nil
+}
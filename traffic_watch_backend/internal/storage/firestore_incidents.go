@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const incidentsCollection = "incidents"
+
+// ============================================================================
+// Vehicle Matching and Incident Methods (Firestore implementation)
+// ============================================================================
+
+// ListReportsByVehicleHashes retrieves non-deleted reports sharing any of the given vehicle hashes
+func (f *FirestoreClient) ListReportsByVehicleHashes(ctx context.Context, hashes []string, excludeReportID string) ([]models.TrafficReport, error) {
+	reports := []models.TrafficReport{}
+	if len(hashes) == 0 {
+		return reports, nil
+	}
+
+	iter := f.client.Collection(reportsCollection).
+		Where("vehicleHashes", "array-contains-any", hashes).
+		Documents(ctx)
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		if report.ID == excludeReportID || report.Status == models.StatusDeleted {
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, nil
+}
+
+// LinkReportsToIncident groups reports into an incident, merging any incidents they already belong to
+func (f *FirestoreClient) LinkReportsToIncident(ctx context.Context, incidentID string, reportIDs []string, note, linkedBy string) (*models.Incident, error) {
+	var existing []string
+	seen := make(map[string]bool)
+	for _, id := range reportIDs {
+		report, err := f.GetReport(ctx, id)
+		if err != nil || report.Status == models.StatusDeleted {
+			return nil, notFound("report")
+		}
+		if report.IncidentID != "" && !seen[report.IncidentID] {
+			seen[report.IncidentID] = true
+			existing = append(existing, report.IncidentID)
+		}
+	}
+
+	if incidentID == "" {
+		if len(existing) > 0 {
+			incidentID = existing[0]
+		} else {
+			incidentID = uuid.New().String()
+		}
+	}
+
+	now := time.Now()
+	incident := models.Incident{
+		ID:        incidentID,
+		Note:      note,
+		CreatedBy: linkedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if doc, err := f.client.Collection(incidentsCollection).Doc(incidentID).Get(ctx); err == nil {
+		var current models.Incident
+		if err := doc.DataTo(&current); err == nil {
+			incident.CreatedBy = current.CreatedBy
+			incident.CreatedAt = current.CreatedAt
+			if note == "" {
+				incident.Note = current.Note
+			}
+		}
+	}
+
+	batch := f.client.Batch()
+	batch.Set(f.client.Collection(incidentsCollection).Doc(incidentID), incident)
+	for _, id := range reportIDs {
+		batch.Update(f.client.Collection(reportsCollection).Doc(id), []firestore.Update{
+			{Path: "incidentId", Value: incidentID},
+		})
+	}
+
+	// Fold previously separate incidents into the target
+	for _, oldID := range existing {
+		if oldID == incidentID {
+			continue
+		}
+		iter := f.client.Collection(reportsCollection).Where("incidentId", "==", oldID).Documents(ctx)
+		for {
+			doc, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			batch.Update(doc.Ref, []firestore.Update{
+				{Path: "incidentId", Value: incidentID},
+			})
+		}
+		batch.Delete(f.client.Collection(incidentsCollection).Doc(oldID))
+	}
+
+	if _, err := batch.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return f.GetIncident(ctx, incidentID)
+}
+
+// GetIncident retrieves an incident and the IDs of its linked reports
+func (f *FirestoreClient) GetIncident(ctx context.Context, incidentID string) (*models.Incident, error) {
+	doc, err := f.client.Collection(incidentsCollection).Doc(incidentID).Get(ctx)
+	if err != nil {
+		return nil, notFound("incident")
+	}
+
+	var incident models.Incident
+	if err := doc.DataTo(&incident); err != nil {
+		return nil, err
+	}
+
+	incident.ReportIDs = []string{}
+	iter := f.client.Collection(reportsCollection).Where("incidentId", "==", incidentID).Documents(ctx)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		if report.Status == models.StatusDeleted {
+			continue
+		}
+		incident.ReportIDs = append(incident.ReportIDs, report.ID)
+	}
+
+	return &incident, nil
+}
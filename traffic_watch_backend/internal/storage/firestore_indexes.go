@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	admin "cloud.google.com/go/firestore/apiv1/admin"
+	"cloud.google.com/go/firestore/apiv1/admin/adminpb"
+	"google.golang.org/api/iterator"
+)
+
+// indexField is one field of a composite index; Order is "ASCENDING" or "DESCENDING"
+type indexField struct {
+	Path  string
+	Order string
+}
+
+// firestoreIndex is a composite index on a collection
+type firestoreIndex struct {
+	Collection string
+	Fields     []indexField
+	Building   bool // Present but still being built; only set on indexes read from Firestore
+}
+
+func asc(path string) indexField  { return indexField{path, "ASCENDING"} }
+func desc(path string) indexField { return indexField{path, "DESCENDING"} }
+
+// requiredIndexes lists the composite indexes the Firestore backend's queries need.
+// Firestore rejects a query at runtime when its index is missing, so add entries
+// here alongside each new query that filters and orders on different fields.
+var requiredIndexes = []firestoreIndex{
+	{Collection: reportsCollection, Fields: []indexField{asc("userId"), desc("createdAt")}},                      // ListReportsByUser
+	{Collection: reportsCollection, Fields: []indexField{asc("status"), asc("createdAt")}},                       // ListReportsAwaitingReview, ArchiveReportsCreatedBefore
+	{Collection: reportsCollection, Fields: []indexField{asc("status"), desc("createdAt")}},                      // ListApprovedReports, ListArchivedReports
+	{Collection: reportsCollection, Fields: []indexField{asc("status"), asc("publishAt")}},                       // NextScheduledPublication
+	{Collection: reportsCollection, Fields: []indexField{asc("status"), asc("priority")}},                        // DecayReportPriorities
+	{Collection: userBlocksCollection, Fields: []indexField{asc("blockerId"), desc("createdAt")}},                // ListBlockedUsers
+	{Collection: roleInvitationsCollection, Fields: []indexField{asc("acceptedAt"), desc("createdAt")}},          // ListPendingRoleInvitations
+	{Collection: notificationsCollection, Fields: []indexField{asc("userId"), desc("createdAt")}},                // ListNotifications
+	{Collection: notificationsCollection, Fields: []indexField{asc("userId"), asc("readAt"), desc("createdAt")}}, // ListNotifications (unread only)
+	{Collection: researchTokenUsageCollection, Fields: []indexField{asc("tokenId"), desc("day")}},                // ListResearchTokenUsage
+	{Collection: reportReviewsCollection, Fields: []indexField{asc("reportId"), asc("createdAt")}},               // ListReportReviews
+}
+
+// ValidateIndexes checks the database has every index in requiredIndexes, naming
+// the gcloud commands that create any that are missing. Listing indexes needs the
+// datastore.indexes.list permission.
+func (f *FirestoreClient) ValidateIndexes(ctx context.Context) error {
+	adminClient, err := admin.NewFirestoreAdminClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create Firestore admin client: %w", err)
+	}
+	defer adminClient.Close()
+
+	it := adminClient.ListIndexes(ctx, &adminpb.ListIndexesRequest{
+		Parent: fmt.Sprintf("projects/%s/databases/(default)/collectionGroups/-", f.projectID),
+	})
+	var present []firestoreIndex
+	for {
+		index, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list Firestore indexes: %w", err)
+		}
+		if index.QueryScope != adminpb.Index_COLLECTION {
+			continue
+		}
+		present = append(present, fromAdminIndex(index))
+	}
+
+	return checkIndexes(f.projectID, requiredIndexes, present)
+}
+
+// fromAdminIndex converts a listed index, dropping the __name__ field Firestore appends
+func fromAdminIndex(index *adminpb.Index) firestoreIndex {
+	// Names look like projects/p/databases/d/collectionGroups/<collection>/indexes/<id>
+	parts := strings.Split(index.Name, "/")
+	collection := ""
+	if len(parts) >= 3 {
+		collection = parts[len(parts)-3]
+	}
+
+	fi := firestoreIndex{Collection: collection, Building: index.State == adminpb.Index_CREATING}
+	for _, field := range index.Fields {
+		if field.FieldPath == "__name__" {
+			continue
+		}
+		fi.Fields = append(fi.Fields, indexField{Path: field.FieldPath, Order: field.GetOrder().String()})
+	}
+	return fi
+}
+
+// checkIndexes compares required indexes with those present
+func checkIndexes(projectID string, required, present []firestoreIndex) error {
+	var missing, building []string
+	for _, want := range required {
+		found := false
+		for _, have := range present {
+			if have.matches(want) {
+				found = true
+				if have.Building {
+					building = append(building, want.String())
+				}
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, want.createCommand(projectID))
+		}
+	}
+
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing composite indexes; create them with: "+strings.Join(missing, "; "))
+	}
+	if len(building) > 0 {
+		problems = append(problems, "indexes still building: "+strings.Join(building, ", "))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("firestore indexes are incomplete (%s)", strings.Join(problems, "; "))
+}
+
+func (i firestoreIndex) matches(other firestoreIndex) bool {
+	if i.Collection != other.Collection || len(i.Fields) != len(other.Fields) {
+		return false
+	}
+	for n := range i.Fields {
+		if i.Fields[n] != other.Fields[n] {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the index as collection(field order, ...)
+func (i firestoreIndex) String() string {
+	fields := make([]string, len(i.Fields))
+	for n, field := range i.Fields {
+		fields[n] = field.Path + " " + strings.ToLower(field.Order)
+	}
+	return i.Collection + "(" + strings.Join(fields, ", ") + ")"
+}
+
+// createCommand returns the gcloud command that creates the index
+func (i firestoreIndex) createCommand(projectID string) string {
+	cmd := fmt.Sprintf("gcloud firestore indexes composite create --project=%s --collection-group=%s --query-scope=COLLECTION", projectID, i.Collection)
+	for _, field := range i.Fields {
+		cmd += fmt.Sprintf(" --field-config=field-path=%s,order=%s", field.Path, strings.ToLower(field.Order))
+	}
+	return cmd
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckIndexes(t *testing.T) {
+	required := []firestoreIndex{
+		{Collection: "reports", Fields: []indexField{asc("userId"), desc("createdAt")}},
+		{Collection: "reports", Fields: []indexField{asc("status"), asc("createdAt")}},
+		{Collection: "notifications", Fields: []indexField{asc("userId"), desc("createdAt")}},
+	}
+
+	t.Run("all present passes", func(t *testing.T) {
+		if err := checkIndexes("proj", required, required); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("missing and building indexes are named", func(t *testing.T) {
+		present := []firestoreIndex{
+			// Same fields, wrong order, so it doesn't count
+			{Collection: "reports", Fields: []indexField{asc("userId"), asc("createdAt")}},
+			{Collection: "reports", Fields: []indexField{asc("status"), asc("createdAt")}, Building: true},
+			// Right fields on another collection
+			{Collection: "users", Fields: []indexField{asc("userId"), desc("createdAt")}},
+		}
+		err := checkIndexes("proj", required, present)
+		if err == nil {
+			t.Fatal("expected error for missing indexes")
+		}
+		msg := err.Error()
+		for _, want := range []string{
+			"gcloud firestore indexes composite create --project=proj --collection-group=reports --query-scope=COLLECTION --field-config=field-path=userId,order=ascending --field-config=field-path=createdAt,order=descending",
+			"--collection-group=notifications",
+			"still building: reports(status ascending, createdAt ascending)",
+		} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("error %q does not mention %q", msg, want)
+			}
+		}
+	})
+}
+
+func TestRequiredIndexesAreComposite(t *testing.T) {
+	seen := map[string]bool{}
+	for _, index := range requiredIndexes {
+		if len(index.Fields) < 2 {
+			t.Errorf("%s has a single field; Firestore creates those automatically", index)
+		}
+		if seen[index.String()] {
+			t.Errorf("%s is listed twice", index)
+		}
+		seen[index.String()] = true
+	}
+}
@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const (
+	roleInvitationsCollection = "roleInvitations"
+	auditLogCollection        = "auditLog"
+)
+
+// ============================================================================
+// Role Invitation and Audit Log Methods (Firestore implementation)
+// ============================================================================
+
+// CreateRoleInvitation stores a new role invitation
+func (f *FirestoreClient) CreateRoleInvitation(ctx context.Context, inv *models.RoleInvitation) error {
+	if inv.ID == "" {
+		return errors.New("invitation ID is required")
+	}
+	inv.CreatedAt = time.Now()
+
+	if _, err := f.client.Collection(roleInvitationsCollection).Doc(inv.ID).Set(ctx, inv); err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	return nil
+}
+
+// GetRoleInvitation retrieves a role invitation by ID
+func (f *FirestoreClient) GetRoleInvitation(ctx context.Context, id string) (*models.RoleInvitation, error) {
+	doc, err := f.client.Collection(roleInvitationsCollection).Doc(id).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, notFound("invitation")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	var inv models.RoleInvitation
+	if err := doc.DataTo(&inv); err != nil {
+		return nil, fmt.Errorf("failed to decode invitation: %w", err)
+	}
+	return &inv, nil
+}
+
+// ListPendingRoleInvitations retrieves unaccepted, unexpired invitations, newest first
+func (f *FirestoreClient) ListPendingRoleInvitations(ctx context.Context) ([]models.RoleInvitation, error) {
+	iter := f.client.Collection(roleInvitationsCollection).
+		Where("acceptedAt", "==", nil).
+		OrderBy("createdAt", firestore.Desc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	now := time.Now()
+	invitations := []models.RoleInvitation{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list invitations: %w", err)
+		}
+		var inv models.RoleInvitation
+		if err := doc.DataTo(&inv); err != nil {
+			return nil, fmt.Errorf("failed to decode invitation: %w", err)
+		}
+		if inv.ExpiresAt.After(now) {
+			invitations = append(invitations, inv)
+		}
+	}
+	return invitations, nil
+}
+
+// AcceptRoleInvitation grants the invitation's role to userID, marks it
+// accepted and records it in the audit log, all in one transaction
+func (f *FirestoreClient) AcceptRoleInvitation(ctx context.Context, id, userID string) error {
+	invRef := f.client.Collection(roleInvitationsCollection).Doc(id)
+	userRef := f.client.Collection(usersCollection).Doc(userID)
+
+	return f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(invRef)
+		if status.Code(err) == codes.NotFound {
+			return notFound("invitation")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get invitation: %w", err)
+		}
+		var inv models.RoleInvitation
+		if err := doc.DataTo(&inv); err != nil {
+			return fmt.Errorf("failed to decode invitation: %w", err)
+		}
+		if inv.AcceptedAt != nil {
+			return conflict("invitation already accepted")
+		}
+		now := time.Now()
+		if !now.Before(inv.ExpiresAt) {
+			return ErrInvitationExpired
+		}
+
+		userDoc, err := tx.Get(userRef)
+		if status.Code(err) == codes.NotFound {
+			return notFound("user")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		// Invitations only ever raise a role; lowering one is an explicit role change
+		var current models.User
+		if err := userDoc.DataTo(&current); err != nil {
+			return fmt.Errorf("failed to decode user: %w", err)
+		}
+		if inv.Role.Rank() < current.Role.Rank() {
+			return ErrRoleDowngrade
+		}
+
+		if err := tx.Update(userRef, []firestore.Update{
+			{Path: "Role", Value: inv.Role},
+			{Path: "UpdatedAt", Value: now},
+		}); err != nil {
+			return fmt.Errorf("failed to update role: %w", err)
+		}
+		if err := tx.Update(invRef, []firestore.Update{
+			{Path: "acceptedAt", Value: now},
+			{Path: "acceptedBy", Value: userID},
+		}); err != nil {
+			return fmt.Errorf("failed to mark invitation accepted: %w", err)
+		}
+
+		entry := models.AuditEntry{
+			ID:        uuid.New().String(),
+			ActorID:   userID,
+			Action:    models.AuditInvitationAccepted,
+			TargetID:  id,
+			Details:   "role=" + string(inv.Role),
+			CreatedAt: now,
+		}
+		if err := tx.Create(f.client.Collection(auditLogCollection).Doc(entry.ID), entry); err != nil {
+			return fmt.Errorf("failed to write audit entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateAuditEntry appends an entry to the audit log
+func (f *FirestoreClient) CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	if entry.ID == "" {
+		return errors.New("audit entry ID is required")
+	}
+	entry.CreatedAt = time.Now()
+
+	if _, err := f.client.Collection(auditLogCollection).Doc(entry.ID).Set(ctx, entry); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries retrieves up to limit audit log entries, newest first
+func (f *FirestoreClient) ListAuditEntries(ctx context.Context, limit int) ([]models.AuditEntry, error) {
+	iter := f.client.Collection(auditLogCollection).
+		OrderBy("createdAt", firestore.Desc).
+		Limit(limit).
+		Documents(ctx)
+	defer iter.Stop()
+
+	entries := []models.AuditEntry{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list audit log: %w", err)
+		}
+		var e models.AuditEntry
+		if err := doc.DataTo(&e); err != nil {
+			return nil, fmt.Errorf("failed to decode audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/geo"
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Map Methods (Firestore implementation)
+// ============================================================================
+
+// ListApprovedReportLocations retrieves coordinates of approved reports inside the bounding box.
+// Firestore cannot range-filter on two fields, so the box is applied in memory.
+func (f *FirestoreClient) ListApprovedReportLocations(ctx context.Context, box models.BoundingBox) ([]models.ReportLocation, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "==", models.StatusReviewedPass).
+		Select("id", "userId", "latitude", "longitude", "publishAt").
+		Documents(ctx)
+
+	banned, err := f.shadowBannedUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deactivated, err := f.deactivatedUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	locations := []models.ReportLocation{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		if report.Latitude == nil || report.Longitude == nil || banned[report.UserID] || deactivated[report.UserID] || report.Embargoed(now) || report.MediaPending() {
+			continue
+		}
+		if !geo.Contains(box, *report.Latitude, *report.Longitude) {
+			continue
+		}
+		locations = append(locations, models.ReportLocation{
+			ID:        doc.Ref.ID,
+			Latitude:  *report.Latitude,
+			Longitude: *report.Longitude,
+		})
+	}
+
+	return locations, nil
+}
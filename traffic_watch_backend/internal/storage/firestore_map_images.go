@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Static Map Methods (Firestore implementation)
+// ============================================================================
+
+// ListReportsForMapImage retrieves non-deleted reports with coordinates that have
+// no static map image, newest first. Reports whose last attempt failed are left
+// out until retryBefore has passed it.
+func (f *FirestoreClient) ListReportsForMapImage(ctx context.Context, retryBefore time.Time, limit int) ([]models.TrafficReport, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "!=", models.StatusDeleted).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var reports []models.TrafficReport
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var r models.TrafficReport
+		if err := doc.DataTo(&r); err != nil {
+			continue
+		}
+		if r.Latitude == nil || r.Longitude == nil || r.MapImagePath != "" ||
+			(r.MapImageAttemptedAt != nil && !r.MapImageAttemptedAt.Before(retryBefore)) {
+			continue
+		}
+		reports = append(reports, r)
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].CreatedAt.After(reports[j].CreatedAt) })
+	if len(reports) > limit {
+		reports = reports[:limit]
+	}
+	return reports, nil
+}
+
+// SetReportMapImage records an attempt to render a report's static map; an empty
+// objectPath records a failed attempt
+func (f *FirestoreClient) SetReportMapImage(ctx context.Context, reportID, objectPath string) error {
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	report.MapImagePath = objectPath
+	report.MapImageAttemptedAt = &now
+
+	err = f.saveReport(ctx, reportID, report)
+	return err
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Media State Methods (Firestore implementation)
+// ============================================================================
+
+// ListPendingMedia retrieves processing and quarantined media on non-deleted reports
+func (f *FirestoreClient) ListPendingMedia(ctx context.Context, limit int) ([]models.MediaStateJob, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "!=", models.StatusDeleted).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var jobs []models.MediaStateJob
+	for len(jobs) < limit {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		for _, mf := range report.MediaFiles {
+			if mf.Pending() && len(jobs) < limit {
+				jobs = append(jobs, models.MediaStateJob{ReportID: report.ID, UserID: report.UserID, Media: mf})
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// SetMediaState moves a media file from state from to state to. It fails if the
+// transition isn't allowed or the media file is no longer in state from.
+func (f *FirestoreClient) SetMediaState(ctx context.Context, reportID, mediaID, from, to string) error {
+	if !models.ValidMediaTransition(from, to) {
+		return fmt.Errorf("invalid media state transition from %q to %q", from, to)
+	}
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	for i := range report.MediaFiles {
+		if report.MediaFiles[i].ID != mediaID || report.MediaFiles[i].EffectiveState() != from {
+			continue
+		}
+		report.MediaFiles[i].State = to
+
+		err = f.saveReport(ctx, reportID, report)
+		return err
+	}
+	return notFound("media file")
+}
+
+// withoutPendingMedia drops reports with media that is still processing or quarantined
+func withoutPendingMedia(reports []models.TrafficReport) []models.TrafficReport {
+	settled := reports[:0]
+	for _, r := range reports {
+		if !r.MediaPending() {
+			settled = append(settled, r)
+		}
+	}
+	return settled
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const reportRedirectsCollection = "reportRedirects"
+
+// reportRedirect is the Firestore document recording a merged duplicate
+type reportRedirect struct {
+	FromReportID string    `firestore:"fromReportId"`
+	ToReportID   string    `firestore:"toReportId"`
+	MergedBy     string    `firestore:"mergedBy"`
+	MergedAt     time.Time `firestore:"mergedAt"`
+}
+
+// ============================================================================
+// Duplicate Merge Methods (Firestore implementation)
+// Note: reactions and comments are not stored in Firestore, so only media,
+// priority, and vehicle data are combined.
+// ============================================================================
+
+// MergeReports merges duplicateID into canonicalID
+func (f *FirestoreClient) MergeReports(ctx context.Context, canonicalID, duplicateID, mergedBy string) error {
+	if canonicalID == duplicateID {
+		return errors.New("cannot merge a report into itself")
+	}
+
+	// Both reports are read in the transaction, so a concurrent review or merge
+	// of either one makes Firestore retry instead of overwriting it
+	return f.runTransaction(ctx, func(t *FirestoreClient) error {
+		canonical, err := t.GetReport(ctx, canonicalID)
+		if err != nil {
+			return err
+		}
+		duplicate, err := t.GetReport(ctx, duplicateID)
+		if err != nil {
+			return err
+		}
+		if canonical.Status == models.StatusDeleted || duplicate.Status == models.StatusDeleted {
+			return notFound("report")
+		}
+		if canonical.Status == models.StatusMerged || !models.CanTransitionStatus(duplicate.Status, models.StatusMerged) {
+			return conflict("report already merged")
+		}
+
+		for _, mf := range duplicate.MediaFiles {
+			if mf.StoragePath == "" && !isYouTubeMediaURL(mf.URL) {
+				mf.StoragePath = fmt.Sprintf("users/%s/reports/%s/%s", duplicate.UserID, duplicate.ID, mf.ID)
+			}
+			canonical.MediaFiles = append(canonical.MediaFiles, mf)
+		}
+
+		if canonical.Priority != nil || duplicate.Priority != nil {
+			merged := 100
+			if canonical.Priority != nil {
+				merged = *canonical.Priority
+			}
+			if duplicate.Priority != nil {
+				merged += *duplicate.Priority - 100
+			}
+			canonical.Priority = &merged
+		}
+
+		seen := make(map[string]bool)
+		for _, hash := range canonical.VehicleHashes {
+			seen[hash] = true
+		}
+		for _, hash := range duplicate.VehicleHashes {
+			if !seen[hash] {
+				canonical.VehicleHashes = append(canonical.VehicleHashes, hash)
+			}
+		}
+		if canonical.IncidentID == "" {
+			canonical.IncidentID = duplicate.IncidentID
+		}
+
+		now := time.Now()
+		canonical.UpdatedAt = now
+		duplicate.Status = models.StatusMerged
+		duplicate.MergedInto = canonicalID
+		duplicate.MediaFiles = []models.MediaFile{}
+		duplicate.UpdatedAt = now
+		duplicate.StatusChangedAt = &now
+
+		if err := t.saveReport(ctx, canonicalID, canonical); err != nil {
+			return err
+		}
+		if err := t.saveReport(ctx, duplicateID, duplicate); err != nil {
+			return err
+		}
+		return t.tx.Set(f.client.Collection(reportRedirectsCollection).Doc(duplicateID), reportRedirect{
+			FromReportID: duplicateID,
+			ToReportID:   canonicalID,
+			MergedBy:     mergedBy,
+			MergedAt:     now,
+		})
+	})
+}
+
+// GetReportRedirect returns the canonical report ID a merged report redirects to ("" if none)
+func (f *FirestoreClient) GetReportRedirect(ctx context.Context, reportID string) (string, error) {
+	target := ""
+	// Follow chains left by merging into a report that was later merged itself
+	for i := 0; i < 10; i++ {
+		doc, err := f.client.Collection(reportRedirectsCollection).Doc(reportID).Get(ctx)
+		if err != nil {
+			break
+		}
+		var redirect reportRedirect
+		if err := doc.DataTo(&redirect); err != nil {
+			return "", err
+		}
+		target = redirect.ToReportID
+		reportID = redirect.ToReportID
+	}
+	return target, nil
+}
+
+// isYouTubeMediaURL checks if a media URL points at YouTube
+func isYouTubeMediaURL(url string) bool {
+	return strings.Contains(url, "youtube.com") || strings.Contains(url, "youtu.be")
+}
@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const (
+	blockedWordsCollection = "blockedWords"
+	settingsCollection     = "settings"
+	commentPolicyDoc       = "commentPolicy"
+)
+
+// ============================================================================
+// Moderation Methods (Firestore implementation)
+// ============================================================================
+
+// ListBlockedWords retrieves every entry in the word filter
+func (f *FirestoreClient) ListBlockedWords(ctx context.Context) ([]models.BlockedWord, error) {
+	iter := f.client.Collection(blockedWordsCollection).OrderBy("word", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	words := []models.BlockedWord{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list blocked words: %w", err)
+		}
+		var w models.BlockedWord
+		if err := doc.DataTo(&w); err != nil {
+			return nil, fmt.Errorf("failed to decode blocked word: %w", err)
+		}
+		words = append(words, w)
+	}
+	return words, nil
+}
+
+// UpsertBlockedWord adds a blocked word or changes its action, keyed by the lowercased word
+func (f *FirestoreClient) UpsertBlockedWord(ctx context.Context, word *models.BlockedWord) error {
+	word.Word = strings.ToLower(strings.TrimSpace(word.Word))
+	ref := f.client.Collection(blockedWordsCollection).Doc(word.Word)
+
+	doc, err := ref.Get(ctx)
+	switch {
+	case err == nil:
+		var existing models.BlockedWord
+		if err := doc.DataTo(&existing); err == nil {
+			word.CreatedBy = existing.CreatedBy
+			word.CreatedAt = existing.CreatedAt
+		}
+	case status.Code(err) == codes.NotFound:
+		word.CreatedAt = time.Now()
+	default:
+		return fmt.Errorf("failed to get blocked word: %w", err)
+	}
+
+	if _, err := ref.Set(ctx, word); err != nil {
+		return fmt.Errorf("failed to upsert blocked word: %w", err)
+	}
+	return nil
+}
+
+// DeleteBlockedWord removes a word from the filter
+func (f *FirestoreClient) DeleteBlockedWord(ctx context.Context, word string) error {
+	ref := f.client.Collection(blockedWordsCollection).Doc(strings.ToLower(strings.TrimSpace(word)))
+	if _, err := ref.Get(ctx); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return notFound("blocked word")
+		}
+		return fmt.Errorf("failed to get blocked word: %w", err)
+	}
+	if _, err := ref.Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete blocked word: %w", err)
+	}
+	return nil
+}
+
+// ListFlaggedComments retrieves comments held for review (stub - comments are not stored in Firestore)
+func (f *FirestoreClient) ListFlaggedComments(ctx context.Context, includeShadowBanned bool) ([]models.Comment, error) {
+	return []models.Comment{}, nil
+}
+
+// ResolveFlaggedComment resolves a flagged comment (stub - not implemented for Firestore)
+func (f *FirestoreClient) ResolveFlaggedComment(ctx context.Context, commentID string, approve bool) error {
+	return errors.New("comments not implemented for Firestore backend")
+}
+
+// CountIdenticalComments counts identical comments (stub - comments are not stored in Firestore)
+func (f *FirestoreClient) CountIdenticalComments(ctx context.Context, userID, content string, since time.Time) (int, error) {
+	return 0, nil
+}
+
+// GetCommentPolicy retrieves the comment spam policy
+func (f *FirestoreClient) GetCommentPolicy(ctx context.Context) (*models.CommentPolicy, error) {
+	doc, err := f.client.Collection(settingsCollection).Doc(commentPolicyDoc).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, notFound("comment policy")
+		}
+		return nil, fmt.Errorf("failed to get comment policy: %w", err)
+	}
+	var policy models.CommentPolicy
+	if err := doc.DataTo(&policy); err != nil {
+		return nil, fmt.Errorf("failed to decode comment policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// SaveCommentPolicy replaces the comment spam policy
+func (f *FirestoreClient) SaveCommentPolicy(ctx context.Context, policy *models.CommentPolicy) error {
+	now := time.Now()
+	policy.UpdatedAt = &now
+	if _, err := f.client.Collection(settingsCollection).Doc(commentPolicyDoc).Set(ctx, policy); err != nil {
+		return fmt.Errorf("failed to save comment policy: %w", err)
+	}
+	return nil
+}
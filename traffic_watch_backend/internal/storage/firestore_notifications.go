@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const notificationsCollection = "notifications"
+
+// ============================================================================
+// Notification Methods (Firestore implementation)
+// ============================================================================
+
+// UpdateNotificationPreferences replaces a user's notification preferences
+func (f *FirestoreClient) UpdateNotificationPreferences(ctx context.Context, userID string, prefs models.NotificationPreferences) error {
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "Notifications", Value: prefs},
+		{Path: "UpdatedAt", Value: time.Now()},
+	})
+	if status.Code(err) == codes.NotFound {
+		return notFound("user")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+	return nil
+}
+
+// CreateNotification adds an entry to a user's inbox
+func (f *FirestoreClient) CreateNotification(ctx context.Context, n *models.Notification) error {
+	if n.ID == "" {
+		return errors.New("notification ID is required")
+	}
+	n.CreatedAt = time.Now()
+
+	if _, err := f.client.Collection(notificationsCollection).Doc(n.ID).Set(ctx, n); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications retrieves up to limit of a user's inbox entries, newest first
+func (f *FirestoreClient) ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int) ([]models.Notification, error) {
+	query := f.client.Collection(notificationsCollection).Where("userId", "==", userID)
+	if unreadOnly {
+		query = query.Where("readAt", "==", nil)
+	}
+	iter := query.OrderBy("createdAt", firestore.Desc).Limit(limit).Documents(ctx)
+	defer iter.Stop()
+
+	notifications := []models.Notification{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list notifications: %w", err)
+		}
+		var n models.Notification
+		if err := doc.DataTo(&n); err != nil {
+			return nil, fmt.Errorf("failed to decode notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
+
+// unreadNotificationRefs returns references to a user's unread inbox entries
+func (f *FirestoreClient) unreadNotificationRefs(ctx context.Context, userID string) ([]*firestore.DocumentRef, error) {
+	iter := f.client.Collection(notificationsCollection).
+		Where("userId", "==", userID).
+		Where("readAt", "==", nil).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var refs []*firestore.DocumentRef
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list unread notifications: %w", err)
+		}
+		refs = append(refs, doc.Ref)
+	}
+	return refs, nil
+}
+
+// CountUnreadNotifications counts a user's unread inbox entries
+func (f *FirestoreClient) CountUnreadNotifications(ctx context.Context, userID string) (int, error) {
+	refs, err := f.unreadNotificationRefs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return len(refs), nil
+}
+
+// MarkNotificationRead marks one of a user's inbox entries as read (idempotent)
+func (f *FirestoreClient) MarkNotificationRead(ctx context.Context, userID, notificationID string) error {
+	ref := f.client.Collection(notificationsCollection).Doc(notificationID)
+	doc, err := ref.Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return notFound("notification")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	var n models.Notification
+	if err := doc.DataTo(&n); err != nil {
+		return fmt.Errorf("failed to decode notification: %w", err)
+	}
+	if n.UserID != userID {
+		return notFound("notification")
+	}
+	if n.ReadAt != nil {
+		return nil
+	}
+
+	if _, err := ref.Update(ctx, []firestore.Update{{Path: "readAt", Value: time.Now()}}); err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks every unread inbox entry of a user as read and returns how many changed
+func (f *FirestoreClient) MarkAllNotificationsRead(ctx context.Context, userID string) (int, error) {
+	refs, err := f.unreadNotificationRefs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, ref := range refs {
+		if _, err := ref.Update(ctx, []firestore.Update{{Path: "readAt", Value: now}}); err != nil {
+			return 0, fmt.Errorf("failed to mark notification read: %w", err)
+		}
+	}
+	return len(refs), nil
+}
+
+// ListDigestRecipients retrieves weekly digest subscribers whose last digest was sent before sentBefore (or never).
+// Firestore can't express "missing or earlier", so the send time is checked in memory.
+func (f *FirestoreClient) ListDigestRecipients(ctx context.Context, sentBefore time.Time) ([]models.User, error) {
+	iter := f.client.Collection(usersCollection).
+		Where("Notifications.WeeklyDigest", "==", true).
+		Documents(ctx)
+	defer iter.Stop()
+
+	users := []models.User{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list digest recipients: %w", err)
+		}
+		var u models.User
+		if err := doc.DataTo(&u); err != nil {
+			return nil, fmt.Errorf("failed to decode user: %w", err)
+		}
+		if u.ShadowBanned || u.IsDeactivated() || (u.LastDigestAt != nil && !u.LastDigestAt.Before(sentBefore)) {
+			continue
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// MarkDigestSent records when a user's weekly digest was sent
+func (f *FirestoreClient) MarkDigestSent(ctx context.Context, userID string, at time.Time) error {
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "LastDigestAt", Value: at},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// OCR Methods (Firestore implementation)
+// ============================================================================
+
+// ListMediaForOCR retrieves GCS-hosted images on non-deleted reports that have not
+// been read by OCR yet
+func (f *FirestoreClient) ListMediaForOCR(ctx context.Context, limit int) ([]models.OCRJob, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "!=", models.StatusDeleted).
+		Documents(ctx)
+
+	var jobs []models.OCRJob
+	for len(jobs) < limit {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		for _, mf := range report.MediaFiles {
+			if strings.HasPrefix(mf.ContentType, "image/") && mf.OCR == nil && mf.EffectiveState() == models.MediaReady && len(jobs) < limit {
+				jobs = append(jobs, models.OCRJob{ReportID: report.ID, UserID: report.UserID, Media: mf})
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// SetMediaOCR stores (or replaces) the OCR result of a media file
+func (f *FirestoreClient) SetMediaOCR(ctx context.Context, reportID, mediaID string, ocr *models.MediaOCR) error {
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	for i := range report.MediaFiles {
+		if report.MediaFiles[i].ID != mediaID {
+			continue
+		}
+		report.MediaFiles[i].OCR = ocr
+
+		err = f.saveReport(ctx, reportID, report)
+		return err
+	}
+	return notFound("media file")
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const eventOutboxCollection = "eventOutbox"
+
+// ============================================================================
+// Event Outbox Methods (Firestore implementation)
+// ============================================================================
+
+// EnqueueOutboxEvent adds an event to the outbox, due for delivery now. In a
+// transaction the write commits with it.
+func (f *FirestoreClient) EnqueueOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	stored := *event
+	stored.NextAttemptAt = &stored.OccurredAt
+	ref := f.client.Collection(eventOutboxCollection).Doc(event.ID)
+
+	var err error
+	if f.tx != nil {
+		err = f.tx.Create(ref, stored)
+	} else {
+		_, err = ref.Create(ctx, stored)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to enqueue outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimOutboxEvents retrieves up to limit due events and moves their next attempt
+// lease into the future in one transaction, so concurrent relays don't both claim them.
+// Events come in due order, which is oldest first for events not yet retried.
+func (f *FirestoreClient) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	var claimed []models.OutboxEvent
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		claimed = []models.OutboxEvent{}
+		now := time.Now()
+		query := f.client.Collection(eventOutboxCollection).
+			Where("nextAttemptAt", "<=", now).
+			OrderBy("nextAttemptAt", firestore.Asc).
+			Limit(limit)
+		docs, err := tx.Documents(query).GetAll()
+		if err != nil {
+			return err
+		}
+
+		leaseUntil := now.Add(lease)
+		for _, doc := range docs {
+			var e models.OutboxEvent
+			if err := doc.DataTo(&e); err != nil {
+				return err
+			}
+			e.NextAttemptAt = &leaseUntil
+			claimed = append(claimed, e)
+		}
+		for _, doc := range docs {
+			if err := tx.Update(doc.Ref, []firestore.Update{{Path: "nextAttemptAt", Value: leaseUntil}}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	return claimed, nil
+}
+
+// MarkOutboxEventDelivered records that an event reached every sink
+func (f *FirestoreClient) MarkOutboxEventDelivered(ctx context.Context, id string) error {
+	return f.updateOutboxEvent(ctx, id, []firestore.Update{
+		{Path: "attempts", Value: firestore.Increment(1)},
+		{Path: "deliveredAt", Value: time.Now()},
+		{Path: "nextAttemptAt", Value: nil},
+		{Path: "lastError", Value: ""},
+	})
+}
+
+// MarkOutboxEventFailed counts a failed delivery attempt and schedules the next one, if any
+func (f *FirestoreClient) MarkOutboxEventFailed(ctx context.Context, id, lastError string, retryAt *time.Time) error {
+	var next interface{}
+	if retryAt != nil {
+		next = *retryAt
+	}
+	return f.updateOutboxEvent(ctx, id, []firestore.Update{
+		{Path: "attempts", Value: firestore.Increment(1)},
+		{Path: "lastError", Value: lastError},
+		{Path: "nextAttemptAt", Value: next},
+	})
+}
+
+// updateOutboxEvent applies updates to one outbox event
+func (f *FirestoreClient) updateOutboxEvent(ctx context.Context, id string, updates []firestore.Update) error {
+	_, err := f.client.Collection(eventOutboxCollection).Doc(id).Update(ctx, updates)
+	if status.Code(err) == codes.NotFound {
+		return notFound("outbox event")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update outbox event: %w", err)
+	}
+	return nil
+}
+
+// PurgeDeliveredOutboxEvents deletes events delivered before before
+func (f *FirestoreClient) PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int, error) {
+	iter := f.client.Collection(eventOutboxCollection).Where("deliveredAt", "<", before).Documents(ctx)
+	defer iter.Stop()
+
+	deleted := 0
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return deleted, nil
+		}
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list delivered outbox events: %w", err)
+		}
+		if _, err := doc.Ref.Delete(ctx); err != nil {
+			return deleted, fmt.Errorf("failed to purge outbox event: %w", err)
+		}
+		deleted++
+	}
+}
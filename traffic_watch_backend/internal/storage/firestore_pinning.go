@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Pinning Methods (Firestore implementation)
+// ============================================================================
+
+// SetReportPinned pins a report to the top of the public feed or unpins it.
+// Pinning an already pinned report keeps its original pin time.
+func (f *FirestoreClient) SetReportPinned(ctx context.Context, reportID string, pinned bool) error {
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	if report.Status == models.StatusDeleted {
+		return notFound("report")
+	}
+
+	now := time.Now()
+	switch {
+	case !pinned:
+		report.PinnedAt = nil
+	case report.PinnedAt == nil:
+		report.PinnedAt = &now
+	}
+	report.Pinned = pinned
+	report.UpdatedAt = now
+
+	err = f.saveReport(ctx, reportID, report)
+	return err
+}
+
+// pinnedFirst moves pinned reports ahead of the rest, keeping the existing order otherwise
+func pinnedFirst(reports []models.TrafficReport) {
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].Pinned && !reports[j].Pinned
+	})
+}
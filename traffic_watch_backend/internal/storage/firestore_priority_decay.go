@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Priority Decay Methods (Firestore implementation)
+// ============================================================================
+
+// DecayReportPriorities lowers the priority of approved, unfrozen reports above the default (100)
+// by percent of their excess, rounding down so every boosted report eventually returns to 100.
+// Returns the IDs of the reports that changed.
+func (f *FirestoreClient) DecayReportPriorities(ctx context.Context, percent int) ([]string, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "==", models.StatusReviewedPass).
+		Where("priority", ">", 100).
+		Documents(ctx)
+	defer iter.Stop()
+
+	var ids []string
+	now := time.Now()
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return ids, fmt.Errorf("failed to list reports to decay: %w", err)
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil || report.Priority == nil || report.PriorityFrozen {
+			continue
+		}
+		priority := 100 + (*report.Priority-100)*(100-percent)/100
+		if _, err := doc.Ref.Update(ctx, []firestore.Update{
+			{Path: "priority", Value: priority},
+			{Path: "updatedAt", Value: now},
+		}); err != nil {
+			return ids, fmt.Errorf("failed to decay priority of report %s: %w", doc.Ref.ID, err)
+		}
+		ids = append(ids, doc.Ref.ID)
+	}
+	return ids, nil
+}
+
+// SetReportPriorityFrozen exempts a report from priority decay or makes it subject to decay again
+func (f *FirestoreClient) SetReportPriorityFrozen(ctx context.Context, reportID string, frozen bool) error {
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	if report.Status == models.StatusDeleted {
+		return notFound("report")
+	}
+
+	report.PriorityFrozen = frozen
+	report.UpdatedAt = time.Now()
+
+	err = f.saveReport(ctx, reportID, report)
+	return err
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ============================================================================
+// Profile Methods (Firestore implementation)
+// ============================================================================
+
+// UpdateUserDefaultLocation sets the state/province and city a user's feed is personalized for
+func (f *FirestoreClient) UpdateUserDefaultLocation(ctx context.Context, userID, state, city string) error {
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "DefaultState", Value: state},
+		{Path: "DefaultCity", Value: city},
+		{Path: "UpdatedAt", Value: time.Now()},
+	})
+	if status.Code(err) == codes.NotFound {
+		return notFound("user")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update default location: %w", err)
+	}
+	return nil
+}
+
+// UpdateUserDisplayName sets the name shown on a user's comments. Comments aren't
+// stored in Firestore, so there are none to rename.
+func (f *FirestoreClient) UpdateUserDisplayName(ctx context.Context, userID, displayName string) error {
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "DisplayName", Value: displayName},
+		{Path: "UpdatedAt", Value: time.Now()},
+	})
+	if status.Code(err) == codes.NotFound {
+		return notFound("user")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update display name: %w", err)
+	}
+	return nil
+}
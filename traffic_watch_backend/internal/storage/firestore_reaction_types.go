@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const reactionTypesCollection = "reactionTypes"
+
+// ============================================================================
+// Reaction Type Methods (Firestore implementation)
+// ============================================================================
+
+// ListReactionTypes retrieves every reaction type, retired ones included, in sort order.
+// An empty collection yields the built-in defaults, which are written on the first change.
+func (f *FirestoreClient) ListReactionTypes(ctx context.Context) ([]models.ReactionType, error) {
+	types, err := f.storedReactionTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(types) == 0 {
+		return models.DefaultReactionTypes(), nil
+	}
+	return types, nil
+}
+
+// UpsertReactionType creates or replaces a reaction type, un-retiring it if it was retired
+func (f *FirestoreClient) UpsertReactionType(ctx context.Context, reactionType *models.ReactionType) error {
+	if err := f.seedReactionTypes(ctx); err != nil {
+		return err
+	}
+	reactionType.RetiredAt = nil
+	reactionType.UpdatedAt = time.Now()
+	if _, err := f.client.Collection(reactionTypesCollection).Doc(reactionType.Type).Set(ctx, reactionType); err != nil {
+		return fmt.Errorf("failed to upsert reaction type: %w", err)
+	}
+	return nil
+}
+
+// RetireReactionType stops a reaction type from being offered or added. Retiring an
+// already retired type keeps its original retirement time.
+func (f *FirestoreClient) RetireReactionType(ctx context.Context, reactionType, retiredBy string) error {
+	if err := f.seedReactionTypes(ctx); err != nil {
+		return err
+	}
+	ref := f.client.Collection(reactionTypesCollection).Doc(reactionType)
+	doc, err := ref.Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return notFound("reaction type")
+		}
+		return fmt.Errorf("failed to get reaction type: %w", err)
+	}
+	var existing models.ReactionType
+	if err := doc.DataTo(&existing); err != nil {
+		return fmt.Errorf("failed to decode reaction type: %w", err)
+	}
+
+	now := time.Now()
+	if existing.RetiredAt == nil {
+		existing.RetiredAt = &now
+	}
+	existing.UpdatedBy = retiredBy
+	existing.UpdatedAt = now
+	if _, err := ref.Set(ctx, existing); err != nil {
+		return fmt.Errorf("failed to retire reaction type: %w", err)
+	}
+	return nil
+}
+
+// storedReactionTypes reads the reaction types collection as stored, in sort order
+func (f *FirestoreClient) storedReactionTypes(ctx context.Context) ([]models.ReactionType, error) {
+	iter := f.client.Collection(reactionTypesCollection).Documents(ctx)
+	defer iter.Stop()
+
+	types := []models.ReactionType{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list reaction types: %w", err)
+		}
+		var t models.ReactionType
+		if err := doc.DataTo(&t); err != nil {
+			return nil, fmt.Errorf("failed to decode reaction type: %w", err)
+		}
+		types = append(types, t)
+	}
+	sort.SliceStable(types, func(i, j int) bool {
+		if types[i].SortOrder != types[j].SortOrder {
+			return types[i].SortOrder < types[j].SortOrder
+		}
+		return types[i].Type < types[j].Type
+	})
+	return types, nil
+}
+
+// seedReactionTypes writes the built-in defaults to an empty collection, mirroring the
+// seed rows of the Postgres migration, so the first admin change doesn't drop them
+func (f *FirestoreClient) seedReactionTypes(ctx context.Context) error {
+	stored, err := f.storedReactionTypes(ctx)
+	if err != nil || len(stored) > 0 {
+		return err
+	}
+	batch := f.client.Batch()
+	now := time.Now()
+	for _, t := range models.DefaultReactionTypes() {
+		t.UpdatedAt = now
+		batch.Set(f.client.Collection(reactionTypesCollection).Doc(t.Type), t)
+	}
+	if _, err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to seed reaction types: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Report Export Methods (Firestore implementation)
+// ============================================================================
+
+// ExportReports calls fn for every non-deleted report, oldest first. Reactions and
+// comments aren't stored in Firestore, so included engagement is always empty.
+func (f *FirestoreClient) ExportReports(ctx context.Context, opts models.ReportExportOptions, fn func(models.ReportExportRow) error) error {
+	// Authors whose reports the approved export leaves out, as ListApprovedReports does
+	hidden := map[string]bool{}
+	if opts.ApprovedOnly {
+		banned, err := f.shadowBannedUserIDs(ctx)
+		if err != nil {
+			return err
+		}
+		deactivated, err := f.deactivatedUserIDs(ctx)
+		if err != nil {
+			return err
+		}
+		for id := range banned {
+			hidden[id] = true
+		}
+		for id := range deactivated {
+			hidden[id] = true
+		}
+	}
+
+	iter := f.client.Collection(reportsCollection).OrderBy("createdAt", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	now := time.Now()
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export reports: %w", err)
+		}
+		var row models.ReportExportRow
+		if err := doc.DataTo(&row.Report); err != nil {
+			return fmt.Errorf("failed to decode report: %w", err)
+		}
+		if row.Report.Status == models.StatusDeleted {
+			continue
+		}
+		if opts.ApprovedOnly && (row.Report.Status != models.StatusReviewedPass || row.Report.Embargoed(now) ||
+			row.Report.MediaPending() || hidden[row.Report.UserID]) {
+			continue
+		}
+		if opts.IncludeEngagement {
+			row.Engagement = &models.ExportEngagement{ReactionCounts: map[string]int{}}
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const reportReviewsCollection = "reportReviews"
+
+// ============================================================================
+// Review History Methods (Firestore implementation)
+// ============================================================================
+
+// CreateReportReview appends an entry to a report's review history. In a
+// transaction the write commits with it.
+func (f *FirestoreClient) CreateReportReview(ctx context.Context, review *models.ReportReview) error {
+	if review.ID == "" {
+		return errors.New("review ID is required")
+	}
+	review.CreatedAt = time.Now()
+	ref := f.client.Collection(reportReviewsCollection).Doc(review.ID)
+
+	var err error
+	if f.tx != nil {
+		err = f.tx.Create(ref, review)
+	} else {
+		_, err = ref.Create(ctx, review)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report review: %w", err)
+	}
+	return nil
+}
+
+// ListReportReviews retrieves a report's review history, oldest first
+func (f *FirestoreClient) ListReportReviews(ctx context.Context, reportID string) ([]models.ReportReview, error) {
+	iter := f.client.Collection(reportReviewsCollection).
+		Where("reportId", "==", reportID).
+		OrderBy("createdAt", firestore.Asc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	reviews := []models.ReportReview{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list report reviews: %w", err)
+		}
+		var r models.ReportReview
+		if err := doc.DataTo(&r); err != nil {
+			return nil, fmt.Errorf("failed to decode report review: %w", err)
+		}
+		reviews = append(reviews, r)
+	}
+	return reviews, nil
+}
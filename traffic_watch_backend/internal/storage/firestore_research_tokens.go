@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const (
+	researchTokensCollection     = "researchTokens"
+	researchTokenUsageCollection = "researchTokenUsage"
+)
+
+// researchTokenUsageDoc is one token's usage for a day; its document ID is tokenId_day
+type researchTokenUsageDoc struct {
+	TokenID  string `firestore:"tokenId"`
+	Day      string `firestore:"day"`
+	Requests int    `firestore:"requests"`
+	Rejected int    `firestore:"rejected"`
+}
+
+// ============================================================================
+// Research Token Methods (Firestore implementation)
+// ============================================================================
+
+// CreateResearchToken stores a new research token
+func (f *FirestoreClient) CreateResearchToken(ctx context.Context, token *models.ResearchToken) error {
+	if token.ID == "" {
+		return errors.New("research token ID is required")
+	}
+	token.CreatedAt = time.Now()
+
+	if _, err := f.client.Collection(researchTokensCollection).Doc(token.ID).Set(ctx, token); err != nil {
+		return fmt.Errorf("failed to create research token: %w", err)
+	}
+	return nil
+}
+
+// GetResearchTokenByHash retrieves the research token whose secret hashes to hash
+func (f *FirestoreClient) GetResearchTokenByHash(ctx context.Context, hash string) (*models.ResearchToken, error) {
+	iter := f.client.Collection(researchTokensCollection).Where("tokenHash", "==", hash).Limit(1).Documents(ctx)
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return nil, notFound("research token")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get research token: %w", err)
+	}
+	var token models.ResearchToken
+	if err := doc.DataTo(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode research token: %w", err)
+	}
+	return &token, nil
+}
+
+// ListResearchTokens retrieves every research token, newest first, with today's and total
+// request counts. Usage is read per token, which is fine for the handful of tokens issued.
+func (f *FirestoreClient) ListResearchTokens(ctx context.Context) ([]models.ResearchToken, error) {
+	iter := f.client.Collection(researchTokensCollection).OrderBy("createdAt", firestore.Desc).Documents(ctx)
+	defer iter.Stop()
+
+	today := models.UsageDay(time.Now())
+	tokens := []models.ResearchToken{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list research tokens: %w", err)
+		}
+		var t models.ResearchToken
+		if err := doc.DataTo(&t); err != nil {
+			return nil, fmt.Errorf("failed to decode research token: %w", err)
+		}
+
+		usage, err := f.ListResearchTokenUsage(ctx, t.ID, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range usage {
+			t.TotalRequests += u.Requests
+			if u.Day == today {
+				t.RequestsToday = u.Requests
+			}
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, nil
+}
+
+// RevokeResearchToken stops a research token from being used. Revoking an already
+// revoked token keeps its original revocation time.
+func (f *FirestoreClient) RevokeResearchToken(ctx context.Context, id string) error {
+	ref := f.client.Collection(researchTokensCollection).Doc(id)
+	return f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if status.Code(err) == codes.NotFound {
+			return notFound("research token")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get research token: %w", err)
+		}
+		var token models.ResearchToken
+		if err := doc.DataTo(&token); err != nil {
+			return fmt.Errorf("failed to decode research token: %w", err)
+		}
+		if token.RevokedAt != nil {
+			return nil
+		}
+		return tx.Update(ref, []firestore.Update{{Path: "revokedAt", Value: time.Now()}})
+	})
+}
+
+// UseResearchToken counts a request against a token's daily quota in a transaction,
+// so concurrent requests can't overshoot it
+func (f *FirestoreClient) UseResearchToken(ctx context.Context, tokenID, day string, quota int) (bool, error) {
+	usageRef := f.client.Collection(researchTokenUsageCollection).Doc(tokenID + "_" + day)
+	tokenRef := f.client.Collection(researchTokensCollection).Doc(tokenID)
+
+	var served bool
+	err := f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		usage := researchTokenUsageDoc{TokenID: tokenID, Day: day}
+		doc, err := tx.Get(usageRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("failed to get research token usage: %w", err)
+		}
+		if err == nil {
+			if err := doc.DataTo(&usage); err != nil {
+				return fmt.Errorf("failed to decode research token usage: %w", err)
+			}
+		}
+
+		served = usage.Requests < quota
+		if !served {
+			usage.Rejected++
+			return tx.Set(usageRef, usage)
+		}
+		usage.Requests++
+		if err := tx.Set(usageRef, usage); err != nil {
+			return err
+		}
+		return tx.Update(tokenRef, []firestore.Update{{Path: "lastUsedAt", Value: time.Now()}})
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to count research token use: %w", err)
+	}
+	return served, nil
+}
+
+// ListResearchTokenUsage retrieves a token's daily usage from since on, newest first.
+// An empty since returns every day.
+func (f *FirestoreClient) ListResearchTokenUsage(ctx context.Context, tokenID, since string) ([]models.ResearchTokenUsage, error) {
+	iter := f.client.Collection(researchTokenUsageCollection).
+		Where("tokenId", "==", tokenID).
+		Where("day", ">=", since).
+		OrderBy("day", firestore.Desc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	usage := []models.ResearchTokenUsage{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list research token usage: %w", err)
+		}
+		var u researchTokenUsageDoc
+		if err := doc.DataTo(&u); err != nil {
+			return nil, fmt.Errorf("failed to decode research token usage: %w", err)
+		}
+		usage = append(usage, models.ResearchTokenUsage{Day: u.Day, Requests: u.Requests, Rejected: u.Rejected})
+	}
+	return usage, nil
+}
@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Role Management Methods (Firestore implementation)
+// ============================================================================
+
+// UpdateUserRole changes a user's role
+func (f *FirestoreClient) UpdateUserRole(ctx context.Context, userID string, role models.UserRole) error {
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "Role", Value: role},
+		{Path: "UpdatedAt", Value: time.Now()},
+	})
+	if status.Code(err) == codes.NotFound {
+		return notFound("user")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Admin Search Methods (Firestore implementation)
+// ============================================================================
+
+// SearchReports finds non-deleted reports whose title, description or location
+// contains any word of query, or whose submitter's email contains query. Firestore
+// has no text search, so this scans every report; reports matching more words rank first.
+func (f *FirestoreClient) SearchReports(ctx context.Context, query string, limit int) ([]models.ReportSearchHit, error) {
+	terms := searchTerms(query)
+	emailQuery := strings.ToLower(strings.TrimSpace(query))
+
+	emails, err := f.userEmails(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := f.allReports(ctx, true, models.ReportSort{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search reports: %w", err)
+	}
+
+	type scored struct {
+		hit   models.ReportSearchHit
+		score int
+	}
+	var matches []scored
+	for _, report := range reports {
+		doc := strings.ToLower(strings.Join([]string{report.Title, report.Description, report.State, report.City}, " "))
+		score := 0
+		for _, term := range terms {
+			if strings.Contains(doc, term) {
+				score++
+			}
+		}
+		email := emails[report.UserID]
+		if score == 0 && (emailQuery == "" || !strings.Contains(strings.ToLower(email), emailQuery)) {
+			continue
+		}
+		matches = append(matches, scored{hit: models.ReportSearchHit{Report: report, SubmitterEmail: email}, score: score})
+	}
+
+	// allReports is newest first, so a stable sort keeps that order within a score
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	hits := make([]models.ReportSearchHit, len(matches))
+	for i, m := range matches {
+		hits[i] = m.hit
+	}
+	return hits, nil
+}
+
+// SearchUsers finds users whose email contains query, alphabetically
+func (f *FirestoreClient) SearchUsers(ctx context.Context, query string, limit int) ([]models.User, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	iter := f.client.Collection(usersCollection).Documents(ctx)
+	defer iter.Stop()
+
+	users := []models.User{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to search users: %w", err)
+		}
+		var user models.User
+		if err := doc.DataTo(&user); err != nil {
+			continue
+		}
+		if strings.Contains(strings.ToLower(user.Email), query) {
+			users = append(users, user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Email < users[j].Email
+	})
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// userEmails maps every user ID to its email
+func (f *FirestoreClient) userEmails(ctx context.Context) (map[string]string, error) {
+	iter := f.client.Collection(usersCollection).Select("email").Documents(ctx)
+	defer iter.Stop()
+
+	emails := map[string]string{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		email, _ := doc.Data()["email"].(string)
+		emails[doc.Ref.ID] = email
+	}
+	return emails, nil
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Shadow Ban Methods (Firestore implementation)
+// ============================================================================
+
+// SetUserShadowBanned sets or clears a user's shadow ban
+func (f *FirestoreClient) SetUserShadowBanned(ctx context.Context, userID string, banned bool) error {
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "ShadowBanned", Value: banned},
+		{Path: "UpdatedAt", Value: time.Now()},
+	})
+	if status.Code(err) == codes.NotFound {
+		return notFound("user")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update shadow ban: %w", err)
+	}
+	return nil
+}
+
+// shadowBannedUserIDs returns the set of shadow-banned user IDs, for filtering query results in memory
+func (f *FirestoreClient) shadowBannedUserIDs(ctx context.Context) (map[string]bool, error) {
+	iter := f.client.Collection(usersCollection).
+		Where("ShadowBanned", "==", true).
+		Documents(ctx)
+	defer iter.Stop()
+
+	banned := make(map[string]bool)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list shadow-banned users: %w", err)
+		}
+		banned[doc.Ref.ID] = true
+	}
+	return banned, nil
+}
+
+// withoutShadowBanned drops reports whose author is shadow-banned
+func (f *FirestoreClient) withoutShadowBanned(ctx context.Context, reports []models.TrafficReport) ([]models.TrafficReport, error) {
+	banned, err := f.shadowBannedUserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(banned) == 0 {
+		return reports, nil
+	}
+
+	visible := reports[:0]
+	for _, r := range reports {
+		if !banned[r.UserID] {
+			visible = append(visible, r)
+		}
+	}
+	return visible, nil
+}
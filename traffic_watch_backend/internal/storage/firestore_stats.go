@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const (
+	reportStatsCollection = "reportStats"
+	reportStatsDocID      = "current"
+)
+
+// ============================================================================
+// Statistics Methods (Firestore implementation)
+// ============================================================================
+
+// GetReportStats retrieves the stats snapshot written by RefreshReportStats, with daily counts from since onwards.
+// If no snapshot exists yet it is built on demand.
+func (f *FirestoreClient) GetReportStats(ctx context.Context, since time.Time) (*models.ReportStats, error) {
+	doc, err := f.client.Collection(reportStatsCollection).Doc(reportStatsDocID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		if err := f.RefreshReportStats(ctx); err != nil {
+			return nil, err
+		}
+		doc, err = f.client.Collection(reportStatsCollection).Doc(reportStatsDocID).Get(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report stats: %w", err)
+	}
+
+	var stats models.ReportStats
+	if err := doc.DataTo(&stats); err != nil {
+		return nil, fmt.Errorf("failed to decode report stats: %w", err)
+	}
+
+	sinceDay := since.UTC().Format("2006-01-02")
+	byDay := []models.DailyCount{}
+	for _, dc := range stats.ByDay {
+		if dc.Date >= sinceDay {
+			byDay = append(byDay, dc)
+		}
+	}
+	stats.ByDay = byDay
+	if stats.ByState == nil {
+		stats.ByState = []models.KeyCount{}
+	}
+	if stats.ByEventType == nil {
+		stats.ByEventType = []models.KeyCount{}
+	}
+
+	return &stats, nil
+}
+
+// RefreshReportStats aggregates approved reports into a single snapshot document
+func (f *FirestoreClient) RefreshReportStats(ctx context.Context) error {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "==", models.StatusReviewedPass).
+		Select("dateTime", "state", "eventTypes").
+		Documents(ctx)
+
+	days := map[string]int{}
+	states := map[string]int{}
+	eventTypes := map[string]int{}
+	total := 0
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list reports for stats: %w", err)
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		total++
+		days[report.DateTime.UTC().Format("2006-01-02")]++
+		states[report.State]++
+		seen := map[string]bool{}
+		for _, et := range report.EventTypes {
+			if !seen[et] {
+				seen[et] = true
+				eventTypes[et]++
+			}
+		}
+	}
+
+	now := time.Now()
+	stats := models.ReportStats{
+		Total:       total,
+		ByDay:       []models.DailyCount{},
+		ByState:     sortedKeyCounts(states),
+		ByEventType: sortedKeyCounts(eventTypes),
+		RefreshedAt: &now,
+	}
+	for day, count := range days {
+		stats.ByDay = append(stats.ByDay, models.DailyCount{Date: day, Count: count})
+	}
+	sort.Slice(stats.ByDay, func(i, j int) bool { return stats.ByDay[i].Date < stats.ByDay[j].Date })
+
+	if _, err := f.client.Collection(reportStatsCollection).Doc(reportStatsDocID).Set(ctx, stats); err != nil {
+		return fmt.Errorf("failed to save report stats: %w", err)
+	}
+	return nil
+}
+
+// sortedKeyCounts orders counts descending, breaking ties by key
+func sortedKeyCounts(counts map[string]int) []models.KeyCount {
+	result := make([]models.KeyCount, 0, len(counts))
+	for key, count := range counts {
+		result = append(result, models.KeyCount{Key: key, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Status Seen Methods (Firestore implementation)
+// ============================================================================
+
+// MarkReportStatusSeen records that the owner has seen a report's current status
+func (f *FirestoreClient) MarkReportStatusSeen(ctx context.Context, reportID, userID string) error {
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	if report.UserID != userID || report.Status == models.StatusDeleted {
+		return notFound("report")
+	}
+
+	now := time.Now()
+	report.StatusSeenAt = &now
+
+	err = f.saveReport(ctx, reportID, report)
+	return err
+}
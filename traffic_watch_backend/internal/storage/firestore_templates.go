@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+const reportTemplatesCollection = "reportTemplates"
+
+// ============================================================================
+// Report Template Methods (Firestore implementation)
+// ============================================================================
+
+// CreateReportTemplate stores a new report template
+func (f *FirestoreClient) CreateReportTemplate(ctx context.Context, t *models.ReportTemplate) error {
+	if t.ID == "" {
+		return errors.New("template ID is required")
+	}
+	now := time.Now()
+	t.CreatedAt = now
+	t.UpdatedAt = now
+
+	if _, err := f.client.Collection(reportTemplatesCollection).Doc(t.ID).Set(ctx, t); err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+	return nil
+}
+
+// ListReportTemplates retrieves a user's report templates by name
+func (f *FirestoreClient) ListReportTemplates(ctx context.Context, userID string) ([]models.ReportTemplate, error) {
+	iter := f.client.Collection(reportTemplatesCollection).
+		Where("userId", "==", userID).
+		Documents(ctx)
+	defer iter.Stop()
+
+	templates := []models.ReportTemplate{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list templates: %w", err)
+		}
+		var t models.ReportTemplate
+		if err := doc.DataTo(&t); err != nil {
+			return nil, fmt.Errorf("failed to decode template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	sort.SliceStable(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// GetReportTemplate retrieves one of a user's report templates
+func (f *FirestoreClient) GetReportTemplate(ctx context.Context, templateID, userID string) (*models.ReportTemplate, error) {
+	doc, err := f.client.Collection(reportTemplatesCollection).Doc(templateID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		return nil, notFound("template")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	var t models.ReportTemplate
+	if err := doc.DataTo(&t); err != nil {
+		return nil, fmt.Errorf("failed to decode template: %w", err)
+	}
+	if t.UserID != userID {
+		return nil, notFound("template")
+	}
+	return &t, nil
+}
+
+// UpdateReportTemplate replaces one of a user's report templates
+func (f *FirestoreClient) UpdateReportTemplate(ctx context.Context, t *models.ReportTemplate) error {
+	existing, err := f.GetReportTemplate(ctx, t.ID, t.UserID)
+	if err != nil {
+		return err
+	}
+	t.CreatedAt = existing.CreatedAt
+	t.UpdatedAt = time.Now()
+
+	if _, err := f.client.Collection(reportTemplatesCollection).Doc(t.ID).Set(ctx, t); err != nil {
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+	return nil
+}
+
+// DeleteReportTemplate removes one of a user's report templates
+func (f *FirestoreClient) DeleteReportTemplate(ctx context.Context, templateID, userID string) error {
+	if _, err := f.GetReportTemplate(ctx, templateID, userID); err != nil {
+		return err
+	}
+	if _, err := f.client.Collection(reportTemplatesCollection).Doc(templateID).Delete(ctx); err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	return nil
+}
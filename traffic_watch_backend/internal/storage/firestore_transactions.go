@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Transaction Methods (Firestore implementation)
+// Note: Firestore transactions must do all reads before any write, so report
+// writes are staged on the transaction's client and applied when fn returns;
+// later reads of a staged report see the staged copy.
+// ============================================================================
+
+// WithTransaction runs fn in a Firestore transaction. Firestore retries fn when a
+// report it read changes before the commit.
+func (f *FirestoreClient) WithTransaction(ctx context.Context, fn func(tx Client) error) error {
+	return f.runTransaction(ctx, func(t *FirestoreClient) error {
+		return fn(t)
+	})
+}
+
+// runTransaction runs fn with a client bound to a transaction, joining the current
+// one when f is already bound
+func (f *FirestoreClient) runTransaction(ctx context.Context, fn func(t *FirestoreClient) error) error {
+	if f.tx != nil {
+		return fn(f)
+	}
+
+	return f.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		t := *f
+		t.tx = tx
+		t.staged = make(map[string]*models.TrafficReport)
+		if err := fn(&t); err != nil {
+			return err
+		}
+		for reportID, report := range t.staged {
+			if err := tx.Set(f.client.Collection(reportsCollection).Doc(reportID), report); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// getReportInTransaction reads a report through the transaction, or returns the
+// copy staged by an earlier write in it
+func (f *FirestoreClient) getReportInTransaction(reportID string) (*models.TrafficReport, error) {
+	if staged, ok := f.staged[reportID]; ok {
+		report := *staged
+		return &report, nil
+	}
+
+	doc, err := f.tx.Get(f.client.Collection(reportsCollection).Doc(reportID))
+	if status.Code(err) == codes.NotFound {
+		return nil, notFound("report")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var report models.TrafficReport
+	if err := doc.DataTo(&report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// saveReport writes a report document, or stages it when f is bound to a transaction
+func (f *FirestoreClient) saveReport(ctx context.Context, reportID string, report *models.TrafficReport) error {
+	if f.tx != nil {
+		staged := *report
+		f.staged[reportID] = &staged
+		return nil
+	}
+
+	_, err := f.client.Collection(reportsCollection).Doc(reportID).Set(ctx, report)
+	return err
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Transcript Methods (Firestore implementation)
+// ============================================================================
+
+// ListMediaForTranscription retrieves GCS-hosted videos on non-deleted reports that have
+// not been transcribed yet or whose transcription is still running
+func (f *FirestoreClient) ListMediaForTranscription(ctx context.Context, limit int) ([]models.TranscriptJob, error) {
+	iter := f.client.Collection(reportsCollection).
+		Where("status", "!=", models.StatusDeleted).
+		Documents(ctx)
+
+	var jobs []models.TranscriptJob
+	for len(jobs) < limit {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var report models.TrafficReport
+		if err := doc.DataTo(&report); err != nil {
+			continue
+		}
+		for _, mf := range report.MediaFiles {
+			if needsTranscript(mf) && len(jobs) < limit {
+				jobs = append(jobs, models.TranscriptJob{ReportID: report.ID, UserID: report.UserID, Media: mf})
+			}
+		}
+	}
+	return jobs, nil
+}
+
+// needsTranscript reports whether a media file is a checked GCS-hosted video without a finished transcription
+func needsTranscript(mf models.MediaFile) bool {
+	if !IsVideoContentType(mf.ContentType) || mf.EffectiveState() != models.MediaReady || strings.Contains(mf.URL, "youtube.com") || strings.Contains(mf.URL, "youtu.be") {
+		return false
+	}
+	return mf.TranscriptStatus == "" || mf.TranscriptStatus == models.TranscriptPending
+}
+
+// UpdateMediaTranscript records the transcription state of a media file: the running
+// operation while pending, the transcript once completed
+func (f *FirestoreClient) UpdateMediaTranscript(ctx context.Context, reportID, mediaID, status, transcript, operation string) error {
+	report, err := f.GetReport(ctx, reportID)
+	if err != nil {
+		return err
+	}
+
+	for i := range report.MediaFiles {
+		mf := &report.MediaFiles[i]
+		if mf.ID != mediaID {
+			continue
+		}
+		mf.TranscriptStatus = status
+		mf.Transcript = transcript
+		mf.TranscriptOperation = operation
+
+		err = f.saveReport(ctx, reportID, report)
+		return err
+	}
+	return notFound("media file")
+}
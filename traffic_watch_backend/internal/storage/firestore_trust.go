@@ -0,0 +1,28 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// SetUserTrustLevel stores a user's recomputed trust level
+func (f *FirestoreClient) SetUserTrustLevel(ctx context.Context, userID string, level models.TrustLevel) error {
+	_, err := f.client.Collection(usersCollection).Doc(userID).Update(ctx, []firestore.Update{
+		{Path: "TrustLevel", Value: level},
+		{Path: "UpdatedAt", Value: time.Now()},
+	})
+	if status.Code(err) == codes.NotFound {
+		return notFound("user")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update trust level: %w", err)
+	}
+	return nil
+}
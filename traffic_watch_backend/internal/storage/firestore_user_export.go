@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// User Export Methods (Firestore implementation)
+// ============================================================================
+
+// ExportUsers calls fn for every user, oldest signup first, with their report
+// counts. Firestore has no joins, so report counts are tallied in one pass first.
+func (f *FirestoreClient) ExportUsers(ctx context.Context, fn func(models.UserExportRow) error) error {
+	total, approved, err := f.reportCountsByUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	iter := f.client.Collection(usersCollection).OrderBy("CreatedAt", firestore.Asc).Documents(ctx)
+	defer iter.Stop()
+
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to export users: %w", err)
+		}
+		var u models.User
+		if err := doc.DataTo(&u); err != nil {
+			return fmt.Errorf("failed to decode user: %w", err)
+		}
+		if err := fn(models.UserExportRow{
+			ID:                  u.ID,
+			Email:               u.Email,
+			Role:                u.Role,
+			CreatedAt:           u.CreatedAt,
+			LastLoginAt:         u.LastLoginAt,
+			ReportCount:         total[u.ID],
+			ApprovedReportCount: approved[u.ID],
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// reportCountsByUser tallies non-deleted and approved reports per user
+func (f *FirestoreClient) reportCountsByUser(ctx context.Context) (map[string]int, map[string]int, error) {
+	iter := f.client.Collection(reportsCollection).Select("userId", "status").Documents(ctx)
+	defer iter.Stop()
+
+	total := make(map[string]int)
+	approved := make(map[string]int)
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			return total, approved, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count reports: %w", err)
+		}
+		userID, _ := doc.Data()["userId"].(string)
+		status, _ := doc.Data()["status"].(string)
+		if status == models.StatusDeleted {
+			continue
+		}
+		total[userID]++
+		if status == models.StatusReviewedPass {
+			approved[userID]++
+		}
+	}
+}
@@ -8,16 +8,20 @@ import (
 	"time"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
 
 	"donzhit_me_backend/internal/validation"
 )
 
 const (
-	// Default signed URL expiration
-	defaultURLExpiration = 1 * time.Hour
+	// MediaURLExpiration is how long a signed media URL stays valid by default
+	MediaURLExpiration = 1 * time.Hour
 
-	// Upload URL expiration (for resumable uploads)
-	uploadURLExpiration = 15 * time.Minute
+	// MaxMediaURLExpiration is the longest lifetime GCS accepts for a V4 signed URL
+	MaxMediaURLExpiration = 7 * 24 * time.Hour
+
+	// UploadURLExpiration is how long a signed upload URL stays valid
+	UploadURLExpiration = 15 * time.Minute
 )
 
 // GCSClient wraps the Google Cloud Storage client
@@ -44,6 +48,16 @@ func (g *GCSClient) Close() error {
 	return g.client.Close()
 }
 
+// CheckAccess lists at most one object, confirming the bucket exists and the
+// service account can read it
+func (g *GCSClient) CheckAccess(ctx context.Context) error {
+	_, err := g.client.Bucket(g.bucketName).Objects(ctx, nil).Next()
+	if err == iterator.Done {
+		return nil
+	}
+	return err
+}
+
 // UploadFile uploads a file to GCS
 func (g *GCSClient) UploadFile(ctx context.Context, userID, reportID, fileID string, contentType string, reader io.Reader) (string, error) {
 	objectPath := g.getObjectPath(userID, reportID, fileID)
@@ -70,7 +84,7 @@ func (g *GCSClient) UploadFile(ctx context.Context, userID, reportID, fileID str
 // GetSignedURL generates a signed URL for reading a file
 func (g *GCSClient) GetSignedURL(ctx context.Context, objectPath string, expiration time.Duration) (string, error) {
 	if expiration == 0 {
-		expiration = defaultURLExpiration
+		expiration = MediaURLExpiration
 	}
 
 	opts := &storage.SignedURLOptions{
@@ -92,7 +106,7 @@ func (g *GCSClient) GetUploadSignedURL(ctx context.Context, userID, reportID, fi
 
 	opts := &storage.SignedURLOptions{
 		Method:      "PUT",
-		Expires:     time.Now().Add(uploadURLExpiration),
+		Expires:     time.Now().Add(UploadURLExpiration),
 		ContentType: contentType,
 	}
 
@@ -104,6 +118,15 @@ func (g *GCSClient) GetUploadSignedURL(ctx context.Context, userID, reportID, fi
 	return url, objectPath, nil
 }
 
+// OpenFile opens a file in GCS for reading; the caller closes it
+func (g *GCSClient) OpenFile(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	reader, err := g.client.Bucket(g.bucketName).Object(objectPath).NewReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return reader, nil
+}
+
 // DeleteFile deletes a file from GCS
 func (g *GCSClient) DeleteFile(ctx context.Context, objectPath string) error {
 	bucket := g.client.Bucket(g.bucketName)
@@ -158,8 +181,25 @@ func (g *GCSClient) FileExists(ctx context.Context, objectPath string) (bool, er
 	return true, nil
 }
 
+// ObjectSize returns the size of a file in GCS; exists is false if there is no such file
+func (g *GCSClient) ObjectSize(ctx context.Context, objectPath string) (size int64, exists bool, err error) {
+	attrs, err := g.client.Bucket(g.bucketName).Object(objectPath).Attrs(ctx)
+	if err == storage.ErrObjectNotExist {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get file attributes: %w", err)
+	}
+	return attrs.Size, true, nil
+}
+
 // getObjectPath generates the object path for a file
 func (g *GCSClient) getObjectPath(userID, reportID, fileID string) string {
+	return reportObjectPath(userID, reportID, fileID)
+}
+
+// reportObjectPath is where a report's media file is stored, in any BlobStore
+func reportObjectPath(userID, reportID, fileID string) string {
 	// Sanitize all path components
 	safeUserID := validation.SanitizeFileName(userID)
 	safeReportID := validation.SanitizeFileName(reportID)
@@ -0,0 +1,962 @@
+package storage
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"donzhit_me_backend/internal/metrics"
+	"donzhit_me_backend/internal/models"
+)
+
+// InstrumentedClient decorates a Client, bounding each call by a per-method
+// timeout, recording a latency histogram per method and logging calls slower
+// than a threshold
+type InstrumentedClient struct {
+	next          Client
+	metrics       *metrics.Registry
+	slowThreshold time.Duration
+	timeouts      Timeouts
+}
+
+var _ Client = (*InstrumentedClient)(nil)
+
+// NewInstrumentedClient wraps next with DefaultTimeouts; a zero slowThreshold
+// disables slow-call logging
+func NewInstrumentedClient(next Client, registry *metrics.Registry, slowThreshold time.Duration) *InstrumentedClient {
+	return &InstrumentedClient{
+		next:          next,
+		metrics:       registry,
+		slowThreshold: slowThreshold,
+		timeouts:      DefaultTimeouts(),
+	}
+}
+
+// SetTimeouts replaces the per-method call timeouts
+func (c *InstrumentedClient) SetTimeouts(timeouts Timeouts) {
+	c.timeouts = timeouts
+}
+
+// call starts a call to method: it bounds ctx by the method's timeout and returns
+// the function that records the outcome; use as:
+//
+//	ctx, done := c.call(ctx, "Method")
+//	defer done(&err)
+func (c *InstrumentedClient) call(ctx context.Context, method string) (context.Context, func(err *error)) {
+	start := time.Now()
+	ctx, finish := c.timeouts.withTimeout(ctx, method)
+	return ctx, func(err *error) {
+		if finish(err) {
+			c.metrics.ObserveTimeout(method)
+			log.Printf("TIMEOUT storage call: %s gave up after %s", method, c.timeouts.For(method))
+		}
+		c.observe(method, start, err)
+	}
+}
+
+// observe records a finished call
+func (c *InstrumentedClient) observe(method string, start time.Time, err *error) {
+	elapsed := time.Since(start)
+	failed := *err != nil
+	c.metrics.Observe(method, elapsed, failed)
+	if c.slowThreshold > 0 && elapsed >= c.slowThreshold {
+		log.Printf("SLOW storage call: %s took %s (threshold %s, failed: %v)", method, elapsed.Round(time.Millisecond), c.slowThreshold, failed)
+	}
+}
+
+// Close closes the wrapped client
+func (c *InstrumentedClient) Close() error {
+	return c.next.Close()
+}
+
+func (c *InstrumentedClient) Ping(ctx context.Context) (err error) {
+	ctx, done := c.call(ctx, "Ping")
+	defer done(&err)
+	return c.next.Ping(ctx)
+}
+
+// WithTransaction records the transaction as a whole, and instruments the calls fn
+// makes through tx like any other
+func (c *InstrumentedClient) WithTransaction(ctx context.Context, fn func(tx Client) error) (err error) {
+	ctx, done := c.call(ctx, "WithTransaction")
+	defer done(&err)
+	return c.next.WithTransaction(ctx, func(tx Client) error {
+		return fn(&InstrumentedClient{next: tx, metrics: c.metrics, slowThreshold: c.slowThreshold, timeouts: c.timeouts})
+	})
+}
+
+func (c *InstrumentedClient) CreateReport(ctx context.Context, report *models.TrafficReport) (err error) {
+	ctx, done := c.call(ctx, "CreateReport")
+	defer done(&err)
+	return c.next.CreateReport(ctx, report)
+}
+
+func (c *InstrumentedClient) ImportReport(ctx context.Context, report *models.TrafficReport) (err error) {
+	ctx, done := c.call(ctx, "ImportReport")
+	defer done(&err)
+	return c.next.ImportReport(ctx, report)
+}
+
+func (c *InstrumentedClient) GetReport(ctx context.Context, reportID string) (result *models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "GetReport")
+	defer done(&err)
+	return c.next.GetReport(ctx, reportID)
+}
+
+func (c *InstrumentedClient) GetReportByIDAndUser(ctx context.Context, reportID, userID string) (result *models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "GetReportByIDAndUser")
+	defer done(&err)
+	return c.next.GetReportByIDAndUser(ctx, reportID, userID)
+}
+
+func (c *InstrumentedClient) ListReportsByUser(ctx context.Context, userID string) (result []models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "ListReportsByUser")
+	defer done(&err)
+	return c.next.ListReportsByUser(ctx, userID)
+}
+
+func (c *InstrumentedClient) ListUserReports(ctx context.Context, userID, status string, sort models.ReportSort) (result []models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "ListUserReports")
+	defer done(&err)
+	return c.next.ListUserReports(ctx, userID, status, sort)
+}
+
+func (c *InstrumentedClient) CountUserReports(ctx context.Context, userID, status string) (result int, err error) {
+	ctx, done := c.call(ctx, "CountUserReports")
+	defer done(&err)
+	return c.next.CountUserReports(ctx, userID, status)
+}
+
+func (c *InstrumentedClient) MarkReportStatusSeen(ctx context.Context, reportID, userID string) (err error) {
+	ctx, done := c.call(ctx, "MarkReportStatusSeen")
+	defer done(&err)
+	return c.next.MarkReportStatusSeen(ctx, reportID, userID)
+}
+
+func (c *InstrumentedClient) UpdateReport(ctx context.Context, report *models.TrafficReport) (err error) {
+	ctx, done := c.call(ctx, "UpdateReport")
+	defer done(&err)
+	return c.next.UpdateReport(ctx, report)
+}
+
+func (c *InstrumentedClient) DeleteReport(ctx context.Context, reportID, userID string) (err error) {
+	ctx, done := c.call(ctx, "DeleteReport")
+	defer done(&err)
+	return c.next.DeleteReport(ctx, reportID, userID)
+}
+
+func (c *InstrumentedClient) AddMediaFileToReport(ctx context.Context, reportID string, mediaFile models.MediaFile) (err error) {
+	ctx, done := c.call(ctx, "AddMediaFileToReport")
+	defer done(&err)
+	return c.next.AddMediaFileToReport(ctx, reportID, mediaFile)
+}
+
+func (c *InstrumentedClient) ListAllReports(ctx context.Context, includeShadowBanned bool, sort models.ReportSort, limit, offset int) (result []models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "ListAllReports")
+	defer done(&err)
+	return c.next.ListAllReports(ctx, includeShadowBanned, sort, limit, offset)
+}
+
+func (c *InstrumentedClient) CountAllReports(ctx context.Context, includeShadowBanned bool) (result int, err error) {
+	ctx, done := c.call(ctx, "CountAllReports")
+	defer done(&err)
+	return c.next.CountAllReports(ctx, includeShadowBanned)
+}
+
+func (c *InstrumentedClient) ListReportsAwaitingReview(ctx context.Context, includeShadowBanned bool, limit, offset int) (result []models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "ListReportsAwaitingReview")
+	defer done(&err)
+	return c.next.ListReportsAwaitingReview(ctx, includeShadowBanned, limit, offset)
+}
+
+func (c *InstrumentedClient) CountReportsAwaitingReview(ctx context.Context, includeShadowBanned bool) (result int, err error) {
+	ctx, done := c.call(ctx, "CountReportsAwaitingReview")
+	defer done(&err)
+	return c.next.CountReportsAwaitingReview(ctx, includeShadowBanned)
+}
+
+func (c *InstrumentedClient) ListApprovedReports(ctx context.Context) (result []models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "ListApprovedReports")
+	defer done(&err)
+	return c.next.ListApprovedReports(ctx)
+}
+
+func (c *InstrumentedClient) UpdateReportStatus(ctx context.Context, reportID, status, reviewReason, reviewedBy string) (err error) {
+	ctx, done := c.call(ctx, "UpdateReportStatus")
+	defer done(&err)
+	return c.next.UpdateReportStatus(ctx, reportID, status, reviewReason, reviewedBy)
+}
+
+func (c *InstrumentedClient) UpdateReportStatusWithPriority(ctx context.Context, reportID, status, reviewReason string, priority *int, reviewedBy string) (err error) {
+	ctx, done := c.call(ctx, "UpdateReportStatusWithPriority")
+	defer done(&err)
+	return c.next.UpdateReportStatusWithPriority(ctx, reportID, status, reviewReason, priority, reviewedBy)
+}
+
+func (c *InstrumentedClient) CreateOrUpdateUser(ctx context.Context, user *models.User) (err error) {
+	ctx, done := c.call(ctx, "CreateOrUpdateUser")
+	defer done(&err)
+	return c.next.CreateOrUpdateUser(ctx, user)
+}
+
+func (c *InstrumentedClient) GetUserByID(ctx context.Context, userID string) (result *models.User, err error) {
+	ctx, done := c.call(ctx, "GetUserByID")
+	defer done(&err)
+	return c.next.GetUserByID(ctx, userID)
+}
+
+func (c *InstrumentedClient) GetUserByEmail(ctx context.Context, email string) (result *models.User, err error) {
+	ctx, done := c.call(ctx, "GetUserByEmail")
+	defer done(&err)
+	return c.next.GetUserByEmail(ctx, email)
+}
+
+func (c *InstrumentedClient) UpdateUserRefreshToken(ctx context.Context, userID, refreshToken string) (err error) {
+	ctx, done := c.call(ctx, "UpdateUserRefreshToken")
+	defer done(&err)
+	return c.next.UpdateUserRefreshToken(ctx, userID, refreshToken)
+}
+
+func (c *InstrumentedClient) UpdateUserLastLogin(ctx context.Context, userID string) (err error) {
+	ctx, done := c.call(ctx, "UpdateUserLastLogin")
+	defer done(&err)
+	return c.next.UpdateUserLastLogin(ctx, userID)
+}
+
+func (c *InstrumentedClient) RevokeUserToken(ctx context.Context, userID string) (err error) {
+	ctx, done := c.call(ctx, "RevokeUserToken")
+	defer done(&err)
+	return c.next.RevokeUserToken(ctx, userID)
+}
+
+func (c *InstrumentedClient) UpdateUserRole(ctx context.Context, userID string, role models.UserRole) (err error) {
+	ctx, done := c.call(ctx, "UpdateUserRole")
+	defer done(&err)
+	return c.next.UpdateUserRole(ctx, userID, role)
+}
+
+func (c *InstrumentedClient) UpdateUserDefaultLocation(ctx context.Context, userID, state, city string) (err error) {
+	ctx, done := c.call(ctx, "UpdateUserDefaultLocation")
+	defer done(&err)
+	return c.next.UpdateUserDefaultLocation(ctx, userID, state, city)
+}
+
+func (c *InstrumentedClient) UpdateUserDisplayName(ctx context.Context, userID, displayName string) (err error) {
+	ctx, done := c.call(ctx, "UpdateUserDisplayName")
+	defer done(&err)
+	return c.next.UpdateUserDisplayName(ctx, userID, displayName)
+}
+
+func (c *InstrumentedClient) SetUserTrustLevel(ctx context.Context, userID string, level models.TrustLevel) (err error) {
+	ctx, done := c.call(ctx, "SetUserTrustLevel")
+	defer done(&err)
+	return c.next.SetUserTrustLevel(ctx, userID, level)
+}
+
+func (c *InstrumentedClient) CreateRoleInvitation(ctx context.Context, inv *models.RoleInvitation) (err error) {
+	ctx, done := c.call(ctx, "CreateRoleInvitation")
+	defer done(&err)
+	return c.next.CreateRoleInvitation(ctx, inv)
+}
+
+func (c *InstrumentedClient) GetRoleInvitation(ctx context.Context, id string) (result *models.RoleInvitation, err error) {
+	ctx, done := c.call(ctx, "GetRoleInvitation")
+	defer done(&err)
+	return c.next.GetRoleInvitation(ctx, id)
+}
+
+func (c *InstrumentedClient) ListPendingRoleInvitations(ctx context.Context) (result []models.RoleInvitation, err error) {
+	ctx, done := c.call(ctx, "ListPendingRoleInvitations")
+	defer done(&err)
+	return c.next.ListPendingRoleInvitations(ctx)
+}
+
+func (c *InstrumentedClient) AcceptRoleInvitation(ctx context.Context, id, userID string) (err error) {
+	ctx, done := c.call(ctx, "AcceptRoleInvitation")
+	defer done(&err)
+	return c.next.AcceptRoleInvitation(ctx, id, userID)
+}
+
+func (c *InstrumentedClient) CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) (err error) {
+	ctx, done := c.call(ctx, "CreateAuditEntry")
+	defer done(&err)
+	return c.next.CreateAuditEntry(ctx, entry)
+}
+
+func (c *InstrumentedClient) CreateReportReview(ctx context.Context, review *models.ReportReview) (err error) {
+	ctx, done := c.call(ctx, "CreateReportReview")
+	defer done(&err)
+	return c.next.CreateReportReview(ctx, review)
+}
+
+func (c *InstrumentedClient) ListReportReviews(ctx context.Context, reportID string) (result []models.ReportReview, err error) {
+	ctx, done := c.call(ctx, "ListReportReviews")
+	defer done(&err)
+	return c.next.ListReportReviews(ctx, reportID)
+}
+
+func (c *InstrumentedClient) ListAuditEntries(ctx context.Context, limit int) (result []models.AuditEntry, err error) {
+	ctx, done := c.call(ctx, "ListAuditEntries")
+	defer done(&err)
+	return c.next.ListAuditEntries(ctx, limit)
+}
+
+func (c *InstrumentedClient) CreateResearchToken(ctx context.Context, token *models.ResearchToken) (err error) {
+	ctx, done := c.call(ctx, "CreateResearchToken")
+	defer done(&err)
+	return c.next.CreateResearchToken(ctx, token)
+}
+
+func (c *InstrumentedClient) GetResearchTokenByHash(ctx context.Context, hash string) (result *models.ResearchToken, err error) {
+	ctx, done := c.call(ctx, "GetResearchTokenByHash")
+	defer done(&err)
+	return c.next.GetResearchTokenByHash(ctx, hash)
+}
+
+func (c *InstrumentedClient) ListResearchTokens(ctx context.Context) (result []models.ResearchToken, err error) {
+	ctx, done := c.call(ctx, "ListResearchTokens")
+	defer done(&err)
+	return c.next.ListResearchTokens(ctx)
+}
+
+func (c *InstrumentedClient) RevokeResearchToken(ctx context.Context, id string) (err error) {
+	ctx, done := c.call(ctx, "RevokeResearchToken")
+	defer done(&err)
+	return c.next.RevokeResearchToken(ctx, id)
+}
+
+func (c *InstrumentedClient) UseResearchToken(ctx context.Context, tokenID, day string, quota int) (result bool, err error) {
+	ctx, done := c.call(ctx, "UseResearchToken")
+	defer done(&err)
+	return c.next.UseResearchToken(ctx, tokenID, day, quota)
+}
+
+func (c *InstrumentedClient) ListResearchTokenUsage(ctx context.Context, tokenID, since string) (result []models.ResearchTokenUsage, err error) {
+	ctx, done := c.call(ctx, "ListResearchTokenUsage")
+	defer done(&err)
+	return c.next.ListResearchTokenUsage(ctx, tokenID, since)
+}
+
+func (c *InstrumentedClient) AddAPIUsage(ctx context.Context, usage []models.APIUsage) (err error) {
+	ctx, done := c.call(ctx, "AddAPIUsage")
+	defer done(&err)
+	return c.next.AddAPIUsage(ctx, usage)
+}
+
+func (c *InstrumentedClient) ListAPIUsage(ctx context.Context, since string) (result []models.APIUsage, err error) {
+	ctx, done := c.call(ctx, "ListAPIUsage")
+	defer done(&err)
+	return c.next.ListAPIUsage(ctx, since)
+}
+
+func (c *InstrumentedClient) SetUserShadowBanned(ctx context.Context, userID string, banned bool) (err error) {
+	ctx, done := c.call(ctx, "SetUserShadowBanned")
+	defer done(&err)
+	return c.next.SetUserShadowBanned(ctx, userID, banned)
+}
+
+func (c *InstrumentedClient) DeactivateUser(ctx context.Context, userID string, reason models.DeactivationReason) (err error) {
+	ctx, done := c.call(ctx, "DeactivateUser")
+	defer done(&err)
+	return c.next.DeactivateUser(ctx, userID, reason)
+}
+
+func (c *InstrumentedClient) ReactivateUser(ctx context.Context, userID string) (err error) {
+	ctx, done := c.call(ctx, "ReactivateUser")
+	defer done(&err)
+	return c.next.ReactivateUser(ctx, userID)
+}
+
+func (c *InstrumentedClient) CreateReportTemplate(ctx context.Context, t *models.ReportTemplate) (err error) {
+	ctx, done := c.call(ctx, "CreateReportTemplate")
+	defer done(&err)
+	return c.next.CreateReportTemplate(ctx, t)
+}
+
+func (c *InstrumentedClient) ListReportTemplates(ctx context.Context, userID string) (result []models.ReportTemplate, err error) {
+	ctx, done := c.call(ctx, "ListReportTemplates")
+	defer done(&err)
+	return c.next.ListReportTemplates(ctx, userID)
+}
+
+func (c *InstrumentedClient) GetReportTemplate(ctx context.Context, templateID, userID string) (result *models.ReportTemplate, err error) {
+	ctx, done := c.call(ctx, "GetReportTemplate")
+	defer done(&err)
+	return c.next.GetReportTemplate(ctx, templateID, userID)
+}
+
+func (c *InstrumentedClient) UpdateReportTemplate(ctx context.Context, t *models.ReportTemplate) (err error) {
+	ctx, done := c.call(ctx, "UpdateReportTemplate")
+	defer done(&err)
+	return c.next.UpdateReportTemplate(ctx, t)
+}
+
+func (c *InstrumentedClient) DeleteReportTemplate(ctx context.Context, templateID, userID string) (err error) {
+	ctx, done := c.call(ctx, "DeleteReportTemplate")
+	defer done(&err)
+	return c.next.DeleteReportTemplate(ctx, templateID, userID)
+}
+
+func (c *InstrumentedClient) CreateReportDraft(ctx context.Context, d *models.ReportDraft) (err error) {
+	ctx, done := c.call(ctx, "CreateReportDraft")
+	defer done(&err)
+	return c.next.CreateReportDraft(ctx, d)
+}
+
+func (c *InstrumentedClient) ListReportDrafts(ctx context.Context, userID string) (result []models.ReportDraft, err error) {
+	ctx, done := c.call(ctx, "ListReportDrafts")
+	defer done(&err)
+	return c.next.ListReportDrafts(ctx, userID)
+}
+
+func (c *InstrumentedClient) GetReportDraft(ctx context.Context, draftID, userID string) (result *models.ReportDraft, err error) {
+	ctx, done := c.call(ctx, "GetReportDraft")
+	defer done(&err)
+	return c.next.GetReportDraft(ctx, draftID, userID)
+}
+
+func (c *InstrumentedClient) UpdateReportDraft(ctx context.Context, d *models.ReportDraft) (err error) {
+	ctx, done := c.call(ctx, "UpdateReportDraft")
+	defer done(&err)
+	return c.next.UpdateReportDraft(ctx, d)
+}
+
+func (c *InstrumentedClient) DeleteReportDraft(ctx context.Context, draftID, userID string) (err error) {
+	ctx, done := c.call(ctx, "DeleteReportDraft")
+	defer done(&err)
+	return c.next.DeleteReportDraft(ctx, draftID, userID)
+}
+
+func (c *InstrumentedClient) ListDraftsToRemind(ctx context.Context, updatedBefore time.Time) (result []models.ReportDraft, err error) {
+	ctx, done := c.call(ctx, "ListDraftsToRemind")
+	defer done(&err)
+	return c.next.ListDraftsToRemind(ctx, updatedBefore)
+}
+
+func (c *InstrumentedClient) MarkDraftReminded(ctx context.Context, draftID string, at time.Time) (err error) {
+	ctx, done := c.call(ctx, "MarkDraftReminded")
+	defer done(&err)
+	return c.next.MarkDraftReminded(ctx, draftID, at)
+}
+
+func (c *InstrumentedClient) BlockUser(ctx context.Context, blockerID, blockedID string) (err error) {
+	ctx, done := c.call(ctx, "BlockUser")
+	defer done(&err)
+	return c.next.BlockUser(ctx, blockerID, blockedID)
+}
+
+func (c *InstrumentedClient) UnblockUser(ctx context.Context, blockerID, blockedID string) (err error) {
+	ctx, done := c.call(ctx, "UnblockUser")
+	defer done(&err)
+	return c.next.UnblockUser(ctx, blockerID, blockedID)
+}
+
+func (c *InstrumentedClient) ListBlockedUsers(ctx context.Context, blockerID string) (result []models.UserBlock, err error) {
+	ctx, done := c.call(ctx, "ListBlockedUsers")
+	defer done(&err)
+	return c.next.ListBlockedUsers(ctx, blockerID)
+}
+
+func (c *InstrumentedClient) IsUserBlocked(ctx context.Context, blockerID, blockedID string) (result bool, err error) {
+	ctx, done := c.call(ctx, "IsUserBlocked")
+	defer done(&err)
+	return c.next.IsUserBlocked(ctx, blockerID, blockedID)
+}
+
+func (c *InstrumentedClient) ExportUsers(ctx context.Context, fn func(models.UserExportRow) error) (err error) {
+	ctx, done := c.call(ctx, "ExportUsers")
+	defer done(&err)
+	return c.next.ExportUsers(ctx, fn)
+}
+
+func (c *InstrumentedClient) ExportReports(ctx context.Context, opts models.ReportExportOptions, fn func(models.ReportExportRow) error) (err error) {
+	ctx, done := c.call(ctx, "ExportReports")
+	defer done(&err)
+	return c.next.ExportReports(ctx, opts, fn)
+}
+
+func (c *InstrumentedClient) UpdateNotificationPreferences(ctx context.Context, userID string, prefs models.NotificationPreferences) (err error) {
+	ctx, done := c.call(ctx, "UpdateNotificationPreferences")
+	defer done(&err)
+	return c.next.UpdateNotificationPreferences(ctx, userID, prefs)
+}
+
+func (c *InstrumentedClient) CreateNotification(ctx context.Context, n *models.Notification) (err error) {
+	ctx, done := c.call(ctx, "CreateNotification")
+	defer done(&err)
+	return c.next.CreateNotification(ctx, n)
+}
+
+func (c *InstrumentedClient) ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int) (result []models.Notification, err error) {
+	ctx, done := c.call(ctx, "ListNotifications")
+	defer done(&err)
+	return c.next.ListNotifications(ctx, userID, unreadOnly, limit)
+}
+
+func (c *InstrumentedClient) CountUnreadNotifications(ctx context.Context, userID string) (result int, err error) {
+	ctx, done := c.call(ctx, "CountUnreadNotifications")
+	defer done(&err)
+	return c.next.CountUnreadNotifications(ctx, userID)
+}
+
+func (c *InstrumentedClient) MarkNotificationRead(ctx context.Context, userID, notificationID string) (err error) {
+	ctx, done := c.call(ctx, "MarkNotificationRead")
+	defer done(&err)
+	return c.next.MarkNotificationRead(ctx, userID, notificationID)
+}
+
+func (c *InstrumentedClient) MarkAllNotificationsRead(ctx context.Context, userID string) (result int, err error) {
+	ctx, done := c.call(ctx, "MarkAllNotificationsRead")
+	defer done(&err)
+	return c.next.MarkAllNotificationsRead(ctx, userID)
+}
+
+func (c *InstrumentedClient) ListDigestRecipients(ctx context.Context, sentBefore time.Time) (result []models.User, err error) {
+	ctx, done := c.call(ctx, "ListDigestRecipients")
+	defer done(&err)
+	return c.next.ListDigestRecipients(ctx, sentBefore)
+}
+
+func (c *InstrumentedClient) MarkDigestSent(ctx context.Context, userID string, at time.Time) (err error) {
+	ctx, done := c.call(ctx, "MarkDigestSent")
+	defer done(&err)
+	return c.next.MarkDigestSent(ctx, userID, at)
+}
+
+func (c *InstrumentedClient) AddReaction(ctx context.Context, reaction *models.Reaction) (err error) {
+	ctx, done := c.call(ctx, "AddReaction")
+	defer done(&err)
+	return c.next.AddReaction(ctx, reaction)
+}
+
+func (c *InstrumentedClient) RemoveReaction(ctx context.Context, reportID, userID, reactionType string) (err error) {
+	ctx, done := c.call(ctx, "RemoveReaction")
+	defer done(&err)
+	return c.next.RemoveReaction(ctx, reportID, userID, reactionType)
+}
+
+func (c *InstrumentedClient) GetUserReactionType(ctx context.Context, reportID, userID string) (result string, err error) {
+	ctx, done := c.call(ctx, "GetUserReactionType")
+	defer done(&err)
+	return c.next.GetUserReactionType(ctx, reportID, userID)
+}
+
+func (c *InstrumentedClient) ToggleReaction(ctx context.Context, reaction *models.Reaction) (previousType string, added bool, err error) {
+	ctx, done := c.call(ctx, "ToggleReaction")
+	defer done(&err)
+	return c.next.ToggleReaction(ctx, reaction)
+}
+
+func (c *InstrumentedClient) GetReactionCounts(ctx context.Context, reportID string) (result []models.ReactionCount, err error) {
+	ctx, done := c.call(ctx, "GetReactionCounts")
+	defer done(&err)
+	return c.next.GetReactionCounts(ctx, reportID)
+}
+
+func (c *InstrumentedClient) GetUserReactions(ctx context.Context, reportID, userID string) (result []string, err error) {
+	ctx, done := c.call(ctx, "GetUserReactions")
+	defer done(&err)
+	return c.next.GetUserReactions(ctx, reportID, userID)
+}
+
+func (c *InstrumentedClient) GetReportEngagement(ctx context.Context, reportID, userID string) (result *models.ReportEngagement, err error) {
+	ctx, done := c.call(ctx, "GetReportEngagement")
+	defer done(&err)
+	return c.next.GetReportEngagement(ctx, reportID, userID)
+}
+
+func (c *InstrumentedClient) GetBulkReportEngagement(ctx context.Context, reportIDs []string, userID string) (result map[string]*models.ReportEngagement, err error) {
+	ctx, done := c.call(ctx, "GetBulkReportEngagement")
+	defer done(&err)
+	return c.next.GetBulkReportEngagement(ctx, reportIDs, userID)
+}
+
+func (c *InstrumentedClient) AddComment(ctx context.Context, comment *models.Comment) (err error) {
+	ctx, done := c.call(ctx, "AddComment")
+	defer done(&err)
+	return c.next.AddComment(ctx, comment)
+}
+
+func (c *InstrumentedClient) GetComments(ctx context.Context, reportID, viewerID string) (result []models.Comment, err error) {
+	ctx, done := c.call(ctx, "GetComments")
+	defer done(&err)
+	return c.next.GetComments(ctx, reportID, viewerID)
+}
+
+func (c *InstrumentedClient) DeleteComment(ctx context.Context, commentID, userID string) (err error) {
+	ctx, done := c.call(ctx, "DeleteComment")
+	defer done(&err)
+	return c.next.DeleteComment(ctx, commentID, userID)
+}
+
+func (c *InstrumentedClient) GetCommentByID(ctx context.Context, commentID string) (result *models.Comment, err error) {
+	ctx, done := c.call(ctx, "GetCommentByID")
+	defer done(&err)
+	return c.next.GetCommentByID(ctx, commentID)
+}
+
+func (c *InstrumentedClient) ListUserComments(ctx context.Context, userID string, limit int) (result []models.CommentActivity, err error) {
+	ctx, done := c.call(ctx, "ListUserComments")
+	defer done(&err)
+	return c.next.ListUserComments(ctx, userID, limit)
+}
+
+func (c *InstrumentedClient) ListUserReactions(ctx context.Context, userID string, limit int) (result []models.ReactionActivity, err error) {
+	ctx, done := c.call(ctx, "ListUserReactions")
+	defer done(&err)
+	return c.next.ListUserReactions(ctx, userID, limit)
+}
+
+func (c *InstrumentedClient) AdjustReportPriority(ctx context.Context, reportID string, delta int) (err error) {
+	ctx, done := c.call(ctx, "AdjustReportPriority")
+	defer done(&err)
+	return c.next.AdjustReportPriority(ctx, reportID, delta)
+}
+
+func (c *InstrumentedClient) ChangeReportPriority(ctx context.Context, reportID string, change models.PriorityChange) (result int, err error) {
+	ctx, done := c.call(ctx, "ChangeReportPriority")
+	defer done(&err)
+	return c.next.ChangeReportPriority(ctx, reportID, change)
+}
+
+func (c *InstrumentedClient) DecayReportPriorities(ctx context.Context, percent int) (result []string, err error) {
+	ctx, done := c.call(ctx, "DecayReportPriorities")
+	defer done(&err)
+	return c.next.DecayReportPriorities(ctx, percent)
+}
+
+func (c *InstrumentedClient) RefreshHotScores(ctx context.Context) (err error) {
+	ctx, done := c.call(ctx, "RefreshHotScores")
+	defer done(&err)
+	return c.next.RefreshHotScores(ctx)
+}
+
+func (c *InstrumentedClient) ListMediaForTranscription(ctx context.Context, limit int) (result []models.TranscriptJob, err error) {
+	ctx, done := c.call(ctx, "ListMediaForTranscription")
+	defer done(&err)
+	return c.next.ListMediaForTranscription(ctx, limit)
+}
+
+func (c *InstrumentedClient) UpdateMediaTranscript(ctx context.Context, reportID, mediaID, status, transcript, operation string) (err error) {
+	ctx, done := c.call(ctx, "UpdateMediaTranscript")
+	defer done(&err)
+	return c.next.UpdateMediaTranscript(ctx, reportID, mediaID, status, transcript, operation)
+}
+
+func (c *InstrumentedClient) ListMediaForOCR(ctx context.Context, limit int) (result []models.OCRJob, err error) {
+	ctx, done := c.call(ctx, "ListMediaForOCR")
+	defer done(&err)
+	return c.next.ListMediaForOCR(ctx, limit)
+}
+
+func (c *InstrumentedClient) SetMediaOCR(ctx context.Context, reportID, mediaID string, ocr *models.MediaOCR) (err error) {
+	ctx, done := c.call(ctx, "SetMediaOCR")
+	defer done(&err)
+	return c.next.SetMediaOCR(ctx, reportID, mediaID, ocr)
+}
+
+func (c *InstrumentedClient) ListReportsForMapImage(ctx context.Context, retryBefore time.Time, limit int) (result []models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "ListReportsForMapImage")
+	defer done(&err)
+	return c.next.ListReportsForMapImage(ctx, retryBefore, limit)
+}
+
+func (c *InstrumentedClient) SetReportMapImage(ctx context.Context, reportID, objectPath string) (err error) {
+	ctx, done := c.call(ctx, "SetReportMapImage")
+	defer done(&err)
+	return c.next.SetReportMapImage(ctx, reportID, objectPath)
+}
+
+func (c *InstrumentedClient) ListPendingMedia(ctx context.Context, limit int) (result []models.MediaStateJob, err error) {
+	ctx, done := c.call(ctx, "ListPendingMedia")
+	defer done(&err)
+	return c.next.ListPendingMedia(ctx, limit)
+}
+
+func (c *InstrumentedClient) SetMediaState(ctx context.Context, reportID, mediaID, from, to string) (err error) {
+	ctx, done := c.call(ctx, "SetMediaState")
+	defer done(&err)
+	return c.next.SetMediaState(ctx, reportID, mediaID, from, to)
+}
+
+func (c *InstrumentedClient) SearchReports(ctx context.Context, query string, limit int) (result []models.ReportSearchHit, err error) {
+	ctx, done := c.call(ctx, "SearchReports")
+	defer done(&err)
+	return c.next.SearchReports(ctx, query, limit)
+}
+
+func (c *InstrumentedClient) SearchUsers(ctx context.Context, query string, limit int) (result []models.User, err error) {
+	ctx, done := c.call(ctx, "SearchUsers")
+	defer done(&err)
+	return c.next.SearchUsers(ctx, query, limit)
+}
+
+func (c *InstrumentedClient) SetReportPriorityFrozen(ctx context.Context, reportID string, frozen bool) (err error) {
+	ctx, done := c.call(ctx, "SetReportPriorityFrozen")
+	defer done(&err)
+	return c.next.SetReportPriorityFrozen(ctx, reportID, frozen)
+}
+
+func (c *InstrumentedClient) ListReportsByVehicleHashes(ctx context.Context, hashes []string, excludeReportID string) (result []models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "ListReportsByVehicleHashes")
+	defer done(&err)
+	return c.next.ListReportsByVehicleHashes(ctx, hashes, excludeReportID)
+}
+
+func (c *InstrumentedClient) LinkReportsToIncident(ctx context.Context, incidentID string, reportIDs []string, note, linkedBy string) (result *models.Incident, err error) {
+	ctx, done := c.call(ctx, "LinkReportsToIncident")
+	defer done(&err)
+	return c.next.LinkReportsToIncident(ctx, incidentID, reportIDs, note, linkedBy)
+}
+
+func (c *InstrumentedClient) GetIncident(ctx context.Context, incidentID string) (result *models.Incident, err error) {
+	ctx, done := c.call(ctx, "GetIncident")
+	defer done(&err)
+	return c.next.GetIncident(ctx, incidentID)
+}
+
+func (c *InstrumentedClient) MergeReports(ctx context.Context, canonicalID, duplicateID, mergedBy string) (err error) {
+	ctx, done := c.call(ctx, "MergeReports")
+	defer done(&err)
+	return c.next.MergeReports(ctx, canonicalID, duplicateID, mergedBy)
+}
+
+func (c *InstrumentedClient) GetReportRedirect(ctx context.Context, reportID string) (result string, err error) {
+	ctx, done := c.call(ctx, "GetReportRedirect")
+	defer done(&err)
+	return c.next.GetReportRedirect(ctx, reportID)
+}
+
+func (c *InstrumentedClient) SetReportPinned(ctx context.Context, reportID string, pinned bool) (err error) {
+	ctx, done := c.call(ctx, "SetReportPinned")
+	defer done(&err)
+	return c.next.SetReportPinned(ctx, reportID, pinned)
+}
+
+func (c *InstrumentedClient) SetReportPublishAt(ctx context.Context, reportID string, publishAt *time.Time) (err error) {
+	ctx, done := c.call(ctx, "SetReportPublishAt")
+	defer done(&err)
+	return c.next.SetReportPublishAt(ctx, reportID, publishAt)
+}
+
+func (c *InstrumentedClient) NextScheduledPublication(ctx context.Context) (result *time.Time, err error) {
+	ctx, done := c.call(ctx, "NextScheduledPublication")
+	defer done(&err)
+	return c.next.NextScheduledPublication(ctx)
+}
+
+func (c *InstrumentedClient) ArchiveReportsCreatedBefore(ctx context.Context, cutoff time.Time) (result []string, err error) {
+	ctx, done := c.call(ctx, "ArchiveReportsCreatedBefore")
+	defer done(&err)
+	return c.next.ArchiveReportsCreatedBefore(ctx, cutoff)
+}
+
+func (c *InstrumentedClient) ListArchivedReports(ctx context.Context) (result []models.TrafficReport, err error) {
+	ctx, done := c.call(ctx, "ListArchivedReports")
+	defer done(&err)
+	return c.next.ListArchivedReports(ctx)
+}
+
+func (c *InstrumentedClient) EnsureMediaPartitions(ctx context.Context, through time.Time) (result []string, err error) {
+	ctx, done := c.call(ctx, "EnsureMediaPartitions")
+	defer done(&err)
+	return c.next.EnsureMediaPartitions(ctx, through)
+}
+
+func (c *InstrumentedClient) ArchiveMedia(ctx context.Context, limit int) (result int, err error) {
+	ctx, done := c.call(ctx, "ArchiveMedia")
+	defer done(&err)
+	return c.next.ArchiveMedia(ctx, limit)
+}
+
+func (c *InstrumentedClient) ListApprovedReportLocations(ctx context.Context, box models.BoundingBox) (result []models.ReportLocation, err error) {
+	ctx, done := c.call(ctx, "ListApprovedReportLocations")
+	defer done(&err)
+	return c.next.ListApprovedReportLocations(ctx, box)
+}
+
+func (c *InstrumentedClient) GetReportStats(ctx context.Context, since time.Time) (result *models.ReportStats, err error) {
+	ctx, done := c.call(ctx, "GetReportStats")
+	defer done(&err)
+	return c.next.GetReportStats(ctx, since)
+}
+
+func (c *InstrumentedClient) RefreshReportStats(ctx context.Context) (err error) {
+	ctx, done := c.call(ctx, "RefreshReportStats")
+	defer done(&err)
+	return c.next.RefreshReportStats(ctx)
+}
+
+func (c *InstrumentedClient) ListBlockedWords(ctx context.Context) (result []models.BlockedWord, err error) {
+	ctx, done := c.call(ctx, "ListBlockedWords")
+	defer done(&err)
+	return c.next.ListBlockedWords(ctx)
+}
+
+func (c *InstrumentedClient) UpsertBlockedWord(ctx context.Context, word *models.BlockedWord) (err error) {
+	ctx, done := c.call(ctx, "UpsertBlockedWord")
+	defer done(&err)
+	return c.next.UpsertBlockedWord(ctx, word)
+}
+
+func (c *InstrumentedClient) DeleteBlockedWord(ctx context.Context, word string) (err error) {
+	ctx, done := c.call(ctx, "DeleteBlockedWord")
+	defer done(&err)
+	return c.next.DeleteBlockedWord(ctx, word)
+}
+
+func (c *InstrumentedClient) ListFlaggedComments(ctx context.Context, includeShadowBanned bool) (result []models.Comment, err error) {
+	ctx, done := c.call(ctx, "ListFlaggedComments")
+	defer done(&err)
+	return c.next.ListFlaggedComments(ctx, includeShadowBanned)
+}
+
+func (c *InstrumentedClient) ResolveFlaggedComment(ctx context.Context, commentID string, approve bool) (err error) {
+	ctx, done := c.call(ctx, "ResolveFlaggedComment")
+	defer done(&err)
+	return c.next.ResolveFlaggedComment(ctx, commentID, approve)
+}
+
+func (c *InstrumentedClient) CountIdenticalComments(ctx context.Context, userID, content string, since time.Time) (result int, err error) {
+	ctx, done := c.call(ctx, "CountIdenticalComments")
+	defer done(&err)
+	return c.next.CountIdenticalComments(ctx, userID, content, since)
+}
+
+func (c *InstrumentedClient) GetCommentPolicy(ctx context.Context) (result *models.CommentPolicy, err error) {
+	ctx, done := c.call(ctx, "GetCommentPolicy")
+	defer done(&err)
+	return c.next.GetCommentPolicy(ctx)
+}
+
+func (c *InstrumentedClient) SaveCommentPolicy(ctx context.Context, policy *models.CommentPolicy) (err error) {
+	ctx, done := c.call(ctx, "SaveCommentPolicy")
+	defer done(&err)
+	return c.next.SaveCommentPolicy(ctx, policy)
+}
+
+func (c *InstrumentedClient) CreateAnnouncement(ctx context.Context, announcement *models.Announcement) (err error) {
+	ctx, done := c.call(ctx, "CreateAnnouncement")
+	defer done(&err)
+	return c.next.CreateAnnouncement(ctx, announcement)
+}
+
+func (c *InstrumentedClient) UpdateAnnouncement(ctx context.Context, announcement *models.Announcement) (err error) {
+	ctx, done := c.call(ctx, "UpdateAnnouncement")
+	defer done(&err)
+	return c.next.UpdateAnnouncement(ctx, announcement)
+}
+
+func (c *InstrumentedClient) DeleteAnnouncement(ctx context.Context, announcementID string) (err error) {
+	ctx, done := c.call(ctx, "DeleteAnnouncement")
+	defer done(&err)
+	return c.next.DeleteAnnouncement(ctx, announcementID)
+}
+
+func (c *InstrumentedClient) ListAnnouncements(ctx context.Context) (result []models.Announcement, err error) {
+	ctx, done := c.call(ctx, "ListAnnouncements")
+	defer done(&err)
+	return c.next.ListAnnouncements(ctx)
+}
+
+func (c *InstrumentedClient) ListActiveAnnouncements(ctx context.Context, at time.Time) (result []models.Announcement, err error) {
+	ctx, done := c.call(ctx, "ListActiveAnnouncements")
+	defer done(&err)
+	return c.next.ListActiveAnnouncements(ctx, at)
+}
+
+func (c *InstrumentedClient) ListFeatureFlags(ctx context.Context) (result []models.FeatureFlag, err error) {
+	ctx, done := c.call(ctx, "ListFeatureFlags")
+	defer done(&err)
+	return c.next.ListFeatureFlags(ctx)
+}
+
+func (c *InstrumentedClient) UpsertFeatureFlag(ctx context.Context, flag *models.FeatureFlag) (err error) {
+	ctx, done := c.call(ctx, "UpsertFeatureFlag")
+	defer done(&err)
+	return c.next.UpsertFeatureFlag(ctx, flag)
+}
+
+func (c *InstrumentedClient) DeleteFeatureFlag(ctx context.Context, key string) (err error) {
+	ctx, done := c.call(ctx, "DeleteFeatureFlag")
+	defer done(&err)
+	return c.next.DeleteFeatureFlag(ctx, key)
+}
+
+func (c *InstrumentedClient) ListReactionTypes(ctx context.Context) (result []models.ReactionType, err error) {
+	ctx, done := c.call(ctx, "ListReactionTypes")
+	defer done(&err)
+	return c.next.ListReactionTypes(ctx)
+}
+
+func (c *InstrumentedClient) UpsertReactionType(ctx context.Context, reactionType *models.ReactionType) (err error) {
+	ctx, done := c.call(ctx, "UpsertReactionType")
+	defer done(&err)
+	return c.next.UpsertReactionType(ctx, reactionType)
+}
+
+func (c *InstrumentedClient) RetireReactionType(ctx context.Context, reactionType, retiredBy string) (err error) {
+	ctx, done := c.call(ctx, "RetireReactionType")
+	defer done(&err)
+	return c.next.RetireReactionType(ctx, reactionType, retiredBy)
+}
+
+func (c *InstrumentedClient) RecordMediaFailure(ctx context.Context, failure *models.MediaFailure) (err error) {
+	ctx, done := c.call(ctx, "RecordMediaFailure")
+	defer done(&err)
+	return c.next.RecordMediaFailure(ctx, failure)
+}
+
+func (c *InstrumentedClient) ListMediaFailures(ctx context.Context, includeResolved bool) (result []models.MediaFailure, err error) {
+	ctx, done := c.call(ctx, "ListMediaFailures")
+	defer done(&err)
+	return c.next.ListMediaFailures(ctx, includeResolved)
+}
+
+func (c *InstrumentedClient) GetMediaFailure(ctx context.Context, id string) (result *models.MediaFailure, err error) {
+	ctx, done := c.call(ctx, "GetMediaFailure")
+	defer done(&err)
+	return c.next.GetMediaFailure(ctx, id)
+}
+
+func (c *InstrumentedClient) RecordMediaRetry(ctx context.Context, id string, retryErr error) (err error) {
+	ctx, done := c.call(ctx, "RecordMediaRetry")
+	defer done(&err)
+	return c.next.RecordMediaRetry(ctx, id, retryErr)
+}
+
+func (c *InstrumentedClient) MoveMediaFile(ctx context.Context, reportID, mediaID, newID, url string) (err error) {
+	ctx, done := c.call(ctx, "MoveMediaFile")
+	defer done(&err)
+	return c.next.MoveMediaFile(ctx, reportID, mediaID, newID, url)
+}
+
+func (c *InstrumentedClient) EnqueueOutboxEvent(ctx context.Context, event *models.OutboxEvent) (err error) {
+	ctx, done := c.call(ctx, "EnqueueOutboxEvent")
+	defer done(&err)
+	return c.next.EnqueueOutboxEvent(ctx, event)
+}
+
+func (c *InstrumentedClient) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) (result []models.OutboxEvent, err error) {
+	ctx, done := c.call(ctx, "ClaimOutboxEvents")
+	defer done(&err)
+	return c.next.ClaimOutboxEvents(ctx, limit, lease)
+}
+
+func (c *InstrumentedClient) MarkOutboxEventDelivered(ctx context.Context, id string) (err error) {
+	ctx, done := c.call(ctx, "MarkOutboxEventDelivered")
+	defer done(&err)
+	return c.next.MarkOutboxEventDelivered(ctx, id)
+}
+
+func (c *InstrumentedClient) MarkOutboxEventFailed(ctx context.Context, id, lastError string, retryAt *time.Time) (err error) {
+	ctx, done := c.call(ctx, "MarkOutboxEventFailed")
+	defer done(&err)
+	return c.next.MarkOutboxEventFailed(ctx, id, lastError, retryAt)
+}
+
+func (c *InstrumentedClient) PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (result int, err error) {
+	ctx, done := c.call(ctx, "PurgeDeliveredOutboxEvents")
+	defer done(&err)
+	return c.next.PurgeDeliveredOutboxEvents(ctx, before)
+}
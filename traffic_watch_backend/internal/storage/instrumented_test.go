@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/deadline"
+	"donzhit_me_backend/internal/metrics"
+	"donzhit_me_backend/internal/models"
+)
+
+// fakeClient implements the methods exercised below; any other call panics
+type fakeClient struct {
+	Client
+	delay time.Duration
+	err   error
+}
+
+func (f *fakeClient) GetReport(ctx context.Context, reportID string) (*models.TrafficReport, error) {
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.TrafficReport{ID: reportID}, nil
+}
+
+func TestInstrumentedClient_RecordsCalls(t *testing.T) {
+	registry := metrics.NewRegistry()
+	fake := &fakeClient{}
+	client := NewInstrumentedClient(fake, registry, time.Millisecond)
+
+	report, err := client.GetReport(context.Background(), "abc")
+	if err != nil || report.ID != "abc" {
+		t.Fatalf("expected passthrough result, got %v, %v", report, err)
+	}
+
+	fake.err = errors.New("report not found")
+	fake.delay = 2 * time.Millisecond
+	if _, err := client.GetReport(context.Background(), "missing"); err == nil || err.Error() != "report not found" {
+		t.Fatalf("expected passthrough error, got %v", err)
+	}
+
+	snap := registry.Snapshot()
+	if len(snap) != 1 || snap[0].Name != "GetReport" {
+		t.Fatalf("expected GetReport stats, got %+v", snap)
+	}
+	if snap[0].Count != 2 || snap[0].Errors != 1 {
+		t.Errorf("expected 2 calls and 1 error, got %d and %d", snap[0].Count, snap[0].Errors)
+	}
+	if snap[0].MaxMs < 2 {
+		t.Errorf("expected max duration of at least 2ms, got %v", snap[0].MaxMs)
+	}
+}
+
+func TestInstrumentedClient_Timeouts(t *testing.T) {
+	registry := metrics.NewRegistry()
+	fake := &fakeClient{delay: time.Second}
+	client := NewInstrumentedClient(fake, registry, 0)
+	client.SetTimeouts(Timeouts{Default: 5 * time.Millisecond})
+
+	ctx := deadline.Track(context.Background())
+	_, err := client.GetReport(ctx, "slow")
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrTimeout wrapping DeadlineExceeded, got %v", err)
+	}
+	if !deadline.Exceeded(ctx) {
+		t.Error("timeout not recorded on the request context")
+	}
+	if snap := registry.Snapshot(); len(snap) != 1 || snap[0].Errors != 1 || snap[0].Timeouts != 1 {
+		t.Errorf("expected 1 error counted as a timeout, got %+v", snap)
+	}
+
+	// The caller's own deadline expiring first is not a storage timeout
+	callerCtx, cancel := context.WithTimeout(deadline.Track(context.Background()), time.Millisecond)
+	defer cancel()
+	client.SetTimeouts(Timeouts{Default: time.Minute})
+	if _, err := client.GetReport(callerCtx, "slow"); errors.Is(err, ErrTimeout) || deadline.Exceeded(callerCtx) {
+		t.Errorf("caller deadline reported as a storage timeout: %v", err)
+	}
+
+	// Per-method overrides win, and zero disables the timeout
+	client.SetTimeouts(Timeouts{Default: time.Millisecond, PerOperation: map[string]time.Duration{"GetReport": 0}})
+	fake.delay = 5 * time.Millisecond
+	if _, err := client.GetReport(context.Background(), "abc"); err != nil {
+		t.Errorf("disabled timeout still applied: %v", err)
+	}
+}
+
+func TestParseOperationTimeouts(t *testing.T) {
+	timeouts := DefaultTimeouts()
+	if err := timeouts.ParseOperationTimeouts(" GetReport=2s, ExportReports=0 ,ListAllReports=1m"); err != nil {
+		t.Fatal(err)
+	}
+	if got := timeouts.For("GetReport"); got != 2*time.Second {
+		t.Errorf("GetReport = %s, want 2s", got)
+	}
+	if got := timeouts.For("ListAllReports"); got != time.Minute {
+		t.Errorf("ListAllReports = %s, want 1m", got)
+	}
+	if got := timeouts.For("ExportReports"); got != 0 {
+		t.Errorf("ExportReports = %s, want 0", got)
+	}
+	if got := timeouts.For("CreateReport"); got != DefaultOperationTimeout {
+		t.Errorf("CreateReport = %s, want the default", got)
+	}
+
+	for _, spec := range []string{"GetReport", "=2s", "GetReport=soon", "GetReport=-1s"} {
+		if err := timeouts.ParseOperationTimeouts(spec); err == nil {
+			t.Errorf("ParseOperationTimeouts(%q) succeeded", spec)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalBlobPath is the route LocalBlobStore's signed URLs point at
+const LocalBlobPath = "/dev/blobs/"
+
+// LocalBlobStore is a BlobStore that keeps files in a local directory, so the
+// upload flow works in development without a bucket or credentials. Its signed
+// URLs point at the server itself, which serves them through ServeHTTP; they
+// are signed with a per-process key and expire like GCS signed URLs.
+type LocalBlobStore struct {
+	dir     string
+	baseURL string // Server URL the signed URLs start with
+	key     []byte
+}
+
+// NewLocalBlobStore creates a blob store in dir, or in a new temporary
+// directory if dir is empty
+func NewLocalBlobStore(dir, baseURL string) (*LocalBlobStore, error) {
+	var err error
+	if dir == "" {
+		dir, err = os.MkdirTemp("", "donzhit-blobs-")
+	} else {
+		err = os.MkdirAll(dir, 0o755)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	return &LocalBlobStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/"), key: key}, nil
+}
+
+// Dir returns the directory files are stored in
+func (l *LocalBlobStore) Dir() string {
+	return l.dir
+}
+
+// UploadFile writes a file under the same object path GCS would use
+func (l *LocalBlobStore) UploadFile(ctx context.Context, userID, reportID, fileID string, contentType string, reader io.Reader) (string, error) {
+	objectPath := reportObjectPath(userID, reportID, fileID)
+	size, err := l.write(objectPath, reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+	log.Printf("Dev blob store: uploaded %s (%s, %d bytes)", objectPath, contentType, size)
+	return objectPath, nil
+}
+
+// GetSignedURL returns a URL that reads the file until it expires
+func (l *LocalBlobStore) GetSignedURL(ctx context.Context, objectPath string, expiration time.Duration) (string, error) {
+	if expiration == 0 {
+		expiration = MediaURLExpiration
+	}
+	return l.signedURL(http.MethodGet, objectPath, expiration), nil
+}
+
+// GetUploadSignedURL returns a URL a client can PUT the file to
+func (l *LocalBlobStore) GetUploadSignedURL(ctx context.Context, userID, reportID, fileID, contentType string) (string, string, error) {
+	objectPath := reportObjectPath(userID, reportID, fileID)
+	return l.signedURL(http.MethodPut, objectPath, UploadURLExpiration), objectPath, nil
+}
+
+// OpenFile opens a file for reading; the caller closes it
+func (l *LocalBlobStore) OpenFile(ctx context.Context, objectPath string) (io.ReadCloser, error) {
+	name, err := l.file(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return f, nil
+}
+
+// DeleteFile deletes a file; one that is already gone is not an error
+func (l *LocalBlobStore) DeleteFile(ctx context.Context, objectPath string) error {
+	name, err := l.file(objectPath)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	log.Printf("Dev blob store: deleted %s", objectPath)
+	return nil
+}
+
+// ObjectSize returns the size of a file; exists is false if there is no such file
+func (l *LocalBlobStore) ObjectSize(ctx context.Context, objectPath string) (int64, bool, error) {
+	name, err := l.file(objectPath)
+	if err != nil {
+		return 0, false, err
+	}
+	info, err := os.Stat(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get file attributes: %w", err)
+	}
+	return info.Size(), true, nil
+}
+
+// GetBucketName names the directory, as there is no bucket
+func (l *LocalBlobStore) GetBucketName() string {
+	return "local"
+}
+
+// ServeHTTP serves the signed URLs: GET reads a file and PUT stores one. It is
+// mounted at LocalBlobPath.
+func (l *LocalBlobStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	objectPath := strings.TrimPrefix(r.URL.Path, LocalBlobPath)
+	if r.Method != http.MethodGet && r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !l.validSignature(r.Method, objectPath, r.URL.Query()) {
+		http.Error(w, "invalid or expired signature", http.StatusForbidden)
+		return
+	}
+	name, err := l.file(objectPath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		http.ServeFile(w, r, name)
+		return
+	}
+	size, err := l.write(objectPath, r.Body)
+	if err != nil {
+		log.Printf("Dev blob store: upload of %s failed: %v", objectPath, err)
+		http.Error(w, "upload failed", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Dev blob store: received %s (%s, %d bytes)", objectPath, r.Header.Get("Content-Type"), size)
+	w.WriteHeader(http.StatusOK)
+}
+
+// write stores reader's content at objectPath, replacing any earlier file
+func (l *LocalBlobStore) write(objectPath string, reader io.Reader) (int64, error) {
+	name, err := l.file(objectPath)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return 0, err
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(f, reader)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return size, err
+}
+
+// file returns the local file for objectPath, rejecting paths that leave the directory
+func (l *LocalBlobStore) file(objectPath string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(objectPath)) {
+		return "", fmt.Errorf("invalid object path %q", objectPath)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(objectPath)), nil
+}
+
+func (l *LocalBlobStore) signedURL(method, objectPath string, expiration time.Duration) string {
+	expires := strconv.FormatInt(time.Now().Add(expiration).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {l.signature(method, objectPath, expires)}}
+	return l.baseURL + LocalBlobPath + objectPath + "?" + query.Encode()
+}
+
+func (l *LocalBlobStore) signature(method, objectPath, expires string) string {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write([]byte(method + "\n" + objectPath + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (l *LocalBlobStore) validSignature(method, objectPath string, query url.Values) bool {
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || time.Now().Unix() > expires {
+		return false
+	}
+	want := l.signature(method, objectPath, query.Get("expires"))
+	return hmac.Equal([]byte(want), []byte(query.Get("signature")))
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestLocalBlobStore_SignedUploadAndDownload(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalBlobStore(t.TempDir(), "http://localhost:8080/")
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore: %v", err)
+	}
+
+	uploadURL, objectPath, err := store.GetUploadSignedURL(ctx, "user-1", "report-1", "file-1", "image/jpeg")
+	if err != nil {
+		t.Fatalf("GetUploadSignedURL: %v", err)
+	}
+	if objectPath != "users/user-1/reports/report-1/file-1" || !strings.HasPrefix(uploadURL, "http://localhost:8080"+LocalBlobPath+objectPath+"?") {
+		t.Fatalf("upload URL %q for %q", uploadURL, objectPath)
+	}
+	if _, exists, _ := store.ObjectSize(ctx, objectPath); exists {
+		t.Fatal("nothing should exist before the upload")
+	}
+
+	serve := func(method, rawURL, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		store.ServeHTTP(w, httptest.NewRequest(method, rawURL, strings.NewReader(body)))
+		return w
+	}
+
+	// The upload URL only allows PUT, and only while its signature matches
+	if w := serve(http.MethodGet, uploadURL, ""); w.Code != http.StatusForbidden {
+		t.Errorf("GET with a PUT signature: expected 403, got %d", w.Code)
+	}
+	tampered, _ := url.Parse(uploadURL)
+	tampered.Path = LocalBlobPath + "users/user-2/reports/report-1/file-1"
+	if w := serve(http.MethodPut, tampered.String(), "x"); w.Code != http.StatusForbidden {
+		t.Errorf("PUT to another path: expected 403, got %d", w.Code)
+	}
+	if w := serve(http.MethodPut, uploadURL, "jpeg bytes"); w.Code != http.StatusOK {
+		t.Fatalf("PUT: expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if size, exists, err := store.ObjectSize(ctx, objectPath); err != nil || !exists || size != int64(len("jpeg bytes")) {
+		t.Errorf("ObjectSize = %d, %v, %v", size, exists, err)
+	}
+	reader, err := store.OpenFile(ctx, objectPath)
+	if err != nil {
+		t.Fatalf("OpenFile: %v", err)
+	}
+	content, _ := io.ReadAll(reader)
+	reader.Close()
+	if string(content) != "jpeg bytes" {
+		t.Errorf("OpenFile read %q", content)
+	}
+
+	downloadURL, err := store.GetSignedURL(ctx, objectPath, 0)
+	if err != nil {
+		t.Fatalf("GetSignedURL: %v", err)
+	}
+	if w := serve(http.MethodGet, downloadURL, ""); w.Code != http.StatusOK || w.Body.String() != "jpeg bytes" {
+		t.Errorf("GET: got %d %q", w.Code, w.Body.String())
+	}
+
+	if err := store.DeleteFile(ctx, objectPath); err != nil {
+		t.Fatalf("DeleteFile: %v", err)
+	}
+	if err := store.DeleteFile(ctx, objectPath); err != nil {
+		t.Errorf("deleting a missing file should not fail: %v", err)
+	}
+	if _, err := store.OpenFile(ctx, "../outside"); err == nil {
+		t.Error("paths outside the directory must be rejected")
+	}
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+// LocalVideoHost is a VideoHost that keeps videos in a local directory, so the
+// YouTube upload path runs in development without credentials. Videos get a
+// "dev-" ID and a YouTube-style URL, which handlers treat like any hosted
+// video; the URL doesn't play, but the file is kept for inspection.
+type LocalVideoHost struct {
+	dir string
+}
+
+// NewLocalVideoHost creates a video host in dir, or in a new temporary
+// directory if dir is empty
+func NewLocalVideoHost(dir string) (*LocalVideoHost, error) {
+	var err error
+	if dir == "" {
+		dir, err = os.MkdirTemp("", "donzhit-videos-")
+	} else {
+		err = os.MkdirAll(dir, 0o755)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create video directory: %w", err)
+	}
+	return &LocalVideoHost{dir: dir}, nil
+}
+
+// Dir returns the directory videos are stored in
+func (l *LocalVideoHost) Dir() string {
+	return l.dir
+}
+
+// UploadVideo stores the video and returns its dev ID and URL
+func (l *LocalVideoHost) UploadVideo(ctx context.Context, title, description string, reader io.Reader, contentType string) (*YouTubeUploadResult, error) {
+	videoID := "dev-" + uuid.New().String()
+	f, err := os.Create(filepath.Join(l.dir, videoID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload video: %w", err)
+	}
+	size, err := io.Copy(f, reader)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload video: %w", err)
+	}
+
+	result := &YouTubeUploadResult{
+		VideoID: videoID,
+		URL:     fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID),
+	}
+	log.Printf("Dev video host: stored %q as %s (%s, %d bytes) in %s", title, videoID, contentType, size, l.dir)
+	return result, nil
+}
+
+// DeleteVideo removes a stored video; one that is already gone is not an error
+func (l *LocalVideoHost) DeleteVideo(ctx context.Context, videoID string) error {
+	if !filepath.IsLocal(videoID) {
+		return fmt.Errorf("invalid video ID %q", videoID)
+	}
+	if err := os.Remove(filepath.Join(l.dir, videoID)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete video %s: %w", videoID, err)
+	}
+	log.Printf("Dev video host: deleted %s", videoID)
+	return nil
+}
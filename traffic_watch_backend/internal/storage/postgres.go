@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/cloudsqlconn"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"donzhit_me_backend/internal/models"
@@ -16,12 +18,34 @@ import (
 
 // PostgresClient wraps the pgx connection pool
 type PostgresClient struct {
-	pool   *pgxpool.Pool
-	dialer *cloudsqlconn.Dialer
+	pool       *pgxpool.Pool
+	db         conn          // pool, or the transaction inside WithTransaction
+	tx         pgx.Tx        // Set on the client WithTransaction hands to its callback
+	readPool   *pgxpool.Pool // Optional read replica for read-heavy public queries
+	dialer     *cloudsqlconn.Dialer
+	poolConfig PoolConfig // Shared by the primary and replica pools
+}
+
+// uniqueViolation is the PostgreSQL error code for a duplicate key
+const uniqueViolation = "23505"
+
+// querier is satisfied by both connection pools and transactions
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// conn is what the client issues statements through: the pool, or a transaction.
+// Begin on a transaction starts a savepoint, so methods that use their own
+// transaction still work inside WithTransaction.
+type conn interface {
+	querier
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
 // NewPostgresClient creates a new PostgreSQL client using Cloud SQL connector
-func NewPostgresClient(ctx context.Context, instanceConnName, dbUser, dbPassword, dbName string) (*PostgresClient, error) {
+func NewPostgresClient(ctx context.Context, instanceConnName, dbUser, dbPassword, dbName string, poolConfig PoolConfig) (*PostgresClient, error) {
 	dialer, err := cloudsqlconn.NewDialer(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Cloud SQL dialer: %w", err)
@@ -41,10 +65,10 @@ func NewPostgresClient(ctx context.Context, instanceConnName, dbUser, dbPassword
 	}
 
 	// Connection pool settings
-	config.MaxConns = 10
-	config.MinConns = 1
-	config.MaxConnLifetime = time.Hour
-	config.MaxConnIdleTime = 30 * time.Minute
+	if err := poolConfig.apply(config); err != nil {
+		dialer.Close()
+		return nil, err
+	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -60,22 +84,23 @@ func NewPostgresClient(ctx context.Context, instanceConnName, dbUser, dbPassword
 	}
 
 	return &PostgresClient{
-		pool:   pool,
-		dialer: dialer,
+		pool:       pool,
+		db:         pool,
+		dialer:     dialer,
+		poolConfig: poolConfig,
 	}, nil
 }
 
 // NewPostgresClientFromConnString creates a PostgreSQL client from a connection string (for local dev)
-func NewPostgresClientFromConnString(ctx context.Context, connString string) (*PostgresClient, error) {
+func NewPostgresClientFromConnString(ctx context.Context, connString string, poolConfig PoolConfig) (*PostgresClient, error) {
 	config, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse connection string: %w", err)
 	}
 
-	config.MaxConns = 10
-	config.MinConns = 1
-	config.MaxConnLifetime = time.Hour
-	config.MaxConnIdleTime = 30 * time.Minute
+	if err := poolConfig.apply(config); err != nil {
+		return nil, err
+	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -88,13 +113,56 @@ func NewPostgresClientFromConnString(ctx context.Context, connString string) (*P
 	}
 
 	return &PostgresClient{
-		pool:   pool,
-		dialer: nil,
+		pool:       pool,
+		db:         pool,
+		dialer:     nil,
+		poolConfig: poolConfig,
 	}, nil
 }
 
+// SetReadReplica connects a read-only pool used for public feed, map and stats queries,
+// tuned with the same PoolConfig as the primary. Those queries stay on the primary until this succeeds.
+func (p *PostgresClient) SetReadReplica(ctx context.Context, connString string) error {
+	config, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return fmt.Errorf("failed to parse read replica connection string: %w", err)
+	}
+
+	if err := p.poolConfig.apply(config); err != nil {
+		return err
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to create read replica pool: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return fmt.Errorf("failed to ping read replica: %w", err)
+	}
+
+	p.readPool = pool
+	return nil
+}
+
+// reader returns the pool for queries that tolerate replication lag, falling back to the primary.
+// Inside a transaction it returns the transaction, so reads see its writes.
+func (p *PostgresClient) reader() conn {
+	if p.tx != nil {
+		return p.tx
+	}
+	if p.readPool != nil {
+		return p.readPool
+	}
+	return p.pool
+}
+
 // Close closes the PostgreSQL client
 func (p *PostgresClient) Close() error {
+	if p.readPool != nil {
+		p.readPool.Close()
+	}
 	p.pool.Close()
 	if p.dialer != nil {
 		return p.dialer.Close()
@@ -102,6 +170,19 @@ func (p *PostgresClient) Close() error {
 	return nil
 }
 
+// Ping checks the primary and, when configured, the read replica
+func (p *PostgresClient) Ping(ctx context.Context) error {
+	if err := p.pool.Ping(ctx); err != nil {
+		return err
+	}
+	if p.readPool != nil {
+		if err := p.readPool.Ping(ctx); err != nil {
+			return fmt.Errorf("read replica: %w", err)
+		}
+	}
+	return nil
+}
+
 // CreateReport creates a new report in PostgreSQL
 func (p *PostgresClient) CreateReport(ctx context.Context, report *models.TrafficReport) error {
 	if report.ID == "" {
@@ -112,7 +193,24 @@ func (p *PostgresClient) CreateReport(ctx context.Context, report *models.Traffi
 	report.UpdatedAt = time.Now()
 	report.Status = models.StatusSubmitted
 
-	tx, err := p.pool.Begin(ctx)
+	return p.insertReport(ctx, report)
+}
+
+// ImportReport stores a report from a historical import as given, keeping its
+// status, review fields and timestamps
+func (p *PostgresClient) ImportReport(ctx context.Context, report *models.TrafficReport) error {
+	if report.ID == "" {
+		return errors.New("report ID is required")
+	}
+	if report.Status == "" || report.CreatedAt.IsZero() || report.UpdatedAt.IsZero() {
+		return errors.New("imported reports need a status and timestamps")
+	}
+	return p.insertReport(ctx, report)
+}
+
+// insertReport inserts a report and its media files in one transaction
+func (p *PostgresClient) insertReport(ctx context.Context, report *models.TrafficReport) error {
+	tx, err := p.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -120,21 +218,28 @@ func (p *PostgresClient) CreateReport(ctx context.Context, report *models.Traffi
 
 	// Insert report
 	_, err = tx.Exec(ctx, `
-		INSERT INTO reports (id, user_id, title, description, date_time, road_usage, event_type, state, city, injuries, retain_media_metadata, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		INSERT INTO reports (id, user_id, title, description, date_time, road_usage, event_type, state, city, injuries, retain_media_metadata, status, created_at, updated_at, vehicle_hashes, latitude, longitude, content_flagged, time_zone, utc_offset_minutes, city_id, severity, review_reason, reviewed_by, priority, status_changed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
 	`, report.ID, report.UserID, report.Title, report.Description, report.DateTime,
 		report.RoadUsages, report.EventTypes, report.State, report.City, report.Injuries,
-		report.RetainMediaMetadata, report.Status, report.CreatedAt, report.UpdatedAt)
+		report.RetainMediaMetadata, report.Status, report.CreatedAt, report.UpdatedAt, report.VehicleHashes,
+		report.Latitude, report.Longitude, report.ContentFlagged, report.TimeZone, report.UTCOffsetMinutes, report.CityID, report.Severity,
+		report.ReviewReason, report.ReviewedBy, report.Priority, report.StatusChangedAt)
 	if err != nil {
+		// Client-generated IDs can be resubmitted by offline clients
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return conflict("report already exists")
+		}
 		return fmt.Errorf("failed to insert report: %w", err)
 	}
 
 	// Insert media files
 	for _, mf := range report.MediaFiles {
 		_, err = tx.Exec(ctx, `
-			INSERT INTO media_files (id, report_id, file_name, content_type, size, url, uploaded_at, metadata)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		`, mf.ID, report.ID, mf.FileName, mf.ContentType, mf.Size, mf.URL, mf.UploadedAt, mf.Metadata)
+			INSERT INTO media_files (id, report_id, file_name, content_type, size, url, uploaded_at, metadata, state)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		`, mf.ID, report.ID, mf.FileName, mf.ContentType, mf.Size, mf.URL, mf.UploadedAt, mf.Metadata, mf.EffectiveState())
 		if err != nil {
 			return fmt.Errorf("failed to insert media file: %w", err)
 		}
@@ -147,25 +252,22 @@ func (p *PostgresClient) CreateReport(ctx context.Context, report *models.Traffi
 func (p *PostgresClient) GetReport(ctx context.Context, reportID string) (*models.TrafficReport, error) {
 	report := &models.TrafficReport{}
 
-	err := p.pool.QueryRow(ctx, `
-		SELECT id, user_id, title, description, date_time, road_usage, event_type, state, COALESCE(city, ''), injuries, COALESCE(retain_media_metadata, true), status, created_at, updated_at
+	err := scanReport(p.db.QueryRow(ctx, `
+		SELECT `+reportColumns+`
 		FROM reports WHERE id = $1
-	`, reportID).Scan(
-		&report.ID, &report.UserID, &report.Title, &report.Description, &report.DateTime,
-		&report.RoadUsages, &report.EventTypes, &report.State, &report.City, &report.Injuries,
-		&report.RetainMediaMetadata, &report.Status, &report.CreatedAt, &report.UpdatedAt,
-	)
+	`, reportID), report)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("report not found")
+			return nil, notFound("report")
 		}
 		return nil, fmt.Errorf("failed to get report: %w", err)
 	}
 
 	// Get media files
-	rows, err := p.pool.Query(ctx, `
-		SELECT id, file_name, content_type, size, url, uploaded_at, metadata
-		FROM media_files WHERE report_id = $1
+	rows, err := p.db.Query(ctx, `
+		SELECT id, file_name, content_type, size, url, uploaded_at, metadata, COALESCE(storage_path, ''),
+			COALESCE(transcript, ''), COALESCE(transcript_status, ''), ocr, state
+		FROM all_media_files WHERE report_id = $1
 	`, reportID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get media files: %w", err)
@@ -174,7 +276,8 @@ func (p *PostgresClient) GetReport(ctx context.Context, reportID string) (*model
 
 	for rows.Next() {
 		var mf models.MediaFile
-		if err := rows.Scan(&mf.ID, &mf.FileName, &mf.ContentType, &mf.Size, &mf.URL, &mf.UploadedAt, &mf.Metadata); err != nil {
+		if err := rows.Scan(&mf.ID, &mf.FileName, &mf.ContentType, &mf.Size, &mf.URL, &mf.UploadedAt, &mf.Metadata, &mf.StoragePath,
+			&mf.Transcript, &mf.TranscriptStatus, &mf.OCR, &mf.State); err != nil {
 			return nil, fmt.Errorf("failed to scan media file: %w", err)
 		}
 		report.MediaFiles = append(report.MediaFiles, mf)
@@ -195,11 +298,11 @@ func (p *PostgresClient) GetReportByIDAndUser(ctx context.Context, reportID, use
 	}
 
 	if report.UserID != userID {
-		return nil, errors.New("report not found")
+		return nil, notFound("report")
 	}
 
 	if report.Status == models.StatusDeleted {
-		return nil, errors.New("report not found")
+		return nil, notFound("report")
 	}
 
 	return report, nil
@@ -207,8 +310,8 @@ func (p *PostgresClient) GetReportByIDAndUser(ctx context.Context, reportID, use
 
 // ListReportsByUser retrieves all non-deleted reports for a user
 func (p *PostgresClient) ListReportsByUser(ctx context.Context, userID string) ([]models.TrafficReport, error) {
-	rows, err := p.pool.Query(ctx, `
-		SELECT id, user_id, title, description, date_time, road_usage, event_type, state, COALESCE(city, ''), injuries, COALESCE(retain_media_metadata, true), status, created_at, updated_at, COALESCE(review_reason, '')
+	rows, err := p.db.Query(ctx, `
+		SELECT `+reportColumns+`
 		FROM reports
 		WHERE user_id = $1 AND status != $2
 		ORDER BY created_at DESC
@@ -218,72 +321,61 @@ func (p *PostgresClient) ListReportsByUser(ctx context.Context, userID string) (
 	}
 	defer rows.Close()
 
-	var reports []models.TrafficReport
-	for rows.Next() {
-		var report models.TrafficReport
-		if err := rows.Scan(
-			&report.ID, &report.UserID, &report.Title, &report.Description, &report.DateTime,
-			&report.RoadUsages, &report.EventTypes, &report.State, &report.City, &report.Injuries,
-			&report.RetainMediaMetadata, &report.Status, &report.CreatedAt, &report.UpdatedAt, &report.ReviewReason,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan report: %w", err)
-		}
-		report.MediaFiles = []models.MediaFile{}
-		reports = append(reports, report)
-	}
-
-	// Get media files for all reports
-	if len(reports) > 0 {
-		reportIDs := make([]string, len(reports))
-		reportMap := make(map[string]*models.TrafficReport)
-		for i := range reports {
-			reportIDs[i] = reports[i].ID
-			reportMap[reports[i].ID] = &reports[i]
-		}
-
-		mediaRows, err := p.pool.Query(ctx, `
-			SELECT report_id, id, file_name, content_type, size, url, uploaded_at, metadata
-			FROM media_files WHERE report_id = ANY($1)
-		`, reportIDs)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get media files: %w", err)
-		}
-		defer mediaRows.Close()
+	return p.scanReportsWithMedia(ctx, p.db, rows)
+}
 
-		for mediaRows.Next() {
-			var reportID string
-			var mf models.MediaFile
-			if err := mediaRows.Scan(&reportID, &mf.ID, &mf.FileName, &mf.ContentType, &mf.Size, &mf.URL, &mf.UploadedAt, &mf.Metadata); err != nil {
-				return nil, fmt.Errorf("failed to scan media file: %w", err)
-			}
-			if r, ok := reportMap[reportID]; ok {
-				r.MediaFiles = append(r.MediaFiles, mf)
-			}
-		}
+// ListUserReports retrieves a user's non-deleted reports in sort order, only those in
+// status when it is non-empty
+func (p *PostgresClient) ListUserReports(ctx context.Context, userID, status string, sort models.ReportSort) ([]models.TrafficReport, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT `+reportColumns+`
+		FROM reports
+		WHERE user_id = $1 AND status != $2 AND ($3::text = '' OR status = $3)
+		ORDER BY `+reportOrderBy(sort)+`
+	`, userID, models.StatusDeleted, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
 	}
+	defer rows.Close()
 
-	if reports == nil {
-		reports = []models.TrafficReport{}
-	}
+	return p.scanReportsWithMedia(ctx, p.db, rows)
+}
 
-	return reports, nil
+// CountUserReports counts the reports ListUserReports would return
+func (p *PostgresClient) CountUserReports(ctx context.Context, userID, status string) (int, error) {
+	var count int
+	err := p.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM reports
+		WHERE user_id = $1 AND status != $2 AND ($3::text = '' OR status = $3)
+	`, userID, models.StatusDeleted, status).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reports: %w", err)
+	}
+	return count, nil
 }
 
-// UpdateReport updates an existing report
+// UpdateReport updates an existing report. A changed status must be allowed by the
+// status state machine.
 func (p *PostgresClient) UpdateReport(ctx context.Context, report *models.TrafficReport) error {
 	report.UpdatedAt = time.Now()
 
-	_, err := p.pool.Exec(ctx, `
+	result, err := p.db.Exec(ctx, `
 		UPDATE reports
 		SET title = $2, description = $3, date_time = $4, road_usage = $5, event_type = $6,
-		    state = $7, city = $8, injuries = $9, status = $10, updated_at = $11
-		WHERE id = $1
+		    state = $7, city = $8, injuries = $9, status = $10, updated_at = $11,
+		    time_zone = $12, utc_offset_minutes = $13, city_id = $15, severity = $16
+		WHERE id = $1 AND (status = $10 OR status = ANY($14))
 	`, report.ID, report.Title, report.Description, report.DateTime, report.RoadUsages,
-		report.EventTypes, report.State, report.City, report.Injuries, report.Status, report.UpdatedAt)
+		report.EventTypes, report.State, report.City, report.Injuries, report.Status, report.UpdatedAt,
+		report.TimeZone, report.UTCOffsetMinutes, models.StatusesTransitioningTo(report.Status), report.CityID, report.Severity)
 	if err != nil {
 		return fmt.Errorf("failed to update report: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		return p.statusTransitionError(ctx, report.ID, report.Status)
+	}
+
 	return nil
 }
 
@@ -300,16 +392,17 @@ func (p *PostgresClient) DeleteReport(ctx context.Context, reportID, userID stri
 
 // AddMediaFileToReport adds a media file reference to a report
 func (p *PostgresClient) AddMediaFileToReport(ctx context.Context, reportID string, mediaFile models.MediaFile) error {
-	_, err := p.pool.Exec(ctx, `
-		INSERT INTO media_files (id, report_id, file_name, content_type, size, url, uploaded_at, metadata)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	`, mediaFile.ID, reportID, mediaFile.FileName, mediaFile.ContentType, mediaFile.Size, mediaFile.URL, mediaFile.UploadedAt, mediaFile.Metadata)
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO media_files (id, report_id, file_name, content_type, size, url, uploaded_at, metadata, state)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, mediaFile.ID, reportID, mediaFile.FileName, mediaFile.ContentType, mediaFile.Size, mediaFile.URL, mediaFile.UploadedAt, mediaFile.Metadata,
+		mediaFile.EffectiveState())
 	if err != nil {
 		return fmt.Errorf("failed to add media file: %w", err)
 	}
 
 	// Update report's updated_at
-	_, err = p.pool.Exec(ctx, `UPDATE reports SET updated_at = $2 WHERE id = $1`, reportID, time.Now())
+	_, err = p.db.Exec(ctx, `UPDATE reports SET updated_at = $2 WHERE id = $1`, reportID, time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to update report timestamp: %w", err)
 	}
@@ -321,72 +414,101 @@ func (p *PostgresClient) AddMediaFileToReport(ctx context.Context, reportID stri
 // Admin Report Methods
 // ============================================================================
 
-// ListAllReports retrieves all non-deleted reports (for admin dashboard)
-func (p *PostgresClient) ListAllReports(ctx context.Context) ([]models.TrafficReport, error) {
-	rows, err := p.pool.Query(ctx, `
-		SELECT id, user_id, title, description, date_time, road_usage, event_type, state, COALESCE(city, ''), injuries, COALESCE(retain_media_metadata, true), status, created_at, updated_at, COALESCE(review_reason, '')
+// ListAllReports retrieves a page of non-deleted reports in sort order (for admin dashboard)
+func (p *PostgresClient) ListAllReports(ctx context.Context, includeShadowBanned bool, sort models.ReportSort, limit, offset int) ([]models.TrafficReport, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT `+reportColumns+`
 		FROM reports
-		WHERE status != $1
-		ORDER BY created_at DESC
-	`, models.StatusDeleted)
+		WHERE status != $1 AND ($2 OR `+notShadowBanned("reports.user_id")+`)
+		ORDER BY `+reportOrderBy(sort)+`
+		LIMIT $3 OFFSET $4
+	`, models.StatusDeleted, includeShadowBanned, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list all reports: %w", err)
 	}
 	defer rows.Close()
 
-	return p.scanReportsWithMedia(ctx, rows)
+	return p.scanReportsWithMedia(ctx, p.db, rows)
 }
 
-// ListReportsAwaitingReview retrieves reports with "submitted" status (for admin review queue)
-func (p *PostgresClient) ListReportsAwaitingReview(ctx context.Context) ([]models.TrafficReport, error) {
-	rows, err := p.pool.Query(ctx, `
-		SELECT id, user_id, title, description, date_time, road_usage, event_type, state, COALESCE(city, ''), injuries, COALESCE(retain_media_metadata, true), status, created_at, updated_at, COALESCE(review_reason, '')
+// CountAllReports counts the reports ListAllReports pages through
+func (p *PostgresClient) CountAllReports(ctx context.Context, includeShadowBanned bool) (int, error) {
+	var count int
+	err := p.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM reports
+		WHERE status != $1 AND ($2 OR `+notShadowBanned("reports.user_id")+`)
+	`, models.StatusDeleted, includeShadowBanned).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count all reports: %w", err)
+	}
+	return count, nil
+}
+
+// ListReportsAwaitingReview retrieves a page of reports with "submitted" status (for admin review queue)
+func (p *PostgresClient) ListReportsAwaitingReview(ctx context.Context, includeShadowBanned bool, limit, offset int) ([]models.TrafficReport, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT `+reportColumns+`
 		FROM reports
-		WHERE status = $1
-		ORDER BY created_at DESC
-	`, models.StatusSubmitted)
+		WHERE status = $1 AND ($2 OR `+notShadowBanned("reports.user_id")+`) AND `+mediaSettled("reports.id")+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`, models.StatusSubmitted, includeShadowBanned, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list reports awaiting review: %w", err)
 	}
 	defer rows.Close()
 
-	return p.scanReportsWithMedia(ctx, rows)
+	return p.scanReportsWithMedia(ctx, p.db, rows)
 }
 
-// ListApprovedReports retrieves reports with "reviewed_pass" status (for public feed)
-// Sorted by priority (higher number = higher priority) first, then by date descending
+// CountReportsAwaitingReview counts the reports ListReportsAwaitingReview would return
+func (p *PostgresClient) CountReportsAwaitingReview(ctx context.Context, includeShadowBanned bool) (int, error) {
+	var count int
+	err := p.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM reports
+		WHERE status = $1 AND ($2 OR `+notShadowBanned("reports.user_id")+`) AND `+mediaSettled("reports.id")+`
+	`, models.StatusSubmitted, includeShadowBanned).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reports awaiting review: %w", err)
+	}
+	return count, nil
+}
+
+// ListApprovedReports retrieves reports with "reviewed_pass" status (for public feed), leaving out
+// reports whose publish time hasn't arrived. Pinned reports come first, then sorted by priority (higher number = higher priority), then by date descending
 func (p *PostgresClient) ListApprovedReports(ctx context.Context) ([]models.TrafficReport, error) {
-	rows, err := p.pool.Query(ctx, `
-		SELECT id, user_id, title, description, date_time, road_usage, event_type, state, COALESCE(city, ''), injuries, COALESCE(retain_media_metadata, true), status, created_at, updated_at, COALESCE(review_reason, ''), priority
+	rows, err := p.reader().Query(ctx, `
+		SELECT `+reportColumns+`
 		FROM reports
-		WHERE status = $1
-		ORDER BY COALESCE(priority, 100) DESC, created_at DESC
+		WHERE status = $1 AND `+published("publish_at")+` AND `+mediaSettled("reports.id")+`
+			AND `+notShadowBanned("reports.user_id")+` AND `+notDeactivated("reports.user_id")+`
+		ORDER BY pinned_at IS NOT NULL DESC, COALESCE(priority, 100) DESC, created_at DESC
 	`, models.StatusReviewedPass)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list approved reports: %w", err)
 	}
 	defer rows.Close()
 
-	return p.scanReportsWithMediaAndPriority(ctx, rows)
+	return p.scanReportsWithMedia(ctx, p.reader(), rows)
 }
 
 // UpdateReportStatus updates a report's status and optional review reason
 func (p *PostgresClient) UpdateReportStatus(ctx context.Context, reportID, status, reviewReason, reviewedBy string) error {
-	result, err := p.pool.Exec(ctx, `
+	result, err := p.db.Exec(ctx, `
 		UPDATE reports
-		SET status = $2, review_reason = $3, updated_at = $4,
+		SET status = $2, review_reason = $3, updated_at = $4, status_changed_at = $4,
 			reviewed_by = CASE
 				WHEN COALESCE(reviewed_by, '') = '' THEN $5
 				ELSE reviewed_by || ',' || $5
 			END
-		WHERE id = $1 AND status != $6
-	`, reportID, status, reviewReason, time.Now(), reviewedBy, models.StatusDeleted)
+		WHERE id = $1 AND status = ANY($6)
+	`, reportID, status, reviewReason, time.Now(), reviewedBy, models.StatusesTransitioningTo(status))
 	if err != nil {
 		return fmt.Errorf("failed to update report status: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("report not found")
+		return p.statusTransitionError(ctx, reportID, status)
 	}
 
 	return nil
@@ -394,123 +516,149 @@ func (p *PostgresClient) UpdateReportStatus(ctx context.Context, reportID, statu
 
 // UpdateReportStatusWithPriority updates a report's status, review reason, and priority
 func (p *PostgresClient) UpdateReportStatusWithPriority(ctx context.Context, reportID, status, reviewReason string, priority *int, reviewedBy string) error {
-	result, err := p.pool.Exec(ctx, `
+	result, err := p.db.Exec(ctx, `
 		UPDATE reports
-		SET status = $2, review_reason = $3, priority = $4, updated_at = $5,
+		SET status = $2, review_reason = $3, priority = $4, updated_at = $5, status_changed_at = $5,
 			reviewed_by = CASE
 				WHEN COALESCE(reviewed_by, '') = '' THEN $6
 				ELSE reviewed_by || ',' || $6
 			END
-		WHERE id = $1 AND status != $7
-	`, reportID, status, reviewReason, priority, time.Now(), reviewedBy, models.StatusDeleted)
+		WHERE id = $1 AND status = ANY($7)
+	`, reportID, status, reviewReason, priority, time.Now(), reviewedBy, models.StatusesTransitioningTo(status))
 	if err != nil {
 		return fmt.Errorf("failed to update report status with priority: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return errors.New("report not found")
+		return p.statusTransitionError(ctx, reportID, status)
 	}
 
 	return nil
 }
 
-// scanReportsWithMedia is a helper to scan report rows and fetch their media files
-func (p *PostgresClient) scanReportsWithMedia(ctx context.Context, rows pgx.Rows) ([]models.TrafficReport, error) {
-	var reports []models.TrafficReport
-	for rows.Next() {
-		var report models.TrafficReport
-		if err := rows.Scan(
-			&report.ID, &report.UserID, &report.Title, &report.Description, &report.DateTime,
-			&report.RoadUsages, &report.EventTypes, &report.State, &report.City, &report.Injuries,
-			&report.RetainMediaMetadata, &report.Status, &report.CreatedAt, &report.UpdatedAt, &report.ReviewReason,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan report: %w", err)
-		}
-		report.MediaFiles = []models.MediaFile{}
-		reports = append(reports, report)
+// statusTransitionError explains why an update guarded by the status state machine changed
+// no rows: the report doesn't exist (or is deleted), or its status can't move to status
+func (p *PostgresClient) statusTransitionError(ctx context.Context, reportID, status string) error {
+	var current string
+	err := p.db.QueryRow(ctx, `SELECT status FROM reports WHERE id = $1`, reportID).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) || current == models.StatusDeleted {
+		return notFound("report")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get report status: %w", err)
 	}
+	if err := models.CheckStatusTransition(current, status); err != nil {
+		return err
+	}
+	return fmt.Errorf("report %s changed while its status was being updated", reportID)
+}
 
-	// Get media files for all reports
-	if len(reports) > 0 {
-		reportIDs := make([]string, len(reports))
-		reportMap := make(map[string]*models.TrafficReport)
-		for i := range reports {
-			reportIDs[i] = reports[i].ID
-			reportMap[reports[i].ID] = &reports[i]
-		}
+// attachMediaFiles loads media files for the given reports in a single query
+func (p *PostgresClient) attachMediaFiles(ctx context.Context, db querier, reports []models.TrafficReport) error {
+	if len(reports) == 0 {
+		return nil
+	}
 
-		mediaRows, err := p.pool.Query(ctx, `
-			SELECT report_id, id, file_name, content_type, size, url, uploaded_at, metadata
-			FROM media_files WHERE report_id = ANY($1)
-		`, reportIDs)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get media files: %w", err)
-		}
-		defer mediaRows.Close()
+	reportIDs := make([]string, len(reports))
+	reportMap := make(map[string]*models.TrafficReport)
+	for i := range reports {
+		reportIDs[i] = reports[i].ID
+		reportMap[reports[i].ID] = &reports[i]
+	}
 
-		for mediaRows.Next() {
-			var reportID string
-			var mf models.MediaFile
-			if err := mediaRows.Scan(&reportID, &mf.ID, &mf.FileName, &mf.ContentType, &mf.Size, &mf.URL, &mf.UploadedAt, &mf.Metadata); err != nil {
-				return nil, fmt.Errorf("failed to scan media file: %w", err)
-			}
-			if r, ok := reportMap[reportID]; ok {
-				r.MediaFiles = append(r.MediaFiles, mf)
-			}
-		}
+	mediaRows, err := db.Query(ctx, `
+		SELECT report_id, id, file_name, content_type, size, url, uploaded_at, metadata, COALESCE(storage_path, ''),
+			COALESCE(transcript, ''), COALESCE(transcript_status, ''), ocr, state
+		FROM all_media_files WHERE report_id = ANY($1)
+	`, reportIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get media files: %w", err)
 	}
+	defer mediaRows.Close()
 
-	if reports == nil {
-		reports = []models.TrafficReport{}
+	for mediaRows.Next() {
+		var reportID string
+		var mf models.MediaFile
+		if err := mediaRows.Scan(&reportID, &mf.ID, &mf.FileName, &mf.ContentType, &mf.Size, &mf.URL, &mf.UploadedAt, &mf.Metadata, &mf.StoragePath,
+			&mf.Transcript, &mf.TranscriptStatus, &mf.OCR, &mf.State); err != nil {
+			return fmt.Errorf("failed to scan media file: %w", err)
+		}
+		if r, ok := reportMap[reportID]; ok {
+			r.MediaFiles = append(r.MediaFiles, mf)
+		}
 	}
 
-	return reports, nil
+	return nil
 }
 
-// scanReportsWithMediaAndPriority is a helper to scan report rows (including priority) and fetch their media files
-func (p *PostgresClient) scanReportsWithMediaAndPriority(ctx context.Context, rows pgx.Rows) ([]models.TrafficReport, error) {
+// reportColumns is the column list selected by every report query; keep in sync with scanReport
+const reportColumns = `id, user_id, title, description, date_time, road_usage, event_type, state, COALESCE(city, ''), injuries,
+	COALESCE(retain_media_metadata, true), status, created_at, updated_at, COALESCE(review_reason, ''), priority,
+	COALESCE(vehicle_hashes, '{}'), COALESCE(incident_id::text, ''),
+	COALESCE((SELECT to_report_id::text FROM report_redirects WHERE from_report_id = reports.id), ''),
+	latitude, longitude, COALESCE(content_flagged, false), time_zone, utc_offset_minutes,
+	pinned_at, publish_at, priority_frozen, hot_score, status_changed_at, status_seen_at, city_id, severity,
+	COALESCE(map_image_path, '')`
+
+// reportOrderBy returns the ORDER BY list for sort, matching models.ReportSort.Compare
+func reportOrderBy(sort models.ReportSort) string {
+	var columns []string
+	switch sort.Field {
+	case models.SortUpdatedAt:
+		columns = []string{"updated_at"}
+	case models.SortPriority:
+		columns = []string{"COALESCE(priority, 100)", "created_at"}
+	case models.SortEngagement:
+		columns = []string{"hot_score", "created_at"}
+	default:
+		columns = []string{"created_at"}
+	}
+	columns = append(columns, "id")
+
+	dir := " DESC"
+	if sort.Ascending {
+		dir = " ASC"
+	}
+	return strings.Join(columns, dir+", ") + dir
+}
+
+// scanReport scans a row selected with reportColumns into a report. Any
+// columns selected after reportColumns are scanned into extra.
+func scanReport(row pgx.Row, report *models.TrafficReport, extra ...interface{}) error {
+	dest := []interface{}{
+		&report.ID, &report.UserID, &report.Title, &report.Description, &report.DateTime,
+		&report.RoadUsages, &report.EventTypes, &report.State, &report.City, &report.Injuries,
+		&report.RetainMediaMetadata, &report.Status, &report.CreatedAt, &report.UpdatedAt, &report.ReviewReason, &report.Priority,
+		&report.VehicleHashes, &report.IncidentID,
+		&report.MergedInto,
+		&report.Latitude, &report.Longitude, &report.ContentFlagged, &report.TimeZone, &report.UTCOffsetMinutes,
+		&report.PinnedAt, &report.PublishAt, &report.PriorityFrozen, &report.HotScore,
+		&report.StatusChangedAt, &report.StatusSeenAt, &report.CityID, &report.Severity,
+		&report.MapImagePath,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	report.Pinned = report.PinnedAt != nil
+	return err
+}
+
+// scanReportsWithMedia is a helper to scan report rows and fetch their media files from the same database
+func (p *PostgresClient) scanReportsWithMedia(ctx context.Context, db querier, rows pgx.Rows) ([]models.TrafficReport, error) {
 	var reports []models.TrafficReport
 	for rows.Next() {
 		var report models.TrafficReport
-		if err := rows.Scan(
-			&report.ID, &report.UserID, &report.Title, &report.Description, &report.DateTime,
-			&report.RoadUsages, &report.EventTypes, &report.State, &report.City, &report.Injuries,
-			&report.RetainMediaMetadata, &report.Status, &report.CreatedAt, &report.UpdatedAt, &report.ReviewReason, &report.Priority,
-		); err != nil {
+		if err := scanReport(rows, &report); err != nil {
 			return nil, fmt.Errorf("failed to scan report: %w", err)
 		}
 		report.MediaFiles = []models.MediaFile{}
 		reports = append(reports, report)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reports: %w", err)
+	}
 
 	// Get media files for all reports
-	if len(reports) > 0 {
-		reportIDs := make([]string, len(reports))
-		reportMap := make(map[string]*models.TrafficReport)
-		for i := range reports {
-			reportIDs[i] = reports[i].ID
-			reportMap[reports[i].ID] = &reports[i]
-		}
-
-		mediaRows, err := p.pool.Query(ctx, `
-			SELECT report_id, id, file_name, content_type, size, url, uploaded_at, metadata
-			FROM media_files WHERE report_id = ANY($1)
-		`, reportIDs)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get media files: %w", err)
-		}
-		defer mediaRows.Close()
-
-		for mediaRows.Next() {
-			var reportID string
-			var mf models.MediaFile
-			if err := mediaRows.Scan(&reportID, &mf.ID, &mf.FileName, &mf.ContentType, &mf.Size, &mf.URL, &mf.UploadedAt, &mf.Metadata); err != nil {
-				return nil, fmt.Errorf("failed to scan media file: %w", err)
-			}
-			if r, ok := reportMap[reportID]; ok {
-				r.MediaFiles = append(r.MediaFiles, mf)
-			}
-		}
+	if err := p.attachMediaFiles(ctx, db, reports); err != nil {
+		return nil, err
 	}
 
 	if reports == nil {
@@ -527,7 +675,7 @@ func (p *PostgresClient) scanReportsWithMediaAndPriority(ctx context.Context, ro
 // CreateOrUpdateUser creates a new user or updates an existing one
 func (p *PostgresClient) CreateOrUpdateUser(ctx context.Context, user *models.User) error {
 	now := time.Now()
-	_, err := p.pool.Exec(ctx, `
+	_, err := p.db.Exec(ctx, `
 		INSERT INTO users (id, email, role, jwt_refresh_token, created_at, updated_at, last_login_at)
 		VALUES ($1, $2, $3, $4, $5, $5, $5)
 		ON CONFLICT (id) DO UPDATE SET
@@ -546,40 +694,52 @@ func (p *PostgresClient) CreateOrUpdateUser(ctx context.Context, user *models.Us
 // GetUserByID retrieves a user by their ID (Google subject)
 func (p *PostgresClient) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
 	user := &models.User{}
-	err := p.pool.QueryRow(ctx, `
-		SELECT id, email, role, COALESCE(jwt_refresh_token, ''), created_at, updated_at, last_login_at
+	var prefs models.NotificationPreferences
+	err := p.db.QueryRow(ctx, `
+		SELECT id, email, role, COALESCE(jwt_refresh_token, ''), created_at, updated_at, last_login_at, shadow_banned,
+			notify_email_on_review, notify_push_on_comment, notify_weekly_digest, notify_draft_reminders,
+			deactivated_at, COALESCE(deactivation_reason, ''), default_state, default_city, display_name, trust_level
 		FROM users WHERE id = $1
 	`, userID).Scan(&user.ID, &user.Email, &user.Role, &user.JWTRefreshToken,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt)
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.ShadowBanned,
+		&prefs.EmailOnReview, &prefs.PushOnComment, &prefs.WeeklyDigest, &prefs.DraftReminders,
+		&user.DeactivatedAt, &user.DeactivationReason, &user.DefaultState, &user.DefaultCity, &user.DisplayName, &user.TrustLevel)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("user not found")
+			return nil, notFound("user")
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
+	user.Notifications = &prefs
 	return user, nil
 }
 
 // GetUserByEmail retrieves a user by their email
 func (p *PostgresClient) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
 	user := &models.User{}
-	err := p.pool.QueryRow(ctx, `
-		SELECT id, email, role, COALESCE(jwt_refresh_token, ''), created_at, updated_at, last_login_at
+	var prefs models.NotificationPreferences
+	err := p.db.QueryRow(ctx, `
+		SELECT id, email, role, COALESCE(jwt_refresh_token, ''), created_at, updated_at, last_login_at, shadow_banned,
+			notify_email_on_review, notify_push_on_comment, notify_weekly_digest, notify_draft_reminders,
+			deactivated_at, COALESCE(deactivation_reason, ''), default_state, default_city, display_name, trust_level
 		FROM users WHERE email = $1
 	`, email).Scan(&user.ID, &user.Email, &user.Role, &user.JWTRefreshToken,
-		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt)
+		&user.CreatedAt, &user.UpdatedAt, &user.LastLoginAt, &user.ShadowBanned,
+		&prefs.EmailOnReview, &prefs.PushOnComment, &prefs.WeeklyDigest, &prefs.DraftReminders,
+		&user.DeactivatedAt, &user.DeactivationReason, &user.DefaultState, &user.DefaultCity, &user.DisplayName, &user.TrustLevel)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("user not found")
+			return nil, notFound("user")
 		}
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
+	user.Notifications = &prefs
 	return user, nil
 }
 
 // UpdateUserRefreshToken updates the user's JWT refresh token
 func (p *PostgresClient) UpdateUserRefreshToken(ctx context.Context, userID, refreshToken string) error {
-	_, err := p.pool.Exec(ctx, `
+	_, err := p.db.Exec(ctx, `
 		UPDATE users SET jwt_refresh_token = $2, updated_at = NOW() WHERE id = $1
 	`, userID, refreshToken)
 	if err != nil {
@@ -590,7 +750,7 @@ func (p *PostgresClient) UpdateUserRefreshToken(ctx context.Context, userID, ref
 
 // UpdateUserLastLogin updates the user's last login timestamp
 func (p *PostgresClient) UpdateUserLastLogin(ctx context.Context, userID string) error {
-	_, err := p.pool.Exec(ctx, `
+	_, err := p.db.Exec(ctx, `
 		UPDATE users SET last_login_at = NOW(), updated_at = NOW() WHERE id = $1
 	`, userID)
 	if err != nil {
@@ -601,7 +761,7 @@ func (p *PostgresClient) UpdateUserLastLogin(ctx context.Context, userID string)
 
 // RevokeUserToken revokes the user's current token by clearing the refresh token
 func (p *PostgresClient) RevokeUserToken(ctx context.Context, userID string) error {
-	_, err := p.pool.Exec(ctx, `
+	_, err := p.db.Exec(ctx, `
 		UPDATE users SET jwt_refresh_token = NULL, updated_at = NOW() WHERE id = $1
 	`, userID)
 	if err != nil {
@@ -618,7 +778,7 @@ func (p *PostgresClient) RevokeUserToken(ctx context.Context, userID string) err
 // If user already has a reaction on this report, it updates the reaction type,
 // preserves created_at, sets modified_at, and appends old type to history
 func (p *PostgresClient) AddReaction(ctx context.Context, reaction *models.Reaction) error {
-	_, err := p.pool.Exec(ctx, `
+	_, err := p.db.Exec(ctx, `
 		INSERT INTO report_reactions (id, report_id, user_id, user_email, reaction_type, created_at, modified_at, history_reaction_type)
 		VALUES ($1, $2, $3, $4, $5, $6, NULL, '')
 		ON CONFLICT (report_id, user_id) DO UPDATE SET
@@ -638,7 +798,7 @@ func (p *PostgresClient) AddReaction(ctx context.Context, reaction *models.React
 
 // RemoveReaction removes a reaction from a report
 func (p *PostgresClient) RemoveReaction(ctx context.Context, reportID, userID, reactionType string) error {
-	_, err := p.pool.Exec(ctx, `
+	_, err := p.db.Exec(ctx, `
 		DELETE FROM report_reactions WHERE report_id = $1 AND user_id = $2
 	`, reportID, userID)
 	if err != nil {
@@ -650,11 +810,11 @@ func (p *PostgresClient) RemoveReaction(ctx context.Context, reportID, userID, r
 // GetUserReactionType gets the current reaction type for a user on a report
 func (p *PostgresClient) GetUserReactionType(ctx context.Context, reportID, userID string) (string, error) {
 	var reactionType string
-	err := p.pool.QueryRow(ctx, `
+	err := p.db.QueryRow(ctx, `
 		SELECT reaction_type FROM report_reactions WHERE report_id = $1 AND user_id = $2
 	`, reportID, userID).Scan(&reactionType)
 	if err != nil {
-		if err.Error() == "no rows in result set" {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return "", nil
 		}
 		return "", fmt.Errorf("failed to get user reaction type: %w", err)
@@ -662,9 +822,59 @@ func (p *PostgresClient) GetUserReactionType(ctx context.Context, reportID, user
 	return reactionType, nil
 }
 
+// ToggleReaction removes the user's reaction if it already has reaction's type and
+// otherwise adds or switches it. A transaction-scoped advisory lock on the report and
+// user serializes concurrent toggles, so a double-tap can't add the same score twice.
+func (p *PostgresClient) ToggleReaction(ctx context.Context, reaction *models.Reaction) (string, bool, error) {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1 || ':' || $2))`, reaction.ReportID, reaction.UserID); err != nil {
+		return "", false, fmt.Errorf("failed to lock reaction: %w", err)
+	}
+
+	var previousType string
+	err = tx.QueryRow(ctx, `
+		SELECT reaction_type FROM report_reactions WHERE report_id = $1 AND user_id = $2
+	`, reaction.ReportID, reaction.UserID).Scan(&previousType)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return "", false, fmt.Errorf("failed to get user reaction type: %w", err)
+	}
+
+	added := previousType != reaction.ReactionType
+	if added {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO report_reactions (id, report_id, user_id, user_email, reaction_type, created_at, modified_at, history_reaction_type)
+			VALUES ($1, $2, $3, $4, $5, $6, NULL, '')
+			ON CONFLICT (report_id, user_id) DO UPDATE SET
+				reaction_type = $5,
+				modified_at = NOW(),
+				history_reaction_type = CASE
+					WHEN report_reactions.history_reaction_type = '' THEN report_reactions.reaction_type
+					ELSE report_reactions.history_reaction_type || ',' || report_reactions.reaction_type
+				END
+		`, reaction.ID, reaction.ReportID, reaction.UserID, reaction.UserEmail, reaction.ReactionType, reaction.CreatedAt)
+	} else {
+		_, err = tx.Exec(ctx, `
+			DELETE FROM report_reactions WHERE report_id = $1 AND user_id = $2
+		`, reaction.ReportID, reaction.UserID)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to toggle reaction: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", false, fmt.Errorf("failed to commit reaction toggle: %w", err)
+	}
+	return previousType, added, nil
+}
+
 // GetReactionCounts gets the count of each reaction type for a report
 func (p *PostgresClient) GetReactionCounts(ctx context.Context, reportID string) ([]models.ReactionCount, error) {
-	rows, err := p.pool.Query(ctx, `
+	rows, err := p.db.Query(ctx, `
 		SELECT reaction_type, COUNT(*) as count
 		FROM report_reactions
 		WHERE report_id = $1
@@ -692,7 +902,7 @@ func (p *PostgresClient) GetReactionCounts(ctx context.Context, reportID string)
 
 // GetUserReactions gets the reaction types a user has made on a report
 func (p *PostgresClient) GetUserReactions(ctx context.Context, reportID, userID string) ([]string, error) {
-	rows, err := p.pool.Query(ctx, `
+	rows, err := p.db.Query(ctx, `
 		SELECT reaction_type FROM report_reactions WHERE report_id = $1 AND user_id = $2
 	`, reportID, userID)
 	if err != nil {
@@ -741,9 +951,11 @@ func (p *PostgresClient) GetReportEngagement(ctx context.Context, reportID, user
 
 	// Get comment count
 	var commentCount int
-	err = p.pool.QueryRow(ctx, `
-		SELECT COUNT(*) FROM report_comments WHERE report_id = $1
-	`, reportID).Scan(&commentCount)
+	err = p.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM report_comments
+		WHERE report_id = $1 AND NOT flagged AND (user_id = $2 OR `+notShadowBanned("report_comments.user_id")+`)
+			AND `+notDeactivated("report_comments.user_id")+` AND `+notBlockedBy("$2", "report_comments.user_id")+`
+	`, reportID, userID).Scan(&commentCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comment count: %w", err)
 	}
@@ -769,7 +981,7 @@ func (p *PostgresClient) GetBulkReportEngagement(ctx context.Context, reportIDs
 	}
 
 	// Get reaction counts for all reports
-	rows, err := p.pool.Query(ctx, `
+	rows, err := p.db.Query(ctx, `
 		SELECT report_id, reaction_type, COUNT(*) as count
 		FROM report_reactions
 		WHERE report_id = ANY($1)
@@ -796,7 +1008,7 @@ func (p *PostgresClient) GetBulkReportEngagement(ctx context.Context, reportIDs
 
 	// Get user reactions if userID provided
 	if userID != "" {
-		userRows, err := p.pool.Query(ctx, `
+		userRows, err := p.db.Query(ctx, `
 			SELECT report_id, reaction_type FROM report_reactions WHERE report_id = ANY($1) AND user_id = $2
 		`, reportIDs, userID)
 		if err != nil {
@@ -816,12 +1028,13 @@ func (p *PostgresClient) GetBulkReportEngagement(ctx context.Context, reportIDs
 	}
 
 	// Get comment counts
-	countRows, err := p.pool.Query(ctx, `
+	countRows, err := p.db.Query(ctx, `
 		SELECT report_id, COUNT(*) as count
 		FROM report_comments
-		WHERE report_id = ANY($1)
+		WHERE report_id = ANY($1) AND NOT flagged AND (user_id = $2 OR `+notShadowBanned("report_comments.user_id")+`)
+			AND `+notDeactivated("report_comments.user_id")+` AND `+notBlockedBy("$2", "report_comments.user_id")+`
 		GROUP BY report_id
-	`, reportIDs)
+	`, reportIDs, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bulk comment counts: %w", err)
 	}
@@ -838,6 +1051,31 @@ func (p *PostgresClient) GetBulkReportEngagement(ctx context.Context, reportIDs
 		}
 	}
 
+	// Get the newest visible comment of each report
+	latestRows, err := p.db.Query(ctx, `
+		SELECT DISTINCT ON (report_id) report_id, id, author_name, content, created_at
+		FROM report_comments
+		WHERE report_id = ANY($1) AND NOT flagged AND (user_id = $2 OR `+notShadowBanned("report_comments.user_id")+`)
+			AND `+notDeactivated("report_comments.user_id")+` AND `+notBlockedBy("$2", "report_comments.user_id")+`
+		ORDER BY report_id, created_at DESC, id DESC
+	`, reportIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest comments: %w", err)
+	}
+	defer latestRows.Close()
+
+	for latestRows.Next() {
+		var reportID, content string
+		var preview models.CommentPreview
+		if err := latestRows.Scan(&reportID, &preview.ID, &preview.AuthorName, &content, &preview.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan latest comment: %w", err)
+		}
+		if e, ok := engagements[reportID]; ok {
+			preview.Snippet = models.CommentSnippet(content)
+			e.LatestComment = &preview
+		}
+	}
+
 	return engagements, nil
 }
 
@@ -847,24 +1085,27 @@ func (p *PostgresClient) GetBulkReportEngagement(ctx context.Context, reportIDs
 
 // AddComment adds a comment to a report
 func (p *PostgresClient) AddComment(ctx context.Context, comment *models.Comment) error {
-	_, err := p.pool.Exec(ctx, `
-		INSERT INTO report_comments (id, report_id, user_id, user_email, content, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-	`, comment.ID, comment.ReportID, comment.UserID, comment.UserEmail, comment.Content, comment.CreatedAt, comment.UpdatedAt)
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO report_comments (id, report_id, user_id, user_email, author_name, content, created_at, updated_at, flagged)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, comment.ID, comment.ReportID, comment.UserID, comment.UserEmail, comment.AuthorName, comment.Content, comment.CreatedAt, comment.UpdatedAt, comment.Flagged)
 	if err != nil {
 		return fmt.Errorf("failed to add comment: %w", err)
 	}
 	return nil
 }
 
-// GetComments gets all comments for a report
-func (p *PostgresClient) GetComments(ctx context.Context, reportID string) ([]models.Comment, error) {
-	rows, err := p.pool.Query(ctx, `
-		SELECT id, report_id, user_id, user_email, content, created_at, updated_at
+// GetComments gets the visible comments for a report. Flagged comments are held back,
+// comments by shadow-banned users are only returned to their author, and comments by
+// deactivated users or users the viewer has blocked are left out.
+func (p *PostgresClient) GetComments(ctx context.Context, reportID, viewerID string) ([]models.Comment, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT id, report_id, user_id, user_email, author_name, content, created_at, updated_at
 		FROM report_comments
-		WHERE report_id = $1
+		WHERE report_id = $1 AND NOT flagged AND (user_id = $2 OR `+notShadowBanned("report_comments.user_id")+`)
+			AND `+notDeactivated("report_comments.user_id")+` AND `+notBlockedBy("$2", "report_comments.user_id")+`
 		ORDER BY created_at ASC
-	`, reportID)
+	`, reportID, viewerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
@@ -873,7 +1114,7 @@ func (p *PostgresClient) GetComments(ctx context.Context, reportID string) ([]mo
 	var comments []models.Comment
 	for rows.Next() {
 		var c models.Comment
-		if err := rows.Scan(&c.ID, &c.ReportID, &c.UserID, &c.UserEmail, &c.Content, &c.CreatedAt, &c.UpdatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.ReportID, &c.UserID, &c.UserEmail, &c.AuthorName, &c.Content, &c.CreatedAt, &c.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan comment: %w", err)
 		}
 		comments = append(comments, c)
@@ -887,14 +1128,14 @@ func (p *PostgresClient) GetComments(ctx context.Context, reportID string) ([]mo
 
 // DeleteComment deletes a comment (only if user owns it)
 func (p *PostgresClient) DeleteComment(ctx context.Context, commentID, userID string) error {
-	result, err := p.pool.Exec(ctx, `
+	result, err := p.db.Exec(ctx, `
 		DELETE FROM report_comments WHERE id = $1 AND user_id = $2
 	`, commentID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete comment: %w", err)
 	}
 	if result.RowsAffected() == 0 {
-		return errors.New("comment not found or not authorized")
+		return forbidden("comment not found or not authorized")
 	}
 	return nil
 }
@@ -902,14 +1143,14 @@ func (p *PostgresClient) DeleteComment(ctx context.Context, commentID, userID st
 // GetCommentByID retrieves a comment by its ID
 func (p *PostgresClient) GetCommentByID(ctx context.Context, commentID string) (*models.Comment, error) {
 	comment := &models.Comment{}
-	err := p.pool.QueryRow(ctx, `
-		SELECT id, report_id, user_id, user_email, content, created_at, updated_at
+	err := p.db.QueryRow(ctx, `
+		SELECT id, report_id, user_id, user_email, author_name, content, created_at, updated_at, flagged
 		FROM report_comments WHERE id = $1
-	`, commentID).Scan(&comment.ID, &comment.ReportID, &comment.UserID, &comment.UserEmail,
-		&comment.Content, &comment.CreatedAt, &comment.UpdatedAt)
+	`, commentID).Scan(&comment.ID, &comment.ReportID, &comment.UserID, &comment.UserEmail, &comment.AuthorName,
+		&comment.Content, &comment.CreatedAt, &comment.UpdatedAt, &comment.Flagged)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New("comment not found")
+			return nil, notFound("comment")
 		}
 		return nil, fmt.Errorf("failed to get comment: %w", err)
 	}
@@ -919,7 +1160,7 @@ func (p *PostgresClient) GetCommentByID(ctx context.Context, commentID string) (
 // AdjustReportPriority increments or decrements a report's priority by delta
 func (p *PostgresClient) AdjustReportPriority(ctx context.Context, reportID string, delta int) error {
 	// Use COALESCE to handle NULL priority values (default to 100)
-	_, err := p.pool.Exec(ctx, `
+	_, err := p.db.Exec(ctx, `
 		UPDATE reports
 		SET priority = COALESCE(priority, 100) + $2, updated_at = $3
 		WHERE id = $1 AND status != $4
@@ -929,3 +1170,22 @@ func (p *PostgresClient) AdjustReportPriority(ctx context.Context, reportID stri
 	}
 	return nil
 }
+
+// ChangeReportPriority sets or adjusts a report's priority within MinPriority..MaxPriority
+// and returns the new priority
+func (p *PostgresClient) ChangeReportPriority(ctx context.Context, reportID string, change models.PriorityChange) (int, error) {
+	var priority int
+	err := p.db.QueryRow(ctx, `
+		UPDATE reports
+		SET priority = LEAST(GREATEST(COALESCE($2::int, COALESCE(priority, 100) + $3), $4), $5), updated_at = $6
+		WHERE id = $1 AND status != $7
+		RETURNING priority
+	`, reportID, change.Set, change.Delta, models.MinPriority, models.MaxPriority, time.Now(), models.StatusDeleted).Scan(&priority)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, notFound("report")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to change report priority: %w", err)
+	}
+	return priority, nil
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// User Activity Methods
+// ============================================================================
+
+// ListUserComments retrieves a user's comments across reports, newest first,
+// including comments held for review. Comments on deleted reports are left out.
+func (p *PostgresClient) ListUserComments(ctx context.Context, userID string, limit int) ([]models.CommentActivity, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT c.id, c.report_id, c.user_id, c.user_email, c.author_name, c.content, c.created_at, c.updated_at, c.flagged,
+			r.title, r.status
+		FROM report_comments c
+		JOIN reports r ON r.id = c.report_id
+		WHERE c.user_id = $1 AND r.status <> $2
+		ORDER BY c.created_at DESC
+		LIMIT $3
+	`, userID, models.StatusDeleted, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []models.CommentActivity{}
+	for rows.Next() {
+		var a models.CommentActivity
+		if err := rows.Scan(&a.ID, &a.ReportID, &a.UserID, &a.UserEmail, &a.AuthorName, &a.Content, &a.CreatedAt, &a.UpdatedAt, &a.Flagged,
+			&a.Report.Title, &a.Report.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan user comment: %w", err)
+		}
+		a.Report.ID = a.ReportID
+		comments = append(comments, a)
+	}
+	return comments, rows.Err()
+}
+
+// ListUserReactions retrieves a user's reactions across reports, most recently
+// changed first. Reactions on deleted reports are left out.
+func (p *PostgresClient) ListUserReactions(ctx context.Context, userID string, limit int) ([]models.ReactionActivity, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT rr.reaction_type, rr.created_at, rr.modified_at, r.id, r.title, r.status
+		FROM report_reactions rr
+		JOIN reports r ON r.id = rr.report_id
+		WHERE rr.user_id = $1 AND r.status <> $2
+		ORDER BY COALESCE(rr.modified_at, rr.created_at) DESC
+		LIMIT $3
+	`, userID, models.StatusDeleted, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user reactions: %w", err)
+	}
+	defer rows.Close()
+
+	reactions := []models.ReactionActivity{}
+	for rows.Next() {
+		var a models.ReactionActivity
+		if err := rows.Scan(&a.ReactionType, &a.CreatedAt, &a.ModifiedAt, &a.Report.ID, &a.Report.Title, &a.Report.Status); err != nil {
+			return nil, fmt.Errorf("failed to scan user reaction: %w", err)
+		}
+		reactions = append(reactions, a)
+	}
+	return reactions, rows.Err()
+}
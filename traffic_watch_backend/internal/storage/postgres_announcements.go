@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Announcement Methods
+// ============================================================================
+
+const announcementColumns = `id::text, title, message, severity, COALESCE(link_url, ''), starts_at, ends_at,
+	COALESCE(created_by, ''), created_at, updated_at`
+
+func scanAnnouncement(row pgx.Row, a *models.Announcement) error {
+	return row.Scan(&a.ID, &a.Title, &a.Message, &a.Severity, &a.LinkURL, &a.StartsAt, &a.EndsAt,
+		&a.CreatedBy, &a.CreatedAt, &a.UpdatedAt)
+}
+
+// CreateAnnouncement stores a new announcement
+func (p *PostgresClient) CreateAnnouncement(ctx context.Context, a *models.Announcement) error {
+	if a.ID == "" {
+		return errors.New("announcement ID is required")
+	}
+	now := time.Now()
+	a.CreatedAt = now
+	a.UpdatedAt = now
+
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO announcements (id, title, message, severity, link_url, starts_at, ends_at, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $9)
+	`, a.ID, a.Title, a.Message, a.Severity, a.LinkURL, a.StartsAt, a.EndsAt, a.CreatedBy, now)
+	if err != nil {
+		return fmt.Errorf("failed to create announcement: %w", err)
+	}
+	return nil
+}
+
+// UpdateAnnouncement replaces an announcement's content and schedule
+func (p *PostgresClient) UpdateAnnouncement(ctx context.Context, a *models.Announcement) error {
+	err := scanAnnouncement(p.db.QueryRow(ctx, `
+		UPDATE announcements
+		SET title = $2, message = $3, severity = $4, link_url = NULLIF($5, ''), starts_at = $6, ends_at = $7, updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+announcementColumns+`
+	`, a.ID, a.Title, a.Message, a.Severity, a.LinkURL, a.StartsAt, a.EndsAt), a)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return notFound("announcement")
+		}
+		return fmt.Errorf("failed to update announcement: %w", err)
+	}
+	return nil
+}
+
+// DeleteAnnouncement removes an announcement
+func (p *PostgresClient) DeleteAnnouncement(ctx context.Context, announcementID string) error {
+	result, err := p.db.Exec(ctx, `DELETE FROM announcements WHERE id = $1`, announcementID)
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("announcement")
+	}
+	return nil
+}
+
+// ListAnnouncements retrieves every announcement, newest first
+func (p *PostgresClient) ListAnnouncements(ctx context.Context) ([]models.Announcement, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT `+announcementColumns+`
+		FROM announcements
+		ORDER BY starts_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list announcements: %w", err)
+	}
+	return scanAnnouncements(rows)
+}
+
+// ListActiveAnnouncements retrieves announcements whose window contains at, most severe first
+func (p *PostgresClient) ListActiveAnnouncements(ctx context.Context, at time.Time) ([]models.Announcement, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT `+announcementColumns+`
+		FROM announcements
+		WHERE starts_at <= $1 AND (ends_at IS NULL OR ends_at > $1)
+		ORDER BY CASE severity WHEN 'critical' THEN 0 WHEN 'warning' THEN 1 ELSE 2 END, starts_at DESC
+	`, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active announcements: %w", err)
+	}
+	return scanAnnouncements(rows)
+}
+
+func scanAnnouncements(rows pgx.Rows) ([]models.Announcement, error) {
+	defer rows.Close()
+
+	announcements := []models.Announcement{}
+	for rows.Next() {
+		var a models.Announcement
+		if err := scanAnnouncement(rows, &a); err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, rows.Err()
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// API Usage Methods
+// ============================================================================
+
+// AddAPIUsage adds request counts to the daily API usage totals in one statement
+func (p *PostgresClient) AddAPIUsage(ctx context.Context, usage []models.APIUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+	days := make([]string, len(usage))
+	routes := make([]string, len(usage))
+	consumers := make([]string, len(usage))
+	filters := make([]string, len(usage))
+	requests := make([]int64, len(usage))
+	errs := make([]int64, len(usage))
+	for i, u := range usage {
+		days[i], routes[i], consumers[i], filters[i] = u.Day, u.Route, u.Consumer, u.Filters
+		requests[i], errs[i] = u.Requests, u.Errors
+	}
+
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO api_usage (day, route, consumer, filters, requests, errors)
+		SELECT * FROM unnest($1::date[], $2::text[], $3::text[], $4::text[], $5::bigint[], $6::bigint[])
+		ON CONFLICT (day, route, consumer, filters) DO UPDATE
+		SET requests = api_usage.requests + EXCLUDED.requests, errors = api_usage.errors + EXCLUDED.errors
+	`, days, routes, consumers, filters, requests, errs)
+	if err != nil {
+		return fmt.Errorf("failed to add API usage: %w", err)
+	}
+	return nil
+}
+
+// ListAPIUsage retrieves the daily API usage totals from since (YYYY-MM-DD) on
+func (p *PostgresClient) ListAPIUsage(ctx context.Context, since string) ([]models.APIUsage, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), route, consumer, filters, requests, errors
+		FROM api_usage
+		WHERE day >= $1::date
+		ORDER BY day, route, consumer, filters
+	`, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := []models.APIUsage{}
+	for rows.Next() {
+		var u models.APIUsage
+		if err := rows.Scan(&u.Day, &u.Route, &u.Consumer, &u.Filters, &u.Requests, &u.Errors); err != nil {
+			return nil, fmt.Errorf("failed to scan API usage: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
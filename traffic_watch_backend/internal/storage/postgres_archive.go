@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Archive Methods
+// ============================================================================
+
+// ArchiveReportsCreatedBefore moves approved reports created before cutoff to "archived"
+// and returns their IDs
+func (p *PostgresClient) ArchiveReportsCreatedBefore(ctx context.Context, cutoff time.Time) ([]string, error) {
+	rows, err := p.db.Query(ctx, `
+		UPDATE reports
+		SET status = $1, updated_at = NOW(), status_changed_at = NOW()
+		WHERE status = $2 AND created_at < $3
+		RETURNING id::text
+	`, models.StatusArchived, models.StatusReviewedPass, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive reports: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan archived report: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// ListArchivedReports retrieves archived reports, newest first
+func (p *PostgresClient) ListArchivedReports(ctx context.Context) ([]models.TrafficReport, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT `+reportColumns+`
+		FROM reports
+		WHERE status = $1 AND `+notShadowBanned("reports.user_id")+` AND `+notDeactivated("reports.user_id")+`
+		ORDER BY created_at DESC
+	`, models.StatusArchived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived reports: %w", err)
+	}
+	defer rows.Close()
+
+	return p.scanReportsWithMedia(ctx, p.reader(), rows)
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Blocking Methods
+// ============================================================================
+
+// notBlockedBy returns a SQL condition that is true unless the viewer (a query
+// parameter such as "$2") has blocked the user in userColumn
+func notBlockedBy(viewerParam, userColumn string) string {
+	return `NOT EXISTS (SELECT 1 FROM user_blocks WHERE user_blocks.blocker_id = ` + viewerParam +
+		` AND user_blocks.blocked_id = ` + userColumn + `)`
+}
+
+// BlockUser records that blockerID has blocked blockedID (idempotent)
+func (p *PostgresClient) BlockUser(ctx context.Context, blockerID, blockedID string) error {
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO user_blocks (blocker_id, blocked_id)
+		VALUES ($1, $2)
+		ON CONFLICT (blocker_id, blocked_id) DO NOTHING
+	`, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to block user: %w", err)
+	}
+	return nil
+}
+
+// UnblockUser removes a block
+func (p *PostgresClient) UnblockUser(ctx context.Context, blockerID, blockedID string) error {
+	result, err := p.db.Exec(ctx, `
+		DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2
+	`, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("failed to unblock user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("block")
+	}
+	return nil
+}
+
+// ListBlockedUsers retrieves the users blockerID has blocked, newest first
+func (p *PostgresClient) ListBlockedUsers(ctx context.Context, blockerID string) ([]models.UserBlock, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT blocker_id, blocked_id, created_at
+		FROM user_blocks
+		WHERE blocker_id = $1
+		ORDER BY created_at DESC
+	`, blockerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked users: %w", err)
+	}
+	defer rows.Close()
+
+	blocks := []models.UserBlock{}
+	for rows.Next() {
+		var b models.UserBlock
+		if err := rows.Scan(&b.BlockerID, &b.BlockedID, &b.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked user: %w", err)
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, rows.Err()
+}
+
+// IsUserBlocked reports whether blockerID has blocked blockedID
+func (p *PostgresClient) IsUserBlocked(ctx context.Context, blockerID, blockedID string) (bool, error) {
+	var blocked bool
+	err := p.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2)
+	`, blockerID, blockedID).Scan(&blocked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	return blocked, nil
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Account Deactivation Methods
+// ============================================================================
+
+// notDeactivated returns a SQL condition that is true unless the user in userColumn is deactivated
+func notDeactivated(userColumn string) string {
+	return `NOT EXISTS (SELECT 1 FROM users WHERE users.id = ` + userColumn + ` AND users.deactivated_at IS NOT NULL)`
+}
+
+// DeactivateUser marks a user's account deactivated and revokes their session
+func (p *PostgresClient) DeactivateUser(ctx context.Context, userID string, reason models.DeactivationReason) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE users SET deactivated_at = NOW(), deactivation_reason = $2, jwt_refresh_token = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, userID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("user")
+	}
+	return nil
+}
+
+// ReactivateUser clears a user's deactivation
+func (p *PostgresClient) ReactivateUser(ctx context.Context, userID string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE users SET deactivated_at = NULL, deactivation_reason = NULL, updated_at = NOW() WHERE id = $1
+	`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("user")
+	}
+	return nil
+}
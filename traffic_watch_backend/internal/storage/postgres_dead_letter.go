@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Dead Letter Methods
+// ============================================================================
+
+const mediaFailureColumns = `id, job, report_id, COALESCE(media_id, ''), file_name, error, attempts,
+	created_at, last_attempt_at, resolved_at`
+
+func scanMediaFailure(row pgx.Row, f *models.MediaFailure) error {
+	return row.Scan(&f.ID, &f.Job, &f.ReportID, &f.MediaID, &f.FileName, &f.Error, &f.Attempts,
+		&f.CreatedAt, &f.LastAttemptAt, &f.ResolvedAt)
+}
+
+// RecordMediaFailure adds a permanently failed media job to the dead-letter queue
+func (p *PostgresClient) RecordMediaFailure(ctx context.Context, failure *models.MediaFailure) error {
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO dead_letter (id, job, report_id, media_id, file_name, error, attempts, created_at, last_attempt_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, 1, $7, $7)
+	`, failure.ID, failure.Job, failure.ReportID, failure.MediaID, failure.FileName, failure.Error, failure.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record media failure: %w", err)
+	}
+	return nil
+}
+
+// ListMediaFailures retrieves failed media jobs, newest first. Resolved ones are left out
+// unless includeResolved is set.
+func (p *PostgresClient) ListMediaFailures(ctx context.Context, includeResolved bool) ([]models.MediaFailure, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT `+mediaFailureColumns+`
+		FROM dead_letter
+		WHERE $1 OR resolved_at IS NULL
+		ORDER BY created_at DESC
+	`, includeResolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media failures: %w", err)
+	}
+	defer rows.Close()
+
+	failures := []models.MediaFailure{}
+	for rows.Next() {
+		var f models.MediaFailure
+		if err := scanMediaFailure(rows, &f); err != nil {
+			return nil, fmt.Errorf("failed to scan media failure: %w", err)
+		}
+		failures = append(failures, f)
+	}
+	return failures, rows.Err()
+}
+
+// GetMediaFailure retrieves one failed media job
+func (p *PostgresClient) GetMediaFailure(ctx context.Context, id string) (*models.MediaFailure, error) {
+	var f models.MediaFailure
+	err := scanMediaFailure(p.db.QueryRow(ctx, `SELECT `+mediaFailureColumns+` FROM dead_letter WHERE id = $1`, id), &f)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, notFound("media failure")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media failure: %w", err)
+	}
+	return &f, nil
+}
+
+// RecordMediaRetry counts a retry of a failed media job. A nil retryErr marks the job
+// resolved; otherwise its error is kept as the latest one.
+func (p *PostgresClient) RecordMediaRetry(ctx context.Context, id string, retryErr error) error {
+	now := time.Now()
+	var resolvedAt *time.Time
+	errMsg := ""
+	if retryErr == nil {
+		resolvedAt = &now
+	} else {
+		errMsg = retryErr.Error()
+	}
+	result, err := p.db.Exec(ctx, `
+		UPDATE dead_letter
+		SET attempts = attempts + 1, last_attempt_at = $2, resolved_at = $3, error = COALESCE(NULLIF($4, ''), error)
+		WHERE id = $1
+	`, id, now, resolvedAt, errMsg)
+	if err != nil {
+		return fmt.Errorf("failed to record media retry: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("media failure")
+	}
+	return nil
+}
+
+// MoveMediaFile points a report's media file at a new ID and URL, e.g. once a video
+// kept in GCS has been uploaded to YouTube
+func (p *PostgresClient) MoveMediaFile(ctx context.Context, reportID, mediaID, newID, url string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE media_files SET id = $3, url = $4 WHERE report_id = $1 AND id = $2
+	`, reportID, mediaID, newID, url)
+	if err != nil {
+		return fmt.Errorf("failed to move media file: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("media file")
+	}
+	return nil
+}
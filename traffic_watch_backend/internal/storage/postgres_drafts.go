@@ -0,0 +1,159 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Report Draft Methods
+// ============================================================================
+
+const draftColumns = `id::text, user_id, title, description, road_usage, event_type, state, city,
+	latitude, longitude, created_at, updated_at, reminded_at`
+
+func scanDraft(row pgx.Row, d *models.ReportDraft) error {
+	return row.Scan(&d.ID, &d.UserID, &d.Title, &d.Description, &d.RoadUsages, &d.EventTypes,
+		&d.State, &d.City, &d.Latitude, &d.Longitude, &d.CreatedAt, &d.UpdatedAt, &d.RemindedAt)
+}
+
+func scanDrafts(rows pgx.Rows) ([]models.ReportDraft, error) {
+	defer rows.Close()
+	drafts := []models.ReportDraft{}
+	for rows.Next() {
+		var d models.ReportDraft
+		if err := scanDraft(rows, &d); err != nil {
+			return nil, fmt.Errorf("failed to scan draft: %w", err)
+		}
+		drafts = append(drafts, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate drafts: %w", err)
+	}
+	return drafts, nil
+}
+
+// CreateReportDraft stores a new report draft
+func (p *PostgresClient) CreateReportDraft(ctx context.Context, d *models.ReportDraft) error {
+	if d.ID == "" {
+		return errors.New("draft ID is required")
+	}
+	now := time.Now()
+	d.CreatedAt = now
+	d.UpdatedAt = now
+
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO report_drafts (id, user_id, title, description, road_usage, event_type, state, city, latitude, longitude, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $11)
+	`, d.ID, d.UserID, d.Title, d.Description, d.RoadUsages, d.EventTypes, d.State, d.City,
+		d.Latitude, d.Longitude, now)
+	if err != nil {
+		return fmt.Errorf("failed to create draft: %w", err)
+	}
+	return nil
+}
+
+// ListReportDrafts retrieves a user's report drafts, most recently edited first
+func (p *PostgresClient) ListReportDrafts(ctx context.Context, userID string) ([]models.ReportDraft, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT `+draftColumns+`
+		FROM report_drafts
+		WHERE user_id = $1
+		ORDER BY updated_at DESC
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+	return scanDrafts(rows)
+}
+
+// GetReportDraft retrieves one of a user's report drafts
+func (p *PostgresClient) GetReportDraft(ctx context.Context, draftID, userID string) (*models.ReportDraft, error) {
+	if _, err := uuid.Parse(draftID); err != nil {
+		return nil, notFound("draft")
+	}
+
+	var d models.ReportDraft
+	err := scanDraft(p.db.QueryRow(ctx, `
+		SELECT `+draftColumns+`
+		FROM report_drafts WHERE id = $1 AND user_id = $2
+	`, draftID, userID), &d)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("draft")
+		}
+		return nil, fmt.Errorf("failed to get draft: %w", err)
+	}
+	return &d, nil
+}
+
+// UpdateReportDraft replaces one of a user's report drafts
+func (p *PostgresClient) UpdateReportDraft(ctx context.Context, d *models.ReportDraft) error {
+	if _, err := uuid.Parse(d.ID); err != nil {
+		return notFound("draft")
+	}
+
+	err := scanDraft(p.db.QueryRow(ctx, `
+		UPDATE report_drafts
+		SET title = $3, description = $4, road_usage = $5, event_type = $6, state = $7, city = $8,
+			latitude = $9, longitude = $10, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+draftColumns+`
+	`, d.ID, d.UserID, d.Title, d.Description, d.RoadUsages, d.EventTypes, d.State, d.City,
+		d.Latitude, d.Longitude), d)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return notFound("draft")
+		}
+		return fmt.Errorf("failed to update draft: %w", err)
+	}
+	return nil
+}
+
+// DeleteReportDraft removes one of a user's report drafts
+func (p *PostgresClient) DeleteReportDraft(ctx context.Context, draftID, userID string) error {
+	if _, err := uuid.Parse(draftID); err != nil {
+		return notFound("draft")
+	}
+
+	result, err := p.db.Exec(ctx, `
+		DELETE FROM report_drafts WHERE id = $1 AND user_id = $2
+	`, draftID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("draft")
+	}
+	return nil
+}
+
+// ListDraftsToRemind retrieves drafts last edited before updatedBefore that haven't been reminded about since
+func (p *PostgresClient) ListDraftsToRemind(ctx context.Context, updatedBefore time.Time) ([]models.ReportDraft, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT `+draftColumns+`
+		FROM report_drafts
+		WHERE updated_at < $1 AND (reminded_at IS NULL OR reminded_at < updated_at)
+		ORDER BY updated_at
+	`, updatedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drafts to remind: %w", err)
+	}
+	return scanDrafts(rows)
+}
+
+// MarkDraftReminded records when the owner was reminded about a draft
+func (p *PostgresClient) MarkDraftReminded(ctx context.Context, draftID string, at time.Time) error {
+	_, err := p.db.Exec(ctx, `UPDATE report_drafts SET reminded_at = $2 WHERE id = $1`, draftID, at)
+	if err != nil {
+		return fmt.Errorf("failed to mark draft reminded: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Scheduled Publication Methods
+// ============================================================================
+
+// published returns a SQL condition excluding reports still under embargo
+func published(publishAtColumn string) string {
+	return `(` + publishAtColumn + ` IS NULL OR ` + publishAtColumn + ` <= NOW())`
+}
+
+// SetReportPublishAt sets or clears the time an approved report enters the public feed
+func (p *PostgresClient) SetReportPublishAt(ctx context.Context, reportID string, publishAt *time.Time) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE reports SET publish_at = $2, updated_at = NOW()
+		WHERE id = $1 AND status != $3
+	`, reportID, publishAt, models.StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to update report publish time: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return notFound("report")
+	}
+
+	return nil
+}
+
+// NextScheduledPublication returns the earliest future publish time of an approved report (nil if none)
+func (p *PostgresClient) NextScheduledPublication(ctx context.Context) (*time.Time, error) {
+	var next *time.Time
+	err := p.reader().QueryRow(ctx, `
+		SELECT MIN(publish_at) FROM reports WHERE status = $1 AND publish_at > NOW()
+	`, models.StatusReviewedPass).Scan(&next)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next scheduled publication: %w", err)
+	}
+	return next, nil
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Feature Flag Methods
+// ============================================================================
+
+// ListFeatureFlags retrieves every stored feature flag
+func (p *PostgresClient) ListFeatureFlags(ctx context.Context) ([]models.FeatureFlag, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT key, COALESCE(description, ''), enabled, rollout_percent, user_ids, COALESCE(updated_by, ''), updated_at
+		FROM feature_flags
+		ORDER BY key
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	flags := []models.FeatureFlag{}
+	for rows.Next() {
+		var f models.FeatureFlag
+		if err := rows.Scan(&f.Key, &f.Description, &f.Enabled, &f.RolloutPercent, &f.UserIDs, &f.UpdatedBy, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+		flags = append(flags, f)
+	}
+	return flags, rows.Err()
+}
+
+// UpsertFeatureFlag creates or replaces a feature flag
+func (p *PostgresClient) UpsertFeatureFlag(ctx context.Context, flag *models.FeatureFlag) error {
+	if flag.UserIDs == nil {
+		flag.UserIDs = []string{}
+	}
+	err := p.db.QueryRow(ctx, `
+		INSERT INTO feature_flags (key, description, enabled, rollout_percent, user_ids, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (key) DO UPDATE SET
+			description = EXCLUDED.description,
+			enabled = EXCLUDED.enabled,
+			rollout_percent = EXCLUDED.rollout_percent,
+			user_ids = EXCLUDED.user_ids,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`, flag.Key, flag.Description, flag.Enabled, flag.RolloutPercent, flag.UserIDs, flag.UpdatedBy).Scan(&flag.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert feature flag: %w", err)
+	}
+	return nil
+}
+
+// DeleteFeatureFlag removes a feature flag
+func (p *PostgresClient) DeleteFeatureFlag(ctx context.Context, key string) error {
+	result, err := p.db.Exec(ctx, `DELETE FROM feature_flags WHERE key = $1`, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("feature flag")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+	"donzhit_me_backend/internal/ranking"
+)
+
+// ============================================================================
+// Hot Score Methods
+// ============================================================================
+
+// RefreshHotScores recomputes the stored hot score of every approved report
+func (p *PostgresClient) RefreshHotScores(ctx context.Context) error {
+	rows, err := p.reader().Query(ctx, `
+		SELECT id::text, COALESCE(priority, 100), created_at,
+			(SELECT COUNT(*) FROM report_reactions WHERE report_id = reports.id),
+			(SELECT COUNT(*) FROM report_comments WHERE report_id = reports.id AND NOT flagged)
+		FROM reports
+		WHERE status = $1
+	`, models.StatusReviewedPass)
+	if err != nil {
+		return fmt.Errorf("failed to load hot score inputs: %w", err)
+	}
+	defer rows.Close()
+
+	now := time.Now()
+	var ids []string
+	var scores []float64
+	for rows.Next() {
+		var id string
+		var in ranking.Inputs
+		if err := rows.Scan(&id, &in.Priority, &in.CreatedAt, &in.Reactions, &in.Comments); err != nil {
+			return fmt.Errorf("failed to scan hot score inputs: %w", err)
+		}
+		ids = append(ids, id)
+		scores = append(scores, ranking.HotScore(in, now))
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate hot score inputs: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = p.db.Exec(ctx, `
+		UPDATE reports SET hot_score = s.score
+		FROM unnest($1::uuid[], $2::float8[]) AS s(id, score)
+		WHERE reports.id = s.id
+	`, ids, scores)
+	if err != nil {
+		return fmt.Errorf("failed to store hot scores: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Vehicle Matching and Incident Methods
+// ============================================================================
+
+// ListReportsByVehicleHashes retrieves non-deleted reports sharing any of the given vehicle hashes
+func (p *PostgresClient) ListReportsByVehicleHashes(ctx context.Context, hashes []string, excludeReportID string) ([]models.TrafficReport, error) {
+	if len(hashes) == 0 {
+		return []models.TrafficReport{}, nil
+	}
+
+	rows, err := p.db.Query(ctx, `
+		SELECT `+reportColumns+`
+		FROM reports
+		WHERE vehicle_hashes && $1 AND id::text != $2 AND status != $3
+		ORDER BY created_at DESC
+	`, hashes, excludeReportID, models.StatusDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports by vehicle hashes: %w", err)
+	}
+	defer rows.Close()
+
+	return p.scanReportsWithMedia(ctx, p.db, rows)
+}
+
+// LinkReportsToIncident groups reports into an incident, merging any incidents they already belong to
+func (p *PostgresClient) LinkReportsToIncident(ctx context.Context, incidentID string, reportIDs []string, note, linkedBy string) (*models.Incident, error) {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Find incidents the reports already belong to so clusters are merged transitively
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT incident_id::text FROM reports
+		WHERE id::text = ANY($1) AND incident_id IS NOT NULL
+	`, reportIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing incidents: %w", err)
+	}
+	var existing []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan incident ID: %w", err)
+		}
+		existing = append(existing, id)
+	}
+	rows.Close()
+
+	if incidentID == "" {
+		if len(existing) > 0 {
+			incidentID = existing[0]
+		} else {
+			incidentID = uuid.New().String()
+		}
+	}
+
+	now := time.Now()
+	_, err = tx.Exec(ctx, `
+		INSERT INTO incidents (id, note, created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (id) DO UPDATE SET
+			note = CASE WHEN $2 = '' THEN incidents.note ELSE $2 END,
+			updated_at = $4
+	`, incidentID, note, linkedBy, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert incident: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE reports SET incident_id = $1
+		WHERE id::text = ANY($2) AND status != $3
+	`, incidentID, reportIDs, models.StatusDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link reports: %w", err)
+	}
+	if int(result.RowsAffected()) != len(reportIDs) {
+		return nil, notFound("report")
+	}
+
+	// Fold previously separate incidents into the target
+	if len(existing) > 0 {
+		if _, err := tx.Exec(ctx, `
+			UPDATE reports SET incident_id = $1 WHERE incident_id::text = ANY($2)
+		`, incidentID, existing); err != nil {
+			return nil, fmt.Errorf("failed to merge incidents: %w", err)
+		}
+		if _, err := tx.Exec(ctx, `
+			DELETE FROM incidents WHERE id::text = ANY($1) AND id != $2
+		`, existing, incidentID); err != nil {
+			return nil, fmt.Errorf("failed to remove merged incidents: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit incident link: %w", err)
+	}
+
+	return p.GetIncident(ctx, incidentID)
+}
+
+// GetIncident retrieves an incident and the IDs of its linked reports
+func (p *PostgresClient) GetIncident(ctx context.Context, incidentID string) (*models.Incident, error) {
+	incident := &models.Incident{}
+	err := p.db.QueryRow(ctx, `
+		SELECT id, COALESCE(note, ''), created_by, created_at, updated_at
+		FROM incidents WHERE id = $1
+	`, incidentID).Scan(&incident.ID, &incident.Note, &incident.CreatedBy, &incident.CreatedAt, &incident.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("incident")
+		}
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	rows, err := p.db.Query(ctx, `
+		SELECT id FROM reports WHERE incident_id = $1 AND status != $2 ORDER BY created_at ASC
+	`, incidentID, models.StatusDeleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incident reports: %w", err)
+	}
+	defer rows.Close()
+
+	incident.ReportIDs = []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan report ID: %w", err)
+		}
+		incident.ReportIDs = append(incident.ReportIDs, id)
+	}
+
+	return incident, nil
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Role Invitation and Audit Log Methods
+// ============================================================================
+
+const insertAuditEntrySQL = `
+	INSERT INTO audit_log (id, actor_id, action, target_id, details, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+// CreateRoleInvitation stores a new role invitation
+func (p *PostgresClient) CreateRoleInvitation(ctx context.Context, inv *models.RoleInvitation) error {
+	if inv.ID == "" {
+		return errors.New("invitation ID is required")
+	}
+	inv.CreatedAt = time.Now()
+
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO role_invitations (id, email, role, invited_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, inv.ID, inv.Email, inv.Role, inv.InvitedBy, inv.CreatedAt, inv.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %w", err)
+	}
+	return nil
+}
+
+// GetRoleInvitation retrieves a role invitation by ID
+func (p *PostgresClient) GetRoleInvitation(ctx context.Context, id string) (*models.RoleInvitation, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, notFound("invitation")
+	}
+
+	var inv models.RoleInvitation
+	err := p.db.QueryRow(ctx, `
+		SELECT id::text, email, role, invited_by, created_at, expires_at, accepted_at, COALESCE(accepted_by, '')
+		FROM role_invitations WHERE id = $1
+	`, id).Scan(&inv.ID, &inv.Email, &inv.Role, &inv.InvitedBy, &inv.CreatedAt, &inv.ExpiresAt, &inv.AcceptedAt, &inv.AcceptedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("invitation")
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	return &inv, nil
+}
+
+// ListPendingRoleInvitations retrieves unaccepted, unexpired invitations, newest first
+func (p *PostgresClient) ListPendingRoleInvitations(ctx context.Context) ([]models.RoleInvitation, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT id::text, email, role, invited_by, created_at, expires_at
+		FROM role_invitations
+		WHERE accepted_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	defer rows.Close()
+
+	invitations := []models.RoleInvitation{}
+	for rows.Next() {
+		var inv models.RoleInvitation
+		if err := rows.Scan(&inv.ID, &inv.Email, &inv.Role, &inv.InvitedBy, &inv.CreatedAt, &inv.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan invitation: %w", err)
+		}
+		invitations = append(invitations, inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+// AcceptRoleInvitation grants the invitation's role to userID, marks it
+// accepted and records it in the audit log, all in one transaction
+func (p *PostgresClient) AcceptRoleInvitation(ctx context.Context, id, userID string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return notFound("invitation")
+	}
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the invitation so it can only be accepted once
+	var role models.UserRole
+	var expiresAt time.Time
+	var acceptedAt *time.Time
+	err = tx.QueryRow(ctx, `
+		SELECT role, expires_at, accepted_at FROM role_invitations WHERE id = $1 FOR UPDATE
+	`, id).Scan(&role, &expiresAt, &acceptedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return notFound("invitation")
+		}
+		return fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if acceptedAt != nil {
+		return conflict("invitation already accepted")
+	}
+	now := time.Now()
+	if !now.Before(expiresAt) {
+		return ErrInvitationExpired
+	}
+
+	// Invitations only ever raise a role; lowering one is an explicit role change
+	var current models.UserRole
+	err = tx.QueryRow(ctx, `SELECT role FROM users WHERE id = $1 FOR UPDATE`, userID).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return notFound("user")
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if role.Rank() < current.Rank() {
+		return ErrRoleDowngrade
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE users SET role = $2, updated_at = $3 WHERE id = $1
+	`, userID, role, now); err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE role_invitations SET accepted_at = $2, accepted_by = $3 WHERE id = $1
+	`, id, now, userID); err != nil {
+		return fmt.Errorf("failed to mark invitation accepted: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, insertAuditEntrySQL,
+		uuid.New().String(), userID, models.AuditInvitationAccepted, id, "role="+string(role), now); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// CreateAuditEntry appends an entry to the audit log
+func (p *PostgresClient) CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) error {
+	if entry.ID == "" {
+		return errors.New("audit entry ID is required")
+	}
+	entry.CreatedAt = time.Now()
+
+	if _, err := p.db.Exec(ctx, insertAuditEntrySQL,
+		entry.ID, entry.ActorID, entry.Action, entry.TargetID, entry.Details, entry.CreatedAt); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// ListAuditEntries retrieves up to limit audit log entries, newest first
+func (p *PostgresClient) ListAuditEntries(ctx context.Context, limit int) ([]models.AuditEntry, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT id::text, actor_id, action, target_id, details, created_at
+		FROM audit_log
+		ORDER BY created_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		var e models.AuditEntry
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.Action, &e.TargetID, &e.Details, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate audit log: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Map Methods
+// ============================================================================
+
+// ListApprovedReportLocations retrieves coordinates of approved reports inside the bounding box
+func (p *PostgresClient) ListApprovedReportLocations(ctx context.Context, box models.BoundingBox) ([]models.ReportLocation, error) {
+	// A box with minLng > maxLng crosses the antimeridian and wraps around
+	rows, err := p.reader().Query(ctx, `
+		SELECT id, latitude, longitude
+		FROM reports
+		WHERE status = $1 AND latitude IS NOT NULL AND longitude IS NOT NULL AND `+published("publish_at")+`
+			AND `+mediaSettled("reports.id")+` AND `+notShadowBanned("reports.user_id")+` AND `+notDeactivated("reports.user_id")+`
+			AND latitude BETWEEN $2 AND $3
+			AND (($4 <= $5 AND longitude BETWEEN $4 AND $5) OR ($4 > $5 AND (longitude >= $4 OR longitude <= $5)))
+	`, models.StatusReviewedPass, box.MinLat, box.MaxLat, box.MinLng, box.MaxLng)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := []models.ReportLocation{}
+	for rows.Next() {
+		var loc models.ReportLocation
+		if err := rows.Scan(&loc.ID, &loc.Latitude, &loc.Longitude); err != nil {
+			return nil, fmt.Errorf("failed to scan report location: %w", err)
+		}
+		locations = append(locations, loc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate report locations: %w", err)
+	}
+
+	return locations, nil
+}
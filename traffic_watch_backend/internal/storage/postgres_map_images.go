@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Static Map Methods
+// ============================================================================
+
+// ListReportsForMapImage retrieves non-deleted reports with coordinates that have
+// no static map image, newest first. Reports whose last attempt failed are left
+// out until retryBefore has passed it.
+func (p *PostgresClient) ListReportsForMapImage(ctx context.Context, retryBefore time.Time, limit int) ([]models.TrafficReport, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT id, user_id, latitude, longitude
+		FROM reports
+		WHERE latitude IS NOT NULL AND longitude IS NOT NULL AND map_image_path IS NULL AND status <> $1
+			AND (map_image_attempted_at IS NULL OR map_image_attempted_at < $2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`, models.StatusDeleted, retryBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports for map images: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []models.TrafficReport
+	for rows.Next() {
+		var r models.TrafficReport
+		if err := rows.Scan(&r.ID, &r.UserID, &r.Latitude, &r.Longitude); err != nil {
+			return nil, fmt.Errorf("failed to scan report for map image: %w", err)
+		}
+		reports = append(reports, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reports for map images: %w", err)
+	}
+	return reports, nil
+}
+
+// SetReportMapImage records an attempt to render a report's static map; an empty
+// objectPath records a failed attempt
+func (p *PostgresClient) SetReportMapImage(ctx context.Context, reportID, objectPath string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE reports SET map_image_path = NULLIF($2, ''), map_image_attempted_at = NOW()
+		WHERE id = $1
+	`, reportID, objectPath)
+	if err != nil {
+		return fmt.Errorf("failed to set map image: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("report")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Media Partitioning and Archival Methods
+// ============================================================================
+
+// mediaColumns lists the columns shared by media_files, media_files_archive and
+// the all_media_files view that covers both
+const mediaColumns = `id, report_id, file_name, content_type, size, url, uploaded_at, metadata, storage_path,
+	transcript, transcript_status, transcript_operation, ocr, state`
+
+// mediaPartition returns the name and bounds of the monthly media_files partition containing t
+func mediaPartition(t time.Time) (name string, from, to time.Time) {
+	t = t.UTC()
+	from = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return fmt.Sprintf("media_files_%04d_%02d", from.Year(), int(from.Month())), from, from.AddDate(0, 1, 0)
+}
+
+// EnsureMediaPartitions creates the monthly media_files partitions from the current
+// month through the month of through
+func (p *PostgresClient) EnsureMediaPartitions(ctx context.Context, through time.Time) ([]string, error) {
+	var created []string
+	_, last, _ := mediaPartition(through)
+	_, month, _ := mediaPartition(time.Now())
+	for ; !month.After(last); month = month.AddDate(0, 1, 0) {
+		name, from, to := mediaPartition(month)
+
+		var exists bool
+		if err := p.db.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists); err != nil {
+			return created, fmt.Errorf("failed to look up media partition %s: %w", name, err)
+		}
+		if exists {
+			continue
+		}
+
+		// Identifiers and bounds can't be bind parameters; all three are generated above
+		_, err := p.db.Exec(ctx, fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s PARTITION OF media_files FOR VALUES FROM ('%s') TO ('%s')`,
+			pgx.Identifier{name}.Sanitize(), from.Format(time.RFC3339), to.Format(time.RFC3339)))
+		if err != nil {
+			return created, fmt.Errorf("failed to create media partition %s: %w", name, err)
+		}
+		created = append(created, name)
+	}
+	return created, nil
+}
+
+// ArchiveMedia moves up to limit media rows of archived reports from media_files to media_files_archive.
+// media_files can't enforce unique ids (its key includes uploaded_at); the archive's
+// unique index on id rejects a batch that would archive a colliding id.
+func (p *PostgresClient) ArchiveMedia(ctx context.Context, limit int) (int, error) {
+	result, err := p.db.Exec(ctx, `
+		WITH moved AS (
+			DELETE FROM media_files
+			WHERE (id, uploaded_at) IN (
+				SELECT m.id, m.uploaded_at
+				FROM media_files m
+				JOIN reports r ON r.id = m.report_id
+				WHERE r.status = $1
+				LIMIT $2
+			)
+			RETURNING `+mediaColumns+`
+		)
+		INSERT INTO media_files_archive (`+mediaColumns+`)
+		SELECT `+mediaColumns+` FROM moved
+	`, models.StatusArchived, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive media: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// restoreArchivedMedia moves a report's media back from media_files_archive, for
+// operations that update media in place
+func restoreArchivedMedia(ctx context.Context, db conn, reportID string) error {
+	_, err := db.Exec(ctx, `
+		WITH restored AS (
+			DELETE FROM media_files_archive WHERE report_id = $1
+			RETURNING `+mediaColumns+`
+		)
+		INSERT INTO media_files (`+mediaColumns+`)
+		SELECT `+mediaColumns+` FROM restored
+	`, reportID)
+	if err != nil {
+		return fmt.Errorf("failed to restore archived media: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMediaPartition(t *testing.T) {
+	// Month boundaries are UTC, whatever the time's location
+	est := time.FixedZone("EST", -5*3600)
+	name, from, to := mediaPartition(time.Date(2026, 1, 31, 22, 0, 0, 0, est))
+	if name != "media_files_2026_02" {
+		t.Errorf("name = %q, want media_files_2026_02", name)
+	}
+	if want := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC); !from.Equal(want) {
+		t.Errorf("from = %s, want %s", from, want)
+	}
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC); !to.Equal(want) {
+		t.Errorf("to = %s, want %s", to, want)
+	}
+
+	if name, _, to := mediaPartition(time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC)); name != "media_files_2026_12" || to.Year() != 2027 || to.Month() != time.January {
+		t.Errorf("December partition = %s ending %s", name, to)
+	}
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Media State Methods
+// ============================================================================
+
+// mediaSettled returns a SQL condition that is true unless the report in reportColumn has
+// media that is still processing or quarantined
+func mediaSettled(reportColumn string) string {
+	return `NOT EXISTS (SELECT 1 FROM media_files WHERE media_files.report_id = ` + reportColumn +
+		` AND media_files.state IN ('` + models.MediaProcessing + `', '` + models.MediaQuarantined + `'))`
+}
+
+// ListPendingMedia retrieves processing and quarantined media on non-deleted reports,
+// oldest upload first
+func (p *PostgresClient) ListPendingMedia(ctx context.Context, limit int) ([]models.MediaStateJob, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT m.report_id::text, r.user_id, m.id, m.file_name, m.content_type, m.size, m.uploaded_at,
+			COALESCE(m.storage_path, ''), m.state
+		FROM media_files m
+		JOIN reports r ON r.id = m.report_id
+		WHERE m.state IN ($1, $2) AND r.status <> $3
+		ORDER BY m.uploaded_at
+		LIMIT $4
+	`, models.MediaProcessing, models.MediaQuarantined, models.StatusDeleted, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending media: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.MediaStateJob
+	for rows.Next() {
+		var job models.MediaStateJob
+		mf := &job.Media
+		if err := rows.Scan(&job.ReportID, &job.UserID, &mf.ID, &mf.FileName, &mf.ContentType, &mf.Size, &mf.UploadedAt,
+			&mf.StoragePath, &mf.State); err != nil {
+			return nil, fmt.Errorf("failed to scan pending media: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate pending media: %w", err)
+	}
+	return jobs, nil
+}
+
+// SetMediaState moves a media file from state from to state to. It fails if the
+// transition isn't allowed or the media file is no longer in state from.
+func (p *PostgresClient) SetMediaState(ctx context.Context, reportID, mediaID, from, to string) error {
+	if !models.ValidMediaTransition(from, to) {
+		return fmt.Errorf("invalid media state transition from %q to %q", from, to)
+	}
+	result, err := p.db.Exec(ctx, `
+		UPDATE media_files SET state = $4
+		WHERE report_id = $1 AND id = $2 AND state = $3
+	`, reportID, mediaID, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to update media state: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("media file")
+	}
+	return nil
+}
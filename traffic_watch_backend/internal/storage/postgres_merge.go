@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Duplicate Merge Methods
+// ============================================================================
+
+// MergeReports merges duplicateID into canonicalID inside a single transaction
+func (p *PostgresClient) MergeReports(ctx context.Context, canonicalID, duplicateID, mergedBy string) error {
+	if canonicalID == duplicateID {
+		return errors.New("cannot merge a report into itself")
+	}
+
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock both rows so concurrent merges or reviews can't interleave
+	rows, err := tx.Query(ctx, `
+		SELECT id::text, user_id, status FROM reports WHERE id = ANY($1::uuid[]) FOR UPDATE
+	`, []string{canonicalID, duplicateID})
+	if err != nil {
+		return fmt.Errorf("failed to lock reports: %w", err)
+	}
+	statuses := make(map[string]string)
+	owners := make(map[string]string)
+	for rows.Next() {
+		var id, userID, status string
+		if err := rows.Scan(&id, &userID, &status); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan report: %w", err)
+		}
+		statuses[id] = status
+		owners[id] = userID
+	}
+	rows.Close()
+
+	for _, id := range []string{canonicalID, duplicateID} {
+		status, ok := statuses[id]
+		if !ok || status == models.StatusDeleted {
+			return notFound("report")
+		}
+		if status == models.StatusMerged {
+			return conflict("report already merged")
+		}
+	}
+	if err := models.CheckStatusTransition(statuses[duplicateID], models.StatusMerged); err != nil {
+		return err
+	}
+
+	// Move media, keeping the original object path for GCS-hosted files. An archived
+	// duplicate's media is brought back from the cold table first.
+	if err := restoreArchivedMedia(ctx, tx, duplicateID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE media_files
+		SET report_id = $1,
+			storage_path = CASE
+				WHEN COALESCE(storage_path, '') != '' THEN storage_path
+				WHEN url LIKE '%youtube.com%' OR url LIKE '%youtu.be%' THEN ''
+				ELSE 'users/' || $3 || '/reports/' || $2 || '/' || id
+			END
+		WHERE report_id = $2
+	`, canonicalID, duplicateID, owners[duplicateID]); err != nil {
+		return fmt.Errorf("failed to move media files: %w", err)
+	}
+
+	// Move reactions; a user who reacted to both keeps their reaction on the canonical report
+	if _, err := tx.Exec(ctx, `
+		UPDATE report_reactions SET report_id = $1
+		WHERE report_id = $2
+		  AND user_id NOT IN (SELECT user_id FROM report_reactions WHERE report_id = $1)
+	`, canonicalID, duplicateID); err != nil {
+		return fmt.Errorf("failed to move reactions: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM report_reactions WHERE report_id = $1`, duplicateID); err != nil {
+		return fmt.Errorf("failed to remove duplicate reactions: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE report_comments SET report_id = $1 WHERE report_id = $2
+	`, canonicalID, duplicateID); err != nil {
+		return fmt.Errorf("failed to move comments: %w", err)
+	}
+
+	// Carry over engagement-driven priority, vehicle hashes, and incident link
+	now := time.Now()
+	if _, err := tx.Exec(ctx, `
+		UPDATE reports c
+		SET priority = CASE
+				WHEN c.priority IS NULL AND d.priority IS NULL THEN NULL
+				ELSE COALESCE(c.priority, 100) + COALESCE(d.priority, 100) - 100
+			END,
+			vehicle_hashes = ARRAY(SELECT DISTINCT unnest(COALESCE(c.vehicle_hashes, '{}') || COALESCE(d.vehicle_hashes, '{}'))),
+			incident_id = COALESCE(c.incident_id, d.incident_id),
+			updated_at = $3
+		FROM reports d
+		WHERE c.id = $1 AND d.id = $2
+	`, canonicalID, duplicateID, now); err != nil {
+		return fmt.Errorf("failed to update canonical report: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE reports SET status = $2, updated_at = $3, status_changed_at = $3 WHERE id = $1
+	`, duplicateID, models.StatusMerged, now); err != nil {
+		return fmt.Errorf("failed to mark report merged: %w", err)
+	}
+
+	// Redirect the duplicate (and anything previously merged into it) to the canonical report
+	if _, err := tx.Exec(ctx, `
+		UPDATE report_redirects SET to_report_id = $1 WHERE to_report_id = $2
+	`, canonicalID, duplicateID); err != nil {
+		return fmt.Errorf("failed to update existing redirects: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO report_redirects (from_report_id, to_report_id, merged_by, merged_at)
+		VALUES ($1, $2, $3, $4)
+	`, duplicateID, canonicalID, mergedBy, now); err != nil {
+		return fmt.Errorf("failed to create redirect: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetReportRedirect returns the canonical report ID a merged report redirects to ("" if none)
+func (p *PostgresClient) GetReportRedirect(ctx context.Context, reportID string) (string, error) {
+	var target string
+	err := p.db.QueryRow(ctx, `
+		SELECT to_report_id::text FROM report_redirects WHERE from_report_id = $1
+	`, reportID).Scan(&target)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get report redirect: %w", err)
+	}
+	return target, nil
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Moderation Methods
+// ============================================================================
+
+// ListBlockedWords retrieves every entry in the word filter
+func (p *PostgresClient) ListBlockedWords(ctx context.Context) ([]models.BlockedWord, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT word, action, COALESCE(created_by, ''), created_at
+		FROM blocked_words
+		ORDER BY word
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blocked words: %w", err)
+	}
+	defer rows.Close()
+
+	words := []models.BlockedWord{}
+	for rows.Next() {
+		var w models.BlockedWord
+		if err := rows.Scan(&w.Word, &w.Action, &w.CreatedBy, &w.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan blocked word: %w", err)
+		}
+		words = append(words, w)
+	}
+	return words, rows.Err()
+}
+
+// UpsertBlockedWord adds a blocked word or changes its action. Words are stored lowercased.
+func (p *PostgresClient) UpsertBlockedWord(ctx context.Context, word *models.BlockedWord) error {
+	word.Word = strings.ToLower(strings.TrimSpace(word.Word))
+	err := p.db.QueryRow(ctx, `
+		INSERT INTO blocked_words (word, action, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (word) DO UPDATE SET action = EXCLUDED.action
+		RETURNING COALESCE(created_by, ''), created_at
+	`, word.Word, word.Action, word.CreatedBy).Scan(&word.CreatedBy, &word.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert blocked word: %w", err)
+	}
+	return nil
+}
+
+// DeleteBlockedWord removes a word from the filter
+func (p *PostgresClient) DeleteBlockedWord(ctx context.Context, word string) error {
+	result, err := p.db.Exec(ctx, `
+		DELETE FROM blocked_words WHERE word = $1
+	`, strings.ToLower(strings.TrimSpace(word)))
+	if err != nil {
+		return fmt.Errorf("failed to delete blocked word: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("blocked word")
+	}
+	return nil
+}
+
+// ListFlaggedComments retrieves comments held for moderator review, oldest first
+func (p *PostgresClient) ListFlaggedComments(ctx context.Context, includeShadowBanned bool) ([]models.Comment, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT id, report_id, user_id, user_email, author_name, content, created_at, updated_at, flagged
+		FROM report_comments
+		WHERE flagged AND ($1 OR `+notShadowBanned("report_comments.user_id")+`)
+		ORDER BY created_at ASC
+	`, includeShadowBanned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flagged comments: %w", err)
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		var c models.Comment
+		if err := rows.Scan(&c.ID, &c.ReportID, &c.UserID, &c.UserEmail, &c.AuthorName, &c.Content, &c.CreatedAt, &c.UpdatedAt, &c.Flagged); err != nil {
+			return nil, fmt.Errorf("failed to scan comment: %w", err)
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
+
+// ResolveFlaggedComment publishes a flagged comment when approve is true, otherwise deletes it
+func (p *PostgresClient) ResolveFlaggedComment(ctx context.Context, commentID string, approve bool) error {
+	query := `UPDATE report_comments SET flagged = false, updated_at = NOW() WHERE id = $1 AND flagged`
+	if !approve {
+		query = `DELETE FROM report_comments WHERE id = $1 AND flagged`
+	}
+
+	result, err := p.db.Exec(ctx, query, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve flagged comment: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("comment")
+	}
+	return nil
+}
+
+// CountIdenticalComments counts a user's comments since the given time whose content
+// matches content, ignoring case and surrounding whitespace
+func (p *PostgresClient) CountIdenticalComments(ctx context.Context, userID, content string, since time.Time) (int, error) {
+	var count int
+	err := p.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM report_comments
+		WHERE user_id = $1 AND created_at >= $2 AND LOWER(BTRIM(content)) = LOWER(BTRIM($3))
+	`, userID, since, content).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count identical comments: %w", err)
+	}
+	return count, nil
+}
+
+// GetCommentPolicy retrieves the comment spam policy
+func (p *PostgresClient) GetCommentPolicy(ctx context.Context) (*models.CommentPolicy, error) {
+	var policy models.CommentPolicy
+	var updatedAt time.Time
+	err := p.db.QueryRow(ctx, `
+		SELECT rate_limit, rate_window_minutes, link_action,
+			duplicate_limit, duplicate_window_minutes, COALESCE(updated_by, ''), updated_at
+		FROM comment_policy
+	`).Scan(&policy.RateLimit, &policy.RateWindowMinutes, &policy.LinkAction,
+		&policy.DuplicateLimit, &policy.DuplicateWindowMinutes, &policy.UpdatedBy, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("comment policy")
+		}
+		return nil, fmt.Errorf("failed to get comment policy: %w", err)
+	}
+	policy.UpdatedAt = &updatedAt
+	return &policy, nil
+}
+
+// SaveCommentPolicy replaces the comment spam policy
+func (p *PostgresClient) SaveCommentPolicy(ctx context.Context, policy *models.CommentPolicy) error {
+	var updatedAt time.Time
+	err := p.db.QueryRow(ctx, `
+		INSERT INTO comment_policy (id, rate_limit, rate_window_minutes, link_action,
+			duplicate_limit, duplicate_window_minutes, updated_by, updated_at)
+		VALUES (TRUE, $1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (id) DO UPDATE SET
+			rate_limit = EXCLUDED.rate_limit,
+			rate_window_minutes = EXCLUDED.rate_window_minutes,
+			link_action = EXCLUDED.link_action,
+			duplicate_limit = EXCLUDED.duplicate_limit,
+			duplicate_window_minutes = EXCLUDED.duplicate_window_minutes,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`, policy.RateLimit, policy.RateWindowMinutes, policy.LinkAction,
+		policy.DuplicateLimit, policy.DuplicateWindowMinutes, policy.UpdatedBy).Scan(&updatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save comment policy: %w", err)
+	}
+	policy.UpdatedAt = &updatedAt
+	return nil
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Notification Methods
+// ============================================================================
+
+// UpdateNotificationPreferences replaces a user's notification preferences
+func (p *PostgresClient) UpdateNotificationPreferences(ctx context.Context, userID string, prefs models.NotificationPreferences) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE users
+		SET notify_email_on_review = $2, notify_push_on_comment = $3, notify_weekly_digest = $4,
+			notify_draft_reminders = $5, updated_at = NOW()
+		WHERE id = $1
+	`, userID, prefs.EmailOnReview, prefs.PushOnComment, prefs.WeeklyDigest, prefs.DraftReminders)
+	if err != nil {
+		return fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("user")
+	}
+	return nil
+}
+
+// CreateNotification adds an entry to a user's inbox
+func (p *PostgresClient) CreateNotification(ctx context.Context, n *models.Notification) error {
+	var reportID interface{}
+	if n.ReportID != "" {
+		reportID = n.ReportID
+	}
+	err := p.db.QueryRow(ctx, `
+		INSERT INTO notifications (id, user_id, kind, report_id, title, body)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING created_at
+	`, n.ID, n.UserID, n.Kind, reportID, n.Title, n.Body).Scan(&n.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications retrieves up to limit of a user's inbox entries, newest first
+func (p *PostgresClient) ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int) ([]models.Notification, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT id, user_id, kind, COALESCE(report_id::text, ''), title, body, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1 AND (NOT $2 OR read_at IS NULL)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`, userID, unreadOnly, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []models.Notification{}
+	for rows.Next() {
+		var n models.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Kind, &n.ReportID, &n.Title, &n.Body, &n.ReadAt, &n.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, rows.Err()
+}
+
+// CountUnreadNotifications counts a user's unread inbox entries
+func (p *PostgresClient) CountUnreadNotifications(ctx context.Context, userID string) (int, error) {
+	var count int
+	err := p.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL
+	`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkNotificationRead marks one of a user's inbox entries as read (idempotent)
+func (p *PostgresClient) MarkNotificationRead(ctx context.Context, userID, notificationID string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW()) WHERE id = $1 AND user_id = $2
+	`, notificationID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("notification")
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks every unread inbox entry of a user as read and returns how many changed
+func (p *PostgresClient) MarkAllNotificationsRead(ctx context.Context, userID string) (int, error) {
+	result, err := p.db.Exec(ctx, `
+		UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL
+	`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// ListDigestRecipients retrieves weekly digest subscribers whose last digest was sent before sentBefore (or never)
+func (p *PostgresClient) ListDigestRecipients(ctx context.Context, sentBefore time.Time) ([]models.User, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT id, email, role, last_digest_at
+		FROM users
+		WHERE notify_weekly_digest AND NOT shadow_banned AND deactivated_at IS NULL AND (last_digest_at IS NULL OR last_digest_at < $1)
+		ORDER BY id
+	`, sentBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest recipients: %w", err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Role, &u.LastDigestAt); err != nil {
+			return nil, fmt.Errorf("failed to scan digest recipient: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+// MarkDigestSent records when a user's weekly digest was sent
+func (p *PostgresClient) MarkDigestSent(ctx context.Context, userID string, at time.Time) error {
+	_, err := p.db.Exec(ctx, `UPDATE users SET last_digest_at = $2 WHERE id = $1`, userID, at)
+	if err != nil {
+		return fmt.Errorf("failed to mark digest sent: %w", err)
+	}
+	return nil
+}
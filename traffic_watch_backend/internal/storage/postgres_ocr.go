@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// OCR Methods
+// ============================================================================
+
+// ListMediaForOCR retrieves GCS-hosted images on non-deleted reports that have not
+// been read by OCR yet, oldest upload first
+func (p *PostgresClient) ListMediaForOCR(ctx context.Context, limit int) ([]models.OCRJob, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT m.report_id::text, r.user_id, m.id, m.file_name, m.content_type, COALESCE(m.storage_path, '')
+		FROM media_files m
+		JOIN reports r ON r.id = m.report_id
+		WHERE m.content_type LIKE 'image/%' AND m.ocr IS NULL AND m.state = $3 AND r.status <> $1
+		ORDER BY m.uploaded_at
+		LIMIT $2
+	`, models.StatusDeleted, limit, models.MediaReady)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media for OCR: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.OCRJob
+	for rows.Next() {
+		var job models.OCRJob
+		mf := &job.Media
+		if err := rows.Scan(&job.ReportID, &job.UserID, &mf.ID, &mf.FileName, &mf.ContentType, &mf.StoragePath); err != nil {
+			return nil, fmt.Errorf("failed to scan media for OCR: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate media for OCR: %w", err)
+	}
+	return jobs, nil
+}
+
+// SetMediaOCR stores (or replaces) the OCR result of a media file. Archived media
+// is updated in place, so purges reach it too.
+func (p *PostgresClient) SetMediaOCR(ctx context.Context, reportID, mediaID string, ocr *models.MediaOCR) error {
+	var updated int
+	err := p.db.QueryRow(ctx, `
+		WITH hot AS (
+			UPDATE media_files SET ocr = $3 WHERE report_id = $1 AND id = $2 RETURNING 1
+		), cold AS (
+			UPDATE media_files_archive SET ocr = $3 WHERE report_id = $1 AND id = $2 RETURNING 1
+		)
+		SELECT (SELECT COUNT(*) FROM hot) + (SELECT COUNT(*) FROM cold)
+	`, reportID, mediaID, ocr).Scan(&updated)
+	if err != nil {
+		return fmt.Errorf("failed to store media OCR: %w", err)
+	}
+	if updated == 0 {
+		return notFound("media file")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Event Outbox Methods
+// ============================================================================
+
+// EnqueueOutboxEvent adds an event to the outbox, due for delivery now
+func (p *PostgresClient) EnqueueOutboxEvent(ctx context.Context, event *models.OutboxEvent) error {
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO event_outbox (id, type, report_id, comment_id, actor, occurred_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+	`, event.ID, event.Type, event.ReportID, event.CommentID, event.Actor, event.OccurredAt)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue outbox event: %w", err)
+	}
+	return nil
+}
+
+// ClaimOutboxEvents retrieves up to limit due events, oldest first, and moves their
+// next attempt lease into the future. SKIP LOCKED keeps concurrent relays on other
+// instances from claiming the same events.
+func (p *PostgresClient) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error) {
+	now := time.Now()
+	rows, err := p.db.Query(ctx, `
+		WITH claimed AS (
+			UPDATE event_outbox SET next_attempt_at = $3
+			WHERE id IN (
+				SELECT id FROM event_outbox
+				WHERE next_attempt_at <= $2
+				ORDER BY occurred_at, id
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id::text, type, report_id, comment_id, actor, occurred_at, attempts, last_error, next_attempt_at
+		)
+		SELECT * FROM claimed ORDER BY occurred_at, id
+	`, limit, now, now.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	claimed := []models.OutboxEvent{}
+	for rows.Next() {
+		var e models.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.Type, &e.ReportID, &e.CommentID, &e.Actor, &e.OccurredAt,
+			&e.Attempts, &e.LastError, &e.NextAttemptAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		claimed = append(claimed, e)
+	}
+	return claimed, rows.Err()
+}
+
+// MarkOutboxEventDelivered records that an event reached every sink
+func (p *PostgresClient) MarkOutboxEventDelivered(ctx context.Context, id string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE event_outbox SET attempts = attempts + 1, delivered_at = NOW(), next_attempt_at = NULL, last_error = ''
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event delivered: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("outbox event")
+	}
+	return nil
+}
+
+// MarkOutboxEventFailed counts a failed delivery attempt and schedules the next one, if any
+func (p *PostgresClient) MarkOutboxEventFailed(ctx context.Context, id, lastError string, retryAt *time.Time) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE event_outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE id = $1
+	`, id, lastError, retryAt)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("outbox event")
+	}
+	return nil
+}
+
+// PurgeDeliveredOutboxEvents deletes events delivered before before
+func (p *PostgresClient) PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int, error) {
+	result, err := p.db.Exec(ctx, `DELETE FROM event_outbox WHERE delivered_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge delivered outbox events: %w", err)
+	}
+	return int(result.RowsAffected()), nil
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Pinning Methods
+// ============================================================================
+
+// SetReportPinned pins a report to the top of the public feed or unpins it.
+// Pinning an already pinned report keeps its original pin time.
+func (p *PostgresClient) SetReportPinned(ctx context.Context, reportID string, pinned bool) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE reports
+		SET pinned_at = CASE WHEN $2 THEN COALESCE(pinned_at, NOW()) END, updated_at = NOW()
+		WHERE id = $1 AND status != $3
+	`, reportID, pinned, models.StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to update report pin: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return notFound("report")
+	}
+
+	return nil
+}
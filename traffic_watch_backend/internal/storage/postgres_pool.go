@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolConfig holds connection pool tuning for PostgresClient pools
+type PoolConfig struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+	// QueryExecMode selects how statements are prepared and cached. Use "exec" or
+	// "simple_protocol" behind PgBouncer in transaction pooling mode.
+	QueryExecMode string
+}
+
+// queryExecModes maps config names to pgx execution modes
+var queryExecModes = map[string]pgx.QueryExecMode{
+	"cache_statement": pgx.QueryExecModeCacheStatement,
+	"cache_describe":  pgx.QueryExecModeCacheDescribe,
+	"describe_exec":   pgx.QueryExecModeDescribeExec,
+	"exec":            pgx.QueryExecModeExec,
+	"simple_protocol": pgx.QueryExecModeSimpleProtocol,
+}
+
+// DefaultPoolConfig returns the pool settings used when nothing is configured
+func DefaultPoolConfig() PoolConfig {
+	return PoolConfig{
+		MaxConns:          10,
+		MinConns:          1,
+		MaxConnLifetime:   time.Hour,
+		MaxConnIdleTime:   30 * time.Minute,
+		HealthCheckPeriod: time.Minute,
+		QueryExecMode:     "cache_statement",
+	}
+}
+
+// Validate checks the settings are usable
+func (c PoolConfig) Validate() error {
+	if c.MaxConns < 1 {
+		return fmt.Errorf("max conns must be at least 1, got %d", c.MaxConns)
+	}
+	if c.MinConns < 0 || c.MinConns > c.MaxConns {
+		return fmt.Errorf("min conns must be between 0 and max conns (%d), got %d", c.MaxConns, c.MinConns)
+	}
+	if c.MaxConnLifetime <= 0 || c.MaxConnIdleTime <= 0 || c.HealthCheckPeriod <= 0 {
+		return fmt.Errorf("pool durations must be positive")
+	}
+	if _, ok := queryExecModes[c.QueryExecMode]; !ok {
+		return fmt.Errorf("unknown query exec mode %q", c.QueryExecMode)
+	}
+	return nil
+}
+
+// apply copies the settings onto a parsed pgxpool config
+func (c PoolConfig) apply(config *pgxpool.Config) error {
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("invalid pool config: %w", err)
+	}
+	config.MaxConns = c.MaxConns
+	config.MinConns = c.MinConns
+	config.MaxConnLifetime = c.MaxConnLifetime
+	config.MaxConnIdleTime = c.MaxConnIdleTime
+	config.HealthCheckPeriod = c.HealthCheckPeriod
+	config.ConnConfig.DefaultQueryExecMode = queryExecModes[c.QueryExecMode]
+	return nil
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Priority Decay Methods
+// ============================================================================
+
+// DecayReportPriorities lowers the priority of approved, unfrozen reports above the default (100)
+// by percent of their excess, rounding down so every boosted report eventually returns to 100.
+// Returns the IDs of the reports that changed.
+func (p *PostgresClient) DecayReportPriorities(ctx context.Context, percent int) ([]string, error) {
+	rows, err := p.db.Query(ctx, `
+		UPDATE reports
+		SET priority = 100 + ((priority - 100) * (100 - $1)) / 100, updated_at = NOW()
+		WHERE status = $2 AND priority > 100 AND NOT priority_frozen
+		RETURNING id::text
+	`, percent, models.StatusReviewedPass)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decay report priorities: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan decayed report: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// SetReportPriorityFrozen exempts a report from priority decay or makes it subject to decay again
+func (p *PostgresClient) SetReportPriorityFrozen(ctx context.Context, reportID string, frozen bool) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE reports SET priority_frozen = $2, updated_at = NOW()
+		WHERE id = $1 AND status != $3
+	`, reportID, frozen, models.StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to update priority freeze: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return notFound("report")
+	}
+
+	return nil
+}
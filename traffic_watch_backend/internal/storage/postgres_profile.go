@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Profile Methods
+// ============================================================================
+
+// UpdateUserDefaultLocation sets the state/province and city a user's feed is personalized for
+func (p *PostgresClient) UpdateUserDefaultLocation(ctx context.Context, userID, state, city string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE users SET default_state = $2, default_city = $3, updated_at = NOW()
+		WHERE id = $1
+	`, userID, state, city)
+	if err != nil {
+		return fmt.Errorf("failed to update default location: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("user")
+	}
+	return nil
+}
+
+// UpdateUserDisplayName sets the name shown on a user's comments, renaming the
+// comments they already posted; an empty name reverts to their pseudonym
+func (p *PostgresClient) UpdateUserDisplayName(ctx context.Context, userID, displayName string) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE users SET display_name = $2, updated_at = NOW()
+		WHERE id = $1
+	`, userID, displayName)
+	if err != nil {
+		return fmt.Errorf("failed to update display name: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("user")
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE report_comments SET author_name = $2 WHERE user_id = $1
+	`, userID, models.AuthorName(userID, displayName)); err != nil {
+		return fmt.Errorf("failed to rename comments: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Reaction Type Methods
+// ============================================================================
+
+// ListReactionTypes retrieves every reaction type, retired ones included, in sort order
+func (p *PostgresClient) ListReactionTypes(ctx context.Context) ([]models.ReactionType, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT type, label, COALESCE(emoji, ''), score, max_score, sort_order, retired_at, COALESCE(updated_by, ''), updated_at
+		FROM reaction_types
+		ORDER BY sort_order, type
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reaction types: %w", err)
+	}
+	defer rows.Close()
+
+	types := []models.ReactionType{}
+	for rows.Next() {
+		var t models.ReactionType
+		if err := rows.Scan(&t.Type, &t.Label, &t.Emoji, &t.Score, &t.MaxScore, &t.SortOrder, &t.RetiredAt, &t.UpdatedBy, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction type: %w", err)
+		}
+		types = append(types, t)
+	}
+	return types, rows.Err()
+}
+
+// UpsertReactionType creates or replaces a reaction type, un-retiring it if it was retired
+func (p *PostgresClient) UpsertReactionType(ctx context.Context, reactionType *models.ReactionType) error {
+	err := p.db.QueryRow(ctx, `
+		INSERT INTO reaction_types (type, label, emoji, score, max_score, sort_order, retired_at, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULL, $7, NOW())
+		ON CONFLICT (type) DO UPDATE SET
+			label = EXCLUDED.label,
+			emoji = EXCLUDED.emoji,
+			score = EXCLUDED.score,
+			max_score = EXCLUDED.max_score,
+			sort_order = EXCLUDED.sort_order,
+			retired_at = NULL,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`, reactionType.Type, reactionType.Label, reactionType.Emoji, reactionType.Score, reactionType.MaxScore, reactionType.SortOrder, reactionType.UpdatedBy).Scan(&reactionType.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert reaction type: %w", err)
+	}
+	reactionType.RetiredAt = nil
+	return nil
+}
+
+// RetireReactionType stops a reaction type from being offered or added. Retiring an
+// already retired type keeps its original retirement time.
+func (p *PostgresClient) RetireReactionType(ctx context.Context, reactionType, retiredBy string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE reaction_types
+		SET retired_at = COALESCE(retired_at, NOW()), updated_by = $2, updated_at = NOW()
+		WHERE type = $1
+	`, reactionType, retiredBy)
+	if err != nil {
+		return fmt.Errorf("failed to retire reaction type: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("reaction type")
+	}
+	return nil
+}
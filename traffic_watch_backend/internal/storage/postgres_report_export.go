@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Report Export Methods
+// ============================================================================
+
+// reportEngagementColumns selects a report's reaction counts (as a JSON object) and
+// unflagged comment count after reportColumns
+const reportEngagementColumns = `,
+	COALESCE((SELECT jsonb_object_agg(reaction_type, n) FROM (
+		SELECT reaction_type, COUNT(*) AS n FROM report_reactions
+		WHERE report_id = reports.id GROUP BY reaction_type) rc), '{}'),
+	(SELECT COUNT(*) FROM report_comments WHERE report_id = reports.id AND NOT flagged)`
+
+// ExportReports calls fn for every non-deleted report, oldest first, with its
+// engagement totals when opts.IncludeEngagement is set. Rows are streamed from the
+// database; an error from fn stops the export.
+func (p *PostgresClient) ExportReports(ctx context.Context, opts models.ReportExportOptions, fn func(models.ReportExportRow) error) error {
+	engagement := ""
+	if opts.IncludeEngagement {
+		engagement = reportEngagementColumns
+	}
+	filter, status := `status != $1`, models.StatusDeleted
+	if opts.ApprovedOnly {
+		// Same visibility rules as ListApprovedReports
+		filter = `status = $1 AND ` + published("publish_at") + ` AND ` + mediaSettled("reports.id") + `
+			AND ` + notShadowBanned("reports.user_id") + ` AND ` + notDeactivated("reports.user_id")
+		status = models.StatusReviewedPass
+	}
+	rows, err := p.reader().Query(ctx, `
+		SELECT `+reportColumns+engagement+`
+		FROM reports
+		WHERE `+filter+`
+		ORDER BY created_at, id
+	`, status)
+	if err != nil {
+		return fmt.Errorf("failed to export reports: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.ReportExportRow
+		if opts.IncludeEngagement {
+			row.Engagement = &models.ExportEngagement{}
+			err = scanReport(rows, &row.Report, &row.Engagement.ReactionCounts, &row.Engagement.CommentCount)
+		} else {
+			err = scanReport(rows, &row.Report)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to scan exported report: %w", err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
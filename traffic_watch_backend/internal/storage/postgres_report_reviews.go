@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Review History Methods
+// ============================================================================
+
+// CreateReportReview appends an entry to a report's review history
+func (p *PostgresClient) CreateReportReview(ctx context.Context, review *models.ReportReview) error {
+	if review.ID == "" {
+		return errors.New("review ID is required")
+	}
+	review.CreatedAt = time.Now()
+
+	if _, err := p.db.Exec(ctx, `
+		INSERT INTO report_reviews (id, report_id, status, reason, actor, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, review.ID, review.ReportID, review.Status, review.Reason, review.Actor, review.CreatedAt); err != nil {
+		return fmt.Errorf("failed to write report review: %w", err)
+	}
+	return nil
+}
+
+// ListReportReviews retrieves a report's review history, oldest first
+func (p *PostgresClient) ListReportReviews(ctx context.Context, reportID string) ([]models.ReportReview, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT id::text, report_id::text, status, reason, actor, created_at
+		FROM report_reviews
+		WHERE report_id = $1
+		ORDER BY created_at, id
+	`, reportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []models.ReportReview{}
+	for rows.Next() {
+		var r models.ReportReview
+		if err := rows.Scan(&r.ID, &r.ReportID, &r.Status, &r.Reason, &r.Actor, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan report review: %w", err)
+		}
+		reviews = append(reviews, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate report reviews: %w", err)
+	}
+	return reviews, nil
+}
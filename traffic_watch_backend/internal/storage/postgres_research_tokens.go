@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Research Token Methods
+// ============================================================================
+
+const researchTokenColumns = `id::text, name, email, token_hash, daily_quota, created_by, created_at, expires_at, revoked_at, last_used_at`
+
+// scanResearchToken scans a row selected with researchTokenColumns. Any columns
+// selected after them are scanned into extra.
+func scanResearchToken(row pgx.Row, t *models.ResearchToken, extra ...interface{}) error {
+	dest := []interface{}{&t.ID, &t.Name, &t.Email, &t.TokenHash, &t.DailyQuota, &t.CreatedBy, &t.CreatedAt, &t.ExpiresAt, &t.RevokedAt, &t.LastUsedAt}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// CreateResearchToken stores a new research token
+func (p *PostgresClient) CreateResearchToken(ctx context.Context, token *models.ResearchToken) error {
+	if token.ID == "" {
+		return errors.New("research token ID is required")
+	}
+	token.CreatedAt = time.Now()
+
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO research_tokens (id, name, email, token_hash, daily_quota, created_by, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, token.ID, token.Name, token.Email, token.TokenHash, token.DailyQuota, token.CreatedBy, token.CreatedAt, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create research token: %w", err)
+	}
+	return nil
+}
+
+// GetResearchTokenByHash retrieves the research token whose secret hashes to hash
+func (p *PostgresClient) GetResearchTokenByHash(ctx context.Context, hash string) (*models.ResearchToken, error) {
+	var token models.ResearchToken
+	err := scanResearchToken(p.reader().QueryRow(ctx, `
+		SELECT `+researchTokenColumns+` FROM research_tokens WHERE token_hash = $1
+	`, hash), &token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("research token")
+		}
+		return nil, fmt.Errorf("failed to get research token: %w", err)
+	}
+	return &token, nil
+}
+
+// ListResearchTokens retrieves every research token, newest first, with today's and total request counts
+func (p *PostgresClient) ListResearchTokens(ctx context.Context) ([]models.ResearchToken, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT `+researchTokenColumns+`,
+			COALESCE((SELECT u.requests FROM research_token_usage u WHERE u.token_id = research_tokens.id AND u.day = $1::date), 0),
+			COALESCE((SELECT SUM(u.requests) FROM research_token_usage u WHERE u.token_id = research_tokens.id), 0)
+		FROM research_tokens
+		ORDER BY created_at DESC
+	`, models.UsageDay(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list research tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []models.ResearchToken{}
+	for rows.Next() {
+		var t models.ResearchToken
+		if err := scanResearchToken(rows, &t, &t.RequestsToday, &t.TotalRequests); err != nil {
+			return nil, fmt.Errorf("failed to scan research token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+// RevokeResearchToken stops a research token from being used. Revoking an already
+// revoked token keeps its original revocation time.
+func (p *PostgresClient) RevokeResearchToken(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return notFound("research token")
+	}
+	result, err := p.db.Exec(ctx, `
+		UPDATE research_tokens SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke research token: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("research token")
+	}
+	return nil
+}
+
+// UseResearchToken counts a request against a token's daily quota. The increment only
+// happens while the day's count is under quota, so concurrent requests can't overshoot it.
+func (p *PostgresClient) UseResearchToken(ctx context.Context, tokenID, day string, quota int) (bool, error) {
+	var served int
+	err := p.db.QueryRow(ctx, `
+		WITH served AS (
+			INSERT INTO research_token_usage (token_id, day, requests)
+			VALUES ($1, $2::date, 1)
+			ON CONFLICT (token_id, day) DO UPDATE SET requests = research_token_usage.requests + 1
+			WHERE research_token_usage.requests < $3
+			RETURNING requests
+		), touched AS (
+			UPDATE research_tokens SET last_used_at = NOW()
+			WHERE id = $1 AND EXISTS (SELECT 1 FROM served)
+		)
+		SELECT COUNT(*) FROM served
+	`, tokenID, day, quota).Scan(&served)
+	if err != nil {
+		return false, fmt.Errorf("failed to count research token use: %w", err)
+	}
+	if served > 0 {
+		return true, nil
+	}
+
+	_, err = p.db.Exec(ctx, `
+		UPDATE research_token_usage SET rejected = rejected + 1 WHERE token_id = $1 AND day = $2::date
+	`, tokenID, day)
+	if err != nil {
+		return false, fmt.Errorf("failed to count rejected research token use: %w", err)
+	}
+	return false, nil
+}
+
+// ListResearchTokenUsage retrieves a token's daily usage from since on, newest first
+func (p *PostgresClient) ListResearchTokenUsage(ctx context.Context, tokenID, since string) ([]models.ResearchTokenUsage, error) {
+	if _, err := uuid.Parse(tokenID); err != nil {
+		return nil, notFound("research token")
+	}
+	rows, err := p.reader().Query(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), requests, rejected
+		FROM research_token_usage
+		WHERE token_id = $1 AND day >= $2::date
+		ORDER BY day DESC
+	`, tokenID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list research token usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := []models.ResearchTokenUsage{}
+	for rows.Next() {
+		var u models.ResearchTokenUsage
+		if err := rows.Scan(&u.Day, &u.Requests, &u.Rejected); err != nil {
+			return nil, fmt.Errorf("failed to scan research token usage: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Role Management Methods
+// ============================================================================
+
+// UpdateUserRole changes a user's role
+func (p *PostgresClient) UpdateUserRole(ctx context.Context, userID string, role models.UserRole) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1
+	`, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("user")
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// schemaColumn is a column the server queries, with the migration that adds it
+type schemaColumn struct {
+	Table     string
+	Column    string
+	DataType  string // information_schema data_type; empty to skip the type check
+	Migration string
+}
+
+// expectedSchema lists every table/column the Postgres backend relies on.
+// Add entries here alongside each new migration.
+var expectedSchema = []schemaColumn{
+	{"reports", "id", "", "001_initial_schema.sql"},
+	{"reports", "user_id", "", "001_initial_schema.sql"},
+	{"reports", "title", "", "001_initial_schema.sql"},
+	{"reports", "description", "", "001_initial_schema.sql"},
+	{"reports", "date_time", "", "001_initial_schema.sql"},
+	{"reports", "road_usage", "ARRAY", "005_convert_to_arrays.sql"},
+	{"reports", "event_type", "ARRAY", "005_convert_to_arrays.sql"},
+	{"reports", "state", "", "001_initial_schema.sql"},
+	{"reports", "injuries", "", "001_initial_schema.sql"},
+	{"reports", "status", "", "001_initial_schema.sql"},
+	{"reports", "created_at", "", "001_initial_schema.sql"},
+	{"reports", "updated_at", "", "001_initial_schema.sql"},
+	{"media_files", "id", "", "001_initial_schema.sql"},
+	{"media_files", "report_id", "", "001_initial_schema.sql"},
+	{"media_files", "file_name", "", "001_initial_schema.sql"},
+	{"media_files", "content_type", "", "001_initial_schema.sql"},
+	{"media_files", "size", "", "001_initial_schema.sql"},
+	{"media_files", "url", "", "001_initial_schema.sql"},
+	{"media_files", "uploaded_at", "", "001_initial_schema.sql"},
+	{"users", "id", "", "002_add_users_table.sql"},
+	{"users", "email", "", "002_add_users_table.sql"},
+	{"users", "role", "", "002_add_users_table.sql"},
+	{"users", "jwt_refresh_token", "", "002_add_users_table.sql"},
+	{"users", "last_login_at", "", "002_add_users_table.sql"},
+	{"reports", "review_reason", "", "003_update_report_status.sql"},
+	{"reports", "city", "", "004_add_city_column.sql"},
+	{"media_files", "metadata", "jsonb", "004_add_media_metadata.sql"},
+	{"report_reactions", "modified_at", "", "004_add_reaction_history.sql"},
+	{"report_reactions", "history_reaction_type", "", "004_add_reaction_history.sql"},
+	{"reports", "retain_media_metadata", "", "005_add_retain_media_metadata.sql"},
+	{"reports", "reviewed_by", "", "005_add_reviewed_by.sql"},
+	{"reports", "priority", "", "006_add_priority_column.sql"},
+	{"report_reactions", "id", "", "007_add_reactions_comments.sql"},
+	{"report_reactions", "report_id", "", "007_add_reactions_comments.sql"},
+	{"report_reactions", "user_id", "", "007_add_reactions_comments.sql"},
+	{"report_reactions", "user_email", "", "007_add_reactions_comments.sql"},
+	{"report_reactions", "reaction_type", "", "007_add_reactions_comments.sql"},
+	{"report_comments", "id", "", "007_add_reactions_comments.sql"},
+	{"report_comments", "report_id", "", "007_add_reactions_comments.sql"},
+	{"report_comments", "user_id", "", "007_add_reactions_comments.sql"},
+	{"report_comments", "content", "", "007_add_reactions_comments.sql"},
+	{"incidents", "id", "", "008_add_vehicle_matching.sql"},
+	{"incidents", "note", "", "008_add_vehicle_matching.sql"},
+	{"incidents", "created_by", "", "008_add_vehicle_matching.sql"},
+	{"reports", "vehicle_hashes", "ARRAY", "008_add_vehicle_matching.sql"},
+	{"reports", "incident_id", "", "008_add_vehicle_matching.sql"},
+	{"report_redirects", "from_report_id", "", "009_add_report_merge.sql"},
+	{"report_redirects", "to_report_id", "", "009_add_report_merge.sql"},
+	{"media_files", "storage_path", "", "009_add_report_merge.sql"},
+	{"reports", "latitude", "", "010_add_report_location.sql"},
+	{"reports", "longitude", "", "010_add_report_location.sql"},
+	{"report_stats_daily", "day", "", "011_add_report_stats.sql"},
+	{"report_stats_state", "state", "", "011_add_report_stats.sql"},
+	{"report_stats_event_type", "event_type", "", "011_add_report_stats.sql"},
+	{"blocked_words", "word", "", "012_add_word_filter.sql"},
+	{"blocked_words", "action", "", "012_add_word_filter.sql"},
+	{"report_comments", "flagged", "boolean", "012_add_word_filter.sql"},
+	{"reports", "content_flagged", "boolean", "012_add_word_filter.sql"},
+	{"users", "shadow_banned", "boolean", "013_add_shadow_ban.sql"},
+	{"user_blocks", "blocker_id", "", "014_add_user_blocks.sql"},
+	{"user_blocks", "blocked_id", "", "014_add_user_blocks.sql"},
+	{"announcements", "id", "", "015_add_announcements.sql"},
+	{"announcements", "severity", "", "015_add_announcements.sql"},
+	{"announcements", "starts_at", "", "015_add_announcements.sql"},
+	{"announcements", "ends_at", "", "015_add_announcements.sql"},
+	{"feature_flags", "key", "", "016_add_feature_flags.sql"},
+	{"feature_flags", "rollout_percent", "", "016_add_feature_flags.sql"},
+	{"feature_flags", "user_ids", "ARRAY", "016_add_feature_flags.sql"},
+	{"users", "notify_email_on_review", "boolean", "017_add_notification_preferences.sql"},
+	{"users", "notify_push_on_comment", "boolean", "017_add_notification_preferences.sql"},
+	{"users", "notify_weekly_digest", "boolean", "017_add_notification_preferences.sql"},
+	{"notifications", "id", "", "018_add_notifications.sql"},
+	{"notifications", "user_id", "", "018_add_notifications.sql"},
+	{"notifications", "kind", "", "018_add_notifications.sql"},
+	{"notifications", "read_at", "", "018_add_notifications.sql"},
+	{"users", "last_digest_at", "", "019_add_digest_tracking.sql"},
+	{"users", "deactivated_at", "", "020_add_user_deactivation.sql"},
+	{"users", "deactivation_reason", "", "020_add_user_deactivation.sql"},
+	{"role_invitations", "id", "", "021_add_role_invitations.sql"},
+	{"role_invitations", "email", "", "021_add_role_invitations.sql"},
+	{"role_invitations", "expires_at", "", "021_add_role_invitations.sql"},
+	{"role_invitations", "accepted_at", "", "021_add_role_invitations.sql"},
+	{"audit_log", "id", "", "021_add_role_invitations.sql"},
+	{"audit_log", "action", "", "021_add_role_invitations.sql"},
+	{"report_templates", "id", "", "022_add_report_templates.sql"},
+	{"report_templates", "user_id", "", "022_add_report_templates.sql"},
+	{"report_templates", "road_usage", "ARRAY", "022_add_report_templates.sql"},
+	{"report_templates", "event_type", "ARRAY", "022_add_report_templates.sql"},
+	{"reports", "time_zone", "", "023_add_report_timezone.sql"},
+	{"reports", "utc_offset_minutes", "integer", "023_add_report_timezone.sql"},
+	{"reports", "pinned_at", "", "024_add_report_pinning.sql"},
+	{"reports", "publish_at", "", "025_add_report_publish_at.sql"},
+	{"reports", "priority_frozen", "boolean", "026_add_priority_freeze.sql"},
+	{"reports", "hot_score", "double precision", "027_add_report_hot_score.sql"},
+	{"users", "default_state", "", "028_add_user_default_location.sql"},
+	{"users", "default_city", "", "028_add_user_default_location.sql"},
+	{"media_files", "transcript", "", "029_add_media_transcripts.sql"},
+	{"media_files", "transcript_status", "", "029_add_media_transcripts.sql"},
+	{"media_files", "transcript_operation", "", "029_add_media_transcripts.sql"},
+	{"media_files", "ocr", "jsonb", "030_add_media_ocr.sql"},
+	{"reaction_types", "type", "", "031_add_reaction_types.sql"},
+	{"reaction_types", "score", "integer", "031_add_reaction_types.sql"},
+	{"reaction_types", "retired_at", "", "031_add_reaction_types.sql"},
+	{"reports", "status_changed_at", "", "032_add_report_status_seen.sql"},
+	{"reports", "status_seen_at", "", "032_add_report_status_seen.sql"},
+	{"dead_letter", "id", "", "034_add_dead_letter.sql"},
+	{"dead_letter", "job", "", "034_add_dead_letter.sql"},
+	{"dead_letter", "attempts", "integer", "034_add_dead_letter.sql"},
+	{"dead_letter", "resolved_at", "", "034_add_dead_letter.sql"},
+	{"media_files", "state", "", "035_add_media_state.sql"},
+	{"media_files_archive", "id", "", "036_partition_media_files.sql"},
+	{"media_files_archive", "report_id", "", "036_partition_media_files.sql"},
+	{"media_files_archive", "state", "", "036_partition_media_files.sql"},
+	{"media_files_archive", "archived_at", "", "036_partition_media_files.sql"},
+	{"all_media_files", "state", "", "036_partition_media_files.sql"},
+	{"reaction_types", "max_score", "integer", "037_add_reaction_score_cap.sql"},
+	{"research_tokens", "token_hash", "", "038_add_research_tokens.sql"},
+	{"research_tokens", "daily_quota", "integer", "038_add_research_tokens.sql"},
+	{"research_token_usage", "requests", "integer", "038_add_research_tokens.sql"},
+	{"event_outbox", "next_attempt_at", "", "039_add_event_outbox.sql"},
+	{"event_outbox", "attempts", "integer", "039_add_event_outbox.sql"},
+	{"users", "display_name", "", "040_add_comment_author_names.sql"},
+	{"report_comments", "author_name", "", "040_add_comment_author_names.sql"},
+	{"reports", "city_id", "", "041_add_report_city_ids.sql"},
+	{"reports", "severity", "", "042_add_report_severity.sql"},
+	{"report_reviews", "report_id", "", "043_add_report_reviews.sql"},
+	{"report_reviews", "actor", "", "043_add_report_reviews.sql"},
+	{"api_usage", "requests", "bigint", "044_add_api_usage.sql"},
+	{"api_usage", "filters", "", "044_add_api_usage.sql"},
+	{"comment_policy", "rate_limit", "", "045_add_comment_policy.sql"},
+	{"comment_policy", "link_action", "", "045_add_comment_policy.sql"},
+	{"comment_policy", "duplicate_window_minutes", "", "045_add_comment_policy.sql"},
+	{"users", "trust_level", "", "046_add_user_trust_levels.sql"},
+	{"reports", "map_image_path", "", "047_add_report_map_images.sql"},
+	{"reports", "map_image_attempted_at", "", "047_add_report_map_images.sql"},
+	{"report_drafts", "id", "", "050_add_report_drafts.sql"},
+	{"report_drafts", "user_id", "", "050_add_report_drafts.sql"},
+	{"report_drafts", "road_usage", "ARRAY", "050_add_report_drafts.sql"},
+	{"report_drafts", "reminded_at", "", "050_add_report_drafts.sql"},
+	{"users", "notify_draft_reminders", "boolean", "050_add_report_drafts.sql"},
+}
+
+// ValidateSchema checks the connected database has every table and column in
+// expectedSchema, naming the migrations to apply if anything is missing.
+func (p *PostgresClient) ValidateSchema(ctx context.Context) error {
+	tables := make([]string, 0)
+	seen := map[string]bool{}
+	for _, col := range expectedSchema {
+		if !seen[col.Table] {
+			seen[col.Table] = true
+			tables = append(tables, col.Table)
+		}
+	}
+
+	rows, err := p.db.Query(ctx, `
+		SELECT table_name, column_name, data_type
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = ANY($1)
+	`, tables)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+	defer rows.Close()
+
+	present := map[string]string{}
+	for rows.Next() {
+		var table, column, dataType string
+		if err := rows.Scan(&table, &column, &dataType); err != nil {
+			return fmt.Errorf("failed to scan schema column: %w", err)
+		}
+		present[table+"."+column] = dataType
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	return checkSchema(expectedSchema, present)
+}
+
+// checkSchema compares expected columns with those present (keyed "table.column" -> data type)
+func checkSchema(expected []schemaColumn, present map[string]string) error {
+	var problems []string
+	var migrations []string
+	needed := map[string]bool{}
+
+	for _, col := range expected {
+		key := col.Table + "." + col.Column
+		dataType, ok := present[key]
+		switch {
+		case !ok:
+			problems = append(problems, "missing column "+key)
+		case col.DataType != "" && !strings.EqualFold(dataType, col.DataType):
+			problems = append(problems, fmt.Sprintf("column %s has type %s, expected %s", key, dataType, col.DataType))
+		default:
+			continue
+		}
+		if !needed[col.Migration] {
+			needed[col.Migration] = true
+			migrations = append(migrations, col.Migration)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("database schema is out of date (%s); apply migrations: %s",
+		strings.Join(problems, ", "), strings.Join(migrations, ", "))
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckSchema(t *testing.T) {
+	expected := []schemaColumn{
+		{"reports", "id", "", "001_initial_schema.sql"},
+		{"reports", "road_usage", "ARRAY", "005_convert_to_arrays.sql"},
+		{"reports", "priority", "", "006_add_priority_column.sql"},
+		{"report_reactions", "id", "", "007_add_reactions_comments.sql"},
+		{"report_reactions", "user_id", "", "007_add_reactions_comments.sql"},
+	}
+
+	t.Run("complete schema passes", func(t *testing.T) {
+		present := map[string]string{
+			"reports.id":               "uuid",
+			"reports.road_usage":       "ARRAY",
+			"reports.priority":         "integer",
+			"report_reactions.id":      "uuid",
+			"report_reactions.user_id": "character varying",
+		}
+		if err := checkSchema(expected, present); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("missing columns name their migrations once", func(t *testing.T) {
+		present := map[string]string{
+			"reports.id":         "uuid",
+			"reports.road_usage": "ARRAY",
+		}
+		err := checkSchema(expected, present)
+		if err == nil {
+			t.Fatal("expected error for missing columns")
+		}
+		msg := err.Error()
+		for _, want := range []string{"reports.priority", "report_reactions.user_id", "006_add_priority_column.sql", "007_add_reactions_comments.sql"} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("expected error to mention %q, got %q", want, msg)
+			}
+		}
+		if strings.Count(msg, "007_add_reactions_comments.sql") != 1 {
+			t.Errorf("expected migration listed once, got %q", msg)
+		}
+	})
+
+	t.Run("wrong column type is reported", func(t *testing.T) {
+		present := map[string]string{
+			"reports.id":               "uuid",
+			"reports.road_usage":       "character varying",
+			"reports.priority":         "integer",
+			"report_reactions.id":      "uuid",
+			"report_reactions.user_id": "character varying",
+		}
+		err := checkSchema(expected, present)
+		if err == nil || !strings.Contains(err.Error(), "005_convert_to_arrays.sql") {
+			t.Errorf("expected type mismatch naming 005 migration, got %v", err)
+		}
+	})
+}
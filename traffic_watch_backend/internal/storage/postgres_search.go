@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// reportSearchDocument is the text admin search matches reports against. It must stay
+// identical to the expression indexed by migrations/033_add_report_search_index.sql.
+const reportSearchDocument = `to_tsvector('english', COALESCE(title, '') || ' ' || COALESCE(description, '') || ' ' || COALESCE(state, '') || ' ' || COALESCE(city, ''))`
+
+// ============================================================================
+// Admin Search Methods
+// ============================================================================
+
+// SearchReports finds non-deleted reports whose title, description or location
+// contains any word of query (by prefix, after stemming), or whose submitter's
+// email contains query. Reports matching more words rank first.
+func (p *PostgresClient) SearchReports(ctx context.Context, query string, limit int) ([]models.ReportSearchHit, error) {
+	terms := searchTerms(query)
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	tsquery := strings.Join(terms, " | ")
+
+	rows, err := p.reader().Query(ctx, `
+		SELECT `+reportColumns+`,
+			COALESCE((SELECT email FROM users WHERE users.id = reports.user_id), '')
+		FROM reports
+		WHERE status != $1 AND (
+			($2 != '' AND `+reportSearchDocument+` @@ to_tsquery('english', $2))
+			OR EXISTS (SELECT 1 FROM users WHERE users.id = reports.user_id AND users.email ILIKE $3)
+		)
+		ORDER BY CASE WHEN $2 = '' THEN 0 ELSE ts_rank(`+reportSearchDocument+`, to_tsquery('english', $2)) END DESC,
+			created_at DESC
+		LIMIT $4
+	`, models.StatusDeleted, tsquery, likeContains(strings.TrimSpace(query)), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []models.TrafficReport
+	var emails []string
+	for rows.Next() {
+		var report models.TrafficReport
+		var email string
+		if err := scanReport(rows, &report, &email); err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		report.MediaFiles = []models.MediaFile{}
+		reports = append(reports, report)
+		emails = append(emails, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate reports: %w", err)
+	}
+	if err := p.attachMediaFiles(ctx, p.reader(), reports); err != nil {
+		return nil, err
+	}
+
+	hits := make([]models.ReportSearchHit, len(reports))
+	for i := range reports {
+		hits[i] = models.ReportSearchHit{Report: reports[i], SubmitterEmail: emails[i]}
+	}
+	return hits, nil
+}
+
+// SearchUsers finds users whose email contains query, alphabetically
+func (p *PostgresClient) SearchUsers(ctx context.Context, query string, limit int) ([]models.User, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT id, email, role, created_at, updated_at, last_login_at,
+			deactivated_at, COALESCE(deactivation_reason, ''), default_state, default_city, display_name, trust_level
+		FROM users
+		WHERE email ILIKE $1
+		ORDER BY email
+		LIMIT $2
+	`, likeContains(strings.TrimSpace(query)), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		var u models.User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Role, &u.CreatedAt, &u.UpdatedAt, &u.LastLoginAt,
+			&u.DeactivatedAt, &u.DeactivationReason, &u.DefaultState, &u.DefaultCity, &u.DisplayName, &u.TrustLevel); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// ============================================================================
+// Shadow Ban Methods
+// ============================================================================
+
+// notShadowBanned returns a SQL condition that is true unless the user in userColumn is shadow-banned
+func notShadowBanned(userColumn string) string {
+	return `NOT EXISTS (SELECT 1 FROM users WHERE users.id = ` + userColumn + ` AND users.shadow_banned)`
+}
+
+// SetUserShadowBanned sets or clears a user's shadow ban
+func (p *PostgresClient) SetUserShadowBanned(ctx context.Context, userID string, banned bool) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE users SET shadow_banned = $2, updated_at = NOW() WHERE id = $1
+	`, userID, banned)
+	if err != nil {
+		return fmt.Errorf("failed to update shadow ban: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("user")
+	}
+	return nil
+}
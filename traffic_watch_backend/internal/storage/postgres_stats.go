@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Statistics Methods
+// ============================================================================
+
+// GetReportStats retrieves pre-aggregated counts of approved reports, with daily counts from since onwards.
+// The aggregate tables are kept current by the report_stats_on_change trigger.
+func (p *PostgresClient) GetReportStats(ctx context.Context, since time.Time) (*models.ReportStats, error) {
+	stats := &models.ReportStats{
+		ByDay:       []models.DailyCount{},
+		ByState:     []models.KeyCount{},
+		ByEventType: []models.KeyCount{},
+	}
+
+	rows, err := p.reader().Query(ctx, `
+		SELECT to_char(day, 'YYYY-MM-DD'), count FROM report_stats_daily
+		WHERE day >= $1::date AND count > 0
+		ORDER BY day
+	`, since.UTC().Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily stats: %w", err)
+	}
+	for rows.Next() {
+		var dc models.DailyCount
+		if err := rows.Scan(&dc.Date, &dc.Count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan daily stats: %w", err)
+		}
+		stats.ByDay = append(stats.ByDay, dc)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate daily stats: %w", err)
+	}
+
+	if stats.ByState, err = p.queryKeyCounts(ctx, "SELECT state, count FROM report_stats_state WHERE count > 0 ORDER BY count DESC, state"); err != nil {
+		return nil, fmt.Errorf("failed to get state stats: %w", err)
+	}
+	if stats.ByEventType, err = p.queryKeyCounts(ctx, "SELECT event_type, count FROM report_stats_event_type WHERE count > 0 ORDER BY count DESC, event_type"); err != nil {
+		return nil, fmt.Errorf("failed to get event type stats: %w", err)
+	}
+
+	// Every approved report has exactly one state, so the state counts sum to the total
+	for _, kc := range stats.ByState {
+		stats.Total += kc.Count
+	}
+
+	return stats, nil
+}
+
+// queryKeyCounts runs a two-column (key, count) aggregate query
+func (p *PostgresClient) queryKeyCounts(ctx context.Context, query string) ([]models.KeyCount, error) {
+	rows, err := p.reader().Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []models.KeyCount{}
+	for rows.Next() {
+		var kc models.KeyCount
+		if err := rows.Scan(&kc.Key, &kc.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, kc)
+	}
+	return counts, rows.Err()
+}
+
+// RefreshReportStats rebuilds the aggregate tables from the reports table
+func (p *PostgresClient) RefreshReportStats(ctx context.Context) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Block trigger updates while rebuilding so no delta is lost or double counted
+	if _, err := tx.Exec(ctx, `LOCK TABLE report_stats_daily, report_stats_state, report_stats_event_type IN EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("failed to lock stats tables: %w", err)
+	}
+
+	statements := []string{
+		`DELETE FROM report_stats_daily`,
+		`DELETE FROM report_stats_state`,
+		`DELETE FROM report_stats_event_type`,
+		`INSERT INTO report_stats_daily (day, count)
+			SELECT (date_time AT TIME ZONE 'UTC')::date, COUNT(*) FROM reports
+			WHERE status = 'reviewed_pass' GROUP BY 1`,
+		`INSERT INTO report_stats_state (state, count)
+			SELECT state, COUNT(*) FROM reports
+			WHERE status = 'reviewed_pass' GROUP BY state`,
+		`INSERT INTO report_stats_event_type (event_type, count)
+			SELECT e, COUNT(DISTINCT id) FROM reports, unnest(COALESCE(event_type, '{}')) AS e
+			WHERE status = 'reviewed_pass' GROUP BY e`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to rebuild stats: %w", err)
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Status Seen Methods
+// ============================================================================
+
+// MarkReportStatusSeen records that the owner has seen a report's current status
+func (p *PostgresClient) MarkReportStatusSeen(ctx context.Context, reportID, userID string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE reports SET status_seen_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND status != $3
+	`, reportID, userID, models.StatusDeleted)
+	if err != nil {
+		return fmt.Errorf("failed to mark report seen: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("report")
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Report Template Methods
+// ============================================================================
+
+const templateColumns = `id::text, user_id, name, title, description, road_usage, event_type, state, city,
+	latitude, longitude, created_at, updated_at`
+
+func scanTemplate(row pgx.Row, t *models.ReportTemplate) error {
+	return row.Scan(&t.ID, &t.UserID, &t.Name, &t.Title, &t.Description, &t.RoadUsages, &t.EventTypes,
+		&t.State, &t.City, &t.Latitude, &t.Longitude, &t.CreatedAt, &t.UpdatedAt)
+}
+
+// CreateReportTemplate stores a new report template
+func (p *PostgresClient) CreateReportTemplate(ctx context.Context, t *models.ReportTemplate) error {
+	if t.ID == "" {
+		return errors.New("template ID is required")
+	}
+	now := time.Now()
+	t.CreatedAt = now
+	t.UpdatedAt = now
+
+	_, err := p.db.Exec(ctx, `
+		INSERT INTO report_templates (id, user_id, name, title, description, road_usage, event_type, state, city, latitude, longitude, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+	`, t.ID, t.UserID, t.Name, t.Title, t.Description, t.RoadUsages, t.EventTypes, t.State, t.City,
+		t.Latitude, t.Longitude, now)
+	if err != nil {
+		return fmt.Errorf("failed to create template: %w", err)
+	}
+	return nil
+}
+
+// ListReportTemplates retrieves a user's report templates by name
+func (p *PostgresClient) ListReportTemplates(ctx context.Context, userID string) ([]models.ReportTemplate, error) {
+	rows, err := p.db.Query(ctx, `
+		SELECT `+templateColumns+`
+		FROM report_templates
+		WHERE user_id = $1
+		ORDER BY name, created_at
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := []models.ReportTemplate{}
+	for rows.Next() {
+		var t models.ReportTemplate
+		if err := scanTemplate(rows, &t); err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetReportTemplate retrieves one of a user's report templates
+func (p *PostgresClient) GetReportTemplate(ctx context.Context, templateID, userID string) (*models.ReportTemplate, error) {
+	if _, err := uuid.Parse(templateID); err != nil {
+		return nil, notFound("template")
+	}
+
+	var t models.ReportTemplate
+	err := scanTemplate(p.db.QueryRow(ctx, `
+		SELECT `+templateColumns+`
+		FROM report_templates WHERE id = $1 AND user_id = $2
+	`, templateID, userID), &t)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, notFound("template")
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	return &t, nil
+}
+
+// UpdateReportTemplate replaces one of a user's report templates
+func (p *PostgresClient) UpdateReportTemplate(ctx context.Context, t *models.ReportTemplate) error {
+	err := scanTemplate(p.db.QueryRow(ctx, `
+		UPDATE report_templates
+		SET name = $3, title = $4, description = $5, road_usage = $6, event_type = $7, state = $8, city = $9,
+			latitude = $10, longitude = $11, updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING `+templateColumns+`
+	`, t.ID, t.UserID, t.Name, t.Title, t.Description, t.RoadUsages, t.EventTypes, t.State, t.City,
+		t.Latitude, t.Longitude), t)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return notFound("template")
+		}
+		return fmt.Errorf("failed to update template: %w", err)
+	}
+	return nil
+}
+
+// DeleteReportTemplate removes one of a user's report templates
+func (p *PostgresClient) DeleteReportTemplate(ctx context.Context, templateID, userID string) error {
+	result, err := p.db.Exec(ctx, `
+		DELETE FROM report_templates WHERE id = $1 AND user_id = $2
+	`, templateID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("template")
+	}
+	return nil
+}
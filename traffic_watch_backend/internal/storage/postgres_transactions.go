@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+)
+
+// ============================================================================
+// Transaction Methods
+// ============================================================================
+
+// WithTransaction runs fn against a client bound to a single transaction, committing
+// when fn returns nil. Called on a client that is already in a transaction, it uses a
+// savepoint, so only the nested part is undone when fn fails.
+func (p *PostgresClient) WithTransaction(ctx context.Context, fn func(tx Client) error) error {
+	tx, err := p.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	txClient := *p
+	txClient.db = tx
+	txClient.tx = tx
+	if err := fn(&txClient); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// Transcript Methods
+// ============================================================================
+
+// ListMediaForTranscription retrieves GCS-hosted videos on non-deleted reports that have
+// not been transcribed yet or whose transcription is still running, oldest upload first
+func (p *PostgresClient) ListMediaForTranscription(ctx context.Context, limit int) ([]models.TranscriptJob, error) {
+	rows, err := p.reader().Query(ctx, `
+		SELECT m.report_id::text, r.user_id, m.id, m.file_name, m.content_type, m.url, COALESCE(m.storage_path, ''),
+			COALESCE(m.transcript_status, ''), COALESCE(m.transcript_operation, '')
+		FROM media_files m
+		JOIN reports r ON r.id = m.report_id
+		WHERE m.content_type LIKE 'video/%'
+			AND m.url NOT LIKE '%youtube.com%' AND m.url NOT LIKE '%youtu.be%'
+			AND m.state = $4
+			AND (m.transcript_status IS NULL OR m.transcript_status = $1)
+			AND r.status <> $2
+		ORDER BY m.uploaded_at
+		LIMIT $3
+	`, models.TranscriptPending, models.StatusDeleted, limit, models.MediaReady)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media for transcription: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.TranscriptJob
+	for rows.Next() {
+		var job models.TranscriptJob
+		mf := &job.Media
+		if err := rows.Scan(&job.ReportID, &job.UserID, &mf.ID, &mf.FileName, &mf.ContentType, &mf.URL, &mf.StoragePath,
+			&mf.TranscriptStatus, &mf.TranscriptOperation); err != nil {
+			return nil, fmt.Errorf("failed to scan media for transcription: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate media for transcription: %w", err)
+	}
+	return jobs, nil
+}
+
+// UpdateMediaTranscript records the transcription state of a media file: the running
+// operation while pending, the transcript once completed
+func (p *PostgresClient) UpdateMediaTranscript(ctx context.Context, reportID, mediaID, status, transcript, operation string) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE media_files
+		SET transcript_status = $3, transcript = NULLIF($4, ''), transcript_operation = NULLIF($5, '')
+		WHERE report_id = $1 AND id = $2
+	`, reportID, mediaID, status, transcript, operation)
+	if err != nil {
+		return fmt.Errorf("failed to update media transcript: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("media file")
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// SetUserTrustLevel stores a user's recomputed trust level
+func (p *PostgresClient) SetUserTrustLevel(ctx context.Context, userID string, level models.TrustLevel) error {
+	result, err := p.db.Exec(ctx, `
+		UPDATE users SET trust_level = $2, updated_at = NOW() WHERE id = $1
+	`, userID, level)
+	if err != nil {
+		return fmt.Errorf("failed to update trust level: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return notFound("user")
+	}
+	return nil
+}
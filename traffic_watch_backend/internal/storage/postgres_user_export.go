@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// ============================================================================
+// User Export Methods
+// ============================================================================
+
+// ExportUsers calls fn for every user, oldest signup first, with their report
+// counts. Rows are streamed from the database; an error from fn stops the export.
+func (p *PostgresClient) ExportUsers(ctx context.Context, fn func(models.UserExportRow) error) error {
+	rows, err := p.reader().Query(ctx, `
+		SELECT u.id, u.email, u.role, u.created_at, u.last_login_at,
+			COUNT(r.id), COUNT(r.id) FILTER (WHERE r.status = $2)
+		FROM users u
+		LEFT JOIN reports r ON r.user_id = u.id AND r.status <> $1
+		GROUP BY u.id
+		ORDER BY u.created_at, u.id
+	`, models.StatusDeleted, models.StatusReviewedPass)
+	if err != nil {
+		return fmt.Errorf("failed to export users: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.UserExportRow
+		if err := rows.Scan(&row.ID, &row.Email, &row.Role, &row.CreatedAt, &row.LastLoginAt,
+			&row.ReportCount, &row.ApprovedReportCount); err != nil {
+			return fmt.Errorf("failed to scan exported user: %w", err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"strings"
+	"unicode"
+)
+
+// searchTerms splits an admin search query into lowercased, de-duplicated words.
+// Punctuation separates words and single characters are dropped, so the terms are
+// safe to splice into a text search query.
+func searchTerms(query string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	seen := make(map[string]bool, len(fields))
+	terms := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if len([]rune(f)) < 2 || seen[f] {
+			continue
+		}
+		seen[f] = true
+		terms = append(terms, f)
+	}
+	return terms
+}
+
+// likeContains builds an ILIKE pattern matching s anywhere, with LIKE wildcards in s escaped
+func likeContains(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + r.Replace(s) + "%"
+}
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"that red truck report from Ohio", []string{"that", "red", "truck", "report", "from", "ohio"}},
+		{"Red  RED red-truck!", []string{"red", "truck"}},
+		{"5th & Main", []string{"5th", "main"}},
+		{"a ' | :* !", []string{}},
+	}
+	for _, tt := range tests {
+		if got := searchTerms(tt.query); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("searchTerms(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestLikeContains(t *testing.T) {
+	if got := likeContains(`50%_off\`); got != `%50\%\_off\\%` {
+		t.Errorf("likeContains = %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"donzhit_me_backend/internal/models"
 )
@@ -12,9 +13,24 @@ type Client interface {
 	// Close closes the storage connection
 	Close() error
 
-	// CreateReport creates a new report
+	// Ping checks that the database can be reached, for health checks
+	Ping(ctx context.Context) error
+
+	// WithTransaction runs fn with a Client whose writes commit together when fn returns
+	// nil and are all undone otherwise. fn may be retried, so it must only have side
+	// effects through tx. On Firestore only report documents (GetReport and the methods
+	// that update a report) join the transaction; other calls run outside it.
+	WithTransaction(ctx context.Context, fn func(tx Client) error) error
+
+	// CreateReport creates a new report. Returns "report already exists" if the
+	// (possibly client-generated) ID is taken.
 	CreateReport(ctx context.Context, report *models.TrafficReport) error
 
+	// ImportReport stores a report migrated from the old spreadsheet workflow as given,
+	// keeping its status, review fields and timestamps. Returns "report already exists"
+	// if the ID is taken.
+	ImportReport(ctx context.Context, report *models.TrafficReport) error
+
 	// GetReport retrieves a report by ID
 	GetReport(ctx context.Context, reportID string) (*models.TrafficReport, error)
 
@@ -24,7 +40,17 @@ type Client interface {
 	// ListReportsByUser retrieves all active reports for a user
 	ListReportsByUser(ctx context.Context, userID string) ([]models.TrafficReport, error)
 
-	// UpdateReport updates an existing report
+	// ListUserReports retrieves a user's non-deleted reports in sort order, only those in
+	// status when it is non-empty
+	ListUserReports(ctx context.Context, userID, status string, sort models.ReportSort) ([]models.TrafficReport, error)
+
+	// CountUserReports counts the reports ListUserReports would return
+	CountUserReports(ctx context.Context, userID, status string) (int, error)
+
+	// MarkReportStatusSeen records that the owner has seen a report's current status
+	MarkReportStatusSeen(ctx context.Context, reportID, userID string) error
+
+	// UpdateReport updates an existing report. Status changes follow the same rules as UpdateReportStatus.
 	UpdateReport(ctx context.Context, report *models.TrafficReport) error
 
 	// DeleteReport performs a soft delete on a report
@@ -33,21 +59,39 @@ type Client interface {
 	// AddMediaFileToReport adds a media file reference to a report
 	AddMediaFileToReport(ctx context.Context, reportID string, mediaFile models.MediaFile) error
 
-	// ListAllReports retrieves all non-deleted reports (for admin dashboard)
-	ListAllReports(ctx context.Context) ([]models.TrafficReport, error)
+	// ListAllReports retrieves a page of non-deleted reports in sort order (for admin dashboard),
+	// skipping offset reports and returning at most limit. Reports by shadow-banned users are
+	// left out unless includeShadowBanned is set.
+	ListAllReports(ctx context.Context, includeShadowBanned bool, sort models.ReportSort, limit, offset int) ([]models.TrafficReport, error)
+
+	// CountAllReports counts every report ListAllReports pages through
+	CountAllReports(ctx context.Context, includeShadowBanned bool) (int, error)
 
-	// ListReportsAwaitingReview retrieves reports with "submitted" status (for admin review queue)
-	ListReportsAwaitingReview(ctx context.Context) ([]models.TrafficReport, error)
+	// ListReportsAwaitingReview retrieves a page of reports with "submitted" status (for admin review queue).
+	// Reports with media still processing or quarantined are left out, as are reports by
+	// shadow-banned users unless includeShadowBanned is set.
+	ListReportsAwaitingReview(ctx context.Context, includeShadowBanned bool, limit, offset int) ([]models.TrafficReport, error)
 
-	// ListApprovedReports retrieves reports with "reviewed_pass" status (for public feed)
+	// CountReportsAwaitingReview counts the reports ListReportsAwaitingReview would return
+	CountReportsAwaitingReview(ctx context.Context, includeShadowBanned bool) (int, error)
+
+	// ListApprovedReports retrieves reports with "reviewed_pass" status (for public feed), pinned reports first.
+	// Reports with media still processing or quarantined are left out.
 	ListApprovedReports(ctx context.Context) ([]models.TrafficReport, error)
 
-	// UpdateReportStatus updates a report's status and optional review reason
+	// UpdateReportStatus updates a report's status and optional review reason. Status changes the
+	// report lifecycle doesn't allow fail with a *models.StatusTransitionError.
 	UpdateReportStatus(ctx context.Context, reportID, status, reviewReason, reviewedBy string) error
 
 	// UpdateReportStatusWithPriority updates a report's status, review reason, and priority
 	UpdateReportStatusWithPriority(ctx context.Context, reportID, status, reviewReason string, priority *int, reviewedBy string) error
 
+	// CreateReportReview appends an entry to a report's review history (review.ID must be set)
+	CreateReportReview(ctx context.Context, review *models.ReportReview) error
+
+	// ListReportReviews retrieves a report's review history, oldest first
+	ListReportReviews(ctx context.Context, reportID string) ([]models.ReportReview, error)
+
 	// User management methods
 
 	// CreateOrUpdateUser creates a new user or updates an existing one
@@ -68,6 +112,211 @@ type Client interface {
 	// RevokeUserToken revokes the user's current token by clearing the refresh token
 	RevokeUserToken(ctx context.Context, userID string) error
 
+	// UpdateUserRole changes a user's role
+	UpdateUserRole(ctx context.Context, userID string, role models.UserRole) error
+
+	// UpdateUserDefaultLocation sets the state/province and city a user's feed is personalized for
+	UpdateUserDefaultLocation(ctx context.Context, userID, state, city string) error
+
+	// UpdateUserDisplayName sets the name shown on a user's comments, renaming the
+	// comments they already posted; an empty name reverts to their pseudonym
+	UpdateUserDisplayName(ctx context.Context, userID, displayName string) error
+
+	// SetUserTrustLevel stores a user's recomputed trust level
+	SetUserTrustLevel(ctx context.Context, userID string, level models.TrustLevel) error
+
+	// Role invitation methods
+
+	// CreateRoleInvitation stores a new role invitation
+	CreateRoleInvitation(ctx context.Context, inv *models.RoleInvitation) error
+
+	// GetRoleInvitation retrieves a role invitation by ID
+	GetRoleInvitation(ctx context.Context, id string) (*models.RoleInvitation, error)
+
+	// ListPendingRoleInvitations retrieves unaccepted, unexpired invitations, newest first
+	ListPendingRoleInvitations(ctx context.Context) ([]models.RoleInvitation, error)
+
+	// AcceptRoleInvitation grants the invitation's role to userID, marks it
+	// accepted and records it in the audit log, all in one transaction
+	AcceptRoleInvitation(ctx context.Context, id, userID string) error
+
+	// CreateAuditEntry appends an entry to the audit log
+	CreateAuditEntry(ctx context.Context, entry *models.AuditEntry) error
+
+	// ListAuditEntries retrieves up to limit audit log entries, newest first
+	ListAuditEntries(ctx context.Context, limit int) ([]models.AuditEntry, error)
+
+	// Research token methods
+
+	// CreateResearchToken stores a new research token
+	CreateResearchToken(ctx context.Context, token *models.ResearchToken) error
+
+	// GetResearchTokenByHash retrieves the research token whose secret hashes to hash
+	GetResearchTokenByHash(ctx context.Context, hash string) (*models.ResearchToken, error)
+
+	// ListResearchTokens retrieves every research token, revoked ones included, newest
+	// first, with the requests served today (see models.UsageDay) and in total
+	ListResearchTokens(ctx context.Context) ([]models.ResearchToken, error)
+
+	// RevokeResearchToken stops a research token from being used
+	RevokeResearchToken(ctx context.Context, id string) error
+
+	// UseResearchToken counts a request against a token's quota for day (YYYY-MM-DD) and
+	// marks the token used. Once quota requests have been served that day it returns
+	// false and counts the request as rejected instead.
+	UseResearchToken(ctx context.Context, tokenID, day string, quota int) (bool, error)
+
+	// ListResearchTokenUsage retrieves a token's daily usage from since (YYYY-MM-DD) on, newest first
+	ListResearchTokenUsage(ctx context.Context, tokenID, since string) ([]models.ResearchTokenUsage, error)
+
+	// AddAPIUsage adds request counts to the daily public API usage totals
+	AddAPIUsage(ctx context.Context, usage []models.APIUsage) error
+
+	// ListAPIUsage retrieves the daily public API usage totals from since (YYYY-MM-DD) on
+	ListAPIUsage(ctx context.Context, since string) ([]models.APIUsage, error)
+
+	// SetUserShadowBanned sets or clears a user's shadow ban
+	SetUserShadowBanned(ctx context.Context, userID string, banned bool) error
+
+	// DeactivateUser marks a user's account deactivated and revokes their session.
+	// Their reports and comments are hidden from others until ReactivateUser.
+	DeactivateUser(ctx context.Context, userID string, reason models.DeactivationReason) error
+
+	// ReactivateUser clears a user's deactivation
+	ReactivateUser(ctx context.Context, userID string) error
+
+	// Report template methods
+
+	// CreateReportTemplate stores a new report template
+	CreateReportTemplate(ctx context.Context, t *models.ReportTemplate) error
+
+	// ListReportTemplates retrieves a user's report templates by name
+	ListReportTemplates(ctx context.Context, userID string) ([]models.ReportTemplate, error)
+
+	// GetReportTemplate retrieves one of a user's report templates; other users'
+	// templates are reported as not found
+	GetReportTemplate(ctx context.Context, templateID, userID string) (*models.ReportTemplate, error)
+
+	// UpdateReportTemplate replaces one of a user's report templates (t.UserID)
+	UpdateReportTemplate(ctx context.Context, t *models.ReportTemplate) error
+
+	// DeleteReportTemplate removes one of a user's report templates
+	DeleteReportTemplate(ctx context.Context, templateID, userID string) error
+
+	// Report draft methods
+
+	// CreateReportDraft stores a new report draft
+	CreateReportDraft(ctx context.Context, d *models.ReportDraft) error
+
+	// ListReportDrafts retrieves a user's report drafts, most recently edited first
+	ListReportDrafts(ctx context.Context, userID string) ([]models.ReportDraft, error)
+
+	// GetReportDraft retrieves one of a user's report drafts; other users'
+	// drafts are reported as not found
+	GetReportDraft(ctx context.Context, draftID, userID string) (*models.ReportDraft, error)
+
+	// UpdateReportDraft replaces one of a user's report drafts (d.UserID)
+	UpdateReportDraft(ctx context.Context, d *models.ReportDraft) error
+
+	// DeleteReportDraft removes one of a user's report drafts
+	DeleteReportDraft(ctx context.Context, draftID, userID string) error
+
+	// ListDraftsToRemind retrieves drafts last edited before updatedBefore that
+	// haven't been reminded about since
+	ListDraftsToRemind(ctx context.Context, updatedBefore time.Time) ([]models.ReportDraft, error)
+
+	// MarkDraftReminded records when the owner was reminded about a draft
+	MarkDraftReminded(ctx context.Context, draftID string, at time.Time) error
+
+	// Blocking methods
+
+	// BlockUser records that blockerID has blocked blockedID (idempotent)
+	BlockUser(ctx context.Context, blockerID, blockedID string) error
+
+	// UnblockUser removes a block
+	UnblockUser(ctx context.Context, blockerID, blockedID string) error
+
+	// ListBlockedUsers retrieves the users blockerID has blocked, newest first
+	ListBlockedUsers(ctx context.Context, blockerID string) ([]models.UserBlock, error)
+
+	// IsUserBlocked reports whether blockerID has blocked blockedID
+	IsUserBlocked(ctx context.Context, blockerID, blockedID string) (bool, error)
+
+	// ExportUsers calls fn for every user, oldest signup first, with their report
+	// counts. An error from fn stops the export and is returned.
+	ExportUsers(ctx context.Context, fn func(models.UserExportRow) error) error
+
+	// ExportReports calls fn for every non-deleted report, oldest first, with its
+	// engagement totals when opts.IncludeEngagement is set. opts.ApprovedOnly limits
+	// the export to the reports the public feed shows. An error from fn stops the
+	// export and is returned.
+	ExportReports(ctx context.Context, opts models.ReportExportOptions, fn func(models.ReportExportRow) error) error
+
+	// UpdateNotificationPreferences replaces a user's notification preferences
+	UpdateNotificationPreferences(ctx context.Context, userID string, prefs models.NotificationPreferences) error
+
+	// Notification inbox methods
+
+	// CreateNotification adds an entry to a user's inbox
+	CreateNotification(ctx context.Context, n *models.Notification) error
+
+	// ListNotifications retrieves up to limit of a user's inbox entries, newest first
+	ListNotifications(ctx context.Context, userID string, unreadOnly bool, limit int) ([]models.Notification, error)
+
+	// CountUnreadNotifications counts a user's unread inbox entries
+	CountUnreadNotifications(ctx context.Context, userID string) (int, error)
+
+	// MarkNotificationRead marks one of a user's inbox entries as read (idempotent)
+	MarkNotificationRead(ctx context.Context, userID, notificationID string) error
+
+	// MarkAllNotificationsRead marks every unread inbox entry of a user as read and returns how many changed
+	MarkAllNotificationsRead(ctx context.Context, userID string) (int, error)
+
+	// ListDigestRecipients retrieves weekly digest subscribers whose last digest was sent before sentBefore (or never)
+	ListDigestRecipients(ctx context.Context, sentBefore time.Time) ([]models.User, error)
+
+	// MarkDigestSent records when a user's weekly digest was sent
+	MarkDigestSent(ctx context.Context, userID string, at time.Time) error
+
+	// Announcement methods
+
+	// CreateAnnouncement stores a new announcement
+	CreateAnnouncement(ctx context.Context, announcement *models.Announcement) error
+
+	// UpdateAnnouncement replaces an announcement's content and schedule
+	UpdateAnnouncement(ctx context.Context, announcement *models.Announcement) error
+
+	// DeleteAnnouncement removes an announcement
+	DeleteAnnouncement(ctx context.Context, announcementID string) error
+
+	// ListAnnouncements retrieves every announcement, newest first (for admin management)
+	ListAnnouncements(ctx context.Context) ([]models.Announcement, error)
+
+	// ListActiveAnnouncements retrieves announcements whose window contains at, most severe first
+	ListActiveAnnouncements(ctx context.Context, at time.Time) ([]models.Announcement, error)
+
+	// Feature flag methods
+
+	// ListFeatureFlags retrieves every stored feature flag
+	ListFeatureFlags(ctx context.Context) ([]models.FeatureFlag, error)
+
+	// UpsertFeatureFlag creates or replaces a feature flag
+	UpsertFeatureFlag(ctx context.Context, flag *models.FeatureFlag) error
+
+	// DeleteFeatureFlag removes a feature flag
+	DeleteFeatureFlag(ctx context.Context, key string) error
+
+	// Reaction type methods
+
+	// ListReactionTypes retrieves every reaction type, retired ones included, in sort order
+	ListReactionTypes(ctx context.Context) ([]models.ReactionType, error)
+
+	// UpsertReactionType creates or replaces a reaction type, un-retiring it if it was retired
+	UpsertReactionType(ctx context.Context, reactionType *models.ReactionType) error
+
+	// RetireReactionType stops a reaction type from being offered or added
+	RetireReactionType(ctx context.Context, reactionType, retiredBy string) error
+
 	// Reaction methods
 
 	// AddReaction adds or updates a reaction to a report (upsert)
@@ -79,6 +328,11 @@ type Client interface {
 	// GetUserReactionType gets the current reaction type for a user on a report
 	GetUserReactionType(ctx context.Context, reportID, userID string) (string, error)
 
+	// ToggleReaction atomically removes the user's reaction if it is already of reaction's type,
+	// and otherwise adds it or switches the existing one to it. previousType is the type held
+	// before the toggle ("" if none); added is false when the reaction was removed.
+	ToggleReaction(ctx context.Context, reaction *models.Reaction) (previousType string, added bool, err error)
+
 	// GetReactionCounts gets the count of each reaction type for a report
 	GetReactionCounts(ctx context.Context, reportID string) ([]models.ReactionCount, error)
 
@@ -96,8 +350,10 @@ type Client interface {
 	// AddComment adds a comment to a report
 	AddComment(ctx context.Context, comment *models.Comment) error
 
-	// GetComments gets all comments for a report
-	GetComments(ctx context.Context, reportID string) ([]models.Comment, error)
+	// GetComments gets the visible comments for a report. Comments by shadow-banned
+	// users are only included for their author (viewerID, "" for anonymous), and
+	// comments by deactivated users or users the viewer has blocked are left out.
+	GetComments(ctx context.Context, reportID, viewerID string) ([]models.Comment, error)
 
 	// DeleteComment deletes a comment (only if user owns it)
 	DeleteComment(ctx context.Context, commentID, userID string) error
@@ -105,6 +361,207 @@ type Client interface {
 	// GetCommentByID retrieves a comment by its ID
 	GetCommentByID(ctx context.Context, commentID string) (*models.Comment, error)
 
+	// User activity methods
+
+	// ListUserComments retrieves up to limit of a user's comments across reports,
+	// newest first, including comments held for review
+	ListUserComments(ctx context.Context, userID string, limit int) ([]models.CommentActivity, error)
+
+	// ListUserReactions retrieves up to limit of a user's reactions across reports,
+	// most recently changed first
+	ListUserReactions(ctx context.Context, userID string, limit int) ([]models.ReactionActivity, error)
+
 	// AdjustReportPriority increments or decrements a report's priority by delta
 	AdjustReportPriority(ctx context.Context, reportID string, delta int) error
+
+	// ChangeReportPriority applies an admin's priority change to a report, keeping the
+	// result within MinPriority..MaxPriority, and returns the new priority
+	ChangeReportPriority(ctx context.Context, reportID string, change models.PriorityChange) (int, error)
+
+	// DecayReportPriorities moves the priority of approved, unfrozen reports above the default
+	// percent of the way back to it, returning the IDs of the reports that changed
+	DecayReportPriorities(ctx context.Context, percent int) ([]string, error)
+
+	// SetReportPriorityFrozen exempts a report from priority decay or makes it subject to decay again
+	SetReportPriorityFrozen(ctx context.Context, reportID string, frozen bool) error
+
+	// RefreshHotScores recomputes the stored engagement ranking of every approved report
+	RefreshHotScores(ctx context.Context) error
+
+	// Vehicle matching and incident methods
+
+	// ListReportsByVehicleHashes retrieves non-deleted reports sharing any of the given vehicle hashes
+	ListReportsByVehicleHashes(ctx context.Context, hashes []string, excludeReportID string) ([]models.TrafficReport, error)
+
+	// LinkReportsToIncident groups reports into an incident, merging any incidents they already belong to.
+	// A new incident is created when incidentID is empty and none of the reports are linked yet.
+	LinkReportsToIncident(ctx context.Context, incidentID string, reportIDs []string, note, linkedBy string) (*models.Incident, error)
+
+	// GetIncident retrieves an incident and the IDs of its linked reports
+	GetIncident(ctx context.Context, incidentID string) (*models.Incident, error)
+
+	// Duplicate merge methods
+
+	// MergeReports merges duplicateID into canonicalID: media, reactions, and comments move to the
+	// canonical report, the duplicate is marked merged, and a redirect record is kept
+	MergeReports(ctx context.Context, canonicalID, duplicateID, mergedBy string) error
+
+	// GetReportRedirect returns the canonical report ID a merged report redirects to ("" if none)
+	GetReportRedirect(ctx context.Context, reportID string) (string, error)
+
+	// Pinning methods
+
+	// SetReportPinned pins a report to the top of the public feed or unpins it
+	SetReportPinned(ctx context.Context, reportID string, pinned bool) error
+
+	// Scheduled publication methods
+
+	// SetReportPublishAt sets the time an approved report enters the public feed (nil publishes on approval)
+	SetReportPublishAt(ctx context.Context, reportID string, publishAt *time.Time) error
+
+	// NextScheduledPublication returns the earliest future publish time of an approved report (nil if none)
+	NextScheduledPublication(ctx context.Context) (*time.Time, error)
+
+	// Archive methods
+
+	// ArchiveReportsCreatedBefore moves approved reports created before cutoff to "archived",
+	// taking them out of the public feed, and returns their IDs
+	ArchiveReportsCreatedBefore(ctx context.Context, cutoff time.Time) ([]string, error)
+
+	// ListArchivedReports retrieves archived reports, newest first, for the public archive
+	ListArchivedReports(ctx context.Context) ([]models.TrafficReport, error)
+
+	// EnsureMediaPartitions creates the monthly media partitions up to and including the
+	// month of through, returning the names of those it created. A no-op on backends
+	// that don't partition media.
+	EnsureMediaPartitions(ctx context.Context, through time.Time) ([]string, error)
+
+	// ArchiveMedia moves up to limit media files of archived reports to cold storage and
+	// returns how many moved. Reports keep returning their media wherever it is stored.
+	ArchiveMedia(ctx context.Context, limit int) (int, error)
+
+	// Transcript methods
+
+	// ListMediaForTranscription retrieves up to limit GCS-hosted videos on non-deleted reports that
+	// have not been transcribed yet or whose transcription is still running
+	ListMediaForTranscription(ctx context.Context, limit int) ([]models.TranscriptJob, error)
+
+	// UpdateMediaTranscript records a media file's transcription status, transcript, and running operation
+	UpdateMediaTranscript(ctx context.Context, reportID, mediaID, status, transcript, operation string) error
+
+	// Dead letter methods
+
+	// RecordMediaFailure adds a permanently failed media job to the dead-letter queue
+	RecordMediaFailure(ctx context.Context, failure *models.MediaFailure) error
+
+	// ListMediaFailures retrieves failed media jobs, newest first. Resolved ones are left out
+	// unless includeResolved is set.
+	ListMediaFailures(ctx context.Context, includeResolved bool) ([]models.MediaFailure, error)
+
+	// GetMediaFailure retrieves one failed media job
+	GetMediaFailure(ctx context.Context, id string) (*models.MediaFailure, error)
+
+	// RecordMediaRetry counts a retry of a failed media job. A nil retryErr marks the job
+	// resolved; otherwise its error is kept as the latest one.
+	RecordMediaRetry(ctx context.Context, id string, retryErr error) error
+
+	// MoveMediaFile points a report's media file at a new ID and URL
+	MoveMediaFile(ctx context.Context, reportID, mediaID, newID, url string) error
+
+	// Event outbox methods
+
+	// EnqueueOutboxEvent adds an event to the outbox, due for delivery now. Called on a
+	// transaction's client, the event is only kept if the transaction commits.
+	EnqueueOutboxEvent(ctx context.Context, event *models.OutboxEvent) error
+
+	// ClaimOutboxEvents retrieves up to limit due events, oldest first, and pushes their
+	// next attempt lease into the future so other relays skip them meanwhile
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent, error)
+
+	// MarkOutboxEventDelivered records that an event reached every sink
+	MarkOutboxEventDelivered(ctx context.Context, id string) error
+
+	// MarkOutboxEventFailed counts a failed delivery attempt. The event is tried again at
+	// retryAt, or never again when retryAt is nil.
+	MarkOutboxEventFailed(ctx context.Context, id, lastError string, retryAt *time.Time) error
+
+	// PurgeDeliveredOutboxEvents deletes events delivered before before and returns how many
+	PurgeDeliveredOutboxEvents(ctx context.Context, before time.Time) (int, error)
+
+	// Admin search methods
+
+	// SearchReports finds up to limit non-deleted reports matching query by text or
+	// submitter email, best match first
+	SearchReports(ctx context.Context, query string, limit int) ([]models.ReportSearchHit, error)
+
+	// SearchUsers finds up to limit users whose email contains query
+	SearchUsers(ctx context.Context, query string, limit int) ([]models.User, error)
+
+	// OCR methods
+
+	// ListMediaForOCR retrieves up to limit GCS-hosted images on non-deleted reports not yet read by OCR
+	ListMediaForOCR(ctx context.Context, limit int) ([]models.OCRJob, error)
+
+	// SetMediaOCR stores the admin-only OCR result of a media file, replacing any earlier one
+	SetMediaOCR(ctx context.Context, reportID, mediaID string, ocr *models.MediaOCR) error
+
+	// Static map methods
+
+	// ListReportsForMapImage retrieves up to limit non-deleted reports with coordinates and
+	// no static map image, newest first, leaving out those last tried after retryBefore
+	ListReportsForMapImage(ctx context.Context, retryBefore time.Time, limit int) ([]models.TrafficReport, error)
+
+	// SetReportMapImage records an attempt to render a report's static map; an empty
+	// objectPath records a failed attempt
+	SetReportMapImage(ctx context.Context, reportID, objectPath string) error
+
+	// Media state methods
+
+	// ListPendingMedia retrieves up to limit processing or quarantined media files on non-deleted reports
+	ListPendingMedia(ctx context.Context, limit int) ([]models.MediaStateJob, error)
+
+	// SetMediaState moves a media file from state from to state to, failing if the transition
+	// isn't allowed or the media file is no longer in state from
+	SetMediaState(ctx context.Context, reportID, mediaID, from, to string) error
+
+	// Map methods
+
+	// ListApprovedReportLocations retrieves coordinates of approved reports inside the bounding box
+	ListApprovedReportLocations(ctx context.Context, box models.BoundingBox) ([]models.ReportLocation, error)
+
+	// Statistics methods
+
+	// GetReportStats retrieves pre-aggregated counts of approved reports, with daily counts from since onwards
+	GetReportStats(ctx context.Context, since time.Time) (*models.ReportStats, error)
+
+	// RefreshReportStats rebuilds the aggregates from the reports table
+	RefreshReportStats(ctx context.Context) error
+
+	// Moderation methods
+
+	// ListBlockedWords retrieves every entry in the word filter
+	ListBlockedWords(ctx context.Context) ([]models.BlockedWord, error)
+
+	// UpsertBlockedWord adds a blocked word or changes its action
+	UpsertBlockedWord(ctx context.Context, word *models.BlockedWord) error
+
+	// DeleteBlockedWord removes a word from the filter
+	DeleteBlockedWord(ctx context.Context, word string) error
+
+	// ListFlaggedComments retrieves comments held for moderator review, oldest first.
+	// Comments by shadow-banned users are left out unless includeShadowBanned is set.
+	ListFlaggedComments(ctx context.Context, includeShadowBanned bool) ([]models.Comment, error)
+
+	// ResolveFlaggedComment publishes a flagged comment when approve is true, otherwise deletes it
+	ResolveFlaggedComment(ctx context.Context, commentID string, approve bool) error
+
+	// CountIdenticalComments counts a user's comments since the given time whose content
+	// matches content, ignoring case and surrounding whitespace
+	CountIdenticalComments(ctx context.Context, userID, content string, since time.Time) (int, error)
+
+	// GetCommentPolicy retrieves the comment spam policy (ErrNotFound until an admin sets one)
+	GetCommentPolicy(ctx context.Context) (*models.CommentPolicy, error)
+
+	// SaveCommentPolicy replaces the comment spam policy, setting its UpdatedAt
+	SaveCommentPolicy(ctx context.Context, policy *models.CommentPolicy) error
 }
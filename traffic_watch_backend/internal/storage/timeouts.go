@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"donzhit_me_backend/internal/deadline"
+)
+
+// DefaultOperationTimeout bounds a storage call that has no override. It is far
+// below the server's write timeout, so a stuck query fails the request instead of
+// holding it open until the connection is cut.
+const DefaultOperationTimeout = 10 * time.Second
+
+// ErrTimeout is wrapped by errors from storage calls that ran past their timeout.
+// The same errors also wrap context.DeadlineExceeded.
+var ErrTimeout = errors.New("storage operation timed out")
+
+// Timeouts bounds how long each storage call may run. Default applies to every
+// method not listed in PerOperation; a zero duration means no timeout.
+type Timeouts struct {
+	Default      time.Duration
+	PerOperation map[string]time.Duration
+}
+
+// DefaultTimeouts returns DefaultOperationTimeout with longer limits for the batch
+// jobs, and none for the exports, which stream for as long as the client reads.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Default: DefaultOperationTimeout,
+		PerOperation: map[string]time.Duration{
+			"ExportReports":               0,
+			"ExportUsers":                 0,
+			"RefreshHotScores":            2 * time.Minute,
+			"RefreshReportStats":          2 * time.Minute,
+			"DecayReportPriorities":       2 * time.Minute,
+			"ArchiveReportsCreatedBefore": 2 * time.Minute,
+			"ArchiveMedia":                2 * time.Minute,
+			"EnsureMediaPartitions":       time.Minute,
+		},
+	}
+}
+
+// For returns the timeout for a method
+func (t Timeouts) For(method string) time.Duration {
+	if d, ok := t.PerOperation[method]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// ParseOperationTimeouts parses overrides of the form "GetReport=2s,ExportReports=0"
+// into t.PerOperation
+func (t *Timeouts) ParseOperationTimeouts(spec string) error {
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		method, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(method) == "" {
+			return fmt.Errorf("invalid operation timeout %q: want Method=duration", entry)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid operation timeout %q: bad duration", entry)
+		}
+		if t.PerOperation == nil {
+			t.PerOperation = make(map[string]time.Duration)
+		}
+		t.PerOperation[strings.TrimSpace(method)] = d
+	}
+	return nil
+}
+
+// timeoutError is a storage error caused by the call's own deadline
+type timeoutError struct {
+	method  string
+	timeout time.Duration
+	err     error
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("%s timed out after %s: %v", e.method, e.timeout, e.err)
+}
+
+func (e *timeoutError) Unwrap() []error {
+	return []error{ErrTimeout, e.err}
+}
+
+// withTimeout bounds ctx by the method's timeout. The returned finish function
+// releases the context and, when the call failed because that timeout fired
+// (rather than the caller cancelling or timing out first), wraps the error with
+// ErrTimeout and marks the request for a 504. It reports whether that happened.
+func (t Timeouts) withTimeout(ctx context.Context, method string) (context.Context, func(err *error) bool) {
+	timeout := t.For(method)
+	if timeout <= 0 {
+		return ctx, func(*error) bool { return false }
+	}
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	return callCtx, func(err *error) bool {
+		defer cancel()
+		if *err == nil || callCtx.Err() != context.DeadlineExceeded || ctx.Err() != nil {
+			return false
+		}
+		*err = &timeoutError{method: method, timeout: timeout, err: *err}
+		deadline.MarkExceeded(ctx)
+		return true
+	}
+}
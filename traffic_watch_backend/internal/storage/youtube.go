@@ -2,20 +2,24 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"strings"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/youtube/v3"
 )
 
 // YouTubeClient handles video uploads to YouTube
 type YouTubeClient struct {
-	service *youtube.Service
+	service     *youtube.Service
+	tokenSource oauth2.TokenSource
 }
 
 // YouTubeUploadResult contains the result of a YouTube upload
@@ -30,7 +34,9 @@ func NewYouTubeClient(ctx context.Context, clientID, clientSecret, refreshToken
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		Endpoint:     google.Endpoint,
-		Scopes:       []string{youtube.YoutubeUploadScope},
+		// Deleting videos needs force-ssl; refresh tokens granted only the upload
+		// scope still upload, but DeleteVideo fails with 403
+		Scopes: []string{youtube.YoutubeUploadScope, youtube.YoutubeForceSslScope},
 	}
 
 	token := &oauth2.Token{
@@ -44,10 +50,20 @@ func NewYouTubeClient(ctx context.Context, clientID, clientSecret, refreshToken
 	}
 
 	return &YouTubeClient{
-		service: service,
+		service:     service,
+		tokenSource: tokenSource,
 	}, nil
 }
 
+// CheckToken confirms the refresh token still yields an access token. The token is
+// cached until it expires, so this only calls Google about once an hour.
+func (y *YouTubeClient) CheckToken(ctx context.Context) error {
+	if _, err := y.tokenSource.Token(); err != nil {
+		return fmt.Errorf("YouTube token refresh failed: %w", err)
+	}
+	return nil
+}
+
 // UploadVideo uploads a video to YouTube and returns the video ID and URL
 func (y *YouTubeClient) UploadVideo(ctx context.Context, title, description string, reader io.Reader, contentType string) (*YouTubeUploadResult, error) {
 	upload := &youtube.Video{
@@ -79,6 +95,20 @@ func (y *YouTubeClient) UploadVideo(ctx context.Context, title, description stri
 	return result, nil
 }
 
+// DeleteVideo removes a video from the channel. A video that is already gone is not an error.
+func (y *YouTubeClient) DeleteVideo(ctx context.Context, videoID string) error {
+	err := y.service.Videos.Delete(videoID).Context(ctx).Do()
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete YouTube video %s: %w", videoID, err)
+	}
+	log.Printf("Deleted YouTube video %s", videoID)
+	return nil
+}
+
 // IsVideoContentType checks if the content type is a video
 func IsVideoContentType(contentType string) bool {
 	contentType = strings.ToLower(contentType)
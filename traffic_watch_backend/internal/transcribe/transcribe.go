@@ -0,0 +1,109 @@
+// Package transcribe turns the speech in uploaded videos into text. It uses the
+// Video Intelligence API's speech transcription, which runs Speech-to-Text on a
+// video's audio track and reads the video straight from GCS, so the server never
+// has to download or demux uploads itself.
+package transcribe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	videointelligence "google.golang.org/api/videointelligence/v1"
+)
+
+// ErrFailed marks a transcription that finished unsuccessfully and should not be retried
+var ErrFailed = errors.New("transcription failed")
+
+// Result is the state of a running transcription
+type Result struct {
+	Done       bool
+	Transcript string // Empty when nothing was said
+}
+
+// Transcriber starts transcriptions of GCS-hosted videos and polls them until done.
+// Transcription is long-running, so callers store the operation name between polls.
+type Transcriber interface {
+	Start(ctx context.Context, gcsURI string) (operation string, err error)
+	Poll(ctx context.Context, operation string) (Result, error)
+}
+
+// VideoTranscriber transcribes with the Video Intelligence API
+type VideoTranscriber struct {
+	service      *videointelligence.Service
+	languageCode string
+}
+
+// NewVideoTranscriber creates a transcription client using application default credentials.
+// languageCode is the BCP-47 language the speech is expected in, e.g. "en-US".
+func NewVideoTranscriber(ctx context.Context, languageCode string) (*VideoTranscriber, error) {
+	service, err := videointelligence.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create video intelligence service: %w", err)
+	}
+	return &VideoTranscriber{service: service, languageCode: languageCode}, nil
+}
+
+// Start begins transcribing the video at gcsURI (gs://bucket/object) and returns the operation name
+func (t *VideoTranscriber) Start(ctx context.Context, gcsURI string) (string, error) {
+	op, err := t.service.Videos.Annotate(&videointelligence.GoogleCloudVideointelligenceV1AnnotateVideoRequest{
+		InputUri: gcsURI,
+		Features: []string{"SPEECH_TRANSCRIPTION"},
+		VideoContext: &videointelligence.GoogleCloudVideointelligenceV1VideoContext{
+			SpeechTranscriptionConfig: &videointelligence.GoogleCloudVideointelligenceV1SpeechTranscriptionConfig{
+				LanguageCode:               t.languageCode,
+				EnableAutomaticPunctuation: true,
+			},
+		},
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("failed to start transcription: %w", err)
+	}
+	return op.Name, nil
+}
+
+// Poll checks a transcription started by Start. Errors wrapping ErrFailed are final;
+// any other error is transient and the operation can be polled again.
+func (t *VideoTranscriber) Poll(ctx context.Context, operation string) (Result, error) {
+	op, err := t.service.Projects.Locations.Operations.Get(operation).Context(ctx).Do()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to get transcription operation: %w", err)
+	}
+	if !op.Done {
+		return Result{}, nil
+	}
+	if op.Error != nil {
+		return Result{}, fmt.Errorf("%w: %s", ErrFailed, op.Error.Message)
+	}
+
+	var resp videointelligence.GoogleCloudVideointelligenceV1AnnotateVideoResponse
+	if err := json.Unmarshal(op.Response, &resp); err != nil {
+		return Result{}, fmt.Errorf("%w: unreadable response: %v", ErrFailed, err)
+	}
+	transcript, err := joinTranscriptions(&resp)
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{Done: true, Transcript: transcript}, nil
+}
+
+// joinTranscriptions concatenates the most likely alternative of each transcribed segment
+func joinTranscriptions(resp *videointelligence.GoogleCloudVideointelligenceV1AnnotateVideoResponse) (string, error) {
+	var parts []string
+	for _, result := range resp.AnnotationResults {
+		if result.Error != nil {
+			return "", fmt.Errorf("%w: %s", ErrFailed, result.Error.Message)
+		}
+		for _, tr := range result.SpeechTranscriptions {
+			if len(tr.Alternatives) == 0 {
+				continue
+			}
+			if text := strings.TrimSpace(tr.Alternatives[0].Transcript); text != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+	return strings.Join(parts, " "), nil
+}
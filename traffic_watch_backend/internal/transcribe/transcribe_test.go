@@ -0,0 +1,34 @@
+package transcribe
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	videointelligence "google.golang.org/api/videointelligence/v1"
+)
+
+func TestJoinTranscriptions(t *testing.T) {
+	var resp videointelligence.GoogleCloudVideointelligenceV1AnnotateVideoResponse
+	err := json.Unmarshal([]byte(`{"annotationResults": [{"speechTranscriptions": [
+		{"alternatives": [{"transcript": " He ran the red light. ", "confidence": 0.9}, {"transcript": "He ran the red lie"}]},
+		{"alternatives": []},
+		{"alternatives": [{"transcript": "Did you see that?"}]}
+	]}]}`), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := joinTranscriptions(&resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "He ran the red light. Did you see that?"; got != want {
+		t.Errorf("transcript = %q, want %q", got, want)
+	}
+
+	resp.AnnotationResults[0].Error = &videointelligence.GoogleRpcStatus{Message: "unsupported codec"}
+	if _, err := joinTranscriptions(&resp); !errors.Is(err, ErrFailed) {
+		t.Errorf("per-video error = %v, want ErrFailed", err)
+	}
+}
@@ -0,0 +1,168 @@
+// Package translate serves machine-translated report titles and descriptions
+// for ?lang= requests on the public feed. Translations come from Cloud
+// Translation and are cached per report and language, so each report is only
+// sent to the API again once it has been edited.
+package translate
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	gtranslate "google.golang.org/api/translate/v2"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// Translator translates texts into the target language, preserving order
+type Translator interface {
+	Translate(ctx context.Context, texts []string, target string) ([]string, error)
+}
+
+// maxSegments is the most texts Cloud Translation accepts in one request
+const maxSegments = 128
+
+// CloudTranslator translates with the Cloud Translation (v2) API
+type CloudTranslator struct {
+	service *gtranslate.Service
+}
+
+// NewCloudTranslator creates a Cloud Translation client using application default credentials
+func NewCloudTranslator(ctx context.Context) (*CloudTranslator, error) {
+	service, err := gtranslate.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation service: %w", err)
+	}
+	return &CloudTranslator{service: service}, nil
+}
+
+// Translate translates texts into target, letting the API detect the source language
+func (t *CloudTranslator) Translate(ctx context.Context, texts []string, target string) ([]string, error) {
+	out := make([]string, 0, len(texts))
+	for start := 0; start < len(texts); start += maxSegments {
+		end := min(start+maxSegments, len(texts))
+		resp, err := t.service.Translations.Translate(&gtranslate.TranslateTextRequest{
+			Q:      texts[start:end],
+			Target: target,
+			Format: "text",
+		}).Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate: %w", err)
+		}
+		if len(resp.Translations) != end-start {
+			return nil, fmt.Errorf("translation returned %d texts, want %d", len(resp.Translations), end-start)
+		}
+		for _, tr := range resp.Translations {
+			out = append(out, html.UnescapeString(tr.TranslatedText))
+		}
+	}
+	return out, nil
+}
+
+var languagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})?$`)
+
+// ValidLanguage reports whether lang looks like a language code ("es", "zh-TW")
+func ValidLanguage(lang string) bool {
+	return languagePattern.MatchString(lang)
+}
+
+// SourceLanguage is the language reports are written in; ?lang= for it is a no-op
+const SourceLanguage = "en"
+
+// DefaultCacheSize bounds the number of cached report translations
+const DefaultCacheSize = 10000
+
+type cacheKey struct {
+	reportID string
+	lang     string
+}
+
+type cacheEntry struct {
+	updatedAt   time.Time // Report version the translation was made from
+	translation models.ReportTranslation
+}
+
+// Service translates reports through a Translator, caching results per report
+// and language until the report is edited
+type Service struct {
+	translator Translator
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+// NewService creates a translation service caching up to maxEntries translations
+func NewService(translator Translator, maxEntries int) *Service {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheSize
+	}
+	return &Service{
+		translator: translator,
+		maxEntries: maxEntries,
+		entries:    make(map[cacheKey]cacheEntry),
+	}
+}
+
+// TranslateReports sets Translation on each report for lang, translating uncached
+// reports in one batch. Reports are updated in place, so callers pass a copy of
+// shared (cached) slices.
+func (s *Service) TranslateReports(ctx context.Context, reports []models.TrafficReport, lang string) error {
+	if strings.EqualFold(lang, SourceLanguage) {
+		return nil
+	}
+
+	var missing []int
+	s.mu.Lock()
+	for i := range reports {
+		entry, ok := s.entries[cacheKey{reports[i].ID, lang}]
+		if ok && entry.updatedAt.Equal(reports[i].UpdatedAt) {
+			tr := entry.translation
+			reports[i].Translation = &tr
+		} else {
+			missing = append(missing, i)
+		}
+	}
+	s.mu.Unlock()
+	if len(missing) == 0 {
+		return nil
+	}
+
+	texts := make([]string, 0, 2*len(missing))
+	for _, i := range missing {
+		texts = append(texts, reports[i].Title, reports[i].Description)
+	}
+	translated, err := s.translator.Translate(ctx, texts, lang)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for n, i := range missing {
+		tr := models.ReportTranslation{
+			Language:    lang,
+			Title:       translated[2*n],
+			Description: translated[2*n+1],
+		}
+		reports[i].Translation = &tr
+		s.store(cacheKey{reports[i].ID, lang}, cacheEntry{updatedAt: reports[i].UpdatedAt, translation: tr})
+	}
+	return nil
+}
+
+// store caches an entry, dropping the whole cache when it is full. Translations
+// are cheap to rebuild and the feed only ever touches a few hundred reports.
+// Callers hold s.mu.
+func (s *Service) store(key cacheKey, entry cacheEntry) {
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= s.maxEntries {
+		log.Printf("Translation cache full (%d entries), clearing", len(s.entries))
+		clear(s.entries)
+	}
+	s.entries[key] = entry
+}
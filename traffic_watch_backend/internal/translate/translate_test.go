@@ -0,0 +1,90 @@
+package translate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"donzhit_me_backend/internal/models"
+)
+
+// fakeTranslator upper-cases texts and records how many it was asked for
+type fakeTranslator struct {
+	calls int
+	texts int
+}
+
+func (f *fakeTranslator) Translate(_ context.Context, texts []string, target string) ([]string, error) {
+	f.calls++
+	f.texts += len(texts)
+	out := make([]string, len(texts))
+	for i, t := range texts {
+		out[i] = target + ":" + strings.ToUpper(t)
+	}
+	return out, nil
+}
+
+func TestTranslateReportsCachesPerReportAndLanguage(t *testing.T) {
+	fake := &fakeTranslator{}
+	svc := NewService(fake, 0)
+	ctx := context.Background()
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	feed := func() []models.TrafficReport {
+		return []models.TrafficReport{
+			{ID: "a", Title: "crash", Description: "two cars", UpdatedAt: updated},
+			{ID: "b", Title: "near miss", Description: "cyclist", UpdatedAt: updated},
+		}
+	}
+
+	reports := feed()
+	if err := svc.TranslateReports(ctx, reports, "es"); err != nil {
+		t.Fatal(err)
+	}
+	if tr := reports[1].Translation; tr == nil || tr.Language != "es" || tr.Title != "es:NEAR MISS" || tr.Description != "es:CYCLIST" {
+		t.Fatalf("translation = %+v", reports[1].Translation)
+	}
+	if fake.calls != 1 || fake.texts != 4 {
+		t.Fatalf("calls = %d, texts = %d; want one batch of 4", fake.calls, fake.texts)
+	}
+
+	// Cached for the same language, so no further calls
+	if err := svc.TranslateReports(ctx, feed(), "es"); err != nil {
+		t.Fatal(err)
+	}
+	if fake.calls != 1 {
+		t.Errorf("cached translation called the API again")
+	}
+
+	// A new language and an edited report are both translated again
+	if err := svc.TranslateReports(ctx, feed(), "fr"); err != nil {
+		t.Fatal(err)
+	}
+	edited := feed()
+	edited[0].UpdatedAt = updated.Add(time.Minute)
+	if err := svc.TranslateReports(ctx, edited, "es"); err != nil {
+		t.Fatal(err)
+	}
+	if fake.calls != 3 || fake.texts != 10 {
+		t.Errorf("calls = %d, texts = %d; want 3 calls translating 10 texts", fake.calls, fake.texts)
+	}
+
+	// The source language is never translated
+	reports = feed()
+	if err := svc.TranslateReports(ctx, reports, "en"); err != nil || reports[0].Translation != nil {
+		t.Errorf("source language translated: %+v, %v", reports[0].Translation, err)
+	}
+}
+
+func TestValidLanguage(t *testing.T) {
+	for _, lang := range []string{"es", "fil", "zh-TW", "pt-BR"} {
+		if !ValidLanguage(lang) {
+			t.Errorf("ValidLanguage(%q) = false", lang)
+		}
+	}
+	for _, lang := range []string{"", "e", "ES", "spanish", "es_MX", "es-", "es-MX-x"} {
+		if ValidLanguage(lang) {
+			t.Errorf("ValidLanguage(%q) = true", lang)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// SniffLength is how many leading bytes of a file ContentMatches looks at
+const SniffLength = 512
+
+// isoMediaBoxes are the box types an ISO base media file (MP4, QuickTime, HEIF) can start with
+var isoMediaBoxes = [][]byte{[]byte("ftyp"), []byte("moov"), []byte("mdat"), []byte("wide"), []byte("free"), []byte("skip")}
+
+// ContentMatches reports whether the leading bytes of a file look like its declared
+// image or video content type. The declared type comes from the client, so this keeps
+// a file from being served as media when it is something else.
+func ContentMatches(contentType string, head []byte) bool {
+	switch contentType {
+	case "image/heic", "image/heif", "video/mp4", "video/quicktime":
+		if len(head) < 8 {
+			return false
+		}
+		for _, box := range isoMediaBoxes {
+			if bytes.Equal(head[4:8], box) {
+				return true
+			}
+		}
+		return false
+	case "video/mpeg":
+		return bytes.HasPrefix(head, []byte{0x00, 0x00, 0x01, 0xBA}) || bytes.HasPrefix(head, []byte{0x00, 0x00, 0x01, 0xB3})
+	case "video/x-msvideo":
+		return http.DetectContentType(head) == "video/avi"
+	}
+	if !allowedImageTypes[contentType] && !allowedVideoTypes[contentType] {
+		return false
+	}
+	return http.DetectContentType(head) == contentType
+}
@@ -0,0 +1,38 @@
+package validation
+
+import "testing"
+
+func TestContentMatches(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	mp4 := []byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+	heic := []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic")
+	html := []byte("<html><script>alert(1)</script></html>")
+
+	tests := []struct {
+		name        string
+		contentType string
+		head        []byte
+		want        bool
+	}{
+		{"png", "image/png", png, true},
+		{"jpeg", "image/jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), true},
+		{"mp4", "video/mp4", mp4, true},
+		{"quicktime", "video/quicktime", []byte("\x00\x00\x00\x08wide\x00\x00\x00\x00mdat"), true},
+		{"heic", "image/heic", heic, true},
+		{"webm", "video/webm", []byte("\x1a\x45\xdf\xa3\x9f\x42\x86\x81\x01"), true},
+		{"mpeg", "video/mpeg", []byte("\x00\x00\x01\xba\x44\x00"), true},
+		{"avi", "video/x-msvideo", []byte("RIFF\x00\x00\x00\x00AVI LIST"), true},
+		{"png declared as jpeg", "image/jpeg", png, false},
+		{"html declared as png", "image/png", html, false},
+		{"html declared as mp4", "video/mp4", html, false},
+		{"truncated", "video/mp4", []byte("\x00\x00"), false},
+		{"type not allowed", "text/html", html, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ContentMatches(tt.contentType, tt.head); got != tt.want {
+				t.Errorf("ContentMatches(%q) = %v, want %v", tt.contentType, got, tt.want)
+			}
+		})
+	}
+}
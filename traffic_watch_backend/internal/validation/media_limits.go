@@ -0,0 +1,55 @@
+package validation
+
+import "fmt"
+
+// MediaLimits caps the media attached to a single report. A zero field is unlimited.
+type MediaLimits struct {
+	MaxFiles     int   // Files per report
+	MaxTotalSize int64 // Combined size of all files, in bytes
+}
+
+// DefaultMediaLimits returns the per-report limits used unless configured otherwise
+func DefaultMediaLimits() MediaLimits {
+	return MediaLimits{
+		MaxFiles:     10,
+		MaxTotalSize: 250 * 1024 * 1024, // 250MB
+	}
+}
+
+// String describes the limits for error messages
+func (l MediaLimits) String() string {
+	files := "any number of files"
+	if l.MaxFiles > 0 {
+		files = fmt.Sprintf("at most %d files", l.MaxFiles)
+	}
+	if l.MaxTotalSize > 0 {
+		return fmt.Sprintf("%s and %dMB in total", files, l.MaxTotalSize/(1024*1024))
+	}
+	return files
+}
+
+// Check validates the sizes of all files on a report against the limits.
+// The message names the limits so clients can tell users what to trim.
+func (l MediaLimits) Check(sizes []int64) (bool, string) {
+	if l.MaxFiles > 0 && len(sizes) > l.MaxFiles {
+		return false, fmt.Sprintf("too many files (%d): a report may have %s", len(sizes), l)
+	}
+	if l.MaxTotalSize > 0 {
+		var total int64
+		for _, size := range sizes {
+			total += size
+		}
+		if total > l.MaxTotalSize {
+			return false, fmt.Sprintf("files total %dMB: a report may have %s", total/(1024*1024), l)
+		}
+	}
+	return true, ""
+}
+
+// DefaultTrustedMediaLimits returns the higher per-report limits for trusted users
+func DefaultTrustedMediaLimits() MediaLimits {
+	return MediaLimits{
+		MaxFiles:     20,
+		MaxTotalSize: 500 * 1024 * 1024, // 500MB
+	}
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMediaLimitsCheck(t *testing.T) {
+	const mb = 1024 * 1024
+	limits := MediaLimits{MaxFiles: 3, MaxTotalSize: 20 * mb}
+
+	tests := []struct {
+		name  string
+		sizes []int64
+		valid bool
+	}{
+		{"no files", nil, true},
+		{"within limits", []int64{5 * mb, 5 * mb, 10 * mb}, true},
+		{"too many files", []int64{1, 1, 1, 1}, false},
+		{"too large in total", []int64{15 * mb, 6 * mb}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, msg := limits.Check(tt.sizes)
+			if valid != tt.valid {
+				t.Fatalf("Check(%v) = %v (%q), want %v", tt.sizes, valid, msg, tt.valid)
+			}
+			if !valid && !strings.Contains(msg, "at most 3 files and 20MB in total") {
+				t.Errorf("message %q does not list the limits", msg)
+			}
+		})
+	}
+
+	if valid, _ := (MediaLimits{}).Check(make([]int64, 100)); !valid {
+		t.Error("zero limits should be unlimited")
+	}
+}
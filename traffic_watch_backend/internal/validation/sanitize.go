@@ -0,0 +1,37 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// Elements whose content is code, not text, are dropped whole
+	codeBlockPattern = regexp.MustCompile(`(?is)<(script|style|iframe|object|embed)\b[^>]*>.*?</(script|style|iframe|object|embed)\s*>`)
+	// Any remaining tag, comment or doctype. A "<" not followed by a letter,
+	// "/" or "!" (e.g. "speed < 30") is left alone.
+	htmlTagPattern = regexp.MustCompile(`(?s)<[a-zA-Z/!][^>]*>`)
+	// Script URL schemes, in case text is later rendered as a link
+	scriptSchemePattern = regexp.MustCompile(`(?i)\b(javascript|vbscript)\s*:`)
+)
+
+// SanitizeText strips markup from user-submitted text before it is stored
+// (report titles, descriptions, injuries and comments). The result is plain
+// text and is deliberately not HTML-escaped: clients render it as text, and
+// escaping here as well would show up as "&amp;lt;" in any client that
+// escapes on display. Null bytes and control characters other than
+// newlines and tabs are removed.
+func SanitizeText(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	s = codeBlockPattern.ReplaceAllString(s, "")
+	s = htmlTagPattern.ReplaceAllString(s, "")
+	s = scriptSchemePattern.ReplaceAllString(s, "")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
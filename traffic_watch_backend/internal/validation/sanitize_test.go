@@ -0,0 +1,29 @@
+package validation
+
+import "testing"
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "Ran the red light at Elm St", "Ran the red light at Elm St"},
+		{"characters kept unescaped", `Tom & Jerry said "hi" at speed < 30`, `Tom & Jerry said "hi" at speed < 30`},
+		{"tags stripped", "<b>bold</b> move", "bold move"},
+		{"script dropped with content", "before<script>alert('xss')</script>after", "beforeafter"},
+		{"attributes dropped with tag", `<img src=x onerror="alert(1)">photo`, "photo"},
+		{"script scheme", "see javascript:alert(1)", "see alert(1)"},
+		{"comment", "a<!-- hidden -->b", "ab"},
+		{"control characters", "line1\nline2\x00\x07", "line1\nline2"},
+		{"surrounding whitespace", "  padded  ", "padded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeText(tt.input); got != tt.expected {
+				t.Errorf("SanitizeText(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,12 +2,16 @@ package validation
 
 import (
 	"mime/multipart"
+	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+
+	"donzhit_me_backend/internal/models"
 )
 
 // Valid road usage types
@@ -54,6 +58,9 @@ var validCanadianProvinces = map[string]bool{
 	"Saskatchewan": true, "Yukon": true,
 }
 
+// MaxClockSkew is how far ahead of the server clock an incident time may be
+const MaxClockSkew = 5 * time.Minute
+
 // File size limits
 const (
 	MaxImageSize = 10 * 1024 * 1024  // 10MB
@@ -81,6 +88,17 @@ var allowedVideoTypes = map[string]bool{
 // RegisterCustomValidators registers all custom validators with Gin
 func RegisterCustomValidators() error {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		// Report JSON field names in validation errors
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" {
+				name, _, _ = strings.Cut(field.Tag.Get("form"), ",")
+			}
+			if name == "-" {
+				return ""
+			}
+			return name
+		})
 		if err := v.RegisterValidation("roadusage", validateRoadUsage); err != nil {
 			return err
 		}
@@ -93,6 +111,12 @@ func RegisterCustomValidators() error {
 		if err := v.RegisterValidation("uuid", validateUUID); err != nil {
 			return err
 		}
+		if err := v.RegisterValidation("notfuture", validateNotFuture); err != nil {
+			return err
+		}
+		if err := v.RegisterValidation("severity", validateSeverity); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -109,8 +133,12 @@ func validateEventType(fl validator.FieldLevel) bool {
 
 // validateStateOrProvince validates US states, DC, and Canadian provinces/territories
 func validateStateOrProvince(fl validator.FieldLevel) bool {
-	value := fl.Field().String()
-	return validUSStates[value] || validCanadianProvinces[value]
+	return ValidStateOrProvince(fl.Field().String())
+}
+
+// ValidStateOrProvince reports whether s is a supported US state, DC, or Canadian province/territory
+func ValidStateOrProvince(s string) bool {
+	return validUSStates[s] || validCanadianProvinces[s]
 }
 
 // validateUUID validates UUID format
@@ -119,6 +147,22 @@ func validateUUID(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+// validateNotFuture rejects times later than now, allowing for client clock skew
+func validateNotFuture(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	return ok && !IsFuture(t)
+}
+
+// validateSeverity validates incident severity levels
+func validateSeverity(fl validator.FieldLevel) bool {
+	return models.ValidSeverity(fl.Field().String())
+}
+
+// IsFuture reports whether t is later than now by more than MaxClockSkew
+func IsFuture(t time.Time) bool {
+	return t.After(time.Now().Add(MaxClockSkew))
+}
+
 // ValidateUUID validates a UUID string
 func ValidateUUID(id string) bool {
 	_, err := uuid.Parse(id)
@@ -127,16 +171,20 @@ func ValidateUUID(id string) bool {
 
 // ValidateFile validates an uploaded file
 func ValidateFile(header *multipart.FileHeader) (bool, string) {
-	contentType := header.Header.Get("Content-Type")
+	return ValidateFileSpec(header.Filename, header.Header.Get("Content-Type"), header.Size)
+}
 
+// ValidateFileSpec validates a file described by name, declared content type
+// and size, for uploads that have not been received yet
+func ValidateFileSpec(fileName, contentType string, size int64) (bool, string) {
 	// If content-type is octet-stream or empty, try to detect from extension
 	if contentType == "" || contentType == "application/octet-stream" {
-		contentType = DetectContentType(header.Filename)
+		contentType = DetectContentType(fileName)
 	}
 
 	// Check if it's an allowed image type
 	if allowedImageTypes[contentType] {
-		if header.Size > MaxImageSize {
+		if size > MaxImageSize {
 			return false, "image file exceeds maximum size of 10MB"
 		}
 		return true, ""
@@ -144,7 +192,7 @@ func ValidateFile(header *multipart.FileHeader) (bool, string) {
 
 	// Check if it's an allowed video type
 	if allowedVideoTypes[contentType] {
-		if header.Size > MaxVideoSize {
+		if size > MaxVideoSize {
 			return false, "video file exceeds maximum size of 100MB"
 		}
 		return true, ""
@@ -4,6 +4,7 @@ import (
 	"mime/multipart"
 	"net/textproto"
 	"testing"
+	"time"
 )
 
 func TestValidateRoadUsage(t *testing.T) {
@@ -24,12 +25,12 @@ func TestValidateRoadUsage(t *testing.T) {
 	}
 
 	invalidCases := []string{
-		"auto",        // lowercase
-		"CYCLIST",     // uppercase
-		"Bike",        // not in list
-		"",            // empty
-		"Car",         // not in list
-		"Motorcycle",  // not in list
+		"auto",       // lowercase
+		"CYCLIST",    // uppercase
+		"Bike",       // not in list
+		"",           // empty
+		"Car",        // not in list
+		"Motorcycle", // not in list
 	}
 
 	for _, tc := range invalidCases {
@@ -59,11 +60,11 @@ func TestValidateEventType(t *testing.T) {
 	}
 
 	invalidCases := []string{
-		"speeding",       // lowercase
-		"RED LIGHT",      // uppercase
-		"Accident",       // not in list
-		"",               // empty
-		"Drunk Driving",  // not in list
+		"speeding",      // lowercase
+		"RED LIGHT",     // uppercase
+		"Accident",      // not in list
+		"",              // empty
+		"Drunk Driving", // not in list
 	}
 
 	for _, tc := range invalidCases {
@@ -111,11 +112,11 @@ func TestValidateStateOrProvince(t *testing.T) {
 	}
 
 	invalidCases := []string{
-		"california",  // lowercase
-		"NEW YORK",    // uppercase
-		"London",      // not in list
-		"",            // empty
-		"Mexico",      // not in list
+		"california", // lowercase
+		"NEW YORK",   // uppercase
+		"London",     // not in list
+		"",           // empty
+		"Mexico",     // not in list
 	}
 
 	for _, tc := range invalidCases {
@@ -144,9 +145,9 @@ func TestValidateUUID(t *testing.T) {
 
 	invalidCases := []string{
 		"not-a-uuid",
-		"550e8400-e29b-41d4-a716",           // too short
-		"550e8400-e29b-41d4-a716-4466554400", // wrong length
-		"",                                   // empty
+		"550e8400-e29b-41d4-a716",              // too short
+		"550e8400-e29b-41d4-a716-4466554400",   // wrong length
+		"",                                     // empty
 		"gggggggg-gggg-gggg-gggg-gggggggggggg", // invalid characters
 	}
 
@@ -293,6 +294,43 @@ func TestValidateFile(t *testing.T) {
 	}
 }
 
+func TestValidateFileSpec(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		contentType string
+		size        int64
+		wantValid   bool
+	}{
+		{"declared image", "photo.jpg", "image/jpeg", 1024, true},
+		{"type detected from extension", "clip.mp4", "", 50 * 1024 * 1024, true},
+		{"octet-stream detected from extension", "photo.png", "application/octet-stream", 1024, true},
+		{"undetectable type", "notes.txt", "", 1024, false},
+		{"image over limit", "photo.jpg", "image/jpeg", MaxImageSize + 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, errMsg := ValidateFileSpec(tt.fileName, tt.contentType, tt.size)
+			if valid != tt.wantValid {
+				t.Errorf("ValidateFileSpec() valid = %v, want %v (%q)", valid, tt.wantValid, errMsg)
+			}
+		})
+	}
+}
+
+func TestIsFuture(t *testing.T) {
+	if IsFuture(time.Now()) {
+		t.Error("expected now to be accepted")
+	}
+	if IsFuture(time.Now().Add(MaxClockSkew / 2)) {
+		t.Error("expected small clock skew to be accepted")
+	}
+	if !IsFuture(time.Now().Add(time.Hour)) {
+		t.Error("expected an hour ahead to be rejected")
+	}
+}
+
 func TestGetAllowedRoadUsages(t *testing.T) {
 	usages := GetAllowedRoadUsages()
 	if len(usages) != 5 {
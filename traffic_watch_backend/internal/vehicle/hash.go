@@ -0,0 +1,112 @@
+package vehicle
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode"
+)
+
+// Hash prefixes identify which descriptor a match key was derived from
+const (
+	PlatePrefix   = "plate:"
+	VehiclePrefix = "vehicle:"
+)
+
+// Match kinds reported to admins when two reports share a key
+const (
+	MatchPlate   = "plate"
+	MatchVehicle = "vehicle"
+)
+
+// Hasher derives keyed hashes from license plates and vehicle descriptions.
+// Raw plate numbers are never stored; only these HMACs are persisted so that
+// reports can be matched without keeping identifying data in the database.
+type Hasher struct {
+	secret []byte
+}
+
+// Descriptor holds the vehicle details submitted with a report
+type Descriptor struct {
+	State        string
+	LicensePlate string
+	Color        string
+	Make         string
+	Model        string
+}
+
+// NewHasher creates a new hasher using the given secret key
+func NewHasher(secret string) *Hasher {
+	return &Hasher{
+		secret: []byte(secret),
+	}
+}
+
+// Hashes returns the match keys for a vehicle descriptor.
+// A plate key is produced when a plate is present; a vehicle key is produced
+// only when color, make, and model are all present, since anything less is
+// too coarse to be useful.
+func (h *Hasher) Hashes(d Descriptor) []string {
+	var hashes []string
+
+	state := normalize(d.State)
+	if plate := NormalizePlate(d.LicensePlate); plate != "" {
+		hashes = append(hashes, PlatePrefix+h.sum(state, plate))
+	}
+
+	color, mk, model := normalize(d.Color), normalize(d.Make), normalize(d.Model)
+	if color != "" && mk != "" && model != "" {
+		hashes = append(hashes, VehiclePrefix+h.sum(state, color, mk, model))
+	}
+
+	return hashes
+}
+
+// MatchKinds returns which kinds of keys (plate, vehicle) two hash sets share
+func MatchKinds(a, b []string) []string {
+	seen := make(map[string]bool, len(a))
+	for _, hash := range a {
+		seen[hash] = true
+	}
+
+	var kinds []string
+	plate, veh := false, false
+	for _, hash := range b {
+		if !seen[hash] {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(hash, PlatePrefix) && !plate:
+			plate = true
+			kinds = append(kinds, MatchPlate)
+		case strings.HasPrefix(hash, VehiclePrefix) && !veh:
+			veh = true
+			kinds = append(kinds, MatchVehicle)
+		}
+	}
+	return kinds
+}
+
+// NormalizePlate uppercases a plate and strips spaces, dashes, and punctuation
+func NormalizePlate(plate string) string {
+	var b strings.Builder
+	for _, r := range plate {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return b.String()
+}
+
+// normalize lowercases a free-text value and collapses whitespace
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// sum computes the hex HMAC-SHA256 of the joined parts
+func (h *Hasher) sum(parts ...string) string {
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write([]byte(strings.Join(parts, "|")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
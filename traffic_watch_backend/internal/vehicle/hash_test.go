@@ -0,0 +1,113 @@
+package vehicle
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizePlate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"abc 123", "ABC123"},
+		{"ABC-123", "ABC123"},
+		{" 7xyz.89 ", "7XYZ89"},
+		{"", ""},
+		{"---", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizePlate(tt.input); got != tt.expected {
+				t.Errorf("NormalizePlate(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHasher_Hashes(t *testing.T) {
+	h := NewHasher("test-secret")
+
+	t.Run("plate formatting does not affect hash", func(t *testing.T) {
+		a := h.Hashes(Descriptor{State: "Ohio", LicensePlate: "abc 123"})
+		b := h.Hashes(Descriptor{State: "Ohio", LicensePlate: "ABC-123"})
+		if len(a) != 1 || len(b) != 1 || a[0] != b[0] {
+			t.Errorf("expected matching plate hashes, got %v and %v", a, b)
+		}
+		if !strings.HasPrefix(a[0], PlatePrefix) {
+			t.Errorf("expected plate prefix, got %q", a[0])
+		}
+	})
+
+	t.Run("same plate in different states does not match", func(t *testing.T) {
+		a := h.Hashes(Descriptor{State: "Ohio", LicensePlate: "ABC123"})
+		b := h.Hashes(Descriptor{State: "Texas", LicensePlate: "ABC123"})
+		if a[0] == b[0] {
+			t.Error("expected different hashes for different states")
+		}
+	})
+
+	t.Run("plate is not present in hash", func(t *testing.T) {
+		hashes := h.Hashes(Descriptor{State: "Ohio", LicensePlate: "ABC123"})
+		if strings.Contains(hashes[0], "ABC123") {
+			t.Error("hash must not contain the raw plate")
+		}
+	})
+
+	t.Run("partial vehicle description is ignored", func(t *testing.T) {
+		hashes := h.Hashes(Descriptor{State: "Ohio", Color: "Red", Make: "Ford"})
+		if len(hashes) != 0 {
+			t.Errorf("expected no hashes, got %v", hashes)
+		}
+	})
+
+	t.Run("full vehicle description", func(t *testing.T) {
+		a := h.Hashes(Descriptor{State: "Ohio", Color: "Red", Make: "Ford", Model: "F-150"})
+		b := h.Hashes(Descriptor{State: "Ohio", Color: " red ", Make: "FORD", Model: "f-150"})
+		if len(a) != 1 || a[0] != b[0] {
+			t.Errorf("expected matching vehicle hashes, got %v and %v", a, b)
+		}
+		if !strings.HasPrefix(a[0], VehiclePrefix) {
+			t.Errorf("expected vehicle prefix, got %q", a[0])
+		}
+	})
+
+	t.Run("different secrets produce different hashes", func(t *testing.T) {
+		other := NewHasher("other-secret")
+		a := h.Hashes(Descriptor{State: "Ohio", LicensePlate: "ABC123"})
+		b := other.Hashes(Descriptor{State: "Ohio", LicensePlate: "ABC123"})
+		if a[0] == b[0] {
+			t.Error("expected different hashes for different secrets")
+		}
+	})
+}
+
+func TestMatchKinds(t *testing.T) {
+	h := NewHasher("test-secret")
+	full := h.Hashes(Descriptor{State: "Ohio", LicensePlate: "ABC123", Color: "Red", Make: "Ford", Model: "F-150"})
+	plateOnly := h.Hashes(Descriptor{State: "Ohio", LicensePlate: "ABC123"})
+	vehicleOnly := h.Hashes(Descriptor{State: "Ohio", Color: "Red", Make: "Ford", Model: "F-150"})
+	unrelated := h.Hashes(Descriptor{State: "Ohio", LicensePlate: "XYZ999"})
+
+	tests := []struct {
+		name     string
+		a, b     []string
+		expected []string
+	}{
+		{"plate and vehicle", full, full, []string{MatchPlate, MatchVehicle}},
+		{"plate only", full, plateOnly, []string{MatchPlate}},
+		{"vehicle only", full, vehicleOnly, []string{MatchVehicle}},
+		{"no match", full, unrelated, nil},
+		{"empty", nil, full, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := MatchKinds(tt.a, tt.b)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("MatchKinds() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package vehicle
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Length bounds of a normalized plate; most North American plates are 5-7 characters
+const (
+	minPlateLength = 4
+	maxPlateLength = 8
+)
+
+// PlateCandidates picks strings out of OCR text that could be license plates: each
+// line and each word, normalized, that mixes letters and digits and has a plausible
+// length. Results are unique and in reading order. These are raw plates, so they
+// must only ever be shown to admins.
+func PlateCandidates(text string) []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	add := func(s string) {
+		plate := NormalizePlate(s)
+		if !seen[plate] && plausiblePlate(plate) {
+			seen[plate] = true
+			candidates = append(candidates, plate)
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		add(line)
+		for _, word := range strings.Fields(line) {
+			add(word)
+		}
+	}
+	return candidates
+}
+
+// plausiblePlate reports whether a normalized string has a plate's length and mix of characters
+func plausiblePlate(plate string) bool {
+	if len(plate) < minPlateLength || len(plate) > maxPlateLength {
+		return false
+	}
+	return strings.IndexFunc(plate, unicode.IsLetter) >= 0 && strings.IndexFunc(plate, unicode.IsDigit) >= 0
+}
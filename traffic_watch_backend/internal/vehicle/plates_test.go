@@ -0,0 +1,27 @@
+package vehicle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlateCandidates(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"plate on one line", "OHIO\nABC 1234\nBirthplace of Aviation", []string{"ABC1234"}},
+		{"plate inside a line", "Rear plate 7XYZ-12 visible", []string{"7XYZ12"}},
+		{"signage only", "SPEED LIMIT\n35\nSTOP", nil},
+		{"duplicates collapsed", "GHT4821\nGHT 4821", []string{"GHT4821"}},
+		{"too long", "ROUTE66EXIT12", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlateCandidates(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlateCandidates(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
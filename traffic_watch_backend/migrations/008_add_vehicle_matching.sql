@@ -0,0 +1,23 @@
+-- Migration: Cross-report vehicle matching and incident clusters
+-- vehicle_hashes stores keyed HMACs of plate/vehicle descriptors (never raw plates)
+
+CREATE TABLE IF NOT EXISTS incidents (
+    id UUID PRIMARY KEY,
+    note TEXT DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS vehicle_hashes TEXT[] DEFAULT '{}';
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS incident_id UUID REFERENCES incidents(id) ON DELETE SET NULL;
+
+-- GIN index for overlap (&&) lookups on vehicle hashes
+CREATE INDEX IF NOT EXISTS idx_reports_vehicle_hashes ON reports USING GIN (vehicle_hashes);
+CREATE INDEX IF NOT EXISTS idx_reports_incident_id ON reports(incident_id);
+
+DROP TRIGGER IF EXISTS update_incidents_updated_at ON incidents;
+CREATE TRIGGER update_incidents_updated_at
+    BEFORE UPDATE ON incidents
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,14 @@
+-- Migration: Duplicate report merge workflow
+-- Merged duplicates keep their row (status = 'merged') and a redirect to the canonical report
+
+CREATE TABLE IF NOT EXISTS report_redirects (
+    from_report_id UUID PRIMARY KEY REFERENCES reports(id) ON DELETE CASCADE,
+    to_report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    merged_by VARCHAR(255) NOT NULL,
+    merged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_redirects_to ON report_redirects(to_report_id);
+
+-- Media moved to another report keeps its original GCS object path
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS storage_path TEXT DEFAULT '';
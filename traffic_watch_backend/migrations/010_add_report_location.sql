@@ -0,0 +1,8 @@
+-- Migration: Optional incident coordinates for map clustering
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+-- Only approved reports with coordinates are served on the public map
+CREATE INDEX IF NOT EXISTS idx_reports_location ON reports(latitude, longitude)
+    WHERE status = 'reviewed_pass' AND latitude IS NOT NULL;
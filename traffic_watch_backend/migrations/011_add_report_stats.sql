@@ -0,0 +1,64 @@
+-- Migration: Materialized statistics for approved reports
+-- Counts are maintained on write by a trigger on reports; the server also
+-- rebuilds them periodically (STATS_REFRESH_INTERVAL) to correct any drift.
+
+CREATE TABLE IF NOT EXISTS report_stats_daily (
+    day DATE PRIMARY KEY,
+    count INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS report_stats_state (
+    state VARCHAR(50) PRIMARY KEY,
+    count INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS report_stats_event_type (
+    event_type VARCHAR(50) PRIMARY KEY,
+    count INTEGER NOT NULL DEFAULT 0
+);
+
+-- Apply a +1/-1 delta for one report to every aggregate
+CREATE OR REPLACE FUNCTION report_stats_apply(r reports, delta INTEGER)
+RETURNS VOID AS $$
+BEGIN
+    INSERT INTO report_stats_daily (day, count)
+    VALUES ((r.date_time AT TIME ZONE 'UTC')::date, delta)
+    ON CONFLICT (day) DO UPDATE SET count = report_stats_daily.count + EXCLUDED.count;
+
+    INSERT INTO report_stats_state (state, count)
+    VALUES (r.state, delta)
+    ON CONFLICT (state) DO UPDATE SET count = report_stats_state.count + EXCLUDED.count;
+
+    INSERT INTO report_stats_event_type (event_type, count)
+    SELECT DISTINCT e, delta FROM unnest(COALESCE(r.event_type, '{}')) AS e
+    ON CONFLICT (event_type) DO UPDATE SET count = report_stats_event_type.count + EXCLUDED.count;
+END;
+$$ language 'plpgsql';
+
+-- Only approved reports are counted; reports enter or leave the aggregates as their status changes
+CREATE OR REPLACE FUNCTION report_stats_on_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND OLD.status = NEW.status
+        AND OLD.date_time = NEW.date_time
+        AND OLD.state = NEW.state
+        AND OLD.event_type IS NOT DISTINCT FROM NEW.event_type THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.status = 'reviewed_pass' THEN
+        PERFORM report_stats_apply(OLD, -1);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.status = 'reviewed_pass' THEN
+        PERFORM report_stats_apply(NEW, 1);
+    END IF;
+    RETURN NULL;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS report_stats_on_change ON reports;
+CREATE TRIGGER report_stats_on_change
+    AFTER INSERT OR UPDATE OR DELETE ON reports
+    FOR EACH ROW
+    EXECUTE FUNCTION report_stats_on_change();
@@ -0,0 +1,15 @@
+-- Migration: Blocked-word filter for comments and report text
+-- Words are managed via /v1/admin/moderation/words. Text matching a "flag"
+-- word is stored but held for moderator review.
+
+CREATE TABLE IF NOT EXISTS blocked_words (
+    word VARCHAR(100) PRIMARY KEY,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('mask', 'flag', 'reject')),
+    created_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE report_comments ADD COLUMN IF NOT EXISTS flagged BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS content_flagged BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_report_comments_flagged ON report_comments(created_at) WHERE flagged;
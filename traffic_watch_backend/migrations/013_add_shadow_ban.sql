@@ -0,0 +1,7 @@
+-- Migration: Shadow-banned users
+-- Reports and comments by shadow-banned users are accepted and shown to the
+-- author, but kept out of public listings and, by default, admin queues.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS shadow_banned BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_users_shadow_banned ON users(id) WHERE shadow_banned;
@@ -0,0 +1,12 @@
+-- Migration: User-level blocking
+-- A blocker no longer sees the blocked user's comments, and the blocked user
+-- can't react to or comment on the blocker's reports.
+
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    blocked_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_blocks_blocked ON user_blocks(blocked_id);
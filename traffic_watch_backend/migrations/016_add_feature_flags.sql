@@ -0,0 +1,13 @@
+-- Migration: Feature flags
+-- Evaluated per user by the server and exposed via /v1/public/flags.
+-- FEATURE_FLAGS in the environment overrides these per deployment.
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(64) PRIMARY KEY,
+    description TEXT,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    rollout_percent INTEGER NOT NULL DEFAULT 100 CHECK (rollout_percent BETWEEN 0 AND 100),
+    user_ids TEXT[] NOT NULL DEFAULT '{}',
+    updated_by VARCHAR(255),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,6 @@
+-- Migration: Per-user notification preferences
+-- Every notification sender checks these before delivering to a user.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notify_email_on_review BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notify_push_on_comment BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notify_weekly_digest BOOLEAN NOT NULL DEFAULT false;
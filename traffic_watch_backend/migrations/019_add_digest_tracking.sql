@@ -0,0 +1,7 @@
+-- Migration: Weekly digest tracking
+-- The digest job runs frequently and sends to subscribers whose last digest
+-- is more than a week old, so restarts never cause duplicate sends.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_digest_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_users_digest ON users(last_digest_at) WHERE notify_weekly_digest;
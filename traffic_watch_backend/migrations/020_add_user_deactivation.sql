@@ -0,0 +1,9 @@
+-- Migration: Deactivated user accounts
+-- Users can deactivate their own account and admins can suspend one. Either
+-- way sign-in is refused and the user's public content is hidden, but nothing
+-- is deleted, so reactivation restores everything.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivation_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_users_deactivated ON users(id) WHERE deactivated_at IS NOT NULL;
@@ -0,0 +1,28 @@
+-- Migration: Role-grant invitations and admin audit log
+-- Admins invite an email address to a role; the invitee accepts with the
+-- signed token from their email after signing in. Role changes, however
+-- they happen, are recorded in audit_log.
+
+CREATE TABLE IF NOT EXISTS role_invitations (
+    id UUID PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    invited_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    accepted_by VARCHAR(255)
+);
+
+CREATE INDEX IF NOT EXISTS idx_role_invitations_pending ON role_invitations(created_at DESC) WHERE accepted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY,
+    actor_id VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at DESC);
@@ -0,0 +1,21 @@
+-- Migration: Report templates
+-- Per-user presets (e.g. for an intersection the user monitors) that prefill
+-- report creation via ?templateId=.
+
+CREATE TABLE IF NOT EXISTS report_templates (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(200) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    road_usage TEXT[] NOT NULL DEFAULT '{}',
+    event_type TEXT[] NOT NULL DEFAULT '{}',
+    state VARCHAR(50) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_templates_user ON report_templates(user_id, name);
@@ -0,0 +1,7 @@
+-- Migration: Submitter timezone for incident times
+-- date_time stays a UTC instant; time_zone (IANA name, optional) and
+-- utc_offset_minutes record where it happened so responses can render the
+-- local time. Existing reports keep offset 0 and render in UTC as before.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS utc_offset_minutes INTEGER NOT NULL DEFAULT 0;
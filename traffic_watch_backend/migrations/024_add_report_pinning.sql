@@ -0,0 +1,5 @@
+-- Migration: Admin pinning of reports
+-- Reports with pinned_at set stay at the top of the public feed, ahead of
+-- priority and date ordering. Unpinning clears the column.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS pinned_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,5 @@
+-- Migration: Scheduled publication of approved reports
+-- An approved report with publish_at in the future stays out of the public
+-- feed and map until that time. NULL publishes on approval as before.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
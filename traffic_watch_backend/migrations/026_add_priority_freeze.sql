@@ -0,0 +1,5 @@
+-- Migration: Priority decay freeze
+-- The decay job lowers the priority of approved reports back toward the
+-- default of 100 over time. Admins can freeze a report's priority to exempt it.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS priority_frozen BOOLEAN NOT NULL DEFAULT false;
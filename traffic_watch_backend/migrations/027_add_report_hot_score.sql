@@ -0,0 +1,5 @@
+-- Migration: Engagement-aware feed ranking
+-- hot_score is recomputed periodically from priority, age, reactions and
+-- comments (see internal/ranking) and backs the ?sort=hot public feed.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS hot_score DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+-- Migration: Default location on user profiles
+-- Signed-in users can set a home state/province and city; the personalized
+-- feed (GET /v1/reports/feed) lists reports from there first.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS default_state VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS default_city VARCHAR(100) NOT NULL DEFAULT '';
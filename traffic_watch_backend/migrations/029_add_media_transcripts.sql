@@ -0,0 +1,10 @@
+-- Migration: Transcripts of uploaded videos
+-- The transcribe-videos job sends GCS-hosted videos to speech transcription and
+-- stores the result on the media file. transcript_operation holds the running
+-- long-running operation's name while transcript_status is 'pending'.
+
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS transcript TEXT;
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS transcript_status VARCHAR(20);
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS transcript_operation TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_media_files_transcript_status ON media_files(transcript_status);
@@ -0,0 +1,6 @@
+-- Migration: Admin-only OCR results on media files
+-- The read-media-text job stores text detected in report images (signage,
+-- plate candidates) here to help admins verify reports. It is never included
+-- in public responses; purged results keep a tombstone so the image isn't read again.
+
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS ocr JSONB;
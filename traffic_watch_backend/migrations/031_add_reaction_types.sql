@@ -0,0 +1,23 @@
+-- Migration: Configurable reaction types
+-- Managed via /v1/admin/reactions and exposed to clients via /v1/public/config.
+-- Retired types can no longer be added but existing reactions of that type remain.
+
+CREATE TABLE IF NOT EXISTS reaction_types (
+    type VARCHAR(50) PRIMARY KEY,
+    label VARCHAR(50) NOT NULL,
+    emoji VARCHAR(16),
+    score INTEGER NOT NULL DEFAULT 0,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    retired_at TIMESTAMP WITH TIME ZONE,
+    updated_by VARCHAR(255),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Seed the previously hardcoded set with its engagement scores
+INSERT INTO reaction_types (type, label, emoji, score, sort_order) VALUES
+    ('thumbs_up', 'Thumbs up', '👍', 2, 10),
+    ('thumbs_down', 'Thumbs down', '👎', -1, 20),
+    ('angry_car', 'Angry driver', '🚗', 3, 30),
+    ('angry_pedestrian', 'Angry pedestrian', '🚶', 3, 40),
+    ('angry_bicycle', 'Angry cyclist', '🚲', 3, 50)
+ON CONFLICT (type) DO NOTHING;
@@ -0,0 +1,7 @@
+-- Migration: Unseen status changes on "my reports"
+-- status_changed_at is set whenever review, archiving or merging changes a
+-- report's status; status_seen_at when its owner marks the report seen.
+-- A report has an unseen update while status_changed_at is the later of the two.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS status_seen_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,7 @@
+-- Migration: Full-text index for admin search (GET /v1/admin/search)
+-- The indexed expression must match reportSearchDocument in postgres_search.go
+-- for the planner to use it.
+
+CREATE INDEX IF NOT EXISTS idx_reports_search ON reports USING GIN (
+    to_tsvector('english', COALESCE(title, '') || ' ' || COALESCE(description, '') || ' ' || COALESCE(state, '') || ' ' || COALESCE(city, ''))
+);
@@ -0,0 +1,19 @@
+-- Migration: Dead-letter queue for failed media jobs
+-- Media uploads that fail permanently are recorded here instead of only being
+-- logged. Admins list them at /v1/admin/media/failures and retry the ones whose
+-- file was kept; resolved_at is set once a retry succeeds.
+
+CREATE TABLE IF NOT EXISTS dead_letter (
+    id UUID PRIMARY KEY,
+    job VARCHAR(50) NOT NULL, -- 'youtube_upload', 'gcs_upload'
+    report_id UUID NOT NULL,  -- Not a foreign key: a failed GCS upload aborts the report it was part of
+    media_id TEXT,
+    file_name VARCHAR(255) NOT NULL,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letter_unresolved ON dead_letter(created_at DESC) WHERE resolved_at IS NULL;
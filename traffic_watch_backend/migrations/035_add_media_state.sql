@@ -0,0 +1,10 @@
+-- Migration: Processing state of uploaded media
+-- Files uploaded with signed URLs are attached before their bytes arrive. They
+-- stay 'processing' until the object exists, 'quarantined' until its content is
+-- checked, then become 'ready' or 'failed'. Reports with processing or
+-- quarantined media are kept out of the review queue and the public feed.
+-- Existing media was uploaded through the server and is ready.
+
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS state VARCHAR(20) NOT NULL DEFAULT 'ready';
+
+CREATE INDEX IF NOT EXISTS idx_media_files_pending ON media_files(report_id) WHERE state IN ('processing', 'quarantined');
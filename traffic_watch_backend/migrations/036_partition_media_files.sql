@@ -0,0 +1,126 @@
+-- Migration: Time-partitioned media files and a cold table for archived reports
+-- media_files becomes range-partitioned by uploaded_at, one partition per UTC
+-- month (media_files_YYYY_MM). The maintain-media job creates partitions for the
+-- coming months; rows outside every partition land in media_files_default.
+-- A partitioned table's primary key must include the partition column, so it
+-- becomes (id, uploaded_at) and the database no longer enforces that ids are
+-- unique on their own. Media is still looked up by id alone (archiving, restoring,
+-- merging), so ids must stay collision-safe: random UUIDs, YouTube video IDs, or
+-- the importer's name-based UUIDs. media_files_archive enforces it (see 049).
+--
+-- The same job moves media of archived reports to media_files_archive. Reports
+-- read their media through the all_media_files view, which covers both tables;
+-- add new media columns to both tables and to the view.
+--
+-- Safe to re-run: the old table is only renamed while media_files is still
+-- unpartitioned, and a run that stopped part way picks up from
+-- media_files_unpartitioned.
+
+DO $$
+BEGIN
+    IF to_regclass('media_files_unpartitioned') IS NULL
+        AND EXISTS (SELECT 1 FROM pg_class WHERE oid = to_regclass('media_files') AND relkind = 'r') THEN
+        ALTER TABLE media_files RENAME TO media_files_unpartitioned;
+    END IF;
+    IF EXISTS (SELECT 1 FROM pg_constraint
+        WHERE conname = 'media_files_pkey' AND conrelid = to_regclass('media_files_unpartitioned')) THEN
+        ALTER TABLE media_files_unpartitioned RENAME CONSTRAINT media_files_pkey TO media_files_unpartitioned_pkey;
+    END IF;
+    -- The old indexes keep their names; drop them so the partitioned table can reuse them
+    IF to_regclass('media_files_unpartitioned') IS NOT NULL THEN
+        DROP INDEX IF EXISTS idx_media_files_report_id;
+        DROP INDEX IF EXISTS idx_media_files_metadata;
+        DROP INDEX IF EXISTS idx_media_files_transcript_status;
+        DROP INDEX IF EXISTS idx_media_files_pending;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS media_files (
+    id TEXT NOT NULL,  -- TEXT to support both UUIDs and YouTube video IDs
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    url TEXT NOT NULL,
+    uploaded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    metadata JSONB,
+    storage_path TEXT DEFAULT '',
+    transcript TEXT,
+    transcript_status VARCHAR(20),
+    transcript_operation TEXT,
+    ocr JSONB,
+    state VARCHAR(20) NOT NULL DEFAULT 'ready',
+    PRIMARY KEY (id, uploaded_at)
+) PARTITION BY RANGE (uploaded_at);
+
+CREATE TABLE IF NOT EXISTS media_files_default PARTITION OF media_files DEFAULT;
+
+-- Monthly partitions from the oldest upload (2020 at the earliest) through the next
+-- three months, created before copying so existing rows don't land in the default partition
+DO $$
+DECLARE
+    oldest TIMESTAMPTZ;
+    bound TIMESTAMP;
+BEGIN
+    IF to_regclass('media_files_unpartitioned') IS NOT NULL THEN
+        SELECT MIN(uploaded_at) INTO oldest FROM media_files_unpartitioned;
+    END IF;
+    bound := date_trunc('month', GREATEST(COALESCE(oldest, NOW()),
+        TIMESTAMPTZ '2020-01-01 00:00:00+00') AT TIME ZONE 'UTC');
+    WHILE bound < date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '4 months' LOOP
+        EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF media_files FOR VALUES FROM (%L) TO (%L)',
+            'media_files_' || to_char(bound, 'YYYY_MM'),
+            to_char(bound, 'YYYY-MM-DD') || ' 00:00:00+00',
+            to_char(bound + INTERVAL '1 month', 'YYYY-MM-DD') || ' 00:00:00+00');
+        bound := bound + INTERVAL '1 month';
+    END LOOP;
+END $$;
+
+DO $$
+BEGIN
+    IF to_regclass('media_files_unpartitioned') IS NOT NULL THEN
+        INSERT INTO media_files (id, report_id, file_name, content_type, size, url, uploaded_at, metadata, storage_path,
+            transcript, transcript_status, transcript_operation, ocr, state)
+        SELECT id, report_id, file_name, content_type, size, url, uploaded_at, metadata, storage_path,
+            transcript, transcript_status, transcript_operation, ocr, state
+        FROM media_files_unpartitioned
+        ON CONFLICT DO NOTHING;
+
+        DROP TABLE media_files_unpartitioned;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_media_files_report_id ON media_files(report_id);
+CREATE INDEX IF NOT EXISTS idx_media_files_metadata ON media_files USING GIN (metadata);
+CREATE INDEX IF NOT EXISTS idx_media_files_transcript_status ON media_files(transcript_status);
+CREATE INDEX IF NOT EXISTS idx_media_files_pending ON media_files(report_id) WHERE state IN ('processing', 'quarantined');
+
+CREATE TABLE IF NOT EXISTS media_files_archive (
+    id TEXT NOT NULL,
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL,
+    url TEXT NOT NULL,
+    uploaded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    metadata JSONB,
+    storage_path TEXT DEFAULT '',
+    transcript TEXT,
+    transcript_status VARCHAR(20),
+    transcript_operation TEXT,
+    ocr JSONB,
+    state VARCHAR(20) NOT NULL DEFAULT 'ready',
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, uploaded_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_media_files_archive_report_id ON media_files_archive(report_id);
+
+CREATE OR REPLACE VIEW all_media_files AS
+    SELECT id, report_id, file_name, content_type, size, url, uploaded_at, metadata, storage_path,
+        transcript, transcript_status, transcript_operation, ocr, state
+    FROM media_files
+    UNION ALL
+    SELECT id, report_id, file_name, content_type, size, url, uploaded_at, metadata, storage_path,
+        transcript, transcript_status, transcript_operation, ocr, state
+    FROM media_files_archive;
@@ -0,0 +1,6 @@
+-- Migration: Reaction score caps
+-- Reactions change their report's priority by their type's score. max_score caps
+-- the total priority change reactions of one type can make to a single report,
+-- so a pile-on can't push a report past everything else. 0 means uncapped.
+
+ALTER TABLE reaction_types ADD COLUMN IF NOT EXISTS max_score INTEGER NOT NULL DEFAULT 0;
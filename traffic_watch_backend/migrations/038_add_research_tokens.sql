@@ -0,0 +1,25 @@
+-- Migration: Researcher API tokens
+-- Admins issue read-only tokens to researchers and journalists for the
+-- approved dataset under /v1/research. Only a SHA-256 hash of each token is
+-- stored. Requests are counted per token per UTC day against its quota.
+
+CREATE TABLE IF NOT EXISTS research_tokens (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    daily_quota INTEGER NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS research_token_usage (
+    token_id UUID NOT NULL REFERENCES research_tokens(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    requests INTEGER NOT NULL DEFAULT 0,
+    rejected INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (token_id, day)
+);
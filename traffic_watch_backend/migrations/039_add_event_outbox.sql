@@ -0,0 +1,22 @@
+-- Migration: Event outbox
+-- Report lifecycle events are written here in the same transaction as the
+-- change they describe. A relay worker delivers them to Pub/Sub and the
+-- notifier and retries failures with backoff, so a committed change always
+-- gets its event. next_attempt_at is NULL once an event is delivered or
+-- given up on.
+
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id UUID PRIMARY KEY,
+    type VARCHAR(50) NOT NULL,
+    report_id VARCHAR(255) NOT NULL,
+    comment_id VARCHAR(255) NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_due ON event_outbox(next_attempt_at)
+    WHERE next_attempt_at IS NOT NULL;
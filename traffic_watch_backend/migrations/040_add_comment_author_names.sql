@@ -0,0 +1,14 @@
+-- Migration: Public comment author names
+-- Comments are shown with their author's display name, or a "Contributor #1234"
+-- pseudonym for users without one, instead of the author's email; the email is
+-- kept for moderators only. author_name is kept in step with display_name when a
+-- user changes it.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS display_name VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE report_comments ADD COLUMN IF NOT EXISTS author_name VARCHAR(50) NOT NULL DEFAULT '';
+
+-- Existing comments get their author's pseudonym. The number is the first 32 bits
+-- of the MD5 of the user ID modulo 10000, as in models.ContributorName.
+UPDATE report_comments
+SET author_name = 'Contributor #' || lpad(((('x' || lpad(substr(md5(user_id), 1, 8), 16, '0'))::bit(64)::bigint) % 10000)::text, 4, '0')
+WHERE author_name = '';
@@ -0,0 +1,9 @@
+-- Migration: Canonical city IDs on reports
+-- Reports filed with a city on the built-in city list (see geo.NormalizeCity)
+-- store its canonical name in city and its ID, e.g. "new-york/new-york", in
+-- city_id, so spelling variants don't split the same place. Other cities stay
+-- free text with an empty city_id.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS city_id VARCHAR(120) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_reports_city_id ON reports (city_id) WHERE city_id <> '';
@@ -0,0 +1,21 @@
+-- Migration: Structured incident severity on reports
+-- severity is one of near_miss, property_damage, minor_injury, major_injury or
+-- fatality, so analytics and feed filters don't have to read the free-text
+-- injuries field. Existing reports are backfilled from injuries with the rules
+-- in models.SeverityFromInjuries (first match wins); keep the two in step.
+-- Reports whose injuries text says nothing recognisable keep an empty severity.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS severity VARCHAR(20) NOT NULL DEFAULT '';
+
+UPDATE reports SET severity = CASE
+    WHEN lower(injuries) ~ '(fatal|died|dead|killed|death)' THEN 'fatality'
+    WHEN lower(injuries) ~ '(no injur|not injur|uninjured|not hurt|nobody|no one|none)' THEN
+        CASE WHEN lower(injuries) ~ '(damage|dented|scratch)' THEN 'property_damage' ELSE 'near_miss' END
+    WHEN lower(injuries) ~ '(hospital|ambulance|serious|severe|major|broken|fracture)' THEN 'major_injury'
+    WHEN lower(injuries) ~ '(injur|hurt|minor|bruise|scrape|whiplash)' THEN 'minor_injury'
+    WHEN lower(injuries) ~ '(damage|dented|scratch)' THEN 'property_damage'
+    ELSE ''
+END
+WHERE severity = '' AND injuries IS NOT NULL AND injuries <> '';
+
+CREATE INDEX IF NOT EXISTS idx_reports_severity ON reports (severity) WHERE severity <> '';
@@ -0,0 +1,16 @@
+-- Migration: Report review history
+-- One row per admin review decision and per owner resubmission of a rejected
+-- report (status 'submitted'), oldest first. The number of resubmission rows
+-- is checked against the resubmission limit. Reviews made before this
+-- migration aren't in the history; reports.reviewed_by still lists them.
+
+CREATE TABLE IF NOT EXISTS report_reviews (
+    id UUID PRIMARY KEY,
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_reviews_report ON report_reviews(report_id, created_at);
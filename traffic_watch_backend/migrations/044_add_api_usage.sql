@@ -0,0 +1,15 @@
+-- Migration: Public API usage analytics
+-- Daily request counts per public route, consumer (research token or web
+-- origin) and set of filter parameter names. Each server instance adds its
+-- counts periodically, so rows are incremented rather than replaced. Nothing
+-- here identifies a person.
+
+CREATE TABLE IF NOT EXISTS api_usage (
+    day DATE NOT NULL,
+    route VARCHAR(200) NOT NULL,
+    consumer VARCHAR(300) NOT NULL,
+    filters VARCHAR(400) NOT NULL DEFAULT '',
+    requests BIGINT NOT NULL DEFAULT 0,
+    errors BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, route, consumer, filters)
+);
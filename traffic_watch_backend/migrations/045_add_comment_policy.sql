@@ -0,0 +1,19 @@
+-- Migration: Comment spam policy
+-- One row of admin-configurable thresholds: the per-user comment rate limit,
+-- what happens to links from untrusted users, and how many identical comments a
+-- user may post before the rest are held. Without a row the built-in defaults apply.
+
+CREATE TABLE IF NOT EXISTS comment_policy (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    rate_limit INTEGER NOT NULL,
+    rate_window_minutes INTEGER NOT NULL,
+    link_action VARCHAR(20) NOT NULL,
+    trusted_approved_reports INTEGER NOT NULL,
+    duplicate_limit INTEGER NOT NULL,
+    duplicate_window_minutes INTEGER NOT NULL,
+    updated_by VARCHAR(255),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Duplicate checks look up a user's recent comments
+CREATE INDEX IF NOT EXISTS idx_report_comments_user_created ON report_comments(user_id, created_at DESC);
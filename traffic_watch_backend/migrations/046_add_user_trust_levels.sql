@@ -0,0 +1,25 @@
+-- Migration: User trust levels
+-- Each user's trust level (new, basic, trusted) is computed from their approved
+-- and rejected reports and recomputed when one of their reports is reviewed. It
+-- gates posting links in comments, higher upload limits and auto-approval
+-- eligibility, and replaces the comment policy's own approved-report threshold.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS trust_level VARCHAR(20) NOT NULL DEFAULT 'new';
+
+-- Backfill with the default thresholds: basic after 1 approved report, trusted
+-- after 5 with at most 20% of reviewed reports rejected
+UPDATE users u SET trust_level = CASE
+        WHEN h.approved >= 5 AND h.rejected * 100 <= 20 * (h.approved + h.rejected) THEN 'trusted'
+        WHEN h.approved >= 1 THEN 'basic'
+        ELSE 'new'
+    END
+FROM (
+    SELECT user_id,
+        COUNT(*) FILTER (WHERE status = 'reviewed_pass') AS approved,
+        COUNT(*) FILTER (WHERE status = 'reviewed_fail') AS rejected
+    FROM reports
+    GROUP BY user_id
+) h
+WHERE h.user_id = u.id;
+
+ALTER TABLE comment_policy DROP COLUMN IF EXISTS trusted_approved_reports;
@@ -0,0 +1,12 @@
+-- Migration: Static map images
+-- Reports with coordinates get a static map of their location, rendered once
+-- with the Static Maps API and stored in the bucket next to their media. A
+-- failed attempt leaves map_image_path NULL and is retried a day later.
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS map_image_path TEXT;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS map_image_attempted_at TIMESTAMPTZ;
+
+-- Reports still waiting for a map, for the rendering job
+CREATE INDEX IF NOT EXISTS idx_reports_map_image_pending
+    ON reports(created_at DESC)
+    WHERE map_image_path IS NULL AND latitude IS NOT NULL AND longitude IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration: Moderator role
+-- Moderators work the review queue and flagged comments without the rest of
+-- the admin API. Ranked between contributor and admin.
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_check;
+ALTER TABLE users ADD CONSTRAINT users_role_check
+    CHECK (role IN ('viewer', 'contributor', 'moderator', 'admin'));
@@ -0,0 +1,6 @@
+-- Migration: Enforce unique media ids in the archive
+-- media_files is partitioned, so its primary key is (id, uploaded_at) and cannot
+-- cover id alone (see 036). Media is archived, restored and merged by id, so the
+-- unpartitioned cold table enforces that an id appears there only once.
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_files_archive_id ON media_files_archive(id);
@@ -0,0 +1,26 @@
+-- Migration: Server-side report drafts
+-- Unfinished reports the app saves as the user writes them, so they follow the
+-- user across devices. Users who opt in (notify_draft_reminders) are reminded once
+-- about a draft left untouched for DRAFT_REMINDER_AFTER; editing it re-arms the
+-- reminder. Creating a report with ?draftId= removes the draft.
+
+CREATE TABLE IF NOT EXISTS report_drafts (
+    id UUID PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    road_usage TEXT[] NOT NULL DEFAULT '{}',
+    event_type TEXT[] NOT NULL DEFAULT '{}',
+    state VARCHAR(50) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL DEFAULT '',
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    reminded_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_drafts_user ON report_drafts(user_id, updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_report_drafts_updated ON report_drafts(updated_at);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS notify_draft_reminders BOOLEAN NOT NULL DEFAULT false;
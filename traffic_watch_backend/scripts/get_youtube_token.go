@@ -35,7 +35,7 @@ func main() {
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
 		Endpoint:     google.Endpoint,
-		Scopes:       []string{youtube.YoutubeUploadScope},
+		Scopes:       []string{youtube.YoutubeUploadScope, youtube.YoutubeForceSslScope}, // Upload, and delete when a report is purged
 		RedirectURL:  "http://localhost:8085/callback",
 	}
 
@@ -71,7 +71,7 @@ func main() {
 	fmt.Println("\n1. Make sure you've added this redirect URI in Google Cloud Console:")
 	fmt.Println("   APIs & Services -> Credentials -> Your OAuth Client -> Authorized redirect URIs")
 	fmt.Println("   Add: http://localhost:8085/callback")
-	fmt.Println("\n2. Open this URL in your browser:\n")
+	fmt.Print("\n2. Open this URL in your browser:\n\n")
 	fmt.Println(authURL)
 	fmt.Println("\n3. Sign in and grant access")
 	fmt.Println("\nWaiting for authorization...")